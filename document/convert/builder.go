@@ -0,0 +1,462 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+// Style IDs used for imported content that has no equivalent in the default
+// document styles.
+const (
+	styleIDSourceCode    = "SourceCode"
+	styleIDVerbatimChar  = "VerbatimChar"
+	styleIDQuote         = "Quote"
+	styleIDHyperlink     = "Hyperlink"
+	styleIDListParagraph = "ListParagraph"
+)
+
+// maxImageWidth is the largest width of imported images, the text width of a
+// letter sized page with one inch margins.
+const maxImageWidth = 6.5 * measurement.Inch
+
+// blockContainer is implemented by the document body and table cells.
+type blockContainer interface {
+	AddParagraph() document.Paragraph
+	AddTable() document.Table
+}
+
+// docBuilder writes flow blocks to a document.
+type docBuilder struct {
+	doc     *document.Document
+	baseDir string
+}
+
+// blockCtx is the formatting context of nested blocks.
+type blockCtx struct {
+	quote  int
+	indent measurement.Distance
+	style  runStyle
+	list   *listCtx
+	level  int
+}
+
+// listCtx tracks the numbering definition shared by a list and its nested
+// lists.
+type listCtx struct {
+	def    document.NumberingDefinition
+	levels map[int]bool
+}
+
+func newDocBuilder(d *document.Document, baseDir string) *docBuilder {
+	return &docBuilder{doc: d, baseDir: baseDir}
+}
+
+// ensureStyle returns the ID of a style, creating it with init if the
+// document does not define it yet.
+func (b *docBuilder) ensureStyle(id string, t wml.ST_StyleType, init func(s document.Style)) string {
+	if _, ok := b.doc.Styles.SearchStyleById(id); !ok {
+		init(b.doc.Styles.AddStyle(id, t, false))
+	}
+	return id
+}
+
+func (b *docBuilder) codeStyle() string {
+	return b.ensureStyle(styleIDSourceCode, wml.ST_StyleTypeParagraph, func(s document.Style) {
+		s.SetName("Source Code")
+		s.SetBasedOn("Normal")
+		s.SetUISortOrder(99)
+		s.RunProperties().SetFontFamily("Courier New")
+		s.RunProperties().SetSize(10 * measurement.Point)
+		pp := s.ParagraphProperties()
+		pp.SetSpacing(0, 0)
+		pp.X().Shd = wml.NewCT_Shd()
+		pp.X().Shd.ValAttr = wml.ST_ShdClear
+		pp.X().Shd.FillAttr = &wml.ST_HexColor{ST_HexColorRGB: unioffice.String("F2F2F2")}
+	})
+}
+
+func (b *docBuilder) verbatimStyle() string {
+	return b.ensureStyle(styleIDVerbatimChar, wml.ST_StyleTypeCharacter, func(s document.Style) {
+		s.SetName("Verbatim Char")
+		s.SetUISortOrder(99)
+		s.RunProperties().SetFontFamily("Courier New")
+	})
+}
+
+func (b *docBuilder) quoteStyle() string {
+	return b.ensureStyle(styleIDQuote, wml.ST_StyleTypeParagraph, func(s document.Style) {
+		s.SetName("Quote")
+		s.SetBasedOn("Normal")
+		s.SetNextStyle("Normal")
+		s.SetUISortOrder(29)
+		s.SetPrimaryStyle(true)
+		s.ParagraphProperties().SetLeftIndent(0.5 * measurement.Inch)
+		s.RunProperties().SetItalic(true)
+		s.RunProperties().SetColor(color.RGB(0x40, 0x40, 0x40))
+	})
+}
+
+func (b *docBuilder) hyperlinkStyle() string {
+	return b.ensureStyle(styleIDHyperlink, wml.ST_StyleTypeCharacter, func(s document.Style) {
+		s.SetName("Hyperlink")
+		s.SetUISortOrder(99)
+		s.SetUnhideWhenUsed(true)
+		s.RunProperties().SetColor(color.RGB(0x05, 0x63, 0xC1))
+		s.RunProperties().SetUnderline(wml.ST_UnderlineSingle, color.Auto)
+	})
+}
+
+func (b *docBuilder) listParagraphStyle() string {
+	return b.ensureStyle(styleIDListParagraph, wml.ST_StyleTypeParagraph, func(s document.Style) {
+		s.SetName("List Paragraph")
+		s.SetBasedOn("Normal")
+		s.SetUISortOrder(34)
+		s.SetPrimaryStyle(true)
+		s.ParagraphProperties().SetContextualSpacing(true)
+	})
+}
+
+// newListCtx creates a numbering definition for a top level list.
+func (b *docBuilder) newListCtx() *listCtx {
+	def := b.doc.Numbering.AddDefinition()
+	def.SetMultiLevelType(wml.ST_MultiLevelTypeHybridMultilevel)
+	for i := 0; i < 9; i++ {
+		lvl := def.AddLevel()
+		lvl.SetAlignment(wml.ST_JcLeft)
+		lvl.Properties().SetLeftIndent(measurement.Distance(i+1) * 0.5 * measurement.Inch)
+		lvl.Properties().SetHangingIndent(0.25 * measurement.Inch)
+		setListLevelFormat(lvl, i, false, 1)
+	}
+	return &listCtx{def: def, levels: map[int]bool{}}
+}
+
+var listBullets = []string{"•", "◦", "▪"}
+
+func setListLevelFormat(lvl document.NumberingLevel, i int, ordered bool, start int) {
+	if !ordered {
+		lvl.SetFormat(wml.ST_NumberFormatBullet)
+		lvl.SetText(listBullets[i%len(listBullets)])
+		return
+	}
+	switch i % 3 {
+	case 0:
+		lvl.SetFormat(wml.ST_NumberFormatDecimal)
+	case 1:
+		lvl.SetFormat(wml.ST_NumberFormatLowerLetter)
+	default:
+		lvl.SetFormat(wml.ST_NumberFormatLowerRoman)
+	}
+	lvl.SetText(fmt.Sprintf("%%%d.", i+1))
+	if start < 0 {
+		start = 0
+	}
+	lvl.X().Start = &wml.CT_DecimalNumber{ValAttr: int64(start)}
+}
+
+func (b *docBuilder) writeBlocks(c blockContainer, blocks []*flowBlock, ctx blockCtx) {
+	for _, bl := range blocks {
+		switch bl.kind {
+		case flowParagraph:
+			p := c.AddParagraph()
+			b.formatParagraph(p, bl, ctx)
+			b.writeRuns(p, bl.runs, ctx.style)
+		case flowHeading:
+			p := c.AddParagraph()
+			p.SetStyle(fmt.Sprintf("Heading%d", bl.level))
+			b.formatParagraph(p, bl, blockCtx{indent: ctx.indent})
+			b.writeRuns(p, bl.runs, ctx.style)
+		case flowCode:
+			p := c.AddParagraph()
+			p.SetStyle(b.codeStyle())
+			if ctx.indent > 0 {
+				p.SetLeftIndent(ctx.indent)
+			}
+			r := p.AddRun()
+			for i, l := range strings.Split(bl.text, "\n") {
+				if i > 0 {
+					r.AddBreak()
+				}
+				if l != "" {
+					r.AddText(l)
+				}
+			}
+		case flowRule:
+			p := c.AddParagraph()
+			p.Borders().SetBottom(wml.ST_BorderSingle, color.Auto, 0.75*measurement.Point)
+		case flowQuote:
+			inner := ctx
+			inner.quote++
+			inner.indent += 0.5 * measurement.Inch
+			b.writeBlocks(c, bl.children, inner)
+		case flowList:
+			b.writeList(c, bl, ctx)
+		case flowTable:
+			b.writeTable(c, bl, ctx)
+		}
+	}
+}
+
+func (b *docBuilder) formatParagraph(p document.Paragraph, bl *flowBlock, ctx blockCtx) {
+	if ctx.quote > 0 {
+		p.SetStyle(b.quoteStyle())
+	}
+	if ctx.indent > 0 {
+		p.SetLeftIndent(ctx.indent)
+	}
+	switch bl.align {
+	case "center":
+		p.SetAlignment(wml.ST_JcCenter)
+	case "right":
+		p.SetAlignment(wml.ST_JcRight)
+	case "justify":
+		p.SetAlignment(wml.ST_JcBoth)
+	}
+}
+
+func (b *docBuilder) writeList(c blockContainer, bl *flowBlock, ctx blockCtx) {
+	lc, level := ctx.list, ctx.level
+	if lc == nil {
+		lc, level = b.newListCtx(), 0
+	}
+	if level > 8 {
+		level = 8
+	}
+	// the first list on a level decides the numbering format of the level
+	if !lc.levels[level] {
+		lvls := lc.def.Levels()
+		setListLevelFormat(lvls[level], level, bl.ordered, bl.start)
+		lc.levels[level] = true
+	}
+	inner := ctx
+	inner.list, inner.level = lc, level+1
+	inner.indent = measurement.Distance(level+1) * 0.5 * measurement.Inch
+	for _, it := range bl.items {
+		blocks := it.blocks
+		p := c.AddParagraph()
+		p.SetStyle(b.listParagraphStyle())
+		p.SetNumberingDefinition(lc.def)
+		p.SetNumberingLevel(level)
+		switch it.task {
+		case 1:
+			p.AddRun().AddText("☐ ")
+		case 2:
+			p.AddRun().AddText("☒ ")
+		}
+		if len(blocks) > 0 && blocks[0].kind == flowParagraph {
+			b.formatParagraph(p, blocks[0], blockCtx{quote: ctx.quote})
+			b.writeRuns(p, blocks[0].runs, ctx.style)
+			blocks = blocks[1:]
+		}
+		b.writeBlocks(c, blocks, inner)
+	}
+}
+
+func (b *docBuilder) writeTable(c blockContainer, bl *flowBlock, ctx blockCtx) {
+	t := c.AddTable()
+	t.Properties().SetWidthPercent(100)
+	t.Properties().Borders().SetAll(wml.ST_BorderSingle, color.Auto, 0.5*measurement.Point)
+
+	// carry tracks vertically merged cells continuing into later rows
+	type carry struct {
+		rows, span int
+	}
+	carries := map[int]*carry{}
+	for ri, row := range bl.rows {
+		tr := t.AddRow()
+		col := 0
+		continueMerges := func() {
+			for {
+				cr, ok := carries[col]
+				if !ok {
+					return
+				}
+				cell := tr.AddCell()
+				cell.Properties().SetVerticalMerge(wml.ST_MergeContinue)
+				if cr.span > 1 {
+					cell.Properties().SetColumnSpan(cr.span)
+				}
+				cell.AddParagraph()
+				if cr.rows--; cr.rows == 0 {
+					delete(carries, col)
+				}
+				col += cr.span
+			}
+		}
+		for _, fc := range row {
+			continueMerges()
+			cell := tr.AddCell()
+			span := fc.colSpan
+			if span < 1 {
+				span = 1
+			}
+			if span > 1 {
+				cell.Properties().SetColumnSpan(span)
+			}
+			if fc.rowSpan > 1 {
+				cell.Properties().SetVerticalMerge(wml.ST_MergeRestart)
+				carries[col] = &carry{rows: fc.rowSpan - 1, span: span}
+			}
+			cctx := blockCtx{style: ctx.style}
+			if bl.header && ri == 0 {
+				cctx.style |= styleBold
+			}
+			b.writeBlocks(cell, fc.blocks, cctx)
+			// a cell has to end with a paragraph
+			if n := len(fc.blocks); n == 0 || fc.blocks[n-1].kind == flowTable {
+				cell.AddParagraph()
+			}
+			col += span
+		}
+		continueMerges()
+	}
+}
+
+// writeRuns adds inline content to a paragraph, grouping consecutive runs
+// with the same link target into a single hyperlink.
+func (b *docBuilder) writeRuns(p document.Paragraph, runs []*flowRun, extra runStyle) {
+	var hl document.HyperLink
+	link := ""
+	for _, fr := range runs {
+		var r document.Run
+		if fr.link != "" {
+			if fr.link != link {
+				hl = p.AddHyperLink()
+				if strings.HasPrefix(fr.link, "#") {
+					hl.X().AnchorAttr = unioffice.String(fr.link[1:])
+				} else {
+					hl.SetTarget(fr.link)
+				}
+				link = fr.link
+			}
+			r = hl.AddRun()
+			r.Properties().SetStyle(b.hyperlinkStyle())
+		} else {
+			link = ""
+			r = p.AddRun()
+		}
+		b.formatRun(r, fr, extra)
+		switch {
+		case fr.lineBreak:
+			r.AddBreak()
+		case fr.image != "":
+			if err := b.addImage(r, fr); err != nil {
+				r.AddText(fr.text)
+			}
+		default:
+			r.AddText(fr.text)
+		}
+	}
+}
+
+func (b *docBuilder) formatRun(r document.Run, fr *flowRun, extra runStyle) {
+	st := fr.style | extra
+	rp := r.Properties()
+	if st&styleCode != 0 {
+		rp.SetStyle(b.verbatimStyle())
+	}
+	if st&styleBold != 0 {
+		rp.SetBold(true)
+	}
+	if st&styleItalic != 0 {
+		rp.SetItalic(true)
+	}
+	if st&styleStrike != 0 {
+		rp.SetStrikeThrough(true)
+	}
+	if st&styleUnderline != 0 {
+		rp.SetUnderline(wml.ST_UnderlineSingle, color.Auto)
+	}
+	switch {
+	case st&styleSuperscript != 0:
+		rp.SetVerticalAlignment(sharedTypes.ST_VerticalAlignRunSuperscript)
+	case st&styleSubscript != 0:
+		rp.SetVerticalAlignment(sharedTypes.ST_VerticalAlignRunSubscript)
+	}
+	if fr.color != "" {
+		rp.SetColor(color.FromHex(fr.color))
+	}
+	if fr.size > 0 {
+		rp.SetSize(measurement.Distance(fr.size) * measurement.Point)
+	}
+	if fr.font != "" {
+		rp.SetFontFamily(fr.font)
+	}
+}
+
+// addImage embeds the image referenced by a run. Images are loaded from data
+// URIs or local files, remote images are not fetched.
+func (b *docBuilder) addImage(r document.Run, fr *flowRun) error {
+	img, err := b.loadImage(fr.image)
+	if err != nil {
+		return err
+	}
+	ref, err := b.doc.AddImage(img)
+	if err != nil {
+		return err
+	}
+	inl, err := r.AddDrawingInline(ref)
+	if err != nil {
+		return err
+	}
+	w, h := measurement.Distance(img.Size.X), measurement.Distance(img.Size.Y)
+	switch {
+	case fr.width > 0 && fr.height > 0:
+		w, h = measurement.Distance(fr.width), measurement.Distance(fr.height)
+	case fr.width > 0 && w > 0:
+		w, h = measurement.Distance(fr.width), h*measurement.Distance(fr.width)/w
+	case fr.height > 0 && h > 0:
+		w, h = w*measurement.Distance(fr.height)/h, measurement.Distance(fr.height)
+	}
+	if w > maxImageWidth {
+		w, h = maxImageWidth, h*maxImageWidth/w
+	}
+	inl.SetSize(w, h)
+	if fr.text != "" {
+		inl.X().DocPr.DescrAttr = unioffice.String(fr.text)
+	}
+	return nil
+}
+
+func (b *docBuilder) loadImage(src string) (common.Image, error) {
+	if strings.HasPrefix(src, "data:") {
+		i := strings.Index(src, ",")
+		if i < 0 || !strings.HasSuffix(src[:i], ";base64") {
+			return common.Image{}, errors.New("unsupported data URI")
+		}
+		data, err := base64.StdEncoding.DecodeString(src[i+1:])
+		if err != nil {
+			return common.Image{}, err
+		}
+		return common.ImageFromBytes(data)
+	}
+	if u, err := url.Parse(src); err == nil && u.Scheme != "" && u.Scheme != "file" && len(u.Scheme) > 1 {
+		return common.Image{}, fmt.Errorf("remote image %s is not loaded", src)
+	}
+	path := strings.TrimPrefix(src, "file://")
+	if p, err := url.PathUnescape(path); err == nil {
+		path = p
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(b.baseDir, filepath.FromSlash(path))
+	}
+	return common.ImageFromFile(path)
+}
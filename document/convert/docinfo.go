@@ -0,0 +1,261 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/dml/picture"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+// docInfo resolves the semantic information used by the text based exporters,
+// such as heading levels and list numbering of paragraphs.
+type docInfo struct {
+	doc *document.Document
+}
+
+var headingStyleRe = regexp.MustCompile(`^(?i)heading ?([1-9])$`)
+
+// styleChain returns the style with the given ID and the styles it is based on.
+func (di docInfo) styleChain(id string) []*wml.CT_Style {
+	var chain []*wml.CT_Style
+	for i := 0; id != "" && i < 16; i++ {
+		s := di.doc.GetStyleByID(id).X()
+		if s == nil {
+			break
+		}
+		chain = append(chain, s)
+		id = ""
+		if s.BasedOn != nil {
+			id = s.BasedOn.ValAttr
+		}
+	}
+	return chain
+}
+
+// paragraphStyles returns the style chain of a paragraph.
+func (di docInfo) paragraphStyles(p *wml.CT_P) []*wml.CT_Style {
+	if p.PPr == nil || p.PPr.PStyle == nil {
+		return nil
+	}
+	return di.styleChain(p.PPr.PStyle.ValAttr)
+}
+
+// styleKey returns the normalized name and ID of a style used to recognize
+// well known styles.
+func styleKey(s *wml.CT_Style) (string, string) {
+	norm := func(v string) string {
+		return strings.ToLower(strings.Replace(v, " ", "", -1))
+	}
+	name := ""
+	if s.Name != nil {
+		name = s.Name.ValAttr
+	}
+	return norm(name), norm(stringValue(s.StyleIdAttr))
+}
+
+// hasStyle reports whether a style chain contains one of the given normalized
+// style names or IDs.
+func hasStyle(chain []*wml.CT_Style, keys ...string) bool {
+	for _, s := range chain {
+		name, id := styleKey(s)
+		for _, k := range keys {
+			if name == k || id == k {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headingLevel returns the HTML heading level of a paragraph, or zero if the
+// paragraph is not a heading.
+func (di docInfo) headingLevel(p *wml.CT_P) int {
+	if p.PPr == nil {
+		return 0
+	}
+	if p.PPr.OutlineLvl != nil && p.PPr.OutlineLvl.ValAttr < 6 {
+		return int(p.PPr.OutlineLvl.ValAttr) + 1
+	}
+	chain := di.paragraphStyles(p)
+	if hasStyle(chain, "title") {
+		return 1
+	}
+	for _, s := range chain {
+		name := ""
+		if s.Name != nil {
+			name = s.Name.ValAttr
+		}
+		for _, n := range []string{name, stringValue(s.StyleIdAttr)} {
+			if m := headingStyleRe.FindStringSubmatch(n); m != nil {
+				lvl, _ := strconv.Atoi(m[1])
+				if lvl > 6 {
+					lvl = 6
+				}
+				return lvl
+			}
+		}
+		if s.PPr != nil && s.PPr.OutlineLvl != nil && s.PPr.OutlineLvl.ValAttr < 6 {
+			return int(s.PPr.OutlineLvl.ValAttr) + 1
+		}
+	}
+	return 0
+}
+
+// isCodeParagraph reports whether a paragraph uses a preformatted style.
+func (di docInfo) isCodeParagraph(p *wml.CT_P) bool {
+	chain := di.paragraphStyles(p)
+	if hasStyle(chain, "sourcecode", "htmlpreformatted", "code", "plaintext") {
+		return true
+	}
+	for _, s := range chain {
+		if s.RPr != nil && isMonospace(s.RPr.RFonts) {
+			return true
+		}
+	}
+	return false
+}
+
+// isQuoteParagraph reports whether a paragraph uses a quotation style.
+func (di docInfo) isQuoteParagraph(p *wml.CT_P) bool {
+	return hasStyle(di.paragraphStyles(p), "quote", "intensequote", "blocktext")
+}
+
+// isCodeRun reports whether a run uses a code character style or a
+// monospace font.
+func (di docInfo) isCodeRun(rpr *wml.CT_RPr) bool {
+	if rpr == nil {
+		return false
+	}
+	if isMonospace(rpr.RFonts) {
+		return true
+	}
+	if rpr.RStyle != nil {
+		chain := di.styleChain(rpr.RStyle.ValAttr)
+		if hasStyle(chain, "verbatimchar", "htmlcode", "code", "sourcecodechar") {
+			return true
+		}
+		for _, s := range chain {
+			if s.RPr != nil && isMonospace(s.RPr.RFonts) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isMonospace(f *wml.CT_Fonts) bool {
+	return f != nil && f.AsciiAttr != nil && strings.HasSuffix(convertutils.CSSFontFamily(*f.AsciiAttr), "monospace")
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// numbering returns the numbering ID and level of a paragraph, looking at the
+// paragraph style if the paragraph has no direct numbering.
+func (di docInfo) numbering(p *wml.CT_P) (int64, int64, bool) {
+	if p.PPr == nil {
+		return 0, 0, false
+	}
+	if np := p.PPr.NumPr; np != nil && np.NumId != nil {
+		lvl := int64(0)
+		if np.Ilvl != nil {
+			lvl = np.Ilvl.ValAttr
+		}
+		return np.NumId.ValAttr, lvl, np.NumId.ValAttr != 0
+	}
+	for _, s := range di.paragraphStyles(p) {
+		if s.PPr != nil && s.PPr.NumPr != nil && s.PPr.NumPr.NumId != nil {
+			lvl := int64(0)
+			if s.PPr.NumPr.Ilvl != nil {
+				lvl = s.PPr.NumPr.Ilvl.ValAttr
+			}
+			return s.PPr.NumPr.NumId.ValAttr, lvl, s.PPr.NumPr.NumId.ValAttr != 0
+		}
+	}
+	return 0, 0, false
+}
+
+// isBulleted reports whether a numbering level uses bullets rather than
+// numbers.
+func (di docInfo) isBulleted(numID, lvl int64) bool {
+	if x := di.doc.GetNumberingLevelByIds(numID, lvl).X(); x != nil && x.NumFmt != nil {
+		switch x.NumFmt.ValAttr {
+		case wml.ST_NumberFormatBullet, wml.ST_NumberFormatNone:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// numberingStart returns the first number of a numbering level.
+func (di docInfo) numberingStart(numID, lvl int64) int {
+	if x := di.doc.GetNumberingLevelByIds(numID, lvl).X(); x != nil && x.Start != nil {
+		return int(x.Start.ValAttr)
+	}
+	return 1
+}
+
+// docImage is an image referenced from a drawing.
+type docImage struct {
+	ref common.ImageRef
+	alt string
+
+	// width and height are the displayed size in points, zero if unknown
+	width, height float64
+}
+
+// drawingImages returns the pictures contained in a drawing.
+func (di docInfo) drawingImages(dr *wml.CT_Drawing) []docImage {
+	var imgs []docImage
+	add := func(g *dml.Graphic, ext *dml.CT_PositiveSize2D, docPr *dml.CT_NonVisualDrawingProps) {
+		if g == nil || g.GraphicData == nil || len(g.GraphicData.Any) == 0 {
+			return
+		}
+		pic, ok := g.GraphicData.Any[0].(*picture.Pic)
+		if !ok || pic.BlipFill == nil || pic.BlipFill.Blip == nil || pic.BlipFill.Blip.EmbedAttr == nil {
+			return
+		}
+		ref, ok := di.doc.GetImageByRelID(*pic.BlipFill.Blip.EmbedAttr)
+		if !ok {
+			return
+		}
+		img := docImage{ref: ref}
+		if docPr != nil {
+			if docPr.DescrAttr != nil {
+				img.alt = *docPr.DescrAttr
+			} else {
+				img.alt = docPr.NameAttr
+			}
+		}
+		if ext != nil && ext.CxAttr > 0 && ext.CyAttr > 0 {
+			img.width, img.height = measurement.FromEMU(ext.CxAttr), measurement.FromEMU(ext.CyAttr)
+		}
+		imgs = append(imgs, img)
+	}
+	for _, inl := range dr.Inline {
+		add(inl.Graphic, inl.Extent, inl.DocPr)
+	}
+	for _, anc := range dr.Anchor {
+		add(anc.Graphic, anc.Extent, anc.DocPr)
+	}
+	return imgs
+}
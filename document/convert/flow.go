@@ -0,0 +1,129 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+// flowBlockKind is the kind of a block in the intermediate representation
+// that the text based importers produce before it is written to a document.
+type flowBlockKind int
+
+const (
+	flowParagraph flowBlockKind = iota
+	flowHeading
+	flowCode
+	flowQuote
+	flowList
+	flowTable
+	flowRule
+)
+
+// flowBlock is a block level element of an imported text document.
+type flowBlock struct {
+	kind flowBlockKind
+
+	// level is the heading level from 1 to 6
+	level int
+
+	// runs is the inline content of paragraphs and headings
+	runs []*flowRun
+
+	// raw is the unparsed inline source, converted to runs once all link
+	// reference definitions are known
+	raw string
+
+	// align is the paragraph alignment, one of "", "left", "center", "right"
+	// or "justify"
+	align string
+
+	// text and lang hold the content and info string of code blocks
+	text string
+	lang string
+
+	// children is the content of block quotes
+	children []*flowBlock
+
+	// ordered, start and items describe lists
+	ordered bool
+	start   int
+	items   []*flowItem
+
+	// colAlign and rows describe tables, the first row is the header row
+	colAlign []string
+	rows     [][]*flowCell
+	header   bool
+}
+
+// flowItem is a list item.
+type flowItem struct {
+	blocks []*flowBlock
+
+	// task is 0 for plain items, 1 for unchecked and 2 for checked task items
+	task int
+}
+
+// flowCell is a table cell.
+type flowCell struct {
+	blocks  []*flowBlock
+	colSpan int
+	rowSpan int
+}
+
+// runStyle is a set of character formatting flags.
+type runStyle uint
+
+const (
+	styleBold runStyle = 1 << iota
+	styleItalic
+	styleStrike
+	styleCode
+	styleUnderline
+	styleSuperscript
+	styleSubscript
+)
+
+// flowRun is a piece of inline content with uniform formatting.
+type flowRun struct {
+	text  string
+	style runStyle
+	link  string
+
+	// image is the source of an image, text holds its alternative text and
+	// width and height the requested size in points, zero if unspecified
+	image         string
+	width, height float64
+
+	// lineBreak marks a hard line break, text is empty
+	lineBreak bool
+
+	// color is a "rrggbb" hex color and size a font size in points, both only
+	// set by importers that support them
+	color string
+	size  float64
+	font  string
+}
+
+// mergeRuns joins adjacent text runs that share the same formatting.
+func mergeRuns(runs []*flowRun) []*flowRun {
+	var out []*flowRun
+	for _, r := range runs {
+		if r.text == "" && !r.lineBreak && r.image == "" {
+			continue
+		}
+		if n := len(out); n > 0 {
+			last := out[n-1]
+			if !last.lineBreak && !r.lineBreak && last.image == "" && r.image == "" &&
+				last.style == r.style && last.link == r.link && last.color == r.color &&
+				last.size == r.size && last.font == r.font {
+				last.text += r.text
+				continue
+			}
+		}
+		c := *r
+		out = append(out, &c)
+	}
+	return out
+}
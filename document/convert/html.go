@@ -0,0 +1,418 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+// HTMLOptions contains the options for converting a document to HTML.
+type HTMLOptions = convertutils.HTMLOptions
+
+// ConvertToHTML writes the document to w as semantic HTML with an embedded
+// stylesheet. This package is beta, breaking changes can take place.
+func ConvertToHTML(d *document.Document, w io.Writer) error {
+	return ConvertToHTMLWithOptions(d, w, nil)
+}
+
+// ConvertToHTMLWithOptions writes the document to w as semantic HTML using the
+// given options. Headings are mapped to <h1>-<h6>, numbered paragraphs to
+// nested lists, tables to <table> with merged cells, footnotes to a list of
+// notes at the end of the document and images to data URIs or extracted files.
+func ConvertToHTMLWithOptions(d *document.Document, w io.Writer, opts *HTMLOptions) error {
+	o := HTMLOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Title == "" {
+		o.Title = d.CoreProperties.Title()
+	}
+	hc := &htmlContext{HTMLWriter: convertutils.NewHTMLWriter(&o), docInfo: docInfo{doc: d}, footnoteNums: map[int64]int{}}
+	hc.AddRule("body { font-family: Calibri, sans-serif; font-size: 11pt; }")
+	hc.AddRule("table.doc { border-collapse: collapse; }")
+	hc.AddRule("table.doc td { border: 1px solid #808080; padding: 2pt 5pt; vertical-align: top; }")
+	hc.AddRule("section.footnotes { border-top: 1px solid #808080; margin-top: 12pt; font-size: 9pt; }")
+	if body := d.X().Body; body != nil {
+		hc.writeBlocks(body.EG_BlockLevelElts)
+	}
+	hc.closeLists(0)
+	hc.writeFootnotes()
+	return hc.Flush(w)
+}
+
+type htmlContext struct {
+	*convertutils.HTMLWriter
+	docInfo
+
+	// lists is the stack of currently open lists, one entry per level
+	lists []string
+
+	footnotes    []int64
+	footnoteNums map[int64]int
+}
+
+func (hc *htmlContext) writeBlocks(blocks []*wml.EG_BlockLevelElts) {
+	for _, ble := range blocks {
+		hc.writeContentBlocks(ble.EG_ContentBlockContent)
+	}
+}
+
+func (hc *htmlContext) writeContentBlocks(cbcs []*wml.EG_ContentBlockContent) {
+	for _, cbc := range cbcs {
+		for _, p := range cbc.P {
+			hc.writeParagraph(p)
+		}
+		for _, tbl := range cbc.Tbl {
+			hc.closeLists(0)
+			hc.writeTable(tbl)
+		}
+		if sdt := cbc.Sdt; sdt != nil && sdt.SdtContent != nil {
+			for _, p := range sdt.SdtContent.P {
+				hc.writeParagraph(p)
+			}
+			for _, tbl := range sdt.SdtContent.Tbl {
+				hc.closeLists(0)
+				hc.writeTable(tbl)
+			}
+		}
+	}
+}
+
+// closeLists closes open lists until only depth lists remain open.
+func (hc *htmlContext) closeLists(depth int) {
+	for len(hc.lists) > depth {
+		hc.Printf("</li></%s>\n", hc.lists[len(hc.lists)-1])
+		hc.lists = hc.lists[:len(hc.lists)-1]
+	}
+}
+
+func (hc *htmlContext) writeParagraph(p *wml.CT_P) {
+	if numID, lvl, ok := hc.numbering(p); ok {
+		depth := int(lvl) + 1
+		hc.closeLists(depth)
+		if len(hc.lists) == depth {
+			hc.WriteString("</li>\n<li>")
+		}
+		for len(hc.lists) < depth {
+			tag := "ol"
+			if hc.isBulleted(numID, int64(len(hc.lists))) {
+				tag = "ul"
+			}
+			if len(hc.lists) > 0 {
+				hc.WriteString("\n")
+			}
+			hc.Printf("<%s>\n<li>", tag)
+			hc.lists = append(hc.lists, tag)
+		}
+		hc.writeInline(p.EG_PContent)
+		return
+	}
+	hc.closeLists(0)
+
+	tag := "p"
+	if lvl := hc.headingLevel(p); lvl > 0 {
+		tag = fmt.Sprintf("h%d", lvl)
+	}
+	hc.WriteString("<" + tag)
+	if p.PPr != nil && p.PPr.Jc != nil {
+		switch p.PPr.Jc.ValAttr {
+		case wml.ST_JcCenter:
+			hc.WriteString(" style=\"text-align:center\"")
+		case wml.ST_JcRight, wml.ST_JcEnd:
+			hc.WriteString(" style=\"text-align:right\"")
+		case wml.ST_JcBoth:
+			hc.WriteString(" style=\"text-align:justify\"")
+		}
+	}
+	hc.WriteString(">")
+	if len(p.EG_PContent) == 0 {
+		hc.WriteString("<br>")
+	}
+	hc.writeInline(p.EG_PContent)
+	hc.WriteString("</" + tag + ">\n")
+}
+
+func (hc *htmlContext) writeInline(pcs []*wml.EG_PContent) {
+	for _, pc := range pcs {
+		for _, fs := range pc.FldSimple {
+			if fs != nil {
+				hc.writeInline(fs.EG_PContent)
+			}
+		}
+		if hl := pc.Hyperlink; hl != nil {
+			href := ""
+			if hl.IdAttr != nil {
+				href = hc.doc.GetTargetByRelId(*hl.IdAttr)
+			} else if hl.AnchorAttr != nil {
+				href = "#" + *hl.AnchorAttr
+			}
+			href = convertutils.SafeHref(href)
+			if href != "" {
+				hc.Printf("<a href=\"%s\">", convertutils.HTMLAttr(href))
+			}
+			hc.writeRunContent(hl.EG_ContentRunContent)
+			if href != "" {
+				hc.WriteString("</a>")
+			}
+		}
+		hc.writeRunContent(pc.EG_ContentRunContent)
+	}
+}
+
+func (hc *htmlContext) writeRunContent(crcs []*wml.EG_ContentRunContent) {
+	for _, crc := range crcs {
+		if crc.R != nil {
+			hc.writeRun(crc.R)
+		}
+		if sdt := crc.Sdt; sdt != nil && sdt.SdtContent != nil {
+			hc.writeRunContent(sdt.SdtContent.EG_ContentRunContent)
+		}
+	}
+}
+
+func onOff(v *wml.CT_OnOff) bool {
+	if v == nil {
+		return false
+	}
+	if v.ValAttr == nil {
+		return true
+	}
+	if v.ValAttr.Bool != nil {
+		return *v.ValAttr.Bool
+	}
+	return v.ValAttr.ST_OnOff1 == sharedTypes.ST_OnOff1On
+}
+
+// runCSS converts direct run formatting into CSS declarations along with the
+// semantic tags that wrap the run text.
+func runCSS(rpr *wml.CT_RPr) ([]string, []string) {
+	if rpr == nil {
+		return nil, nil
+	}
+	var decls, tags []string
+	if onOff(rpr.B) {
+		tags = append(tags, "strong")
+	}
+	if onOff(rpr.I) {
+		tags = append(tags, "em")
+	}
+	if rpr.U != nil && rpr.U.ValAttr != wml.ST_UnderlineNone {
+		tags = append(tags, "u")
+	}
+	if onOff(rpr.Strike) || onOff(rpr.Dstrike) {
+		tags = append(tags, "s")
+	}
+	if rpr.VertAlign != nil {
+		switch rpr.VertAlign.ValAttr {
+		case sharedTypes.ST_VerticalAlignRunSuperscript:
+			tags = append(tags, "sup")
+		case sharedTypes.ST_VerticalAlignRunSubscript:
+			tags = append(tags, "sub")
+		}
+	}
+	if c := rpr.Color; c != nil && c.ValAttr.ST_HexColorRGB != nil {
+		if css := convertutils.CSSColor(*c.ValAttr.ST_HexColorRGB); css != "" {
+			decls = append(decls, "color:"+css)
+		}
+	}
+	if sz := rpr.Sz; sz != nil && sz.ValAttr.ST_UnsignedDecimalNumber != nil {
+		decls = append(decls, fmt.Sprintf("font-size:%gpt", float64(*sz.ValAttr.ST_UnsignedDecimalNumber)/2))
+	}
+	if f := rpr.RFonts; f != nil && f.AsciiAttr != nil {
+		if ff := convertutils.CSSFontFamily(*f.AsciiAttr); ff != "" {
+			decls = append(decls, "font-family:"+ff)
+		}
+	}
+	if h := rpr.Highlight; h != nil && h.ValAttr != wml.ST_HighlightColorNone && h.ValAttr != wml.ST_HighlightColorUnset {
+		decls = append(decls, "background-color:"+strings.ToLower(h.ValAttr.String()))
+	}
+	if onOff(rpr.Caps) {
+		decls = append(decls, "text-transform:uppercase")
+	}
+	if onOff(rpr.SmallCaps) {
+		decls = append(decls, "font-variant:small-caps")
+	}
+	return decls, tags
+}
+
+func (hc *htmlContext) writeRun(r *wml.CT_R) {
+	decls, tags := runCSS(r.RPr)
+	if r.RPr != nil && r.RPr.RStyle != nil {
+		if s := hc.doc.GetStyleByID(r.RPr.RStyle.ValAttr).X(); s != nil {
+			sd, st := runCSS(s.RPr)
+			decls = append(sd, decls...)
+			tags = append(st, tags...)
+		}
+	}
+	class := hc.Class("r", strings.Join(decls, ";"))
+	if class != "" {
+		hc.Printf("<span class=\"%s\">", class)
+	}
+	for _, t := range tags {
+		hc.Printf("<%s>", t)
+	}
+	for _, ric := range r.EG_RunInnerContent {
+		switch {
+		case ric.T != nil:
+			hc.WriteText(ric.T.Content)
+		case ric.Tab != nil:
+			hc.WriteString("&emsp;")
+		case ric.Br != nil:
+			hc.WriteString("<br>")
+		case ric.NoBreakHyphen != nil:
+			hc.WriteString("&#8209;")
+		case ric.Drawing != nil:
+			hc.writeDrawing(ric.Drawing)
+		case ric.FootnoteReference != nil:
+			hc.writeFootnoteReference(ric.FootnoteReference.IdAttr)
+		}
+	}
+	for i := len(tags) - 1; i >= 0; i-- {
+		hc.Printf("</%s>", tags[i])
+	}
+	if class != "" {
+		hc.WriteString("</span>")
+	}
+}
+
+func (hc *htmlContext) writeDrawing(dr *wml.CT_Drawing) {
+	for _, img := range hc.drawingImages(dr) {
+		src, err := hc.ImageSource(img.ref.Data(), img.ref.Path(), img.ref.Format())
+		if err != nil {
+			continue
+		}
+		hc.Printf("<img src=\"%s\" alt=\"%s\"", src, convertutils.HTMLAttr(img.alt))
+		if img.width > 0 && img.height > 0 {
+			hc.Printf(" style=\"width:%.2fpt;height:%.2fpt\"", img.width, img.height)
+		}
+		hc.WriteString(">")
+	}
+}
+
+func (hc *htmlContext) writeFootnoteReference(id int64) {
+	n, ok := hc.footnoteNums[id]
+	if !ok {
+		hc.footnotes = append(hc.footnotes, id)
+		n = len(hc.footnotes)
+		hc.footnoteNums[id] = n
+	}
+	hc.Printf("<sup><a href=\"#fn%d\" id=\"fnref%d\">%d</a></sup>", n, n, n)
+}
+
+func (hc *htmlContext) writeFootnotes() {
+	if len(hc.footnotes) == 0 {
+		return
+	}
+	hc.WriteString("<section class=\"footnotes\">\n<ol>\n")
+	// footnotes may reference further footnotes, so the list can grow while
+	// being written
+	for i := 0; i < len(hc.footnotes); i++ {
+		hc.Printf("<li id=\"fn%d\">\n", i+1)
+		if fn := hc.doc.Footnote(hc.footnotes[i]).X(); fn != nil {
+			hc.writeBlocks(fn.EG_BlockLevelElts)
+			hc.closeLists(0)
+		}
+		hc.Printf("<a href=\"#fnref%d\">&#8617;</a>\n</li>\n", i+1)
+	}
+	hc.WriteString("</ol>\n</section>\n")
+}
+
+// htmlCell is a table cell placed on the table grid.
+type htmlCell struct {
+	tc      *wml.CT_Tc
+	col     int
+	colSpan int
+	rowSpan int
+	cont    bool
+}
+
+func vMerge(tc *wml.CT_Tc) (bool, bool) {
+	if tc.TcPr == nil || tc.TcPr.VMerge == nil {
+		return false, false
+	}
+	return true, tc.TcPr.VMerge.ValAttr == wml.ST_MergeRestart
+}
+
+func (hc *htmlContext) writeTable(tbl *wml.CT_Tbl) {
+	var grid [][]*htmlCell
+	for _, rc := range tbl.EG_ContentRowContent {
+		for _, tr := range rc.Tr {
+			var row []*htmlCell
+			col := 0
+			for _, cc := range tr.EG_ContentCellContent {
+				for _, tc := range cc.Tc {
+					span := 1
+					if tc.TcPr != nil && tc.TcPr.GridSpan != nil && tc.TcPr.GridSpan.ValAttr > 1 {
+						span = int(tc.TcPr.GridSpan.ValAttr)
+					}
+					merged, restart := vMerge(tc)
+					row = append(row, &htmlCell{tc: tc, col: col, colSpan: span, rowSpan: 1, cont: merged && !restart})
+					col += span
+				}
+			}
+			grid = append(grid, row)
+		}
+	}
+	// extend the row span of vertically merged cells over the continuation
+	// cells below them
+	for r, row := range grid {
+		for _, c := range row {
+			if merged, restart := vMerge(c.tc); !merged || !restart {
+				continue
+			}
+		next:
+			for rr := r + 1; rr < len(grid); rr++ {
+				for _, below := range grid[rr] {
+					if below.col == c.col && below.cont {
+						c.rowSpan++
+						continue next
+					}
+				}
+				break
+			}
+		}
+	}
+
+	hc.WriteString("<table class=\"doc\">\n")
+	for _, row := range grid {
+		hc.WriteString("<tr>")
+		for _, c := range row {
+			if c.cont {
+				continue
+			}
+			hc.WriteString("<td")
+			if c.colSpan > 1 {
+				hc.Printf(" colspan=\"%d\"", c.colSpan)
+			}
+			if c.rowSpan > 1 {
+				hc.Printf(" rowspan=\"%d\"", c.rowSpan)
+			}
+			if pr := c.tc.TcPr; pr != nil && pr.Shd != nil && pr.Shd.FillAttr != nil && pr.Shd.FillAttr.ST_HexColorRGB != nil {
+				if css := convertutils.CSSColor(*pr.Shd.FillAttr.ST_HexColorRGB); css != "" {
+					hc.Printf(" style=\"background-color:%s\"", css)
+				}
+			}
+			hc.WriteString(">")
+			saved := hc.lists
+			hc.lists = nil
+			hc.writeBlocks(c.tc.EG_BlockLevelElts)
+			hc.closeLists(0)
+			hc.lists = saved
+			hc.WriteString("</td>")
+		}
+		hc.WriteString("</tr>\n")
+	}
+	hc.WriteString("</table>\n")
+}
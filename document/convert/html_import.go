@@ -0,0 +1,652 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/document"
+)
+
+// HTMLImportOptions contains the options for converting HTML to a document.
+type HTMLImportOptions struct {
+	// BaseDir is the directory that relative image paths are resolved
+	// against.
+	BaseDir string
+}
+
+// ConvertFromHTML reads HTML from r and returns it as a new document. This
+// package is beta, breaking changes can take place.
+func ConvertFromHTML(r io.Reader) (*document.Document, error) {
+	return ConvertFromHTMLWithOptions(r, nil)
+}
+
+// ConvertFromHTMLWithOptions reads HTML from r and returns it as a new
+// document. Block elements become paragraphs, headings, lists, tables and
+// quotes, inline elements and the color, font-size, font-family,
+// font-weight, font-style, text-decoration, vertical-align and text-align
+// properties of inline styles become direct formatting. Images are embedded
+// from data URIs or local files. Unsupported elements are converted to their
+// text content, so that malformed or unknown markup does not fail the import.
+func ConvertFromHTMLWithOptions(r io.Reader, opts *HTMLImportOptions) (*document.Document, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	o := HTMLImportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	d := document.New()
+	b := newDocBuilder(d, o.BaseDir)
+	b.writeBlocks(d, htmlToFlow(parseHTML(string(src))), blockCtx{})
+	return d, nil
+}
+
+// htmlSkipTags are elements whose content is not part of the document text.
+var htmlSkipTags = map[string]bool{
+	"head": true, "script": true, "style": true, "title": true, "template": true,
+	"noscript": true, "select": true, "object": true, "iframe": true, "svg": true,
+	"canvas": true, "colgroup": true, "col": true,
+}
+
+// inlineFmt is the character formatting inherited by inline content.
+type inlineFmt struct {
+	style runStyle
+	link  string
+	color string
+	size  float64
+	font  string
+}
+
+func htmlToFlow(root *htmlNode) []*flowBlock {
+	return htmlBlocks(root, inlineFmt{}, "")
+}
+
+// htmlBlocks converts the children of an element to blocks. Inline content
+// between block elements is collected into paragraphs.
+func htmlBlocks(parent *htmlNode, f inlineFmt, align string) []*flowBlock {
+	var out []*flowBlock
+	var para *flowBlock
+	flush := func() {
+		if para != nil {
+			para.runs = trimRuns(para.runs)
+			if len(para.runs) > 0 {
+				out = append(out, para)
+			}
+			para = nil
+		}
+	}
+	addRun := func(r *flowRun) {
+		if para == nil {
+			para = &flowBlock{kind: flowParagraph, align: align}
+		}
+		para.runs = append(para.runs, r)
+	}
+	var walk func(n *htmlNode, f inlineFmt)
+	walk = func(n *htmlNode, f inlineFmt) {
+		for _, c := range n.children {
+			switch {
+			case c.tag == "":
+				if text := collapseSpace(c.text); text != "" {
+					addRun(&flowRun{text: text, style: f.style, link: f.link, color: f.color, size: f.size, font: f.font})
+				}
+			case htmlSkipTags[c.tag]:
+			case htmlBlockTags[c.tag] || c.tag == "tr" || c.tag == "td" || c.tag == "th":
+				flush()
+				out = append(out, htmlBlock(c, f, align)...)
+			case c.tag == "br":
+				addRun(&flowRun{lineBreak: true, style: f.style, link: f.link})
+			case c.tag == "img":
+				addRun(htmlImage(c, f))
+			case c.tag == "input":
+				if t := strings.ToLower(c.attr("type")); t == "checkbox" || t == "radio" {
+					mark := "☐ "
+					if _, checked := c.attrs["checked"]; checked {
+						mark = "☒ "
+					}
+					addRun(&flowRun{text: mark, style: f.style})
+				} else if v := c.attr("value"); v != "" {
+					addRun(&flowRun{text: v, style: f.style})
+				}
+			default:
+				walk(c, htmlInlineFmt(c, f))
+			}
+		}
+	}
+	walk(parent, f)
+	flush()
+	return out
+}
+
+// htmlBlock converts a block element.
+func htmlBlock(n *htmlNode, f inlineFmt, align string) []*flowBlock {
+	f = htmlInlineFmt(n, f)
+	align = htmlAlign(n, align)
+	switch n.tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		blocks := htmlBlocks(n, f, align)
+		for _, b := range blocks {
+			if b.kind == flowParagraph {
+				b.kind = flowHeading
+				b.level = int(n.tag[1] - '0')
+			}
+		}
+		return blocks
+	case "p":
+		blocks := htmlBlocks(n, f, align)
+		if len(blocks) == 0 {
+			// empty paragraphs are used by editors for blank lines
+			blocks = []*flowBlock{{kind: flowParagraph, align: align}}
+		}
+		return blocks
+	case "blockquote":
+		return []*flowBlock{{kind: flowQuote, children: htmlBlocks(n, f, align)}}
+	case "pre":
+		return []*flowBlock{htmlPre(n)}
+	case "hr":
+		return []*flowBlock{{kind: flowRule}}
+	case "ul", "ol":
+		return []*flowBlock{htmlList(n, f, align)}
+	case "table":
+		return htmlTable(n, f, align)
+	case "dd":
+		return []*flowBlock{{kind: flowQuote, children: htmlBlocks(n, f, align)}}
+	case "dt":
+		f.style |= styleBold
+	}
+	return htmlBlocks(n, f, align)
+}
+
+// htmlPre converts a preformatted element to a code block.
+func htmlPre(n *htmlNode) *flowBlock {
+	var b strings.Builder
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		for _, c := range n.children {
+			switch {
+			case c.tag == "":
+				b.WriteString(c.text)
+			case c.tag == "br":
+				b.WriteString("\n")
+			case !htmlSkipTags[c.tag]:
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	text := strings.TrimPrefix(b.String(), "\r")
+	text = strings.TrimPrefix(text, "\n")
+	text = strings.Replace(strings.TrimRight(text, "\r\n"), "\r\n", "\n", -1)
+	block := &flowBlock{kind: flowCode, text: text}
+	classes := n.attr("class")
+	if len(n.children) == 1 && n.children[0].tag == "code" {
+		classes += " " + n.children[0].attr("class")
+	}
+	for _, c := range strings.Fields(classes) {
+		if strings.HasPrefix(c, "language-") {
+			block.lang = strings.TrimPrefix(c, "language-")
+		}
+	}
+	return block
+}
+
+// htmlList converts a list element. Content that is not wrapped in list items
+// is attached to the previous item or forms an item of its own.
+func htmlList(n *htmlNode, f inlineFmt, align string) *flowBlock {
+	list := &flowBlock{kind: flowList, ordered: n.tag == "ol", start: 1}
+	if s, err := strconv.Atoi(strings.TrimSpace(n.attr("start"))); err == nil {
+		list.start = s
+	}
+	var loose []*htmlNode
+	flushLoose := func() {
+		if len(loose) == 0 {
+			return
+		}
+		blocks := htmlBlocks(&htmlNode{tag: "li", children: loose}, f, align)
+		loose = nil
+		if len(blocks) == 0 {
+			return
+		}
+		// nested lists written directly into a list belong to the previous item
+		if k := len(list.items); k > 0 && blocks[0].kind == flowList {
+			list.items[k-1].blocks = append(list.items[k-1].blocks, blocks...)
+			return
+		}
+		list.items = append(list.items, &flowItem{blocks: blocks})
+	}
+	for _, c := range n.children {
+		if c.tag != "li" {
+			loose = append(loose, c)
+			continue
+		}
+		flushLoose()
+		item := &flowItem{}
+		li := c
+		// a leading checkbox makes the item a task list item
+		for i, cc := range c.children {
+			if cc.tag == "" && strings.TrimSpace(cc.text) == "" {
+				continue
+			}
+			if cc.tag == "input" && strings.ToLower(cc.attr("type")) == "checkbox" {
+				item.task = 1
+				if _, checked := cc.attrs["checked"]; checked {
+					item.task = 2
+				}
+				li = &htmlNode{tag: c.tag, attrs: c.attrs, children: c.children[i+1:]}
+			}
+			break
+		}
+		item.blocks = htmlBlocks(li, htmlInlineFmt(c, f), htmlAlign(c, align))
+		list.items = append(list.items, item)
+	}
+	flushLoose()
+	return list
+}
+
+// htmlTable converts a table element, a caption is written as a paragraph in
+// front of the table.
+func htmlTable(n *htmlNode, f inlineFmt, align string) []*flowBlock {
+	var out []*flowBlock
+	table := &flowBlock{kind: flowTable}
+	var rows []*htmlNode
+	var collect func(n *htmlNode)
+	collect = func(n *htmlNode) {
+		for _, c := range n.children {
+			switch c.tag {
+			case "caption":
+				out = append(out, htmlBlocks(c, f, "center")...)
+			case "tr":
+				rows = append(rows, c)
+			case "thead", "tbody", "tfoot":
+				collect(c)
+			case "td", "th":
+				// cells outside of rows form a row of their own
+				rows = append(rows, &htmlNode{tag: "tr", children: []*htmlNode{c}})
+			}
+		}
+	}
+	collect(n)
+	allHeader := len(rows) > 0
+	for ri, tr := range rows {
+		var row []*flowCell
+		for _, c := range tr.children {
+			if c.tag != "td" && c.tag != "th" {
+				continue
+			}
+			cf := htmlInlineFmt(c, f)
+			if c.tag == "th" {
+				cf.style |= styleBold
+			} else if ri == 0 {
+				allHeader = false
+			}
+			cell := &flowCell{colSpan: htmlSpan(c.attr("colspan")), rowSpan: htmlSpan(c.attr("rowspan"))}
+			cell.blocks = htmlBlocks(c, cf, htmlAlign(c, htmlAlign(tr, "")))
+			row = append(row, cell)
+		}
+		if len(row) > 0 {
+			table.rows = append(table.rows, row)
+		}
+	}
+	if len(table.rows) == 0 {
+		return out
+	}
+	table.header = allHeader
+	return append(out, table)
+}
+
+func htmlSpan(v string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || n < 1 {
+		return 1
+	}
+	if n > 1000 {
+		return 1000
+	}
+	return n
+}
+
+// htmlImage converts an img element to an image run, or to its alternative
+// text if it has no source.
+func htmlImage(n *htmlNode, f inlineFmt) *flowRun {
+	src := strings.TrimSpace(n.attr("src"))
+	if src == "" {
+		return &flowRun{text: n.attr("alt"), style: f.style, link: f.link, color: f.color, size: f.size, font: f.font}
+	}
+	r := &flowRun{image: src, text: n.attr("alt"), link: f.link}
+	css := parseCSS(n.attr("style"))
+	r.width = cssLength(css["width"])
+	if r.width == 0 {
+		r.width = htmlPixels(n.attr("width"))
+	}
+	r.height = cssLength(css["height"])
+	if r.height == 0 {
+		r.height = htmlPixels(n.attr("height"))
+	}
+	return r
+}
+
+// htmlPixels converts an HTML length attribute in pixels to points.
+func htmlPixels(v string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(v), "px"), 64)
+	if err != nil || f <= 0 {
+		return 0
+	}
+	return f * 0.75
+}
+
+// htmlInlineFmt returns the formatting of an element's content.
+func htmlInlineFmt(n *htmlNode, f inlineFmt) inlineFmt {
+	switch n.tag {
+	case "b", "strong":
+		f.style |= styleBold
+	case "i", "em", "cite", "var", "dfn":
+		f.style |= styleItalic
+	case "u", "ins":
+		f.style |= styleUnderline
+	case "s", "strike", "del":
+		f.style |= styleStrike
+	case "code", "kbd", "samp", "tt":
+		f.style |= styleCode
+	case "sup":
+		f.style = f.style&^styleSubscript | styleSuperscript
+	case "sub":
+		f.style = f.style&^styleSuperscript | styleSubscript
+	case "a":
+		if href := strings.TrimSpace(n.attr("href")); href != "" && !strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			f.link = href
+		}
+	case "font":
+		if c := cssColor(n.attr("color")); c != "" {
+			f.color = c
+		}
+		if face := n.attr("face"); face != "" {
+			f.font = cssFontFamily(face)
+		}
+		if s, err := strconv.Atoi(strings.TrimSpace(n.attr("size"))); err == nil && s >= 1 && s <= 7 {
+			f.size = []float64{8, 10, 12, 14, 18, 24, 36}[s-1]
+		}
+	}
+	css := parseCSS(n.attr("style"))
+	if c := cssColor(css["color"]); c != "" {
+		f.color = c
+	}
+	if s := cssFontSize(css["font-size"], f.size); s > 0 {
+		f.size = s
+	}
+	if v := css["font-family"]; v != "" {
+		f.font = cssFontFamily(v)
+	}
+	switch v := css["font-weight"]; v {
+	case "bold", "bolder", "600", "700", "800", "900":
+		f.style |= styleBold
+	case "normal", "lighter", "100", "200", "300", "400":
+		f.style &^= styleBold
+	}
+	switch css["font-style"] {
+	case "italic", "oblique":
+		f.style |= styleItalic
+	case "normal":
+		f.style &^= styleItalic
+	}
+	for _, v := range []string{css["text-decoration"], css["text-decoration-line"]} {
+		if strings.Contains(v, "underline") {
+			f.style |= styleUnderline
+		}
+		if strings.Contains(v, "line-through") {
+			f.style |= styleStrike
+		}
+		if v == "none" {
+			f.style &^= styleUnderline | styleStrike
+		}
+	}
+	switch css["vertical-align"] {
+	case "super":
+		f.style = f.style&^styleSubscript | styleSuperscript
+	case "sub":
+		f.style = f.style&^styleSuperscript | styleSubscript
+	}
+	return f
+}
+
+// htmlAlign returns the text alignment of an element.
+func htmlAlign(n *htmlNode, inherited string) string {
+	v := parseCSS(n.attr("style"))["text-align"]
+	if v == "" {
+		v = strings.ToLower(strings.TrimSpace(n.attr("align")))
+	}
+	if n.tag == "center" {
+		v = "center"
+	}
+	switch v {
+	case "left", "start":
+		return "left"
+	case "right", "end":
+		return "right"
+	case "center", "justify":
+		return v
+	}
+	return inherited
+}
+
+// parseCSS parses the declarations of an inline style attribute.
+func parseCSS(style string) map[string]string {
+	css := map[string]string{}
+	for _, decl := range strings.Split(style, ";") {
+		i := strings.IndexByte(decl, ':')
+		if i < 0 {
+			continue
+		}
+		prop := strings.ToLower(strings.TrimSpace(decl[:i]))
+		val := strings.TrimSpace(decl[i+1:])
+		val = strings.TrimSpace(strings.TrimSuffix(val, "!important"))
+		css[prop] = strings.ToLower(val)
+		if prop == "font-family" {
+			css[prop] = val
+		}
+	}
+	return css
+}
+
+var cssNamedColors = map[string]string{
+	"black": "000000", "silver": "C0C0C0", "gray": "808080", "grey": "808080", "white": "FFFFFF",
+	"maroon": "800000", "red": "FF0000", "purple": "800080", "fuchsia": "FF00FF", "magenta": "FF00FF",
+	"green": "008000", "lime": "00FF00", "olive": "808000", "yellow": "FFFF00", "navy": "000080",
+	"blue": "0000FF", "teal": "008080", "aqua": "00FFFF", "cyan": "00FFFF", "orange": "FFA500",
+	"brown": "A52A2A", "pink": "FFC0CB", "gold": "FFD700", "darkred": "8B0000", "darkgreen": "006400",
+	"darkblue": "00008B", "darkgray": "A9A9A9", "darkgrey": "A9A9A9", "lightgray": "D3D3D3",
+	"lightgrey": "D3D3D3", "indigo": "4B0082", "violet": "EE82EE", "crimson": "DC143C",
+}
+
+// cssColor converts a CSS color to a "RRGGBB" hex string, returning an empty
+// string for unsupported values.
+func cssColor(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if c, ok := cssNamedColors[v]; ok {
+		return c
+	}
+	if strings.HasPrefix(v, "#") {
+		h := v[1:]
+		if len(h) == 3 || len(h) == 4 {
+			h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+		}
+		if len(h) == 8 {
+			h = h[:6]
+		}
+		if _, err := strconv.ParseUint(h, 16, 32); err == nil && len(h) == 6 {
+			return strings.ToUpper(h)
+		}
+		return ""
+	}
+	if strings.HasPrefix(v, "rgb") {
+		i, j := strings.IndexByte(v, '('), strings.IndexByte(v, ')')
+		if i < 0 || j < i {
+			return ""
+		}
+		parts := strings.FieldsFunc(v[i+1:j], func(r rune) bool { return r == ',' || r == ' ' || r == '/' })
+		if len(parts) < 3 {
+			return ""
+		}
+		var rgb [3]int
+		for k := 0; k < 3; k++ {
+			p := parts[k]
+			var f float64
+			var err error
+			if strings.HasSuffix(p, "%") {
+				f, err = strconv.ParseFloat(strings.TrimSuffix(p, "%"), 64)
+				f = f * 255 / 100
+			} else {
+				f, err = strconv.ParseFloat(p, 64)
+			}
+			if err != nil {
+				return ""
+			}
+			if f < 0 {
+				f = 0
+			} else if f > 255 {
+				f = 255
+			}
+			rgb[k] = int(f + 0.5)
+		}
+		return fmt.Sprintf("%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+	}
+	return ""
+}
+
+// cssLength converts a CSS length to points, returning zero for relative or
+// unsupported values.
+func cssLength(v string) float64 {
+	v = strings.TrimSpace(v)
+	units := []struct {
+		suffix string
+		factor float64
+	}{{"pt", 1}, {"px", 0.75}, {"in", 72}, {"cm", 72 / 2.54}, {"mm", 72 / 25.4}, {"pc", 12}}
+	for _, u := range units {
+		if strings.HasSuffix(v, u.suffix) {
+			f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(v, u.suffix)), 64)
+			if err != nil || f <= 0 {
+				return 0
+			}
+			return f * u.factor
+		}
+	}
+	return 0
+}
+
+// cssFontSize converts a CSS font size to points, relative sizes are based on
+// the inherited size or 11pt.
+func cssFontSize(v string, inherited float64) float64 {
+	if v == "" {
+		return 0
+	}
+	if s := cssLength(v); s > 0 {
+		return s
+	}
+	base := inherited
+	if base == 0 {
+		base = 11
+	}
+	keywords := map[string]float64{
+		"xx-small": 7, "x-small": 7.5, "small": 10, "medium": 12, "large": 13.5,
+		"x-large": 18, "xx-large": 24, "xxx-large": 36,
+	}
+	if s, ok := keywords[v]; ok {
+		return s
+	}
+	for _, u := range []string{"em", "rem", "%"} {
+		if strings.HasSuffix(v, u) {
+			f, err := strconv.ParseFloat(strings.TrimSuffix(v, u), 64)
+			if err != nil || f <= 0 {
+				return 0
+			}
+			if u == "%" {
+				f /= 100
+			}
+			return f * base
+		}
+	}
+	return 0
+}
+
+// cssFontFamily returns the first font of a font-family list.
+func cssFontFamily(v string) string {
+	first := strings.TrimSpace(strings.Split(v, ",")[0])
+	first = strings.Trim(first, "\"'")
+	switch strings.ToLower(first) {
+	case "serif", "sans-serif", "monospace", "cursive", "fantasy", "system-ui", "inherit", "initial":
+		return ""
+	}
+	return first
+}
+
+// collapseSpace collapses white space sequences to a single space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// trimRuns removes white space at the start and end of a paragraph, around
+// line breaks and between adjacent runs.
+func trimRuns(runs []*flowRun) []*flowRun {
+	var out []*flowRun
+	atStart := true
+	for _, r := range runs {
+		if r.lineBreak || r.image != "" {
+			if r.lineBreak && len(out) > 0 && out[len(out)-1].image == "" && !out[len(out)-1].lineBreak {
+				out[len(out)-1].text = strings.TrimRight(out[len(out)-1].text, " ")
+			}
+			out = append(out, r)
+			atStart = r.lineBreak
+			continue
+		}
+		if atStart {
+			r.text = strings.TrimLeft(r.text, " ")
+		}
+		if r.text == "" {
+			continue
+		}
+		atStart = strings.HasSuffix(r.text, " ")
+		out = append(out, r)
+	}
+	// trailing white space and line breaks carry no content
+	for len(out) > 0 {
+		last := out[len(out)-1]
+		if last.lineBreak {
+			out = out[:len(out)-1]
+			continue
+		}
+		if last.image == "" {
+			last.text = strings.TrimRight(last.text, " ")
+			if last.text == "" {
+				out = out[:len(out)-1]
+				continue
+			}
+		}
+		break
+	}
+	return mergeRuns(out)
+}
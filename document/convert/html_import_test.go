@@ -0,0 +1,235 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+const importHTML = `<html><head><title>Skipped</title><style>p { color: red }</style></head><body>
+<h1>Report</h1>
+<p>Plain <b>bold</b> <i>italic</i> <span style="color:#ff0000;font-size:14pt">red</span> and <a href="https://example.com/">a link</a>.</p>
+<p style="text-align:center">Line one<br>line two</p>
+<table>
+<tr><th>A</th><th>B</th><th>C</th></tr>
+<tr><td colspan="2">wide</td><td rowspan="2">tall</td></tr>
+<tr><td>x</td><td>y</td></tr>
+</table>
+<p><img src="data:image/png;base64,` + pngDot + `" alt="dot" style="width:72pt"></p>
+</body></html>`
+
+// bodyParagraphs returns the paragraphs of a document that are not in tables.
+func bodyParagraphs(d *document.Document) []document.Paragraph {
+	ps := d.Paragraphs()
+	for _, t := range d.Tables() {
+		for _, r := range t.Rows() {
+			for _, c := range r.Cells() {
+				ps = ps[:len(ps)-len(c.Paragraphs())]
+			}
+		}
+	}
+	return ps
+}
+
+func runText(p document.Paragraph) string {
+	text := ""
+	for _, r := range p.Runs() {
+		text += r.Text()
+	}
+	return text
+}
+
+func findRun(p document.Paragraph, text string) (document.Run, bool) {
+	for _, r := range p.Runs() {
+		if r.Text() == text {
+			return r, true
+		}
+	}
+	return document.Run{}, false
+}
+
+func TestConvertFromHTMLParagraphs(t *testing.T) {
+	d, err := ConvertFromHTML(strings.NewReader(importHTML))
+	if err != nil {
+		t.Fatalf("error converting from HTML: %s", err)
+	}
+	ps := bodyParagraphs(d)
+	if len(ps) != 4 {
+		t.Fatalf("expected 4 paragraphs outside of the table, got %d", len(ps))
+	}
+	for _, p := range d.Paragraphs() {
+		if strings.Contains(runText(p), "Skipped") {
+			t.Errorf("expected the title to be skipped")
+		}
+	}
+
+	if got := ps[0].Style(); got != "Heading1" {
+		t.Errorf("expected the heading to use Heading1, got %q", got)
+	}
+	if got := runText(ps[0]); got != "Report" {
+		t.Errorf("expected the heading text Report, got %q", got)
+	}
+
+	if got := runText(ps[1]); got != "Plain bold italic red and a link." {
+		t.Errorf("expected the runs to keep the text and spacing, got %q", got)
+	}
+	if r, ok := findRun(ps[1], "bold"); !ok || !r.Properties().IsBold() || r.Properties().IsItalic() {
+		t.Errorf("expected a bold run")
+	}
+	if r, ok := findRun(ps[1], "italic"); !ok || !r.Properties().IsItalic() || r.Properties().IsBold() {
+		t.Errorf("expected an italic run")
+	}
+	if r, ok := findRun(ps[1], "Plain "); !ok || r.Properties().IsBold() || r.Properties().IsItalic() {
+		t.Errorf("expected a plain run")
+	}
+	if r, ok := findRun(ps[1], "red"); !ok {
+		t.Errorf("expected a colored run")
+	} else {
+		rpr := r.Properties().X()
+		if rpr.Color == nil || !strings.EqualFold(stringValue(rpr.Color.ValAttr.ST_HexColorRGB), "FF0000") {
+			t.Errorf("expected the color FF0000")
+		}
+		if rpr.Sz == nil || rpr.Sz.ValAttr.ST_UnsignedDecimalNumber == nil || *rpr.Sz.ValAttr.ST_UnsignedDecimalNumber != 28 {
+			t.Errorf("expected a size of 28 half points")
+		}
+	}
+	link := ""
+	for _, pc := range ps[1].X().EG_PContent {
+		if hl := pc.Hyperlink; hl != nil && hl.IdAttr != nil {
+			link = d.GetTargetByRelId(*hl.IdAttr)
+		}
+	}
+	if link != "https://example.com/" {
+		t.Errorf("expected a hyperlink to https://example.com/, got %q", link)
+	}
+
+	if pp := ps[2].X().PPr; pp == nil || pp.Jc == nil || pp.Jc.ValAttr != wml.ST_JcCenter {
+		t.Errorf("expected a centered paragraph")
+	}
+	breaks := 0
+	for _, r := range ps[2].Runs() {
+		for _, ric := range r.X().EG_RunInnerContent {
+			if ric.Br != nil {
+				breaks++
+			}
+		}
+	}
+	if breaks != 1 {
+		t.Errorf("expected a line break, got %d", breaks)
+	}
+}
+
+func TestConvertFromHTMLTables(t *testing.T) {
+	d, err := ConvertFromHTML(strings.NewReader(importHTML))
+	if err != nil {
+		t.Fatalf("error converting from HTML: %s", err)
+	}
+	tables := d.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("expected a table, got %d", len(tables))
+	}
+	rows := tables[0].Rows()
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	type cellExp struct {
+		text   string
+		span   int64
+		vMerge wml.ST_Merge
+		bold   bool
+	}
+	exp := [][]cellExp{
+		{{"A", 0, wml.ST_MergeUnset, true}, {"B", 0, wml.ST_MergeUnset, true}, {"C", 0, wml.ST_MergeUnset, true}},
+		{{"wide", 2, wml.ST_MergeUnset, false}, {"tall", 0, wml.ST_MergeRestart, false}},
+		{{"x", 0, wml.ST_MergeUnset, false}, {"y", 0, wml.ST_MergeUnset, false}, {"", 0, wml.ST_MergeContinue, false}},
+	}
+	for ri, row := range rows {
+		cells := row.Cells()
+		if len(cells) != len(exp[ri]) {
+			t.Errorf("row %d: expected %d cells, got %d", ri, len(exp[ri]), len(cells))
+			continue
+		}
+		for ci, c := range cells {
+			ce := exp[ri][ci]
+			text := ""
+			bold := false
+			for _, p := range c.Paragraphs() {
+				text += runText(p)
+				for _, r := range p.Runs() {
+					bold = bold || r.Properties().IsBold()
+				}
+			}
+			if text != ce.text {
+				t.Errorf("row %d cell %d: expected %q, got %q", ri, ci, ce.text, text)
+			}
+			if bold != ce.bold {
+				t.Errorf("row %d cell %d: expected bold %v, got %v", ri, ci, ce.bold, bold)
+			}
+			tcPr := c.Properties().X()
+			span := int64(0)
+			if tcPr.GridSpan != nil {
+				span = tcPr.GridSpan.ValAttr
+			}
+			if span != ce.span {
+				t.Errorf("row %d cell %d: expected a column span of %d, got %d", ri, ci, ce.span, span)
+			}
+			vMerge := wml.ST_MergeUnset
+			if tcPr.VMerge != nil {
+				vMerge = tcPr.VMerge.ValAttr
+			}
+			if vMerge != ce.vMerge {
+				t.Errorf("row %d cell %d: expected the vertical merge %v, got %v", ri, ci, ce.vMerge, vMerge)
+			}
+		}
+	}
+}
+
+func TestConvertFromHTMLImages(t *testing.T) {
+	d, err := ConvertFromHTML(strings.NewReader(importHTML))
+	if err != nil {
+		t.Fatalf("error converting from HTML: %s", err)
+	}
+	if len(d.Images) != 1 {
+		t.Fatalf("expected an embedded image, got %d", len(d.Images))
+	}
+	ps := bodyParagraphs(d)
+	var inl []document.InlineDrawing
+	for _, r := range ps[len(ps)-1].Runs() {
+		inl = append(inl, r.DrawingInline()...)
+	}
+	if len(inl) != 1 {
+		t.Fatalf("expected an inline image, got %d", len(inl))
+	}
+	x := inl[0].X()
+	// the 2x1 pixel image is scaled to the requested width of 72pt
+	if x.Extent.CxAttr != 914400 || x.Extent.CyAttr != 457200 {
+		t.Errorf("expected a size of 914400x457200 EMU, got %dx%d", x.Extent.CxAttr, x.Extent.CyAttr)
+	}
+	if x.DocPr == nil || stringValue(x.DocPr.DescrAttr) != "dot" {
+		t.Errorf("expected the alternative text to be kept")
+	}
+	if _, ok := inl[0].GetImage(); !ok {
+		t.Errorf("expected the drawing to reference the image")
+	}
+
+	// images that can't be loaded fall back to their alternative text
+	d, err = ConvertFromHTML(strings.NewReader(`<p><img src="https://example.com/a.png" alt="remote"></p>`))
+	if err != nil {
+		t.Fatalf("error converting from HTML: %s", err)
+	}
+	if len(d.Images) != 0 {
+		t.Errorf("expected remote images not to be embedded")
+	}
+	if ps := d.Paragraphs(); len(ps) != 1 || runText(ps[0]) != "remote" {
+		t.Errorf("expected the alternative text of the remote image")
+	}
+}
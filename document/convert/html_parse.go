@@ -0,0 +1,271 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlNode is an element or text node of a parsed HTML document.
+type htmlNode struct {
+	// tag is the lower case element name, empty for text nodes
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*htmlNode
+	parent   *htmlNode
+}
+
+func (n *htmlNode) attr(name string) string { return n.attrs[name] }
+
+var htmlVoidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+var htmlRawTextTags = map[string]bool{"script": true, "style": true, "textarea": true, "title": true}
+
+// htmlBlockTags are the elements that are converted to blocks. Starting one of
+// them closes an open paragraph.
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "center": true,
+	"details": true, "dialog": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hr": true, "li": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "summary": true, "table": true, "ul": true,
+}
+
+// parseHTML parses an HTML document into a tree. The parser is lenient: it
+// never fails, closes elements implied by the HTML content model and ignores
+// stray end tags.
+func parseHTML(src string) *htmlNode {
+	root := &htmlNode{tag: "#root"}
+	cur := root
+	for i := 0; i < len(src); {
+		lt := strings.IndexByte(src[i:], '<')
+		if lt < 0 {
+			addHTMLText(cur, src[i:])
+			break
+		}
+		if lt > 0 {
+			addHTMLText(cur, src[i:i+lt])
+			i += lt
+		}
+		rest := src[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return root
+			}
+			i += end + 7
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return root
+			}
+			i += end + 1
+		case strings.HasPrefix(rest, "</"):
+			name, _ := scanTagName(rest[2:])
+			end := strings.IndexByte(rest, '>')
+			if name == "" || end < 0 {
+				addHTMLText(cur, "<")
+				i++
+				continue
+			}
+			cur = closeHTMLElement(cur, name)
+			i += end + 1
+		default:
+			name, attrs, selfClosing, n := parseStartTag(rest)
+			if n == 0 {
+				addHTMLText(cur, "<")
+				i++
+				continue
+			}
+			i += n
+			el := openHTMLElement(cur, name, attrs)
+			switch {
+			case htmlVoidTags[name] || selfClosing:
+			case htmlRawTextTags[name]:
+				end := strings.Index(strings.ToLower(src[i:]), "</"+name)
+				if end < 0 {
+					end = len(src) - i
+				}
+				text := src[i : i+end]
+				if name == "textarea" || name == "title" {
+					text = html.UnescapeString(text)
+				}
+				el.children = append(el.children, &htmlNode{text: text, parent: el})
+				i += end
+				cur = el
+			default:
+				cur = el
+			}
+		}
+	}
+	return root
+}
+
+func addHTMLText(cur *htmlNode, s string) {
+	if s == "" {
+		return
+	}
+	s = html.UnescapeString(s)
+	if n := len(cur.children); n > 0 && cur.children[n-1].tag == "" {
+		cur.children[n-1].text += s
+		return
+	}
+	cur.children = append(cur.children, &htmlNode{text: s, parent: cur})
+}
+
+func isTagNameChar(c byte, first bool) bool {
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && ((c >= '0' && c <= '9') || c == '-' || c == ':' || c == '_')
+}
+
+// scanTagName returns the lower case tag name at the start of s and its length.
+func scanTagName(s string) (string, int) {
+	n := 0
+	for n < len(s) && isTagNameChar(s[n], n == 0) {
+		n++
+	}
+	return strings.ToLower(s[:n]), n
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// parseStartTag parses a start tag at the start of s, returning its name,
+// attributes and length. A zero length means that s does not start with a
+// valid tag.
+func parseStartTag(s string) (string, map[string]string, bool, int) {
+	name, n := scanTagName(s[1:])
+	if name == "" {
+		return "", nil, false, 0
+	}
+	attrs := map[string]string{}
+	i := n + 1
+	for {
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			return "", nil, false, 0
+		}
+		switch {
+		case s[i] == '>':
+			return name, attrs, false, i + 1
+		case strings.HasPrefix(s[i:], "/>"):
+			return name, attrs, true, i + 2
+		case s[i] == '/':
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '=' && s[i] != '>' && !strings.HasPrefix(s[i:], "/>") {
+			i++
+		}
+		key := strings.ToLower(s[start:i])
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		val := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				end := strings.IndexByte(s[i+1:], s[i])
+				if end < 0 {
+					return "", nil, false, 0
+				}
+				val = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				val = s[start:i]
+			}
+		}
+		if _, ok := attrs[key]; !ok && key != "" {
+			attrs[key] = html.UnescapeString(val)
+		}
+	}
+}
+
+// closeImplied returns the parent of the nearest open element named in names,
+// searching up to an element named in stop. If there is none, cur is
+// returned.
+func closeImplied(cur *htmlNode, names, stop map[string]bool) *htmlNode {
+	for n := cur; n != nil && n.tag != "#root"; n = n.parent {
+		if names[n.tag] {
+			return n.parent
+		}
+		if stop[n.tag] {
+			break
+		}
+	}
+	return cur
+}
+
+var (
+	htmlParagraphTag = map[string]bool{"p": true}
+	htmlListItemTag  = map[string]bool{"li": true}
+	htmlDefTags      = map[string]bool{"dt": true, "dd": true}
+	htmlRowTag       = map[string]bool{"tr": true}
+	htmlCellTags     = map[string]bool{"td": true, "th": true}
+	htmlSectionTags  = map[string]bool{"thead": true, "tbody": true, "tfoot": true}
+
+	htmlParagraphStop = map[string]bool{
+		"div": true, "li": true, "td": true, "th": true, "blockquote": true, "table": true,
+		"section": true, "article": true, "body": true, "dd": true, "dt": true,
+	}
+	htmlListStop    = map[string]bool{"ul": true, "ol": true, "table": true}
+	htmlDefStop     = map[string]bool{"dl": true, "table": true}
+	htmlRowStop     = map[string]bool{"table": true, "thead": true, "tbody": true, "tfoot": true}
+	htmlCellStop    = map[string]bool{"tr": true, "table": true}
+	htmlSectionStop = map[string]bool{"table": true}
+)
+
+func openHTMLElement(cur *htmlNode, name string, attrs map[string]string) *htmlNode {
+	if htmlBlockTags[name] {
+		cur = closeImplied(cur, htmlParagraphTag, htmlParagraphStop)
+	}
+	switch name {
+	case "li":
+		cur = closeImplied(cur, htmlListItemTag, htmlListStop)
+	case "dt", "dd":
+		cur = closeImplied(cur, htmlDefTags, htmlDefStop)
+	case "tr":
+		cur = closeImplied(cur, htmlRowTag, htmlRowStop)
+	case "td", "th":
+		cur = closeImplied(cur, htmlCellTags, htmlCellStop)
+	case "thead", "tbody", "tfoot":
+		cur = closeImplied(cur, htmlSectionTags, htmlSectionStop)
+	}
+	el := &htmlNode{tag: name, attrs: attrs, parent: cur}
+	cur.children = append(cur.children, el)
+	return el
+}
+
+func closeHTMLElement(cur *htmlNode, name string) *htmlNode {
+	for n := cur; n != nil && n.tag != "#root"; n = n.parent {
+		if n.tag == name {
+			return n.parent
+		}
+	}
+	return cur
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+// pngDot is a 2x1 pixel PNG image.
+const pngDot = "iVBORw0KGgoAAAANSUhEUgAAAAIAAAABCAAAAADRSSBWAAAAEElEQVR4nAADAPz/AgAAAwAACQADCLq+SAAAAABJRU5ErkJggg=="
+
+func exportDocument(t *testing.T) *document.Document {
+	d := document.New()
+	d.CoreProperties.SetTitle("Notes & more")
+
+	p := d.AddParagraph()
+	p.SetStyle("Heading2")
+	p.AddRun().AddText("Section")
+
+	p = d.AddParagraph()
+	p.AddRun().AddText("plain ")
+	r := p.AddRun()
+	r.Properties().SetBold(true)
+	r.AddText("bold <tag>")
+	hl := p.AddHyperLink()
+	hl.SetTarget("https://example.com/?a=1&b=2")
+	hl.AddRun().AddText("link")
+
+	hl = d.AddParagraph().AddHyperLink()
+	hl.SetTarget("javascript:alert(1)")
+	hl.AddRun().AddText("unsafe")
+
+	nd := d.Numbering.AddDefinition()
+	nd.AddLevel().SetFormat(wml.ST_NumberFormatBullet)
+	nd.AddLevel().SetFormat(wml.ST_NumberFormatDecimal)
+	for _, item := range []struct {
+		text  string
+		level int
+	}{{"one", 0}, {"nested", 1}, {"two", 0}} {
+		p = d.AddParagraph()
+		p.SetNumberingDefinition(nd)
+		p.SetNumberingLevel(item.level)
+		p.AddRun().AddText(item.text)
+	}
+
+	tbl := d.AddTable()
+	row := tbl.AddRow()
+	c := row.AddCell()
+	c.Properties().SetColumnSpan(2)
+	c.AddParagraph().AddRun().AddText("wide")
+	c = row.AddCell()
+	c.Properties().SetVerticalMerge(wml.ST_MergeRestart)
+	c.AddParagraph().AddRun().AddText("tall")
+	row = tbl.AddRow()
+	row.AddCell().AddParagraph().AddRun().AddText("x")
+	row.AddCell().AddParagraph().AddRun().AddText("y")
+	c = row.AddCell()
+	c.Properties().SetVerticalMerge(wml.ST_MergeContinue)
+	c.AddParagraph()
+
+	data, _ := base64.StdEncoding.DecodeString(pngDot)
+	img, err := common.ImageFromBytes(data)
+	if err != nil {
+		t.Fatalf("error reading image: %s", err)
+	}
+	ref, err := d.AddImage(img)
+	if err != nil {
+		t.Fatalf("error adding image: %s", err)
+	}
+	inl, err := d.AddParagraph().AddRun().AddDrawingInline(ref)
+	if err != nil {
+		t.Fatalf("error adding drawing: %s", err)
+	}
+	inl.SetSize(72*measurement.Point, 36*measurement.Point)
+	inl.X().DocPr.DescrAttr = unioffice.String("dot")
+	return d
+}
+
+func TestConvertDocumentToHTML(t *testing.T) {
+	buf := bytes.Buffer{}
+	if err := ConvertToHTML(exportDocument(t), &buf); err != nil {
+		t.Fatalf("error converting to HTML: %s", err)
+	}
+	out := buf.String()
+	td := []struct {
+		Name string
+		Exp  string
+		Want bool
+	}{
+		{"title", "<title>Notes &amp; more</title>", true},
+		{"heading", "<h2>Section</h2>\n", true},
+		{"runs and link", "<p>plain <strong>bold &lt;tag&gt;</strong><a href=\"https://example.com/?a=1&amp;b=2\">link</a></p>\n", true},
+		{"unsafe link text", "<p>unsafe</p>\n", true},
+		{"unsafe link", "javascript:", false},
+		{"lists", "<ul>\n<li>one\n<ol>\n<li>nested</li></ol>\n</li>\n<li>two</li></ul>\n", true},
+		{"merged cells", "<table class=\"doc\">\n" +
+			"<tr><td colspan=\"2\"><p>wide</p>\n</td><td rowspan=\"2\"><p>tall</p>\n</td></tr>\n" +
+			"<tr><td><p>x</p>\n</td><td><p>y</p>\n</td></tr>\n</table>\n", true},
+		{"image", "<img src=\"data:image/png;base64," + pngDot + "\" alt=\"dot\" style=\"width:72.00pt;height:36.00pt\">", true},
+		{"document", "</body>\n</html>\n", true},
+	}
+	for _, tc := range td {
+		if got := strings.Contains(out, tc.Exp); got != tc.Want {
+			t.Errorf("%s: expected %q to be in the output: %v\n%s", tc.Name, tc.Exp, tc.Want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := ConvertToHTMLWithOptions(exportDocument(t), &buf, &HTMLOptions{Fragment: true}); err != nil {
+		t.Fatalf("error converting to HTML: %s", err)
+	}
+	if out := buf.String(); strings.Contains(out, "<html>") || !strings.Contains(out, "<h2>Section</h2>") {
+		t.Errorf("expected a fragment without the document wrapper:\n%s", out)
+	}
+}
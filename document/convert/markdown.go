@@ -0,0 +1,655 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+// MarkdownOptions contains the options for converting between Markdown and
+// documents.
+type MarkdownOptions struct {
+	// BaseDir is the directory that relative image paths are resolved against
+	// when importing Markdown.
+	BaseDir string
+
+	// ImageDirectory, if set, makes the export write images into this
+	// directory instead of embedding them as data URIs.
+	ImageDirectory string
+
+	// ImageURLPrefix is prepended to the file name of exported images when
+	// referencing them from the Markdown. It is only used with ImageDirectory.
+	ImageURLPrefix string
+}
+
+// ConvertFromMarkdown reads CommonMark with the GitHub extensions (tables,
+// strikethrough, task lists and autolinks) from r and returns it as a new
+// document. This package is beta, breaking changes can take place.
+func ConvertFromMarkdown(r io.Reader) (*document.Document, error) {
+	return ConvertFromMarkdownWithOptions(r, nil)
+}
+
+// ConvertFromMarkdownWithOptions reads Markdown from r and returns it as a new
+// document. Headings use the Heading styles, code spans and blocks a
+// monospace style, lists are numbered through the document numbering and
+// links become hyperlinks. Images are embedded from local files or data URIs.
+func ConvertFromMarkdownWithOptions(r io.Reader, opts *MarkdownOptions) (*document.Document, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	o := MarkdownOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	d := document.New()
+	b := newDocBuilder(d, o.BaseDir)
+	b.writeBlocks(d, parseMarkdown(string(src)), blockCtx{})
+	return d, nil
+}
+
+// ConvertToMarkdown writes the document to w as GitHub flavored Markdown.
+func ConvertToMarkdown(d *document.Document, w io.Writer) error {
+	return ConvertToMarkdownWithOptions(d, w, nil)
+}
+
+// ConvertToMarkdownWithOptions writes the document to w as GitHub flavored
+// Markdown. Heading styles are mapped to ATX headings, numbering to nested
+// lists, preformatted styles to fenced code blocks, tables to pipe tables and
+// footnotes to footnote references.
+func ConvertToMarkdownWithOptions(d *document.Document, w io.Writer, opts *MarkdownOptions) error {
+	o := MarkdownOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	mw := &mdWriter{
+		docInfo:      docInfo{doc: d},
+		images:       convertutils.NewHTMLWriter(&convertutils.HTMLOptions{ImageDirectory: o.ImageDirectory, ImageURLPrefix: o.ImageURLPrefix}),
+		counters:     map[int64][]int{},
+		footnoteNums: map[int64]int{},
+	}
+	if body := d.X().Body; body != nil {
+		mw.writeBlocks(body.EG_BlockLevelElts)
+	}
+	mw.flushCode()
+	out := mw.join()
+	if len(mw.footnotes) > 0 {
+		var notes []string
+		// footnotes may reference further footnotes, so the list can grow while
+		// being written
+		for i := 0; i < len(mw.footnotes); i++ {
+			text := ""
+			if fn := d.Footnote(mw.footnotes[i]).X(); fn != nil {
+				var parts []string
+				for _, p := range paragraphsOf(fn.EG_BlockLevelElts) {
+					if t := mw.inline(p.EG_PContent, "\\\n    "); t != "" {
+						parts = append(parts, t)
+					}
+				}
+				text = strings.Join(parts, "\n\n    ")
+			}
+			notes = append(notes, fmt.Sprintf("[^%d]: %s", i+1, text))
+		}
+		out += "\n\n" + strings.Join(notes, "\n")
+	}
+	if out != "" {
+		out += "\n"
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// mdChunkKind determines how adjacent Markdown chunks are separated.
+type mdChunkKind int
+
+const (
+	mdChunkBlock mdChunkKind = iota
+	mdChunkList
+	mdChunkQuote
+)
+
+type mdChunk struct {
+	kind mdChunkKind
+	text string
+}
+
+type mdWriter struct {
+	docInfo
+	images *convertutils.HTMLWriter
+	chunks []mdChunk
+	code   []string
+
+	// counters holds the current item number of each level per numbering
+	counters map[int64][]int
+	// markers holds the marker width of the currently open list levels
+	markers []int
+
+	footnotes    []int64
+	footnoteNums map[int64]int
+}
+
+// join concatenates the chunks, keeping list items and quote paragraphs
+// together.
+func (mw *mdWriter) join() string {
+	var b strings.Builder
+	for i, c := range mw.chunks {
+		if i > 0 {
+			prev := mw.chunks[i-1].kind
+			switch {
+			case prev == mdChunkList && c.kind == mdChunkList:
+				b.WriteString("\n")
+			case prev == mdChunkQuote && c.kind == mdChunkQuote:
+				b.WriteString("\n>\n")
+			default:
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(c.text)
+	}
+	return b.String()
+}
+
+func (mw *mdWriter) add(kind mdChunkKind, text string) {
+	mw.flushCode()
+	mw.chunks = append(mw.chunks, mdChunk{kind: kind, text: text})
+}
+
+// flushCode writes the pending code paragraphs as a fenced code block.
+func (mw *mdWriter) flushCode() {
+	if mw.code == nil {
+		return
+	}
+	text := strings.Join(mw.code, "\n")
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	mw.code = nil
+	mw.chunks = append(mw.chunks, mdChunk{text: fence + "\n" + text + "\n" + fence})
+}
+
+// paragraphsOf returns the paragraphs of block level content, including
+// paragraphs in content controls.
+func paragraphsOf(blocks []*wml.EG_BlockLevelElts) []*wml.CT_P {
+	var ps []*wml.CT_P
+	for _, ble := range blocks {
+		for _, cbc := range ble.EG_ContentBlockContent {
+			ps = append(ps, cbc.P...)
+			if cbc.Sdt != nil && cbc.Sdt.SdtContent != nil {
+				ps = append(ps, cbc.Sdt.SdtContent.P...)
+			}
+		}
+	}
+	return ps
+}
+
+func (mw *mdWriter) writeBlocks(blocks []*wml.EG_BlockLevelElts) {
+	for _, ble := range blocks {
+		for _, cbc := range ble.EG_ContentBlockContent {
+			for _, p := range cbc.P {
+				mw.writeParagraph(p)
+			}
+			for _, tbl := range cbc.Tbl {
+				mw.writeTable(tbl)
+			}
+			if sdt := cbc.Sdt; sdt != nil && sdt.SdtContent != nil {
+				for _, p := range sdt.SdtContent.P {
+					mw.writeParagraph(p)
+				}
+				for _, tbl := range sdt.SdtContent.Tbl {
+					mw.writeTable(tbl)
+				}
+			}
+		}
+	}
+}
+
+func (mw *mdWriter) writeParagraph(p *wml.CT_P) {
+	if mw.isCodeParagraph(p) {
+		mw.markers = nil
+		mw.code = append(mw.code, strings.Split(plainText(p.EG_PContent), "\n")...)
+		return
+	}
+	if numID, lvl, ok := mw.numbering(p); ok {
+		mw.writeListItem(p, numID, int(lvl))
+		return
+	}
+	mw.markers = nil
+	text := mw.inline(p.EG_PContent, "\\\n")
+	if strings.TrimSpace(text) == "" {
+		mw.flushCode()
+		return
+	}
+	if lvl := mw.headingLevel(p); lvl > 0 {
+		mw.add(mdChunkBlock, strings.Repeat("#", lvl)+" "+strings.Replace(text, "\\\n", " ", -1))
+		return
+	}
+	text = escapeBlockStarts(text)
+	if mw.isQuoteParagraph(p) {
+		mw.add(mdChunkQuote, "> "+strings.Replace(text, "\n", "\n> ", -1))
+		return
+	}
+	mw.add(mdChunkBlock, text)
+}
+
+func (mw *mdWriter) writeListItem(p *wml.CT_P, numID int64, lvl int) {
+	if lvl > 8 {
+		lvl = 8
+	}
+	counters := mw.counters[numID]
+	for len(counters) <= lvl {
+		counters = append(counters, 0)
+	}
+	// a shallower item restarts the numbering of the deeper levels
+	for i := lvl + 1; i < len(counters); i++ {
+		counters[i] = 0
+	}
+	if counters[lvl] == 0 {
+		counters[lvl] = mw.numberingStart(numID, int64(lvl))
+	} else {
+		counters[lvl]++
+	}
+	mw.counters[numID] = counters
+
+	marker := "-"
+	if !mw.isBulleted(numID, int64(lvl)) {
+		marker = fmt.Sprintf("%d.", counters[lvl])
+	}
+	for len(mw.markers) < lvl {
+		// levels skipped in the document are indented by a bullet width
+		mw.markers = append(mw.markers, 2)
+	}
+	mw.markers = append(mw.markers[:lvl], len(marker)+1)
+	indent := 0
+	for _, m := range mw.markers[:lvl] {
+		indent += m
+	}
+	pad := strings.Repeat(" ", indent)
+	text := escapeBlockStarts(mw.inline(p.EG_PContent, "\\\n"))
+	text = strings.Replace(text, "\n", "\n"+pad+strings.Repeat(" ", len(marker)+1), -1)
+	mw.add(mdChunkList, pad+marker+" "+text)
+}
+
+func (mw *mdWriter) writeTable(tbl *wml.CT_Tbl) {
+	mw.markers = nil
+	var rows [][]string
+	cols := 0
+	for _, rc := range tbl.EG_ContentRowContent {
+		for _, tr := range rc.Tr {
+			var row []string
+			for _, cc := range tr.EG_ContentCellContent {
+				for _, tc := range cc.Tc {
+					text := ""
+					if merged, restart := vMerge(tc); !merged || restart {
+						var parts []string
+						for _, p := range paragraphsOf(tc.EG_BlockLevelElts) {
+							if t := mw.inline(p.EG_PContent, "<br>"); t != "" {
+								parts = append(parts, t)
+							}
+						}
+						text = strings.Replace(strings.Join(parts, "<br>"), "|", "\\|", -1)
+					}
+					row = append(row, text)
+					if tc.TcPr != nil && tc.TcPr.GridSpan != nil {
+						for i := int64(1); i < tc.TcPr.GridSpan.ValAttr; i++ {
+							row = append(row, "")
+						}
+					}
+				}
+			}
+			if len(row) > cols {
+				cols = len(row)
+			}
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 || cols == 0 {
+		return
+	}
+	var lines []string
+	for i, row := range rows {
+		for len(row) < cols {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", cols))
+		}
+	}
+	mw.add(mdChunkBlock, strings.Join(lines, "\n"))
+}
+
+// plainText returns the text of paragraph content with line breaks and tabs.
+func plainText(pcs []*wml.EG_PContent) string {
+	var b strings.Builder
+	var runs func(crcs []*wml.EG_ContentRunContent)
+	runs = func(crcs []*wml.EG_ContentRunContent) {
+		for _, crc := range crcs {
+			if crc.R != nil {
+				for _, ric := range crc.R.EG_RunInnerContent {
+					switch {
+					case ric.T != nil:
+						b.WriteString(ric.T.Content)
+					case ric.Tab != nil:
+						b.WriteString("\t")
+					case ric.Br != nil:
+						b.WriteString("\n")
+					}
+				}
+			}
+			if crc.Sdt != nil && crc.Sdt.SdtContent != nil {
+				runs(crc.Sdt.SdtContent.EG_ContentRunContent)
+			}
+		}
+	}
+	for _, pc := range pcs {
+		if pc.Hyperlink != nil {
+			runs(pc.Hyperlink.EG_ContentRunContent)
+		}
+		runs(pc.EG_ContentRunContent)
+	}
+	return b.String()
+}
+
+// mdSpan is a piece of inline content. Raw spans hold Markdown that is
+// written unchanged.
+type mdSpan struct {
+	text  string
+	style runStyle
+	link  string
+	raw   bool
+}
+
+// inline renders paragraph content as Markdown, using lineBreak for hard
+// line breaks.
+func (mw *mdWriter) inline(pcs []*wml.EG_PContent, lineBreak string) string {
+	var spans []mdSpan
+	var runs func(crcs []*wml.EG_ContentRunContent, link string)
+	runs = func(crcs []*wml.EG_ContentRunContent, link string) {
+		for _, crc := range crcs {
+			if crc.R != nil {
+				spans = append(spans, mw.runSpans(crc.R, link, lineBreak)...)
+			}
+			if crc.Sdt != nil && crc.Sdt.SdtContent != nil {
+				runs(crc.Sdt.SdtContent.EG_ContentRunContent, link)
+			}
+		}
+	}
+	for _, pc := range pcs {
+		if hl := pc.Hyperlink; hl != nil {
+			link := ""
+			if hl.IdAttr != nil {
+				link = mw.doc.GetTargetByRelId(*hl.IdAttr)
+			} else if hl.AnchorAttr != nil {
+				link = "#" + *hl.AnchorAttr
+			}
+			runs(hl.EG_ContentRunContent, link)
+		}
+		runs(pc.EG_ContentRunContent, "")
+	}
+	return renderSpans(spans)
+}
+
+// runStyleOf returns the formatting of a run, taking its character style into
+// account.
+func (mw *mdWriter) runStyleOf(rpr *wml.CT_RPr) runStyle {
+	var st runStyle
+	if rpr == nil {
+		return st
+	}
+	apply := func(rpr *wml.CT_RPr) {
+		if rpr == nil {
+			return
+		}
+		set := func(v *wml.CT_OnOff, flag runStyle) {
+			if v == nil {
+				return
+			}
+			if onOff(v) {
+				st |= flag
+			} else {
+				st &^= flag
+			}
+		}
+		set(rpr.B, styleBold)
+		set(rpr.I, styleItalic)
+		set(rpr.Strike, styleStrike)
+		set(rpr.Dstrike, styleStrike)
+		if rpr.VertAlign != nil {
+			switch rpr.VertAlign.ValAttr {
+			case sharedTypes.ST_VerticalAlignRunSuperscript:
+				st |= styleSuperscript
+			case sharedTypes.ST_VerticalAlignRunSubscript:
+				st |= styleSubscript
+			}
+		}
+	}
+	if rpr.RStyle != nil {
+		chain := mw.styleChain(rpr.RStyle.ValAttr)
+		for i := len(chain) - 1; i >= 0; i-- {
+			apply(chain[i].RPr)
+		}
+	}
+	apply(rpr)
+	if mw.isCodeRun(rpr) {
+		st |= styleCode
+	}
+	return st
+}
+
+func (mw *mdWriter) runSpans(r *wml.CT_R, link, lineBreak string) []mdSpan {
+	st := mw.runStyleOf(r.RPr)
+	var spans []mdSpan
+	for _, ric := range r.EG_RunInnerContent {
+		switch {
+		case ric.T != nil:
+			spans = append(spans, mdSpan{text: ric.T.Content, style: st, link: link})
+		case ric.Tab != nil:
+			spans = append(spans, mdSpan{text: " ", style: st, link: link})
+		case ric.Br != nil:
+			spans = append(spans, mdSpan{text: lineBreak, style: st, link: link, raw: true})
+		case ric.Drawing != nil:
+			for _, img := range mw.drawingImages(ric.Drawing) {
+				src, err := mw.images.ImageSource(img.ref.Data(), img.ref.Path(), img.ref.Format())
+				if err != nil {
+					continue
+				}
+				spans = append(spans, mdSpan{text: "![" + escapeMarkdown(img.alt) + "](" + markdownURL(src) + ")", link: link, raw: true})
+			}
+		case ric.FootnoteReference != nil:
+			id := ric.FootnoteReference.IdAttr
+			n, ok := mw.footnoteNums[id]
+			if !ok {
+				mw.footnotes = append(mw.footnotes, id)
+				n = len(mw.footnotes)
+				mw.footnoteNums[id] = n
+			}
+			spans = append(spans, mdSpan{text: fmt.Sprintf("[^%d]", n), raw: true})
+		}
+	}
+	return spans
+}
+
+var mdEscaper = strings.NewReplacer(
+	"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+	"<", "\\<", "~", "\\~",
+)
+
+func escapeMarkdown(s string) string { return mdEscaper.Replace(s) }
+
+// markdownURL makes a link destination safe to use in inline links.
+func markdownURL(u string) string {
+	if strings.ContainsAny(u, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(u) + ">"
+	}
+	return u
+}
+
+var mdBlockStartRe = regexp.MustCompile(`^(?:#{1,6}(?:[ \t]|$)|[-+*](?:[ \t]|$)|[0-9]{1,9}[.)](?:[ \t]|$)|>|=+[ \t]*$|` + "```" + `|~~~)`)
+
+// escapeBlockStarts escapes lines of paragraph text that would otherwise be
+// read as the start of another block.
+func escapeBlockStarts(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		if !mdBlockStartRe.MatchString(l) && !mdRuleRe.MatchString(l) {
+			continue
+		}
+		j := 0
+		if l[0] >= '0' && l[0] <= '9' {
+			for l[j] >= '0' && l[j] <= '9' {
+				j++
+			}
+		}
+		lines[i] = l[:j] + "\\" + l[j:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// emphasis markers in the order they are opened
+var mdMarkers = []struct {
+	style  runStyle
+	marker string
+}{
+	{styleStrike, "~~"},
+	{styleBold, "**"},
+	{styleItalic, "*"},
+	{styleSuperscript, "<sup>"},
+	{styleSubscript, "<sub>"},
+}
+
+func closingMarker(m string) string {
+	if strings.HasPrefix(m, "<") {
+		return "</" + m[1:]
+	}
+	return m
+}
+
+// renderSpans renders spans as Markdown. Spans with the same link target are
+// written as a single link and emphasis is kept open across spans sharing it.
+func renderSpans(in []mdSpan) string {
+	var spans []mdSpan
+	for _, s := range in {
+		if n := len(spans); n > 0 && !s.raw && !spans[n-1].raw && spans[n-1].style == s.style && spans[n-1].link == s.link {
+			spans[n-1].text += s.text
+			continue
+		}
+		spans = append(spans, s)
+	}
+	var b strings.Builder
+	for i := 0; i < len(spans); {
+		j := i + 1
+		for j < len(spans) && spans[j].link == spans[i].link {
+			j++
+		}
+		text := renderStyled(spans[i:j])
+		if link := spans[i].link; link != "" && strings.TrimSpace(text) != "" {
+			b.WriteString("[" + text + "](" + markdownURL(link) + ")")
+		} else {
+			b.WriteString(text)
+		}
+		i = j
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func renderStyled(spans []mdSpan) string {
+	var b strings.Builder
+	var open []string
+	var cur runStyle
+	pendingWS := ""
+	// transition closes the markers that are no longer needed and opens the
+	// new ones, keeping whitespace outside of the markers
+	transition := func(st runStyle, lead string) {
+		keep := 0
+		for keep < len(open) {
+			m := mdMarkers[markerIndex(open[keep])]
+			if st&m.style == 0 {
+				break
+			}
+			keep++
+		}
+		for k := len(open) - 1; k >= keep; k-- {
+			b.WriteString(closingMarker(open[k]))
+		}
+		open = open[:keep]
+		b.WriteString(pendingWS + lead)
+		pendingWS = ""
+		have := runStyle(0)
+		for _, m := range open {
+			have |= mdMarkers[markerIndex(m)].style
+		}
+		for _, m := range mdMarkers {
+			if st&m.style != 0 && have&m.style == 0 {
+				b.WriteString(m.marker)
+				open = append(open, m.marker)
+			}
+		}
+		cur = st
+	}
+	for _, s := range spans {
+		if s.raw {
+			if cur != s.style&^styleCode {
+				transition(s.style&^styleCode, "")
+			}
+			b.WriteString(pendingWS + s.text)
+			pendingWS = ""
+			continue
+		}
+		core := strings.TrimSpace(s.text)
+		if core == "" {
+			pendingWS += s.text
+			continue
+		}
+		lead := s.text[:strings.Index(s.text, core)]
+		trail := s.text[len(lead)+len(core):]
+		st := s.style &^ styleCode
+		if st != cur {
+			transition(st, lead)
+		} else {
+			b.WriteString(pendingWS + lead)
+			pendingWS = ""
+		}
+		if s.style&styleCode != 0 {
+			fence := "`"
+			for strings.Contains(core, fence) {
+				fence += "`"
+			}
+			if strings.HasPrefix(core, "`") || strings.HasSuffix(core, "`") {
+				core = " " + core + " "
+			}
+			b.WriteString(fence + core + fence)
+		} else {
+			b.WriteString(escapeMarkdown(core))
+		}
+		pendingWS = trail
+	}
+	for k := len(open) - 1; k >= 0; k-- {
+		b.WriteString(closingMarker(open[k]))
+	}
+	b.WriteString(pendingWS)
+	return b.String()
+}
+
+func markerIndex(m string) int {
+	for i, mm := range mdMarkers {
+		if mm.marker == m {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,801 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	mdATXRe        = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+|$)(.*)$`)
+	mdATXCloseRe   = regexp.MustCompile(`(?:^|[ \t]+)#+[ \t]*$`)
+	mdRuleRe       = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdSetextRe     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdFenceRe      = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	mdListRe       = regexp.MustCompile(`^( {0,3})([-+*]|[0-9]{1,9}[.)])([ \t]+|$)`)
+	mdQuoteRe      = regexp.MustCompile(`^ {0,3}> ?`)
+	mdTaskRe       = regexp.MustCompile(`^\[([ xX])\][ \t]+`)
+	mdTableDelimRe = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdRefDefRe     = regexp.MustCompile(`^ {0,3}\[((?:[^\]\\]|\\.)+)\]:[ \t]*<?([^\s>]*)>?(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+	mdAutolinkRe   = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+	mdEmailRe      = regexp.MustCompile(`^<([^\s<>@]+@[^\s<>@]+\.[^\s<>@]+)>`)
+	mdURLRe        = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]*[^\s<?!.,:*_~'")\]]`)
+	mdEntityRe     = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	mdBreakTagRe   = regexp.MustCompile(`^<[bB][rR][ \t]*/?>`)
+	mdTagRe        = regexp.MustCompile(`^</?[a-zA-Z][a-zA-Z0-9-]*(?:[ \t\n][^<>]*)?/?>`)
+)
+
+// mdParser converts CommonMark with the GitHub extensions for tables,
+// strikethrough, task list items and autolinks into flow blocks.
+type mdParser struct {
+	refs map[string]string
+}
+
+// parseMarkdown parses a Markdown source into flow blocks.
+func parseMarkdown(src string) []*flowBlock {
+	p := &mdParser{refs: map[string]string{}}
+	src = strings.Replace(src, "\r\n", "\n", -1)
+	src = strings.Replace(src, "\r", "\n", -1)
+	lines := strings.Split(src, "\n")
+	for i, l := range lines {
+		lines[i] = expandIndent(l)
+	}
+	blocks := p.parseBlocks(lines)
+	p.resolveInlines(blocks)
+	return blocks
+}
+
+// expandIndent replaces tabs in the indentation of a line by spaces using a
+// tab width of four.
+func expandIndent(l string) string {
+	if !strings.Contains(l, "\t") {
+		return l
+	}
+	col := 0
+	for i := 0; i < len(l); i++ {
+		switch l[i] {
+		case ' ':
+			col++
+		case '\t':
+			col += 4 - col%4
+		default:
+			return strings.Repeat(" ", col) + l[i:]
+		}
+	}
+	return ""
+}
+
+func isBlank(l string) bool { return strings.TrimSpace(l) == "" }
+
+func leadingSpaces(l string) int { return len(l) - len(strings.TrimLeft(l, " ")) }
+
+// startsBlock reports whether a line starts a block that interrupts a
+// paragraph.
+func startsBlock(l string) bool {
+	if mdATXRe.MatchString(l) || mdRuleRe.MatchString(l) || mdFenceRe.MatchString(l) || mdQuoteRe.MatchString(l) {
+		return true
+	}
+	m := mdListRe.FindStringSubmatch(l)
+	if m == nil || isBlank(l[len(m[0]):]) {
+		return false
+	}
+	return !isOrderedMarker(m[2]) || m[2][:len(m[2])-1] == "1"
+}
+
+func isOrderedMarker(m string) bool { return m[0] >= '0' && m[0] <= '9' }
+
+func (p *mdParser) parseBlocks(lines []string) []*flowBlock {
+	var blocks []*flowBlock
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, p.paragraph(para)...)
+			para = nil
+		}
+	}
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			flush()
+			i++
+			continue
+		}
+		// indented code block
+		if leadingSpaces(line) >= 4 && len(para) == 0 {
+			var code []string
+			j := i
+			for ; j < len(lines); j++ {
+				if isBlank(lines[j]) {
+					code = append(code, "")
+					continue
+				}
+				if leadingSpaces(lines[j]) < 4 {
+					break
+				}
+				code = append(code, lines[j][4:])
+			}
+			for len(code) > 0 && code[len(code)-1] == "" {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, &flowBlock{kind: flowCode, text: strings.Join(code, "\n")})
+			i = j
+			continue
+		}
+		if m := mdSetextRe.FindStringSubmatch(line); m != nil && len(para) > 0 {
+			level := 1
+			if m[1][0] == '-' {
+				level = 2
+			}
+			blocks = append(blocks, &flowBlock{kind: flowHeading, level: level, raw: strings.Join(para, "\n")})
+			para = nil
+			i++
+			continue
+		}
+		if m := mdATXRe.FindStringSubmatch(line); m != nil {
+			flush()
+			text := mdATXCloseRe.ReplaceAllString(m[2], "")
+			blocks = append(blocks, &flowBlock{kind: flowHeading, level: len(m[1]), raw: strings.TrimSpace(text)})
+			i++
+			continue
+		}
+		if mdRuleRe.MatchString(line) {
+			flush()
+			blocks = append(blocks, &flowBlock{kind: flowRule})
+			i++
+			continue
+		}
+		if m := mdFenceRe.FindStringSubmatch(line); m != nil {
+			flush()
+			code, next := fencedCode(lines, i+1, len(m[1]), m[2])
+			b := &flowBlock{kind: flowCode, text: code}
+			if f := strings.Fields(html.UnescapeString(m[3])); len(f) > 0 {
+				b.lang = f[0]
+			}
+			blocks = append(blocks, b)
+			i = next
+			continue
+		}
+		if mdQuoteRe.MatchString(line) {
+			flush()
+			var inner []string
+			j := i
+			for ; j < len(lines); j++ {
+				l := lines[j]
+				if loc := mdQuoteRe.FindStringIndex(l); loc != nil {
+					inner = append(inner, l[loc[1]:])
+					continue
+				}
+				// lazy continuation of a quoted paragraph
+				if !isBlank(l) && !isBlank(inner[len(inner)-1]) && !startsBlock(l) {
+					inner = append(inner, l)
+					continue
+				}
+				break
+			}
+			blocks = append(blocks, &flowBlock{kind: flowQuote, children: p.parseBlocks(inner)})
+			i = j
+			continue
+		}
+		if mdListRe.MatchString(line) && (len(para) == 0 || startsBlock(line)) {
+			flush()
+			list, next := p.parseList(lines, i)
+			blocks = append(blocks, list)
+			i = next
+			continue
+		}
+		if len(para) == 0 && i+1 < len(lines) && strings.Contains(line, "|") && mdTableDelimRe.MatchString(lines[i+1]) {
+			header := splitTableRow(line)
+			if delim := splitTableRow(lines[i+1]); len(header) == len(delim) {
+				table, next := p.parseTable(lines, i+2, header, delim)
+				blocks = append(blocks, table)
+				i = next
+				continue
+			}
+		}
+		para = append(para, strings.TrimLeft(line, " "))
+		i++
+	}
+	flush()
+	return blocks
+}
+
+// fencedCode collects the lines of a fenced code block starting at line i and
+// returns the code along with the index of the line following the block.
+func fencedCode(lines []string, i, indent int, fence string) (string, int) {
+	var code []string
+	for ; i < len(lines); i++ {
+		l := lines[i]
+		if t := strings.TrimLeft(l, " "); len(l)-len(t) < 4 && t != "" && t[0] == fence[0] {
+			c := strings.TrimRight(t, " \t")
+			if strings.Trim(c, fence[:1]) == "" && len(c) >= len(fence) {
+				return strings.Join(code, "\n"), i + 1
+			}
+		}
+		n := leadingSpaces(l)
+		if n > indent {
+			n = indent
+		}
+		code = append(code, l[n:])
+	}
+	return strings.Join(code, "\n"), i
+}
+
+// paragraph records link reference definitions at the start of a paragraph
+// and returns the remaining lines as a paragraph block.
+func (p *mdParser) paragraph(lines []string) []*flowBlock {
+	for len(lines) > 0 {
+		m := mdRefDefRe.FindStringSubmatch(lines[0])
+		if m == nil {
+			break
+		}
+		label := normalizeLabel(m[1])
+		if _, ok := p.refs[label]; !ok {
+			p.refs[label] = unescapeMarkdown(m[2])
+		}
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []*flowBlock{{kind: flowParagraph, raw: strings.Join(lines, "\n")}}
+}
+
+func normalizeLabel(l string) string {
+	return strings.ToLower(strings.Join(strings.Fields(l), " "))
+}
+
+func (p *mdParser) parseList(lines []string, i int) (*flowBlock, int) {
+	first := mdListRe.FindStringSubmatch(lines[i])
+	list := &flowBlock{kind: flowList, ordered: isOrderedMarker(first[2]), start: 1}
+	if list.ordered {
+		list.start, _ = strconv.Atoi(first[2][:len(first[2])-1])
+	}
+	delim := first[2][len(first[2])-1]
+	for i < len(lines) {
+		m := mdListRe.FindStringSubmatch(lines[i])
+		if m == nil || m[2][len(m[2])-1] != delim || isOrderedMarker(m[2]) != list.ordered || mdRuleRe.MatchString(lines[i]) {
+			break
+		}
+		width := len(m[1]) + len(m[2])
+		rest := lines[i][len(m[0]):]
+		switch sp := len(m[3]); {
+		case isBlank(rest):
+			width++
+			rest = ""
+		case sp > 4:
+			// the item starts with indented code
+			width++
+			rest = strings.Repeat(" ", sp-1) + rest
+		default:
+			width += sp
+		}
+		item := &flowItem{}
+		if t := mdTaskRe.FindStringSubmatch(rest); t != nil {
+			item.task = 1
+			if t[1] != " " {
+				item.task = 2
+			}
+			rest = rest[len(t[0]):]
+		}
+		content := []string{rest}
+		j := i + 1
+		for ; j < len(lines); j++ {
+			l := lines[j]
+			if isBlank(l) {
+				content = append(content, "")
+				continue
+			}
+			if leadingSpaces(l) >= width {
+				content = append(content, l[width:])
+				continue
+			}
+			// lazy continuation of the item paragraph
+			if !isBlank(content[len(content)-1]) && !startsBlock(l) && !mdListRe.MatchString(l) {
+				content = append(content, l)
+				continue
+			}
+			break
+		}
+		item.blocks = p.parseBlocks(content)
+		list.items = append(list.items, item)
+		i = j
+	}
+	return list, i
+}
+
+// splitTableRow splits a table row into its cells.
+func splitTableRow(l string) []string {
+	s := strings.TrimSpace(l)
+	s = strings.TrimPrefix(s, "|")
+	if strings.HasSuffix(s, "|") && !strings.HasSuffix(s, "\\|") {
+		s = s[:len(s)-1]
+	}
+	var cells []string
+	var cur strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '|':
+			cur.WriteByte('|')
+			i++
+		case s[i] == '|':
+			cells = append(cells, strings.TrimSpace(cur.String()))
+			cur.Reset()
+		default:
+			cur.WriteByte(s[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cur.String()))
+}
+
+func (p *mdParser) parseTable(lines []string, i int, header, delim []string) (*flowBlock, int) {
+	t := &flowBlock{kind: flowTable, header: true}
+	for _, d := range delim {
+		switch {
+		case strings.HasPrefix(d, ":") && strings.HasSuffix(d, ":"):
+			t.colAlign = append(t.colAlign, "center")
+		case strings.HasSuffix(d, ":"):
+			t.colAlign = append(t.colAlign, "right")
+		case strings.HasPrefix(d, ":"):
+			t.colAlign = append(t.colAlign, "left")
+		default:
+			t.colAlign = append(t.colAlign, "")
+		}
+	}
+	addRow := func(cells []string) {
+		row := make([]*flowCell, len(header))
+		for c := range row {
+			raw := ""
+			if c < len(cells) {
+				raw = cells[c]
+			}
+			row[c] = &flowCell{colSpan: 1, rowSpan: 1, blocks: []*flowBlock{{kind: flowParagraph, raw: raw, align: t.colAlign[c]}}}
+		}
+		t.rows = append(t.rows, row)
+	}
+	addRow(header)
+	for ; i < len(lines) && !isBlank(lines[i]) && !startsBlock(lines[i]); i++ {
+		addRow(splitTableRow(lines[i]))
+	}
+	return t, i
+}
+
+// resolveInlines parses the inline content of all blocks.
+func (p *mdParser) resolveInlines(blocks []*flowBlock) {
+	for _, b := range blocks {
+		switch b.kind {
+		case flowParagraph, flowHeading:
+			b.runs = p.parseInlines(b.raw)
+		case flowQuote:
+			p.resolveInlines(b.children)
+		case flowList:
+			for _, it := range b.items {
+				p.resolveInlines(it.blocks)
+			}
+		case flowTable:
+			for _, row := range b.rows {
+				for _, c := range row {
+					p.resolveInlines(c.blocks)
+				}
+			}
+		}
+	}
+}
+
+// mdInline is a node of the inline parser, either a run of content or an
+// emphasis delimiter run.
+type mdInline struct {
+	run       *flowRun
+	delim     byte
+	count     int
+	origCount int
+	canOpen   bool
+	canClose  bool
+}
+
+func (p *mdParser) parseInlines(s string) []*flowRun {
+	nodes := p.scanInlines(strings.TrimSpace(s))
+	processEmphasis(nodes)
+	var runs []*flowRun
+	for _, n := range nodes {
+		if n.delim != 0 {
+			if n.count > 0 {
+				runs = append(runs, &flowRun{text: strings.Repeat(string(n.delim), n.count), style: n.run.style, link: n.run.link})
+			}
+			continue
+		}
+		runs = append(runs, n.run)
+	}
+	return mergeRuns(runs)
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < 0x80 && c > ' ' && !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c != 0x7f
+}
+
+func isPunctRune(r rune) bool {
+	if r < 0x80 {
+		return isASCIIPunct(byte(r))
+	}
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func runeBefore(s string, i int) rune {
+	if i == 0 {
+		return ' '
+	}
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return r
+}
+
+func runeAfter(s string, i int) rune {
+	if i >= len(s) {
+		return ' '
+	}
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return r
+}
+
+func countRun(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// findBacktickRun returns the start of the next backtick run of exactly n
+// backticks, or -1.
+func findBacktickRun(s string, from, n int) int {
+	for j := from; j < len(s); {
+		if s[j] == '`' {
+			k := countRun(s, j, '`')
+			if k == n {
+				return j
+			}
+			j += k
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+func (p *mdParser) scanInlines(s string) []*mdInline {
+	var nodes []*mdInline
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &mdInline{run: &flowRun{text: text.String()}})
+			text.Reset()
+		}
+	}
+	addRun := func(r *flowRun) {
+		flushText()
+		nodes = append(nodes, &mdInline{run: r})
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				addRun(&flowRun{lineBreak: true})
+				i += 2
+				continue
+			}
+			if i+1 < len(s) && isASCIIPunct(s[i+1]) {
+				text.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+		case '`':
+			n := countRun(s, i, '`')
+			end := findBacktickRun(s, i+n, n)
+			if end < 0 {
+				text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			code := strings.Replace(s[i+n:end], "\n", " ", -1)
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			addRun(&flowRun{text: code, style: styleCode})
+			i = end + n
+			continue
+		case '*', '_', '~':
+			n := countRun(s, i, c)
+			if c == '~' && n > 2 {
+				text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			before, after := runeBefore(s, i), runeAfter(s, i+n)
+			left := !unicode.IsSpace(after) && (!isPunctRune(after) || unicode.IsSpace(before) || isPunctRune(before))
+			right := !unicode.IsSpace(before) && (!isPunctRune(before) || unicode.IsSpace(after) || isPunctRune(after))
+			d := &mdInline{run: &flowRun{}, delim: c, count: n, origCount: n, canOpen: left, canClose: right}
+			if c == '_' {
+				d.canOpen = left && (!right || isPunctRune(before))
+				d.canClose = right && (!left || isPunctRune(after))
+			}
+			flushText()
+			nodes = append(nodes, d)
+			i += n
+			continue
+		case '!', '[':
+			start := i
+			if c == '!' {
+				if i+1 >= len(s) || s[i+1] != '[' {
+					break
+				}
+				start++
+			}
+			if runs, end, ok := p.parseLink(s, start, c == '!'); ok {
+				for _, r := range runs {
+					addRun(r)
+				}
+				i = end
+				continue
+			}
+		case '<':
+			if m := mdAutolinkRe.FindStringSubmatch(s[i:]); m != nil {
+				addRun(&flowRun{text: m[1], link: m[1]})
+				i += len(m[0])
+				continue
+			}
+			if m := mdEmailRe.FindStringSubmatch(s[i:]); m != nil {
+				addRun(&flowRun{text: m[1], link: "mailto:" + m[1]})
+				i += len(m[0])
+				continue
+			}
+			if m := mdBreakTagRe.FindString(s[i:]); m != "" {
+				addRun(&flowRun{lineBreak: true})
+				i += len(m)
+				continue
+			}
+			// other inline HTML carries no meaning for the document and is
+			// dropped
+			if m := mdTagRe.FindString(s[i:]); m != "" {
+				i += len(m)
+				continue
+			}
+		case '&':
+			if m := mdEntityRe.FindString(s[i:]); m != "" {
+				text.WriteString(html.UnescapeString(m))
+				i += len(m)
+				continue
+			}
+		case '\n':
+			t := text.String()
+			trimmed := strings.TrimRight(t, " ")
+			text.Reset()
+			text.WriteString(trimmed)
+			if len(t)-len(trimmed) >= 2 {
+				addRun(&flowRun{lineBreak: true})
+			} else {
+				text.WriteByte(' ')
+			}
+			for i++; i < len(s) && s[i] == ' '; i++ {
+			}
+			continue
+		case 'h', 'w':
+			if i > 0 && !strings.ContainsRune(" \n(*_~", rune(s[i-1])) {
+				break
+			}
+			if m := mdURLRe.FindString(s[i:]); m != "" {
+				link := m
+				if strings.HasPrefix(m, "www.") {
+					link = "http://" + m
+				}
+				addRun(&flowRun{text: m, link: link})
+				i += len(m)
+				continue
+			}
+		}
+		text.WriteByte(c)
+		i++
+	}
+	flushText()
+	return nodes
+}
+
+// matchBracket returns the index of the bracket closing the one at i, or -1.
+func matchBracket(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			n := countRun(s, j, '`')
+			if e := findBacktickRun(s, j+n, n); e >= 0 {
+				j = e + n - 1
+			} else {
+				j += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// parseLinkDest parses an inline link destination and optional title
+// starting at the opening parenthesis at i.
+func parseLinkDest(s string, i int) (string, int, bool) {
+	skip := func(j int) int {
+		for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\n') {
+			j++
+		}
+		return j
+	}
+	j := skip(i + 1)
+	var dest string
+	if j < len(s) && s[j] == '<' {
+		k := strings.IndexAny(s[j+1:], ">\n")
+		if k < 0 || s[j+1+k] != '>' {
+			return "", 0, false
+		}
+		dest = s[j+1 : j+1+k]
+		j += k + 2
+	} else {
+		start, depth := j, 0
+	loop:
+		for j < len(s) {
+			switch s[j] {
+			case '\\':
+				j++
+			case '(':
+				depth++
+			case ')':
+				if depth == 0 {
+					break loop
+				}
+				depth--
+			case ' ', '\t', '\n':
+				break loop
+			}
+			j++
+		}
+		if j > len(s) {
+			return "", 0, false
+		}
+		dest = s[start:j]
+	}
+	j = skip(j)
+	if j < len(s) && (s[j] == '"' || s[j] == '\'' || s[j] == '(') {
+		closing := s[j]
+		if closing == '(' {
+			closing = ')'
+		}
+		k := strings.IndexByte(s[j+1:], closing)
+		if k < 0 {
+			return "", 0, false
+		}
+		j = skip(j + k + 2)
+	}
+	if j >= len(s) || s[j] != ')' {
+		return "", 0, false
+	}
+	return unescapeMarkdown(dest), j + 1, true
+}
+
+// unescapeMarkdown resolves backslash escapes and entities.
+func unescapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return html.UnescapeString(b.String())
+}
+
+// parseLink parses a link or image whose label starts at the bracket at i and
+// returns its runs along with the index following the link.
+func (p *mdParser) parseLink(s string, i int, image bool) ([]*flowRun, int, bool) {
+	end := matchBracket(s, i)
+	if end < 0 {
+		return nil, 0, false
+	}
+	label := s[i+1 : end]
+	dest, next, ok := "", end+1, false
+	if next < len(s) && s[next] == '(' {
+		if d, e, isLink := parseLinkDest(s, next); isLink {
+			dest, next, ok = d, e, true
+		}
+	}
+	if !ok && next < len(s) && s[next] == '[' {
+		if e := matchBracket(s, next); e >= 0 {
+			ref := s[next+1 : e]
+			if ref == "" {
+				ref = label
+			}
+			dest, ok = p.refs[normalizeLabel(ref)]
+			next = e + 1
+		}
+	} else if !ok {
+		dest, ok = p.refs[normalizeLabel(label)]
+		next = end + 1
+	}
+	if !ok {
+		return nil, 0, false
+	}
+	inner := p.parseInlines(label)
+	if image {
+		var alt strings.Builder
+		for _, r := range inner {
+			alt.WriteString(r.text)
+		}
+		return []*flowRun{{image: dest, text: alt.String()}}, next, true
+	}
+	for _, r := range inner {
+		if r.link == "" {
+			r.link = dest
+		}
+	}
+	return inner, next, true
+}
+
+// processEmphasis matches emphasis delimiter runs and applies the resulting
+// formatting to the nodes between them.
+func processEmphasis(nodes []*mdInline) {
+	for ci, closer := range nodes {
+		if closer.delim == 0 || !closer.canClose {
+			continue
+		}
+		for closer.count > 0 {
+			oi := -1
+			for k := ci - 1; k >= 0; k-- {
+				o := nodes[k]
+				if o.delim != closer.delim || !o.canOpen || o.count == 0 {
+					continue
+				}
+				if closer.delim == '~' {
+					if o.count != closer.count {
+						continue
+					}
+				} else if (o.canClose || closer.canOpen) && (o.origCount+closer.origCount)%3 == 0 &&
+					(o.origCount%3 != 0 || closer.origCount%3 != 0) {
+					continue
+				}
+				oi = k
+				break
+			}
+			if oi < 0 {
+				break
+			}
+			o := nodes[oi]
+			n, st := 1, styleItalic
+			switch {
+			case closer.delim == '~':
+				n, st = o.count, styleStrike
+			case o.count >= 2 && closer.count >= 2:
+				n, st = 2, styleBold
+			}
+			for _, between := range nodes[oi+1 : ci] {
+				between.run.style |= st
+				if between.delim != 0 {
+					between.canOpen, between.canClose = false, false
+				}
+			}
+			o.count -= n
+			closer.count -= n
+		}
+	}
+}
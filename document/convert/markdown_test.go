@@ -0,0 +1,59 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func markdownRoundTrip(t *testing.T, src string) string {
+	d, err := ConvertFromMarkdown(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("error converting from Markdown: %s", err)
+	}
+	buf := bytes.Buffer{}
+	if err := ConvertToMarkdown(d, &buf); err != nil {
+		t.Fatalf("error converting to Markdown: %s", err)
+	}
+	return buf.String()
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	td := []struct {
+		Name string
+		In   string
+		// Exp is the exported Markdown, empty if it is the same as In
+		Exp string
+	}{
+		{"headings", "# Title\n\n## Sub *it*\n\n###### Deep\n", ""},
+		{"bullet list", "- one\n- two\n  - nested\n- three\n", ""},
+		{"ordered list", "3. three\n4. four\n   1. sub\n", ""},
+		{"emphasis and links", "Some **bold**, *italic*, ~~gone~~ and `code` with a [link](https://example.com/a).\n", ""},
+		{"escaped text", "2\\. not a list with a \\*star\\*\n", ""},
+		{"table", "| Name | Value |\n| --- | --- |\n| a | **1** |\n",
+			"| **Name** | **Value** |\n| --- | --- |\n| a | **1** |\n"},
+		{"code block", "```go\nfunc main() {\n\n}\n```\n", "```\nfunc main() {\n\n}\n```\n"},
+	}
+	for _, tc := range td {
+		exp := tc.Exp
+		if exp == "" {
+			exp = tc.In
+		}
+		got := markdownRoundTrip(t, tc.In)
+		if got != exp {
+			t.Errorf("%s: expected\n%q\ngot\n%q", tc.Name, exp, got)
+			continue
+		}
+		// the exported Markdown has to survive another round trip unchanged
+		if again := markdownRoundTrip(t, got); again != got {
+			t.Errorf("%s: expected a second round trip to keep\n%q\ngot\n%q", tc.Name, got, again)
+		}
+	}
+}
@@ -0,0 +1,892 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/unidoc/unioffice/common/logger"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/dml/chart"
+	"github.com/unidoc/unipdf/v3/contentstream/draw"
+	"github.com/unidoc/unipdf/v3/creator"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// chartRenderer draws a chart space with a creator. Coordinates are in points
+// from the top left corner of the chart multiplied by scale, which is larger
+// than one when the chart is rendered to an image.
+type chartRenderer struct {
+	c     *creator.Creator
+	theme *dml.Theme
+	scale float64
+
+	// text is the default text style of the chart space
+	text   chartText
+	blanks chart.ST_DispBlanksAs
+
+	groups []*chartGroup
+	axes   []*chartAxis
+	plot   Rectangle
+	labels []pendingLabel
+}
+
+// makeChartCreator draws a chart of the given size in points onto a new
+// creator. Images are drawn eight times larger to keep them sharp.
+func makeChartCreator(cs *chart.ChartSpace, width, height float64, theme *dml.Theme, image bool) (*creator.Creator, error) {
+	scale := 1.0
+	if image {
+		scale = 8
+	}
+	c := MakeTempCreator(width*scale+1, height*scale+1)
+	r := &chartRenderer{c: c, theme: theme, scale: scale}
+	if err := r.drawChartSpace(cs, width*scale, height*scale); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *chartRenderer) drawChartSpace(cs *chart.ChartSpace, w, h float64) error {
+	r.text = r.textStyle(chartText{size: 10, color: "000000"}, cs.TxPr)
+	area := &Rectangle{Right: w, Bottom: h}
+	r.drawShape(cs.SpPr, area, "FFFFFF", "")
+	ch := cs.Chart
+	if ch == nil {
+		return nil
+	}
+	if ch.PlotArea == nil {
+		return errors.New("No plot area")
+	}
+	if ch.DispBlanksAs != nil {
+		r.blanks = ch.DispBlanksAs.ValAttr
+	}
+	r.readPlotArea(ch.PlotArea)
+
+	pad := r.pt(6)
+	free := Rectangle{Left: pad, Top: pad, Right: w - pad, Bottom: h - pad}
+	r.drawTitle(ch, &free, w, h)
+	if ch.Legend != nil {
+		r.drawLegend(ch.Legend, &free, w, h)
+	}
+	plot := free
+	inner := false
+	if l := ch.PlotArea.Layout; l != nil {
+		if rect, ok := r.manualLayout(l.ManualLayout, w, h); ok {
+			plot = rect
+			inner = l.ManualLayout.LayoutTarget != nil && l.ManualLayout.LayoutTarget.ValAttr == chart.ST_LayoutTargetInner
+		}
+	}
+	r.drawPlotArea(ch.PlotArea, plot, inner)
+	return nil
+}
+
+// manualLayout returns the rectangle of an element with a manual layout in
+// edge mode, where the position and size are fractions of the chart size.
+func (r *chartRenderer) manualLayout(l *chart.CT_ManualLayout, w, h float64) (Rectangle, bool) {
+	if l == nil || l.X == nil || l.Y == nil || l.W == nil || l.H == nil {
+		return Rectangle{}, false
+	}
+	edge := func(m *chart.CT_LayoutMode) bool {
+		return m == nil || m.ValAttr != chart.ST_LayoutModeFactor
+	}
+	if !edge(l.XMode) || !edge(l.YMode) {
+		return Rectangle{}, false
+	}
+	rect := Rectangle{Left: l.X.ValAttr * w, Top: l.Y.ValAttr * h}
+	rect.Right = rect.Left + l.W.ValAttr*w
+	rect.Bottom = rect.Top + l.H.ValAttr*h
+	return rect, rect.Right > rect.Left && rect.Bottom > rect.Top
+}
+
+// pt converts a length in points to chart coordinates.
+func (r *chartRenderer) pt(v float64) float64 {
+	return v * r.scale
+}
+
+// drawTitle draws the chart title at the top and removes its space from free
+// unless the title overlays the plot area.
+func (r *chartRenderer) drawTitle(ch *chart.CT_Chart, free *Rectangle, w, h float64) {
+	t := ch.Title
+	if t == nil {
+		return
+	}
+	auto := "Chart Title"
+	if series := r.allSeries(); len(series) == 1 {
+		auto = series[0].name
+	}
+	base := r.text
+	base.size = 14
+	lbl := r.titleLabel(t, base, auto)
+	if lbl == nil {
+		return
+	}
+	x := (w - lbl.w) / 2
+	y := free.Top
+	if l := t.Layout; l != nil && l.ManualLayout != nil && l.ManualLayout.X != nil && l.ManualLayout.Y != nil {
+		x, y = l.ManualLayout.X.ValAttr*w, l.ManualLayout.Y.ValAttr*h
+	}
+	r.drawShape(t.SpPr, &Rectangle{Left: x - r.pt(2), Top: y, Right: x + lbl.w + r.pt(2), Bottom: y + lbl.h}, "", "")
+	r.drawLabel(lbl, x, y)
+	if t.Overlay == nil || !boolVal(t.Overlay, false) {
+		free.Top = math.Max(free.Top, y+lbl.h+r.pt(4))
+	}
+}
+
+// titleLabel returns the text of a chart or axis title. Titles without text
+// show the automatic text.
+func (r *chartRenderer) titleLabel(t *chart.CT_Title, base chartText, auto string) *chartLabel {
+	st := r.textStyle(base, t.TxPr)
+	var lines []string
+	if t.Tx != nil && t.Tx.Choice != nil {
+		if rich := t.Tx.Choice.Rich; rich != nil {
+			st = r.textStyle(st, rich)
+			lines = r.richText(rich, &st)
+		} else if ref := t.Tx.Choice.StrRef; ref != nil && ref.StrCache != nil {
+			for _, pt := range ref.StrCache.Pt {
+				lines = append(lines, pt.V)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		if auto == "" {
+			return nil
+		}
+		lines = []string{auto}
+	}
+	return r.newLabel(lines, st)
+}
+
+// richText returns the lines of a text body. The style of the first run is
+// used for the whole text.
+func (r *chartRenderer) richText(body *dml.CT_TextBody, st *chartText) []string {
+	var lines []string
+	styled := false
+	for _, p := range body.P {
+		var b strings.Builder
+		for _, run := range p.EG_TextRun {
+			switch {
+			case run.R != nil:
+				if !styled {
+					*st = r.runStyle(*st, run.R.RPr)
+					styled = true
+				}
+				b.WriteString(run.R.T)
+			case run.Br != nil:
+				lines = append(lines, b.String())
+				b.Reset()
+			case run.Fld != nil && run.Fld.T != nil:
+				b.WriteString(*run.Fld.T)
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// legendItem is an entry of the legend with the key drawn in front of it.
+type legendItem struct {
+	text   string
+	fill   chartPaint
+	line   chartPaint
+	marker chart.ST_MarkerStyle
+	size   float64
+	// markerLine is the outline of the marker
+	markerLine chartPaint
+	// lineKey draws the key as a line, as for line and scatter charts
+	lineKey bool
+}
+
+// drawLegend lays out the legend at its position and removes its space from
+// free unless the legend overlays the plot area.
+func (r *chartRenderer) drawLegend(l *chart.CT_Legend, free *Rectangle, w, h float64) {
+	items := r.legendItems(l)
+	if len(items) == 0 {
+		return
+	}
+	st := r.textStyle(r.text, l.TxPr)
+	size := r.pt(st.size)
+	rowH := size * 1.4
+	keyW := size * 0.7
+	for _, it := range items {
+		if it.lineKey {
+			keyW = size * 2
+		}
+	}
+	gap := size * 0.4
+	pad := size * 0.4
+	widths := make([]float64, len(items))
+	maxW := 0.0
+	for i, it := range items {
+		widths[i] = keyW + gap + r.textWidth(it.text, st)
+		maxW = math.Max(maxW, widths[i])
+	}
+
+	pos := chart.ST_LegendPosR
+	if l.LegendPos != nil && l.LegendPos.ValAttr != chart.ST_LegendPosUnset {
+		pos = l.LegendPos.ValAttr
+	}
+	overlay := boolVal(l.Overlay, false)
+	horizontal := pos == chart.ST_LegendPosT || pos == chart.ST_LegendPosB
+
+	// place the items on rows, a single column unless the legend is at the
+	// top or at the bottom
+	type place struct{ x, y float64 }
+	places := make([]place, len(items))
+	var boxW, boxH float64
+	if horizontal {
+		avail := free.Right - free.Left - 2*pad
+		var rows [][]int
+		var row []int
+		rowW := 0.0
+		for i, iw := range widths {
+			if len(row) > 0 && rowW+iw > avail {
+				rows = append(rows, row)
+				row, rowW = nil, 0
+			}
+			row = append(row, i)
+			rowW += iw + size
+		}
+		rows = append(rows, row)
+		for ri, row := range rows {
+			rw := -size
+			for _, i := range row {
+				rw += widths[i] + size
+			}
+			boxW = math.Max(boxW, rw)
+			x := 0.0
+			for _, i := range row {
+				places[i] = place{x, float64(ri) * rowH}
+				x += widths[i] + size
+			}
+		}
+		// center each row
+		for _, row := range rows {
+			last := row[len(row)-1]
+			rw := places[last].x + widths[last]
+			for _, i := range row {
+				places[i].x += (boxW - rw) / 2
+			}
+		}
+		boxH = float64(len(rows)) * rowH
+	} else {
+		n := len(items)
+		if maxRows := int((free.Bottom - free.Top - 2*pad) / rowH); n > maxRows && maxRows > 0 {
+			n = maxRows
+		}
+		items = items[:n]
+		for i := range items {
+			places[i] = place{0, float64(i) * rowH}
+		}
+		boxW, boxH = maxW, float64(n)*rowH
+	}
+	boxW += 2 * pad
+	boxH += 2 * pad
+
+	var x, y float64
+	switch pos {
+	case chart.ST_LegendPosT:
+		x, y = (free.Left+free.Right-boxW)/2, free.Top
+	case chart.ST_LegendPosB:
+		x, y = (free.Left+free.Right-boxW)/2, free.Bottom-boxH
+	case chart.ST_LegendPosL:
+		x, y = free.Left, (free.Top+free.Bottom-boxH)/2
+	case chart.ST_LegendPosTr:
+		x, y = free.Right-boxW, free.Top
+	default:
+		x, y = free.Right-boxW, (free.Top+free.Bottom-boxH)/2
+	}
+	manual := false
+	if l.Layout != nil && l.Layout.ManualLayout != nil {
+		if ml := l.Layout.ManualLayout; ml.X != nil && ml.Y != nil {
+			x, y = ml.X.ValAttr*w, ml.Y.ValAttr*h
+			manual = true
+		}
+	}
+	r.drawShape(l.SpPr, &Rectangle{Left: x, Top: y, Right: x + boxW, Bottom: y + boxH}, "", "")
+	for i, it := range items {
+		ix, iy := x+pad+places[i].x, y+pad+places[i].y
+		cy := iy + rowH/2
+		if it.lineKey {
+			r.drawLine(ix, cy, ix+keyW, cy, it.line)
+			r.drawMarker(it.marker, it.size, ix+keyW/2, cy, it.fill, it.markerLine)
+		} else {
+			r.drawRect(ix, cy-keyW/2, keyW, keyW, it.fill, it.line)
+		}
+		r.drawString(it.text, st, ix+keyW+gap, cy-size*0.6, 0)
+	}
+	if overlay || manual {
+		return
+	}
+	space := r.pt(4)
+	switch pos {
+	case chart.ST_LegendPosT:
+		free.Top = y + boxH + space
+	case chart.ST_LegendPosB:
+		free.Bottom = y - space
+	case chart.ST_LegendPosL:
+		free.Left = x + boxW + space
+	default:
+		free.Right = x - space
+	}
+}
+
+// legendItems returns the legend entries, one per series or, for charts
+// that vary colors by point, one per category.
+func (r *chartRenderer) legendItems(l *chart.CT_Legend) []*legendItem {
+	var items []*legendItem
+	for _, g := range r.groups {
+		items = append(items, r.groupLegendItems(g)...)
+	}
+	deleted := map[int]bool{}
+	for _, e := range l.LegendEntry {
+		if e.Idx != nil && e.Choice != nil && boolVal(e.Choice.Delete, false) {
+			deleted[int(e.Idx.ValAttr)] = true
+		}
+	}
+	if len(deleted) == 0 {
+		return items
+	}
+	var kept []*legendItem
+	for i, it := range items {
+		if !deleted[i] {
+			kept = append(kept, it)
+		}
+	}
+	return kept
+}
+
+// chartText is a resolved text style.
+type chartText struct {
+	typeface     string
+	size         float64
+	color        string
+	bold, italic bool
+}
+
+// textStyle applies the default run properties of a text body to a style.
+func (r *chartRenderer) textStyle(base chartText, body *dml.CT_TextBody) chartText {
+	if body == nil {
+		return base
+	}
+	for _, p := range body.P {
+		if p.PPr != nil && p.PPr.DefRPr != nil {
+			return r.runStyle(base, p.PPr.DefRPr)
+		}
+	}
+	return base
+}
+
+func (r *chartRenderer) runStyle(base chartText, rp *dml.CT_TextCharacterProperties) chartText {
+	if rp == nil {
+		return base
+	}
+	if rp.SzAttr != nil {
+		base.size = float64(*rp.SzAttr) / 100
+	}
+	if rp.BAttr != nil {
+		base.bold = *rp.BAttr
+	}
+	if rp.IAttr != nil {
+		base.italic = *rp.IAttr
+	}
+	if rp.SolidFill != nil {
+		if c, _ := r.solidColor(rp.SolidFill); c != "" {
+			base.color = c
+		}
+	}
+	if rp.Latin != nil && rp.Latin.TypefaceAttr != "" {
+		base.typeface = rp.Latin.TypefaceAttr
+	}
+	return base
+}
+
+// font returns the registered font of a text style, or a standard font
+// when the typeface is not registered.
+func (r *chartRenderer) font(t chartText) *model.PdfFont {
+	return GetFont(t.typeface, t.bold, t.italic)
+}
+
+// textWidth returns the width of a line of text in chart coordinates.
+func (r *chartRenderer) textWidth(s string, t chartText) float64 {
+	return TextWidth(r.font(t), s, r.pt(t.size))
+}
+
+// chartLabel is a measured block of text lines.
+type chartLabel struct {
+	lines []string
+	style chartText
+	w, h  float64
+}
+
+func (r *chartRenderer) newLabel(lines []string, t chartText) *chartLabel {
+	l := &chartLabel{lines: lines, style: t}
+	for _, s := range lines {
+		l.w = math.Max(l.w, r.textWidth(s, t))
+	}
+	l.h = float64(len(lines)) * r.pt(t.size) * 1.2
+	return l
+}
+
+// drawLabel draws the lines of a label centered in its box with the top left
+// corner at x, y.
+func (r *chartRenderer) drawLabel(l *chartLabel, x, y float64) {
+	size := r.pt(l.style.size)
+	for i, s := range l.lines {
+		lw := r.textWidth(s, l.style)
+		r.drawString(s, l.style, x+(l.w-lw)/2, y+float64(i)*size*1.2-size*0.1, 0)
+	}
+}
+
+// drawLabelRotated draws the lines of a label turned counterclockwise by 90
+// degrees into the box with the top left corner at x, y. The box is l.h
+// wide and l.w high.
+func (r *chartRenderer) drawLabelRotated(l *chartLabel, x, y float64) {
+	size := r.pt(l.style.size)
+	for i, s := range l.lines {
+		lw := r.textWidth(s, l.style)
+		// the text starts at the baseline, which is size below the position
+		// and runs upwards after the rotation
+		base := x + float64(i)*size*1.2 + size*0.95
+		bottom := y + l.w - (l.w-lw)/2
+		r.drawString(s, l.style, base, bottom-size, 90)
+	}
+}
+
+// drawString draws a line of text with the top of its line box at y.
+func (r *chartRenderer) drawString(s string, t chartText, x, y, angle float64) {
+	if s == "" {
+		return
+	}
+	p := r.c.NewParagraph(s)
+	p.SetFont(r.font(t))
+	p.SetFontSize(r.pt(t.size))
+	p.SetColor(creator.ColorRGBFromHex("#" + t.color))
+	p.SetEnableWrap(false)
+	p.SetPos(x, y)
+	if angle != 0 {
+		p.SetAngle(angle)
+	}
+	if err := r.c.Draw(p); err != nil {
+		logger.Log.Debug("Cannot draw chart text: %s", err)
+	}
+}
+
+// chartPaint is a resolved fill or line. An empty color paints nothing.
+type chartPaint struct {
+	color   string
+	opacity float64
+	// width is the line width in points
+	width float64
+}
+
+var noPaint = chartPaint{}
+
+func autoPaint(color string, width float64) chartPaint {
+	return chartPaint{color: color, opacity: 1, width: width}
+}
+
+// fill resolves the fill of shape properties. Elements without a fill of
+// their own use the automatic color, which may be empty.
+func (r *chartRenderer) fill(sp *dml.CT_ShapeProperties, auto string) chartPaint {
+	if sp == nil {
+		return autoPaint(auto, 0)
+	}
+	switch {
+	case sp.NoFill != nil:
+		return noPaint
+	case sp.SolidFill != nil:
+		c, a := r.solidColor(sp.SolidFill)
+		return chartPaint{color: c, opacity: a}
+	case sp.GradFill != nil:
+		// gradients are flattened to the color of the first stop
+		if gs := sp.GradFill.GsLst; gs != nil && len(gs.Gs) > 0 {
+			s := gs.Gs[0]
+			c, a := r.solidColor(&dml.CT_SolidColorFillProperties{SrgbClr: s.SrgbClr, SchemeClr: s.SchemeClr, SysClr: s.SysClr, PrstClr: s.PrstClr})
+			return chartPaint{color: c, opacity: a}
+		}
+	case sp.PattFill != nil:
+		if fg := sp.PattFill.FgClr; fg != nil {
+			c, a := r.solidColor(&dml.CT_SolidColorFillProperties{SrgbClr: fg.SrgbClr, SchemeClr: fg.SchemeClr, SysClr: fg.SysClr, PrstClr: fg.PrstClr})
+			return chartPaint{color: c, opacity: a}
+		}
+	}
+	return autoPaint(auto, 0)
+}
+
+// line resolves the outline of shape properties with width in points as
+// the default width.
+func (r *chartRenderer) line(sp *dml.CT_ShapeProperties, auto string, width float64) chartPaint {
+	if sp == nil || sp.Ln == nil {
+		return autoPaint(auto, width)
+	}
+	ln := sp.Ln
+	if ln.WAttr != nil {
+		width = measurement.FromEMU(int64(*ln.WAttr))
+	}
+	switch {
+	case ln.NoFill != nil:
+		return noPaint
+	case ln.SolidFill != nil:
+		c, a := r.solidColor(ln.SolidFill)
+		return chartPaint{color: c, opacity: a, width: width}
+	case ln.GradFill != nil:
+		if gs := ln.GradFill.GsLst; gs != nil && len(gs.Gs) > 0 {
+			s := gs.Gs[0]
+			c, a := r.solidColor(&dml.CT_SolidColorFillProperties{SrgbClr: s.SrgbClr, SchemeClr: s.SchemeClr, SysClr: s.SysClr, PrstClr: s.PrstClr})
+			return chartPaint{color: c, opacity: a, width: width}
+		}
+	}
+	return autoPaint(auto, width)
+}
+
+// solidColor returns the color of a solid fill as RRGGBB and its opacity.
+func (r *chartRenderer) solidColor(f *dml.CT_SolidColorFillProperties) (string, float64) {
+	var c string
+	var tr []*dml.EG_ColorTransform
+	switch {
+	case f.SrgbClr != nil:
+		c, tr = f.SrgbClr.ValAttr, f.SrgbClr.EG_ColorTransform
+	case f.SchemeClr != nil:
+		c, tr = r.schemeColor(f.SchemeClr.ValAttr), f.SchemeClr.EG_ColorTransform
+	case f.SysClr != nil:
+		c, tr = systemColor(f.SysClr), f.SysClr.EG_ColorTransform
+	case f.PrstClr != nil:
+		c, tr = presetColors[f.PrstClr.ValAttr.String()], f.PrstClr.EG_ColorTransform
+		if c == "" {
+			c = "000000"
+		}
+	default:
+		return "", 1
+	}
+	return AdjustColor(c, tr), GetOpacityFromColorTransform(tr)
+}
+
+// officeColors is the color scheme of the default Office theme.
+var officeColors = map[dml.ST_SchemeColorVal]string{
+	dml.ST_SchemeColorValDk1:      "000000",
+	dml.ST_SchemeColorValLt1:      "FFFFFF",
+	dml.ST_SchemeColorValDk2:      "44546A",
+	dml.ST_SchemeColorValLt2:      "E7E6E6",
+	dml.ST_SchemeColorValAccent1:  "4472C4",
+	dml.ST_SchemeColorValAccent2:  "ED7D31",
+	dml.ST_SchemeColorValAccent3:  "A5A5A5",
+	dml.ST_SchemeColorValAccent4:  "FFC000",
+	dml.ST_SchemeColorValAccent5:  "5B9BD5",
+	dml.ST_SchemeColorValAccent6:  "70AD47",
+	dml.ST_SchemeColorValHlink:    "0563C1",
+	dml.ST_SchemeColorValFolHlink: "954F72",
+}
+
+// schemeColor returns a color of the theme, or of the default Office theme
+// when there is no theme.
+func (r *chartRenderer) schemeColor(v dml.ST_SchemeColorVal) string {
+	switch v {
+	case dml.ST_SchemeColorValTx1, dml.ST_SchemeColorValPhClr:
+		v = dml.ST_SchemeColorValDk1
+	case dml.ST_SchemeColorValBg1:
+		v = dml.ST_SchemeColorValLt1
+	case dml.ST_SchemeColorValTx2:
+		v = dml.ST_SchemeColorValDk2
+	case dml.ST_SchemeColorValBg2:
+		v = dml.ST_SchemeColorValLt2
+	}
+	if r.theme != nil && r.theme.ThemeElements != nil && r.theme.ThemeElements.ClrScheme != nil {
+		cs := r.theme.ThemeElements.ClrScheme
+		var c *dml.CT_Color
+		switch v {
+		case dml.ST_SchemeColorValDk1:
+			c = cs.Dk1
+		case dml.ST_SchemeColorValLt1:
+			c = cs.Lt1
+		case dml.ST_SchemeColorValDk2:
+			c = cs.Dk2
+		case dml.ST_SchemeColorValLt2:
+			c = cs.Lt2
+		case dml.ST_SchemeColorValAccent1:
+			c = cs.Accent1
+		case dml.ST_SchemeColorValAccent2:
+			c = cs.Accent2
+		case dml.ST_SchemeColorValAccent3:
+			c = cs.Accent3
+		case dml.ST_SchemeColorValAccent4:
+			c = cs.Accent4
+		case dml.ST_SchemeColorValAccent5:
+			c = cs.Accent5
+		case dml.ST_SchemeColorValAccent6:
+			c = cs.Accent6
+		case dml.ST_SchemeColorValHlink:
+			c = cs.Hlink
+		case dml.ST_SchemeColorValFolHlink:
+			c = cs.FolHlink
+		}
+		if c != nil {
+			switch {
+			case c.SrgbClr != nil:
+				return c.SrgbClr.ValAttr
+			case c.SysClr != nil:
+				return systemColor(c.SysClr)
+			}
+		}
+	}
+	return officeColors[v]
+}
+
+func systemColor(c *dml.CT_SystemColor) string {
+	if c.LastClrAttr != nil {
+		return *c.LastClrAttr
+	}
+	if c.ValAttr == dml.ST_SystemColorValWindow {
+		return "FFFFFF"
+	}
+	return "000000"
+}
+
+// presetColors are the values of the common preset colors.
+var presetColors = map[string]string{
+	"black":     "000000",
+	"white":     "FFFFFF",
+	"red":       "FF0000",
+	"green":     "008000",
+	"lime":      "00FF00",
+	"blue":      "0000FF",
+	"yellow":    "FFFF00",
+	"cyan":      "00FFFF",
+	"magenta":   "FF00FF",
+	"gray":      "808080",
+	"lightGray": "D3D3D3",
+	"darkGray":  "A9A9A9",
+	"orange":    "FFA500",
+	"purple":    "800080",
+	"brown":     "A52A2A",
+	"navy":      "000080",
+}
+
+// autoColor returns the automatic color of the series or data point with the
+// given index: the accent colors of the theme, darkened and lightened on
+// every repetition as Office does.
+func (r *chartRenderer) autoColor(i int) string {
+	accents := [...]dml.ST_SchemeColorVal{
+		dml.ST_SchemeColorValAccent1, dml.ST_SchemeColorValAccent2, dml.ST_SchemeColorValAccent3,
+		dml.ST_SchemeColorValAccent4, dml.ST_SchemeColorValAccent5, dml.ST_SchemeColorValAccent6,
+	}
+	if i < 0 {
+		i = 0
+	}
+	c := r.schemeColor(accents[i%6])
+	switch (i / 6) % 6 {
+	case 1:
+		c = AdjustColorByLumMod(c, 0.6)
+	case 2:
+		c = AdjustColorByLumOff(AdjustColorByLumMod(c, 0.8), 0.2)
+	case 3:
+		c = AdjustColorByLumMod(c, 0.8)
+	case 4:
+		c = AdjustColorByLumOff(AdjustColorByLumMod(c, 0.6), 0.4)
+	case 5:
+		c = AdjustColorByLumMod(c, 0.5)
+	}
+	return c
+}
+
+func pdfColor(hex string) creator.Color {
+	return creator.ColorRGBFromHex("#" + hex)
+}
+
+// drawShape fills and outlines a rectangle with shape properties.
+func (r *chartRenderer) drawShape(sp *dml.CT_ShapeProperties, rect *Rectangle, fill, line string) {
+	r.drawRect(rect.Left, rect.Top, rect.Right-rect.Left, rect.Bottom-rect.Top, r.fill(sp, fill), r.line(sp, line, 0.75))
+}
+
+func (r *chartRenderer) drawRect(x, y, w, h float64, fill, line chartPaint) {
+	if w < 0 {
+		x, w = x+w, -w
+	}
+	if h < 0 {
+		y, h = y+h, -h
+	}
+	if fill.color == "" && line.color == "" {
+		return
+	}
+	rc := r.c.NewRectangle(x, y, w, h)
+	rc.SetBorderWidth(0)
+	if fill.color != "" {
+		rc.SetFillColor(pdfColor(fill.color))
+		if fill.opacity < 1 {
+			rc.SetFillOpacity(fill.opacity)
+		}
+	}
+	if line.color != "" && line.width > 0 {
+		rc.SetBorderColor(pdfColor(line.color))
+		rc.SetBorderWidth(r.pt(line.width))
+		if line.opacity < 1 {
+			rc.SetBorderOpacity(line.opacity)
+		}
+	}
+	r.draw(rc)
+}
+
+// drawPolygon fills and outlines a closed polygon.
+func (r *chartRenderer) drawPolygon(points []draw.Point, fill, line chartPaint) {
+	if len(points) < 3 || (fill.color == "" && line.color == "") {
+		return
+	}
+	// the creator flips the points in place, so they are copied
+	pts := append([]draw.Point(nil), points...)
+	p := r.c.NewPolygon([][]draw.Point{pts})
+	if fill.color != "" {
+		p.SetFillColor(pdfColor(fill.color))
+		if fill.opacity < 1 {
+			p.SetFillOpacity(fill.opacity)
+		}
+	}
+	if line.color != "" && line.width > 0 {
+		p.SetBorderColor(pdfColor(line.color))
+		p.SetBorderWidth(r.pt(line.width))
+	}
+	r.draw(p)
+}
+
+// drawPolyline draws connected line segments.
+func (r *chartRenderer) drawPolyline(points []draw.Point, line chartPaint) {
+	if len(points) < 2 || line.color == "" || line.width <= 0 {
+		return
+	}
+	pts := append([]draw.Point(nil), points...)
+	p := r.c.NewPolyline(pts)
+	p.SetLineColor(pdfColor(line.color))
+	p.SetLineWidth(r.pt(line.width))
+	if line.opacity < 1 {
+		p.SetLineOpacity(line.opacity)
+	}
+	r.draw(p)
+}
+
+// drawCurve draws a smooth line through the points with Catmull-Rom
+// splines.
+func (r *chartRenderer) drawCurve(points []draw.Point, line chartPaint) {
+	if len(points) < 3 {
+		r.drawPolyline(points, line)
+		return
+	}
+	if line.color == "" || line.width <= 0 {
+		return
+	}
+	at := func(i int) draw.Point {
+		if i < 0 {
+			i = 0
+		}
+		if i >= len(points) {
+			i = len(points) - 1
+		}
+		return points[i]
+	}
+	var curves []draw.CubicBezierCurve
+	for i := 0; i+1 < len(points); i++ {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		c1x, c1y := p1.X+(p2.X-p0.X)/6, p1.Y+(p2.Y-p0.Y)/6
+		c2x, c2y := p2.X-(p3.X-p1.X)/6, p2.Y-(p3.Y-p1.Y)/6
+		curves = append(curves, draw.NewCubicBezierCurve(p1.X, p1.Y, c1x, c1y, c2x, c2y, p2.X, p2.Y))
+	}
+	c := r.c.NewPolyBezierCurve(curves)
+	c.SetBorderColor(pdfColor(line.color))
+	c.SetBorderWidth(r.pt(line.width))
+	if line.opacity < 1 {
+		c.SetBorderOpacity(line.opacity)
+	}
+	r.draw(c)
+}
+
+func (r *chartRenderer) drawLine(x0, y0, x1, y1 float64, line chartPaint) {
+	if line.color == "" || line.width <= 0 {
+		return
+	}
+	l := r.c.NewLine(x0, y0, x1, y1)
+	l.SetLineWidth(r.pt(line.width))
+	l.SetColor(pdfColor(line.color))
+	r.draw(l)
+}
+
+func (r *chartRenderer) draw(d creator.Drawable) {
+	if err := r.c.Draw(d); err != nil {
+		logger.Log.Debug("Cannot draw a chart element: %s", err)
+	}
+}
+
+// circlePoints approximates an ellipse arc from angle a0 to a1, in degrees
+// clockwise from the top, with a polygon.
+func circlePoints(cx, cy, rx, ry, a0, a1 float64) []draw.Point {
+	steps := int(math.Ceil(math.Abs(a1-a0) / 3))
+	if steps < 2 {
+		steps = 2
+	}
+	pts := make([]draw.Point, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		a := (a0 + (a1-a0)*float64(i)/float64(steps)) * math.Pi / 180
+		pts = append(pts, draw.Point{X: cx + rx*math.Sin(a), Y: cy - ry*math.Cos(a)})
+	}
+	return pts
+}
+
+// autoMarkers is the sequence of automatic marker symbols of the series.
+var autoMarkers = []chart.ST_MarkerStyle{
+	chart.ST_MarkerStyleDiamond, chart.ST_MarkerStyleSquare, chart.ST_MarkerStyleTriangle,
+	chart.ST_MarkerStyleX, chart.ST_MarkerStyleStar, chart.ST_MarkerStyleCircle, chart.ST_MarkerStylePlus,
+}
+
+// drawMarker draws a marker symbol of size points centered at x, y.
+func (r *chartRenderer) drawMarker(symbol chart.ST_MarkerStyle, size, x, y float64, fill, line chartPaint) {
+	if size <= 0 {
+		return
+	}
+	h := r.pt(size) / 2
+	if line.width <= 0 {
+		line.width = 0.75
+	}
+	switch symbol {
+	case chart.ST_MarkerStyleSquare:
+		r.drawRect(x-h, y-h, 2*h, 2*h, fill, line)
+	case chart.ST_MarkerStyleDiamond:
+		r.drawPolygon([]draw.Point{{X: x, Y: y - h}, {X: x + h, Y: y}, {X: x, Y: y + h}, {X: x - h, Y: y}}, fill, line)
+	case chart.ST_MarkerStyleTriangle:
+		r.drawPolygon([]draw.Point{{X: x, Y: y - h}, {X: x + h, Y: y + h}, {X: x - h, Y: y + h}}, fill, line)
+	case chart.ST_MarkerStyleCircle:
+		r.drawPolygon(circlePoints(x, y, h, h, 0, 360), fill, line)
+	case chart.ST_MarkerStyleDot:
+		r.drawPolygon(circlePoints(x, y, h/2, h/2, 0, 360), fill, line)
+	case chart.ST_MarkerStyleDash:
+		r.drawRect(x-h, y-h/5, 2*h, 2*h/5, fill, line)
+	case chart.ST_MarkerStyleX, chart.ST_MarkerStyleStar, chart.ST_MarkerStylePlus:
+		if line.color == "" {
+			line = chartPaint{color: fill.color, opacity: fill.opacity, width: line.width}
+		}
+		if symbol != chart.ST_MarkerStylePlus {
+			r.drawLine(x-h, y-h, x+h, y+h, line)
+			r.drawLine(x-h, y+h, x+h, y-h, line)
+		}
+		if symbol != chart.ST_MarkerStyleX {
+			r.drawLine(x, y-h, x, y+h, line)
+		}
+		if symbol == chart.ST_MarkerStylePlus {
+			r.drawLine(x-h, y, x+h, y, line)
+		}
+	}
+}
+
+func boolVal(b *chart.CT_Boolean, def bool) bool {
+	if b == nil {
+		return def
+	}
+	if b.ValAttr == nil {
+		// the schema default of a present element is true
+		return true
+	}
+	return *b.ValAttr
+}
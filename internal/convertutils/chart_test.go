@@ -0,0 +1,315 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/unidoc/unioffice"
+	unichart "github.com/unidoc/unioffice/chart"
+	"github.com/unidoc/unioffice/schema/soo/dml/chart"
+)
+
+func TestNiceUnit(t *testing.T) {
+	td := []struct {
+		Inp float64
+		Exp float64
+	}{
+		{1, 1},
+		{1.2, 2},
+		{3, 5},
+		{7, 10},
+		{0.03, 0.05},
+		{250, 500},
+		{0, 1},
+		{-2, 1},
+		{math.NaN(), 1},
+	}
+	for _, tc := range td {
+		if got := niceUnit(tc.Inp); math.Abs(got-tc.Exp) > 1e-12 {
+			t.Errorf("expected the unit of %v to be %v, got %v", tc.Inp, tc.Exp, got)
+		}
+	}
+}
+
+func TestAxisTicks(t *testing.T) {
+	td := []struct {
+		Name  string
+		Axis  chartAxis
+		Minor bool
+		Exp   string
+	}{
+		{"major", chartAxis{lo: 0, hi: 1, unit: 0.2}, false, "[0 0.2 0.4 0.6 0.8 1]"},
+		{"negative", chartAxis{lo: -10, hi: 10, unit: 5}, false, "[-10 -5 0 5 10]"},
+		{"minor", chartAxis{lo: 0, hi: 10, unit: 5}, true, "[0 1 2 3 4 5 6 7 8 9 10]"},
+		{"minor unit", chartAxis{lo: 0, hi: 10, unit: 5, minorUnit: 2.5}, true, "[0 2.5 5 7.5 10]"},
+		{"too many", chartAxis{lo: 0, hi: 1e6, unit: 1}, false, "[]"},
+		{"log", chartAxis{lo: 1, hi: 1000, logBase: 10}, false, "[1 10 100 1000]"},
+		{"log minor", chartAxis{lo: 1, hi: 8, logBase: 2}, true, "[]"},
+		{"log minor base 10", chartAxis{lo: 1, hi: 10, logBase: 10}, true, "[2 3 4 5 6 7 8 9]"},
+	}
+	for _, tc := range td {
+		if got := fmt.Sprint(tc.Axis.ticks(tc.Minor)); got != tc.Exp {
+			t.Errorf("%s: expected ticks %s, got %s", tc.Name, tc.Exp, got)
+		}
+	}
+}
+
+func TestAxisCoord(t *testing.T) {
+	val := chartAxis{lo: 0, hi: 100, start: 200, end: 0}
+	log := chartAxis{lo: 1, hi: 100, logBase: 10, start: 0, end: 100}
+	cat := chartAxis{cats: []string{"a", "b", "c", "d"}, start: 0, end: 100}
+	mid := chartAxis{cats: []string{"a", "b", "c", "d", "e"}, start: 0, end: 100, midCat: true}
+	td := []struct {
+		Name string
+		Got  float64
+		Exp  float64
+	}{
+		{"value", val.coord(25), 150},
+		{"log", log.coord(10), 50},
+		{"log of zero", log.coord(0), 0},
+		{"category", cat.catCoord(1), 37.5},
+		{"between categories", mid.catCoord(1), 25},
+		{"slot", cat.slot(), 25},
+	}
+	for _, tc := range td {
+		if math.Abs(tc.Got-tc.Exp) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tc.Name, tc.Exp, tc.Got)
+		}
+	}
+}
+
+func TestNumberCache(t *testing.T) {
+	d := &chart.CT_NumData{
+		PtCount: &chart.CT_UnsignedInt{ValAttr: 3},
+		Pt: []*chart.CT_NumVal{
+			{IdxAttr: 0, V: "1.5"},
+			{IdxAttr: 3, V: "-2", FormatCodeAttr: unioffice.String("0.00")},
+			{IdxAttr: 2, V: "x"},
+		},
+	}
+	vals, code := numberCache(d)
+	if got := fmt.Sprint(vals); got != "[1.5 NaN NaN -2]" {
+		t.Errorf("expected the values with the missing points as NaN, got %s", got)
+	}
+	if code != "0.00" {
+		t.Errorf("expected the format of a point, got %q", code)
+	}
+	d.FormatCode = unioffice.String("General")
+	if _, code := numberCache(d); code != "" {
+		t.Errorf("expected General to be the default format, got %q", code)
+	}
+	d.FormatCode = nil
+
+	labels, nums, code := axisData(&chart.CT_AxDataSource{Choice: &chart.CT_AxDataSourceChoice{NumLit: d}})
+	if fmt.Sprintf("%q", labels) != `["1.50" "" "" "-2.00"]` || len(nums) != 4 || code != "0.00" {
+		t.Errorf("expected formatted numeric categories, got %q", labels)
+	}
+	strs := &chart.CT_StrData{PtCount: &chart.CT_UnsignedInt{ValAttr: 2}, Pt: []*chart.CT_StrVal{{IdxAttr: 1, V: "b"}}}
+	labels, nums, _ = axisData(&chart.CT_AxDataSource{Choice: &chart.CT_AxDataSourceChoice{StrLit: strs}})
+	if fmt.Sprintf("%q", labels) != `["" "b"]` || nums != nil {
+		t.Errorf("expected text categories, got %q", labels)
+	}
+}
+
+func TestStacker(t *testing.T) {
+	group := func(stacked, percent bool) *chartGroup {
+		return &chartGroup{stacked: stacked, percent: percent, series: []*chartSeries{
+			{vals: []float64{1, 2, math.NaN()}},
+			{vals: []float64{3, -2, 4}},
+		}}
+	}
+	td := []struct {
+		Name           string
+		Group          *chartGroup
+		Lo, Hi         float64
+		Stacks, Totals string
+	}{
+		{"clustered", group(false, false), -2, 4, "[0 1 0 2 0 NaN 0 3 0 -2 0 4]", ""},
+		{"stacked", group(true, false), -2, 4, "[0 1 0 2 0 0 1 4 0 -2 0 4]", "[4 4 4]"},
+		{"percent", group(true, true), -0.5, 1, "[0 0.25 0 0.5 0 0 0.25 1 0 -0.5 0 1]", "[4 4 4]"},
+	}
+	for _, tc := range td {
+		lo, hi, ok := tc.Group.valueRange()
+		if !ok || lo != tc.Lo || hi != tc.Hi {
+			t.Errorf("%s: expected the range %v to %v, got %v to %v", tc.Name, tc.Lo, tc.Hi, lo, hi)
+		}
+		st := newStacker(tc.Group)
+		stacks := []float64{}
+		for _, s := range tc.Group.series {
+			for i, v := range s.vals {
+				from, to := st.add(i, v)
+				stacks = append(stacks, from, to)
+			}
+		}
+		if got := fmt.Sprint(stacks); got != tc.Stacks {
+			t.Errorf("%s: expected the stacks %s, got %s", tc.Name, tc.Stacks, got)
+		}
+		if tc.Totals != "" && fmt.Sprint(st.total) != tc.Totals {
+			t.Errorf("%s: expected the totals %s, got %v", tc.Name, tc.Totals, st.total)
+		}
+	}
+	if _, _, ok := (&chartGroup{}).valueRange(); ok {
+		t.Errorf("expected no range for a group without values")
+	}
+}
+
+// seriesAdder is a series of any type of chart.
+type seriesAdder interface {
+	Values() unichart.NumberDataSource
+	CategoryAxis() unichart.CategoryAxisDataSource
+}
+
+func TestChartTypes(t *testing.T) {
+	axes := func(c unichart.Chart, add func(unichart.Axis), series bool) {
+		cat := c.AddCategoryAxis()
+		val := c.AddValueAxis()
+		cat.SetCrosses(val)
+		val.SetCrosses(cat)
+		add(cat)
+		add(val)
+		if series {
+			ser := c.AddSeriesAxis()
+			ser.SetCrosses(val)
+			add(ser)
+		}
+	}
+	td := []struct {
+		Name   string
+		Add    func(c unichart.Chart) func() seriesAdder
+		Kind   chartKind
+		ThreeD bool
+	}{
+		{"bar", func(c unichart.Chart) func() seriesAdder {
+			b := c.AddBarChart()
+			b.InitializeDefaults()
+			axes(c, b.AddAxis, false)
+			return func() seriesAdder { return b.AddSeries() }
+		}, chartBar, false},
+		{"bar 3D", func(c unichart.Chart) func() seriesAdder {
+			b := c.AddBar3DChart()
+			b.InitializeDefaults()
+			axes(c, b.AddAxis, false)
+			return func() seriesAdder { return b.AddSeries() }
+		}, chartBar, true},
+		{"line", func(c unichart.Chart) func() seriesAdder {
+			l := c.AddLineChart()
+			axes(c, l.AddAxis, false)
+			return func() seriesAdder { return l.AddSeries() }
+		}, chartLine, false},
+		{"line 3D", func(c unichart.Chart) func() seriesAdder {
+			l := c.AddLine3DChart()
+			axes(c, l.AddAxis, true)
+			return func() seriesAdder { return l.AddSeries() }
+		}, chartLine, true},
+		{"pie", func(c unichart.Chart) func() seriesAdder {
+			p := c.AddPieChart()
+			p.InitializeDefaults()
+			return func() seriesAdder { return p.AddSeries() }
+		}, chartPie, false},
+		{"pie 3D", func(c unichart.Chart) func() seriesAdder {
+			p := c.AddPie3DChart()
+			p.InitializeDefaults()
+			return func() seriesAdder { return p.AddSeries() }
+		}, chartPie, true},
+		{"doughnut", func(c unichart.Chart) func() seriesAdder {
+			d := c.AddDoughnutChart()
+			d.InitializeDefaults()
+			d.SetHoleSize(40)
+			return func() seriesAdder { return d.AddSeries() }
+		}, chartDoughnut, false},
+		{"pie of pie", func(c unichart.Chart) func() seriesAdder {
+			p := c.AddPieOfPieChart()
+			p.InitializeDefaults()
+			return func() seriesAdder { return p.AddSeries() }
+		}, chartOfPie, false},
+		{"area", func(c unichart.Chart) func() seriesAdder {
+			a := c.AddAreaChart()
+			a.InitializeDefaults()
+			axes(c, a.AddAxis, false)
+			return func() seriesAdder { return a.AddSeries() }
+		}, chartArea, false},
+		{"area 3D", func(c unichart.Chart) func() seriesAdder {
+			a := c.AddArea3DChart()
+			a.InitializeDefaults()
+			axes(c, a.AddAxis, true)
+			return func() seriesAdder { return a.AddSeries() }
+		}, chartArea, true},
+		{"scatter", func(c unichart.Chart) func() seriesAdder {
+			s := c.AddScatterChart()
+			s.InitializeDefaults()
+			axes(c, s.AddAxis, false)
+			return func() seriesAdder { return s.AddSeries() }
+		}, chartScatter, false},
+		{"bubble", func(c unichart.Chart) func() seriesAdder {
+			b := c.AddBubbleChart()
+			b.InitializeDefaults()
+			axes(c, b.AddAxis, false)
+			return func() seriesAdder {
+				s := b.AddSeries()
+				s.BubbleSizes().CreateEmptyNumberCache()
+				s.BubbleSizes().SetValues([]float64{1, 2, 3})
+				return s
+			}
+		}, chartBubble, false},
+		{"radar", func(c unichart.Chart) func() seriesAdder {
+			r := c.AddRadarChart()
+			r.InitializeDefaults()
+			axes(c, r.AddAxis, false)
+			return func() seriesAdder { return r.AddSeries() }
+		}, chartRadar, false},
+		{"stock", func(c unichart.Chart) func() seriesAdder {
+			s := c.AddStockChart()
+			s.InitializeDefaults()
+			axes(c, s.AddAxis, false)
+			return func() seriesAdder { return s.AddSeries() }
+		}, chartStock, false},
+		{"surface", func(c unichart.Chart) func() seriesAdder {
+			s := c.AddSurfaceChart()
+			s.InitializeDefaults()
+			axes(c, s.AddAxis, true)
+			return func() seriesAdder { return s.AddSeries() }
+		}, chartSurface, false},
+		{"surface 3D", func(c unichart.Chart) func() seriesAdder {
+			s := c.AddSurface3DChart()
+			s.InitializeDefaults()
+			axes(c, s.AddAxis, true)
+			return func() seriesAdder { return s.AddSeries() }
+		}, chartSurface, true},
+	}
+	for _, tc := range td {
+		c := unichart.MakeChart(chart.NewChartSpace())
+		addSeries := tc.Add(c)
+		for i := 0; i < 2; i++ {
+			s := addSeries()
+			s.CategoryAxis().SetValues([]string{"1", "2", "3"})
+			s.Values().CreateEmptyNumberCache()
+			s.Values().SetValues([]float64{float64(i + 1), 5, -2})
+		}
+
+		if _, err := makeChartCreator(c.X(), 400, 300, nil, false); err != nil {
+			t.Errorf("%s: error drawing the chart: %s", tc.Name, err)
+			continue
+		}
+		r := &chartRenderer{c: MakeTempCreator(401, 301), scale: 1}
+		r.readPlotArea(c.X().Chart.PlotArea)
+		if len(r.groups) != 1 {
+			t.Errorf("%s: expected a single chart, got %d", tc.Name, len(r.groups))
+			continue
+		}
+		g := r.groups[0]
+		if g.kind != tc.Kind || g.threeD != tc.ThreeD {
+			t.Errorf("%s: expected the kind %d and 3D %v, got %d and %v", tc.Name, tc.Kind, tc.ThreeD, g.kind, g.threeD)
+		}
+		if len(g.series) != 2 || fmt.Sprint(g.series[1].vals) != "[2 5 -2]" {
+			t.Errorf("%s: expected the values of both series", tc.Name)
+		}
+	}
+}
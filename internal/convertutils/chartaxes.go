@@ -0,0 +1,848 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/dml/chart"
+)
+
+// chartAxis is an axis of the plot area. Category, date and series axes
+// place their values in slots, value axes scale them.
+type chartAxis struct {
+	id, crossID uint32
+	category    bool
+	deleted     bool
+	reversed    bool
+	pos         chart.ST_AxPos
+
+	min, max             *float64
+	logBase              float64
+	majorUnit, minorUnit float64
+	dispUnit             float64
+	numFmt               *chart.CT_NumFmt
+	majorGrid, minorGrid *chart.CT_ChartLines
+	majorTick            chart.ST_TickMark
+	lblPos               chart.ST_TickLblPos
+	lblSkip              int
+	spPr                 *dml.CT_ShapeProperties
+	txPr                 *dml.CT_TextBody
+	title                *chart.CT_Title
+	crosses              chart.ST_Crosses
+	crossesAt            *float64
+	midCat               bool
+
+	// the following fields are set by the layout
+	vertical   bool
+	cross      *chartAxis
+	cats       []string
+	format     string
+	percent    bool
+	lo, hi     float64
+	unit       float64
+	start, end float64
+	// line is the coordinate of the axis line across the axis
+	line float64
+	// labelSide is true for labels right of or below the axis line
+	labelSide bool
+	text      chartText
+	bound     bool
+}
+
+func (r *chartRenderer) readAxes(c *chart.CT_PlotAreaChoice1) {
+	if c == nil {
+		return
+	}
+	for _, ax := range c.CatAx {
+		a := &chartAxis{category: true}
+		a.read(ax.AxId, ax.Scaling, ax.Delete, ax.AxPos, ax.MajorGridlines, ax.MinorGridlines, ax.Title, ax.NumFmt, ax.MajorTickMark, ax.TickLblPos, ax.SpPr, ax.TxPr, ax.CrossAx, ax.Choice)
+		if ax.TickLblSkip != nil {
+			a.lblSkip = int(ax.TickLblSkip.ValAttr)
+		}
+		r.axes = append(r.axes, a)
+	}
+	for _, ax := range c.DateAx {
+		a := &chartAxis{category: true}
+		a.read(ax.AxId, ax.Scaling, ax.Delete, ax.AxPos, ax.MajorGridlines, ax.MinorGridlines, ax.Title, ax.NumFmt, ax.MajorTickMark, ax.TickLblPos, ax.SpPr, ax.TxPr, ax.CrossAx, ax.Choice)
+		r.axes = append(r.axes, a)
+	}
+	for _, ax := range c.SerAx {
+		a := &chartAxis{category: true}
+		a.read(ax.AxId, ax.Scaling, ax.Delete, ax.AxPos, ax.MajorGridlines, ax.MinorGridlines, ax.Title, ax.NumFmt, ax.MajorTickMark, ax.TickLblPos, ax.SpPr, ax.TxPr, ax.CrossAx, ax.Choice)
+		if ax.TickLblSkip != nil {
+			a.lblSkip = int(ax.TickLblSkip.ValAttr)
+		}
+		r.axes = append(r.axes, a)
+	}
+	for _, ax := range c.ValAx {
+		a := &chartAxis{}
+		a.read(ax.AxId, ax.Scaling, ax.Delete, ax.AxPos, ax.MajorGridlines, ax.MinorGridlines, ax.Title, ax.NumFmt, ax.MajorTickMark, ax.TickLblPos, ax.SpPr, ax.TxPr, ax.CrossAx, ax.Choice)
+		a.midCat = ax.CrossBetween != nil && ax.CrossBetween.ValAttr == chart.ST_CrossBetweenMidCat
+		if ax.MajorUnit != nil {
+			a.majorUnit = ax.MajorUnit.ValAttr
+		}
+		if ax.MinorUnit != nil {
+			a.minorUnit = ax.MinorUnit.ValAttr
+		}
+		if du := ax.DispUnits; du != nil && du.Choice != nil {
+			if du.Choice.CustUnit != nil {
+				a.dispUnit = du.Choice.CustUnit.ValAttr
+			} else if du.Choice.BuiltInUnit != nil {
+				a.dispUnit = builtInUnits[du.Choice.BuiltInUnit.ValAttr]
+			}
+		}
+		r.axes = append(r.axes, a)
+	}
+}
+
+var builtInUnits = map[chart.ST_BuiltInUnit]float64{
+	chart.ST_BuiltInUnitHundreds:         1e2,
+	chart.ST_BuiltInUnitThousands:        1e3,
+	chart.ST_BuiltInUnitTenThousands:     1e4,
+	chart.ST_BuiltInUnitHundredThousands: 1e5,
+	chart.ST_BuiltInUnitMillions:         1e6,
+	chart.ST_BuiltInUnitTenMillions:      1e7,
+	chart.ST_BuiltInUnitHundredMillions:  1e8,
+	chart.ST_BuiltInUnitBillions:         1e9,
+	chart.ST_BuiltInUnitTrillions:        1e12,
+}
+
+func (a *chartAxis) read(id *chart.CT_UnsignedInt, sc *chart.CT_Scaling, del *chart.CT_Boolean, pos *chart.CT_AxPos,
+	major, minor *chart.CT_ChartLines, title *chart.CT_Title, numFmt *chart.CT_NumFmt, tick *chart.CT_TickMark,
+	lblPos *chart.CT_TickLblPos, spPr *dml.CT_ShapeProperties, txPr *dml.CT_TextBody, cross *chart.CT_UnsignedInt,
+	shared *chart.EG_AxSharedChoice) {
+	if id != nil {
+		a.id = id.ValAttr
+	}
+	if sc != nil {
+		a.reversed = sc.Orientation != nil && sc.Orientation.ValAttr == chart.ST_OrientationMaxMin
+		if sc.Min != nil {
+			v := sc.Min.ValAttr
+			a.min = &v
+		}
+		if sc.Max != nil {
+			v := sc.Max.ValAttr
+			a.max = &v
+		}
+		if sc.LogBase != nil && sc.LogBase.ValAttr > 1 {
+			a.logBase = sc.LogBase.ValAttr
+		}
+	}
+	a.deleted = boolVal(del, false)
+	if pos != nil {
+		a.pos = pos.ValAttr
+	}
+	a.majorGrid, a.minorGrid = major, minor
+	a.title = title
+	a.numFmt = numFmt
+	a.majorTick = chart.ST_TickMarkOut
+	if tick != nil && tick.ValAttr != chart.ST_TickMarkUnset {
+		a.majorTick = tick.ValAttr
+	}
+	if lblPos != nil {
+		a.lblPos = lblPos.ValAttr
+	}
+	a.spPr, a.txPr = spPr, txPr
+	if cross != nil {
+		a.crossID = cross.ValAttr
+	}
+	if shared != nil {
+		if shared.Crosses != nil {
+			a.crosses = shared.Crosses.ValAttr
+		} else if shared.CrossesAt != nil {
+			v := shared.CrossesAt.ValAttr
+			a.crossesAt = &v
+		}
+	}
+}
+
+func (r *chartRenderer) axis(id uint32) *chartAxis {
+	for _, a := range r.axes {
+		if a.id == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// bindAxes finds the axes of a chart group. Missing axes are replaced by
+// hidden ones so that the values can be placed anyway.
+func (r *chartRenderer) bindAxes(g *chartGroup) {
+	var first, second *chartAxis
+	if len(g.axIDs) > 0 {
+		first = r.axis(g.axIDs[0])
+	}
+	if len(g.axIDs) > 1 {
+		second = r.axis(g.axIDs[1])
+	}
+	xy := g.kind == chartScatter || g.kind == chartBubble
+	if first == nil {
+		first = &chartAxis{category: !xy, deleted: true, pos: chart.ST_AxPosB}
+		r.axes = append(r.axes, first)
+	}
+	if second == nil {
+		second = &chartAxis{deleted: true, pos: chart.ST_AxPosL}
+		r.axes = append(r.axes, second)
+	}
+	if xy {
+		first.category = false
+	} else if !first.category && second.category {
+		first, second = second, first
+	}
+	first.cross, second.cross = second, first
+	first.bound, second.bound = true, true
+	g.cat, g.val = first, second
+	if g.kind == chartSurface && len(g.axIDs) > 2 {
+		// the series axis of a surface chart is shown as the vertical
+		// axis of the flattened chart, the value axis is left out
+		if ser := r.axis(g.axIDs[2]); ser != nil {
+			ser.cross, g.cat.cross = g.cat, ser
+			ser.bound = true
+			g.val.deleted = true
+			g.val.majorGrid, g.val.minorGrid = nil, nil
+			g.ser = ser
+		}
+	}
+	// the orientation follows the chart type rather than the axis position,
+	// as it does in Office
+	g.cat.vertical = g.horizontal
+	g.val.vertical = !g.horizontal
+	if g.ser != nil {
+		g.ser.vertical = true
+	}
+}
+
+// layoutAxes scales the axes of the groups with rectangular axes, reserves
+// space for their labels and titles and returns the remaining plot area.
+// The plot area is kept as is when the layout targets the inner area.
+func (r *chartRenderer) layoutAxes(groups []*chartGroup, rect Rectangle, inner bool) Rectangle {
+	var axes []*chartAxis
+	seen := map[*chartAxis]bool{}
+	for _, g := range groups {
+		r.bindAxes(g)
+		for _, a := range []*chartAxis{g.cat, g.val, g.ser} {
+			if a != nil && !seen[a] {
+				seen[a] = true
+				axes = append(axes, a)
+			}
+		}
+	}
+	for _, a := range axes {
+		a.text = r.textStyle(r.text, a.txPr)
+		if a.category {
+			r.setCategories(a, groups)
+		} else {
+			length := rect.Right - rect.Left
+			if a.vertical {
+				length = rect.Bottom - rect.Top
+			}
+			r.scaleValues(a, groups, length)
+		}
+	}
+
+	// reserve the space of labels and titles on the sides of the plot area
+	var left, right, top, bottom float64
+	for _, a := range axes {
+		if a.deleted {
+			continue
+		}
+		a.labelSide = a.pos == chart.ST_AxPosR || a.pos == chart.ST_AxPosB
+		if !a.vertical {
+			a.labelSide = a.pos != chart.ST_AxPosT
+		}
+		switch a.lblPos {
+		case chart.ST_TickLblPosHigh:
+			a.labelSide = a.vertical
+		case chart.ST_TickLblPosLow:
+			a.labelSide = !a.vertical
+		}
+		space := 0.0
+		if a.lblPos != chart.ST_TickLblPosNone {
+			space = r.tickLength(a) + r.pt(3)
+			if a.vertical {
+				space += r.maxLabelWidth(a)
+			} else {
+				space += r.pt(a.text.size) * 1.2
+			}
+		}
+		if a.title != nil {
+			if lbl := r.axisTitle(a); lbl != nil {
+				space += lbl.h + r.pt(4)
+			}
+		}
+		switch {
+		case a.vertical && a.labelSide:
+			right += space
+		case a.vertical:
+			left += space
+		case a.labelSide:
+			bottom += space
+		default:
+			top += space
+		}
+	}
+	if !inner {
+		rect.Left += left
+		rect.Right -= right
+		rect.Top += top
+		rect.Bottom -= bottom
+	}
+	if rect.Right-rect.Left < r.pt(10) {
+		rect.Right = rect.Left + r.pt(10)
+	}
+	if rect.Bottom-rect.Top < r.pt(10) {
+		rect.Bottom = rect.Top + r.pt(10)
+	}
+
+	for _, a := range axes {
+		if a.vertical {
+			a.start, a.end = rect.Bottom, rect.Top
+		} else {
+			a.start, a.end = rect.Left, rect.Right
+		}
+		if a.reversed {
+			a.start, a.end = a.end, a.start
+		}
+	}
+	for _, a := range axes {
+		if a.cross != nil {
+			a.line = a.cross.crossCoord(a)
+		} else if a.vertical {
+			a.line = rect.Left
+		} else {
+			a.line = rect.Bottom
+		}
+	}
+	r.axes = axes
+	return rect
+}
+
+// crossCoord returns the coordinate on a at which the perpendicular axis o
+// crosses it.
+func (a *chartAxis) crossCoord(o *chartAxis) float64 {
+	if a.category {
+		switch {
+		case o.crossesAt != nil:
+			return a.catCoord(*o.crossesAt - 1)
+		case o.crosses == chart.ST_CrossesMax:
+			return a.end
+		}
+		return a.start
+	}
+	return a.coord(a.crossValue(o))
+}
+
+// crossValue returns the value of the value axis a at which the
+// perpendicular axis o crosses it. Bars grow from this value.
+func (a *chartAxis) crossValue(o *chartAxis) float64 {
+	switch {
+	case o.crossesAt != nil:
+		return math.Max(a.lo, math.Min(a.hi, *o.crossesAt))
+	case o.crosses == chart.ST_CrossesMax:
+		return a.hi
+	case o.crosses == chart.ST_CrossesMin:
+		return a.lo
+	}
+	if a.logBase > 1 {
+		return a.lo
+	}
+	return math.Max(a.lo, math.Min(a.hi, 0))
+}
+
+// setCategories reads the labels of a category axis from the series of the
+// groups using it.
+func (r *chartRenderer) setCategories(a *chartAxis, groups []*chartGroup) {
+	n := 0
+	var labels []string
+	for _, g := range groups {
+		if g.ser == a {
+			for _, s := range g.series {
+				labels = append(labels, s.name)
+			}
+			n = len(labels)
+			continue
+		}
+		if g.cat != a {
+			continue
+		}
+		for _, s := range g.series {
+			if len(s.vals) > n {
+				n = len(s.vals)
+			}
+			if labels == nil && len(s.cats) > 0 {
+				labels = r.categoryLabels(a, s)
+			}
+		}
+	}
+	a.cats = make([]string, n)
+	for i := range a.cats {
+		if i < len(labels) {
+			a.cats[i] = labels[i]
+		} else if labels == nil {
+			a.cats[i] = strconv.Itoa(i + 1)
+		}
+	}
+	if a.cross != nil {
+		a.midCat = a.cross.midCat
+	}
+}
+
+// categoryLabels formats the categories of a series, numeric categories use
+// the number format of the axis unless it is linked to the source.
+func (r *chartRenderer) categoryLabels(a *chartAxis, s *chartSeries) []string {
+	if s.catNums == nil || a.numFmt == nil || a.numFmt.FormatCodeAttr == "" || (a.numFmt.SourceLinkedAttr != nil && *a.numFmt.SourceLinkedAttr) {
+		return s.cats
+	}
+	labels := make([]string, len(s.cats))
+	for i, v := range s.catNums {
+		if math.IsNaN(v) {
+			labels[i] = s.cats[i]
+		} else {
+			labels[i] = formatChartValue(v, a.numFmt.FormatCodeAttr)
+		}
+	}
+	return labels
+}
+
+// scaleValues sets the range, major unit and number format of a value axis
+// from the values of the groups using it.
+func (r *chartRenderer) scaleValues(a *chartAxis, groups []*chartGroup, length float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	zero := false
+	format := ""
+	for _, g := range groups {
+		var glo, ghi float64
+		var ok bool
+		switch {
+		case g.val == a:
+			glo, ghi, ok = g.valueRange()
+			if g.kind == chartBar || g.kind == chartArea || g.stacked {
+				zero = true
+			}
+			if g.percent {
+				a.percent = true
+			}
+			if format == "" && len(g.series) > 0 {
+				format = g.series[0].format
+			}
+		case g.cat == a:
+			// the x axis of scatter and bubble charts
+			glo, ghi, ok = g.xRange()
+			if format == "" && len(g.series) > 0 {
+				format = g.series[0].xFormat
+			}
+		}
+		if ok {
+			lo, hi = math.Min(lo, glo), math.Max(hi, ghi)
+		}
+	}
+	if math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		lo, hi = 0, 1
+	}
+	if a.percent {
+		format = "0%"
+	}
+	if a.numFmt != nil && a.numFmt.FormatCodeAttr != "" && (a.numFmt.SourceLinkedAttr == nil || !*a.numFmt.SourceLinkedAttr || format == "") {
+		format = a.numFmt.FormatCodeAttr
+	}
+	a.format = format
+
+	if a.logBase > 1 {
+		a.scaleLog(lo, hi)
+		return
+	}
+	if !zero {
+		// like Office, the axis starts at zero unless the values are
+		// close together compared to their size
+		zero = !(lo > 0 && hi-lo < hi/6) && !(hi < 0 && hi-lo < -lo/6)
+	}
+	if zero {
+		lo, hi = math.Min(lo, 0), math.Max(hi, 0)
+	}
+	if a.min != nil {
+		lo = *a.min
+	}
+	if a.max != nil {
+		hi = *a.max
+	}
+	if hi <= lo {
+		switch {
+		case a.max == nil:
+			hi = lo + 1
+		default:
+			lo = hi - 1
+		}
+	}
+
+	size := r.pt(a.text.size) * 1.2
+	ticks := length / (size * 2.5)
+	if !a.vertical {
+		ticks = length / (size * 5)
+	}
+	ticks = math.Max(2, math.Min(10, math.Floor(ticks)))
+	unit := a.majorUnit
+	if unit <= 0 {
+		unit = niceUnit((hi - lo) / ticks)
+	}
+	a.unit = unit
+	a.lo, a.hi = lo, hi
+	if a.min == nil {
+		a.lo = math.Floor(lo/unit+1e-9) * unit
+		if lo < 0 && a.lo == lo && !a.percent {
+			a.lo -= unit
+		}
+	}
+	if a.max == nil {
+		a.hi = math.Ceil(hi/unit-1e-9) * unit
+		if hi > 0 && a.hi == hi && !a.percent {
+			a.hi += unit
+		}
+	}
+	if a.percent {
+		a.lo, a.hi = math.Max(a.lo, -1), math.Min(a.hi, 1)
+	}
+}
+
+// scaleLog scales a logarithmic axis to whole powers of its base.
+func (a *chartAxis) scaleLog(lo, hi float64) {
+	b := a.logBase
+	if lo <= 0 {
+		lo = 1
+	}
+	if hi <= lo {
+		hi = lo * b
+	}
+	a.lo = math.Pow(b, math.Floor(math.Log(lo)/math.Log(b)+1e-9))
+	a.hi = math.Pow(b, math.Ceil(math.Log(hi)/math.Log(b)-1e-9))
+	if a.min != nil && *a.min > 0 {
+		a.lo = *a.min
+	}
+	if a.max != nil && *a.max > a.lo {
+		a.hi = *a.max
+	}
+	if a.hi <= a.lo {
+		a.hi = a.lo * b
+	}
+	a.unit = b
+}
+
+// niceUnit rounds a step up to 1, 2 or 5 times a power of ten.
+func niceUnit(step float64) float64 {
+	if step <= 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		return 1
+	}
+	p := math.Pow(10, math.Floor(math.Log10(step)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if m*p >= step*(1-1e-9) {
+			return m * p
+		}
+	}
+	return 10 * p
+}
+
+// ticks returns the values of the major or minor tick marks.
+func (a *chartAxis) ticks(minor bool) []float64 {
+	var vs []float64
+	if a.logBase > 1 {
+		for v := a.lo; v <= a.hi*(1+1e-9); v *= a.logBase {
+			if !minor {
+				vs = append(vs, v)
+				continue
+			}
+			for k := 2.0; k < a.logBase && v*k < a.hi; k++ {
+				vs = append(vs, v*k)
+			}
+		}
+		return vs
+	}
+	unit := a.unit
+	if minor {
+		unit = a.minorUnit
+		if unit <= 0 {
+			unit = a.unit / 5
+		}
+	}
+	if unit <= 0 || (a.hi-a.lo)/unit > 1000 {
+		return nil
+	}
+	n := int(math.Floor((a.hi-a.lo)/unit + 1e-9))
+	for i := 0; i <= n; i++ {
+		v, _ := strconv.ParseFloat(strconv.FormatFloat(a.lo+float64(i)*unit, 'g', 12, 64), 64)
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// coord maps a value to a coordinate along a value axis.
+func (a *chartAxis) coord(v float64) float64 {
+	t := 0.0
+	if a.logBase > 1 {
+		if v <= 0 {
+			v = a.lo
+		}
+		t = (math.Log(v) - math.Log(a.lo)) / (math.Log(a.hi) - math.Log(a.lo))
+	} else if a.hi != a.lo {
+		t = (v - a.lo) / (a.hi - a.lo)
+	}
+	return a.start + t*(a.end-a.start)
+}
+
+// catCoord maps a category index to a coordinate along a category axis,
+// the center of its slot or, for axes crossing between categories, the
+// category itself.
+func (a *chartAxis) catCoord(i float64) float64 {
+	n := float64(len(a.cats))
+	if n == 0 {
+		return (a.start + a.end) / 2
+	}
+	if !a.midCat {
+		return a.start + (i+0.5)/n*(a.end-a.start)
+	}
+	if n == 1 {
+		return (a.start + a.end) / 2
+	}
+	return a.start + i/(n-1)*(a.end-a.start)
+}
+
+// slot returns the width of the slot of a category.
+func (a *chartAxis) slot() float64 {
+	n := float64(len(a.cats))
+	if n == 0 {
+		n = 1
+	}
+	return math.Abs(a.end-a.start) / n
+}
+
+// label returns the text of a tick mark of a value axis.
+func (a *chartAxis) label(v float64) string {
+	if a.dispUnit > 0 {
+		v /= a.dispUnit
+	}
+	return formatChartValue(v, a.format)
+}
+
+func (r *chartRenderer) tickLength(a *chartAxis) float64 {
+	if a.majorTick == chart.ST_TickMarkOut || a.majorTick == chart.ST_TickMarkCross {
+		return r.pt(4)
+	}
+	return 0
+}
+
+func (r *chartRenderer) maxLabelWidth(a *chartAxis) float64 {
+	w := 0.0
+	if a.category {
+		for _, c := range a.cats {
+			w = math.Max(w, r.textWidth(c, a.text))
+		}
+		return w
+	}
+	for _, v := range a.ticks(false) {
+		w = math.Max(w, r.textWidth(a.label(v), a.text))
+	}
+	return w
+}
+
+func (r *chartRenderer) axisTitle(a *chartAxis) *chartLabel {
+	base := a.text
+	base.bold = true
+	return r.titleLabel(a.title, base, "Axis Title")
+}
+
+// drawGridlines draws the minor and major gridlines of the axes across the
+// plot area.
+func (r *chartRenderer) drawGridlines() {
+	for _, minor := range []bool{true, false} {
+		for _, a := range r.axes {
+			lines := a.majorGrid
+			color := "D9D9D9"
+			if minor {
+				lines, color = a.minorGrid, "F2F2F2"
+			}
+			if lines == nil || a.cross == nil {
+				continue
+			}
+			paint := r.line(lines.SpPr, color, 0.75)
+			for _, c := range r.gridCoords(a, minor) {
+				if a.vertical {
+					r.drawLine(a.cross.start, c, a.cross.end, c, paint)
+				} else {
+					r.drawLine(c, a.cross.start, c, a.cross.end, paint)
+				}
+			}
+		}
+	}
+}
+
+// gridCoords returns the coordinates of the gridlines and tick marks of an
+// axis. Category axes have them between the categories unless the value
+// axis crosses at the categories.
+func (r *chartRenderer) gridCoords(a *chartAxis, minor bool) []float64 {
+	var cs []float64
+	if a.category {
+		if minor {
+			return nil
+		}
+		n := len(a.cats)
+		if a.midCat {
+			for i := 0; i < n; i++ {
+				cs = append(cs, a.catCoord(float64(i)))
+			}
+			return cs
+		}
+		for i := 0; i <= n; i++ {
+			cs = append(cs, a.start+float64(i)/float64(n)*(a.end-a.start))
+		}
+		return cs
+	}
+	for _, v := range a.ticks(minor) {
+		cs = append(cs, a.coord(v))
+	}
+	return cs
+}
+
+// drawAxes draws the lines, tick marks, labels and titles of the axes.
+func (r *chartRenderer) drawAxes() {
+	for _, a := range r.axes {
+		if a.deleted || a.cross == nil {
+			continue
+		}
+		paint := r.line(a.spPr, "000000", 0.75)
+		if a.vertical {
+			r.drawLine(a.line, a.start, a.line, a.end, paint)
+		} else {
+			r.drawLine(a.start, a.line, a.end, a.line, paint)
+		}
+
+		// tick marks point away from the labels when outside
+		tick := r.pt(4)
+		var in, out float64
+		switch a.majorTick {
+		case chart.ST_TickMarkOut:
+			out = tick
+		case chart.ST_TickMarkIn:
+			in = tick
+		case chart.ST_TickMarkCross:
+			in, out = tick, tick
+		}
+		dir := -1.0
+		if a.labelSide {
+			dir = 1
+		}
+		for _, c := range r.gridCoords(a, false) {
+			if a.vertical {
+				r.drawLine(a.line-dir*in, c, a.line+dir*out, c, paint)
+			} else {
+				r.drawLine(c, a.line-dir*in, c, a.line+dir*out, paint)
+			}
+		}
+
+		edge := a.line
+		if a.lblPos == chart.ST_TickLblPosHigh || a.lblPos == chart.ST_TickLblPosLow {
+			edge = a.cross.start
+			if a.labelSide == (a.cross.start < a.cross.end) {
+				edge = a.cross.end
+			}
+		}
+		extent := 0.0
+		if a.lblPos != chart.ST_TickLblPosNone {
+			extent = r.drawTickLabels(a, edge+dir*(r.tickLength(a)+r.pt(3)), dir)
+		}
+		if a.title != nil {
+			r.drawAxisTitle(a, edge+dir*(r.tickLength(a)+r.pt(3)+extent+r.pt(4)), dir)
+		}
+	}
+}
+
+// drawTickLabels draws the labels of an axis starting at the coordinate at
+// across the axis, in direction dir, and returns their extent.
+func (r *chartRenderer) drawTickLabels(a *chartAxis, at, dir float64) float64 {
+	size := r.pt(a.text.size)
+	lineH := size * 1.2
+	type tickLabel struct {
+		text string
+		c    float64
+	}
+	var labels []tickLabel
+	maxW := 0.0
+	if a.category {
+		for i, s := range a.cats {
+			labels = append(labels, tickLabel{s, a.catCoord(float64(i))})
+			maxW = math.Max(maxW, r.textWidth(s, a.text))
+		}
+	} else {
+		for _, v := range a.ticks(false) {
+			s := a.label(v)
+			labels = append(labels, tickLabel{s, a.coord(v)})
+			maxW = math.Max(maxW, r.textWidth(s, a.text))
+		}
+	}
+	if len(labels) == 0 {
+		return 0
+	}
+
+	// skip labels that would overlap
+	skip := a.lblSkip
+	if skip <= 0 && a.category && len(labels) > 1 {
+		step := math.Abs(labels[1].c - labels[0].c)
+		need := maxW + size
+		if a.vertical {
+			need = lineH
+		}
+		skip = int(math.Ceil(need / math.Max(step, 1e-9)))
+	}
+	if skip < 1 {
+		skip = 1
+	}
+	for i := 0; i < len(labels); i += skip {
+		l := labels[i]
+		w := r.textWidth(l.text, a.text)
+		if a.vertical {
+			x := at
+			if dir < 0 {
+				x = at - w
+			}
+			r.drawString(l.text, a.text, x, l.c-size*0.6, 0)
+		} else {
+			y := at
+			if dir < 0 {
+				y = at - lineH
+			}
+			r.drawString(l.text, a.text, l.c-w/2, y, 0)
+		}
+	}
+	if a.vertical {
+		return maxW
+	}
+	return lineH
+}
+
+// drawAxisTitle draws the title of an axis next to its labels, titles of
+// vertical axes are turned by 90 degrees.
+func (r *chartRenderer) drawAxisTitle(a *chartAxis, at, dir float64) {
+	lbl := r.axisTitle(a)
+	if lbl == nil {
+		return
+	}
+	mid := (a.start + a.end) / 2
+	if a.vertical {
+		x := at
+		if dir < 0 {
+			x = at - lbl.h
+		}
+		r.drawLabelRotated(lbl, x, mid-lbl.w/2)
+		return
+	}
+	y := at
+	if dir < 0 {
+		y = at - lbl.h
+	}
+	r.drawLabel(lbl, mid-lbl.w/2, y)
+}
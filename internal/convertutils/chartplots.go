@@ -0,0 +1,1982 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/dml/chart"
+	"github.com/unidoc/unioffice/spreadsheet/format"
+	"github.com/unidoc/unipdf/v3/contentstream/draw"
+)
+
+type chartKind byte
+
+const (
+	chartBar chartKind = iota
+	chartLine
+	chartArea
+	chartPie
+	chartDoughnut
+	chartOfPie
+	chartScatter
+	chartBubble
+	chartRadar
+	chartStock
+	chartSurface
+)
+
+// chartGroup is a chart of the plot area with the series drawn the same
+// way. 3D charts are read as their 2D counterparts.
+type chartGroup struct {
+	kind       chartKind
+	horizontal bool
+	stacked    bool
+	percent    bool
+	varyColors bool
+	markers    bool
+	filled     bool
+	threeD     bool
+	wireframe  bool
+	ofPieBar   bool
+	sizeWidth  bool
+	showNeg    bool
+
+	gapWidth    float64
+	overlap     float64
+	holeSize    float64
+	firstSlice  float64
+	bubbleScale float64
+	secondSize  float64
+	splitType   chart.ST_SplitType
+	splitPos    float64
+	custSplit   []int
+
+	dLbls      *chart.CT_DLbls
+	dropLines  *chart.CT_ChartLines
+	hiLowLines *chart.CT_ChartLines
+	upDownBars *chart.CT_UpDownBars
+	serLines   []*chart.CT_ChartLines
+	bandFmts   *chart.CT_BandFmts
+
+	axIDs         []uint32
+	series        []*chartSeries
+	cat, val, ser *chartAxis
+	bands         *chartAxis
+}
+
+// chartSeries is a series with its cached values. Missing values are NaN.
+type chartSeries struct {
+	index, order int
+	name         string
+	cats         []string
+	catNums      []float64
+	xs           []float64
+	vals         []float64
+	sizes        []float64
+	format       string
+	xFormat      string
+	spPr         *dml.CT_ShapeProperties
+	marker       *chart.CT_Marker
+	dPt          []*chart.CT_DPt
+	dLbls        *chart.CT_DLbls
+	smooth       bool
+	invert       bool
+	explosion    float64
+}
+
+func (r *chartRenderer) readPlotArea(pa *chart.CT_PlotArea) {
+	for _, c := range pa.Choice {
+		switch {
+		case c.BarChart != nil:
+			b := c.BarChart
+			g := r.barGroup(b.BarDir, b.Grouping, b.VaryColors, b.GapWidth, b.Overlap, b.AxId)
+			g.dLbls, g.serLines = b.DLbls, b.SerLines
+			r.addBarSeries(g, b.Ser)
+		case c.Bar3DChart != nil:
+			b := c.Bar3DChart
+			g := r.barGroup(b.BarDir, b.Grouping, b.VaryColors, b.GapWidth, nil, b.AxId)
+			g.dLbls, g.threeD = b.DLbls, true
+			r.addBarSeries(g, b.Ser)
+		case c.LineChart != nil:
+			l := c.LineChart
+			g := r.newGroup(chartLine, l.VaryColors, false, l.AxId)
+			g.setGrouping(l.Grouping)
+			g.markers = boolVal(l.Marker, true)
+			g.dLbls, g.dropLines, g.hiLowLines, g.upDownBars = l.DLbls, l.DropLines, l.HiLowLines, l.UpDownBars
+			r.addLineSeries(g, l.Ser, boolVal(l.Smooth, false))
+		case c.Line3DChart != nil:
+			l := c.Line3DChart
+			g := r.newGroup(chartLine, l.VaryColors, false, l.AxId)
+			g.setGrouping(l.Grouping)
+			g.dLbls, g.dropLines, g.threeD = l.DLbls, l.DropLines, true
+			r.addLineSeries(g, l.Ser, false)
+		case c.StockChart != nil:
+			s := c.StockChart
+			g := r.newGroup(chartStock, nil, false, s.AxId)
+			g.dLbls, g.dropLines, g.hiLowLines, g.upDownBars = s.DLbls, s.DropLines, s.HiLowLines, s.UpDownBars
+			r.addLineSeries(g, s.Ser, false)
+		case c.AreaChart != nil:
+			a := c.AreaChart
+			g := r.newGroup(chartArea, a.VaryColors, false, a.AxId)
+			g.setGrouping(a.Grouping)
+			g.dLbls, g.dropLines = a.DLbls, a.DropLines
+			r.addAreaSeries(g, a.Ser)
+		case c.Area3DChart != nil:
+			a := c.Area3DChart
+			g := r.newGroup(chartArea, a.VaryColors, false, a.AxId)
+			g.setGrouping(a.Grouping)
+			g.dLbls, g.dropLines, g.threeD = a.DLbls, a.DropLines, true
+			r.addAreaSeries(g, a.Ser)
+		case c.PieChart != nil:
+			p := c.PieChart
+			g := r.newGroup(chartPie, p.VaryColors, true, nil)
+			g.dLbls = p.DLbls
+			if p.FirstSliceAng != nil && p.FirstSliceAng.ValAttr != nil {
+				g.firstSlice = float64(*p.FirstSliceAng.ValAttr)
+			}
+			r.addPieSeries(g, p.Ser)
+		case c.Pie3DChart != nil:
+			p := c.Pie3DChart
+			g := r.newGroup(chartPie, p.VaryColors, true, nil)
+			g.dLbls, g.threeD = p.DLbls, true
+			r.addPieSeries(g, p.Ser)
+		case c.DoughnutChart != nil:
+			d := c.DoughnutChart
+			g := r.newGroup(chartDoughnut, d.VaryColors, true, nil)
+			g.dLbls = d.DLbls
+			if d.FirstSliceAng != nil && d.FirstSliceAng.ValAttr != nil {
+				g.firstSlice = float64(*d.FirstSliceAng.ValAttr)
+			}
+			g.holeSize = 50
+			if d.HoleSize != nil && d.HoleSize.ValAttr != nil {
+				if h := d.HoleSize.ValAttr; h.ST_HoleSizeUByte != nil {
+					g.holeSize = float64(*h.ST_HoleSizeUByte)
+				} else if h.ST_HoleSizePercent != nil {
+					g.holeSize = parsePercent(*h.ST_HoleSizePercent, 50)
+				}
+			}
+			r.addPieSeries(g, d.Ser)
+		case c.OfPieChart != nil:
+			o := c.OfPieChart
+			g := r.newGroup(chartOfPie, o.VaryColors, true, nil)
+			g.dLbls, g.serLines = o.DLbls, o.SerLines
+			g.ofPieBar = o.OfPieType != nil && o.OfPieType.ValAttr == chart.ST_OfPieTypeBar
+			g.gapWidth = gapAmount(o.GapWidth, 150)
+			g.secondSize = 75
+			if o.SecondPieSize != nil && o.SecondPieSize.ValAttr != nil {
+				if s := o.SecondPieSize.ValAttr; s.ST_SecondPieSizeUShort != nil {
+					g.secondSize = float64(*s.ST_SecondPieSizeUShort)
+				} else if s.ST_SecondPieSizePercent != nil {
+					g.secondSize = parsePercent(*s.ST_SecondPieSizePercent, 75)
+				}
+			}
+			g.splitType = chart.ST_SplitTypePos
+			if o.SplitType != nil && o.SplitType.ValAttr != chart.ST_SplitTypeUnset {
+				g.splitType = o.SplitType.ValAttr
+			}
+			g.splitPos = 2
+			if o.SplitPos != nil {
+				g.splitPos = o.SplitPos.ValAttr
+			}
+			if o.CustSplit != nil {
+				for _, p := range o.CustSplit.SecondPiePt {
+					g.custSplit = append(g.custSplit, int(p.ValAttr))
+				}
+			}
+			r.addPieSeries(g, o.Ser)
+		case c.ScatterChart != nil:
+			s := c.ScatterChart
+			g := r.newGroup(chartScatter, s.VaryColors, false, s.AxId)
+			g.dLbls = s.DLbls
+			g.markers = true
+			if s.ScatterStyle != nil {
+				switch s.ScatterStyle.ValAttr {
+				case chart.ST_ScatterStyleNone, chart.ST_ScatterStyleLine, chart.ST_ScatterStyleSmooth:
+					g.markers = false
+				}
+			}
+			r.addScatterSeries(g, s.Ser)
+		case c.BubbleChart != nil:
+			b := c.BubbleChart
+			g := r.newGroup(chartBubble, b.VaryColors, false, b.AxId)
+			g.dLbls = b.DLbls
+			g.bubbleScale = 100
+			if b.BubbleScale != nil && b.BubbleScale.ValAttr != nil {
+				if s := b.BubbleScale.ValAttr; s.ST_BubbleScaleUInt != nil {
+					g.bubbleScale = float64(*s.ST_BubbleScaleUInt)
+				} else if s.ST_BubbleScalePercent != nil {
+					g.bubbleScale = parsePercent(*s.ST_BubbleScalePercent, 100)
+				}
+			}
+			g.showNeg = boolVal(b.ShowNegBubbles, false)
+			g.sizeWidth = b.SizeRepresents != nil && b.SizeRepresents.ValAttr == chart.ST_SizeRepresentsW
+			r.addBubbleSeries(g, b.Ser)
+		case c.RadarChart != nil:
+			rc := c.RadarChart
+			g := r.newGroup(chartRadar, rc.VaryColors, false, rc.AxId)
+			g.dLbls = rc.DLbls
+			if rc.RadarStyle != nil {
+				g.filled = rc.RadarStyle.ValAttr == chart.ST_RadarStyleFilled
+				g.markers = rc.RadarStyle.ValAttr == chart.ST_RadarStyleMarker
+			}
+			r.addRadarSeries(g, rc.Ser)
+		case c.SurfaceChart != nil:
+			s := c.SurfaceChart
+			g := r.newGroup(chartSurface, nil, false, s.AxId)
+			g.wireframe, g.bandFmts = boolVal(s.Wireframe, false), s.BandFmts
+			r.addSurfaceSeries(g, s.Ser)
+		case c.Surface3DChart != nil:
+			s := c.Surface3DChart
+			g := r.newGroup(chartSurface, nil, false, s.AxId)
+			g.wireframe, g.bandFmts, g.threeD = boolVal(s.Wireframe, false), s.BandFmts, true
+			r.addSurfaceSeries(g, s.Ser)
+		}
+	}
+	r.readAxes(pa.CChoice)
+}
+
+func (r *chartRenderer) newGroup(kind chartKind, vary *chart.CT_Boolean, varyDefault bool, axIDs []*chart.CT_UnsignedInt) *chartGroup {
+	g := &chartGroup{kind: kind, varyColors: boolVal(vary, varyDefault)}
+	for _, id := range axIDs {
+		g.axIDs = append(g.axIDs, id.ValAttr)
+	}
+	r.groups = append(r.groups, g)
+	return g
+}
+
+func (r *chartRenderer) barGroup(dir *chart.CT_BarDir, grouping *chart.CT_BarGrouping, vary *chart.CT_Boolean,
+	gap *chart.CT_GapAmount, overlap *chart.CT_Overlap, axIDs []*chart.CT_UnsignedInt) *chartGroup {
+	g := r.newGroup(chartBar, vary, false, axIDs)
+	g.horizontal = dir != nil && dir.ValAttr == chart.ST_BarDirBar
+	if grouping != nil {
+		switch grouping.ValAttr {
+		case chart.ST_BarGroupingStacked:
+			g.stacked = true
+		case chart.ST_BarGroupingPercentStacked:
+			g.stacked, g.percent = true, true
+		}
+	}
+	g.gapWidth = gapAmount(gap, 150)
+	if g.stacked {
+		g.overlap = 100
+	}
+	if overlap != nil && overlap.ValAttr != nil {
+		if o := overlap.ValAttr; o.ST_OverlapByte != nil {
+			g.overlap = float64(*o.ST_OverlapByte)
+		} else if o.ST_OverlapPercent != nil {
+			g.overlap = parsePercent(*o.ST_OverlapPercent, g.overlap)
+		}
+	}
+	return g
+}
+
+func (g *chartGroup) setGrouping(grouping *chart.CT_Grouping) {
+	if grouping == nil {
+		return
+	}
+	switch grouping.ValAttr {
+	case chart.ST_GroupingStacked:
+		g.stacked = true
+	case chart.ST_GroupingPercentStacked:
+		g.stacked, g.percent = true, true
+	}
+}
+
+func gapAmount(gap *chart.CT_GapAmount, def float64) float64 {
+	if gap == nil || gap.ValAttr == nil {
+		return def
+	}
+	if gap.ValAttr.ST_GapAmountUShort != nil {
+		return float64(*gap.ValAttr.ST_GapAmountUShort)
+	}
+	if gap.ValAttr.ST_GapAmountPercent != nil {
+		return parsePercent(*gap.ValAttr.ST_GapAmountPercent, def)
+	}
+	return def
+}
+
+// parsePercent parses a percentage such as "150%".
+func parsePercent(s string, def float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func (r *chartRenderer) newSeries(idx, order *chart.CT_UnsignedInt, tx *chart.CT_SerTx, spPr *dml.CT_ShapeProperties,
+	dPt []*chart.CT_DPt, dLbls *chart.CT_DLbls, cat *chart.CT_AxDataSource, val *chart.CT_NumDataSource) *chartSeries {
+	s := &chartSeries{spPr: spPr, dPt: dPt, dLbls: dLbls}
+	if idx != nil {
+		s.index = int(idx.ValAttr)
+	}
+	s.order = s.index
+	if order != nil {
+		s.order = int(order.ValAttr)
+	}
+	s.name = seriesName(tx, s.index)
+	s.cats, s.catNums, _ = axisData(cat)
+	s.vals, s.format = numberData(val)
+	return s
+}
+
+func sortSeries(g *chartGroup) {
+	sort.SliceStable(g.series, func(i, j int) bool {
+		return g.series[i].order < g.series[j].order
+	})
+}
+
+func (r *chartRenderer) addBarSeries(g *chartGroup, series []*chart.CT_BarSer) {
+	for _, ser := range series {
+		s := r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.Cat, ser.Val)
+		s.invert = boolVal(ser.InvertIfNegative, false)
+		g.series = append(g.series, s)
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addLineSeries(g *chartGroup, series []*chart.CT_LineSer, smooth bool) {
+	for _, ser := range series {
+		s := r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.Cat, ser.Val)
+		s.marker = ser.Marker
+		s.smooth = boolVal(ser.Smooth, smooth)
+		g.series = append(g.series, s)
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addAreaSeries(g *chartGroup, series []*chart.CT_AreaSer) {
+	for _, ser := range series {
+		g.series = append(g.series, r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.Cat, ser.Val))
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addPieSeries(g *chartGroup, series []*chart.CT_PieSer) {
+	for _, ser := range series {
+		s := r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.Cat, ser.Val)
+		if ser.Explosion != nil {
+			s.explosion = float64(ser.Explosion.ValAttr)
+		}
+		g.series = append(g.series, s)
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addScatterSeries(g *chartGroup, series []*chart.CT_ScatterSer) {
+	for _, ser := range series {
+		s := r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.XVal, ser.YVal)
+		s.xs, s.xFormat = s.catNums, axisFormat(ser.XVal)
+		s.marker = ser.Marker
+		s.smooth = boolVal(ser.Smooth, false)
+		g.series = append(g.series, s)
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addBubbleSeries(g *chartGroup, series []*chart.CT_BubbleSer) {
+	for _, ser := range series {
+		s := r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.XVal, ser.YVal)
+		s.xs, s.xFormat = s.catNums, axisFormat(ser.XVal)
+		s.sizes, _ = numberData(ser.BubbleSize)
+		s.invert = boolVal(ser.InvertIfNegative, false)
+		g.series = append(g.series, s)
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addRadarSeries(g *chartGroup, series []*chart.CT_RadarSer) {
+	for _, ser := range series {
+		s := r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, ser.DPt, ser.DLbls, ser.Cat, ser.Val)
+		s.marker = ser.Marker
+		g.series = append(g.series, s)
+	}
+	sortSeries(g)
+}
+
+func (r *chartRenderer) addSurfaceSeries(g *chartGroup, series []*chart.CT_SurfaceSer) {
+	for _, ser := range series {
+		g.series = append(g.series, r.newSeries(ser.Idx, ser.Order, ser.Tx, ser.SpPr, nil, nil, ser.Cat, ser.Val))
+	}
+	sortSeries(g)
+}
+
+// seriesName returns the cached name of a series or the name Office shows
+// for series without one.
+func seriesName(tx *chart.CT_SerTx, idx int) string {
+	if tx != nil && tx.Choice != nil {
+		if tx.Choice.V != nil {
+			return *tx.Choice.V
+		}
+		if ref := tx.Choice.StrRef; ref != nil && ref.StrCache != nil && len(ref.StrCache.Pt) > 0 {
+			return ref.StrCache.Pt[0].V
+		}
+	}
+	return "Series" + strconv.Itoa(idx+1)
+}
+
+// numberData returns the cached values of a number source and their format.
+func numberData(ds *chart.CT_NumDataSource) ([]float64, string) {
+	if ds == nil || ds.Choice == nil {
+		return nil, ""
+	}
+	d := ds.Choice.NumLit
+	if ds.Choice.NumRef != nil {
+		d = ds.Choice.NumRef.NumCache
+	}
+	return numberCache(d)
+}
+
+func numberCache(d *chart.CT_NumData) ([]float64, string) {
+	if d == nil {
+		return nil, ""
+	}
+	n := 0
+	if d.PtCount != nil {
+		n = int(d.PtCount.ValAttr)
+	}
+	for _, pt := range d.Pt {
+		if int(pt.IdxAttr) >= n {
+			n = int(pt.IdxAttr) + 1
+		}
+	}
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = math.NaN()
+	}
+	code := ""
+	if d.FormatCode != nil {
+		code = *d.FormatCode
+	}
+	for _, pt := range d.Pt {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(pt.V), 64); err == nil {
+			vals[pt.IdxAttr] = v
+		}
+		if code == "" && pt.FormatCodeAttr != nil {
+			code = *pt.FormatCodeAttr
+		}
+	}
+	if code == "General" {
+		code = ""
+	}
+	return vals, code
+}
+
+// axisData returns the labels of a category source and, for numeric
+// categories, their values.
+func axisData(ds *chart.CT_AxDataSource) ([]string, []float64, string) {
+	if ds == nil || ds.Choice == nil {
+		return nil, nil, ""
+	}
+	c := ds.Choice
+	var d *chart.CT_NumData
+	var sd *chart.CT_StrData
+	switch {
+	case c.NumRef != nil:
+		d = c.NumRef.NumCache
+	case c.NumLit != nil:
+		d = c.NumLit
+	case c.StrRef != nil:
+		sd = c.StrRef.StrCache
+	case c.StrLit != nil:
+		sd = c.StrLit
+	case c.MultiLvlStrRef != nil && c.MultiLvlStrRef.MultiLvlStrCache != nil:
+		// the first level holds the innermost labels
+		mc := c.MultiLvlStrRef.MultiLvlStrCache
+		sd = &chart.CT_StrData{PtCount: mc.PtCount}
+		if len(mc.Lvl) > 0 {
+			sd.Pt = mc.Lvl[0].Pt
+		}
+	}
+	if d != nil {
+		nums, code := numberCache(d)
+		labels := make([]string, len(nums))
+		for i, v := range nums {
+			if !math.IsNaN(v) {
+				labels[i] = formatChartValue(v, code)
+			}
+		}
+		return labels, nums, code
+	}
+	if sd == nil {
+		return nil, nil, ""
+	}
+	n := 0
+	if sd.PtCount != nil {
+		n = int(sd.PtCount.ValAttr)
+	}
+	for _, pt := range sd.Pt {
+		if int(pt.IdxAttr) >= n {
+			n = int(pt.IdxAttr) + 1
+		}
+	}
+	labels := make([]string, n)
+	for _, pt := range sd.Pt {
+		labels[pt.IdxAttr] = pt.V
+	}
+	return labels, nil, ""
+}
+
+func axisFormat(ds *chart.CT_AxDataSource) string {
+	_, _, code := axisData(ds)
+	return code
+}
+
+// formatChartValue formats a value with a number format, General when the
+// format is empty.
+func formatChartValue(v float64, code string) string {
+	if code == "" {
+		code = "General"
+	}
+	return format.Number(v, code)
+}
+
+// allSeries returns the series of all groups.
+func (r *chartRenderer) allSeries() []*chartSeries {
+	var series []*chartSeries
+	for _, g := range r.groups {
+		series = append(series, g.series...)
+	}
+	return series
+}
+
+// varies reports whether the points of a group have their own colors, which
+// Office only does for pies and for groups with a single series.
+func (g *chartGroup) varies() bool {
+	if !g.varyColors {
+		return false
+	}
+	switch g.kind {
+	case chartPie, chartDoughnut, chartOfPie:
+		return true
+	}
+	return len(g.series) == 1
+}
+
+func (g *chartGroup) round() bool {
+	return g.kind == chartPie || g.kind == chartDoughnut || g.kind == chartOfPie
+}
+
+// lineKind reports whether the series of a group are drawn as lines.
+func (g *chartGroup) lineKind() bool {
+	switch g.kind {
+	case chartLine, chartStock, chartScatter:
+		return true
+	case chartRadar:
+		return !g.filled
+	}
+	return false
+}
+
+func (s *chartSeries) point(i int) *chart.CT_DPt {
+	for _, p := range s.dPt {
+		if p.Idx != nil && int(p.Idx.ValAttr) == i {
+			return p
+		}
+	}
+	return nil
+}
+
+func hasFill(sp *dml.CT_ShapeProperties) bool {
+	return sp != nil && (sp.NoFill != nil || sp.SolidFill != nil || sp.GradFill != nil || sp.PattFill != nil)
+}
+
+// seriesColor returns the automatic color of a series or, when the points
+// vary, of a point.
+func (r *chartRenderer) seriesColor(g *chartGroup, s *chartSeries, i int) string {
+	if g.varies() {
+		return r.autoColor(i)
+	}
+	return r.autoColor(s.index)
+}
+
+// pointPaint returns the fill and outline of a data point from the series
+// and the data point overrides.
+func (r *chartRenderer) pointPaint(g *chartGroup, s *chartSeries, i int, line string, width float64) (chartPaint, chartPaint) {
+	auto := r.seriesColor(g, s, i)
+	f, l := r.fill(s.spPr, auto), r.line(s.spPr, line, width)
+	if p := s.point(i); p != nil && p.SpPr != nil {
+		if hasFill(p.SpPr) {
+			f = r.fill(p.SpPr, auto)
+		}
+		if p.SpPr.Ln != nil {
+			l = r.line(p.SpPr, l.color, l.width)
+		}
+	}
+	return f, l
+}
+
+// markerStyle returns the marker symbol and size of a series.
+func (r *chartRenderer) markerStyle(g *chartGroup, s *chartSeries, m *chart.CT_Marker) (chart.ST_MarkerStyle, float64) {
+	symbol := chart.ST_MarkerStyleNone
+	if g.markers {
+		symbol = chart.ST_MarkerStyleAuto
+	}
+	size := 5.0
+	if m == nil {
+		m = s.marker
+	}
+	if m != nil {
+		if m.Symbol != nil && m.Symbol.ValAttr != chart.ST_MarkerStyleUnset {
+			symbol = m.Symbol.ValAttr
+		}
+		if m.Size != nil && m.Size.ValAttr != nil {
+			size = float64(*m.Size.ValAttr)
+		}
+	}
+	if symbol == chart.ST_MarkerStyleAuto {
+		symbol = autoMarkers[s.index%len(autoMarkers)]
+	}
+	if symbol == chart.ST_MarkerStyleNone || symbol == chart.ST_MarkerStylePicture {
+		return chart.ST_MarkerStyleNone, 0
+	}
+	return symbol, size
+}
+
+// markerPaint returns the fill and outline of the markers of a series.
+func (r *chartRenderer) markerPaint(s *chartSeries, m *chart.CT_Marker, color string) (chartPaint, chartPaint) {
+	if m == nil {
+		m = s.marker
+	}
+	var sp *dml.CT_ShapeProperties
+	if m != nil {
+		sp = m.SpPr
+	}
+	return r.fill(sp, color), r.line(sp, color, 0.75)
+}
+
+func (r *chartRenderer) groupLegendItems(g *chartGroup) []*legendItem {
+	var items []*legendItem
+	if g.kind == chartSurface {
+		return r.bandLegendItems(g)
+	}
+	if g.varies() && len(g.series) > 0 {
+		s := g.series[0]
+		for i := range s.vals {
+			text := strconv.Itoa(i + 1)
+			if i < len(s.cats) && s.cats[i] != "" {
+				text = s.cats[i]
+			}
+			it := &legendItem{text: text}
+			if g.lineKind() {
+				it.lineKey = true
+				it.line = r.line(s.spPr, r.seriesColor(g, s, i), 2.25)
+			} else {
+				it.fill, it.line = r.pointPaint(g, s, i, r.pieOutline(g), 0.75)
+			}
+			items = append(items, it)
+		}
+		return items
+	}
+	for _, s := range g.series {
+		it := &legendItem{text: s.name}
+		color := r.autoColor(s.index)
+		if g.lineKind() {
+			it.lineKey = true
+			it.line = r.line(s.spPr, r.seriesLine(g, color), 2.25)
+			it.marker, it.size = r.markerStyle(g, s, nil)
+			it.fill, it.markerLine = r.markerPaint(s, nil, color)
+		} else {
+			it.fill, it.line = r.pointPaint(g, s, -1, "", 0.75)
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// seriesLine returns the automatic line color of a series. Stock series
+// have no lines unless they are given one.
+func (r *chartRenderer) seriesLine(g *chartGroup, color string) string {
+	if g.kind == chartStock {
+		return ""
+	}
+	return color
+}
+
+func (r *chartRenderer) pieOutline(g *chartGroup) string {
+	if g.round() {
+		return "FFFFFF"
+	}
+	return ""
+}
+
+// drawPlotArea lays out the axes, draws the plot area, the series and the
+// axes over them, and the data labels over everything.
+func (r *chartRenderer) drawPlotArea(pa *chart.CT_PlotArea, rect Rectangle, inner bool) {
+	var axial, radar, round []*chartGroup
+	for _, g := range r.groups {
+		switch {
+		case g.round():
+			round = append(round, g)
+		case g.kind == chartRadar:
+			radar = append(radar, g)
+		default:
+			axial = append(axial, g)
+		}
+	}
+	for _, g := range radar {
+		r.bindAxes(g)
+	}
+	r.labels = nil
+	if len(axial) > 0 {
+		rect = r.layoutAxes(axial, rect, inner)
+	} else {
+		r.axes = nil
+	}
+	r.plot = rect
+	r.drawShape(pa.SpPr, &rect, "", "")
+	r.drawGridlines()
+
+	order := []chartKind{chartSurface, chartArea, chartBar, chartLine, chartStock, chartScatter, chartBubble}
+	for _, k := range order {
+		for _, g := range axial {
+			if g.kind != k {
+				continue
+			}
+			switch k {
+			case chartSurface:
+				r.drawSurface(g)
+			case chartArea:
+				r.drawAreas(g)
+			case chartBar:
+				r.drawBars(g)
+			case chartLine, chartStock:
+				r.drawLines(g)
+			case chartScatter:
+				r.drawScatter(g)
+			case chartBubble:
+				r.drawBubbles(g)
+			}
+		}
+	}
+	for _, g := range radar {
+		r.drawRadar(g, rect)
+	}
+	for _, g := range round {
+		switch g.kind {
+		case chartOfPie:
+			r.drawOfPie(g, rect)
+		default:
+			r.drawPie(g, rect)
+		}
+	}
+	r.drawAxes()
+	for _, l := range r.labels {
+		r.drawShape(l.spPr, &Rectangle{Left: l.x - r.pt(2), Top: l.y, Right: l.x + l.lbl.w + r.pt(2), Bottom: l.y + l.lbl.h}, "", "")
+		r.drawLabel(l.lbl, l.x, l.y)
+	}
+}
+
+// valueRange returns the range of the values of a group as they are
+// plotted, that is the sums of stacked values.
+func (g *chartGroup) valueRange() (float64, float64, bool) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	if g.stacked {
+		pos, neg := g.stackSums()
+		for i := range pos {
+			if g.percent {
+				total := pos[i] - neg[i]
+				if total == 0 {
+					continue
+				}
+				pos[i], neg[i] = pos[i]/total, neg[i]/total
+			}
+			lo, hi = math.Min(lo, neg[i]), math.Max(hi, pos[i])
+		}
+		return lo, hi, !math.IsInf(lo, 0)
+	}
+	for _, s := range g.series {
+		for _, v := range s.vals {
+			if !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	return lo, hi, !math.IsInf(lo, 0)
+}
+
+// stackSums returns the sums of the positive and of the negative values of
+// each category.
+func (g *chartGroup) stackSums() ([]float64, []float64) {
+	n := 0
+	for _, s := range g.series {
+		if len(s.vals) > n {
+			n = len(s.vals)
+		}
+	}
+	pos, neg := make([]float64, n), make([]float64, n)
+	for _, s := range g.series {
+		for i, v := range s.vals {
+			switch {
+			case v > 0:
+				pos[i] += v
+			case v < 0:
+				neg[i] += v
+			}
+		}
+	}
+	return pos, neg
+}
+
+// xRange returns the range of the x values of a scatter or bubble group.
+func (g *chartGroup) xRange() (float64, float64, bool) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range g.series {
+		for i := range s.vals {
+			x := s.x(i)
+			if !math.IsNaN(x) {
+				lo, hi = math.Min(lo, x), math.Max(hi, x)
+			}
+		}
+	}
+	return lo, hi, !math.IsInf(lo, 0)
+}
+
+// x returns the x value of a point, its position when the x values are not
+// numbers.
+func (s *chartSeries) x(i int) float64 {
+	if s.xs == nil {
+		return float64(i + 1)
+	}
+	if i < len(s.xs) {
+		return s.xs[i]
+	}
+	return math.NaN()
+}
+
+// stacker adds up the values of stacked series category by category.
+type stacker struct {
+	g        *chartGroup
+	pos, neg []float64
+	total    []float64
+}
+
+func newStacker(g *chartGroup) *stacker {
+	st := &stacker{g: g}
+	if g.stacked {
+		st.pos, st.neg = g.stackSums()
+		st.total = make([]float64, len(st.pos))
+		for i := range st.pos {
+			st.total[i] = st.pos[i] - st.neg[i]
+		}
+		for i := range st.pos {
+			st.pos[i], st.neg[i] = 0, 0
+		}
+	}
+	return st
+}
+
+// add returns the start and the end of a value on its stack, values of
+// percent stacked groups are divided by the total of their category.
+func (st *stacker) add(i int, v float64) (float64, float64) {
+	if !st.g.stacked {
+		return 0, v
+	}
+	if math.IsNaN(v) {
+		v = 0
+	}
+	if st.g.percent {
+		if st.total[i] == 0 {
+			v = 0
+		} else {
+			v /= st.total[i]
+		}
+	}
+	if v >= 0 {
+		base := st.pos[i]
+		st.pos[i] += v
+		return base, st.pos[i]
+	}
+	base := st.neg[i]
+	st.neg[i] += v
+	return base, st.neg[i]
+}
+
+// clamp keeps a value inside the range of a value axis.
+func (a *chartAxis) clamp(v float64) float64 {
+	return math.Max(a.lo, math.Min(a.hi, v))
+}
+
+func sign(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
+
+// drawBars draws clustered, stacked and percent stacked bars and columns.
+func (r *chartRenderer) drawBars(g *chartGroup) {
+	cat, val := g.cat, g.val
+	k := float64(len(g.series))
+	if g.stacked || k == 0 {
+		k = 1
+	}
+	overlap := g.overlap / 100
+	span := k - (k-1)*overlap
+	width := cat.slot() / (span + g.gapWidth/100)
+	dir := sign(cat.end - cat.start)
+	grow := sign(val.end - val.start)
+	base := val.crossValue(cat)
+	st := newStacker(g)
+
+	// the ends of the stacked bars for the series lines
+	ends := make([][]float64, len(g.series))
+	for si, s := range g.series {
+		pos := float64(si)
+		if g.stacked {
+			pos = 0
+		}
+		offset := dir * (-span*width/2 + width/2 + pos*width*(1-overlap))
+		ends[si] = make([]float64, len(s.vals))
+		for i, v := range s.vals {
+			lo, hi := base, v
+			if g.stacked {
+				lo, hi = st.add(i, v)
+			}
+			if math.IsNaN(v) {
+				ends[si][i] = math.NaN()
+				continue
+			}
+			a0, a1 := val.coord(val.clamp(lo)), val.coord(val.clamp(hi))
+			ends[si][i] = a1
+			c := cat.catCoord(float64(i)) + offset
+			fill, line := r.pointPaint(g, s, i, "", 0.75)
+			invert := s.invert
+			if p := s.point(i); p != nil && p.InvertIfNegative != nil {
+				invert = boolVal(p.InvertIfNegative, false)
+			}
+			if invert && v < 0 {
+				if line.color == "" {
+					line = chartPaint{color: fill.color, opacity: fill.opacity, width: 0.75}
+				}
+				fill = autoPaint("FFFFFF", 0)
+			}
+			box := Rectangle{Left: c - width/2, Right: c + width/2, Top: math.Min(a0, a1), Bottom: math.Max(a0, a1)}
+			growth := draw.Point{Y: grow * sign(hi-lo)}
+			if g.horizontal {
+				box = Rectangle{Top: c - width/2, Bottom: c + width/2, Left: math.Min(a0, a1), Right: math.Max(a0, a1)}
+				growth = draw.Point{X: grow * sign(hi-lo)}
+			}
+			r.drawRect(box.Left, box.Top, box.Right-box.Left, box.Bottom-box.Top, fill, line)
+			r.queueLabel(g, s, i, v, box, growth)
+		}
+	}
+	if !g.stacked || len(g.serLines) == 0 {
+		return
+	}
+	paint := r.line(g.serLines[0].SpPr, "000000", 0.75)
+	for si := range g.series {
+		for i := 0; i+1 < len(ends[si]); i++ {
+			e0, e1 := ends[si][i], ends[si][i+1]
+			if math.IsNaN(e0) || math.IsNaN(e1) {
+				continue
+			}
+			c0 := cat.catCoord(float64(i)) + dir*width/2
+			c1 := cat.catCoord(float64(i+1)) - dir*width/2
+			if g.horizontal {
+				r.drawLine(e0, c0, e1, c1, paint)
+			} else {
+				r.drawLine(c0, e0, c1, e1, paint)
+			}
+		}
+	}
+}
+
+// linePoints returns the runs of points of a series, split at missing
+// values unless blanks are spanned or shown as zero.
+func (r *chartRenderer) linePoints(n int, at func(i int) (draw.Point, bool)) [][]draw.Point {
+	var runs [][]draw.Point
+	var run []draw.Point
+	for i := 0; i < n; i++ {
+		p, ok := at(i)
+		if !ok {
+			if r.blanks != chart.ST_DispBlanksAsSpan && len(run) > 0 {
+				runs = append(runs, run)
+				run = nil
+			}
+			continue
+		}
+		run = append(run, p)
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// blank returns the value shown for a missing value.
+func (r *chartRenderer) blank(v float64) (float64, bool) {
+	if !math.IsNaN(v) {
+		return v, true
+	}
+	if r.blanks == chart.ST_DispBlanksAsZero {
+		return 0, true
+	}
+	return v, false
+}
+
+// drawLines draws the series of line and stock charts with their markers,
+// drop lines, high-low lines and up-down bars.
+func (r *chartRenderer) drawLines(g *chartGroup) {
+	cat, val := g.cat, g.val
+	st := newStacker(g)
+	n := 0
+	for _, s := range g.series {
+		if len(s.vals) > n {
+			n = len(s.vals)
+		}
+	}
+	ys := make([][]float64, len(g.series))
+	for si, s := range g.series {
+		ys[si] = make([]float64, n)
+		for i := range ys[si] {
+			ys[si][i] = math.NaN()
+			if i >= len(s.vals) {
+				continue
+			}
+			v, ok := r.blank(s.vals[i])
+			if g.stacked {
+				_, top := st.add(i, v)
+				if ok {
+					ys[si][i] = val.coord(val.clamp(top))
+				}
+			} else if ok {
+				ys[si][i] = val.coord(val.clamp(v))
+			}
+		}
+	}
+
+	if g.upDownBars != nil && len(g.series) > 1 {
+		r.drawUpDownBars(g, ys[0], ys[len(ys)-1])
+	}
+	if g.hiLowLines != nil {
+		paint := r.line(g.hiLowLines.SpPr, "000000", 0.75)
+		for i := 0; i < n; i++ {
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for si := range ys {
+				if y := ys[si][i]; !math.IsNaN(y) {
+					lo, hi = math.Min(lo, y), math.Max(hi, y)
+				}
+			}
+			if hi > lo {
+				x := cat.catCoord(float64(i))
+				r.drawLine(x, lo, x, hi, paint)
+			}
+		}
+	}
+	if g.dropLines != nil {
+		paint := r.line(g.dropLines.SpPr, "000000", 0.75)
+		for i := 0; i < n; i++ {
+			top := math.NaN()
+			for si := range ys {
+				y := ys[si][i]
+				if !math.IsNaN(y) && (math.IsNaN(top) || math.Abs(y-cat.line) > math.Abs(top-cat.line)) {
+					top = y
+				}
+			}
+			if !math.IsNaN(top) {
+				x := cat.catCoord(float64(i))
+				r.drawLine(x, top, x, cat.line, paint)
+			}
+		}
+	}
+
+	for si, s := range g.series {
+		color := r.autoColor(s.index)
+		line := r.line(s.spPr, r.seriesLine(g, color), 2.25)
+		runs := r.linePoints(n, func(i int) (draw.Point, bool) {
+			y := ys[si][i]
+			return draw.Point{X: cat.catCoord(float64(i)), Y: y}, !math.IsNaN(y)
+		})
+		for _, run := range runs {
+			if s.smooth {
+				r.drawCurve(run, line)
+			} else {
+				r.drawPolyline(run, line)
+			}
+		}
+		r.drawSeriesMarkers(g, s, color, func(i int) (float64, float64, bool) {
+			if i >= n {
+				return 0, 0, false
+			}
+			y := ys[si][i]
+			return cat.catCoord(float64(i)), y, !math.IsNaN(y)
+		})
+		for i := 0; i < n && i < len(s.vals); i++ {
+			if y := ys[si][i]; !math.IsNaN(y) {
+				x := cat.catCoord(float64(i))
+				r.queueLabel(g, s, i, s.vals[i], Rectangle{Left: x, Right: x, Top: y, Bottom: y}, draw.Point{Y: -1})
+			}
+		}
+	}
+}
+
+// drawSeriesMarkers draws the markers of the points of a series at the
+// coordinates returned by at.
+func (r *chartRenderer) drawSeriesMarkers(g *chartGroup, s *chartSeries, color string, at func(i int) (float64, float64, bool)) {
+	for i := range s.vals {
+		var m *chart.CT_Marker
+		if p := s.point(i); p != nil {
+			m = p.Marker
+		}
+		symbol, size := r.markerStyle(g, s, m)
+		if symbol == chart.ST_MarkerStyleNone {
+			continue
+		}
+		x, y, ok := at(i)
+		if !ok {
+			continue
+		}
+		c := color
+		if g.varies() {
+			c = r.autoColor(i)
+		}
+		fill, line := r.markerPaint(s, m, c)
+		r.drawMarker(symbol, size, x, y, fill, line)
+	}
+}
+
+// drawUpDownBars draws the bars between the first and the last series of a
+// line or stock chart.
+func (r *chartRenderer) drawUpDownBars(g *chartGroup, first, last []float64) {
+	b := g.upDownBars
+	gap := gapAmount(b.GapWidth, 150)
+	width := g.cat.slot() / (1 + gap/100)
+	var upSp, downSp *dml.CT_ShapeProperties
+	if b.UpBars != nil {
+		upSp = b.UpBars.SpPr
+	}
+	if b.DownBars != nil {
+		downSp = b.DownBars.SpPr
+	}
+	for i := range first {
+		y0, y1 := first[i], last[i]
+		if math.IsNaN(y0) || math.IsNaN(y1) {
+			continue
+		}
+		x := g.cat.catCoord(float64(i))
+		// coordinates grow downwards, so a rising value has a smaller y
+		up := (y1 < y0) == (g.val.end < g.val.start)
+		fill, line := r.fill(upSp, "FFFFFF"), r.line(upSp, "000000", 0.75)
+		if !up {
+			fill, line = r.fill(downSp, "000000"), r.line(downSp, "000000", 0.75)
+		}
+		r.drawRect(x-width/2, math.Min(y0, y1), width, math.Abs(y1-y0), fill, line)
+	}
+}
+
+// drawAreas draws standard and stacked areas.
+func (r *chartRenderer) drawAreas(g *chartGroup) {
+	cat, val := g.cat, g.val
+	st := newStacker(g)
+	base := val.coord(val.crossValue(cat))
+	n := 0
+	for _, s := range g.series {
+		if len(s.vals) > n {
+			n = len(s.vals)
+		}
+	}
+	type area struct {
+		s      *chartSeries
+		top    []draw.Point
+		bottom []draw.Point
+	}
+	var areas []area
+	for _, s := range g.series {
+		a := area{s: s}
+		for i := 0; i < n; i++ {
+			v := math.NaN()
+			if i < len(s.vals) {
+				v = s.vals[i]
+			}
+			x := cat.catCoord(float64(i))
+			lo, hi := 0.0, v
+			if g.stacked {
+				lo, hi = st.add(i, v)
+				a.bottom = append(a.bottom, draw.Point{X: x, Y: val.coord(val.clamp(lo))})
+			} else {
+				a.bottom = append(a.bottom, draw.Point{X: x, Y: base})
+			}
+			if math.IsNaN(hi) {
+				hi = val.crossValue(cat)
+			}
+			a.top = append(a.top, draw.Point{X: x, Y: val.coord(val.clamp(hi))})
+		}
+		areas = append(areas, a)
+	}
+	if g.threeD && !g.stacked {
+		// the first series is in front of 3D areas
+		for i, j := 0, len(areas)-1; i < j; i, j = i+1, j-1 {
+			areas[i], areas[j] = areas[j], areas[i]
+		}
+	}
+	for _, a := range areas {
+		pts := append([]draw.Point(nil), a.top...)
+		for i := len(a.bottom) - 1; i >= 0; i-- {
+			pts = append(pts, a.bottom[i])
+		}
+		fill, line := r.pointPaint(g, a.s, -1, "", 0.75)
+		r.drawPolygon(pts, fill, noPaint)
+		r.drawPolyline(a.top, line)
+	}
+	if g.dropLines != nil {
+		paint := r.line(g.dropLines.SpPr, "000000", 0.75)
+		for i := 0; i < n; i++ {
+			top := base
+			for _, a := range areas {
+				if math.Abs(a.top[i].Y-base) > math.Abs(top-base) {
+					top = a.top[i].Y
+				}
+			}
+			r.drawLine(cat.catCoord(float64(i)), top, cat.catCoord(float64(i)), base, paint)
+		}
+	}
+	for _, a := range areas {
+		for i, p := range a.top {
+			if i < len(a.s.vals) && !math.IsNaN(a.s.vals[i]) {
+				y := (p.Y + a.bottom[i].Y) / 2
+				r.queueLabel(g, a.s, i, a.s.vals[i], Rectangle{Left: p.X, Right: p.X, Top: y, Bottom: y}, draw.Point{Y: -1})
+			}
+		}
+	}
+}
+
+// drawScatter draws the points of scatter series with the lines between
+// them unless the series have no line.
+func (r *chartRenderer) drawScatter(g *chartGroup) {
+	xa, ya := g.cat, g.val
+	for _, s := range g.series {
+		color := r.autoColor(s.index)
+		at := func(i int) (float64, float64, bool) {
+			x := s.x(i)
+			v, ok := r.blank(s.vals[i])
+			if !ok || math.IsNaN(x) {
+				return 0, 0, false
+			}
+			return xa.coord(xa.clamp(x)), ya.coord(ya.clamp(v)), true
+		}
+		line := r.line(s.spPr, color, 2.25)
+		runs := r.linePoints(len(s.vals), func(i int) (draw.Point, bool) {
+			x, y, ok := at(i)
+			return draw.Point{X: x, Y: y}, ok
+		})
+		for _, run := range runs {
+			if s.smooth {
+				r.drawCurve(run, line)
+			} else {
+				r.drawPolyline(run, line)
+			}
+		}
+		r.drawSeriesMarkers(g, s, color, at)
+		for i, v := range s.vals {
+			if x, y, ok := at(i); ok {
+				r.queueLabel(g, s, i, v, Rectangle{Left: x, Right: x, Top: y, Bottom: y}, draw.Point{Y: -1})
+			}
+		}
+	}
+}
+
+// drawBubbles draws the bubbles of bubble series, scaled by their area or
+// width so that the largest bubble is a quarter of the plot area.
+func (r *chartRenderer) drawBubbles(g *chartGroup) {
+	xa, ya := g.cat, g.val
+	maxSize := 0.0
+	for _, s := range g.series {
+		for _, v := range s.sizes {
+			if !math.IsNaN(v) {
+				maxSize = math.Max(maxSize, math.Abs(v))
+			}
+		}
+	}
+	if maxSize == 0 {
+		return
+	}
+	maxD := math.Min(r.plot.Right-r.plot.Left, r.plot.Bottom-r.plot.Top) / 4 * g.bubbleScale / 100
+	for _, s := range g.series {
+		for i, v := range s.vals {
+			x := s.x(i)
+			if math.IsNaN(v) || math.IsNaN(x) || i >= len(s.sizes) || math.IsNaN(s.sizes[i]) {
+				continue
+			}
+			size := s.sizes[i]
+			if size < 0 && !g.showNeg || size == 0 {
+				continue
+			}
+			d := maxD * math.Sqrt(math.Abs(size)/maxSize)
+			if g.sizeWidth {
+				d = maxD * math.Abs(size) / maxSize
+			}
+			cx, cy := xa.coord(xa.clamp(x)), ya.coord(ya.clamp(v))
+			fill, line := r.pointPaint(g, s, i, "", 0.75)
+			if size < 0 {
+				if line.color == "" {
+					line = chartPaint{color: fill.color, opacity: 1, width: 0.75}
+				}
+				fill = noPaint
+			}
+			r.drawPolygon(circlePoints(cx, cy, d/2, d/2, 0, 360), fill, line)
+			r.queueLabel(g, s, i, v, Rectangle{Left: cx, Right: cx, Top: cy, Bottom: cy}, draw.Point{Y: -1})
+		}
+	}
+}
+
+// drawSurface draws a surface chart seen from above: every cell between
+// the categories and the series is filled with the color of the band its
+// value falls in.
+func (r *chartRenderer) drawSurface(g *chartGroup) {
+	cat, bands := g.cat, g.bandAxis()
+	rows := len(g.series)
+	if rows == 0 {
+		return
+	}
+	rowCoord := func(j int) (float64, float64) {
+		if g.ser != nil {
+			c := g.ser.catCoord(float64(j))
+			h := g.ser.slot() / 2
+			return c - h, c + h
+		}
+		h := (r.plot.Bottom - r.plot.Top) / float64(rows)
+		return r.plot.Bottom - float64(j+1)*h, r.plot.Bottom - float64(j)*h
+	}
+	w := cat.slot()
+	for j, s := range g.series {
+		y0, y1 := rowCoord(j)
+		for i, v := range s.vals {
+			if math.IsNaN(v) {
+				continue
+			}
+			x := cat.catCoord(float64(i))
+			fill, line := r.bandPaint(g, bands.band(v))
+			if g.wireframe {
+				if line.color == "" {
+					line = chartPaint{color: fill.color, opacity: fill.opacity, width: 0.75}
+				}
+				fill = noPaint
+			}
+			r.drawRect(x-w/2, math.Min(y0, y1), w, math.Abs(y1-y0), fill, line)
+		}
+	}
+}
+
+// band returns the index of the band between two major ticks a value
+// falls in.
+func (a *chartAxis) band(v float64) int {
+	ticks := a.ticks(false)
+	for i := 1; i < len(ticks); i++ {
+		if v < ticks[i] {
+			return i - 1
+		}
+	}
+	if len(ticks) > 1 {
+		return len(ticks) - 2
+	}
+	return 0
+}
+
+func (r *chartRenderer) bandPaint(g *chartGroup, band int) (chartPaint, chartPaint) {
+	var sp *dml.CT_ShapeProperties
+	if g.bandFmts != nil {
+		for _, b := range g.bandFmts.BandFmt {
+			if b.Idx != nil && int(b.Idx.ValAttr) == band {
+				sp = b.SpPr
+			}
+		}
+	}
+	return r.fill(sp, r.autoColor(band)), r.line(sp, "", 0.75)
+}
+
+// bandAxis returns the scale the bands of a surface chart are taken from.
+// The bands do not depend on the value axis as the legend is laid out
+// before the axes.
+func (g *chartGroup) bandAxis() *chartAxis {
+	if g.bands != nil {
+		return g.bands
+	}
+	a := &chartAxis{lo: 0, hi: 1, unit: 1}
+	if lo, hi, ok := g.valueRange(); ok {
+		a.unit = niceUnit((hi - lo) / 5)
+		a.lo, a.hi = math.Floor(lo/a.unit)*a.unit, math.Ceil(hi/a.unit)*a.unit
+		if a.hi <= a.lo {
+			a.hi = a.lo + a.unit
+		}
+	}
+	if len(g.series) > 0 {
+		a.format = g.series[0].format
+	}
+	g.bands = a
+	return a
+}
+
+// bandLegendItems returns the legend entries of the bands of a surface
+// chart, which are named after their ranges.
+func (r *chartRenderer) bandLegendItems(g *chartGroup) []*legendItem {
+	a := g.bandAxis()
+	var items []*legendItem
+	ticks := a.ticks(false)
+	for i := 0; i+1 < len(ticks); i++ {
+		it := &legendItem{text: a.label(ticks[i]) + "-" + a.label(ticks[i+1])}
+		it.fill, it.line = r.bandPaint(g, i)
+		if g.wireframe {
+			it.lineKey = true
+			it.line = chartPaint{color: it.fill.color, opacity: it.fill.opacity, width: 2.25}
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// drawRadar draws a radar chart in the plot area with the categories on
+// spokes and the value axis as the first spoke.
+func (r *chartRenderer) drawRadar(g *chartGroup, rect Rectangle) {
+	cat, val := g.cat, g.val
+	cat.text = r.textStyle(r.text, cat.txPr)
+	val.text = r.textStyle(r.text, val.txPr)
+	r.setCategories(cat, []*chartGroup{g})
+	n := len(cat.cats)
+	if n == 0 {
+		return
+	}
+	lineH := r.pt(cat.text.size) * 1.2
+	labelW := 0.0
+	if !cat.deleted {
+		labelW = r.maxLabelWidth(cat)
+	}
+	radius := math.Min(rect.Right-rect.Left-2*labelW, rect.Bottom-rect.Top-2*lineH)/2 - r.pt(4)
+	if radius < r.pt(10) {
+		radius = r.pt(10)
+	}
+	r.scaleValues(val, []*chartGroup{g}, radius)
+	val.start, val.end = 0, radius
+	cx, cy := (rect.Left+rect.Right)/2, (rect.Top+rect.Bottom)/2
+	at := func(i int, d float64) (float64, float64) {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		return cx + d*math.Sin(a), cy - d*math.Cos(a)
+	}
+	ring := func(d float64) []draw.Point {
+		pts := make([]draw.Point, 0, n+1)
+		for i := 0; i <= n; i++ {
+			x, y := at(i%n, d)
+			pts = append(pts, draw.Point{X: x, Y: y})
+		}
+		return pts
+	}
+
+	if val.majorGrid != nil {
+		paint := r.line(val.majorGrid.SpPr, "D9D9D9", 0.75)
+		for _, v := range val.ticks(false) {
+			r.drawPolyline(ring(val.coord(v)), paint)
+		}
+	}
+	if !cat.deleted {
+		spoke := r.line(cat.spPr, "D9D9D9", 0.75)
+		if cat.majorGrid != nil {
+			spoke = r.line(cat.majorGrid.SpPr, "D9D9D9", 0.75)
+		}
+		for i := 0; i < n; i++ {
+			x, y := at(i, radius)
+			r.drawLine(cx, cy, x, y, spoke)
+		}
+	}
+
+	for _, s := range g.series {
+		color := r.autoColor(s.index)
+		var pts []draw.Point
+		for i := 0; i < n && i < len(s.vals); i++ {
+			v, ok := r.blank(s.vals[i])
+			if !ok {
+				v = val.lo
+			}
+			x, y := at(i, val.coord(val.clamp(v)))
+			pts = append(pts, draw.Point{X: x, Y: y})
+		}
+		if len(pts) == 0 {
+			continue
+		}
+		if g.filled {
+			fill, line := r.pointPaint(g, s, -1, "", 0.75)
+			r.drawPolygon(pts, fill, line)
+		} else {
+			r.drawPolyline(append(pts, pts[0]), r.line(s.spPr, color, 2.25))
+			r.drawSeriesMarkers(g, s, color, func(i int) (float64, float64, bool) {
+				if i >= len(pts) {
+					return 0, 0, false
+				}
+				return pts[i].X, pts[i].Y, true
+			})
+		}
+		for i, p := range pts {
+			r.queueLabel(g, s, i, s.vals[i], Rectangle{Left: p.X, Right: p.X, Top: p.Y, Bottom: p.Y}, draw.Point{Y: -1})
+		}
+	}
+
+	if !val.deleted {
+		paint := r.line(val.spPr, "000000", 0.75)
+		r.drawLine(cx, cy, cx, cy-radius, paint)
+		if val.lblPos != chart.ST_TickLblPosNone {
+			size := r.pt(val.text.size)
+			for _, v := range val.ticks(false) {
+				s := val.label(v)
+				r.drawString(s, val.text, cx-r.pt(4)-r.textWidth(s, val.text), cy-val.coord(v)-size*0.6, 0)
+			}
+		}
+	}
+	if !cat.deleted && cat.lblPos != chart.ST_TickLblPosNone {
+		for i, s := range cat.cats {
+			a := 2 * math.Pi * float64(i) / float64(n)
+			x, y := at(i, radius+r.pt(4))
+			w := r.textWidth(s, cat.text)
+			switch sin := math.Sin(a); {
+			case sin < -0.1:
+				x -= w
+			case sin <= 0.1:
+				x -= w / 2
+			}
+			switch cos := math.Cos(a); {
+			case cos > 0.1:
+				y -= lineH
+			case cos >= -0.1:
+				y -= lineH / 2
+			}
+			r.drawString(s, cat.text, x, y, 0)
+		}
+	}
+}
+
+// pieRadius returns the radius of a pie in a rectangle, leaving room for
+// data labels outside of the pie.
+func (r *chartRenderer) pieRadius(g *chartGroup, w, h float64) float64 {
+	radius := math.Min(w, h) / 2
+	if g.dLbls != nil || (len(g.series) > 0 && g.series[0].dLbls != nil) {
+		radius -= r.pt(r.text.size) * 1.5
+	}
+	return math.Max(radius, r.pt(5))
+}
+
+// drawPie draws a pie with the first series of a group or a doughnut with
+// a ring for each series.
+func (r *chartRenderer) drawPie(g *chartGroup, rect Rectangle) {
+	if len(g.series) == 0 {
+		return
+	}
+	cx, cy := (rect.Left+rect.Right)/2, (rect.Top+rect.Bottom)/2
+	radius := r.pieRadius(g, rect.Right-rect.Left, rect.Bottom-rect.Top)
+	series := g.series
+	if g.kind == chartPie {
+		series = series[:1]
+	}
+	// exploded slices move out from the center, the pie shrinks to make
+	// room for them
+	maxExp := 0.0
+	for _, s := range series {
+		for i := range s.vals {
+			maxExp = math.Max(maxExp, s.pointExplosion(i))
+		}
+	}
+	radius /= 1 + maxExp/100
+	hole := 0.0
+	if g.kind == chartDoughnut {
+		hole = radius * g.holeSize / 100
+	}
+	ring := (radius - hole) / float64(len(series))
+	for j, s := range series {
+		total := s.total()
+		if total == 0 {
+			continue
+		}
+		inner := hole + float64(j)*ring
+		outer := inner + ring
+		if g.kind == chartPie {
+			inner, outer = 0, radius
+		}
+		a := g.firstSlice
+		for i, v := range s.vals {
+			if math.IsNaN(v) || v <= 0 {
+				continue
+			}
+			sweep := v / total * 360
+			mid := (a + sweep/2) * math.Pi / 180
+			ex := s.pointExplosion(i) / 100 * radius
+			px, py := cx+ex*math.Sin(mid), cy-ex*math.Cos(mid)
+			fill, line := r.pointPaint(g, s, i, "FFFFFF", 0.75)
+			r.drawPolygon(slice(px, py, inner, outer, a, a+sweep), fill, line)
+			r.queuePieLabel(g, s, i, v, total, px, py, inner, outer, mid)
+			a += sweep
+		}
+	}
+}
+
+// slice returns the outline of a pie slice or, with an inner radius, of a
+// ring segment.
+func slice(cx, cy, inner, outer, a0, a1 float64) []draw.Point {
+	pts := circlePoints(cx, cy, outer, outer, a0, a1)
+	if inner <= 0 {
+		if a1-a0 >= 360 {
+			return pts
+		}
+		return append(pts, draw.Point{X: cx, Y: cy})
+	}
+	in := circlePoints(cx, cy, inner, inner, a0, a1)
+	for i := len(in) - 1; i >= 0; i-- {
+		pts = append(pts, in[i])
+	}
+	return pts
+}
+
+func (s *chartSeries) total() float64 {
+	total := 0.0
+	for _, v := range s.vals {
+		if !math.IsNaN(v) && v > 0 {
+			total += v
+		}
+	}
+	return total
+}
+
+func (s *chartSeries) pointExplosion(i int) float64 {
+	if p := s.point(i); p != nil && p.Explosion != nil {
+		return float64(p.Explosion.ValAttr)
+	}
+	return s.explosion
+}
+
+// drawOfPie draws a pie of pie or bar of pie chart: the points split off
+// the first series are summed up in a slice of the primary pie and shown
+// in a secondary pie or stacked bar on its right.
+func (r *chartRenderer) drawOfPie(g *chartGroup, rect Rectangle) {
+	if len(g.series) == 0 {
+		return
+	}
+	s := g.series[0]
+	total := s.total()
+	if total == 0 {
+		return
+	}
+	second := r.splitPoints(g, s, total)
+	otherSum := 0.0
+	for i := range s.vals {
+		if second[i] && s.vals[i] > 0 {
+			otherSum += s.vals[i]
+		}
+	}
+
+	w, h := rect.Right-rect.Left, rect.Bottom-rect.Top
+	gap, k := g.gapWidth/100, g.secondSize/100
+	radius := math.Min(h/2, w/(2+gap+2*k)) * 0.9
+	if g.dLbls != nil || s.dLbls != nil {
+		radius = math.Max(radius-r.pt(r.text.size)*1.5, r.pt(5))
+	}
+	r2 := radius * k
+	used := 2*radius + gap*radius + 2*r2
+	cx := rect.Left + (w-used)/2 + radius
+	cy := (rect.Top + rect.Bottom) / 2
+	cx2 := cx + radius + gap*radius + r2
+
+	// the slice of the split points faces the secondary chart
+	otherSweep := otherSum / total * 360
+	a := 90 + otherSweep/2
+	for i, v := range s.vals {
+		if second[i] || math.IsNaN(v) || v <= 0 {
+			continue
+		}
+		sweep := v / total * 360
+		fill, line := r.pointPaint(g, s, i, "FFFFFF", 0.75)
+		r.drawPolygon(slice(cx, cy, 0, radius, a, a+sweep), fill, line)
+		r.queuePieLabel(g, s, i, v, total, cx, cy, 0, radius, (a+sweep/2)*math.Pi/180)
+		a += sweep
+	}
+	if otherSum == 0 {
+		return
+	}
+	otherFill, otherLine := r.pointPaint(g, s, len(s.vals), "FFFFFF", 0.75)
+	r.drawPolygon(slice(cx, cy, 0, radius, 90-otherSweep/2, 90+otherSweep/2), otherFill, otherLine)
+
+	top := circlePoints(cx, cy, radius, radius, 90-otherSweep/2, 90-otherSweep/2)[0]
+	bottom := circlePoints(cx, cy, radius, radius, 90+otherSweep/2, 90+otherSweep/2)[0]
+	var lineTop, lineBottom draw.Point
+	if g.ofPieBar {
+		barW := r2 * 0.7
+		barH := 2 * r2
+		x := cx2 - barW/2
+		y := cy + r2
+		for i, v := range s.vals {
+			if !second[i] || math.IsNaN(v) || v <= 0 {
+				continue
+			}
+			bh := v / otherSum * barH
+			fill, line := r.pointPaint(g, s, i, "FFFFFF", 0.75)
+			r.drawRect(x, y-bh, barW, bh, fill, line)
+			r.queueLabel(g, s, i, v, Rectangle{Left: x, Right: x + barW, Top: y - bh, Bottom: y}, draw.Point{Y: -1})
+			y -= bh
+		}
+		lineTop, lineBottom = draw.Point{X: x, Y: cy - r2}, draw.Point{X: x, Y: cy + r2}
+	} else {
+		a2 := 0.0
+		for i, v := range s.vals {
+			if !second[i] || math.IsNaN(v) || v <= 0 {
+				continue
+			}
+			sweep := v / otherSum * 360
+			fill, line := r.pointPaint(g, s, i, "FFFFFF", 0.75)
+			r.drawPolygon(slice(cx2, cy, 0, r2, a2, a2+sweep), fill, line)
+			r.queuePieLabel(g, s, i, v, total, cx2, cy, 0, r2, (a2+sweep/2)*math.Pi/180)
+			a2 += sweep
+		}
+		lineTop, lineBottom = draw.Point{X: cx2, Y: cy - r2}, draw.Point{X: cx2, Y: cy + r2}
+	}
+	if len(g.serLines) > 0 {
+		paint := r.line(g.serLines[0].SpPr, "000000", 0.75)
+		r.drawLine(top.X, top.Y, lineTop.X, lineTop.Y, paint)
+		r.drawLine(bottom.X, bottom.Y, lineBottom.X, lineBottom.Y, paint)
+	}
+}
+
+// splitPoints returns which points of an of-pie series go to the secondary
+// chart.
+func (r *chartRenderer) splitPoints(g *chartGroup, s *chartSeries, total float64) map[int]bool {
+	second := map[int]bool{}
+	switch g.splitType {
+	case chart.ST_SplitTypeVal:
+		for i, v := range s.vals {
+			second[i] = !math.IsNaN(v) && v < g.splitPos
+		}
+	case chart.ST_SplitTypePercent:
+		for i, v := range s.vals {
+			second[i] = !math.IsNaN(v) && v/total*100 < g.splitPos
+		}
+	case chart.ST_SplitTypeCust:
+		for _, i := range g.custSplit {
+			second[i] = true
+		}
+	default:
+		n := int(g.splitPos)
+		for i := len(s.vals) - n; i < len(s.vals); i++ {
+			if i >= 0 {
+				second[i] = true
+			}
+		}
+	}
+	return second
+}
+
+// pendingLabel is a data label drawn after the axes.
+type pendingLabel struct {
+	lbl  *chartLabel
+	x, y float64
+	spPr *dml.CT_ShapeProperties
+}
+
+// labelSpec is the resolved data label of a point.
+type labelSpec struct {
+	val, cat, ser, pct, size bool
+	sep                      string
+	numFmt                   string
+	pos                      chart.ST_DLblPos
+	text                     chartText
+	spPr                     *dml.CT_ShapeProperties
+	custom                   []string
+	deleted                  bool
+}
+
+func (l *labelSpec) apply(del *chart.CT_Boolean, numFmt *chart.CT_NumFmt, spPr *dml.CT_ShapeProperties, pos *chart.CT_DLblPos,
+	val, cat, ser, pct, size *chart.CT_Boolean, sep *string) {
+	l.deleted = boolVal(del, false)
+	if numFmt != nil && numFmt.FormatCodeAttr != "" && (numFmt.SourceLinkedAttr == nil || !*numFmt.SourceLinkedAttr) {
+		l.numFmt = numFmt.FormatCodeAttr
+	}
+	if spPr != nil {
+		l.spPr = spPr
+	}
+	if pos != nil && pos.ValAttr != chart.ST_DLblPosUnset {
+		l.pos = pos.ValAttr
+	}
+	set := func(dst *bool, b *chart.CT_Boolean) {
+		if b != nil {
+			*dst = boolVal(b, false)
+		}
+	}
+	set(&l.val, val)
+	set(&l.cat, cat)
+	set(&l.ser, ser)
+	set(&l.pct, pct)
+	set(&l.size, size)
+	if sep != nil {
+		l.sep = *sep
+	}
+}
+
+// dataLabel resolves the data label of a point from the labels of the
+// group, of the series and of the point. It returns nil for points without
+// a label.
+func (r *chartRenderer) dataLabel(g *chartGroup, s *chartSeries, i int) *labelSpec {
+	l := &labelSpec{sep: ", ", text: r.text, numFmt: s.format}
+	found := false
+	for _, d := range []*chart.CT_DLbls{g.dLbls, s.dLbls} {
+		if d == nil || d.Choice == nil {
+			continue
+		}
+		c := d.Choice
+		l.apply(c.Delete, c.NumFmt, c.SpPr, c.DLblPos, c.ShowVal, c.ShowCatName, c.ShowSerName, c.ShowPercent, c.ShowBubbleSize, c.Separator)
+		l.text = r.textStyle(l.text, c.TxPr)
+		found = true
+	}
+	if s.dLbls != nil {
+		for _, d := range s.dLbls.DLbl {
+			if d.Idx == nil || int(d.Idx.ValAttr) != i || d.Choice == nil {
+				continue
+			}
+			c := d.Choice
+			l.apply(c.Delete, c.NumFmt, c.SpPr, c.DLblPos, c.ShowVal, c.ShowCatName, c.ShowSerName, c.ShowPercent, c.ShowBubbleSize, c.Separator)
+			l.text = r.textStyle(l.text, c.TxPr)
+			if c.Tx != nil && c.Tx.Choice != nil && c.Tx.Choice.Rich != nil {
+				l.text = r.textStyle(l.text, c.Tx.Choice.Rich)
+				l.custom = r.richText(c.Tx.Choice.Rich, &l.text)
+			}
+			found = true
+		}
+	}
+	if !found || l.deleted {
+		return nil
+	}
+	if !g.round() {
+		l.pct = false
+	}
+	if g.kind != chartBubble {
+		l.size = false
+	}
+	if len(l.custom) == 0 && !l.val && !l.cat && !l.ser && !l.pct && !l.size {
+		return nil
+	}
+	return l
+}
+
+// labelText returns the text of a data label in the order Office shows the
+// parts.
+func (r *chartRenderer) labelText(l *labelSpec, s *chartSeries, i int, v, total float64) []string {
+	if len(l.custom) > 0 {
+		return l.custom
+	}
+	var parts []string
+	if l.ser {
+		parts = append(parts, s.name)
+	}
+	if l.cat {
+		if i < len(s.cats) {
+			parts = append(parts, s.cats[i])
+		} else {
+			parts = append(parts, strconv.Itoa(i+1))
+		}
+	}
+	if l.val {
+		parts = append(parts, formatChartValue(v, l.numFmt))
+	}
+	if l.pct && total != 0 {
+		parts = append(parts, formatChartValue(v/total, "0%"))
+	}
+	if l.size && i < len(s.sizes) && !math.IsNaN(s.sizes[i]) {
+		parts = append(parts, formatChartValue(s.sizes[i], ""))
+	}
+	if strings.Contains(l.sep, "\n") {
+		return strings.Split(strings.Join(parts, l.sep), "\n")
+	}
+	return []string{strings.Join(parts, l.sep)}
+}
+
+// queueLabel adds the data label of a point shown in box, growth is the
+// direction from the base of a bar to its end.
+func (r *chartRenderer) queueLabel(g *chartGroup, s *chartSeries, i int, v float64, box Rectangle, growth draw.Point) {
+	spec := r.dataLabel(g, s, i)
+	if spec == nil {
+		return
+	}
+	lbl := r.newLabel(r.labelText(spec, s, i, v, 0), spec.text)
+	pos := spec.pos
+	if pos == chart.ST_DLblPosUnset || pos == chart.ST_DLblPosBestFit {
+		switch {
+		case g.kind == chartBar && g.stacked, g.kind == chartArea, g.kind == chartBubble, g.kind == chartSurface:
+			pos = chart.ST_DLblPosCtr
+		case g.kind == chartBar:
+			pos = chart.ST_DLblPosOutEnd
+		default:
+			pos = chart.ST_DLblPosR
+		}
+	}
+	gap := r.pt(3)
+	cx, cy := (box.Left+box.Right)/2, (box.Top+box.Bottom)/2
+	x, y := cx-lbl.w/2, cy-lbl.h/2
+	along := func(d float64) {
+		x += growth.X * d
+		y += growth.Y * d
+	}
+	half := (box.Bottom - box.Top) / 2
+	lhalf := lbl.h / 2
+	if growth.X != 0 {
+		half, lhalf = (box.Right-box.Left)/2, lbl.w/2
+	}
+	switch pos {
+	case chart.ST_DLblPosOutEnd:
+		along(half + gap + lhalf)
+	case chart.ST_DLblPosInEnd:
+		along(half - gap - lhalf)
+	case chart.ST_DLblPosInBase:
+		along(-half + gap + lhalf)
+	case chart.ST_DLblPosT:
+		y = box.Top - gap - lbl.h
+	case chart.ST_DLblPosB:
+		y = box.Bottom + gap
+	case chart.ST_DLblPosL:
+		x = box.Left - gap - lbl.w
+	case chart.ST_DLblPosR:
+		x = box.Right + gap
+	}
+	r.labels = append(r.labels, pendingLabel{lbl: lbl, x: x, y: y, spPr: spec.spPr})
+}
+
+// queuePieLabel adds the data label of a slice, mid is the angle of the
+// middle of the slice in radians clockwise from the top.
+func (r *chartRenderer) queuePieLabel(g *chartGroup, s *chartSeries, i int, v, total, cx, cy, inner, outer, mid float64) {
+	spec := r.dataLabel(g, s, i)
+	if spec == nil {
+		return
+	}
+	lbl := r.newLabel(r.labelText(spec, s, i, v, total), spec.text)
+	d := inner + (outer-inner)*0.6
+	if g.kind == chartDoughnut {
+		d = (inner + outer) / 2
+	}
+	switch spec.pos {
+	case chart.ST_DLblPosInEnd:
+		d = inner + (outer-inner)*0.8
+	case chart.ST_DLblPosInBase:
+		d = inner + (outer-inner)*0.3
+	case chart.ST_DLblPosOutEnd:
+		d = outer + r.pt(3)
+	}
+	sin, cos := math.Sin(mid), math.Cos(mid)
+	x, y := cx+d*sin, cy-d*cos
+	if spec.pos == chart.ST_DLblPosOutEnd {
+		// outside labels extend away from the pie
+		if sin < -0.1 {
+			x -= lbl.w
+		} else if sin <= 0.1 {
+			x -= lbl.w / 2
+		}
+		if cos > 0.1 {
+			y -= lbl.h
+		} else if cos >= -0.1 {
+			y -= lbl.h / 2
+		}
+	} else {
+		x, y = x-lbl.w/2, y-lbl.h/2
+	}
+	r.labels = append(r.labels, pendingLabel{lbl: lbl, x: x, y: y, spPr: spec.spPr})
+}
@@ -0,0 +1,256 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unioffice/common/tempstorage"
+)
+
+// HTMLOptions contains the options for converting a document to HTML.
+type HTMLOptions struct {
+	// Title is written to the <title> element. If empty, a title is derived
+	// from the document core properties when available.
+	Title string
+
+	// Fragment omits the <html>, <head> and <body> wrappers so that the output
+	// can be embedded into an existing page. The stylesheet is still emitted as
+	// a <style> element in front of the content.
+	Fragment bool
+
+	// ImageDirectory, if set, makes the converter extract images into this
+	// directory instead of embedding them as data URIs.
+	ImageDirectory string
+
+	// ImageURLPrefix is prepended to the file name of extracted images when
+	// referencing them from the HTML. It is only used with ImageDirectory.
+	ImageURLPrefix string
+}
+
+// HTMLWriter accumulates HTML output along with a deduplicated set of CSS
+// rules that are emitted in the document head.
+type HTMLWriter struct {
+	Options   *HTMLOptions
+	body      bytes.Buffer
+	css       []string
+	classes   map[string]string
+	imageNum  int
+	imageURIs map[string]string
+}
+
+// NewHTMLWriter constructs a new HTMLWriter. A nil options value is allowed.
+func NewHTMLWriter(opts *HTMLOptions) *HTMLWriter {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+	return &HTMLWriter{Options: opts, classes: map[string]string{}, imageURIs: map[string]string{}}
+}
+
+// WriteString appends raw markup to the body.
+func (h *HTMLWriter) WriteString(s string) { h.body.WriteString(s) }
+
+// WriteText appends escaped text to the body.
+func (h *HTMLWriter) WriteText(s string) { h.body.WriteString(html.EscapeString(s)) }
+
+// Printf appends formatted raw markup to the body.
+func (h *HTMLWriter) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(&h.body, format, args...)
+}
+
+// AddRule adds a fixed CSS rule such as "table { border-collapse: collapse }".
+func (h *HTMLWriter) AddRule(rule string) { h.css = append(h.css, rule) }
+
+// Class returns a class name for a set of CSS declarations, creating a new
+// rule the first time a given declaration block is seen.  An empty
+// declaration block returns an empty class name.
+func (h *HTMLWriter) Class(prefix, decls string) string {
+	if decls == "" || strings.ContainsAny(decls, "<>{}") {
+		// declarations must not end the rule or the style element
+		return ""
+	}
+	if c, ok := h.classes[decls]; ok {
+		return c
+	}
+	c := fmt.Sprintf("%s%d", prefix, len(h.classes)+1)
+	h.classes[decls] = c
+	h.css = append(h.css, fmt.Sprintf(".%s { %s }", c, decls))
+	return c
+}
+
+// ImageSource returns the value used for the src attribute of an image.
+// Images are embedded as data URIs unless an image directory is configured,
+// in which case the image is written to disk and a relative URL is returned.
+// Either data or path (a file in temporary storage) must be set.
+func (h *HTMLWriter) ImageSource(data *[]byte, path, format string) (string, error) {
+	key := path
+	if key == "" && data != nil {
+		key = fmt.Sprintf("%p", data)
+	}
+	if src, ok := h.imageURIs[key]; ok && key != "" {
+		return src, nil
+	}
+	raw, err := imageBytes(data, path)
+	if err != nil {
+		return "", err
+	}
+	format = strings.ToLower(format)
+	if format == "" || format == "jpg" {
+		format = "jpeg"
+	}
+	var src string
+	if dir := h.Options.ImageDirectory; dir != "" {
+		h.imageNum++
+		ext := format
+		if ext == "jpeg" {
+			ext = "jpg"
+		}
+		name := fmt.Sprintf("image%d.%s", h.imageNum, ext)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), raw, 0644); err != nil {
+			return "", err
+		}
+		src = h.Options.ImageURLPrefix + name
+	} else {
+		src = "data:image/" + format + ";base64," + base64.StdEncoding.EncodeToString(raw)
+	}
+	if key != "" {
+		h.imageURIs[key] = src
+	}
+	return src, nil
+}
+
+func imageBytes(data *[]byte, path string) ([]byte, error) {
+	if data != nil && len(*data) > 0 {
+		return *data, nil
+	}
+	if path == "" {
+		return nil, fmt.Errorf("image has neither data nor path")
+	}
+	f, err := tempstorage.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
+}
+
+// Flush writes the complete HTML document (or fragment) to w.
+func (h *HTMLWriter) Flush(w io.Writer) error {
+	var out bytes.Buffer
+	if !h.Options.Fragment {
+		out.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		if h.Options.Title != "" {
+			fmt.Fprintf(&out, "<title>%s</title>\n", html.EscapeString(h.Options.Title))
+		}
+	}
+	if len(h.css) > 0 {
+		out.WriteString("<style>\n")
+		for _, r := range h.css {
+			out.WriteString(r)
+			out.WriteByte('\n')
+		}
+		out.WriteString("</style>\n")
+	}
+	if !h.Options.Fragment {
+		out.WriteString("</head>\n<body>\n")
+	}
+	out.Write(h.body.Bytes())
+	if !h.Options.Fragment {
+		out.WriteString("</body>\n</html>\n")
+	}
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// CSSColor converts a hex color string as used in OOXML ("FF0000",
+// "FFFF0000" or "#FF0000") to a CSS color. Other values return an empty
+// string.
+func CSSColor(s string) string {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 8 {
+		s = s[2:]
+	}
+	if len(s) != 6 {
+		return ""
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return ""
+		}
+	}
+	return "#" + strings.ToLower(s)
+}
+
+// CSSFontFamily returns a CSS font-family value for a font name with a generic
+// fallback. Font names come from the input file, so only letters, digits,
+// spaces, hyphens, underscores and periods are kept; anything else such as
+// quotes, semicolons or < could end the declaration or the style element.
+func CSSFontFamily(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return -1
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	generic := "sans-serif"
+	switch lower := strings.ToLower(name); {
+	case strings.Contains(lower, "courier"), strings.Contains(lower, "consol"), strings.Contains(lower, "mono"):
+		generic = "monospace"
+	case strings.Contains(lower, "times"), strings.Contains(lower, "georgia"), strings.Contains(lower, "cambria"), strings.Contains(lower, "garamond"):
+		generic = "serif"
+	}
+	return fmt.Sprintf("'%s', %s", name, generic)
+}
+
+// HTMLAttr escapes a string for use in a double quoted attribute value.
+func HTMLAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", "\"", "&quot;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// SafeHref returns a hyperlink target if it is safe to use as the href of a
+// link, i.e. an http, https or mailto URL or a # anchor, and an empty string
+// otherwise. This keeps javascript: and other scripting URLs from the input
+// file out of the output.
+func SafeHref(href string) string {
+	// browsers ignore whitespace and control characters in the scheme, e.g.
+	// "java\tscript:"
+	clean := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, href)
+	if strings.HasPrefix(clean, "#") {
+		return href
+	}
+	i := strings.IndexByte(clean, ':')
+	if i < 0 {
+		return ""
+	}
+	switch strings.ToLower(clean[:i]) {
+	case "http", "https", "mailto":
+		return href
+	}
+	return ""
+}
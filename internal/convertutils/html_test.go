@@ -0,0 +1,106 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSSFontFamily(t *testing.T) {
+	td := []struct {
+		Name, Exp string
+	}{
+		{"", ""},
+		{"Calibri", "'Calibri', sans-serif"},
+		{"Times New Roman", "'Times New Roman', serif"},
+		{"Courier New", "'Courier New', monospace"},
+		{"O'Brien Sans", "'OBrien Sans', sans-serif"},
+		{"x</style><script>alert(1)</script>", "'xstylescriptalert1script', sans-serif"},
+		{"a;color:red}", "'acolorred', sans-serif"},
+		{"<>;{}", ""},
+		{"ＭＳ ゴシック", "'ＭＳ ゴシック', sans-serif"},
+	}
+	for _, tc := range td {
+		if got := CSSFontFamily(tc.Name); got != tc.Exp {
+			t.Errorf("CSSFontFamily(%q) = %q, expected %q", tc.Name, got, tc.Exp)
+		}
+	}
+}
+
+func TestCSSColor(t *testing.T) {
+	td := []struct {
+		Color, Exp string
+	}{
+		{"FF0000", "#ff0000"},
+		{"FFFF0000", "#ff0000"},
+		{"#00ff00", "#00ff00"},
+		{"12345", ""},
+		{"ab<cd>", ""},
+		{"", ""},
+	}
+	for _, tc := range td {
+		if got := CSSColor(tc.Color); got != tc.Exp {
+			t.Errorf("CSSColor(%q) = %q, expected %q", tc.Color, got, tc.Exp)
+		}
+	}
+}
+
+func TestSafeHref(t *testing.T) {
+	td := []struct {
+		Href, Exp string
+	}{
+		{"http://example.com/a?b=c", "http://example.com/a?b=c"},
+		{"HTTPS://example.com", "HTTPS://example.com"},
+		{"mailto:someone@example.com", "mailto:someone@example.com"},
+		{"#bookmark", "#bookmark"},
+		{"javascript:alert(1)", ""},
+		{"JavaScript:alert(1)", ""},
+		{"java\tscript:alert(1)", ""},
+		{" javascript:alert(1)", ""},
+		{"data:text/html,<script>alert(1)</script>", ""},
+		{"vbscript:msgbox", ""},
+		{"file:///etc/passwd", ""},
+		{"relative/path.html", ""},
+		{"", ""},
+	}
+	for _, tc := range td {
+		if got := SafeHref(tc.Href); got != tc.Exp {
+			t.Errorf("SafeHref(%q) = %q, expected %q", tc.Href, got, tc.Exp)
+		}
+	}
+}
+
+func TestHTMLAttr(t *testing.T) {
+	if got, exp := HTMLAttr(`a"b<c>&d`), "a&quot;b&lt;c&gt;&amp;d"; got != exp {
+		t.Errorf("HTMLAttr = %q, expected %q", got, exp)
+	}
+}
+
+func TestHTMLWriterStyleElement(t *testing.T) {
+	hw := NewHTMLWriter(&HTMLOptions{Fragment: true})
+	if c := hw.Class("r", "font-family:x</style><script>alert(1)</script>"); c != "" {
+		t.Errorf("expected no class for declarations with markup, got %q", c)
+	}
+	c := hw.Class("r", "font-family:"+CSSFontFamily("x</style><script>alert(1)</script>"))
+	if c == "" {
+		t.Fatalf("expected a class for a sanitized font")
+	}
+	if c2 := hw.Class("r", "font-family:"+CSSFontFamily("x</style><script>alert(1)</script>")); c2 != c {
+		t.Errorf("expected the class to be reused, got %q and %q", c, c2)
+	}
+	var buf bytes.Buffer
+	if err := hw.Flush(&buf); err != nil {
+		t.Fatalf("error flushing: %s", err)
+	}
+	out := buf.String()
+	if strings.Count(out, "</style>") != 1 || strings.Contains(out, "<script>") {
+		t.Errorf("style element was escaped: %s", out)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/unidoc/unipdf/v3/creator"
+	"github.com/unidoc/unipdf/v3/model"
+	"github.com/unidoc/unipdf/v3/render"
+)
+
+// DefaultImageDPI is the resolution of rendered images if none is set.
+const DefaultImageDPI = 96.0
+
+// ImageOptions contains the options for rendering slides and sheets to raster
+// images.
+type ImageOptions struct {
+	// DPI is the resolution of the output in pixels per inch. A page that
+	// is 72 points wide is DPI pixels wide. DefaultImageDPI is used if it is
+	// zero.
+	DPI float64
+
+	// Background is the color of the canvas the page is drawn onto, white if
+	// nil. Use color.Transparent to keep the areas that are not painted
+	// transparent, e.g. for PNG output.
+	Background color.Color
+}
+
+func (o *ImageOptions) dpi() float64 {
+	if o == nil || o.DPI <= 0 {
+		return DefaultImageDPI
+	}
+	return o.DPI
+}
+
+func (o *ImageOptions) background() color.Color {
+	if o == nil || o.Background == nil {
+		return color.White
+	}
+	return o.Background
+}
+
+// RenderCreatorToImages rasterizes every page of a creator. The pages are
+// laid out exactly as for PDF output and then drawn by the pure Go renderer
+// of unipdf, so fonts registered with RegisterFont are used for text. The
+// returned images are *image.RGBA and can be encoded with image/png or
+// image/jpeg.
+func RenderCreatorToImages(c *creator.Creator, opts *ImageOptions) ([]image.Image, error) {
+	buf := bytes.Buffer{}
+	if err := c.Write(&buf); err != nil {
+		return nil, err
+	}
+	reader, err := model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	n, err := reader.GetNumPages()
+	if err != nil {
+		return nil, err
+	}
+	images := make([]image.Image, 0, n)
+	for i := 1; i <= n; i++ {
+		page, err := reader.GetPage(i)
+		if err != nil {
+			return nil, err
+		}
+		img, err := RenderPageToImage(page, opts)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// RenderPageToImage rasterizes a PDF page at the resolution of the options.
+func RenderPageToImage(page *model.PdfPage, opts *ImageOptions) (*image.RGBA, error) {
+	box, err := page.GetMediaBox()
+	if err != nil {
+		return nil, err
+	}
+	width, height := box.Width(), box.Height()
+	if cb := page.CropBox; cb != nil {
+		width, height = math.Abs(cb.Urx-cb.Llx), math.Abs(cb.Ury-cb.Lly)
+	}
+	// the renderer measures the output width after rotating the page
+	if page.Rotate != nil && (*page.Rotate/90)%2 != 0 {
+		width = height
+	}
+	device := render.NewImageDevice()
+	device.OutputWidth = int(math.Max(1, math.Round(width*opts.dpi()/72)))
+	img, err := device.Render(page)
+	if err != nil {
+		return nil, err
+	}
+	return flattenImage(img, opts.background()), nil
+}
+
+// flattenImage draws an image over a uniform background onto a new RGBA
+// canvas. The renderer leaves unpainted areas transparent, which would turn
+// black when encoded to a format without alpha.
+func flattenImage(img image.Image, bg color.Color) *image.RGBA {
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Over)
+	return out
+}
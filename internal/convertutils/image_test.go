@@ -0,0 +1,64 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFlattenImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 10, 12, 11))
+	img.Set(10, 10, color.NRGBA{0xFF, 0, 0, 0xFF})
+	img.Set(11, 10, color.NRGBA{0, 0, 0xFF, 0x80})
+
+	td := []struct {
+		Name  string
+		Bg    color.Color
+		Left  color.RGBA
+		Right color.RGBA
+	}{
+		{"white", color.White, color.RGBA{0xFF, 0, 0, 0xFF}, color.RGBA{0x7F, 0x7F, 0xFF, 0xFF}},
+		{"transparent", color.Transparent, color.RGBA{0xFF, 0, 0, 0xFF}, color.RGBA{0, 0, 0x80, 0x80}},
+	}
+	for _, tc := range td {
+		out := flattenImage(img, tc.Bg)
+		if out.Bounds() != image.Rect(0, 0, 2, 1) {
+			t.Errorf("%s: expected the image to be moved to the origin, got %v", tc.Name, out.Bounds())
+		}
+		if got := out.RGBAAt(0, 0); got != tc.Left {
+			t.Errorf("%s: expected the opaque pixel %v, got %v", tc.Name, tc.Left, got)
+		}
+		if got := out.RGBAAt(1, 0); got != tc.Right {
+			t.Errorf("%s: expected the blended pixel %v, got %v", tc.Name, tc.Right, got)
+		}
+	}
+}
+
+func TestImageOptionDefaults(t *testing.T) {
+	td := []struct {
+		Name string
+		Opts *ImageOptions
+		DPI  float64
+		Bg   color.Color
+	}{
+		{"nil", nil, DefaultImageDPI, color.White},
+		{"zero", &ImageOptions{}, DefaultImageDPI, color.White},
+		{"negative", &ImageOptions{DPI: -1}, DefaultImageDPI, color.White},
+		{"set", &ImageOptions{DPI: 300, Background: color.Transparent}, 300, color.Transparent},
+	}
+	for _, tc := range td {
+		if got := tc.Opts.dpi(); got != tc.DPI {
+			t.Errorf("%s: expected %v DPI, got %v", tc.Name, tc.DPI, got)
+		}
+		if got := tc.Opts.background(); got != tc.Bg {
+			t.Errorf("%s: expected the background %v, got %v", tc.Name, tc.Bg, got)
+		}
+	}
+}
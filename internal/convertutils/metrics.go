@@ -0,0 +1,63 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convertutils
+
+import (
+	"sync"
+
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+var standardFonts = struct {
+	sync.Mutex
+	fonts map[model.StdFontName]*model.PdfFont
+}{fonts: map[model.StdFontName]*model.PdfFont{}}
+
+// GetFont returns the font registered with RegisterFont for a typeface and
+// style, or the matching standard font when the typeface is not registered.
+func GetFont(typeface string, bold, italic bool) *model.PdfFont {
+	style := FontStyle_Regular
+	name := model.HelveticaName
+	switch {
+	case bold && italic:
+		style, name = FontStyle_BoldItalic, model.HelveticaBoldObliqueName
+	case bold:
+		style, name = FontStyle_Bold, model.HelveticaBoldName
+	case italic:
+		style, name = FontStyle_Italic, model.HelveticaObliqueName
+	}
+	if f := GetRegisteredFont(typeface, style); f != nil {
+		return f
+	}
+	if names, ok := StdFontsMap[typeface]; ok {
+		name = model.StdFontName(names[style])
+	}
+	standardFonts.Lock()
+	defer standardFonts.Unlock()
+	f, ok := standardFonts.fonts[name]
+	if !ok {
+		f = model.NewStandard14FontMustCompile(name)
+		standardFonts.fonts[name] = f
+	}
+	return f
+}
+
+// TextWidth returns the width in points of a line of text drawn with a font
+// of the given size in points. Characters missing from the font are counted
+// as half an em wide.
+func TextWidth(f *model.PdfFont, s string, size float64) float64 {
+	w := 0.0
+	for _, c := range s {
+		if m, ok := f.GetRuneMetrics(c); ok {
+			w += m.Wx
+		} else {
+			w += 500
+		}
+	}
+	return w * size / 1000
+}
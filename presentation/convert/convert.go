@@ -18,7 +18,7 @@ func RegisterFontsFromDirectory (dirName string )error {return _cd .RegisterFont
 type FontStyle =_cd .FontStyle ;var _bbeae =[]romanMatch {romanMatch {1000,"\u006d"},romanMatch {900,"\u0063\u006d"},romanMatch {500,"\u0064"},romanMatch {400,"\u0063\u0064"},romanMatch {100,"\u0063"},romanMatch {90,"\u0078\u0063"},romanMatch {50,"\u006c"},romanMatch {40,"\u0078\u006c"},romanMatch {10,"\u0078"},romanMatch {9,"\u0069\u0078"},romanMatch {5,"\u0076"},romanMatch {4,"\u0069\u0076"},romanMatch {1,"\u0069"}};func (_caf *textboxContext )alignParagraph (){_ecaa :=_caf ._fdf ;if _ecaa ._aca ==_f .ST_TextAlignTypeL {return ;};_adfge :=len (_ecaa ._bcb )-1;for _bedc ,_bbbb :=range _ecaa ._bcb {_bcfg :=true ;_dacd :=len (_bbbb ._fae );_dab :=0.0;for _aed :=len (_bbbb ._fae )-1;_aed >=0;_aed --{_eedg :=_bbbb ._fae [_aed ];if _bcfg &&_eedg ._gadc {_dacd =_aed ;}else {_bcfg =false ;for _ ,_cgda :=range _eedg ._ffbd {_dab +=_cgda ._aba ;};};};_bbbb ._fae =_bbbb ._fae [:_dacd ];_efgf :=_bbbb ._egf -_bbbb ._ddg -_dab ;switch _ecaa ._aca {case _f .ST_TextAlignTypeR :_bbbb .moveRight (_efgf );case _f .ST_TextAlignTypeCtr :_bbbb .moveRight (_efgf /2);case _f .ST_TextAlignTypeJust :if _bedc !=_adfge {_gecc :=[]*word {};for _ ,_aefb :=range _bbbb ._fae {if _aefb ._gadc {_gecc =append (_gecc ,_aefb );};};_bfec :=_efgf /float64 (len (_gecc ));for _ ,_bdfca :=range _gecc {_bdfca ._cdcd +=_bfec ;};var _bffa *word ;for _ ,_fdge :=range _bbbb ._fae {if _bffa !=nil {_fdge ._eddg =_bffa ._eddg +_bffa ._cdcd ;};_bffa =_fdge ;};};};};};func (_dfd *convertContext )makePdfBlockFromTable (_ceg *_f .Tbl ,_aegg float64 )*_b .Table {_fgb :=_ceg .TblGrid ;if _fgb ==nil {return nil ;};_dcg :=len (_fgb .GridCol );if _dcg ==0{return nil ;};_gbe :=[]float64 {};_cbab :=0.0;for _ ,_dgd :=range _fgb .GridCol {_dce :=_gc .FromEMU (_cd .FromSTCoordinate (_dgd .WAttr ));_gbe =append (_gbe ,_dce );_cbab +=_dce ;};_fdb :=[]float64 {};for _bdeb :=0;_bdeb < _dcg ;_bdeb ++{_fdb =append (_fdb ,_gbe [_bdeb ]/_cbab );};_eege :=_dfd ._fcbbg .NewTable (_dcg );_eege .SetColumnWidths (_fdb ...);_cfff :=_ceg .TblPr ;var _fde *_f .CT_TableStyle ;if _dgbg :=_cfff .Choice ;_dgbg !=nil {if _dgbg .TableStyle !=nil {_fde =_dgbg .TableStyle ;}else if _dgbg .TableStyleId !=nil {_fde =_dfd ._cbed .GetTableStyleById (*_dgbg .TableStyleId );};};_gca :=_f .NewCT_TablePartStyle ();_gca .TcStyle =_f .NewCT_TableStyleCellStyle ();_gca .TcTxStyle =_f .NewCT_TableStyleTextStyle ();if _fde !=nil {if _fde .WholeTbl !=nil {*_gca =*_fde .WholeTbl ;};if _fde .TblBg !=nil {if _gca .TcStyle .Fill ==nil {_gca .TcStyle .Fill =_fde .TblBg .Fill ;};};};if _gca .TcStyle .Fill ==nil {_gca .TcStyle .Fill =_f .NewCT_FillProperties ();_gca .TcStyle .Fill .NoFill =_cfff .NoFill ;_gca .TcStyle .Fill .SolidFill =_cfff .SolidFill ;};_ged :=len (_ceg .Tr );for _ffd ,_fdgd :=range _ceg .Tr {_cef :=_ffd ==0;_bbea :=_ffd ==_ged -1;_dgec :=_ffd %2==0;_bbdg :=len (_fdgd .Tc );var _gab *_f .CT_TablePartStyle ;if _cef {_gab =_fde .FirstRow ;}else if _dgec {_gab =_fde .Band2H ;}else {_gab =_fde .Band1H ;};var _bea float64 ;for _bfb ,_dgecb :=range _fdgd .Tc {_daaf :=_bfb ==0;_abb :=_bfb ==_bbdg -1;_egdd :=_bfb %2==0;var _gcf *_f .CT_TablePartStyle ;if _daaf {_gcf =_fde .FirstCol ;}else if _egdd {_gcf =_fde .Band2V ;}else {_gcf =_fde .Band1V ;};_eea :=_afdc (_afdc (_gcf ,_gab ),_gca );_dcba :=_dfd .addCellToTable (_eege ,_dgecb ,_eea ,_aegg *_fdb [_bfb ],_cef ,_bbea ,_daaf ,_abb );if _dcba > _bea {_bea =_dcba ;};};_cbec :=_gc .FromEMU (_cd .FromSTCoordinate (_fdgd .HAttr ));if _cbec < _bea {_cbec =_bea ;};if _cbec < _eaea (4){_cbec =_eaea (4);};_eege .SetRowHeight (_eege .CurRow (),_cbec );};return _eege ;};func _eddf (_bcdc string )[]*symbol {_caae :=[]*symbol {};for _ ,_gcg :=range _bcdc {_caae =append (_caae ,&symbol {_bfga :string (_gcg )});};return _caae ;};type convertContext struct{_fcbbg *_b .Creator ;_ecd *_cd .Rectangle ;_cbed *_dec .Presentation ;_afa *_dec .Slide ;_eee *_bfd .SldMaster ;_ecfg *_bfd .SldLayout ;_eeed float64 ;_dfag float64 ;_dcaa []_b .Drawable ;_dccf *background ;_ccca *_f .CT_TextParagraphProperties ;_abed *_f .CT_TextCharacterProperties ;_gfab *_f .CT_TextParagraphProperties ;_ecdd *_f .CT_TextCharacterProperties ;_dfb *_f .CT_TextParagraphProperties ;_dba *_f .CT_TextCharacterProperties ;_gaff []*_f .CT_TextParagraphProperties ;_def []*_f .CT_TextParagraphProperties ;_bage []*_f .CT_TextParagraphProperties ;_dbdb *_f .Theme ;_gef *_f .CT_ColorMappingOverride ;};
 
 // ConvertToPdf converts a presentation to a PDF file. This package is beta, breaking changes can take place.
-func ConvertToPdf (pr *_dec .Presentation )*_b .Creator {_ff :=pr .X ().SldSz ;_ed :=_gc .FromEMU (int64 (_ff .CxAttr ));_fa :=_gc .FromEMU (int64 (_ff .CyAttr ));_da :=_b .PageSize {_ed ,_fa };_fe :=_b .New ();_fe .SetPageSize (_da );var _ce *_f .Theme ;if len (pr .Themes ())> 0{_ce =pr .Themes ()[0];};for _ ,_ee :=range pr .Slides (){if _ee .X ()==nil {continue ;};_gf :=&convertContext {_fcbbg :_fe ,_afa :&_ee ,_ecfg :_ee .GetSlideLayout (),_eee :pr .SlideMasters ()[0].X (),_cbed :pr ,_dbdb :_ce ,_gef :_ee .X ().ClrMapOvr ,_eeed :_da [1],_dfag :_da [0]};_gf ._ecfg =_ee .GetSlideLayout ();_gf .extractDefaultProperties ();_gf .makeSlide ();_gf .drawSlide ();};return _fe ;};type symbolStyle struct{_cegdb *string ;_gedc *float64 ;_dbac *string ;_dgca *bool ;_defd *bool ;_gac *bool ;_bcdd *bool ;_bda *bool ;};var _bgba =map[string ]int32 {"\u0076":9830,"\u00d8":8594,"\u00fc":8730};func (_fbbb *textboxContext )newLine (){if _fbbb ._fdf ==nil {_fbbb .newParagraph ();};_faca :=_fbbb ._fdf ._fgf +_fbbb ._fdf ._cbddf ;_fdea :=&line {};_fdea ._ddg =_fbbb ._fdf ._geea ;if len (_fbbb ._fdf ._bcb )==0{_fdea ._ddg +=_fbbb ._fdf ._ded ;};_fdea ._egf =_fbbb ._fdf ._fgaf ;_fdea ._fac =_fdea ._ddg ;_fdea ._bgg =_faca ;_fbbb ._fdf ._bcb =append (_fbbb ._fdf ._bcb ,_fdea );_fbbb ._geb =_fdea ;};
+func ConvertToPdf (pr *_dec .Presentation )*_b .Creator {_da :=slideSize (pr );_fe :=_b .New ();_fe .SetPageSize (_da );var _ce *_f .Theme ;if len (pr .Themes ())> 0{_ce =pr .Themes ()[0];};for _ ,_ee :=range pr .Slides (){convertSlide (_fe ,pr ,&_ee ,_ce ,_da );};return _fe ;};type symbolStyle struct{_cegdb *string ;_gedc *float64 ;_dbac *string ;_dgca *bool ;_defd *bool ;_gac *bool ;_bcdd *bool ;_bda *bool ;};var _bgba =map[string ]int32 {"\u0076":9830,"\u00d8":8594,"\u00fc":8730};func (_fbbb *textboxContext )newLine (){if _fbbb ._fdf ==nil {_fbbb .newParagraph ();};_faca :=_fbbb ._fdf ._fgf +_fbbb ._fdf ._cbddf ;_fdea :=&line {};_fdea ._ddg =_fbbb ._fdf ._geea ;if len (_fbbb ._fdf ._bcb )==0{_fdea ._ddg +=_fbbb ._fdf ._ded ;};_fdea ._egf =_fbbb ._fdf ._fgaf ;_fdea ._fac =_fdea ._ddg ;_fdea ._bgg =_faca ;_fbbb ._fdf ._bcb =append (_fbbb ._fdf ._bcb ,_fdea );_fbbb ._geb =_fdea ;};
 
 // RegisterFont makes a PdfFont accessible for using in converting to PDF.
 func RegisterFont (name string ,style FontStyle ,font *_bd .PdfFont ){_cd .RegisterFont (name ,style ,font );};func (_eca *convertContext )extractDefaultProperties (){_ba :=_eca ._cbed .X ();_gbd :=_ba .DefaultTextStyle ;var _ad ,_egc ,_dd ,_bab ,_fc ,_cdg ,_dc ,_ac ,_dg ,_eda *_f .CT_TextParagraphProperties ;if _gbd !=nil {_ad =_gbd .DefPPr ;_egc =_bgab (_gbd .Lvl1pPr ,_ad );_dd =_bgab (_gbd .Lvl2pPr ,_ad );_bab =_bgab (_gbd .Lvl3pPr ,_ad );_fc =_bgab (_gbd .Lvl4pPr ,_ad );_cdg =_bgab (_gbd .Lvl5pPr ,_ad );_dc =_bgab (_gbd .Lvl6pPr ,_ad );_ac =_bgab (_gbd .Lvl7pPr ,_ad );_dg =_bgab (_gbd .Lvl8pPr ,_ad );_eda =_bgab (_gbd .Lvl9pPr ,_ad );_eca ._ccca =_ad ;_eca ._abed =_ad .DefRPr ;};_eca ._gaff =make ([]*_f .CT_TextParagraphProperties ,9);_eca ._gaff [0]=_egc ;_eca ._gaff [1]=_dd ;_eca ._gaff [2]=_bab ;_eca ._gaff [3]=_fc ;_eca ._gaff [4]=_cdg ;_eca ._gaff [5]=_dc ;_eca ._gaff [6]=_ac ;_eca ._gaff [7]=_dg ;_eca ._gaff [8]=_eda ;_cb :=_eca ._cbed .SlideMasters ()[0].X ();_faf :=_cb .TxStyles ;_bff :=_faf .TitleStyle ;_eca ._gfab =_bgab (_bff .DefPPr ,_ad );_eca ._ecdd =_eca ._gfab .DefRPr ;_eca ._def =make ([]*_f .CT_TextParagraphProperties ,9);_eca ._def [0]=_bgab (_bff .Lvl1pPr ,_egc );_eca ._def [1]=_bgab (_bff .Lvl2pPr ,_dd );_eca ._def [2]=_bgab (_bff .Lvl3pPr ,_bab );_eca ._def [3]=_bgab (_bff .Lvl4pPr ,_fc );_eca ._def [4]=_bgab (_bff .Lvl5pPr ,_cdg );_eca ._def [5]=_bgab (_bff .Lvl6pPr ,_dc );_eca ._def [6]=_bgab (_bff .Lvl7pPr ,_ac );_eca ._def [7]=_bgab (_bff .Lvl8pPr ,_dg );_eca ._def [8]=_bgab (_bff .Lvl9pPr ,_eda );_ffe :=_faf .BodyStyle ;_eca ._dfb =_bgab (_ffe .DefPPr ,_ad );_eca ._dba =_eca ._dfb .DefRPr ;_eca ._bage =make ([]*_f .CT_TextParagraphProperties ,9);_eca ._bage [0]=_bgab (_ffe .Lvl1pPr ,_egc );_eca ._bage [1]=_bgab (_ffe .Lvl2pPr ,_dd );_eca ._bage [2]=_bgab (_ffe .Lvl3pPr ,_bab );_eca ._bage [3]=_bgab (_ffe .Lvl4pPr ,_fc );_eca ._bage [4]=_bgab (_ffe .Lvl5pPr ,_cdg );_eca ._bage [5]=_bgab (_ffe .Lvl6pPr ,_dc );_eca ._bage [6]=_bgab (_ffe .Lvl7pPr ,_ac );_eca ._bage [7]=_bgab (_ffe .Lvl8pPr ,_dg );_eca ._bage [8]=_bgab (_ffe .Lvl9pPr ,_eda );};func (_debgb *convertContext )getBorderStyle (_abegg *_f .CT_LineProperties )(_b .CellBorderStyle ,*_b .Color ,float64 ){if _abegg ==nil ||_abegg .NoFill !=nil {return _b .CellBorderStyleNone ,nil ,0;};var _cegd _b .Color ;if _aee :=_abegg .SolidFill ;_aee !=nil {_cegd ,_ =_debgb .getColorFromSolidFill (_aee );};_aged :=0.0;if _ceeg :=_abegg .WAttr ;_ceeg !=nil {_aged =_gc .FromEMU (int64 (*_ceeg ));};return _b .CellBorderStyleSingle ,&_cegd ,_aged ;};func (_fddf *convertContext )makeStyleFromRPr (_gcc *_f .CT_TextCharacterProperties )(*_b .TextStyle ,bool ,bool ,bool ){var _dfe ,_bffg ,_gafa bool ;_fdg :=_fddf ._fcbbg .NewTextStyle ();if _gcc !=nil {_fgad :=_cd .FontStyle_Regular ;_cgdf :=_fegb (_gcc .BAttr );_ccbd :=_fegb (_gcc .IAttr );if _cgdf &&_ccbd {_fgad =_cd .FontStyle_BoldItalic ;}else if _cgdf {_fgad =_cd .FontStyle_Bold ;}else if _ccbd {_fgad =_cd .FontStyle_Italic ;};_gafa =_gcc .UAttr !=_f .ST_TextUnderlineTypeUnset &&_gcc .UAttr !=_f .ST_TextUnderlineTypeNone ;_bbfe :="\u0064e\u0066\u0061\u0075\u006c\u0074";if _cab :=_gcc .Latin ;_cab !=nil {_bbfe =_cab .TypefaceAttr ;}else if _cdfc :=_gcc .Ea ;_cdfc !=nil {_bbfe =_cdfc .TypefaceAttr ;}else if _cbg :=_gcc .Cs ;_cbg !=nil {_bbfe =_cbg .TypefaceAttr ;}else if _aae :=_gcc .Sym ;_aae !=nil {_bbfe =_aae .TypefaceAttr ;};if _feab ,_debe :=_cd .StdFontsMap [_bbfe ];_debe {_fdg .Font =_cd .AssignStdFontByName (_fdg ,_feab [_fgad ]);}else if _gdd :=_cd .GetRegisteredFont (_bbfe ,_fgad );_gdd !=nil {_fdg .Font =_gdd ;}else {_deg .Log .Debug ("\u0046\u006f\u006e\u0074\u0020\u0025\u0073\u0020\u0077\u0069\u0074h\u0020\u0073\u0074\u0079\u006c\u0065\u0020\u0025s\u0020i\u0073\u0020\u006e\u006f\u0074\u0020\u0066\u006f\u0075\u006e\u0064\u002c\u0020\u0072\u0065\u0073\u0065\u0074 \u0074\u006f\u0020\u0064\u0065\u0066\u0061\u0075\u006c\u0074\u002e",_bbfe ,_fgad );_fdg .Font =_cd .AssignStdFontByName (_fdg ,_cd .StdFontsMap ["\u0064e\u0066\u0061\u0075\u006c\u0074"][_fgad ]);};var _cbad float64 ;if _bcfa :=_gcc .SzAttr ;_bcfa !=nil {_cbad =float64 (*_bcfa )/100;}else {_cbad =_cd .DefaultFontSize ;};if _acgf :=_gcc .BaselineAttr ;_acgf !=nil {if _bfef :=_acgf .ST_PercentageDecimal ;_bfef !=nil {if *_bfef > 0{_dfe =true ;}else if *_bfef < 0{_bffg =true ;};};};if _dfe ||_bffg {_cbad *=0.64;};_fdg .FontSize =_cbad ;_afe :=0.0;if _gdad :=_gcc .SpcAttr ;_gdad !=nil {if _cbdd :=_gdad .ST_TextPointUnqualified ;_cbdd !=nil {_afe =float64 (*_cbdd )/100;};};_fdg .CharSpacing =_afe ;};return &_fdg ,_dfe ,_bffg ,_gafa ;};func (_gae *textboxContext )drawParagraphs (){_gae ._aacg .NewPage ();for _ ,_adb :=range _gae ._ece {for _ ,_cfbg :=range _adb ._bcb {for _ ,_ebb :=range _cfbg ._fae {for _ ,_cgf :=range _ebb ._ffbd {_bgdb :=_gae ._aacg .NewStyledParagraph ();if _cgf ._bggg {_cgf ._faab =0;}else if _cgf ._dfc {_cgf ._faab =1.2*_cfbg ._dafc -_cgf ._adcb ;};_fccc :=_ebb ._eddg +_cgf ._debc ;_bfdfg :=_adb ._faaag +_cfbg ._bgg +_cgf ._faab ;_bgdb .SetPos (_fccc ,_bfdfg );_adaa :=_bgdb .Append (_cgf ._bfga );if _cgf ._fbec !=nil {_adaa .Style =*_cgf ._fbec ;};_gae ._aacg .Draw (_bgdb );if _cgf ._bacb {_ffeda :=_bfdfg +_cgf ._adcb +2;_cd .DrawLine (_gae ._aacg ,_fccc ,_ffeda ,_fccc +_cgf ._aba ,_ffeda ,1,_cgf ._fbec .Color );};};};};};};func (_acf *textboxContext )assignPropsToCurrentParagraph (_fcce *_f .CT_TextParagraphProperties ){_fdfb :=12.4;if _fcce ==nil {_acf ._fdf ._ffdef =_fdfb ;return ;};if _fbca :=_fcce .DefRPr ;_fbca !=nil {_fda :=_fbca .SzAttr ;if _fda !=nil {_efa :=float64 (*_fda )/1200;if _fdfb <=_efa {_fdfb =_efa ;};};};if _bgca :=_fcce .MarLAttr ;_bgca !=nil {_acf ._fdf ._geea =_gc .FromEMU (int64 (*_bgca ));};_acf ._fdf ._fgaf =_acf ._accg ;if _fff :=_fcce .MarRAttr ;_fff !=nil {_acf ._fdf ._fgaf -=_gc .FromEMU (int64 (*_fff ));};if _deee :=_fcce .IndentAttr ;_deee !=nil {_acf ._fdf ._ded =_gc .FromEMU (int64 (*_deee ));};if _eaeg :=_fcce .LatinLnBrkAttr ;_eaeg !=nil {_acf ._fdf ._fcfg =*_eaeg ;};if _eec :=_fcce .LnSpc ;_eec !=nil {if _eegc :=_eec .SpcPct ;_eegc !=nil {if _aagg :=_eegc .ValAttr .ST_TextSpacingPercent ;_aagg !=nil {_fdfb =float64 (*_aagg )/5000;};};};var _eaf float64 ;if _bggge :=_fcce .SpcBef ;_bggge !=nil {if _egbb :=_bggge .SpcPts ;_egbb !=nil {_eaf =float64 (_egbb .ValAttr )/100;};};_fbcg :=_acf ._ece ;if len (_fbcg )> 0{_eaf -=_fbcg [len (_fbcg )-1]._fafd ;if _eaf < 0{_eaf =0;};};_acf ._fdf ._cbddf =_eaf ;if _eabd :=_fcce .SpcAft ;_eabd !=nil {if _bdfc :=_eabd .SpcPts ;_bdfc !=nil {_acf ._fdf ._fafd =float64 (_bdfc .ValAttr )/100;};};_acf ._fdf ._ffdef =_fdfb ;_acf ._fdf ._aca =_fcce .AlgnAttr ;};func _baa (_agfc *_f .CT_AdjPoint2D )(float64 ,float64 ){var _cbabd ,_gaeg float64 ;_dbca ,_gbae :=_agfc .XAttr ,_agfc .YAttr ;if _bdfd :=_dbca .ST_Coordinate ;_bdfd !=nil {_cbabd =_gc .FromEMU (_cd .FromSTCoordinate (*_bdfd ));};if _bgad :=_gbae .ST_Coordinate ;_bgad !=nil {_gaeg =_gc .FromEMU (_cd .FromSTCoordinate (*_bgad ));};return _cbabd ,_gaeg ;};func (_acc *convertContext )makePdfDivisionFromTxBody (_bgdc *_f .CT_TextBody ,_dac ,_fcbb float64 ,_ccf *_f .CT_TableStyleTextStyle )*_b .Division {_aac :=_acc ._fcbbg .NewDivision ();_dfgd :=_acc ._ccca ;_bgf :=_f .ST_TextAnchoringTypeT ;if _egb :=_bgdc .BodyPr ;_egb !=nil {if _ecff :=_egb .AnchorAttr ;_ecff !=_f .ST_TextAnchoringTypeUnset {_bgf =_egb .AnchorAttr ;};};if _aag :=_bgdc .LstStyle ;_aag !=nil {var _cdff *_f .CT_TextParagraphProperties ;if _aag .Lvl1pPr !=nil {_cdff =_aag .Lvl1pPr ;}else {_cdff =_acc ._gaff [0];};_dfgd =_bgab (_cdff ,_bgab (_aag .DefPPr ,_dfgd ));};for _ ,_gbc :=range _bgdc .P {if _gbc !=nil {_bad :=_acc ._fcbbg .NewStyledParagraph ();_cggc :=_bgab (_gbc .PPr ,_dfgd );_ecc :=_dagf (_gbc .EndParaRPr ,_cggc .DefRPr );if len (_gbc .EG_TextRun )==0{_bad .Append ("\u000a");_aac .Add (_bad );continue ;};for _ ,_bfae :=range _gbc .EG_TextRun {if _dae :=_bfae .Br ;_dae !=nil {_bad .Append ("\u000a");}else if _cag :=_bfae .R ;_cag !=nil {_dbdg :=_gafef (_cag .RPr ,_ccf );_dbdg =_dagf (_dbdg ,_ecc );var _gfg _b .Color ;if _dbdg .SolidFill !=nil {_gfg ,_ =_acc .getColorFromSolidFill (_dbdg .SolidFill );}else {_gfg =_b .ColorBlack ;};_adfg ,_gad ,_edgb ,_ :=_acc .makeStyleFromRPr (_dbdg );_adfg .Color =_gfg ;if _gad {_adfg .TextRise =0.5;}else if _edgb {_adfg .TextRise =-0.5;};_cfaae :=_cag .T ;if _dbdg .CapAttr ==_f .ST_TextCapsTypeAll {for _ ,_bca :=range _cfaae {_bca =[]rune (_eg .ToUpper (string (_bca )))[0];};};_acde :=_bad .Append (_cfaae );_acde .Style =*_adfg ;};};_ =_bgf ;_aac .Add (_bad );};};return _aac ;};func (_gfd *convertContext )drawSlide (){_gfd ._fcbbg .NewPage ();for _ ,_cbd :=range _gfd ._dcaa {if _cbd !=nil {_gfd ._fcbbg .MoveTo (0,0);_gfd ._fcbbg .Draw (_cbd );};};};func (_gfgd *textboxContext )adjustHeights (_acda float64 ){if _gfgd ._geb ._dafc < _acda {_gfgd ._fdf ._fgf +=(_acda -_gfgd ._geb ._dafc );_gfgd ._geb ._dafc =_acda ;};};func (_bbc *convertContext )getShapes (_cba *_bfd .CT_Shape )[]_b .Drawable {_ebe :=[]_b .Drawable {};_fea :=_cba .SpPr ;if _fea ==nil {return _ebe ;};var _bdg bool ;if _fbd :=_cba .UseBgFillAttr ;_fbd !=nil {_bdg =*_fbd ;};_ecb ,_baf ,_egeg ,_acg ,_bfe ,_edc ,_cfd :=_bbc .getShapesFromSpPr (_fea ,_cba .Style ,_bdg );_ebe =append (_ebe ,_ecb ...);if _aa :=_cba .TxBody ;_aa !=nil {_bgdeb ,_dcb ,_cbaa ,_ecbe ,_gbgf :=_bbc .getPhData (_cba );if _bgdeb !=nil &&!_cfd {_baf ,_egeg ,_acg ,_bfe =_cd .GetDataFromXfrm (_bgdeb );};_abe ,_cfg :=_bbc .makePdfBlockFromTxBody (_aa ,_dcb ,_cbaa ,_acg ,_bfe ,_edc ,_ecbe ,_gbgf );if _cfg !=nil {_deg .Log .Debug ("\u0043\u0061\u006e\u006e\u006f\u0074\u0020\u006d\u0061\u006b\u0065\u0020\u0050\u0044\u0046\u0020\u0062\u006c\u006f\u0063\u006b\u0020\u0066\u0072o\u006d\u0020\u0074\u0065\u0078t\u0062\u006fx\u003a\u0020\u0025\u0073",_cfg );}else if _abe !=nil {_abe .SetPos (_baf ,_egeg );_ebe =append (_ebe ,_abe );};};return _ebe ;};type textboxContext struct{_bfgg *convertContext ;_accg float64 ;_bfefg float64 ;_aacg *_b .Creator ;_ggc float64 ;_ece []*paragraph ;_fdf *paragraph ;_geb *line ;_dcef *word ;_cbcg bool ;};func (_bce *convertContext )getStyleColors (_efae *_f .CT_ShapeStyle )(_b .Color ,_b .Color ,_b .Color ){var _gece ,_dace ,_aefe _b .Color ;if _fca :=_efae .LnRef ;_fca !=nil {_dace =_bce .getColorFromMatrixReference (_fca );};if _bcae :=_efae .FillRef ;_bcae !=nil {_aefe =_bce .getColorFromMatrixReference (_bcae );};if _dgbf :=_efae .FontRef ;_dgbf !=nil {_gece =_bce .getColorFromFontReference (_dgbf );};return _gece ,_aefe ,_dace ;};func _eaea (_daac float64 )float64 {return _daac *_gc .Millimeter };func (_dagc *convertContext )makePdfImageFromBlipFill (_fcde *_f .CT_BlipFillProperties )(*_b .Image ,[]*_f .CT_BlipChoice ,error ){if _fcg :=_fcde .Blip ;_fcg !=nil {if _gaee :=_fcg .EmbedAttr ;_gaee !=nil {_bega ,_gefd :=_dagc ._afa .GetImageByRelID (*_gaee );if _gefd {_bffda ,_febe :=_cdc .Open (_bega .Path ());if _febe !=nil {_deg .Log .Debug ("\u0046\u0069\u006c\u0065 o\u0070\u0065\u006e\u0020\u0065\u0072\u0072\u006f\u0072\u003a\u0020\u0025\u0073",_febe );return nil ,nil ,_febe ;};defer _bffda .Close ();_gcda ,_ ,_febe :=_a .Decode (_bffda );if _febe !=nil {_deg .Log .Debug ("\u0044\u0065\u0063\u006fde\u0020\u0069\u006d\u0061\u0067\u0065\u0020\u0065\u0072\u0072\u006f\u0072\u003a\u0020%\u0073",_febe );return nil ,nil ,_febe ;};if _defdf :=_fcde .SrcRect ;_defdf !=nil {_ecaf :=_gcda .Bounds ().Size ();_fdbf :=_ecaf .X ;_aacf :=_ecaf .Y ;var _bec ,_bdb ,_dafd ,_ecbc int ;var _dage bool ;if _afdf :=_defdf .LAttr ;_afdf !=nil {_bec =int (float64 (_fdbf )*_cd .FromSTPercentage (_afdf ));_dage =true ;}else {_bec =0;};if _edaca :=_defdf .TAttr ;_edaca !=nil {_dafd =int (float64 (_aacf )*_cd .FromSTPercentage (_edaca ));_dage =true ;}else {_dafd =0;};if _cgdb :=_defdf .RAttr ;_cgdb !=nil {_bdb =int (float64 (_fdbf )*(1-_cd .FromSTPercentage (_cgdb )));_dage =true ;}else {_bdb =_fdbf ;};if _fgfd :=_defdf .BAttr ;_fgfd !=nil {_ecbc =int (float64 (_aacf )*(1-_cd .FromSTPercentage (_fgfd )));_dage =true ;}else {_ecbc =_aacf ;};if _dage {_gcda =_cd .CropImageByRect (_gcda ,_a .Rect (_bec ,_dafd ,_bdb +1,_ecbc +1));};};_eeac ,_febe :=_dagc ._fcbbg .NewImageFromGoImage (_gcda );if _febe !=nil {_deg .Log .Debug ("\u0043\u0061\u006e\u006e\u006ft\u0020\u0063\u0072\u0065\u0061\u0074\u0065\u0020\u0050\u0044\u0046\u0020\u0069m\u0061\u0067\u0065\u0020\u0066\u0072\u006f\u006d\u0020\u0047\u006f\u0020\u0069\u006d\u0061\u0067\u0065\u003a\u0020\u0025\u0073",_febe );return nil ,nil ,_febe ;};return _eeac ,_fcg .Choice ,nil ;};};};return nil ,nil ,nil ;};func _gafef (_aced *_f .CT_TextCharacterProperties ,_ffbf *_f .CT_TableStyleTextStyle )*_f .CT_TextCharacterProperties {_beec :=_f .NewCT_TextCharacterProperties ();if _aced !=nil {*_beec =*_aced ;};if _ffbf ==nil {return _beec ;};if _beec .BAttr ==nil &&_ffbf .BAttr !=_f .ST_OnOffStyleTypeUnset {_afda :=_ffbf .BAttr ==_f .ST_OnOffStyleTypeOn ;_beec .BAttr =&_afda ;};if _beec .IAttr ==nil &&_ffbf .IAttr !=_f .ST_OnOffStyleTypeUnset {_gecfe :=_ffbf .IAttr ==_f .ST_OnOffStyleTypeOn ;_beec .IAttr =&_gecfe ;};if _beec .NoFill ==nil &&_beec .SolidFill ==nil {_beec .SolidFill =_f .NewCT_SolidColorFillProperties ();_beec .SolidFill .ScrgbClr =_ffbf .ScrgbClr ;_beec .SolidFill .SrgbClr =_ffbf .SrgbClr ;_beec .SolidFill .HslClr =_ffbf .HslClr ;_beec .SolidFill .SysClr =_ffbf .SysClr ;_beec .SolidFill .SchemeClr =_ffbf .SchemeClr ;_beec .SolidFill .PrstClr =_ffbf .PrstClr ;};if _ddda :=_ffbf .Font ;_ddda !=nil &&_beec .Latin ==nil &&_beec .Ea ==nil &&_beec .Cs ==nil {_beec .Latin =_ddda .Latin ;_beec .Ea =_ddda .Ea ;_beec .Cs =_ddda .Cs ;};return _beec ;};func (_aagc *convertContext )getColorFromSolidFill (_bdad *_f .CT_SolidColorFillProperties )(_b .Color ,float64 ){if _bdad ==nil {return nil ,1;};var _gddb string ;_faef :=1.0;if _daff :=_bdad .SrgbClr ;_daff !=nil {_gddb =_daff .ValAttr ;_faef =_cd .GetOpacityFromColorTransform (_daff .EG_ColorTransform );}else if _cgdc :=_bdad .SchemeClr ;_cgdc !=nil {_gddb =_cd .GetColorStringFromDmlColor (_aagc ._afa .GetColorBySchemeColor (_cgdc .ValAttr ));_gddb =_cd .AdjustColor (_gddb ,_cgdc .EG_ColorTransform );_faef =_cd .GetOpacityFromColorTransform (_cgdc .EG_ColorTransform );};if _gddb !=""{_agedc :=_b .ColorRGBFromHex ("\u0023"+_gddb );return _agedc ,_faef ;};return nil ,1;};func _afad (_gfed *_bfd .CT_Shape )(_bfd .ST_PlaceholderType ,*uint32 ){if _aadc :=_gfed .NvSpPr ;_aadc !=nil {if _cgad :=_aadc .NvPr ;_cgad !=nil {if _dde :=_cgad .Ph ;_dde !=nil {return _dde .TypeAttr ,_dde .IdxAttr ;};};};return _bfd .ST_PlaceholderTypeUnset ,nil ;};type word struct{_ffbd []*symbol ;_eddg float64 ;_cdcd float64 ;_gadc bool ;};
\ No newline at end of file
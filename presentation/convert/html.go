@@ -0,0 +1,421 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/presentation"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/pml"
+)
+
+// HTMLOptions contains the options for converting a presentation to HTML.
+type HTMLOptions = convertutils.HTMLOptions
+
+// ConvertToHTML writes the presentation to w as HTML, each slide being a
+// fixed size container with absolutely positioned shapes, pictures and text.
+// This package is beta, breaking changes can take place.
+func ConvertToHTML(pr *presentation.Presentation, w io.Writer) error {
+	return ConvertToHTMLWithOptions(pr, w, nil)
+}
+
+// ConvertToHTMLWithOptions writes the presentation to w as HTML using the given
+// options.
+func ConvertToHTMLWithOptions(pr *presentation.Presentation, w io.Writer, opts *HTMLOptions) error {
+	o := HTMLOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Title == "" {
+		o.Title = pr.CoreProperties.Title()
+	}
+	hw := convertutils.NewHTMLWriter(&o)
+	width, height := 720.0, 540.0
+	ss := pr.SlideSize()
+	if sz := ss.Size(); sz.Width() > 0 && sz.Height() > 0 {
+		width = measurement.FromEMU(int64(sz.Width()))
+		height = measurement.FromEMU(int64(sz.Height()))
+	}
+	hw.AddRule(fmt.Sprintf("div.slide { position: relative; overflow: hidden; width: %.2fpt; height: %.2fpt; margin: 0 auto 16pt auto; background: #ffffff; box-shadow: 0 0 4pt #888888; font-family: Calibri, sans-serif; font-size: 18pt; }", width, height))
+	hw.AddRule("div.slide .shape { position: absolute; box-sizing: border-box; display: flex; flex-direction: column; overflow: visible; }")
+	hw.AddRule("div.slide .shape p { margin: 0; }")
+	hw.AddRule("div.slide img { position: absolute; }")
+	for i, s := range pr.Slides() {
+		sc := &slideHTMLContext{hw: hw, slide: s}
+		hw.Printf("<div class=\"slide\" id=\"slide-%d\"", i+1)
+		if bg := sc.backgroundCSS(); bg != "" {
+			hw.Printf(" style=\"%s\"", bg)
+		}
+		hw.WriteString(">\n")
+		if x := s.X(); x != nil && x.CSld != nil && x.CSld.SpTree != nil {
+			sc.writeGroup(x.CSld.SpTree, identityTransform)
+		}
+		hw.WriteString("</div>\n")
+	}
+	return hw.Flush(w)
+}
+
+type slideHTMLContext struct {
+	hw    *convertutils.HTMLWriter
+	slide presentation.Slide
+}
+
+// htmlTransform maps child coordinates of a group to slide coordinates.
+type htmlTransform struct {
+	dx, dy, sx, sy float64
+}
+
+var identityTransform = htmlTransform{sx: 1, sy: 1}
+
+func (t htmlTransform) apply(x, y, w, h float64) (float64, float64, float64, float64) {
+	return t.dx + x*t.sx, t.dy + y*t.sy, w * t.sx, h * t.sy
+}
+
+func (sc *slideHTMLContext) backgroundCSS() string {
+	x := sc.slide.X()
+	if x == nil || x.CSld == nil || x.CSld.Bg == nil || x.CSld.Bg.BgPr == nil {
+		return ""
+	}
+	if c := sc.solidFillCSS(x.CSld.Bg.BgPr.SolidFill); c != "" {
+		return "background:" + c
+	}
+	return ""
+}
+
+func (sc *slideHTMLContext) writeGroup(g *pml.CT_GroupShape, t htmlTransform) {
+	for _, ch := range g.Choice {
+		for _, sp := range ch.Sp {
+			sc.writeShape(sp, t)
+		}
+		for _, pic := range ch.Pic {
+			sc.writePicture(pic, t)
+		}
+		for _, grp := range ch.GrpSp {
+			sc.writeGroup(grp, groupTransform(grp, t))
+		}
+	}
+}
+
+func groupTransform(g *pml.CT_GroupShape, parent htmlTransform) htmlTransform {
+	if g.GrpSpPr == nil || g.GrpSpPr.Xfrm == nil {
+		return parent
+	}
+	xf := g.GrpSpPr.Xfrm
+	if xf.Off == nil || xf.Ext == nil || xf.ChOff == nil || xf.ChExt == nil || xf.ChExt.CxAttr == 0 || xf.ChExt.CyAttr == 0 {
+		return parent
+	}
+	offX := measurement.FromEMU(convertutils.FromSTCoordinate(xf.Off.XAttr))
+	offY := measurement.FromEMU(convertutils.FromSTCoordinate(xf.Off.YAttr))
+	chX := measurement.FromEMU(convertutils.FromSTCoordinate(xf.ChOff.XAttr))
+	chY := measurement.FromEMU(convertutils.FromSTCoordinate(xf.ChOff.YAttr))
+	sx := float64(xf.Ext.CxAttr) / float64(xf.ChExt.CxAttr)
+	sy := float64(xf.Ext.CyAttr) / float64(xf.ChExt.CyAttr)
+	// child (x, y) maps to off + (c - chOff) * s within the parent space
+	local := htmlTransform{dx: offX - chX*sx, dy: offY - chY*sy, sx: sx, sy: sy}
+	return htmlTransform{
+		dx: parent.dx + local.dx*parent.sx,
+		dy: parent.dy + local.dy*parent.sy,
+		sx: local.sx * parent.sx,
+		sy: local.sy * parent.sy,
+	}
+}
+
+// placeholderXfrm finds the transform of the layout placeholder that a slide
+// placeholder without its own transform inherits its position from.
+func (sc *slideHTMLContext) placeholderXfrm(sp *pml.CT_Shape) *dml.CT_Transform2D {
+	if sp.NvSpPr == nil || sp.NvSpPr.NvPr == nil || sp.NvSpPr.NvPr.Ph == nil {
+		return nil
+	}
+	ph := sp.NvSpPr.NvPr.Ph
+	layout := sc.slide.GetSlideLayout()
+	if layout == nil || layout.CSld == nil || layout.CSld.SpTree == nil {
+		return nil
+	}
+	for _, ch := range layout.CSld.SpTree.Choice {
+		for _, lsp := range ch.Sp {
+			if lsp.NvSpPr == nil || lsp.NvSpPr.NvPr == nil || lsp.NvSpPr.NvPr.Ph == nil || lsp.SpPr == nil || lsp.SpPr.Xfrm == nil {
+				continue
+			}
+			lph := lsp.NvSpPr.NvPr.Ph
+			if ph.IdxAttr != nil && lph.IdxAttr != nil && *ph.IdxAttr == *lph.IdxAttr {
+				return lsp.SpPr.Xfrm
+			}
+			if ph.IdxAttr == nil && lph.TypeAttr == ph.TypeAttr {
+				return lsp.SpPr.Xfrm
+			}
+		}
+	}
+	return nil
+}
+
+func boxCSS(xfrm *dml.CT_Transform2D, t htmlTransform) string {
+	x, y, w, h := convertutils.GetDataFromXfrm(xfrm)
+	x, y, w, h = t.apply(x, y, w, h)
+	css := fmt.Sprintf("left:%.2fpt;top:%.2fpt;width:%.2fpt;height:%.2fpt", x, y, w, h)
+	var tr []string
+	if xfrm.RotAttr != nil && *xfrm.RotAttr != 0 {
+		tr = append(tr, fmt.Sprintf("rotate(%gdeg)", float64(*xfrm.RotAttr)/60000))
+	}
+	if xfrm.FlipHAttr != nil && *xfrm.FlipHAttr {
+		tr = append(tr, "scaleX(-1)")
+	}
+	if xfrm.FlipVAttr != nil && *xfrm.FlipVAttr {
+		tr = append(tr, "scaleY(-1)")
+	}
+	if len(tr) > 0 {
+		css += ";transform:" + strings.Join(tr, " ")
+	}
+	return css
+}
+
+func (sc *slideHTMLContext) writeShape(sp *pml.CT_Shape, t htmlTransform) {
+	var xfrm *dml.CT_Transform2D
+	if sp.SpPr != nil {
+		xfrm = sp.SpPr.Xfrm
+	}
+	if xfrm == nil {
+		xfrm = sc.placeholderXfrm(sp)
+	}
+	if xfrm == nil {
+		return
+	}
+	decls := []string{boxCSS(xfrm, t)}
+	if spPr := sp.SpPr; spPr != nil {
+		if spPr.NoFill == nil {
+			if c := sc.solidFillCSS(spPr.SolidFill); c != "" {
+				decls = append(decls, "background:"+c)
+			}
+		}
+		if ln := spPr.Ln; ln != nil && ln.NoFill == nil {
+			if c := sc.solidFillCSS(ln.SolidFill); c != "" {
+				width := 0.75
+				if ln.WAttr != nil {
+					width = measurement.FromEMU(int64(*ln.WAttr))
+				}
+				decls = append(decls, fmt.Sprintf("border:%.2fpt solid %s", width, c))
+			}
+		}
+		if pg := spPr.PrstGeom; pg != nil {
+			switch pg.PrstAttr {
+			case dml.ST_ShapeTypeEllipse:
+				decls = append(decls, "border-radius:50%")
+			case dml.ST_ShapeTypeRoundRect:
+				decls = append(decls, "border-radius:10%")
+			}
+		}
+	}
+	if tb := sp.TxBody; tb != nil {
+		decls = append(decls, bodyPropertiesCSS(tb.BodyPr)...)
+	}
+	sc.hw.Printf("<div class=\"shape\" style=\"%s\">", strings.Join(decls, ";"))
+	if tb := sp.TxBody; tb != nil {
+		sc.writeTextBody(tb)
+	}
+	sc.hw.WriteString("</div>\n")
+}
+
+func bodyPropertiesCSS(bp *dml.CT_TextBodyProperties) []string {
+	// default insets are 0.1in left/right and 0.05in top/bottom
+	l, t, r, b := 7.2, 3.6, 7.2, 3.6
+	var decls []string
+	if bp != nil {
+		if bp.LInsAttr != nil {
+			l = measurement.FromEMU(convertutils.FromSTCoordinate32(*bp.LInsAttr))
+		}
+		if bp.TInsAttr != nil {
+			t = measurement.FromEMU(convertutils.FromSTCoordinate32(*bp.TInsAttr))
+		}
+		if bp.RInsAttr != nil {
+			r = measurement.FromEMU(convertutils.FromSTCoordinate32(*bp.RInsAttr))
+		}
+		if bp.BInsAttr != nil {
+			b = measurement.FromEMU(convertutils.FromSTCoordinate32(*bp.BInsAttr))
+		}
+		switch bp.AnchorAttr {
+		case dml.ST_TextAnchoringTypeCtr:
+			decls = append(decls, "justify-content:center")
+		case dml.ST_TextAnchoringTypeB:
+			decls = append(decls, "justify-content:flex-end")
+		}
+	}
+	return append(decls, fmt.Sprintf("padding:%.2fpt %.2fpt %.2fpt %.2fpt", t, r, b, l))
+}
+
+func (sc *slideHTMLContext) writeTextBody(tb *dml.CT_TextBody) {
+	for _, p := range tb.P {
+		var decls []string
+		var bullet string
+		if ppr := p.PPr; ppr != nil {
+			switch ppr.AlgnAttr {
+			case dml.ST_TextAlignTypeCtr:
+				decls = append(decls, "text-align:center")
+			case dml.ST_TextAlignTypeR:
+				decls = append(decls, "text-align:right")
+			case dml.ST_TextAlignTypeJust, dml.ST_TextAlignTypeJustLow, dml.ST_TextAlignTypeDist, dml.ST_TextAlignTypeThaiDist:
+				decls = append(decls, "text-align:justify")
+			}
+			if ppr.MarLAttr != nil {
+				decls = append(decls, fmt.Sprintf("margin-left:%.2fpt", measurement.FromEMU(int64(*ppr.MarLAttr))))
+			}
+			if ppr.IndentAttr != nil {
+				decls = append(decls, fmt.Sprintf("text-indent:%.2fpt", measurement.FromEMU(int64(*ppr.IndentAttr))))
+			}
+			if ppr.BuChar != nil && ppr.BuNone == nil {
+				bullet = ppr.BuChar.CharAttr
+			}
+		}
+		sc.hw.WriteString("<p")
+		if len(decls) > 0 {
+			sc.hw.Printf(" style=\"%s\"", strings.Join(decls, ";"))
+		}
+		sc.hw.WriteString(">")
+		if bullet != "" && len(p.EG_TextRun) > 0 {
+			sc.hw.WriteText(bullet + " ")
+		}
+		if len(p.EG_TextRun) == 0 {
+			sc.hw.WriteString("&nbsp;")
+		}
+		for _, tr := range p.EG_TextRun {
+			switch {
+			case tr.R != nil:
+				sc.writeRun(tr.R.RPr, tr.R.T)
+			case tr.Fld != nil:
+				sc.writeRun(tr.Fld.RPr, textFieldText(tr.Fld))
+			case tr.Br != nil:
+				sc.hw.WriteString("<br>")
+			}
+		}
+		sc.hw.WriteString("</p>")
+	}
+}
+
+func textFieldText(f *dml.CT_TextField) string {
+	if f.T != nil {
+		return *f.T
+	}
+	return ""
+}
+
+func (sc *slideHTMLContext) writeRun(rpr *dml.CT_TextCharacterProperties, text string) {
+	var decls []string
+	href := ""
+	if rpr != nil {
+		if rpr.SzAttr != nil {
+			decls = append(decls, fmt.Sprintf("font-size:%gpt", float64(*rpr.SzAttr)/100))
+		}
+		if rpr.BAttr != nil && *rpr.BAttr {
+			decls = append(decls, "font-weight:bold")
+		}
+		if rpr.IAttr != nil && *rpr.IAttr {
+			decls = append(decls, "font-style:italic")
+		}
+		var deco []string
+		if rpr.UAttr != dml.ST_TextUnderlineTypeUnset && rpr.UAttr != dml.ST_TextUnderlineTypeNone {
+			deco = append(deco, "underline")
+		}
+		if rpr.StrikeAttr == dml.ST_TextStrikeTypeSngStrike || rpr.StrikeAttr == dml.ST_TextStrikeTypeDblStrike {
+			deco = append(deco, "line-through")
+		}
+		if len(deco) > 0 {
+			decls = append(decls, "text-decoration:"+strings.Join(deco, " "))
+		}
+		if c := sc.solidFillCSS(rpr.SolidFill); c != "" {
+			decls = append(decls, "color:"+c)
+		}
+		if rpr.Latin != nil && rpr.Latin.TypefaceAttr != "" && !strings.HasPrefix(rpr.Latin.TypefaceAttr, "+") {
+			if ff := convertutils.CSSFontFamily(rpr.Latin.TypefaceAttr); ff != "" {
+				decls = append(decls, "font-family:"+ff)
+			}
+		}
+		if rpr.BaselineAttr != nil {
+			if b := convertutils.FromSTPercentage(rpr.BaselineAttr); b > 0 {
+				decls = append(decls, "vertical-align:super")
+			} else if b < 0 {
+				decls = append(decls, "vertical-align:sub")
+			}
+		}
+		if hl := rpr.HlinkClick; hl != nil && hl.IdAttr != nil {
+			href = convertutils.SafeHref(sc.relTarget(*hl.IdAttr))
+		}
+	}
+	if href != "" {
+		sc.hw.Printf("<a href=\"%s\">", convertutils.HTMLAttr(href))
+	}
+	if len(decls) > 0 {
+		sc.hw.Printf("<span class=\"%s\">", sc.hw.Class("r", strings.Join(decls, ";")))
+		sc.hw.WriteText(text)
+		sc.hw.WriteString("</span>")
+	} else {
+		sc.hw.WriteText(text)
+	}
+	if href != "" {
+		sc.hw.WriteString("</a>")
+	}
+}
+
+// relTarget returns the target of a slide relationship, used for hyperlinks.
+func (sc *slideHTMLContext) relTarget(id string) string {
+	return sc.slide.GetTargetByRelId(id)
+}
+
+func (sc *slideHTMLContext) writePicture(pic *pml.CT_Picture, t htmlTransform) {
+	if pic.SpPr == nil || pic.SpPr.Xfrm == nil || pic.BlipFill == nil || pic.BlipFill.Blip == nil || pic.BlipFill.Blip.EmbedAttr == nil {
+		return
+	}
+	ref, ok := sc.slide.GetImageByRelID(*pic.BlipFill.Blip.EmbedAttr)
+	if !ok {
+		return
+	}
+	src, err := sc.hw.ImageSource(ref.Data(), ref.Path(), ref.Format())
+	if err != nil {
+		return
+	}
+	alt := ""
+	if pic.NvPicPr != nil && pic.NvPicPr.CNvPr != nil && pic.NvPicPr.CNvPr.DescrAttr != nil {
+		alt = *pic.NvPicPr.CNvPr.DescrAttr
+	}
+	sc.hw.Printf("<img src=\"%s\" alt=\"%s\" style=\"%s\">\n", src, convertutils.HTMLAttr(alt), boxCSS(pic.SpPr.Xfrm, t))
+}
+
+// solidFillCSS resolves a solid fill to a CSS color, looking up scheme colors
+// through the slide's color map and theme.
+func (sc *slideHTMLContext) solidFillCSS(f *dml.CT_SolidColorFillProperties) string {
+	if f == nil {
+		return ""
+	}
+	var c string
+	var transforms []*dml.EG_ColorTransform
+	switch {
+	case f.SrgbClr != nil:
+		c = f.SrgbClr.ValAttr
+		transforms = f.SrgbClr.EG_ColorTransform
+	case f.SysClr != nil:
+		if f.SysClr.LastClrAttr != nil {
+			c = *f.SysClr.LastClrAttr
+		}
+		transforms = f.SysClr.EG_ColorTransform
+	case f.SchemeClr != nil:
+		if dc := sc.slide.GetColorBySchemeColor(f.SchemeClr.ValAttr); dc != nil {
+			if dc.SrgbClr != nil {
+				c = dc.SrgbClr.ValAttr
+			} else if dc.SysClr != nil && dc.SysClr.LastClrAttr != nil {
+				c = *dc.SysClr.LastClrAttr
+			}
+		}
+		transforms = f.SchemeClr.EG_ColorTransform
+	}
+	if c == "" {
+		return ""
+	}
+	return convertutils.CSSColor(convertutils.AdjustColor(c, transforms))
+}
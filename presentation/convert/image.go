@@ -0,0 +1,88 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"errors"
+	"image"
+
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/presentation"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unipdf/v3/creator"
+)
+
+// ImageOptions contains the options for rendering slides to images.
+type ImageOptions = convertutils.ImageOptions
+
+// ConvertToImages renders every slide of the presentation to an image, using
+// the same layout as ConvertToPdf. The images can be encoded with image/png
+// or image/jpeg. This package is beta, breaking changes can take place.
+func ConvertToImages(pr *presentation.Presentation, opts *ImageOptions) ([]image.Image, error) {
+	return convertutils.RenderCreatorToImages(ConvertToPdf(pr), opts)
+}
+
+// ConvertSlideToImage renders a single slide to an image at the resolution
+// given in the options, 96 DPI by default.
+func ConvertSlideToImage(s presentation.Slide, opts *ImageOptions) (image.Image, error) {
+	c, err := slideCreator(s)
+	if err != nil {
+		return nil, err
+	}
+	images, err := convertutils.RenderCreatorToImages(c, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, errors.New("slide has no content")
+	}
+	return images[0], nil
+}
+
+// slideCreator draws a single slide onto a page of the size of the slides.
+func slideCreator(s presentation.Slide) (*creator.Creator, error) {
+	pr := s.Presentation()
+	if pr == nil || s.X() == nil {
+		return nil, errors.New("slide is not part of a presentation")
+	}
+	size := slideSize(pr)
+	c := creator.New()
+	c.SetPageSize(size)
+	convertSlide(c, pr, &s, presentationTheme(pr), size)
+	return c, nil
+}
+
+// slideSize returns the slide size of the presentation, 10 by 7.5 inches if
+// it is not set.
+func slideSize(pr *presentation.Presentation) creator.PageSize {
+	sz := pr.X().SldSz
+	if sz == nil {
+		return creator.PageSize{10 * measurement.Inch, 7.5 * measurement.Inch}
+	}
+	return creator.PageSize{measurement.FromEMU(int64(sz.CxAttr)), measurement.FromEMU(int64(sz.CyAttr))}
+}
+
+func presentationTheme(pr *presentation.Presentation) *dml.Theme {
+	if len(pr.Themes()) > 0 {
+		return pr.Themes()[0]
+	}
+	return nil
+}
+
+// convertSlide draws a slide onto a new page of the creator. It is shared by
+// the PDF and image converters.
+func convertSlide(c *creator.Creator, pr *presentation.Presentation, s *presentation.Slide, theme *dml.Theme, size creator.PageSize) {
+	if s.X() == nil {
+		return
+	}
+	ctx := &convertContext{_fcbbg: c, _afa: s, _ecfg: s.GetSlideLayout(), _eee: pr.SlideMasters()[0].X(), _cbed: pr, _dbdb: theme, _gef: s.X().ClrMapOvr, _eeed: size[1], _dfag: size[0]}
+	ctx.extractDefaultProperties()
+	ctx.makeSlide()
+	ctx.drawSlide()
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/presentation"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/pml"
+)
+
+func TestSlideCreator(t *testing.T) {
+	pr := presentation.New()
+	// the converter needs the text styles that PowerPoint writes
+	pr.X().DefaultTextStyle = dml.NewCT_TextListStyle()
+	pr.X().DefaultTextStyle.DefPPr = dml.NewCT_TextParagraphProperties()
+	styles := pml.NewCT_SlideMasterTextStyles()
+	styles.TitleStyle = dml.NewCT_TextListStyle()
+	styles.BodyStyle = dml.NewCT_TextListStyle()
+	pr.SlideMasters()[0].X().TxStyles = styles
+	slide, err := pr.AddDefaultSlideWithLayout(pr.SlideLayouts()[0])
+	if err != nil {
+		t.Fatalf("error adding slide: %s", err)
+	}
+	td := []struct {
+		Name string
+		Size *pml.CT_SlideSize
+		W, H float64
+	}{
+		{"default", nil, 10 * measurement.Inch, 7.5 * measurement.Inch},
+		{"wide", &pml.CT_SlideSize{CxAttr: 12192000, CyAttr: 6858000}, 960, 540},
+	}
+	for _, tc := range td {
+		pr.X().SldSz = tc.Size
+		c, err := slideCreator(slide)
+		if err != nil {
+			t.Fatalf("%s: error drawing the slide: %s", tc.Name, err)
+		}
+		if c.Context().Page != 1 {
+			t.Errorf("%s: expected the slide on a single page, got %d pages", tc.Name, c.Context().Page)
+		}
+		if math.Abs(c.Width()-tc.W) > 1e-6 || math.Abs(c.Height()-tc.H) > 1e-6 {
+			t.Errorf("%s: expected a page of %vx%v, got %vx%v", tc.Name, tc.W, tc.H, c.Width(), c.Height())
+		}
+	}
+
+	if _, err := ConvertSlideToImage(presentation.Slide{}, nil); err == nil {
+		t.Errorf("expected an error for a slide without a presentation")
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package presentation
+
+// GetTargetByRelId returns the target of the slide relationship with the given
+// ID, e.g. the URL of a hyperlink.
+func (s *Slide) GetTargetByRelId(idAttr string) string {
+	rels := s.getSlideRels()
+	if rels.X() == nil {
+		return ""
+	}
+	return rels.GetTargetByRelId(idAttr)
+}
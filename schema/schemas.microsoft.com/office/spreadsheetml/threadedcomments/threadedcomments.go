@@ -0,0 +1,497 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+// Package threadedcomments contains the threaded comments and persons parts
+// of SpreadsheetML that are used by Excel 365 for comments with replies.
+package threadedcomments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common/logger"
+)
+
+// NS is the namespace of threaded comments and persons.
+const NS = "http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"
+
+// nsMain is the SpreadsheetML main namespace that Excel binds to the prefix x
+// in threaded comment parts.
+const nsMain = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+
+// dateLayouts are the layouts of the date of a threaded comment, Excel writes
+// hundredths of seconds without a time zone.
+var dateLayouts = []string{"2006-01-02T15:04:05.00", time.RFC3339Nano, "2006-01-02T15:04:05"}
+
+// ThreadedComments is the root element of a threaded comments part of a
+// worksheet.
+type ThreadedComments struct {
+	CT_ThreadedComments
+}
+
+func NewThreadedComments() *ThreadedComments {
+	ret := &ThreadedComments{}
+	ret.CT_ThreadedComments = *NewCT_ThreadedComments()
+	return ret
+}
+
+func (m *ThreadedComments) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "ThreadedComments"}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: NS})
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:x"}, Value: nsMain})
+	return m.CT_ThreadedComments.MarshalXML(e, start)
+}
+
+// Validate validates the ThreadedComments and its children
+func (m *ThreadedComments) Validate() error {
+	return m.ValidateWithPath("ThreadedComments")
+}
+
+// ValidateWithPath validates the ThreadedComments and its children, prefixing
+// error messages with path
+func (m *ThreadedComments) ValidateWithPath(path string) error {
+	return m.CT_ThreadedComments.ValidateWithPath(path)
+}
+
+type CT_ThreadedComments struct {
+	ThreadedComment []*CT_ThreadedComment
+}
+
+func NewCT_ThreadedComments() *CT_ThreadedComments { return &CT_ThreadedComments{} }
+
+func (m *CT_ThreadedComments) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeToken(start)
+	se := xml.StartElement{Name: xml.Name{Local: "threadedComment"}}
+	for _, c := range m.ThreadedComment {
+		e.EncodeElement(c, se)
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_ThreadedComments) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+lThreadedComments:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NS, Local: "threadedComment"}:
+				c := NewCT_ThreadedComment()
+				if err := d.DecodeElement(c, &el); err != nil {
+					return err
+				}
+				m.ThreadedComment = append(m.ThreadedComment, c)
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_ThreadedComments %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lThreadedComments
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_ThreadedComments and its children,
+// prefixing error messages with path
+func (m *CT_ThreadedComments) ValidateWithPath(path string) error {
+	for i, c := range m.ThreadedComment {
+		if err := c.ValidateWithPath(fmt.Sprintf("%s/ThreadedComment[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type CT_ThreadedComment struct {
+	// RefAttr is the cell the comment belongs to.
+	RefAttr *string
+	// DTAttr is the time the comment was written.
+	DTAttr *time.Time
+	// PersonIdAttr is the id of the author in the person list.
+	PersonIdAttr string
+	IdAttr       string
+	// ParentIdAttr is the id of the first comment of the thread for replies.
+	ParentIdAttr *string
+	// DoneAttr marks a thread as resolved, it is only set on the first
+	// comment.
+	DoneAttr *bool
+	Text     *string
+	Mentions *CT_Mentions
+}
+
+func NewCT_ThreadedComment() *CT_ThreadedComment { return &CT_ThreadedComment{} }
+
+func (m *CT_ThreadedComment) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if m.RefAttr != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "ref"}, Value: *m.RefAttr})
+	}
+	if m.DTAttr != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "dT"}, Value: m.DTAttr.Format(dateLayouts[0])})
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "personId"}, Value: m.PersonIdAttr})
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "id"}, Value: m.IdAttr})
+	if m.ParentIdAttr != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "parentId"}, Value: *m.ParentIdAttr})
+	}
+	if m.DoneAttr != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "done"}, Value: boolValue(*m.DoneAttr)})
+	}
+	e.EncodeToken(start)
+	if m.Text != nil {
+		e.EncodeElement(*m.Text, xml.StartElement{Name: xml.Name{Local: "text"}})
+	}
+	if m.Mentions != nil {
+		e.EncodeElement(m.Mentions, xml.StartElement{Name: xml.Name{Local: "mentions"}})
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_ThreadedComment) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "ref":
+			v := attr.Value
+			m.RefAttr = &v
+		case "dT":
+			for _, layout := range dateLayouts {
+				if t, err := time.Parse(layout, attr.Value); err == nil {
+					m.DTAttr = &t
+					break
+				}
+			}
+		case "personId":
+			m.PersonIdAttr = attr.Value
+		case "id":
+			m.IdAttr = attr.Value
+		case "parentId":
+			v := attr.Value
+			m.ParentIdAttr = &v
+		case "done":
+			v, err := strconv.ParseBool(attr.Value)
+			if err != nil {
+				return err
+			}
+			m.DoneAttr = &v
+		}
+	}
+lThreadedComment:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NS, Local: "text"}:
+				var t string
+				if err := d.DecodeElement(&t, &el); err != nil {
+					return err
+				}
+				m.Text = &t
+			case xml.Name{Space: NS, Local: "mentions"}:
+				m.Mentions = NewCT_Mentions()
+				if err := d.DecodeElement(m.Mentions, &el); err != nil {
+					return err
+				}
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_ThreadedComment %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lThreadedComment
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_ThreadedComment and its children,
+// prefixing error messages with path
+func (m *CT_ThreadedComment) ValidateWithPath(path string) error {
+	if m.IdAttr == "" {
+		return fmt.Errorf("%s/IdAttr is a mandatory field", path)
+	}
+	if m.PersonIdAttr == "" {
+		return fmt.Errorf("%s/PersonIdAttr is a mandatory field", path)
+	}
+	if m.Mentions != nil {
+		return m.Mentions.ValidateWithPath(path + "/Mentions")
+	}
+	return nil
+}
+
+type CT_Mentions struct {
+	Mention []*CT_Mention
+}
+
+func NewCT_Mentions() *CT_Mentions { return &CT_Mentions{} }
+
+func (m *CT_Mentions) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeToken(start)
+	se := xml.StartElement{Name: xml.Name{Local: "mention"}}
+	for _, mt := range m.Mention {
+		e.EncodeElement(mt, se)
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_Mentions) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+lMentions:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NS, Local: "mention"}:
+				mt := NewCT_Mention()
+				if err := d.DecodeElement(mt, &el); err != nil {
+					return err
+				}
+				m.Mention = append(m.Mention, mt)
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_Mentions %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lMentions
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_Mentions and its children, prefixing
+// error messages with path
+func (m *CT_Mentions) ValidateWithPath(path string) error {
+	for i, mt := range m.Mention {
+		if err := mt.ValidateWithPath(fmt.Sprintf("%s/Mention[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CT_Mention is a mention of a person within the text of a comment.
+type CT_Mention struct {
+	MentionpersonIdAttr string
+	MentionIdAttr       string
+	// StartIndexAttr is the index of the first character of the mention in
+	// the text, including the leading @.
+	StartIndexAttr uint32
+	LengthAttr     uint32
+}
+
+func NewCT_Mention() *CT_Mention { return &CT_Mention{} }
+
+func (m *CT_Mention) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "mentionpersonId"}, Value: m.MentionpersonIdAttr},
+		xml.Attr{Name: xml.Name{Local: "mentionId"}, Value: m.MentionIdAttr},
+		xml.Attr{Name: xml.Name{Local: "startIndex"}, Value: strconv.FormatUint(uint64(m.StartIndexAttr), 10)},
+		xml.Attr{Name: xml.Name{Local: "length"}, Value: strconv.FormatUint(uint64(m.LengthAttr), 10)})
+	e.EncodeToken(start)
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_Mention) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "mentionpersonId":
+			m.MentionpersonIdAttr = attr.Value
+		case "mentionId":
+			m.MentionIdAttr = attr.Value
+		case "startIndex", "length":
+			v, err := strconv.ParseUint(attr.Value, 10, 32)
+			if err != nil {
+				return err
+			}
+			if attr.Name.Local == "length" {
+				m.LengthAttr = uint32(v)
+			} else {
+				m.StartIndexAttr = uint32(v)
+			}
+		}
+	}
+	return d.Skip()
+}
+
+// ValidateWithPath validates the CT_Mention, prefixing error messages with
+// path
+func (m *CT_Mention) ValidateWithPath(path string) error {
+	if m.MentionpersonIdAttr == "" {
+		return fmt.Errorf("%s/MentionpersonIdAttr is a mandatory field", path)
+	}
+	return nil
+}
+
+// PersonList is the root element of the persons part of a workbook, it lists
+// the authors of threaded comments.
+type PersonList struct {
+	CT_PersonList
+}
+
+func NewPersonList() *PersonList {
+	ret := &PersonList{}
+	ret.CT_PersonList = *NewCT_PersonList()
+	return ret
+}
+
+func (m *PersonList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "personList"}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: NS})
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:x"}, Value: nsMain})
+	return m.CT_PersonList.MarshalXML(e, start)
+}
+
+// Validate validates the PersonList and its children
+func (m *PersonList) Validate() error {
+	return m.ValidateWithPath("PersonList")
+}
+
+// ValidateWithPath validates the PersonList and its children, prefixing error
+// messages with path
+func (m *PersonList) ValidateWithPath(path string) error {
+	return m.CT_PersonList.ValidateWithPath(path)
+}
+
+type CT_PersonList struct {
+	Person []*CT_Person
+}
+
+func NewCT_PersonList() *CT_PersonList { return &CT_PersonList{} }
+
+func (m *CT_PersonList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeToken(start)
+	se := xml.StartElement{Name: xml.Name{Local: "person"}}
+	for _, p := range m.Person {
+		e.EncodeElement(p, se)
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_PersonList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+lPersonList:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NS, Local: "person"}:
+				p := NewCT_Person()
+				if err := d.DecodeElement(p, &el); err != nil {
+					return err
+				}
+				m.Person = append(m.Person, p)
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_PersonList %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lPersonList
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_PersonList and its children, prefixing
+// error messages with path
+func (m *CT_PersonList) ValidateWithPath(path string) error {
+	for i, p := range m.Person {
+		if err := p.ValidateWithPath(fmt.Sprintf("%s/Person[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type CT_Person struct {
+	DisplayNameAttr string
+	IdAttr          string
+	// UserIdAttr identifies the person to the identity provider, e.g. an
+	// email address.
+	UserIdAttr *string
+	// ProviderIdAttr is the identity provider, e.g. AD or None.
+	ProviderIdAttr *string
+}
+
+func NewCT_Person() *CT_Person { return &CT_Person{} }
+
+func (m *CT_Person) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "displayName"}, Value: m.DisplayNameAttr})
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "id"}, Value: m.IdAttr})
+	if m.UserIdAttr != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "userId"}, Value: *m.UserIdAttr})
+	}
+	if m.ProviderIdAttr != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "providerId"}, Value: *m.ProviderIdAttr})
+	}
+	e.EncodeToken(start)
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_Person) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		v := attr.Value
+		switch attr.Name.Local {
+		case "displayName":
+			m.DisplayNameAttr = v
+		case "id":
+			m.IdAttr = v
+		case "userId":
+			m.UserIdAttr = &v
+		case "providerId":
+			m.ProviderIdAttr = &v
+		}
+	}
+	return d.Skip()
+}
+
+// ValidateWithPath validates the CT_Person, prefixing error messages with path
+func (m *CT_Person) ValidateWithPath(path string) error {
+	if m.IdAttr == "" {
+		return fmt.Errorf("%s/IdAttr is a mandatory field", path)
+	}
+	return nil
+}
+
+func boolValue(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func init() {
+	unioffice.RegisterConstructor(NS, "ThreadedComments", NewThreadedComments)
+	unioffice.RegisterConstructor(NS, "personList", NewPersonList)
+}
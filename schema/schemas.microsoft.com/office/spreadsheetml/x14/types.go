@@ -0,0 +1,126 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package x14
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// ST_SparklineType is the type of the sparklines of a group.
+type ST_SparklineType byte
+
+const (
+	ST_SparklineTypeUnset   ST_SparklineType = 0
+	ST_SparklineTypeLine    ST_SparklineType = 1
+	ST_SparklineTypeColumn  ST_SparklineType = 2
+	ST_SparklineTypeStacked ST_SparklineType = 3
+)
+
+var sparklineTypeNames = []string{"", "line", "column", "stacked"}
+
+func (m ST_SparklineType) String() string { return enumString(sparklineTypeNames, byte(m)) }
+
+func (m ST_SparklineType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: m.String()}, nil
+}
+
+func (m *ST_SparklineType) UnmarshalXMLAttr(attr xml.Attr) error {
+	*m = ST_SparklineType(enumValue(sparklineTypeNames, attr.Value))
+	return nil
+}
+
+func (m ST_SparklineType) Validate() error { return m.ValidateWithPath("") }
+
+func (m ST_SparklineType) ValidateWithPath(path string) error {
+	return validateEnum(path, sparklineTypeNames, byte(m))
+}
+
+// ST_DispBlanksAs determines how empty cells are shown in sparklines.
+type ST_DispBlanksAs byte
+
+const (
+	ST_DispBlanksAsUnset ST_DispBlanksAs = 0
+	ST_DispBlanksAsSpan  ST_DispBlanksAs = 1
+	ST_DispBlanksAsGap   ST_DispBlanksAs = 2
+	ST_DispBlanksAsZero  ST_DispBlanksAs = 3
+)
+
+var dispBlanksAsNames = []string{"", "span", "gap", "zero"}
+
+func (m ST_DispBlanksAs) String() string { return enumString(dispBlanksAsNames, byte(m)) }
+
+func (m ST_DispBlanksAs) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: m.String()}, nil
+}
+
+func (m *ST_DispBlanksAs) UnmarshalXMLAttr(attr xml.Attr) error {
+	*m = ST_DispBlanksAs(enumValue(dispBlanksAsNames, attr.Value))
+	return nil
+}
+
+func (m ST_DispBlanksAs) Validate() error { return m.ValidateWithPath("") }
+
+func (m ST_DispBlanksAs) ValidateWithPath(path string) error {
+	return validateEnum(path, dispBlanksAsNames, byte(m))
+}
+
+// ST_SparklineAxisMinMax determines how the minimum or maximum of the vertical
+// axis of sparklines is chosen.
+type ST_SparklineAxisMinMax byte
+
+const (
+	ST_SparklineAxisMinMaxUnset      ST_SparklineAxisMinMax = 0
+	ST_SparklineAxisMinMaxIndividual ST_SparklineAxisMinMax = 1
+	ST_SparklineAxisMinMaxGroup      ST_SparklineAxisMinMax = 2
+	ST_SparklineAxisMinMaxCustom     ST_SparklineAxisMinMax = 3
+)
+
+var sparklineAxisMinMaxNames = []string{"", "individual", "group", "custom"}
+
+func (m ST_SparklineAxisMinMax) String() string {
+	return enumString(sparklineAxisMinMaxNames, byte(m))
+}
+
+func (m ST_SparklineAxisMinMax) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: m.String()}, nil
+}
+
+func (m *ST_SparklineAxisMinMax) UnmarshalXMLAttr(attr xml.Attr) error {
+	*m = ST_SparklineAxisMinMax(enumValue(sparklineAxisMinMaxNames, attr.Value))
+	return nil
+}
+
+func (m ST_SparklineAxisMinMax) Validate() error { return m.ValidateWithPath("") }
+
+func (m ST_SparklineAxisMinMax) ValidateWithPath(path string) error {
+	return validateEnum(path, sparklineAxisMinMaxNames, byte(m))
+}
+
+func enumString(names []string, v byte) string {
+	if int(v) < len(names) {
+		return names[v]
+	}
+	return ""
+}
+
+func enumValue(names []string, s string) byte {
+	for i, n := range names {
+		if n == s {
+			return byte(i)
+		}
+	}
+	return 0
+}
+
+func validateEnum(path string, names []string, v byte) error {
+	if int(v) >= len(names) {
+		return fmt.Errorf("%s: out of range value %d", path, v)
+	}
+	return nil
+}
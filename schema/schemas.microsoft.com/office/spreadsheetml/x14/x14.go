@@ -0,0 +1,514 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+// Package x14 contains the Excel 2010 extensions to SpreadsheetML that are
+// stored in the extension lists of a worksheet, e.g. sparkline groups.
+package x14
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common/logger"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+const (
+	// NS is the namespace of the Excel 2010 extensions, usually bound to the
+	// prefix x14.
+	NS = "http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"
+	// NSExcelMain is the namespace of formulas and references in extensions,
+	// usually bound to the prefix xm.
+	NSExcelMain = "http://schemas.microsoft.com/office/excel/2006/main"
+
+	// SparklineGroupsExtURI identifies the worksheet extension that holds the
+	// sparkline groups.
+	SparklineGroupsExtURI = "{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"
+)
+
+// SparklineGroups is the x14:sparklineGroups element of a worksheet extension.
+type SparklineGroups struct {
+	CT_SparklineGroups
+}
+
+func NewSparklineGroups() *SparklineGroups {
+	ret := &SparklineGroups{}
+	ret.CT_SparklineGroups = *NewCT_SparklineGroups()
+	return ret
+}
+
+func (m *SparklineGroups) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "x14:sparklineGroups"}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:x14"}, Value: NS})
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:xm"}, Value: NSExcelMain})
+	return m.CT_SparklineGroups.MarshalXML(e, start)
+}
+
+// Validate validates the SparklineGroups and its children
+func (m *SparklineGroups) Validate() error {
+	return m.ValidateWithPath("SparklineGroups")
+}
+
+// ValidateWithPath validates the SparklineGroups and its children, prefixing
+// error messages with path
+func (m *SparklineGroups) ValidateWithPath(path string) error {
+	return m.CT_SparklineGroups.ValidateWithPath(path)
+}
+
+type CT_SparklineGroups struct {
+	SparklineGroup []*CT_SparklineGroup
+}
+
+func NewCT_SparklineGroups() *CT_SparklineGroups { return &CT_SparklineGroups{} }
+
+func (m *CT_SparklineGroups) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeToken(start)
+	se := xml.StartElement{Name: xml.Name{Local: "x14:sparklineGroup"}}
+	for _, g := range m.SparklineGroup {
+		e.EncodeElement(g, se)
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_SparklineGroups) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+lSparklineGroups:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NS, Local: "sparklineGroup"}:
+				g := NewCT_SparklineGroup()
+				if err := d.DecodeElement(g, &el); err != nil {
+					return err
+				}
+				m.SparklineGroup = append(m.SparklineGroup, g)
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_SparklineGroups %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lSparklineGroups
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_SparklineGroups and its children, prefixing
+// error messages with path
+func (m *CT_SparklineGroups) ValidateWithPath(path string) error {
+	for i, g := range m.SparklineGroup {
+		if err := g.ValidateWithPath(fmt.Sprintf("%s/SparklineGroup[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type CT_SparklineGroup struct {
+	ManualMaxAttr           *float64
+	ManualMinAttr           *float64
+	LineWeightAttr          *float64
+	TypeAttr                ST_SparklineType
+	DateAxisAttr            *bool
+	DisplayEmptyCellsAsAttr ST_DispBlanksAs
+	MarkersAttr             *bool
+	HighAttr                *bool
+	LowAttr                 *bool
+	FirstAttr               *bool
+	LastAttr                *bool
+	NegativeAttr            *bool
+	DisplayXAxisAttr        *bool
+	DisplayHiddenAttr       *bool
+	MinAxisTypeAttr         ST_SparklineAxisMinMax
+	MaxAxisTypeAttr         ST_SparklineAxisMinMax
+	RightToLeftAttr         *bool
+	ColorSeries             *sml.CT_Color
+	ColorNegative           *sml.CT_Color
+	ColorAxis               *sml.CT_Color
+	ColorMarkers            *sml.CT_Color
+	ColorFirst              *sml.CT_Color
+	ColorLast               *sml.CT_Color
+	ColorHigh               *sml.CT_Color
+	ColorLow                *sml.CT_Color
+	// F is the range of the dates of a date axis.
+	F          *string
+	Sparklines *CT_Sparklines
+	ExtLst     *sml.CT_ExtensionList
+}
+
+func NewCT_SparklineGroup() *CT_SparklineGroup {
+	ret := &CT_SparklineGroup{}
+	ret.Sparklines = NewCT_Sparklines()
+	return ret
+}
+
+func (m *CT_SparklineGroup) colors() []struct {
+	name string
+	c    **sml.CT_Color
+} {
+	return []struct {
+		name string
+		c    **sml.CT_Color
+	}{
+		{"colorSeries", &m.ColorSeries},
+		{"colorNegative", &m.ColorNegative},
+		{"colorAxis", &m.ColorAxis},
+		{"colorMarkers", &m.ColorMarkers},
+		{"colorFirst", &m.ColorFirst},
+		{"colorLast", &m.ColorLast},
+		{"colorHigh", &m.ColorHigh},
+		{"colorLow", &m.ColorLow},
+	}
+}
+
+func (m *CT_SparklineGroup) bools() []struct {
+	name string
+	b    **bool
+} {
+	return []struct {
+		name string
+		b    **bool
+	}{
+		{"dateAxis", &m.DateAxisAttr},
+		{"markers", &m.MarkersAttr},
+		{"high", &m.HighAttr},
+		{"low", &m.LowAttr},
+		{"first", &m.FirstAttr},
+		{"last", &m.LastAttr},
+		{"negative", &m.NegativeAttr},
+		{"displayXAxis", &m.DisplayXAxisAttr},
+		{"displayHidden", &m.DisplayHiddenAttr},
+		{"rightToLeft", &m.RightToLeftAttr},
+	}
+}
+
+func (m *CT_SparklineGroup) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, f := range []struct {
+		name string
+		v    *float64
+	}{{"manualMax", m.ManualMaxAttr}, {"manualMin", m.ManualMinAttr}, {"lineWeight", m.LineWeightAttr}} {
+		if f.v != nil {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: f.name}, Value: strconv.FormatFloat(*f.v, 'f', -1, 64)})
+		}
+	}
+	if m.TypeAttr != ST_SparklineTypeUnset {
+		attr, err := m.TypeAttr.MarshalXMLAttr(xml.Name{Local: "type"})
+		if err != nil {
+			return err
+		}
+		start.Attr = append(start.Attr, attr)
+	}
+	if m.DisplayEmptyCellsAsAttr != ST_DispBlanksAsUnset {
+		attr, err := m.DisplayEmptyCellsAsAttr.MarshalXMLAttr(xml.Name{Local: "displayEmptyCellsAs"})
+		if err != nil {
+			return err
+		}
+		start.Attr = append(start.Attr, attr)
+	}
+	if m.MinAxisTypeAttr != ST_SparklineAxisMinMaxUnset {
+		attr, err := m.MinAxisTypeAttr.MarshalXMLAttr(xml.Name{Local: "minAxisType"})
+		if err != nil {
+			return err
+		}
+		start.Attr = append(start.Attr, attr)
+	}
+	if m.MaxAxisTypeAttr != ST_SparklineAxisMinMaxUnset {
+		attr, err := m.MaxAxisTypeAttr.MarshalXMLAttr(xml.Name{Local: "maxAxisType"})
+		if err != nil {
+			return err
+		}
+		start.Attr = append(start.Attr, attr)
+	}
+	for _, b := range m.bools() {
+		if *b.b != nil {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: b.name}, Value: boolValue(**b.b)})
+		}
+	}
+	e.EncodeToken(start)
+	for _, c := range m.colors() {
+		if *c.c != nil {
+			e.EncodeElement(*c.c, xml.StartElement{Name: xml.Name{Local: "x14:" + c.name}})
+		}
+	}
+	if m.F != nil {
+		e.EncodeElement(*m.F, xml.StartElement{Name: xml.Name{Local: "xm:f"}})
+	}
+	if m.Sparklines != nil {
+		e.EncodeElement(m.Sparklines, xml.StartElement{Name: xml.Name{Local: "x14:sparklines"}})
+	}
+	if m.ExtLst != nil {
+		e.EncodeElement(m.ExtLst, xml.StartElement{Name: xml.Name{Local: "x14:extLst"}})
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_SparklineGroup) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.Sparklines = nil
+attrs:
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "manualMax", "manualMin", "lineWeight":
+			v, err := strconv.ParseFloat(attr.Value, 64)
+			if err != nil {
+				return err
+			}
+			switch attr.Name.Local {
+			case "manualMax":
+				m.ManualMaxAttr = &v
+			case "manualMin":
+				m.ManualMinAttr = &v
+			default:
+				m.LineWeightAttr = &v
+			}
+			continue
+		case "type":
+			m.TypeAttr.UnmarshalXMLAttr(attr)
+			continue
+		case "displayEmptyCellsAs":
+			m.DisplayEmptyCellsAsAttr.UnmarshalXMLAttr(attr)
+			continue
+		case "minAxisType":
+			m.MinAxisTypeAttr.UnmarshalXMLAttr(attr)
+			continue
+		case "maxAxisType":
+			m.MaxAxisTypeAttr.UnmarshalXMLAttr(attr)
+			continue
+		}
+		for _, b := range m.bools() {
+			if attr.Name.Local == b.name {
+				v, err := strconv.ParseBool(attr.Value)
+				if err != nil {
+					return err
+				}
+				*b.b = &v
+				continue attrs
+			}
+		}
+	}
+lSparklineGroup:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case el.Name == xml.Name{Space: NSExcelMain, Local: "f"}:
+				var f string
+				if err := d.DecodeElement(&f, &el); err != nil {
+					return err
+				}
+				m.F = &f
+			case el.Name == xml.Name{Space: NS, Local: "sparklines"}:
+				m.Sparklines = NewCT_Sparklines()
+				if err := d.DecodeElement(m.Sparklines, &el); err != nil {
+					return err
+				}
+			case el.Name == xml.Name{Space: NS, Local: "extLst"}:
+				m.ExtLst = sml.NewCT_ExtensionList()
+				if err := d.DecodeElement(m.ExtLst, &el); err != nil {
+					return err
+				}
+			case el.Name.Space == NS && m.colorField(el.Name.Local) != nil:
+				c := sml.NewCT_Color()
+				if err := d.DecodeElement(c, &el); err != nil {
+					return err
+				}
+				*m.colorField(el.Name.Local) = c
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_SparklineGroup %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lSparklineGroup
+		}
+	}
+	if m.Sparklines == nil {
+		m.Sparklines = NewCT_Sparklines()
+	}
+	return nil
+}
+
+// colorField returns the color field for the element of the given name, or
+// nil if there is none.
+func (m *CT_SparklineGroup) colorField(name string) **sml.CT_Color {
+	for _, c := range m.colors() {
+		if c.name == name {
+			return c.c
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_SparklineGroup and its children, prefixing
+// error messages with path
+func (m *CT_SparklineGroup) ValidateWithPath(path string) error {
+	if err := m.TypeAttr.ValidateWithPath(path + "/TypeAttr"); err != nil {
+		return err
+	}
+	if err := m.DisplayEmptyCellsAsAttr.ValidateWithPath(path + "/DisplayEmptyCellsAsAttr"); err != nil {
+		return err
+	}
+	if err := m.MinAxisTypeAttr.ValidateWithPath(path + "/MinAxisTypeAttr"); err != nil {
+		return err
+	}
+	if err := m.MaxAxisTypeAttr.ValidateWithPath(path + "/MaxAxisTypeAttr"); err != nil {
+		return err
+	}
+	for _, c := range m.colors() {
+		if *c.c != nil {
+			if err := (*c.c).ValidateWithPath(path + "/" + c.name); err != nil {
+				return err
+			}
+		}
+	}
+	if m.Sparklines == nil {
+		return fmt.Errorf("%s/Sparklines is a mandatory field", path)
+	}
+	return m.Sparklines.ValidateWithPath(path + "/Sparklines")
+}
+
+type CT_Sparklines struct {
+	Sparkline []*CT_Sparkline
+}
+
+func NewCT_Sparklines() *CT_Sparklines { return &CT_Sparklines{} }
+
+func (m *CT_Sparklines) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeToken(start)
+	se := xml.StartElement{Name: xml.Name{Local: "x14:sparkline"}}
+	for _, s := range m.Sparkline {
+		e.EncodeElement(s, se)
+	}
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_Sparklines) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+lSparklines:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NS, Local: "sparkline"}:
+				s := NewCT_Sparkline()
+				if err := d.DecodeElement(s, &el); err != nil {
+					return err
+				}
+				m.Sparkline = append(m.Sparkline, s)
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_Sparklines %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lSparklines
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_Sparklines and its children, prefixing
+// error messages with path
+func (m *CT_Sparklines) ValidateWithPath(path string) error {
+	for i, s := range m.Sparkline {
+		if err := s.ValidateWithPath(fmt.Sprintf("%s/Sparkline[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type CT_Sparkline struct {
+	// F is the range of the data of the sparkline, e.g. Sheet1!A1:E1.
+	F *string
+	// Sqref is the cell that displays the sparkline.
+	Sqref string
+}
+
+func NewCT_Sparkline() *CT_Sparkline { return &CT_Sparkline{} }
+
+func (m *CT_Sparkline) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeToken(start)
+	if m.F != nil {
+		e.EncodeElement(*m.F, xml.StartElement{Name: xml.Name{Local: "xm:f"}})
+	}
+	e.EncodeElement(m.Sqref, xml.StartElement{Name: xml.Name{Local: "xm:sqref"}})
+	e.EncodeToken(xml.EndElement{Name: start.Name})
+	return nil
+}
+
+func (m *CT_Sparkline) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+lSparkline:
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name {
+			case xml.Name{Space: NSExcelMain, Local: "f"}:
+				var f string
+				if err := d.DecodeElement(&f, &el); err != nil {
+					return err
+				}
+				m.F = &f
+			case xml.Name{Space: NSExcelMain, Local: "sqref"}:
+				if err := d.DecodeElement(&m.Sqref, &el); err != nil {
+					return err
+				}
+			default:
+				logger.Log.Debug("skipping unsupported element on CT_Sparkline %v", el.Name)
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			break lSparkline
+		}
+	}
+	return nil
+}
+
+// ValidateWithPath validates the CT_Sparkline and its children, prefixing
+// error messages with path
+func (m *CT_Sparkline) ValidateWithPath(path string) error {
+	if m.Sqref == "" {
+		return fmt.Errorf("%s/Sqref is a mandatory field", path)
+	}
+	return nil
+}
+
+func boolValue(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func init() {
+	unioffice.RegisterConstructor(NS, "sparklineGroups", NewSparklineGroups)
+}
@@ -0,0 +1,533 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+// Package audit traces the precedents and dependents of formulas across the
+// sheets of a workbook and reports formulas that are likely to be wrong.
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+const (
+	maxRow    = 1048576
+	maxColumn = 16383
+	// maxNameDepth limits the nesting of defined names that refer to other
+	// defined names.
+	maxNameDepth = 8
+)
+
+// Cell identifies a cell of a workbook.
+type Cell struct {
+	// Sheet is the name of the sheet.
+	Sheet string
+	// Ref is the reference of the cell without dollar signs, e.g. B3.
+	Ref string
+}
+
+// String returns the cell as it appears in a formula, e.g. 'My Sheet'!B3.
+func (c Cell) String() string {
+	return formula.Precedent{Type: formula.ReferenceTypeCell, Sheet: c.Sheet, Ref: c.Ref}.String()
+}
+
+// area is a rectangle of cells on a sheet with zero based columns and one
+// based rows.
+type area struct {
+	sheet      string
+	col0, row0 uint32
+	col1, row1 uint32
+}
+
+func (a area) contains(sheet string, col, row uint32) bool {
+	return a.sheet == sheet && col >= a.col0 && col <= a.col1 && row >= a.row0 && row <= a.row1
+}
+
+func (a area) isCell() bool { return a.col0 == a.col1 && a.row0 == a.row1 }
+
+// formulaCell is a cell with a formula.
+type formulaCell struct {
+	cell     Cell
+	col, row uint32
+	x        *sml.CT_Cell
+	// text is the formula, shifted for the cells of shared formulas other
+	// than the first one.
+	text  string
+	expr  formula.Expression
+	areas []area
+	// cells are the areas that are single cell references, as opposed to
+	// ranges, names and cells of ranges.
+	cells []area
+}
+
+// Auditor holds an index of the formulas of a workbook and the cells they
+// refer to. The index is built by New, so an Auditor must be created again
+// after the workbook is modified.
+type Auditor struct {
+	wb       *spreadsheet.Workbook
+	sheets   []spreadsheet.Sheet
+	formulas []*formulaCell
+	byCell   map[Cell]*formulaCell
+	// values are the cells that are not empty, by sheet
+	values map[string][]Cell
+	filled map[Cell]bool
+}
+
+// New returns an auditor for the formulas of a workbook.
+func New(wb *spreadsheet.Workbook) *Auditor {
+	a := &Auditor{
+		wb:     wb,
+		sheets: wb.Sheets(),
+		byCell: map[Cell]*formulaCell{},
+		values: map[string][]Cell{},
+		filled: map[Cell]bool{},
+	}
+	for idx, s := range a.sheets {
+		a.indexSheet(idx, s)
+	}
+	return a
+}
+
+func (a *Auditor) indexSheet(idx int, s spreadsheet.Sheet) {
+	name := s.Name()
+	masters := map[uint32]*formulaCell{}
+	var children []*formulaCell
+	for _, r := range s.Rows() {
+		for _, c := range r.Cells() {
+			if c.IsEmpty() {
+				continue
+			}
+			ref, err := reference.ParseCellReference(c.Reference())
+			if err != nil {
+				continue
+			}
+			cell := Cell{Sheet: name, Ref: ref.Column + strconv.Itoa(int(ref.RowIdx))}
+			a.values[name] = append(a.values[name], cell)
+			a.filled[cell] = true
+			x := c.X()
+			if x.F == nil {
+				continue
+			}
+			fc := &formulaCell{cell: cell, col: ref.ColumnIdx, row: ref.RowIdx, x: x, text: x.F.Content}
+			a.formulas = append(a.formulas, fc)
+			a.byCell[cell] = fc
+			if x.F.TAttr == sml.ST_CellFormulaTypeShared && x.F.SiAttr != nil {
+				if x.F.Content != "" {
+					masters[*x.F.SiAttr] = fc
+				} else {
+					children = append(children, fc)
+				}
+			}
+		}
+	}
+	for _, fc := range children {
+		if m := masters[*fc.x.F.SiAttr]; m != nil && m.text != "" {
+			if expr := formula.ParseString(m.text); expr != nil {
+				fc.expr = shift(expr, int(fc.col)-int(m.col), int(fc.row)-int(m.row))
+				fc.text = formula.Format(fc.expr)
+			}
+		}
+	}
+	for _, fc := range a.formulas {
+		if fc.cell.Sheet != name {
+			continue
+		}
+		if fc.expr == nil && fc.text != "" {
+			fc.expr = formula.ParseString(fc.text)
+		}
+		if fc.expr == nil {
+			continue
+		}
+		for _, p := range formula.Precedents(fc.expr) {
+			areas := a.resolve(idx, fc, p, 0)
+			fc.areas = append(fc.areas, areas...)
+			if p.Type == formula.ReferenceTypeCell {
+				fc.cells = append(fc.cells, areas...)
+			}
+		}
+	}
+}
+
+// shift returns a shared formula for a cell that is dc columns and dr rows
+// away from the cell that holds the formula.
+func shift(expr formula.Expression, dc, dr int) formula.Expression {
+	return formula.Rewrite(expr, func(e formula.Expression) formula.Expression {
+		ref, ok := e.(formula.CellRef)
+		if !ok {
+			return e
+		}
+		cr, err := reference.ParseCellReference(ref.String())
+		if err != nil {
+			return e
+		}
+		col, row := int(cr.ColumnIdx), int(cr.RowIdx)
+		if !cr.AbsoluteColumn {
+			col += dc
+		}
+		if !cr.AbsoluteRow {
+			row += dr
+		}
+		if col < 0 || row < 1 {
+			return formula.NewError("#REF!")
+		}
+		s := ""
+		if cr.AbsoluteColumn {
+			s += "$"
+		}
+		s += reference.IndexToColumn(uint32(col))
+		if cr.AbsoluteRow {
+			s += "$"
+		}
+		return formula.NewCellRef(s + strconv.Itoa(row))
+	})
+}
+
+// sheetName returns the name of a sheet of the workbook, compared
+// case-insensitively, or false if there is no such sheet.
+func (a *Auditor) sheetName(name string) (string, bool) {
+	for _, s := range a.sheets {
+		if strings.EqualFold(s.Name(), name) {
+			return s.Name(), true
+		}
+	}
+	return "", false
+}
+
+// resolve returns the areas that a precedent of a formula on the sheet with
+// index idx refers to. References to other workbooks are ignored.
+func (a *Auditor) resolve(idx int, fc *formulaCell, p formula.Precedent, depth int) []area {
+	sheet := a.sheets[idx].Name()
+	if p.Sheet != "" {
+		if strings.HasPrefix(p.Sheet, "[") {
+			return nil
+		}
+		name, ok := a.sheetName(p.Sheet)
+		if !ok {
+			return nil
+		}
+		sheet = name
+	}
+	ref := strings.Replace(p.Ref, "$", "", -1)
+	switch p.Type {
+	case formula.ReferenceTypeCell:
+		cr, err := reference.ParseCellReference(ref)
+		if err != nil {
+			return nil
+		}
+		return []area{{sheet, cr.ColumnIdx, cr.RowIdx, cr.ColumnIdx, cr.RowIdx}}
+	case formula.ReferenceTypeRange:
+		from, to, err := reference.ParseRangeReference(ref)
+		if err != nil {
+			return nil
+		}
+		return []area{normalize(area{sheet, from.ColumnIdx, from.RowIdx, to.ColumnIdx, to.RowIdx})}
+	case formula.ReferenceTypeHorizontalRange:
+		parts := strings.Split(ref, ":")
+		if len(parts) != 2 {
+			return nil
+		}
+		r0, err0 := strconv.Atoi(parts[0])
+		r1, err1 := strconv.Atoi(parts[1])
+		if err0 != nil || err1 != nil {
+			return nil
+		}
+		return []area{normalize(area{sheet, 0, uint32(r0), maxColumn, uint32(r1)})}
+	case formula.ReferenceTypeVerticalRange:
+		parts := strings.Split(ref, ":")
+		if len(parts) != 2 {
+			return nil
+		}
+		c0, c1 := reference.ColumnToIndex(parts[0]), reference.ColumnToIndex(parts[1])
+		return []area{normalize(area{sheet, c0, 1, c1, maxRow})}
+	case formula.ReferenceTypeNamedRange:
+		if strings.Contains(p.Ref, "[") {
+			if s, ok := formula.ParseString(p.Ref).(formula.StructuredRef); ok {
+				first, last := s.Columns()
+				return a.resolveTable(fc, s.TableName(), first, last, s.Items(), s.IsThisRow())
+			}
+			return nil
+		}
+		return a.resolveName(idx, fc, p, depth)
+	}
+	return nil
+}
+
+func normalize(ar area) area {
+	if ar.col0 > ar.col1 {
+		ar.col0, ar.col1 = ar.col1, ar.col0
+	}
+	if ar.row0 > ar.row1 {
+		ar.row0, ar.row1 = ar.row1, ar.row0
+	}
+	return ar
+}
+
+// resolveName returns the areas that a defined name or a table name refers
+// to. Names that are local to the sheet of the formula take precedence over
+// global names.
+func (a *Auditor) resolveName(idx int, fc *formulaCell, p formula.Precedent, depth int) []area {
+	if depth >= maxNameDepth {
+		return nil
+	}
+	scope := idx
+	if p.Sheet != "" {
+		name, _ := a.sheetName(p.Sheet)
+		for i, s := range a.sheets {
+			if s.Name() == name {
+				scope = i
+			}
+		}
+	}
+	var content string
+	found := false
+	for _, dn := range a.wb.DefinedNames() {
+		if !strings.EqualFold(dn.Name(), p.Ref) {
+			continue
+		}
+		local := dn.X().LocalSheetIdAttr
+		switch {
+		case local != nil && int(*local) == scope:
+			content, found = dn.Content(), true
+		case local == nil && !found:
+			content, found = dn.Content(), true
+		}
+	}
+	if !found {
+		if t, err := a.wb.GetTable(p.Ref); err == nil {
+			// a table name without brackets refers to the data rows
+			return a.resolveTable(fc, t.Name(), "", "", formula.TableItemData, false)
+		}
+		return nil
+	}
+	expr := formula.ParseString(content)
+	if expr == nil {
+		return nil
+	}
+	var areas []area
+	for _, np := range formula.Precedents(expr) {
+		areas = append(areas, a.resolve(scope, fc, np, depth+1)...)
+	}
+	return areas
+}
+
+// resolveTable returns the area of a structured reference, see
+// formula.StructuredRef.
+func (a *Auditor) resolveTable(fc *formulaCell, name, first, last string, items formula.TableItem, thisRow bool) []area {
+	var table spreadsheet.Table
+	if name != "" {
+		t, err := a.wb.GetTable(name)
+		if err != nil {
+			return nil
+		}
+		table = t
+	} else {
+		for _, t := range a.wb.Tables() {
+			if ar, ok := tableArea(t); ok && ar.contains(fc.cell.Sheet, fc.col, fc.row) {
+				table = t
+				break
+			}
+		}
+		if table.X() == nil {
+			return nil
+		}
+	}
+	ar, ok := tableArea(table)
+	if !ok {
+		return nil
+	}
+
+	if first != "" {
+		c0, c1 := -1, -1
+		for i, c := range table.Columns() {
+			if strings.EqualFold(c.Name(), first) {
+				c0 = i
+			}
+			if strings.EqualFold(c.Name(), last) {
+				c1 = i
+			}
+		}
+		if c0 < 0 || c1 < 0 {
+			return nil
+		}
+		if c0 > c1 {
+			c0, c1 = c1, c0
+		}
+		ar.col0, ar.col1 = ar.col0+uint32(c0), ar.col0+uint32(c1)
+	}
+
+	header, totals := ar.row0, ar.row1
+	data0, data1 := ar.row0, ar.row1
+	if table.HasHeaderRow() {
+		data0++
+	}
+	if table.HasTotalsRow() {
+		data1--
+	}
+	switch {
+	case thisRow:
+		ar.row0, ar.row1 = fc.row, fc.row
+	case items == formula.TableItemHeaders:
+		if !table.HasHeaderRow() {
+			return nil
+		}
+		ar.row0, ar.row1 = header, header
+	case items == formula.TableItemTotals:
+		if !table.HasTotalsRow() {
+			return nil
+		}
+		ar.row0, ar.row1 = totals, totals
+	case items&formula.TableItemHeaders != 0 && items&formula.TableItemTotals != 0:
+	case items&formula.TableItemHeaders != 0:
+		ar.row1 = data1
+	case items&formula.TableItemTotals != 0:
+		ar.row0 = data0
+	default:
+		ar.row0, ar.row1 = data0, data1
+	}
+	if ar.row0 > ar.row1 {
+		return nil
+	}
+	return []area{ar}
+}
+
+// tableArea returns the area of a table including its header and totals rows.
+func tableArea(t spreadsheet.Table) (area, bool) {
+	s, err := t.Sheet()
+	if err != nil {
+		return area{}, false
+	}
+	from, to, err := reference.ParseRangeReference(t.Reference())
+	if err != nil {
+		return area{}, false
+	}
+	return normalize(area{s.Name(), from.ColumnIdx, from.RowIdx, to.ColumnIdx, to.RowIdx}), true
+}
+
+// lookup returns the formula of a cell, or nil if it hasn't a formula.
+func (a *Auditor) lookup(c Cell) (*formulaCell, uint32, uint32, error) {
+	name, ok := a.sheetName(c.Sheet)
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("sheet %s not found", c.Sheet)
+	}
+	cr, err := reference.ParseCellReference(strings.Replace(c.Ref, "$", "", -1))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	key := Cell{Sheet: name, Ref: cr.Column + strconv.Itoa(int(cr.RowIdx))}
+	return a.byCell[key], cr.ColumnIdx, cr.RowIdx, nil
+}
+
+// Precedents returns the cells that the formula of a cell refers to directly,
+// including the cells that defined names and structured references refer to.
+// Ranges are expanded to the cells of the range that aren't empty. It returns
+// nil if the cell hasn't a formula.
+func (a *Auditor) Precedents(c Cell) ([]Cell, error) {
+	fc, _, _, err := a.lookup(c)
+	if err != nil || fc == nil {
+		return nil, err
+	}
+	return a.precedents(fc), nil
+}
+
+func (a *Auditor) precedents(fc *formulaCell) []Cell {
+	var res []Cell
+	seen := map[Cell]bool{}
+	add := func(c Cell) {
+		if !seen[c] {
+			seen[c] = true
+			res = append(res, c)
+		}
+	}
+	for _, ar := range fc.areas {
+		if ar.isCell() {
+			add(Cell{Sheet: ar.sheet, Ref: reference.IndexToColumn(ar.col0) + strconv.Itoa(int(ar.row0))})
+			continue
+		}
+		for _, v := range a.values[ar.sheet] {
+			if cr, err := reference.ParseCellReference(v.Ref); err == nil && ar.contains(v.Sheet, cr.ColumnIdx, cr.RowIdx) {
+				add(v)
+			}
+		}
+	}
+	return res
+}
+
+// Dependents returns the cells with formulas that refer directly to a cell,
+// in the order of the sheets and rows.
+func (a *Auditor) Dependents(c Cell) ([]Cell, error) {
+	name, ok := a.sheetName(c.Sheet)
+	if !ok {
+		return nil, fmt.Errorf("sheet %s not found", c.Sheet)
+	}
+	cr, err := reference.ParseCellReference(strings.Replace(c.Ref, "$", "", -1))
+	if err != nil {
+		return nil, err
+	}
+	return a.dependents(name, cr.ColumnIdx, cr.RowIdx), nil
+}
+
+func (a *Auditor) dependents(sheet string, col, row uint32) []Cell {
+	var res []Cell
+	for _, fc := range a.formulas {
+		for _, ar := range fc.areas {
+			if ar.contains(sheet, col, row) {
+				res = append(res, fc.cell)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// TracePrecedents returns the cells that the formula of a cell depends on
+// directly or through other formulas, nearest first.
+func (a *Auditor) TracePrecedents(c Cell) ([]Cell, error) {
+	return a.trace(c, func(c Cell) []Cell {
+		fc, _, _, _ := a.lookup(c)
+		if fc == nil {
+			return nil
+		}
+		return a.precedents(fc)
+	})
+}
+
+// TraceDependents returns the cells with formulas that depend on a cell
+// directly or through other formulas, nearest first.
+func (a *Auditor) TraceDependents(c Cell) ([]Cell, error) {
+	return a.trace(c, func(c Cell) []Cell {
+		res, _ := a.Dependents(c)
+		return res
+	})
+}
+
+// trace returns the cells reachable from a cell in breadth first order.
+func (a *Auditor) trace(c Cell, next func(Cell) []Cell) ([]Cell, error) {
+	if _, _, _, err := a.lookup(c); err != nil {
+		return nil, err
+	}
+	var res []Cell
+	seen := map[Cell]bool{}
+	queue := []Cell{c}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range next(cur) {
+			if !seen[n] {
+				seen[n] = true
+				res = append(res, n)
+				queue = append(queue, n)
+			}
+		}
+	}
+	return res, nil
+}
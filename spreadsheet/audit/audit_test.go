@@ -0,0 +1,146 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package audit
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet"
+)
+
+// auditWorkbook returns a workbook with a table of sales on the sheet Data,
+// formulas referring to it and a sheet with formulas referring to those.
+func auditWorkbook(t *testing.T) *spreadsheet.Workbook {
+	wb := spreadsheet.New()
+	data := wb.AddSheet()
+	data.SetName("Data")
+	for i, row := range [][]interface{}{
+		{"Item", "Qty", "Price"},
+		{"apple", 3.0, 0.5},
+		{"pear", 4.0, 0.75},
+		{"plum", 10.0, 0.2},
+	} {
+		for j, v := range row {
+			c := data.Cell(fmt.Sprintf("%c%d", 'A'+j, i+1))
+			if s, ok := v.(string); ok {
+				c.SetString(s)
+			} else {
+				c.SetNumber(v.(float64))
+			}
+		}
+	}
+	if _, err := data.AddTable("A1:C4", "Sales"); err != nil {
+		t.Fatalf("error adding table: %s", err)
+	}
+	data.Cell("G1").SetNumber(1.07)
+	wb.AddDefinedName("Rate", "Data!$G$1")
+	for ref, f := range map[string]string{
+		"D2": "B2*C2",
+		"D3": "B3+C3",
+		"D4": "B4*C4",
+		"E2": "SUM(Sales[Qty])",
+		"E3": "Rate*D2",
+		"E4": "A10",
+		"E5": "D2*1.07",
+		"E6": "1/0",
+		"E7": "FOO(1)",
+	} {
+		data.Cell(ref).SetFormulaRaw(f)
+	}
+	// formulas that can't be parsed are only found in files
+	broken := data.Cell("E8").X()
+	broken.F = sml.NewCT_CellFormula()
+	broken.F.Content = "SUM("
+
+	other := wb.AddSheet()
+	other.SetName("My Sheet")
+	other.Cell("A1").SetFormulaRaw("Data!E3*2")
+	other.Cell("A2").SetFormulaShared("Data!D2+1", 1, 0)
+	return wb
+}
+
+func TestPrecedentsAndDependents(t *testing.T) {
+	a := New(auditWorkbook(t))
+	cells := func(refs ...string) []Cell {
+		var res []Cell
+		for _, r := range refs {
+			sheet, ref := "Data", r
+			if r[0] == '!' {
+				sheet, ref = "My Sheet", r[1:]
+			}
+			res = append(res, Cell{Sheet: sheet, Ref: ref})
+		}
+		return res
+	}
+	td := []struct {
+		Name string
+		Fn   func(Cell) ([]Cell, error)
+		Cell Cell
+		Exp  []Cell
+	}{
+		{"precedents", a.Precedents, Cell{"Data", "D2"}, cells("B2", "C2")},
+		{"precedents of a table column", a.Precedents, Cell{"Data", "E2"}, cells("B2", "B3", "B4")},
+		{"precedents of a name", a.Precedents, Cell{"data", "$E$3"}, cells("G1", "D2")},
+		{"precedents of a shared formula", a.Precedents, Cell{"My Sheet", "A3"}, cells("D3")},
+		{"precedents of a value", a.Precedents, Cell{"Data", "B2"}, nil},
+		{"dependents", a.Dependents, Cell{"Data", "D2"}, cells("E3", "E5", "!A2")},
+		{"dependents in a table", a.Dependents, Cell{"Data", "B3"}, cells("E2", "D3")},
+		{"dependents of an empty cell", a.Dependents, Cell{"Data", "A10"}, cells("E4")},
+		{"dependents of a formula", a.Dependents, Cell{"My Sheet", "A1"}, nil},
+		{"trace precedents", a.TracePrecedents, Cell{"My Sheet", "A1"}, cells("E3", "G1", "D2", "B2", "C2")},
+		{"trace dependents", a.TraceDependents, Cell{"Data", "G1"}, cells("E3", "!A1")},
+	}
+	for _, tc := range td {
+		got, err := tc.Fn(tc.Cell)
+		if err != nil {
+			t.Errorf("%s: %s", tc.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.Exp) {
+			t.Errorf("%s of %s: expected %v, got %v", tc.Name, tc.Cell, tc.Exp, got)
+		}
+	}
+
+	for _, c := range []Cell{{"Missing", "A1"}, {"Data", "not a cell"}} {
+		if _, err := a.Precedents(c); err == nil {
+			t.Errorf("expected an error for the precedents of %s", c)
+		}
+		if _, err := a.TraceDependents(c); err == nil {
+			t.Errorf("expected an error for the dependents of %s", c)
+		}
+	}
+	if got := (Cell{"My Sheet", "B3"}).String(); got != "'My Sheet'!B3" {
+		t.Errorf("expected 'My Sheet'!B3, got %s", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	a := New(auditWorkbook(t))
+	var got []string
+	for _, i := range a.Report() {
+		got = append(got, i.String())
+	}
+	exp := []string{
+		"Data!D3: inconsistent formula: differs from the adjacent formulas in the column, e.g. =B2*C2 in D2 (=B3+C3)",
+		"Data!E4: empty reference: refers to empty cell A10 (=A10)",
+		"Data!E5: constant: contains hard-coded 1.07 (=D2*1.07)",
+		"Data!E6: error: evaluates to #DIV/0! (=1/0)",
+		"Data!E7: unsupported function: calls unsupported function FOO (=FOO(1))",
+		"Data!E8: error: formula can't be parsed (=SUM()",
+		"'My Sheet'!A1: constant: contains hard-coded 2 (=Data!E3*2)",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected the issues\n%v\ngot\n%v", exp, got)
+	}
+	if got := IssueType(42).String(); got != "IssueType(42)" {
+		t.Errorf("expected IssueType(42), got %s", got)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// IssueType is the kind of problem found in a formula.
+type IssueType byte
+
+// IssueType values.
+const (
+	// IssueError is a formula that evaluates to an error such as #DIV/0! or
+	// can't be parsed.
+	IssueError IssueType = iota + 1
+	// IssueEmptyReference is a formula that refers to an empty cell.
+	IssueEmptyReference
+	// IssueConstant is a formula that contains a hard-coded number, e.g.
+	// =A1*1.07, which is easily missed when the number changes.
+	IssueConstant
+	// IssueInconsistent is a formula that differs from the formulas on both
+	// sides of it in a row or column, e.g. =SUM(C1:C9) between =SUM(A1:A9)
+	// and =SUM(B1:B9) is expected to be =SUM(B1:B9) shifted by one column.
+	IssueInconsistent
+	// IssueUnsupportedFunction is a formula that calls a function that
+	// isn't in formula.SupportedFunctions, so it can't be recalculated.
+	IssueUnsupportedFunction
+)
+
+var issueTypeNames = map[IssueType]string{
+	IssueError:               "error",
+	IssueEmptyReference:      "empty reference",
+	IssueConstant:            "constant",
+	IssueInconsistent:        "inconsistent formula",
+	IssueUnsupportedFunction: "unsupported function",
+}
+
+// String returns a description of the issue type.
+func (t IssueType) String() string {
+	if s, ok := issueTypeNames[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("IssueType(%d)", t)
+}
+
+// Issue is a problem found in the formula of a cell.
+type Issue struct {
+	Type    IssueType
+	Cell    Cell
+	Formula string
+	// Message describes the issue, e.g. "refers to empty cell B4".
+	Message string
+}
+
+// String returns the issue as a line of a report.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s (=%s)", i.Cell, i.Type, i.Message, i.Formula)
+}
+
+// Report returns the issues found in the formulas of the workbook in the order
+// of the sheets and rows.
+func (a *Auditor) Report() []Issue {
+	supported := map[string]bool{}
+	for _, fn := range formula.SupportedFunctions() {
+		supported[fn] = true
+	}
+	var issues []Issue
+	for _, fc := range a.formulas {
+		add := func(t IssueType, msg string) {
+			issues = append(issues, Issue{Type: t, Cell: fc.cell, Formula: fc.text, Message: msg})
+		}
+		if fc.expr == nil {
+			if fc.text != "" {
+				add(IssueError, "formula can't be parsed")
+			}
+			continue
+		}
+
+		var unsupported []string
+		for _, fn := range formula.Functions(fc.expr) {
+			if !supported[fn] {
+				unsupported = append(unsupported, fn)
+			}
+		}
+		if msg := a.errorValue(fc); msg != "" && len(unsupported) == 0 {
+			add(IssueError, "evaluates to "+msg)
+		}
+		for _, c := range fc.cells {
+			cell := Cell{Sheet: c.sheet, Ref: reference.IndexToColumn(c.col0) + strconv.Itoa(int(c.row0))}
+			if !a.filled[cell] {
+				add(IssueEmptyReference, "refers to empty cell "+a.relative(fc, cell))
+			}
+		}
+		if consts := constants(fc.expr); len(consts) > 0 {
+			add(IssueConstant, "contains hard-coded "+strings.Join(consts, ", "))
+		}
+		if msg := a.inconsistent(fc); msg != "" {
+			add(IssueInconsistent, msg)
+		}
+		for _, fn := range unsupported {
+			add(IssueUnsupportedFunction, "calls unsupported function "+fn)
+		}
+	}
+	return issues
+}
+
+// relative returns a cell as it is written in a formula on the sheet of fc.
+func (a *Auditor) relative(fc *formulaCell, c Cell) string {
+	if c.Sheet == fc.cell.Sheet {
+		return c.Ref
+	}
+	return c.String()
+}
+
+// errorValue returns the error value of a formula, or an empty string if it
+// doesn't evaluate to an error. The cached result is used if there is one.
+func (a *Auditor) errorValue(fc *formulaCell) string {
+	if fc.x.V != nil {
+		if fc.x.TAttr == sml.ST_CellTypeE {
+			return *fc.x.V
+		}
+		return ""
+	}
+	s, err := a.wb.GetSheet(fc.cell.Sheet)
+	if err != nil {
+		return ""
+	}
+	res := formula.NewEvaluator().Eval(s.FormulaContext(), fc.text)
+	if res.Type == formula.ResultTypeError {
+		return res.Value()
+	}
+	return ""
+}
+
+// constants returns the numbers that are operands of operators in an
+// expression, other than 0 and 1 which are rarely assumptions. Numbers that are
+// arguments of functions such as ROUND(A1,2) are not reported.
+func constants(expr formula.Expression) []string {
+	var res []string
+	formula.Inspect(expr, func(e formula.Expression) bool {
+		b, ok := e.(formula.BinaryExpr)
+		if !ok {
+			return e != nil
+		}
+		lhs, rhs := b.Operands()
+		for _, op := range []formula.Expression{lhs, rhs} {
+			if n, ok := op.(formula.Negate); ok {
+				op = n.Operand()
+			}
+			if n, ok := op.(formula.Number); ok && n.Value() != 0 && n.Value() != 1 {
+				res = append(res, formula.Format(n))
+			}
+		}
+		return true
+	})
+	return res
+}
+
+// inconsistent returns a message if the formula of a cell differs from the
+// formulas of the cells on both sides of it that agree with each other. The
+// formulas are compared with relative references, so =A1+1 in B1 and =A2+1 in
+// B2 are the same formula.
+func (a *Auditor) inconsistent(fc *formulaCell) string {
+	key := relativeKey(fc)
+	neighbor := func(dc, dr int) *formulaCell {
+		col, row := int(fc.col)+dc, int(fc.row)+dr
+		if col < 0 || row < 1 {
+			return nil
+		}
+		return a.byCell[Cell{Sheet: fc.cell.Sheet, Ref: reference.IndexToColumn(uint32(col)) + strconv.Itoa(row)}]
+	}
+	for _, dir := range []struct {
+		dc, dr int
+		name   string
+	}{{0, 1, "column"}, {1, 0, "row"}} {
+		before, after := neighbor(-dir.dc, -dir.dr), neighbor(dir.dc, dir.dr)
+		if before == nil || after == nil || before.expr == nil || after.expr == nil {
+			continue
+		}
+		if k := relativeKey(before); k == relativeKey(after) && k != key {
+			return fmt.Sprintf("differs from the adjacent formulas in the %s, e.g. =%s in %s", dir.name, before.text, before.cell.Ref)
+		}
+	}
+	return ""
+}
+
+// relativeKey returns the formula of a cell with its relative references
+// replaced by offsets to the cell in R1C1 notation.
+func relativeKey(fc *formulaCell) string {
+	return formula.Format(formula.Rewrite(fc.expr, func(e formula.Expression) formula.Expression {
+		ref, ok := e.(formula.CellRef)
+		if !ok {
+			return e
+		}
+		cr, err := reference.ParseCellReference(ref.String())
+		if err != nil {
+			return e
+		}
+		r := "R[" + strconv.Itoa(int(cr.RowIdx)-int(fc.row)) + "]"
+		if cr.AbsoluteRow {
+			r = "R" + strconv.Itoa(int(cr.RowIdx))
+		}
+		c := "C[" + strconv.Itoa(int(cr.ColumnIdx)-int(fc.col)) + "]"
+		if cr.AbsoluteColumn {
+			c = "C" + strconv.Itoa(int(cr.ColumnIdx)+1)
+		}
+		return formula.NewCellRef(r + c)
+	}))
+}
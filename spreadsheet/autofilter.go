@@ -0,0 +1,789 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/internal/wildcard"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// AutoFilter is the autofilter of a sheet or a table. Its filter columns hold
+// the criteria that Excel shows in the filter drop downs. Apply evaluates the
+// criteria and hides the rows that don't match, so that the file opens
+// filtered as in Excel.
+type AutoFilter struct {
+	x     *sml.CT_AutoFilter
+	sheet *Sheet
+	now   time.Time
+}
+
+// CustomFilter is a comparison of a custom filter. Value is compared as a
+// number if it is numeric, otherwise as text where equal and not equal
+// comparisons support the wildcards * and ?, e.g. "abc*" for values that
+// begin with abc.
+type CustomFilter struct {
+	Operator sml.ST_FilterOperator
+	Value    string
+}
+
+// FilterColumn holds the filter criteria of a column of an autofilter.
+type FilterColumn struct {
+	x *sml.CT_FilterColumn
+	f AutoFilter
+}
+
+// AutoFilter returns the autofilter of the sheet that is created with
+// SetAutoFilter.
+func (s *Sheet) AutoFilter() (AutoFilter, error) {
+	if s.X().AutoFilter == nil {
+		return AutoFilter{}, ErrorNotFound
+	}
+	return AutoFilter{x: s.X().AutoFilter, sheet: s}, nil
+}
+
+// AutoFilter returns the autofilter of the table, which is not set if the
+// table doesn't show filter buttons. Its range excludes the totals row.
+func (t Table) AutoFilter() (AutoFilter, error) {
+	if t._bcfd.AutoFilter == nil {
+		return AutoFilter{}, ErrorNotFound
+	}
+	s, err := t.Sheet()
+	if err != nil {
+		return AutoFilter{}, err
+	}
+	return AutoFilter{x: t._bcfd.AutoFilter, sheet: &s}, nil
+}
+
+// X returns the inner wrapped XML type.
+func (f AutoFilter) X() *sml.CT_AutoFilter { return f.x }
+
+// Reference returns the range of the autofilter including the header row.
+func (f AutoFilter) Reference() string {
+	if f.x.RefAttr == nil {
+		return ""
+	}
+	return *f.x.RefAttr
+}
+
+// Column returns the filter column with the zero based index col within the
+// range of the autofilter, creating it if it doesn't exist yet.
+func (f AutoFilter) Column(col int) FilterColumn {
+	for _, c := range f.x.FilterColumn {
+		if int(c.ColIdAttr) == col {
+			return FilterColumn{c, f}
+		}
+	}
+	c := sml.NewCT_FilterColumn()
+	c.ColIdAttr = uint32(col)
+	f.x.FilterColumn = append(f.x.FilterColumn, c)
+	sort.Slice(f.x.FilterColumn, func(i, j int) bool {
+		return f.x.FilterColumn[i].ColIdAttr < f.x.FilterColumn[j].ColIdAttr
+	})
+	return FilterColumn{c, f}
+}
+
+// Columns returns the filter columns that have been defined.
+func (f AutoFilter) Columns() []FilterColumn {
+	ret := []FilterColumn{}
+	for _, c := range f.x.FilterColumn {
+		ret = append(ret, FilterColumn{c, f})
+	}
+	return ret
+}
+
+// RemoveColumn removes the criteria of the column with the zero based index
+// col. Call Apply to show the rows again.
+func (f AutoFilter) RemoveColumn(col int) {
+	cols := f.x.FilterColumn[:0]
+	for _, c := range f.x.FilterColumn {
+		if int(c.ColIdAttr) != col {
+			cols = append(cols, c)
+		}
+	}
+	f.x.FilterColumn = cols
+}
+
+// Clear removes all criteria and shows all rows of the range.
+func (f AutoFilter) Clear() error {
+	f.x.FilterColumn = nil
+	return f.Apply()
+}
+
+// Apply evaluates the criteria of all filter columns against the cell values
+// and hides the rows of the range that don't match, showing the others. Cells
+// are compared by their formatted values, as displayed by Excel, dates by
+// their value. Icon filters are not evaluated and match every row. Dynamic
+// date filters such as this week are relative to the current date.
+func (f AutoFilter) Apply() error { return f.ApplyAt(time.Now()) }
+
+// ApplyAt is like Apply but dynamic date filters such as this week or last
+// month are relative to the date now.
+func (f AutoFilter) ApplyAt(now time.Time) error {
+	f.now = now
+	r, err := parseTableRect(f.Reference())
+	if err != nil {
+		return err
+	}
+	first, last := r.row0+1, r.row1
+	rows := map[int]Row{}
+	lastRow := -1
+	for _, row := range f.sheet.Rows() {
+		rows[int(row.RowNumber())-1] = row
+		if int(row.RowNumber())-1 > lastRow {
+			lastRow = int(row.RowNumber()) - 1
+		}
+	}
+	// rows after the last row of the sheet are empty and stay visible, which
+	// avoids creating a million rows for a range such as A:D
+	if last > lastRow {
+		last = lastRow
+	}
+
+	matchers := []func(Cell) bool{}
+	cols := []int{}
+	for _, c := range f.x.FilterColumn {
+		col := r.col0 + int(c.ColIdAttr)
+		if col > r.col1 {
+			return fmt.Errorf("filter column %d is outside of %s", c.ColIdAttr, f.Reference())
+		}
+		cells := []Cell{}
+		for row := first; row <= last; row++ {
+			if cell, ok := cellInRow(rows, row, col); ok {
+				cells = append(cells, cell)
+			}
+		}
+		m, err := FilterColumn{c, f}.matcher(cells)
+		if err != nil {
+			return err
+		}
+		if m != nil {
+			matchers = append(matchers, m)
+			cols = append(cols, col)
+		}
+	}
+
+	for row := first; row <= last; row++ {
+		visible := true
+		for i, m := range matchers {
+			cell, ok := cellInRow(rows, row, cols[i])
+			if !ok {
+				cell = Cell{f.sheet._gccb, f.sheet, nil, sml.NewCT_Cell()}
+			}
+			if !m(cell) {
+				visible = false
+				break
+			}
+		}
+		if existing, ok := rows[row]; ok {
+			existing.SetHidden(!visible)
+		} else if !visible {
+			f.sheet.Row(uint32(row + 1)).SetHidden(true)
+		}
+	}
+
+	if f.x == f.sheet.X().AutoFilter {
+		ws := f.sheet.X()
+		if len(matchers) > 0 {
+			if ws.SheetPr == nil {
+				ws.SheetPr = sml.NewCT_SheetPr()
+			}
+			ws.SheetPr.FilterModeAttr = unioffice.Bool(true)
+		} else if ws.SheetPr != nil {
+			ws.SheetPr.FilterModeAttr = nil
+		}
+	}
+	return nil
+}
+
+// cellInRow returns the existing cell at a zero based row and column.
+func cellInRow(rows map[int]Row, row, col int) (Cell, bool) {
+	r, ok := rows[row]
+	if !ok {
+		return Cell{}, false
+	}
+	for _, c := range r.Cells() {
+		if c.X().RAttr == nil {
+			continue
+		}
+		ref, err := reference.ParseCellReference(*c.X().RAttr)
+		if err == nil && int(ref.ColumnIdx) == col {
+			return c, true
+		}
+	}
+	return Cell{}, false
+}
+
+// X returns the inner wrapped XML type.
+func (c FilterColumn) X() *sml.CT_FilterColumn { return c.x }
+
+// Index returns the zero based index of the column within the range of the
+// autofilter.
+func (c FilterColumn) Index() int { return int(c.x.ColIdAttr) }
+
+// SetShowButton controls whether the filter button of the column is shown.
+func (c FilterColumn) SetShowButton(b bool) {
+	if b {
+		c.x.HiddenButtonAttr = nil
+	} else {
+		c.x.HiddenButtonAttr = unioffice.Bool(true)
+	}
+}
+
+func (c FilterColumn) clearCriteria() {
+	c.x.Filters = nil
+	c.x.Top10 = nil
+	c.x.CustomFilters = nil
+	c.x.DynamicFilter = nil
+	c.x.ColorFilter = nil
+	c.x.IconFilter = nil
+}
+
+// SetValues filters the column to the cells whose displayed value is one of
+// values, compared case-insensitively, as when values are checked in the
+// filter drop down of Excel.
+func (c FilterColumn) SetValues(values ...string) {
+	blank := c.x.Filters != nil && c.x.Filters.BlankAttr != nil && *c.x.Filters.BlankAttr
+	c.clearCriteria()
+	c.x.Filters = sml.NewCT_Filters()
+	for _, v := range values {
+		c.x.Filters.Filter = append(c.x.Filters.Filter, &sml.CT_Filter{ValAttr: unioffice.String(v)})
+	}
+	c.SetBlank(blank)
+}
+
+// SetBlank controls whether empty cells match a filter by values.
+func (c FilterColumn) SetBlank(b bool) {
+	if c.x.Filters == nil {
+		c.clearCriteria()
+		c.x.Filters = sml.NewCT_Filters()
+	}
+	if b {
+		c.x.Filters.BlankAttr = unioffice.Bool(true)
+	} else {
+		c.x.Filters.BlankAttr = nil
+	}
+}
+
+// AddDateGroup adds the dates that share the given part of d, e.g. the same
+// year and month for sml.ST_DateTimeGroupingMonth, to a filter by values.
+func (c FilterColumn) AddDateGroup(grouping sml.ST_DateTimeGrouping, d time.Time) {
+	if c.x.Filters == nil {
+		c.clearCriteria()
+		c.x.Filters = sml.NewCT_Filters()
+	}
+	item := sml.NewCT_DateGroupItem()
+	item.DateTimeGroupingAttr = grouping
+	item.YearAttr = uint16(d.Year())
+	parts := []struct {
+		g sml.ST_DateTimeGrouping
+		p **uint16
+		v int
+	}{
+		{sml.ST_DateTimeGroupingMonth, &item.MonthAttr, int(d.Month())},
+		{sml.ST_DateTimeGroupingDay, &item.DayAttr, d.Day()},
+		{sml.ST_DateTimeGroupingHour, &item.HourAttr, d.Hour()},
+		{sml.ST_DateTimeGroupingMinute, &item.MinuteAttr, d.Minute()},
+		{sml.ST_DateTimeGroupingSecond, &item.SecondAttr, d.Second()},
+	}
+	for _, p := range parts {
+		if p.g <= grouping {
+			*p.p = unioffice.Uint16(uint16(p.v))
+		}
+	}
+	c.x.Filters.DateGroupItem = append(c.x.Filters.DateGroupItem, item)
+}
+
+// SetCustomFilters filters the column with one or two comparisons that must
+// all match if and is set, or any of them otherwise.
+func (c FilterColumn) SetCustomFilters(and bool, filters ...CustomFilter) error {
+	if len(filters) == 0 || len(filters) > 2 {
+		return errors.New("a custom filter has one or two comparisons")
+	}
+	c.clearCriteria()
+	c.x.CustomFilters = sml.NewCT_CustomFilters()
+	if and {
+		c.x.CustomFilters.AndAttr = unioffice.Bool(true)
+	}
+	for _, f := range filters {
+		x := sml.NewCT_CustomFilter()
+		if f.Operator != sml.ST_FilterOperatorEqual {
+			x.OperatorAttr = f.Operator
+		}
+		x.ValAttr = unioffice.String(f.Value)
+		c.x.CustomFilters.CustomFilter = append(c.x.CustomFilters.CustomFilter, x)
+	}
+	return nil
+}
+
+// SetTop10 filters the column to its n highest (top) or lowest numbers, or to
+// the n percent highest or lowest numbers if percent is set.
+func (c FilterColumn) SetTop10(top, percent bool, n float64) {
+	c.clearCriteria()
+	c.x.Top10 = sml.NewCT_Top10()
+	if !top {
+		c.x.Top10.TopAttr = unioffice.Bool(false)
+	}
+	if percent {
+		c.x.Top10.PercentAttr = unioffice.Bool(true)
+	}
+	c.x.Top10.ValAttr = n
+}
+
+// SetDynamicFilter filters the column by a filter that depends on the values
+// or the current date, e.g. sml.ST_DynamicFilterTypeAboveAverage or
+// sml.ST_DynamicFilterTypeLastMonth.
+func (c FilterColumn) SetDynamicFilter(t sml.ST_DynamicFilterType) {
+	c.clearCriteria()
+	c.x.DynamicFilter = sml.NewCT_DynamicFilter()
+	c.x.DynamicFilter.TypeAttr = t
+}
+
+// SetCellColorFilter filters the column to the cells filled with the given
+// color. The color is stored as a differential format, which is shared with
+// other filters of the same color.
+func (c FilterColumn) SetCellColorFilter(clr color.Color) {
+	dxf := sml.NewCT_Dxf()
+	fill := sml.NewCT_PatternFill()
+	fill.PatternTypeAttr = sml.ST_PatternTypeSolid
+	fill.FgColor = sml.NewCT_Color()
+	fill.FgColor.RgbAttr = clr.AsRGBAString()
+	fill.BgColor = sml.NewCT_Color()
+	fill.BgColor.RgbAttr = clr.AsRGBAString()
+	dxf.Fill = sml.NewCT_Fill()
+	dxf.Fill.PatternFill = fill
+	c.clearCriteria()
+	c.x.ColorFilter = sml.NewCT_ColorFilter()
+	c.x.ColorFilter.DxfIdAttr = unioffice.Uint32(c.f.sheet._gccb.StyleSheet.getOrCreateDxf(dxf))
+}
+
+// SetFontColorFilter filters the column to the cells whose text has the
+// given color.
+func (c FilterColumn) SetFontColorFilter(clr color.Color) {
+	dxf := sml.NewCT_Dxf()
+	dxf.Font = sml.NewCT_Font()
+	fc := sml.NewCT_Color()
+	fc.RgbAttr = clr.AsRGBAString()
+	dxf.Font.Color = []*sml.CT_Color{fc}
+	c.clearCriteria()
+	c.x.ColorFilter = sml.NewCT_ColorFilter()
+	c.x.ColorFilter.DxfIdAttr = unioffice.Uint32(c.f.sheet._gccb.StyleSheet.getOrCreateDxf(dxf))
+	c.x.ColorFilter.CellColorAttr = unioffice.Bool(false)
+}
+
+// matcher returns a function that reports whether a cell matches the criteria
+// of the column, given the cells of the column, or nil if the column doesn't
+// filter.
+func (c FilterColumn) matcher(cells []Cell) (func(Cell) bool, error) {
+	switch {
+	case c.x.Filters != nil:
+		return c.valuesMatcher(), nil
+	case c.x.CustomFilters != nil:
+		return c.customMatcher(), nil
+	case c.x.Top10 != nil:
+		return c.top10Matcher(cells), nil
+	case c.x.DynamicFilter != nil:
+		return c.dynamicMatcher(cells), nil
+	case c.x.ColorFilter != nil:
+		return c.colorMatcher()
+	}
+	return nil, nil
+}
+
+func (c FilterColumn) valuesMatcher() func(Cell) bool {
+	flt := c.x.Filters
+	values := map[string]bool{}
+	for _, v := range flt.Filter {
+		if v.ValAttr != nil {
+			values[strings.ToLower(*v.ValAttr)] = true
+		}
+	}
+	blank := flt.BlankAttr != nil && *flt.BlankAttr
+	return func(cell Cell) bool {
+		if cell.IsEmpty() {
+			return blank
+		}
+		if t, ok := cellDate(cell); ok && len(flt.DateGroupItem) > 0 {
+			for _, item := range flt.DateGroupItem {
+				if dateGroupMatches(item, t) {
+					return true
+				}
+			}
+		}
+		return values[strings.ToLower(cell.GetFormattedValue())]
+	}
+}
+
+func dateGroupMatches(item *sml.CT_DateGroupItem, t time.Time) bool {
+	if t.Year() != int(item.YearAttr) {
+		return false
+	}
+	parts := []struct {
+		p *uint16
+		v int
+	}{
+		{item.MonthAttr, int(t.Month())},
+		{item.DayAttr, t.Day()},
+		{item.HourAttr, t.Hour()},
+		{item.MinuteAttr, t.Minute()},
+		{item.SecondAttr, t.Second()},
+	}
+	for i, p := range parts {
+		if sml.ST_DateTimeGrouping(i+2) > item.DateTimeGroupingAttr {
+			break
+		}
+		if p.p == nil || int(*p.p) != p.v {
+			return false
+		}
+	}
+	return true
+}
+
+func (c FilterColumn) customMatcher() func(Cell) bool {
+	flt := c.x.CustomFilters
+	and := flt.AndAttr != nil && *flt.AndAttr
+	return func(cell Cell) bool {
+		for _, cf := range flt.CustomFilter {
+			val := ""
+			if cf.ValAttr != nil {
+				val = *cf.ValAttr
+			}
+			m := customFilterMatches(cf.OperatorAttr, val, cell)
+			if and && !m {
+				return false
+			}
+			if !and && m {
+				return true
+			}
+		}
+		return and
+	}
+}
+
+func customFilterMatches(op sml.ST_FilterOperator, val string, cell Cell) bool {
+	if op == sml.ST_FilterOperatorUnset {
+		op = sml.ST_FilterOperatorEqual
+	}
+	if v, err := strconv.ParseFloat(val, 64); err == nil {
+		n, ok := cellNumber(cell)
+		if !ok {
+			return op == sml.ST_FilterOperatorNotEqual
+		}
+		switch op {
+		case sml.ST_FilterOperatorEqual:
+			return n == v
+		case sml.ST_FilterOperatorNotEqual:
+			return n != v
+		case sml.ST_FilterOperatorLessThan:
+			return n < v
+		case sml.ST_FilterOperatorLessThanOrEqual:
+			return n <= v
+		case sml.ST_FilterOperatorGreaterThan:
+			return n > v
+		case sml.ST_FilterOperatorGreaterThanOrEqual:
+			return n >= v
+		}
+		return false
+	}
+
+	text := strings.ToLower(cell.GetFormattedValue())
+	val = strings.ToLower(val)
+	switch op {
+	case sml.ST_FilterOperatorEqual:
+		return wildcard.Match(val, text)
+	case sml.ST_FilterOperatorNotEqual:
+		return !wildcard.Match(val, text)
+	}
+	if _, ok := cellNumber(cell); ok || cell.IsEmpty() {
+		return false
+	}
+	switch op {
+	case sml.ST_FilterOperatorLessThan:
+		return text < val
+	case sml.ST_FilterOperatorLessThanOrEqual:
+		return text <= val
+	case sml.ST_FilterOperatorGreaterThan:
+		return text > val
+	case sml.ST_FilterOperatorGreaterThanOrEqual:
+		return text >= val
+	}
+	return false
+}
+
+func (c FilterColumn) top10Matcher(cells []Cell) func(Cell) bool {
+	flt := c.x.Top10
+	top := flt.TopAttr == nil || *flt.TopAttr
+	numbers := columnNumbers(cells)
+	if len(numbers) == 0 {
+		return func(Cell) bool { return false }
+	}
+	sort.Float64s(numbers)
+	if top {
+		sort.Sort(sort.Reverse(sort.Float64Slice(numbers)))
+	}
+	n := int(flt.ValAttr)
+	if flt.PercentAttr != nil && *flt.PercentAttr {
+		n = int(math.Floor(float64(len(numbers)) * flt.ValAttr / 100))
+	}
+	if n < 1 {
+		n = 1
+	}
+	if n > len(numbers) {
+		n = len(numbers)
+	}
+	threshold := numbers[n-1]
+	flt.FilterValAttr = unioffice.Float64(threshold)
+	return func(cell Cell) bool {
+		v, ok := cellNumber(cell)
+		if !ok {
+			return false
+		}
+		if top {
+			return v >= threshold
+		}
+		return v <= threshold
+	}
+}
+
+func (c FilterColumn) dynamicMatcher(cells []Cell) func(Cell) bool {
+	flt := c.x.DynamicFilter
+	switch flt.TypeAttr {
+	case sml.ST_DynamicFilterTypeAboveAverage, sml.ST_DynamicFilterTypeBelowAverage:
+		numbers := columnNumbers(cells)
+		avg := 0.0
+		for _, v := range numbers {
+			avg += v
+		}
+		if len(numbers) > 0 {
+			avg /= float64(len(numbers))
+		}
+		flt.ValAttr = unioffice.Float64(avg)
+		above := flt.TypeAttr == sml.ST_DynamicFilterTypeAboveAverage
+		return func(cell Cell) bool {
+			v, ok := cellNumber(cell)
+			return ok && (above && v > avg || !above && v < avg)
+		}
+	case sml.ST_DynamicFilterTypeNull, sml.ST_DynamicFilterTypeUnset:
+		return nil
+	}
+
+	if flt.TypeAttr >= sml.ST_DynamicFilterTypeQ1 && flt.TypeAttr <= sml.ST_DynamicFilterTypeQ4 {
+		q := int(flt.TypeAttr-sml.ST_DynamicFilterTypeQ1) + 1
+		return func(cell Cell) bool {
+			t, ok := cellDate(cell)
+			return ok && (int(t.Month())-1)/3+1 == q
+		}
+	}
+	if flt.TypeAttr >= sml.ST_DynamicFilterTypeM1 && flt.TypeAttr <= sml.ST_DynamicFilterTypeM12 {
+		m := time.Month(flt.TypeAttr-sml.ST_DynamicFilterTypeM1) + 1
+		return func(cell Cell) bool {
+			t, ok := cellDate(cell)
+			return ok && t.Month() == m
+		}
+	}
+
+	from, to := dynamicDateRange(flt.TypeAttr, c.f.now)
+	epoch := c.f.sheet._gccb.Epoch()
+	flt.ValAttr = unioffice.Float64(from.Sub(epoch).Hours() / 24)
+	flt.MaxValAttr = unioffice.Float64(to.Sub(epoch).Hours() / 24)
+	return func(cell Cell) bool {
+		t, ok := cellDate(cell)
+		return ok && !t.Before(from) && t.Before(to)
+	}
+}
+
+// dynamicDateRange returns the start and the exclusive end of the period of a
+// dynamic date filter relative to now. Weeks start on Sunday as in Excel.
+func dynamicDateRange(t sml.ST_DynamicFilterType, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	week := today.AddDate(0, 0, -int(today.Weekday()))
+	month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	quarter := time.Date(today.Year(), (today.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	year := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	switch t {
+	case sml.ST_DynamicFilterTypeTomorrow:
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case sml.ST_DynamicFilterTypeToday:
+		return today, today.AddDate(0, 0, 1)
+	case sml.ST_DynamicFilterTypeYesterday:
+		return today.AddDate(0, 0, -1), today
+	case sml.ST_DynamicFilterTypeNextWeek:
+		return week.AddDate(0, 0, 7), week.AddDate(0, 0, 14)
+	case sml.ST_DynamicFilterTypeThisWeek:
+		return week, week.AddDate(0, 0, 7)
+	case sml.ST_DynamicFilterTypeLastWeek:
+		return week.AddDate(0, 0, -7), week
+	case sml.ST_DynamicFilterTypeNextMonth:
+		return month.AddDate(0, 1, 0), month.AddDate(0, 2, 0)
+	case sml.ST_DynamicFilterTypeThisMonth:
+		return month, month.AddDate(0, 1, 0)
+	case sml.ST_DynamicFilterTypeLastMonth:
+		return month.AddDate(0, -1, 0), month
+	case sml.ST_DynamicFilterTypeNextQuarter:
+		return quarter.AddDate(0, 3, 0), quarter.AddDate(0, 6, 0)
+	case sml.ST_DynamicFilterTypeThisQuarter:
+		return quarter, quarter.AddDate(0, 3, 0)
+	case sml.ST_DynamicFilterTypeLastQuarter:
+		return quarter.AddDate(0, -3, 0), quarter
+	case sml.ST_DynamicFilterTypeNextYear:
+		return year.AddDate(1, 0, 0), year.AddDate(2, 0, 0)
+	case sml.ST_DynamicFilterTypeThisYear:
+		return year, year.AddDate(1, 0, 0)
+	case sml.ST_DynamicFilterTypeLastYear:
+		return year.AddDate(-1, 0, 0), year
+	case sml.ST_DynamicFilterTypeYearToDate:
+		return year, today.AddDate(0, 0, 1)
+	}
+	return today, today
+}
+
+func (c FilterColumn) colorMatcher() (func(Cell) bool, error) {
+	flt := c.x.ColorFilter
+	dxfs := c.f.sheet._gccb.StyleSheet.X().Dxfs
+	if flt.DxfIdAttr == nil || dxfs == nil || int(*flt.DxfIdAttr) >= len(dxfs.Dxf) {
+		return nil, errors.New("color filter refers to a missing differential style")
+	}
+	dxf := dxfs.Dxf[*flt.DxfIdAttr]
+	if flt.CellColorAttr != nil && !*flt.CellColorAttr {
+		want := ""
+		if dxf.Font != nil && len(dxf.Font.Color) > 0 {
+			want = rgbOf(dxf.Font.Color[0])
+		}
+		return func(cell Cell) bool {
+			got := ""
+			if font := cellFont(cell); font != nil && len(font.Color) > 0 {
+				got = rgbOf(font.Color[0])
+			}
+			return got == want
+		}, nil
+	}
+	want := ""
+	if dxf.Fill != nil && dxf.Fill.PatternFill != nil {
+		if pf := dxf.Fill.PatternFill; pf.BgColor != nil {
+			want = rgbOf(pf.BgColor)
+		} else if pf.FgColor != nil {
+			want = rgbOf(pf.FgColor)
+		}
+	}
+	return func(cell Cell) bool {
+		got := ""
+		if fill := cellFill(cell); fill != nil && fill.PatternFill != nil && fill.PatternFill.PatternTypeAttr == sml.ST_PatternTypeSolid {
+			got = rgbOf(fill.PatternFill.FgColor)
+		}
+		return got == want
+	}, nil
+}
+
+// cellFont returns the font of the cell style, or nil if there is none.
+func cellFont(cell Cell) *sml.CT_Font {
+	if cs, ok := cellStyle(cell); ok {
+		return cs.GetFont()
+	}
+	return nil
+}
+
+// cellFill returns the fill of the cell style, or nil if there is none.
+func cellFill(cell Cell) *sml.CT_Fill {
+	if cs, ok := cellStyle(cell); ok {
+		return cs.GetFill()
+	}
+	return nil
+}
+
+func cellStyle(cell Cell) (CellStyle, bool) {
+	if cell._ea == nil || cell.X().SAttr == nil {
+		return CellStyle{}, false
+	}
+	cs := cell._ea.StyleSheet.GetCellStyle(*cell.X().SAttr)
+	return cs, cs._cfc != nil
+}
+
+// rgbOf returns the RGB part of an explicit color as upper case hex digits.
+func rgbOf(c *sml.CT_Color) string {
+	if c == nil || c.RgbAttr == nil {
+		return ""
+	}
+	rgb := strings.ToUpper(*c.RgbAttr)
+	if len(rgb) == 8 {
+		rgb = rgb[2:]
+	}
+	return rgb
+}
+
+// cellNumber returns the numeric value of a cell, dates included.
+func cellNumber(cell Cell) (float64, bool) {
+	if cell.IsEmpty() || cell.X().TAttr == sml.ST_CellTypeS || cell.X().TAttr == sml.ST_CellTypeInlineStr ||
+		cell.X().TAttr == sml.ST_CellTypeB || cell.X().TAttr == sml.ST_CellTypeE {
+		return 0, false
+	}
+	v, err := cell.GetValueAsNumber()
+	if err != nil || math.IsNaN(v) {
+		return 0, false
+	}
+	return v, true
+}
+
+func columnNumbers(cells []Cell) []float64 {
+	numbers := []float64{}
+	for _, cell := range cells {
+		if v, ok := cellNumber(cell); ok {
+			numbers = append(numbers, v)
+		}
+	}
+	return numbers
+}
+
+// cellDate returns the date of a number that is formatted as a date.
+func cellDate(cell Cell) (time.Time, bool) {
+	v, ok := cellNumber(cell)
+	if !ok || cell._ea == nil || !isDateFormat(cell.getFormat()) {
+		return time.Time{}, false
+	}
+	epoch := cell._ea.Epoch()
+	return epoch.Add(time.Duration(math.Round(v*86400)) * time.Second), true
+}
+
+// isDateFormat reports whether a number format displays dates or times.
+func isDateFormat(f string) bool {
+	inQuote, inBracket := false, false
+	for i := 0; i < len(f); i++ {
+		c := f[i]
+		switch {
+		case inQuote:
+			inQuote = c != '"'
+		case inBracket:
+			inBracket = c != ']'
+		case c == '"':
+			inQuote = true
+		case c == '[':
+			// elapsed time such as [h] is a time, colors and conditions are not
+			if end := strings.IndexByte(f[i:], ']'); end > 0 && strings.Trim(strings.ToLower(f[i+1:i+end]), "hms") == "" {
+				return true
+			}
+			inBracket = true
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		default:
+			switch c | 0x20 {
+			case 'y', 'm', 'd', 'h', 's':
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// filterSheet returns a sheet with names in column A, quantities in column B
+// and dates in column C below a header row, with an autofilter on A1:C7.
+func filterSheet(t *testing.T) (*Workbook, *Sheet, AutoFilter) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.Cell("A1").SetString("Name")
+	sheet.Cell("B1").SetString("Qty")
+	sheet.Cell("C1").SetString("Date")
+	names := []string{"apple", "apricot", "pear", "plum", "banana", "cherry"}
+	dates := []time.Time{
+		time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC),
+	}
+	for i, n := range names {
+		sheet.Cell(fmt.Sprintf("A%d", i+2)).SetString(n)
+		sheet.Cell(fmt.Sprintf("B%d", i+2)).SetNumber(float64(i + 1))
+		sheet.Cell(fmt.Sprintf("C%d", i+2)).SetDateWithStyle(dates[i])
+	}
+	sheet.SetAutoFilter("A1:C7")
+	af, err := sheet.AutoFilter()
+	if err != nil {
+		t.Fatalf("expected an autofilter: %s", err)
+	}
+	return wb, &sheet, af
+}
+
+func hiddenRows(sheet *Sheet) []int {
+	var rows []int
+	for _, r := range sheet.Rows() {
+		if r.IsHidden() {
+			rows = append(rows, int(r.RowNumber()))
+		}
+	}
+	return rows
+}
+
+func TestAutoFilterApply(t *testing.T) {
+	td := []struct {
+		Name   string
+		Set    func(af AutoFilter)
+		Hidden string
+	}{
+		{"values", func(af AutoFilter) { af.Column(0).SetValues("pear", "plum") }, "[2 3 6 7]"},
+		{"custom", func(af AutoFilter) {
+			af.Column(0).SetCustomFilters(false, CustomFilter{sml.ST_FilterOperatorEqual, "ap*"})
+		}, "[4 5 6 7]"},
+		{"custom and", func(af AutoFilter) {
+			af.Column(1).SetCustomFilters(true,
+				CustomFilter{sml.ST_FilterOperatorGreaterThan, "2"},
+				CustomFilter{sml.ST_FilterOperatorLessThanOrEqual, "4"})
+		}, "[2 3 6 7]"},
+		{"top 2", func(af AutoFilter) { af.Column(1).SetTop10(true, false, 2) }, "[2 3 4 5]"},
+		{"bottom 50 percent", func(af AutoFilter) { af.Column(1).SetTop10(false, true, 50) }, "[5 6 7]"},
+		{"above average", func(af AutoFilter) {
+			af.Column(1).SetDynamicFilter(sml.ST_DynamicFilterTypeAboveAverage)
+		}, "[2 3 4]"},
+		{"two columns", func(af AutoFilter) {
+			af.Column(0).SetCustomFilters(false, CustomFilter{sml.ST_FilterOperatorEqual, "p*"})
+			af.Column(1).SetCustomFilters(false, CustomFilter{sml.ST_FilterOperatorGreaterThan, "3"})
+		}, "[2 3 4 6 7]"},
+	}
+	for _, tc := range td {
+		_, sheet, af := filterSheet(t)
+		tc.Set(af)
+		if err := af.Apply(); err != nil {
+			t.Fatalf("%s: error applying filter: %s", tc.Name, err)
+		}
+		if got := fmt.Sprint(hiddenRows(sheet)); got != tc.Hidden {
+			t.Errorf("%s: expected hidden rows %s, got %s", tc.Name, tc.Hidden, got)
+		}
+		if err := af.Clear(); err != nil {
+			t.Fatalf("%s: error clearing filter: %s", tc.Name, err)
+		}
+		if got := hiddenRows(sheet); len(got) != 0 {
+			t.Errorf("%s: expected no hidden rows after clearing, got %v", tc.Name, got)
+		}
+	}
+}
+
+func TestAutoFilterDynamicDates(t *testing.T) {
+	// Wednesday, March 6, 2024
+	now := time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)
+	td := []struct {
+		Type   sml.ST_DynamicFilterType
+		Hidden string
+	}{
+		{sml.ST_DynamicFilterTypeThisWeek, "[4 5 7]"},
+		{sml.ST_DynamicFilterTypeNextWeek, "[2 3 5 6 7]"},
+		{sml.ST_DynamicFilterTypeLastMonth, "[2 3 4 6 7]"},
+		{sml.ST_DynamicFilterTypeThisMonth, "[5 7]"},
+		{sml.ST_DynamicFilterTypeLastYear, "[2 3 4 5 6]"},
+		{sml.ST_DynamicFilterTypeM3, "[5]"},
+	}
+	for _, tc := range td {
+		_, sheet, af := filterSheet(t)
+		af.Column(2).SetDynamicFilter(tc.Type)
+		if err := af.ApplyAt(now); err != nil {
+			t.Fatalf("%s: error applying filter: %s", tc.Type, err)
+		}
+		if got := fmt.Sprint(hiddenRows(sheet)); got != tc.Hidden {
+			t.Errorf("%s: expected hidden rows %s, got %s", tc.Type, tc.Hidden, got)
+		}
+	}
+}
+
+func TestAutoFilterWholeColumns(t *testing.T) {
+	_, sheet, _ := filterSheet(t)
+	sheet.SetAutoFilter("A1:C1048576")
+	af, _ := sheet.AutoFilter()
+	af.Column(0).SetValues("apple")
+	if err := af.Apply(); err != nil {
+		t.Fatalf("error applying filter: %s", err)
+	}
+	if got, exp := fmt.Sprint(hiddenRows(sheet)), "[3 4 5 6 7]"; got != exp {
+		t.Errorf("expected hidden rows %s, got %s", exp, got)
+	}
+	if n := len(sheet.Rows()); n != 7 {
+		t.Errorf("expected no rows to be added below the data, got %d rows", n)
+	}
+}
+
+func TestAutoFilterColorDxfs(t *testing.T) {
+	wb, sheet, af := filterSheet(t)
+	red := NewRGBColor(color.Red)
+	style, err := wb.StyleSheet.GetOrCreateStyle(StyleDescriptor{Fill: &FillDescriptor{FgColor: red}})
+	if err != nil {
+		t.Fatalf("error creating style: %s", err)
+	}
+	sheet.Cell("A3").SetStyle(style)
+
+	for i := 0; i < 3; i++ {
+		af.Column(0).SetCellColorFilter(color.Red)
+		af.Column(1).SetFontColorFilter(color.Blue)
+		af.Column(1).SetFontColorFilter(color.Blue)
+	}
+	if n := len(wb.StyleSheet.X().Dxfs.Dxf); n != 2 {
+		t.Errorf("expected the color filters to share 2 differential formats, got %d", n)
+	}
+	af.Column(2).SetCellColorFilter(color.Red)
+	if n := len(wb.StyleSheet.X().Dxfs.Dxf); n != 2 {
+		t.Errorf("expected the red fill format to be reused, got %d formats", n)
+	}
+
+	af.RemoveColumn(1)
+	af.RemoveColumn(2)
+	if err := af.Apply(); err != nil {
+		t.Fatalf("error applying filter: %s", err)
+	}
+	if got, exp := fmt.Sprint(hiddenRows(sheet)), "[2 4 5 6 7]"; got != exp {
+		t.Errorf("expected hidden rows %s, got %s", exp, got)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"math"
+	"strings"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// maxColumnWidth is the largest width of a column in characters.
+const maxColumnWidth = 255
+
+// cellFontSpec is the typeface, size in points and style used to measure
+// text.
+type cellFontSpec struct {
+	name         string
+	size         float64
+	bold, italic bool
+}
+
+func fontSpec(f *sml.CT_Font, def cellFontSpec) cellFontSpec {
+	if f == nil {
+		return def
+	}
+	if len(f.Name) > 0 && f.Name[0].ValAttr != "" {
+		def.name = f.Name[0].ValAttr
+	}
+	if len(f.Sz) > 0 && f.Sz[0].ValAttr > 0 {
+		def.size = f.Sz[0].ValAttr
+	}
+	if len(f.B) > 0 {
+		def.bold = isSet(f.B[0])
+	}
+	if len(f.I) > 0 {
+		def.italic = isSet(f.I[0])
+	}
+	return def
+}
+
+// width returns the width of a line of text in pixels.
+func (f cellFontSpec) width(s string) float64 {
+	pts := convertutils.TextWidth(convertutils.GetFont(f.name, f.bold, f.italic), s, f.size)
+	return float64(measurement.Distance(pts) / measurement.Pixel96)
+}
+
+// AutoFitColumns sets the width of the given columns (e.g. "A", "BC") to fit
+// their content, or of all the columns with content if none are given. The
+// text of each cell is formatted with its number format and measured with the
+// font of the cell, using the fonts registered with the convert packages and
+// standard font metrics for the other fonts. Hidden rows and merged cells are
+// ignored, as they are by Excel, and columns without content keep their width.
+func (s *Sheet) AutoFitColumns(columns ...string) {
+	wanted := map[uint32]bool{}
+	for _, c := range columns {
+		wanted[reference.ColumnToIndex(strings.ToUpper(c))] = true
+	}
+	merged := []tableRect{}
+	for _, mc := range s.MergedCells() {
+		if r, err := parseTableRect(mc.Reference()); err == nil {
+			merged = append(merged, r)
+		}
+	}
+	isMerged := func(row, col int) bool {
+		for _, r := range merged {
+			if row >= r.row0 && row <= r.row1 && col >= r.col0 && col <= r.col1 {
+				return true
+			}
+		}
+		return false
+	}
+
+	def := cellFontSpec{name: "Calibri", size: 11}
+	if fonts := s._gccb.StyleSheet.Fonts(); len(fonts) > 0 {
+		def = fontSpec(fonts[0].X(), def)
+	}
+	// column widths are measured in widths of the digits of the default font
+	digit := def.width("0")
+	if digit <= 0 {
+		digit = 7
+	}
+
+	widths := map[uint32]float64{}
+	for _, row := range s.Rows() {
+		if row.IsHidden() {
+			continue
+		}
+		for _, cell := range row.Cells() {
+			ref, err := reference.ParseCellReference(cell.Reference())
+			if err != nil || len(wanted) > 0 && !wanted[ref.ColumnIdx] {
+				continue
+			}
+			if isMerged(int(ref.RowIdx)-1, int(ref.ColumnIdx)) {
+				continue
+			}
+			if w := cellTextWidth(cell, fontSpec(cellFont(cell), def)); w > widths[ref.ColumnIdx] {
+				widths[ref.ColumnIdx] = w
+			}
+		}
+	}
+
+	for idx, w := range widths {
+		if w == 0 {
+			continue
+		}
+		// five pixels of padding are added to the text, and the width is
+		// truncated to 1/256 of a character
+		chars := math.Floor((w+5)/digit*256) / 256
+		if chars > maxColumnWidth {
+			chars = maxColumnWidth
+		}
+		col := s.columnRange(idx+1, idx+1)[0]
+		col.WidthAttr = unioffice.Float64(chars)
+		col.CustomWidthAttr = unioffice.Bool(true)
+		col.BestFitAttr = unioffice.Bool(true)
+	}
+}
+
+// cellTextWidth returns the width in pixels of the longest line of the
+// formatted text of a cell.
+func cellTextWidth(cell Cell, font cellFontSpec) float64 {
+	if rt, ok := cell.GetRichText(); ok && len(rt.X().R) > 0 {
+		max, line := 0.0, 0.0
+		for _, run := range rt.Runs() {
+			f := font
+			if run._dcfc.RPr != nil {
+				if name := run.GetFont(); name != "" {
+					f.name = name
+				}
+				if size := run.GetSize(); size > 0 {
+					f.size = float64(size / measurement.Point)
+				}
+				f.bold, f.italic = run.IsBold(), run.IsItalic()
+			}
+			parts := strings.Split(run.GetText(), "\n")
+			for i, p := range parts {
+				if i > 0 {
+					line = 0
+				}
+				line += f.width(p)
+				if line > max {
+					max = line
+				}
+			}
+		}
+		return max
+	}
+
+	max := 0.0
+	for _, line := range strings.Split(cell.GetFormattedValue(), "\n") {
+		if w := font.width(line); w > max {
+			max = w
+		}
+	}
+	return max
+}
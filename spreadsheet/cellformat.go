@@ -0,0 +1,67 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"strconv"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/format"
+)
+
+// shortDateFormatID is the built-in number format that shows the short date of
+// the system, m/d/yyyy for en-US.
+const shortDateFormatID = 14
+
+// GetFormattedValueLocale returns the formatted cell value like
+// GetFormattedValue, using the decimal and thousands separators and the names
+// of months and days of a locale. The built-in short date format is replaced
+// by the short date format of the locale. If the locale is nil, en-US is used.
+func (c Cell) GetFormattedValueLocale(loc *format.Locale) string {
+	f := c.getFormat()
+	if loc != nil && c._cga.SAttr != nil {
+		style := c._ea.StyleSheet.GetCellStyle(*c._cga.SAttr)
+		if style.NumberFormat() == shortDateFormatID {
+			f = loc.ShortDateFormat
+		}
+	}
+	switch c._cga.TAttr {
+	case sml.ST_CellTypeB:
+		b, _ := c.GetValueAsBool()
+		if b {
+			return "TRUE"
+		}
+		return "FALSE"
+	case sml.ST_CellTypeN:
+		v, _ := c.GetValueAsNumber()
+		return format.NumberLocale(v, f, loc)
+	case sml.ST_CellTypeE:
+		if c._cga.V != nil {
+			return *c._cga.V
+		}
+		return ""
+	case sml.ST_CellTypeS, sml.ST_CellTypeInlineStr:
+		return format.StringLocale(c.GetString(), f, loc)
+	case sml.ST_CellTypeStr:
+		s := c.GetString()
+		if format.IsNumber(s) {
+			v, _ := strconv.ParseFloat(s, 64)
+			return format.NumberLocale(v, f, loc)
+		}
+		return format.StringLocale(s, f, loc)
+	default:
+		raw, _ := c.GetRawValue()
+		if len(raw) == 0 {
+			return ""
+		}
+		if v, err := c.GetValueAsNumber(); err == nil {
+			return format.NumberLocale(v, f, loc)
+		}
+		return format.StringLocale(raw, f, loc)
+	}
+}
@@ -0,0 +1,740 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// ConditionalFormat is the result of evaluating the conditional formatting
+// rules of a sheet for a cell.
+type ConditionalFormat struct {
+	// Rules are the rules that apply to the cell, in priority order.
+	Rules []ConditionalFormattingRule
+
+	// Style is the differential style of the rules that apply, where the
+	// properties of a higher priority rule take precedence. It is nil if no
+	// rule with a style applies.
+	Style *sml.CT_Dxf
+
+	// Color is the fill color computed by a color scale, or nil.
+	Color *sml.CT_Color
+
+	// DataBar is the data bar drawn in the cell, or nil. DataBarLength is its
+	// length as a fraction of the cell width.
+	DataBar       *sml.CT_DataBar
+	DataBarLength float64
+
+	// IconSet is the icon set that an icon of the cell is taken from, or
+	// sml.ST_IconSetTypeUnset. Icon is the index of the icon within the set,
+	// starting at 0 for the icon of the lowest values.
+	IconSet sml.ST_IconSetType
+	Icon    int
+
+	// HideValue is set if a data bar or icon set hides the cell value.
+	HideValue bool
+}
+
+// ConditionalFormatEvaluator computes the conditional formatting of the cells
+// of a sheet. It takes a snapshot of the rules and the cell values, so it must
+// be recreated after the sheet is modified.
+type ConditionalFormatEvaluator struct {
+	sheet *Sheet
+	now   time.Time
+	cells map[[2]int]Cell
+	rules []*cfRule
+}
+
+// cfRule is a rule with the ranges it applies to and the statistics of the
+// values of those ranges, computed when first needed.
+type cfRule struct {
+	x      *sml.CT_CfRule
+	rects  []tableRect
+	origin tableRect
+
+	loaded  bool
+	numbers []float64
+	counts  map[string]int
+}
+
+// ConditionalFormatEvaluator returns an evaluator for the conditional
+// formatting rules of the sheet.
+func (s *Sheet) ConditionalFormatEvaluator() *ConditionalFormatEvaluator {
+	e := &ConditionalFormatEvaluator{sheet: s, now: time.Now(), cells: map[[2]int]Cell{}}
+	for _, row := range s.Rows() {
+		for _, c := range row.Cells() {
+			if c.X().RAttr == nil {
+				continue
+			}
+			if ref, err := reference.ParseCellReference(*c.X().RAttr); err == nil {
+				e.cells[[2]int{int(ref.RowIdx) - 1, int(ref.ColumnIdx)}] = c
+			}
+		}
+	}
+	for _, cf := range s.X().ConditionalFormatting {
+		if cf.SqrefAttr == nil {
+			continue
+		}
+		rects, origin := parseSqref(*cf.SqrefAttr)
+		if len(rects) == 0 {
+			continue
+		}
+		for _, rule := range cf.CfRule {
+			e.rules = append(e.rules, &cfRule{x: rule, rects: rects, origin: origin})
+		}
+	}
+	sort.SliceStable(e.rules, func(i, j int) bool {
+		return e.rules[i].x.PriorityAttr < e.rules[j].x.PriorityAttr
+	})
+	return e
+}
+
+// parseSqref parses the valid ranges of a sequence of references, returning
+// them with the top left cell of all the ranges that the relative references
+// of formulas applying to them are relative to.
+func parseSqref(sqref sml.ST_Sqref) ([]tableRect, tableRect) {
+	rects := []tableRect{}
+	for _, refs := range sqref {
+		for _, ref := range strings.Fields(refs) {
+			if !strings.Contains(ref, ":") {
+				ref = ref + ":" + ref
+			}
+			if rect, err := parseTableRect(ref); err == nil {
+				rects = append(rects, rect)
+			}
+		}
+	}
+	if len(rects) == 0 {
+		return nil, tableRect{}
+	}
+	origin := rects[0]
+	for _, r := range rects[1:] {
+		if r.row0 < origin.row0 {
+			origin.row0 = r.row0
+		}
+		if r.col0 < origin.col0 {
+			origin.col0 = r.col0
+		}
+	}
+	return rects, origin
+}
+
+// SetDate sets the date that time period rules are relative to, which is
+// the current date by default.
+func (e *ConditionalFormatEvaluator) SetDate(d time.Time) { e.now = d }
+
+// Evaluate returns the conditional formatting of a cell, evaluating the rules
+// that apply to it in priority order until a rule that stops evaluation if
+// true applies.
+func (e *ConditionalFormatEvaluator) Evaluate(cellRef string) (ConditionalFormat, error) {
+	ref, err := reference.ParseCellReference(strings.Replace(cellRef, "$", "", -1))
+	if err != nil {
+		return ConditionalFormat{}, err
+	}
+	row, col := int(ref.RowIdx)-1, int(ref.ColumnIdx)
+	cell := e.cell(row, col)
+
+	res := ConditionalFormat{}
+	for _, r := range e.rules {
+		if !r.contains(row, col) {
+			continue
+		}
+		applies, err := e.apply(r, cell, row, col, &res)
+		if err != nil {
+			return ConditionalFormat{}, err
+		}
+		if !applies {
+			continue
+		}
+		res.Rules = append(res.Rules, ConditionalFormattingRule{r.x})
+		if r.x.DxfIdAttr != nil {
+			if dxfs := e.sheet._gccb.StyleSheet.X().Dxfs; dxfs != nil && int(*r.x.DxfIdAttr) < len(dxfs.Dxf) {
+				res.Style = mergeDxf(res.Style, dxfs.Dxf[*r.x.DxfIdAttr])
+			}
+		}
+		if r.x.StopIfTrueAttr != nil && *r.x.StopIfTrueAttr {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (e *ConditionalFormatEvaluator) cell(row, col int) Cell {
+	if c, ok := e.cells[[2]int{row, col}]; ok {
+		return c
+	}
+	x := sml.NewCT_Cell()
+	x.RAttr = unioffice.String(cellName(row, col))
+	return Cell{e.sheet._gccb, e.sheet, nil, x}
+}
+
+func (r *cfRule) contains(row, col int) bool {
+	for _, rect := range r.rects {
+		if row >= rect.row0 && row <= rect.row1 && col >= rect.col0 && col <= rect.col1 {
+			return true
+		}
+	}
+	return false
+}
+
+// load collects the statistics of the values of the ranges of the rule.
+func (r *cfRule) load(e *ConditionalFormatEvaluator) {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+	r.counts = map[string]int{}
+	for pos, c := range e.cells {
+		if !r.contains(pos[0], pos[1]) {
+			continue
+		}
+		if v, ok := cellNumber(c); ok {
+			r.numbers = append(r.numbers, v)
+		}
+		if key, ok := cellKey(c); ok {
+			r.counts[key]++
+		}
+	}
+	sort.Float64s(r.numbers)
+}
+
+// cellKey returns the value of a cell in a form where equal values compare
+// equal, ignoring the case of text.
+func cellKey(cell Cell) (string, bool) {
+	if cell.IsEmpty() {
+		return "", false
+	}
+	if v, ok := cellNumber(cell); ok {
+		return "n" + strconv.FormatFloat(v, 'g', -1, 64), true
+	}
+	return "s" + strings.ToLower(cell.GetString()), true
+}
+
+// apply reports whether the rule applies to the cell, setting the color, data
+// bar or icon of the result for rules that compute them.
+func (e *ConditionalFormatEvaluator) apply(r *cfRule, cell Cell, row, col int, res *ConditionalFormat) (bool, error) {
+	x := r.x
+	switch x.TypeAttr {
+	case sml.ST_CfTypeCellIs:
+		return e.cellIs(r, cell, row, col), nil
+	case sml.ST_CfTypeExpression:
+		if len(x.Formula) == 0 {
+			return false, nil
+		}
+		return isTrue(e.eval(r, x.Formula[0], row, col)), nil
+	case sml.ST_CfTypeTop10:
+		return r.top10(e, cell), nil
+	case sml.ST_CfTypeAboveAverage:
+		return r.aboveAverage(e, cell), nil
+	case sml.ST_CfTypeDuplicateValues, sml.ST_CfTypeUniqueValues:
+		key, ok := cellKey(cell)
+		if !ok {
+			return false, nil
+		}
+		r.load(e)
+		return (r.counts[key] > 1) == (x.TypeAttr == sml.ST_CfTypeDuplicateValues), nil
+	case sml.ST_CfTypeContainsText, sml.ST_CfTypeNotContainsText, sml.ST_CfTypeBeginsWith, sml.ST_CfTypeEndsWith:
+		return textRuleMatches(x, cell), nil
+	case sml.ST_CfTypeContainsBlanks:
+		return strings.TrimSpace(cell.GetString()) == "", nil
+	case sml.ST_CfTypeNotContainsBlanks:
+		return strings.TrimSpace(cell.GetString()) != "", nil
+	case sml.ST_CfTypeContainsErrors:
+		return cell.X().TAttr == sml.ST_CellTypeE, nil
+	case sml.ST_CfTypeNotContainsErrors:
+		return cell.X().TAttr != sml.ST_CellTypeE, nil
+	case sml.ST_CfTypeTimePeriod:
+		return e.inTimePeriod(x.TimePeriodAttr, cell), nil
+	case sml.ST_CfTypeColorScale:
+		return e.colorScale(r, cell, res)
+	case sml.ST_CfTypeDataBar:
+		return e.dataBar(r, cell, res)
+	case sml.ST_CfTypeIconSet:
+		return e.iconSet(r, cell, res)
+	}
+	return false, nil
+}
+
+// eval evaluates a formula of a rule for a cell.
+func (e *ConditionalFormatEvaluator) eval(r *cfRule, f string, row, col int) formula.Result {
+	return evalRelative(e.sheet, f, r.origin, row, col)
+}
+
+// evalRelative evaluates a formula of a rule that applies to ranges with the
+// given top left cell for another cell of the ranges, shifting the relative
+// references of the formula by the offset of the cell.
+func evalRelative(s *Sheet, f string, origin tableRect, row, col int) formula.Result {
+	ctx := _afgg(s)
+	ctx.SetOffset(uint32(col-origin.col0), uint32(row-origin.row0))
+	ctx._ffbe = cellName(row, col)
+	return formula.NewEvaluator().Eval(ctx, f)
+}
+
+func isTrue(res formula.Result) bool {
+	switch res.Type {
+	case formula.ResultTypeNumber:
+		return res.ValueNumber != 0
+	case formula.ResultTypeString:
+		return strings.EqualFold(res.ValueString, "TRUE")
+	case formula.ResultTypeList, formula.ResultTypeArray:
+		if list := res.ListValues(); len(list) > 0 {
+			return isTrue(list[0])
+		}
+	}
+	return false
+}
+
+func (e *ConditionalFormatEvaluator) cellIs(r *cfRule, cell Cell, row, col int) bool {
+	x := r.x
+	if len(x.Formula) == 0 {
+		return false
+	}
+	cmp := func(i int) (int, bool) {
+		if i >= len(x.Formula) {
+			return 0, false
+		}
+		return compareCell(cell, e.eval(r, x.Formula[i], row, col))
+	}
+	c, ok := cmp(0)
+	if !ok {
+		return false
+	}
+	switch x.OperatorAttr {
+	case sml.ST_ConditionalFormattingOperatorLessThan:
+		return c < 0
+	case sml.ST_ConditionalFormattingOperatorLessThanOrEqual:
+		return c <= 0
+	case sml.ST_ConditionalFormattingOperatorEqual:
+		return c == 0
+	case sml.ST_ConditionalFormattingOperatorNotEqual:
+		return c != 0
+	case sml.ST_ConditionalFormattingOperatorGreaterThanOrEqual:
+		return c >= 0
+	case sml.ST_ConditionalFormattingOperatorGreaterThan:
+		return c > 0
+	case sml.ST_ConditionalFormattingOperatorBetween, sml.ST_ConditionalFormattingOperatorNotBetween:
+		c2, ok := cmp(1)
+		if !ok {
+			return false
+		}
+		// the bounds of between can be given in either order
+		between := (c >= 0 && c2 <= 0) || (c <= 0 && c2 >= 0)
+		return between == (x.OperatorAttr == sml.ST_ConditionalFormattingOperatorBetween)
+	}
+	return false
+}
+
+// compareCell compares a cell value to a formula result as Excel does, where
+// an empty cell is zero or empty text, text compares case insensitively and
+// text is greater than any number.
+func compareCell(cell Cell, res formula.Result) (int, bool) {
+	if res.Type == formula.ResultTypeList || res.Type == formula.ResultTypeArray {
+		list := res.ListValues()
+		if len(list) == 0 {
+			return 0, false
+		}
+		res = list[0]
+	}
+	if res.Type == formula.ResultTypeError || cell.X().TAttr == sml.ST_CellTypeE {
+		return 0, false
+	}
+	n, isNumber := cellNumber(cell)
+	if cell.IsEmpty() {
+		isNumber = res.Type != formula.ResultTypeString
+	}
+	switch {
+	case isNumber && res.Type == formula.ResultTypeString:
+		return -1, true
+	case isNumber:
+		v := res.ValueNumber
+		switch {
+		case n < v:
+			return -1, true
+		case n > v:
+			return 1, true
+		}
+		return 0, true
+	case res.Type != formula.ResultTypeString && res.Type != formula.ResultTypeEmpty:
+		return 1, true
+	}
+	return strings.Compare(strings.ToLower(cell.GetString()), strings.ToLower(res.ValueString)), true
+}
+
+func (r *cfRule) top10(e *ConditionalFormatEvaluator, cell Cell) bool {
+	v, ok := cellNumber(cell)
+	if !ok {
+		return false
+	}
+	r.load(e)
+	if len(r.numbers) == 0 {
+		return false
+	}
+	n := 10
+	if r.x.RankAttr != nil {
+		n = int(*r.x.RankAttr)
+	}
+	if r.x.PercentAttr != nil && *r.x.PercentAttr {
+		n = len(r.numbers) * n / 100
+	}
+	if n < 1 {
+		n = 1
+	}
+	if n > len(r.numbers) {
+		n = len(r.numbers)
+	}
+	if r.x.BottomAttr != nil && *r.x.BottomAttr {
+		return v <= r.numbers[n-1]
+	}
+	return v >= r.numbers[len(r.numbers)-n]
+}
+
+func (r *cfRule) aboveAverage(e *ConditionalFormatEvaluator, cell Cell) bool {
+	v, ok := cellNumber(cell)
+	if !ok {
+		return false
+	}
+	r.load(e)
+	if len(r.numbers) == 0 {
+		return false
+	}
+	avg := 0.0
+	for _, n := range r.numbers {
+		avg += n
+	}
+	avg /= float64(len(r.numbers))
+
+	above := r.x.AboveAverageAttr == nil || *r.x.AboveAverageAttr
+	threshold := avg
+	if r.x.StdDevAttr != nil && len(r.numbers) > 1 {
+		sum := 0.0
+		for _, n := range r.numbers {
+			sum += (n - avg) * (n - avg)
+		}
+		sd := math.Sqrt(sum/float64(len(r.numbers)-1)) * float64(*r.x.StdDevAttr)
+		if above {
+			threshold += sd
+		} else {
+			threshold -= sd
+		}
+	}
+	if r.x.EqualAverageAttr != nil && *r.x.EqualAverageAttr && v == threshold {
+		return true
+	}
+	if above {
+		return v > threshold
+	}
+	return v < threshold
+}
+
+func textRuleMatches(x *sml.CT_CfRule, cell Cell) bool {
+	if x.TextAttr == nil || cell.X().TAttr == sml.ST_CellTypeE {
+		return false
+	}
+	text := strings.ToLower(cell.GetString())
+	search := strings.ToLower(*x.TextAttr)
+	switch x.TypeAttr {
+	case sml.ST_CfTypeContainsText:
+		return strings.Contains(text, search)
+	case sml.ST_CfTypeNotContainsText:
+		return !strings.Contains(text, search)
+	case sml.ST_CfTypeBeginsWith:
+		return strings.HasPrefix(text, search)
+	case sml.ST_CfTypeEndsWith:
+		return strings.HasSuffix(text, search)
+	}
+	return false
+}
+
+// timePeriodFilters maps the time periods of rules to the dynamic filters
+// that cover the same dates.
+var timePeriodFilters = map[sml.ST_TimePeriod]sml.ST_DynamicFilterType{
+	sml.ST_TimePeriodToday:     sml.ST_DynamicFilterTypeToday,
+	sml.ST_TimePeriodYesterday: sml.ST_DynamicFilterTypeYesterday,
+	sml.ST_TimePeriodTomorrow:  sml.ST_DynamicFilterTypeTomorrow,
+	sml.ST_TimePeriodThisWeek:  sml.ST_DynamicFilterTypeThisWeek,
+	sml.ST_TimePeriodLastWeek:  sml.ST_DynamicFilterTypeLastWeek,
+	sml.ST_TimePeriodNextWeek:  sml.ST_DynamicFilterTypeNextWeek,
+	sml.ST_TimePeriodThisMonth: sml.ST_DynamicFilterTypeThisMonth,
+	sml.ST_TimePeriodLastMonth: sml.ST_DynamicFilterTypeLastMonth,
+	sml.ST_TimePeriodNextMonth: sml.ST_DynamicFilterTypeNextMonth,
+}
+
+func (e *ConditionalFormatEvaluator) inTimePeriod(p sml.ST_TimePeriod, cell Cell) bool {
+	v, ok := cellNumber(cell)
+	if !ok {
+		return false
+	}
+	d := e.sheet._gccb.Epoch().Add(time.Duration(math.Round(v*86400)) * time.Second)
+	var from, to time.Time
+	if p == sml.ST_TimePeriodLast7Days {
+		to, _ = dynamicDateRange(sml.ST_DynamicFilterTypeTomorrow, e.now)
+		from = to.AddDate(0, 0, -7)
+	} else if t, ok := timePeriodFilters[p]; ok {
+		from, to = dynamicDateRange(t, e.now)
+	} else {
+		return false
+	}
+	return !d.Before(from) && d.Before(to)
+}
+
+// thresholds returns the values of the conditional format value objects of
+// a color scale, data bar or icon set.
+func (e *ConditionalFormatEvaluator) thresholds(r *cfRule, cfvos []*sml.CT_Cfvo) ([]float64, error) {
+	r.load(e)
+	min, max := 0.0, 0.0
+	if len(r.numbers) > 0 {
+		min, max = r.numbers[0], r.numbers[len(r.numbers)-1]
+	}
+	values := make([]float64, len(cfvos))
+	for i, cfvo := range cfvos {
+		val := ""
+		if cfvo.ValAttr != nil {
+			val = *cfvo.ValAttr
+		}
+		switch cfvo.TypeAttr {
+		case sml.ST_CfvoTypeMin:
+			values[i] = min
+		case sml.ST_CfvoTypeMax:
+			values[i] = max
+		case sml.ST_CfvoTypeNum, sml.ST_CfvoTypePercent, sml.ST_CfvoTypePercentile, sml.ST_CfvoTypeFormula:
+			v, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				// the value can be a formula such as =$A$1
+				res := e.eval(r, strings.TrimPrefix(val, "="), r.origin.row0, r.origin.col0).AsNumber()
+				if res.Type != formula.ResultTypeNumber {
+					return nil, fmt.Errorf("invalid conditional format value %q", val)
+				}
+				v = res.ValueNumber
+			}
+			switch cfvo.TypeAttr {
+			case sml.ST_CfvoTypePercent:
+				v = min + (max-min)*v/100
+			case sml.ST_CfvoTypePercentile:
+				v = percentile(r.numbers, v/100)
+			}
+			values[i] = v
+		default:
+			return nil, fmt.Errorf("unsupported conditional format value type %s", cfvo.TypeAttr)
+		}
+	}
+	return values, nil
+}
+
+// percentile returns the percentile p of sorted numbers, interpolating as
+// PERCENTILE.INC does.
+func percentile(numbers []float64, p float64) float64 {
+	if len(numbers) == 0 {
+		return 0
+	}
+	p = math.Max(0, math.Min(1, p))
+	pos := p * float64(len(numbers)-1)
+	i := int(pos)
+	if i+1 >= len(numbers) {
+		return numbers[len(numbers)-1]
+	}
+	return numbers[i] + (pos-float64(i))*(numbers[i+1]-numbers[i])
+}
+
+func (e *ConditionalFormatEvaluator) colorScale(r *cfRule, cell Cell, res *ConditionalFormat) (bool, error) {
+	cs := r.x.ColorScale
+	v, ok := cellNumber(cell)
+	if !ok || cs == nil || len(cs.Cfvo) < 2 || len(cs.Color) != len(cs.Cfvo) {
+		return false, nil
+	}
+	stops, err := e.thresholds(r, cs.Cfvo)
+	if err != nil {
+		return false, err
+	}
+	if res.Color != nil {
+		// a higher priority color scale already colors the cell
+		return true, nil
+	}
+	res.Color = cs.Color[len(stops)-1]
+	for i, stop := range stops {
+		if v <= stop {
+			res.Color = cs.Color[i]
+			if i > 0 && stops[i] > stops[i-1] {
+				res.Color = interpolateColor(cs.Color[i-1], cs.Color[i], (v-stops[i-1])/(stops[i]-stops[i-1]))
+			}
+			break
+		}
+	}
+	return true, nil
+}
+
+// interpolateColor returns the color at a fraction f between the colors a and
+// b. Colors that aren't given as RGB values can't be mixed, the nearer of the
+// two is returned for them.
+func interpolateColor(a, b *sml.CT_Color, f float64) *sml.CT_Color {
+	ca, cb := rgbOf(a), rgbOf(b)
+	if len(ca) != 6 || len(cb) != 6 {
+		if f < 0.5 {
+			return a
+		}
+		return b
+	}
+	out := "FF"
+	for i := 0; i < 6; i += 2 {
+		x, _ := strconv.ParseUint(ca[i:i+2], 16, 8)
+		y, _ := strconv.ParseUint(cb[i:i+2], 16, 8)
+		out += fmt.Sprintf("%02X", int(math.Round(float64(x)+f*(float64(y)-float64(x)))))
+	}
+	c := sml.NewCT_Color()
+	c.RgbAttr = &out
+	return c
+}
+
+func (e *ConditionalFormatEvaluator) dataBar(r *cfRule, cell Cell, res *ConditionalFormat) (bool, error) {
+	db := r.x.DataBar
+	v, ok := cellNumber(cell)
+	if !ok || db == nil || len(db.Cfvo) != 2 {
+		return false, nil
+	}
+	bounds, err := e.thresholds(r, db.Cfvo)
+	if err != nil {
+		return false, err
+	}
+	if res.DataBar != nil {
+		return true, nil
+	}
+	minLength, maxLength := 10.0, 90.0
+	if db.MinLengthAttr != nil {
+		minLength = float64(*db.MinLengthAttr)
+	}
+	if db.MaxLengthAttr != nil {
+		maxLength = float64(*db.MaxLengthAttr)
+	}
+	f := 1.0
+	if bounds[1] > bounds[0] {
+		f = math.Max(0, math.Min(1, (v-bounds[0])/(bounds[1]-bounds[0])))
+	} else if v < bounds[0] {
+		f = 0
+	}
+	res.DataBar = db
+	res.DataBarLength = (minLength + f*(maxLength-minLength)) / 100
+	res.HideValue = res.HideValue || (db.ShowValueAttr != nil && !*db.ShowValueAttr)
+	return true, nil
+}
+
+func (e *ConditionalFormatEvaluator) iconSet(r *cfRule, cell Cell, res *ConditionalFormat) (bool, error) {
+	is := r.x.IconSet
+	v, ok := cellNumber(cell)
+	if !ok || is == nil || len(is.Cfvo) == 0 {
+		return false, nil
+	}
+	thresholds, err := e.thresholds(r, is.Cfvo)
+	if err != nil {
+		return false, err
+	}
+	if res.IconSet != sml.ST_IconSetTypeUnset {
+		return true, nil
+	}
+	icon := 0
+	for i := len(thresholds) - 1; i > 0; i-- {
+		gte := is.Cfvo[i].GteAttr == nil || *is.Cfvo[i].GteAttr
+		if v > thresholds[i] || (gte && v == thresholds[i]) {
+			icon = i
+			break
+		}
+	}
+	if is.ReverseAttr != nil && *is.ReverseAttr {
+		icon = len(thresholds) - 1 - icon
+	}
+	res.IconSet = is.IconSetAttr
+	if res.IconSet == sml.ST_IconSetTypeUnset {
+		res.IconSet = sml.ST_IconSetType3TrafficLights1
+	}
+	res.Icon = icon
+	res.HideValue = res.HideValue || (is.ShowValueAttr != nil && !*is.ShowValueAttr)
+	return true, nil
+}
+
+// mergeDxf adds the properties of a lower priority differential style that
+// aren't set by the merged style of the higher priority rules.
+func mergeDxf(dst, src *sml.CT_Dxf) *sml.CT_Dxf {
+	if dst == nil {
+		dst = sml.NewCT_Dxf()
+	}
+	if src.Font != nil {
+		if dst.Font == nil {
+			dst.Font = sml.NewCT_Font()
+		}
+		f, s := dst.Font, src.Font
+		if len(f.Name) == 0 {
+			f.Name = s.Name
+		}
+		if len(f.Charset) == 0 {
+			f.Charset = s.Charset
+		}
+		if len(f.Family) == 0 {
+			f.Family = s.Family
+		}
+		if len(f.B) == 0 {
+			f.B = s.B
+		}
+		if len(f.I) == 0 {
+			f.I = s.I
+		}
+		if len(f.Strike) == 0 {
+			f.Strike = s.Strike
+		}
+		if len(f.Outline) == 0 {
+			f.Outline = s.Outline
+		}
+		if len(f.Shadow) == 0 {
+			f.Shadow = s.Shadow
+		}
+		if len(f.Condense) == 0 {
+			f.Condense = s.Condense
+		}
+		if len(f.Extend) == 0 {
+			f.Extend = s.Extend
+		}
+		if len(f.Color) == 0 {
+			f.Color = s.Color
+		}
+		if len(f.Sz) == 0 {
+			f.Sz = s.Sz
+		}
+		if len(f.U) == 0 {
+			f.U = s.U
+		}
+		if len(f.VertAlign) == 0 {
+			f.VertAlign = s.VertAlign
+		}
+		if len(f.Scheme) == 0 {
+			f.Scheme = s.Scheme
+		}
+	}
+	if dst.NumFmt == nil {
+		dst.NumFmt = src.NumFmt
+	}
+	if dst.Fill == nil {
+		dst.Fill = src.Fill
+	}
+	if dst.Alignment == nil {
+		dst.Alignment = src.Alignment
+	}
+	if dst.Border == nil {
+		dst.Border = src.Border
+	}
+	if dst.Protection == nil {
+		dst.Protection = src.Protection
+	}
+	return dst
+}
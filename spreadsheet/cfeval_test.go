@@ -0,0 +1,243 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// cfSheet returns a sheet with the numbers 1 to 10 in A1:A10, text in B1:B3
+// with B4 empty and the dates 2024-03-06, 2024-03-05 and 2024-02-20 in
+// C1:C3.
+func cfSheet() (*Workbook, *Sheet) {
+	wb := New()
+	sheet := wb.AddSheet()
+	for i := 1; i <= 10; i++ {
+		sheet.Cell(fmt.Sprintf("A%d", i)).SetNumber(float64(i))
+	}
+	for i, s := range []string{"apple", "Apple", "pear"} {
+		sheet.Cell(fmt.Sprintf("B%d", i+1)).SetString(s)
+	}
+	for i, d := range []time.Time{
+		time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC),
+	} {
+		sheet.Cell(fmt.Sprintf("C%d", i+1)).SetDateWithStyle(d)
+	}
+	return wb, &sheet
+}
+
+// cellsOf returns the names of the cells of a range in row order.
+func cellsOf(rng string) []string {
+	from, to, _ := reference.ParseRangeReference(rng)
+	var cells []string
+	for r := from.RowIdx; r <= to.RowIdx; r++ {
+		for c := from.ColumnIdx; c <= to.ColumnIdx; c++ {
+			cells = append(cells, cellName(int(r)-1, int(c)))
+		}
+	}
+	return cells
+}
+
+func TestConditionalFormatRules(t *testing.T) {
+	td := []struct {
+		Name  string
+		Range string
+		Setup func(r ConditionalFormattingRule)
+		Exp   string
+	}{
+		{"greater than", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetConditionValue("7")
+		}, "A8 A9 A10"},
+		{"between reversed", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetOperator(sml.ST_ConditionalFormattingOperatorBetween)
+			r.X().Formula = []string{"5", "3"}
+		}, "A3 A4 A5"},
+		{"not equal to a cell", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetOperator(sml.ST_ConditionalFormattingOperatorNotEqual)
+			r.SetConditionValue("$A$2")
+		}, "A1 A3 A4 A5 A6 A7 A8 A9 A10"},
+		{"expression", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeExpression)
+			r.SetConditionValue("MOD(A1,4)=0")
+		}, "A4 A8"},
+		{"top 2", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeTop10)
+			r.X().RankAttr = unioffice.Uint32(2)
+		}, "A9 A10"},
+		{"bottom 20 percent", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeTop10)
+			r.X().RankAttr = unioffice.Uint32(20)
+			r.X().PercentAttr = unioffice.Bool(true)
+			r.X().BottomAttr = unioffice.Bool(true)
+		}, "A1 A2"},
+		{"above average", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeAboveAverage)
+		}, "A6 A7 A8 A9 A10"},
+		{"one deviation below average", "A1:A10", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeAboveAverage)
+			r.X().AboveAverageAttr = unioffice.Bool(false)
+			r.X().StdDevAttr = unioffice.Int32(1)
+		}, "A1 A2"},
+		{"contains text", "B1:B4", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeContainsText)
+			r.X().TextAttr = unioffice.String("PP")
+		}, "B1 B2"},
+		{"begins with", "B1:B4", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeBeginsWith)
+			r.X().TextAttr = unioffice.String("pe")
+		}, "B3"},
+		{"duplicates", "B1:B4", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeDuplicateValues)
+		}, "B1 B2"},
+		{"unique", "B1:B4", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeUniqueValues)
+		}, "B3"},
+		{"blanks", "B1:B4", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeContainsBlanks)
+		}, "B4"},
+		{"today", "C1:C3", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeTimePeriod)
+			r.X().TimePeriodAttr = sml.ST_TimePeriodToday
+		}, "C1"},
+		{"yesterday", "C1:C3", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeTimePeriod)
+			r.X().TimePeriodAttr = sml.ST_TimePeriodYesterday
+		}, "C2"},
+		{"last 7 days", "C1:C3", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeTimePeriod)
+			r.X().TimePeriodAttr = sml.ST_TimePeriodLast7Days
+		}, "C1 C2"},
+		{"last month", "C1:C3", func(r ConditionalFormattingRule) {
+			r.SetType(sml.ST_CfTypeTimePeriod)
+			r.X().TimePeriodAttr = sml.ST_TimePeriodLastMonth
+		}, "C3"},
+	}
+	for _, tc := range td {
+		_, sheet := cfSheet()
+		tc.Setup(sheet.AddConditionalFormatting([]string{tc.Range}).AddRule())
+		e := sheet.ConditionalFormatEvaluator()
+		e.SetDate(time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC))
+
+		var got []string
+		for _, c := range cellsOf(tc.Range) {
+			res, err := e.Evaluate(c)
+			if err != nil {
+				t.Fatalf("%s: error evaluating %s: %s", tc.Name, c, err)
+			}
+			if len(res.Rules) > 0 {
+				got = append(got, c)
+			}
+		}
+		if strings.Join(got, " ") != tc.Exp {
+			t.Errorf("%s: expected %s to match, got %s", tc.Name, tc.Exp, strings.Join(got, " "))
+		}
+	}
+}
+
+func TestConditionalFormatScales(t *testing.T) {
+	_, sheet := cfSheet()
+	cs := sheet.AddConditionalFormatting([]string{"A1:A10"}).AddRule().SetColorScale()
+	cs.AddFormatValue(sml.ST_CfvoTypeNum, "0")
+	cs.AddGradientStop(color.RGB(0xFF, 0, 0))
+	cs.AddFormatValue(sml.ST_CfvoTypeNum, "10")
+	cs.AddGradientStop(color.RGB(0, 0, 0xFF))
+
+	_, bars := cfSheet()
+	db := bars.AddConditionalFormatting([]string{"A1:A10"}).AddRule().SetDataBar()
+	db.AddFormatValue(sml.ST_CfvoTypeMin, "0")
+	db.AddFormatValue(sml.ST_CfvoTypeMax, "0")
+	db.SetShowValue(false)
+
+	_, icons := cfSheet()
+	is := icons.AddConditionalFormatting([]string{"A1:A10"}).AddRule().SetIcons()
+	is.AddFormatValue(sml.ST_CfvoTypePercent, "0")
+	is.AddFormatValue(sml.ST_CfvoTypePercent, "33")
+	is.AddFormatValue(sml.ST_CfvoTypePercent, "67")
+
+	for _, tc := range []struct {
+		Cell  string
+		Color string
+	}{{"A5", "FF800080"}, {"A10", "FF0000FF"}} {
+		res, err := sheet.ConditionalFormatEvaluator().Evaluate(tc.Cell)
+		if err != nil || res.Color == nil || *res.Color.RgbAttr != tc.Color {
+			t.Errorf("expected %s to be colored %s, got %v %v", tc.Cell, tc.Color, res.Color, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		Cell   string
+		Length float64
+	}{{"A1", 0.1}, {"A10", 0.9}, {"A4", 0.1 + 0.8/3}} {
+		res, err := bars.ConditionalFormatEvaluator().Evaluate(tc.Cell)
+		if err != nil || res.DataBar == nil || math.Abs(res.DataBarLength-tc.Length) > 1e-9 || !res.HideValue {
+			t.Errorf("expected %s to have a bar of %v without value, got %v %v", tc.Cell, tc.Length, res.DataBarLength, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		Cell string
+		Icon int
+	}{{"A1", 0}, {"A3", 0}, {"A4", 1}, {"A7", 1}, {"A8", 2}} {
+		res, err := icons.ConditionalFormatEvaluator().Evaluate(tc.Cell)
+		if err != nil || res.IconSet != sml.ST_IconSetType3TrafficLights1 || res.Icon != tc.Icon {
+			t.Errorf("expected %s to have icon %d, got %d %v", tc.Cell, tc.Icon, res.Icon, err)
+		}
+	}
+}
+
+func TestConditionalFormatStyles(t *testing.T) {
+	wb, sheet := cfSheet()
+	bold := wb.StyleSheet.AddDifferentialStyle()
+	bold.X().Font = sml.NewCT_Font()
+	bold.X().Font.B = []*sml.CT_BooleanProperty{sml.NewCT_BooleanProperty()}
+	italic := wb.StyleSheet.AddDifferentialStyle()
+	italic.X().Font = sml.NewCT_Font()
+	italic.X().Font.I = []*sml.CT_BooleanProperty{sml.NewCT_BooleanProperty()}
+	italic.X().Font.B = []*sml.CT_BooleanProperty{{ValAttr: unioffice.Bool(false)}}
+
+	cf := sheet.AddConditionalFormatting([]string{"A1:A10"})
+	high := cf.AddRule()
+	high.SetConditionValue("5")
+	high.SetStyle(bold)
+	high.SetPriority(1)
+	all := cf.AddRule()
+	all.SetType(sml.ST_CfTypeExpression)
+	all.SetConditionValue("TRUE")
+	all.SetStyle(italic)
+	all.SetPriority(2)
+
+	res, err := sheet.ConditionalFormatEvaluator().Evaluate("A6")
+	if err != nil {
+		t.Fatalf("error evaluating: %s", err)
+	}
+	if len(res.Rules) != 2 || res.Style == nil || res.Style.Font == nil ||
+		len(res.Style.Font.I) != 1 || len(res.Style.Font.B) != 1 || res.Style.Font.B[0].ValAttr != nil {
+		t.Errorf("expected A6 to be bold and italic")
+	}
+	res, _ = sheet.ConditionalFormatEvaluator().Evaluate("A1")
+	if len(res.Rules) != 1 || res.Style == nil || len(res.Style.Font.B) != 1 || res.Style.Font.B[0].ValAttr == nil {
+		t.Errorf("expected A1 to be italic only")
+	}
+
+	high.X().StopIfTrueAttr = unioffice.Bool(true)
+	res, _ = sheet.ConditionalFormatEvaluator().Evaluate("A6")
+	if len(res.Rules) != 1 || res.Style == nil || len(res.Style.Font.I) != 0 {
+		t.Errorf("expected evaluation to stop after the first rule")
+	}
+}
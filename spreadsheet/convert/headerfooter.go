@@ -0,0 +1,296 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unioffice/common/logger"
+	"github.com/unidoc/unipdf/v3/creator"
+)
+
+// hfChunk is a run of header or footer text with uniform formatting. Field
+// codes such as &P are kept in field and substituted when a page is drawn.
+type hfChunk struct {
+	text  string
+	field byte
+	delta int
+	style style
+}
+
+// hfValues are the values of the header and footer field codes of a page.
+type hfValues struct {
+	page, pages int
+	sheet       string
+	file, path  string
+	time        time.Time
+}
+
+func (v hfValues) field(code byte, delta int) string {
+	switch code {
+	case 'P':
+		return strconv.Itoa(v.page + delta)
+	case 'N':
+		return strconv.Itoa(v.pages + delta)
+	case 'D':
+		return v.time.Format("1/2/2006")
+	case 'T':
+		return v.time.Format("3:04 PM")
+	case 'A':
+		return v.sheet
+	case 'F':
+		if v.file == "." {
+			return ""
+		}
+		return v.file
+	case 'Z':
+		if v.path == "" {
+			return ""
+		}
+		return filepath.Dir(v.path) + string(filepath.Separator)
+	}
+	return ""
+}
+
+// parseHeaderFooter splits a header or footer definition into its left,
+// center and right sections. Text before the first section code belongs to
+// the center section. Formatting codes apply until the end of the section.
+func parseHeaderFooter(s string, base style) [3][]hfChunk {
+	var sections [3][]hfChunk
+	sec := 1
+	st := base
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			sections[sec] = append(sections[sec], hfChunk{text: text.String(), style: st})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '&' || i+1 == len(s) {
+			text.WriteByte(s[i])
+			continue
+		}
+		i++
+		code := s[i]
+		switch {
+		case code == '&':
+			text.WriteByte('&')
+		case code == 'L' || code == 'C' || code == 'R':
+			flush()
+			sec = strings.IndexByte("LCR", code)
+			st = base
+		case strings.IndexByte("PNDTAFZG", code) >= 0:
+			flush()
+			ch := hfChunk{field: code, style: st}
+			if (code == 'P' || code == 'N') && i+2 < len(s) && (s[i+1] == '+' || s[i+1] == '-') {
+				j := i + 2
+				for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+					j++
+				}
+				if j > i+2 {
+					ch.delta, _ = strconv.Atoi(s[i+2 : j])
+					if s[i+1] == '-' {
+						ch.delta = -ch.delta
+					}
+					i = j - 1
+				}
+			}
+			// pictures (&G) are not supported
+			if code != 'G' {
+				sections[sec] = append(sections[sec], ch)
+			}
+		case code == 'B':
+			flush()
+			st._gbdf = toggled(st._gbdf)
+		case code == 'I':
+			flush()
+			st._fgf = toggled(st._fgf)
+		case code == 'U' || code == 'E':
+			flush()
+			st._aec = toggled(st._aec)
+		case code == 'X':
+			flush()
+			st._bggd = toggled(st._bggd)
+			st._fgad = nil
+		case code == 'Y':
+			flush()
+			st._fgad = toggled(st._fgad)
+			st._bggd = nil
+		case code == '"':
+			flush()
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				i = len(s)
+				break
+			}
+			applyHFFont(&st, s[i+1:i+1+end])
+			i += end + 1
+		case code >= '0' && code <= '9':
+			flush()
+			j := i
+			for j < len(s) && j-i < 3 && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			size, _ := strconv.ParseFloat(s[i:j], 64)
+			if size > 0 {
+				st._fddg = &size
+			}
+			i = j - 1
+		case code == 'K':
+			flush()
+			if i+6 < len(s) {
+				hex := s[i+1 : i+7]
+				if _, err := strconv.ParseUint(hex, 16, 32); err == nil {
+					clr := "#" + hex
+					st._dbe = &clr
+				}
+				// theme colors use the same length, e.g. &K01+000
+				i += 6
+			}
+		default:
+			// strikethrough, outline, shadow and unknown codes are not rendered
+			flush()
+		}
+	}
+	flush()
+	return sections
+}
+
+func toggled(b *bool) *bool {
+	v := !_fbac(b)
+	return &v
+}
+
+// applyHFFont applies a font code such as &"Arial,Bold Italic". A font name
+// of "-" keeps the current font.
+func applyHFFont(st *style, spec string) {
+	name, fontStyle := spec, ""
+	if i := strings.IndexByte(spec, ','); i >= 0 {
+		name, fontStyle = spec[:i], strings.ToLower(spec[i+1:])
+	}
+	if name = strings.TrimSpace(name); name != "" && name != "-" {
+		st._adff = &name
+	}
+	if fontStyle != "" {
+		bold := strings.Contains(fontStyle, "bold")
+		italic := strings.Contains(fontStyle, "italic") || strings.Contains(fontStyle, "oblique")
+		st._gbdf, st._fgf = &bold, &italic
+	}
+}
+
+// drawHeaderFooter draws the header and footer of a page.
+func (l *sheetLayout) drawHeaderFooter(first bool, v hfValues) {
+	hf := l.sheet.X().HeaderFooter
+	if hf == nil {
+		return
+	}
+	header, footer := hf.OddHeader, hf.OddFooter
+	switch {
+	case first && _fbac(hf.DifferentFirstAttr):
+		header, footer = hf.FirstHeader, hf.FirstFooter
+	case v.page%2 == 0 && _fbac(hf.DifferentOddEvenAttr):
+		header, footer = hf.EvenHeader, hf.EvenFooter
+	}
+	if header != nil {
+		l.drawHeaderFooterText(*header, v, true)
+	}
+	if footer != nil {
+		l.drawHeaderFooterText(*footer, v, false)
+	}
+}
+
+// drawHeaderFooterText draws the sections of a header below the header
+// margin or of a footer above the footer margin.
+func (l *sheetLayout) drawHeaderFooterText(text string, v hfValues, header bool) {
+	c := l.ctx._gdbe
+	base := style{}
+	if st := l.ctx.getStyle(new(uint32)); st != nil {
+		base = style{_adff: st._adff, _fddg: st._fddg, _dbe: st._dbe}
+	}
+	for sec, chunks := range parseHeaderFooter(text, base) {
+		if len(chunks) == 0 {
+			continue
+		}
+		paras := l.headerFooterLines(chunks, v)
+		height := 0.0
+		for _, p := range paras {
+			height += p.Height()
+		}
+		y := l.headerMargin
+		if !header {
+			y = l.pageSize[1] - l.footerMargin - height
+		}
+		for _, p := range paras {
+			w := p.Width()
+			x := l.left
+			switch sec {
+			case 1:
+				x = (l.pageSize[0] - w) / 2
+			case 2:
+				x = l.pageSize[0] - l.right - w
+			}
+			p.SetPos(x, y)
+			if err := c.Draw(p); err != nil {
+				logger.Log.Debug("Cannot draw a header or footer: %s", err)
+			}
+			y += p.Height()
+		}
+	}
+}
+
+// headerFooterLines substitutes the field codes of a section and splits it
+// into one paragraph per line.
+func (l *sheetLayout) headerFooterLines(chunks []hfChunk, v hfValues) []*creator.StyledParagraph {
+	c := l.ctx._gdbe
+	var paras []*creator.StyledParagraph
+	var p *creator.StyledParagraph
+	var last *creator.TextStyle
+	empty := true
+	finish := func() {
+		if p == nil {
+			return
+		}
+		if empty && last != nil {
+			// blank lines keep the height of the current font
+			p.Append(" ").Style = *last
+		}
+		paras = append(paras, p)
+		p = nil
+	}
+	start := func() {
+		p = c.NewStyledParagraph()
+		p.SetEnableWrap(false)
+		empty = true
+	}
+	start()
+	for i := range chunks {
+		ch := &chunks[i]
+		text := ch.text
+		if ch.field != 0 {
+			text = v.field(ch.field, ch.delta)
+		}
+		ts := l.ctx.makeTextStyleFromCellStyle(&ch.style)
+		last = ts
+		for j, part := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n") {
+			if j > 0 {
+				finish()
+				start()
+			}
+			if part != "" {
+				p.Append(part).Style = *ts
+				empty = false
+			}
+		}
+	}
+	finish()
+	return paras
+}
@@ -0,0 +1,461 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// HTMLOptions contains the options for converting a sheet to HTML.
+type HTMLOptions = convertutils.HTMLOptions
+
+// ConvertToHTML writes the sheet to w as an HTML document containing a single
+// table. This package is beta, breaking changes can take place.
+func ConvertToHTML(s *spreadsheet.Sheet, w io.Writer) error {
+	return ConvertToHTMLWithOptions(s, w, nil)
+}
+
+// ConvertToHTMLWithOptions writes the sheet to w as an HTML table honoring
+// merged cells, column widths, row heights, fonts, fills, borders, alignment
+// and number formats.
+func ConvertToHTMLWithOptions(s *spreadsheet.Sheet, w io.Writer, opts *HTMLOptions) error {
+	hw := newSheetHTMLWriter(s.Workbook(), opts)
+	if hw.Options.Title == "" {
+		hw.Options.Title = s.Name()
+	}
+	hw.writeSheet(s)
+	return hw.Flush(w)
+}
+
+// ConvertWorkbookToHTML writes every sheet of the workbook to w, each sheet as
+// a separate section with a heading and a table.
+func ConvertWorkbookToHTML(wb *spreadsheet.Workbook, w io.Writer, opts *HTMLOptions) error {
+	hw := newSheetHTMLWriter(wb, opts)
+	if hw.Options.Title == "" {
+		hw.Options.Title = wb.CoreProperties.Title()
+	}
+	for _, s := range wb.Sheets() {
+		s := s
+		hw.Printf("<section class=\"sheet\">\n<h2>")
+		hw.WriteText(s.Name())
+		hw.WriteString("</h2>\n")
+		hw.writeSheet(&s)
+		hw.WriteString("</section>\n")
+	}
+	return hw.Flush(w)
+}
+
+type sheetHTMLWriter struct {
+	*convertutils.HTMLWriter
+	wb     *spreadsheet.Workbook
+	ctx    *convertContext
+	styles map[uint32]string
+}
+
+func newSheetHTMLWriter(wb *spreadsheet.Workbook, opts *HTMLOptions) *sheetHTMLWriter {
+	o := HTMLOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	hw := &sheetHTMLWriter{
+		HTMLWriter: convertutils.NewHTMLWriter(&o),
+		wb:         wb,
+		ctx:        &convertContext{_gbff: wb},
+		styles:     map[uint32]string{},
+	}
+	hw.AddRule("table.sheet { border-collapse: collapse; table-layout: fixed; font-family: Calibri, sans-serif; font-size: 11pt; }")
+	hw.AddRule("table.sheet td { padding: 0 3px; overflow: hidden; white-space: nowrap; vertical-align: bottom; }")
+	return hw
+}
+
+// htmlMerge describes a merged region anchored at its top-left cell.
+type htmlMerge struct {
+	rowSpan, colSpan int
+}
+
+// colWidthPx converts a column width in characters to pixels the way Excel
+// does for the default font.
+func colWidthPx(w float64) int {
+	return int(w*7 + 5)
+}
+
+func (hw *sheetHTMLWriter) writeSheet(s *spreadsheet.Sheet) {
+	ws := s.X()
+	maxRow, maxCol := uint32(0), uint32(0)
+	rows := map[uint32]spreadsheet.Row{}
+	cells := map[string]spreadsheet.Cell{}
+	for _, r := range s.Rows() {
+		rows[r.RowNumber()] = r
+		if r.RowNumber() > maxRow {
+			maxRow = r.RowNumber()
+		}
+		for _, c := range r.Cells() {
+			cells[c.Reference()] = c
+			if cr, err := reference.ParseCellReference(c.Reference()); err == nil && cr.ColumnIdx+1 > maxCol {
+				maxCol = cr.ColumnIdx + 1
+			}
+		}
+	}
+
+	// anchors maps the top-left cell of a merged region to its span, covered
+	// marks the remaining cells of the region which must not be emitted.
+	anchors := map[string]htmlMerge{}
+	covered := map[string]bool{}
+	for _, mc := range s.MergedCells() {
+		from, to, err := reference.ParseRangeReference(mc.Reference())
+		if err != nil {
+			continue
+		}
+		if to.RowIdx > maxRow {
+			maxRow = to.RowIdx
+		}
+		if to.ColumnIdx+1 > maxCol {
+			maxCol = to.ColumnIdx + 1
+		}
+		for r := from.RowIdx; r <= to.RowIdx; r++ {
+			for c := from.ColumnIdx; c <= to.ColumnIdx; c++ {
+				covered[fmt.Sprintf("%s%d", reference.IndexToColumn(c), r)] = true
+			}
+		}
+		anchor := fmt.Sprintf("%s%d", reference.IndexToColumn(from.ColumnIdx), from.RowIdx)
+		anchors[anchor] = htmlMerge{}
+		delete(covered, anchor)
+	}
+	if maxRow == 0 || maxCol == 0 {
+		hw.WriteString("<table class=\"sheet\"></table>\n")
+		return
+	}
+
+	defaultWidth := 8.43
+	defaultHeight := 15.0
+	if fp := ws.SheetFormatPr; fp != nil {
+		if fp.DefaultColWidthAttr != nil {
+			defaultWidth = *fp.DefaultColWidthAttr
+		} else if fp.BaseColWidthAttr != nil {
+			defaultWidth = float64(*fp.BaseColWidthAttr) + 0.71
+		}
+		if fp.DefaultRowHeightAttr > 0 {
+			defaultHeight = fp.DefaultRowHeightAttr
+		}
+	}
+	widths := make([]float64, maxCol)
+	hiddenCols := make([]bool, maxCol)
+	for i := range widths {
+		widths[i] = defaultWidth
+	}
+	for _, cols := range ws.Cols {
+		for _, col := range cols.Col {
+			for c := col.MinAttr; c <= col.MaxAttr && c <= maxCol; c++ {
+				if c == 0 {
+					continue
+				}
+				if col.WidthAttr != nil {
+					widths[c-1] = *col.WidthAttr
+				}
+				if col.HiddenAttr != nil && *col.HiddenAttr {
+					hiddenCols[c-1] = true
+				}
+			}
+		}
+	}
+	isHiddenRow := func(rowNum uint32) bool {
+		r, ok := rows[rowNum]
+		return ok && r.IsHidden()
+	}
+
+	// compute the visible spans of merged regions, hidden rows and columns
+	// don't count towards the span
+	for _, mc := range s.MergedCells() {
+		from, to, err := reference.ParseRangeReference(mc.Reference())
+		if err != nil {
+			continue
+		}
+		m := htmlMerge{}
+		for r := from.RowIdx; r <= to.RowIdx; r++ {
+			if !isHiddenRow(r) {
+				m.rowSpan++
+			}
+		}
+		for c := from.ColumnIdx; c <= to.ColumnIdx; c++ {
+			if !hiddenCols[c] {
+				m.colSpan++
+			}
+		}
+		anchors[fmt.Sprintf("%s%d", reference.IndexToColumn(from.ColumnIdx), from.RowIdx)] = m
+	}
+
+	hw.WriteString("<table class=\"sheet\">\n<colgroup>")
+	for c := uint32(0); c < maxCol; c++ {
+		if hiddenCols[c] {
+			continue
+		}
+		hw.Printf("<col style=\"width:%dpx\">", colWidthPx(widths[c]))
+	}
+	hw.WriteString("</colgroup>\n")
+	for rowNum := uint32(1); rowNum <= maxRow; rowNum++ {
+		if isHiddenRow(rowNum) {
+			continue
+		}
+		height := defaultHeight
+		if row, ok := rows[rowNum]; ok && row.X().HtAttr != nil {
+			height = *row.X().HtAttr
+		}
+		hw.Printf("<tr style=\"height:%.2fpt\">", height)
+		for c := uint32(0); c < maxCol; c++ {
+			if hiddenCols[c] {
+				continue
+			}
+			ref := fmt.Sprintf("%s%d", reference.IndexToColumn(c), rowNum)
+			if covered[ref] {
+				continue
+			}
+			hw.WriteString("<td")
+			if m, ok := anchors[ref]; ok {
+				if m.rowSpan > 1 {
+					hw.Printf(" rowspan=\"%d\"", m.rowSpan)
+				}
+				if m.colSpan > 1 {
+					hw.Printf(" colspan=\"%d\"", m.colSpan)
+				}
+			}
+			cell, ok := cells[ref]
+			if !ok {
+				hw.WriteString("></td>")
+				continue
+			}
+			if cell.X().SAttr != nil {
+				if class := hw.styleClass(*cell.X().SAttr); class != "" {
+					hw.Printf(" class=\"%s\"", class)
+				}
+			}
+			if cell.IsNumber() && !hw.hasHorizontalAlignment(cell) {
+				hw.WriteString(" style=\"text-align:right\"")
+			}
+			hw.WriteString(">")
+			hw.WriteText(cell.GetFormattedValue())
+			hw.WriteString("</td>")
+		}
+		hw.WriteString("</tr>\n")
+	}
+	hw.WriteString("</table>\n")
+}
+
+func (hw *sheetHTMLWriter) hasHorizontalAlignment(c spreadsheet.Cell) bool {
+	if c.X().SAttr == nil {
+		return false
+	}
+	cs := hw.wb.StyleSheet.GetCellStyle(*c.X().SAttr)
+	if cs.IsEmpty() {
+		return false
+	}
+	a := cs.GetHorizontalAlignment()
+	return a != sml.ST_HorizontalAlignmentUnset && a != sml.ST_HorizontalAlignmentGeneral
+}
+
+// styleClass returns the CSS class for the cell format with the given index.
+func (hw *sheetHTMLWriter) styleClass(idx uint32) string {
+	if class, ok := hw.styles[idx]; ok {
+		return class
+	}
+	cs := hw.wb.StyleSheet.GetCellStyle(idx)
+	class := ""
+	if !cs.IsEmpty() {
+		var decls []string
+		decls = append(decls, hw.fontCSS(cs.GetFont())...)
+		decls = append(decls, hw.fillCSS(cs.GetFill())...)
+		decls = append(decls, hw.borderCSS(cs.GetBorder())...)
+		switch cs.GetHorizontalAlignment() {
+		case sml.ST_HorizontalAlignmentLeft:
+			decls = append(decls, "text-align:left")
+		case sml.ST_HorizontalAlignmentCenter, sml.ST_HorizontalAlignmentCenterContinuous:
+			decls = append(decls, "text-align:center")
+		case sml.ST_HorizontalAlignmentRight:
+			decls = append(decls, "text-align:right")
+		case sml.ST_HorizontalAlignmentJustify, sml.ST_HorizontalAlignmentDistributed:
+			decls = append(decls, "text-align:justify")
+		}
+		switch cs.GetVerticalAlignment() {
+		case sml.ST_VerticalAlignmentTop:
+			decls = append(decls, "vertical-align:top")
+		case sml.ST_VerticalAlignmentCenter, sml.ST_VerticalAlignmentJustify, sml.ST_VerticalAlignmentDistributed:
+			decls = append(decls, "vertical-align:middle")
+		}
+		if cs.Wrapped() {
+			decls = append(decls, "white-space:pre-wrap")
+		}
+		class = hw.Class("s", strings.Join(decls, ";"))
+	}
+	hw.styles[idx] = class
+	return class
+}
+
+func (hw *sheetHTMLWriter) colorCSS(c *sml.CT_Color) string {
+	if c == nil || (c.AutoAttr != nil && *c.AutoAttr) {
+		return ""
+	}
+	if s := hw.ctx.getColorStringFromSmlColor(c); s != nil {
+		return convertutils.CSSColor(*s)
+	}
+	return ""
+}
+
+func (hw *sheetHTMLWriter) fontCSS(f *sml.CT_Font) []string {
+	if f == nil {
+		return nil
+	}
+	var decls []string
+	if len(f.Name) > 0 {
+		if ff := convertutils.CSSFontFamily(f.Name[0].ValAttr); ff != "" {
+			decls = append(decls, "font-family:"+ff)
+		}
+	}
+	if len(f.Sz) > 0 && f.Sz[0].ValAttr > 0 {
+		decls = append(decls, fmt.Sprintf("font-size:%gpt", f.Sz[0].ValAttr))
+	}
+	if len(f.B) > 0 && boolProperty(f.B[0]) {
+		decls = append(decls, "font-weight:bold")
+	}
+	if len(f.I) > 0 && boolProperty(f.I[0]) {
+		decls = append(decls, "font-style:italic")
+	}
+	var deco []string
+	if len(f.U) > 0 && f.U[0].ValAttr != sml.ST_UnderlineValuesNone {
+		deco = append(deco, "underline")
+		if f.U[0].ValAttr == sml.ST_UnderlineValuesDouble || f.U[0].ValAttr == sml.ST_UnderlineValuesDoubleAccounting {
+			deco = append(deco, "double")
+		}
+	}
+	if len(f.Strike) > 0 && boolProperty(f.Strike[0]) {
+		deco = append(deco, "line-through")
+	}
+	if len(deco) > 0 {
+		decls = append(decls, "text-decoration:"+strings.Join(deco, " "))
+	}
+	if len(f.Color) > 0 {
+		if c := hw.colorCSS(f.Color[0]); c != "" {
+			decls = append(decls, "color:"+c)
+		}
+	}
+	if len(f.VertAlign) > 0 {
+		switch f.VertAlign[0].ValAttr {
+		case sharedTypes.ST_VerticalAlignRunSuperscript:
+			decls = append(decls, "vertical-align:super")
+		case sharedTypes.ST_VerticalAlignRunSubscript:
+			decls = append(decls, "vertical-align:sub")
+		}
+	}
+	return decls
+}
+
+func boolProperty(b *sml.CT_BooleanProperty) bool {
+	return b != nil && (b.ValAttr == nil || *b.ValAttr)
+}
+
+func (hw *sheetHTMLWriter) fillCSS(f *sml.CT_Fill) []string {
+	if f == nil {
+		return nil
+	}
+	if pf := f.PatternFill; pf != nil {
+		switch pf.PatternTypeAttr {
+		case sml.ST_PatternTypeUnset, sml.ST_PatternTypeNone:
+			return nil
+		case sml.ST_PatternTypeSolid:
+			if c := hw.colorCSS(pf.FgColor); c != "" {
+				return []string{"background-color:" + c}
+			}
+		default:
+			// patterns can't be expressed in plain CSS, so approximate them
+			// with the foreground color if present and the background
+			// color otherwise
+			if c := hw.colorCSS(pf.FgColor); c != "" {
+				return []string{"background-color:" + c}
+			}
+			if c := hw.colorCSS(pf.BgColor); c != "" {
+				return []string{"background-color:" + c}
+			}
+		}
+		return nil
+	}
+	if gf := f.GradientFill; gf != nil && len(gf.Stop) > 0 {
+		var stops []string
+		for _, st := range gf.Stop {
+			c := hw.colorCSS(st.Color)
+			if c == "" {
+				continue
+			}
+			stops = append(stops, fmt.Sprintf("%s %g%%", c, st.PositionAttr*100))
+		}
+		if len(stops) == 0 {
+			return nil
+		}
+		if gf.TypeAttr == sml.ST_GradientTypePath {
+			return []string{"background:radial-gradient(" + strings.Join(stops, ",") + ")"}
+		}
+		deg := 0.0
+		if gf.DegreeAttr != nil {
+			deg = *gf.DegreeAttr
+		}
+		// OOXML measures the angle clockwise from the left edge, CSS from the top
+		return []string{fmt.Sprintf("background:linear-gradient(%gdeg,%s)", deg+90, strings.Join(stops, ","))}
+	}
+	return nil
+}
+
+func (hw *sheetHTMLWriter) borderCSS(b *sml.CT_Border) []string {
+	if b == nil {
+		return nil
+	}
+	var decls []string
+	add := func(side string, pr *sml.CT_BorderPr) {
+		if pr == nil {
+			return
+		}
+		style := borderStyleCSS(pr.StyleAttr)
+		if style == "" {
+			return
+		}
+		c := hw.colorCSS(pr.Color)
+		if c == "" {
+			c = "#000000"
+		}
+		decls = append(decls, fmt.Sprintf("border-%s:%s %s", side, style, c))
+	}
+	add("left", b.Left)
+	add("right", b.Right)
+	add("top", b.Top)
+	add("bottom", b.Bottom)
+	return decls
+}
+
+func borderStyleCSS(s sml.ST_BorderStyle) string {
+	switch s {
+	case sml.ST_BorderStyleThin:
+		return "1px solid"
+	case sml.ST_BorderStyleMedium:
+		return "2px solid"
+	case sml.ST_BorderStyleThick:
+		return "3px solid"
+	case sml.ST_BorderStyleDouble:
+		return "3px double"
+	case sml.ST_BorderStyleDotted, sml.ST_BorderStyleHair:
+		return "1px dotted"
+	case sml.ST_BorderStyleDashed, sml.ST_BorderStyleDashDot, sml.ST_BorderStyleDashDotDot:
+		return "1px dashed"
+	case sml.ST_BorderStyleMediumDashed, sml.ST_BorderStyleMediumDashDot, sml.ST_BorderStyleMediumDashDotDot, sml.ST_BorderStyleSlantDashDot:
+		return "2px dashed"
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice/spreadsheet"
+)
+
+func TestConvertToHTML(t *testing.T) {
+	wb := spreadsheet.New()
+	sheet := wb.AddSheet()
+	sheet.SetName("Prices")
+	sheet.Cell("A1").SetString("Item <b> & co")
+	sheet.Cell("B1").SetNumber(1234.5)
+	pct := wb.StyleSheet.AddCellStyle()
+	pct.SetNumberFormat("0.0%")
+	sheet.Cell("B1").SetStyle(pct)
+	sheet.Cell("A2").SetString("merged")
+	sheet.AddMergedCells("A2", "C3")
+	sheet.Cell("A4").SetString("hidden row")
+	sheet.Row(4).SetHidden(true)
+	sheet.Cell("D1").SetString("hidden column")
+	sheet.Column(4).SetHidden(true)
+	sheet.Cell("E1").SetString("last")
+	font := wb.StyleSheet.AddFont()
+	font.SetBold(true)
+	bold := wb.StyleSheet.AddCellStyle()
+	bold.SetFont(font)
+	sheet.Cell("E1").SetStyle(bold)
+
+	buf := bytes.Buffer{}
+	if err := ConvertToHTML(&sheet, &buf); err != nil {
+		t.Fatalf("error converting to HTML: %s", err)
+	}
+	out := buf.String()
+	td := []struct {
+		Name string
+		Exp  string
+		Want bool
+	}{
+		{"title", "<title>Prices</title>", true},
+		{"escaped text", "Item &lt;b&gt; &amp; co", true},
+		{"raw text", "<b> &", false},
+		{"number format", ">123450.0%</td>", true},
+		{"numbers aligned right", "style=\"text-align:right\"", true},
+		{"merged cell", "<td rowspan=\"2\" colspan=\"3\">merged</td>", true},
+		{"hidden row", "hidden row", false},
+		{"hidden column", "hidden column", false},
+		{"bold", "font-weight:bold", true},
+		{"last cell", ">last</td>", true},
+	}
+	for _, tc := range td {
+		if got := strings.Contains(out, tc.Exp); got != tc.Want {
+			t.Errorf("%s: expected %q to be in the output: %v\n%s", tc.Name, tc.Exp, tc.Want, out)
+		}
+	}
+	if n := strings.Count(out, "<col "); n != 4 {
+		t.Errorf("expected 4 visible columns, got %d", n)
+	}
+}
+
+func TestConvertWorkbookToHTML(t *testing.T) {
+	wb := spreadsheet.New()
+	wb.CoreProperties.SetTitle("Report")
+	for _, name := range []string{"First", "Q&A"} {
+		sheet := wb.AddSheet()
+		sheet.SetName(name)
+		sheet.Cell("A1").SetString("on " + name)
+	}
+	empty := wb.AddSheet()
+	empty.SetName("Empty")
+
+	buf := bytes.Buffer{}
+	if err := ConvertWorkbookToHTML(wb, &buf, nil); err != nil {
+		t.Fatalf("error converting to HTML: %s", err)
+	}
+	out := buf.String()
+	for _, exp := range []string{"<title>Report</title>", "<h2>First</h2>", "<h2>Q&amp;A</h2>", ">on Q&amp;A</td>",
+		"<h2>Empty</h2>\n<table class=\"sheet\"></table>"} {
+		if !strings.Contains(out, exp) {
+			t.Errorf("expected %q in the output:\n%s", exp, out)
+		}
+	}
+	if n := strings.Count(out, "<section class=\"sheet\">"); n != 3 {
+		t.Errorf("expected a section per sheet, got %d", n)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"errors"
+	"image"
+
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/spreadsheet"
+	"github.com/unidoc/unipdf/v3/creator"
+)
+
+// ImageOptions contains the options for rendering sheets to images.
+type ImageOptions = convertutils.ImageOptions
+
+// ConvertToImages renders the pages of a sheet to images, one image per page
+// as produced by ConvertToPdf. The images can be encoded with image/png or
+// image/jpeg. This package is beta, breaking changes can take place.
+func ConvertToImages(s *spreadsheet.Sheet, opts *ImageOptions) ([]image.Image, error) {
+	c := ConvertToPdf(s)
+	if c == nil {
+		return nil, errors.New("sheet is empty")
+	}
+	return convertutils.RenderCreatorToImages(c, opts)
+}
+
+// ConvertRangeToImage renders a range of a sheet such as "B2:F20" to an image
+// that is exactly as large as the range. Cells, merged regions, borders,
+// fills, pictures and charts are drawn as on a printed page, the page setup,
+// print titles and headers and footers are ignored. Grid lines and headings
+// are drawn if the sheet prints them.
+func ConvertRangeToImage(s *spreadsheet.Sheet, ref string, opts *ImageOptions) (image.Image, error) {
+	if s.X() == nil {
+		return nil, errors.New("sheet is empty")
+	}
+	refs := parsePrintRefs(ref)
+	if len(refs) != 1 || refs[0].wholeRows || refs[0].wholeCols {
+		return nil, errors.New("invalid range reference: " + ref)
+	}
+	area := refs[0]
+
+	wb := s.Workbook()
+	var theme *dml.Theme
+	if len(wb.Themes()) > 0 {
+		theme = wb.Themes()[0]
+	}
+	c := creator.New()
+	ctx := &convertContext{_gdbe: c, _fege: s, _gbff: wb, _edge: theme, _bda: &wb.StyleSheet}
+	l := newSheetLayout(ctx, sheetIndex(s), &PdfOptions{IgnorePrintArea: true})
+	if area.rows.to >= len(l.rowHeights) || area.cols.to >= len(l.colWidths) {
+		l.measureGrid(maxInt(area.rows.to+1, len(l.rowHeights)), maxInt(area.cols.to+1, len(l.colWidths)))
+	}
+	l.hCentered, l.vCentered = false, false
+
+	w := l.colX[area.cols.to+1] - l.colX[area.cols.from] + l.headingW
+	h := l.rowY[area.rows.to+1] - l.rowY[area.rows.from] + l.headingH
+	if w <= 0 || h <= 0 {
+		return nil, errors.New("range has no visible cells: " + ref)
+	}
+	c.SetPageSize(creator.PageSize{w, h})
+	c.SetPageMargins(0, 0, 0, 0)
+	c.NewPage()
+	blk := l.gridBlock(&printPage{rows: area.rows, cols: area.cols}, w, h, false)
+	blk.SetPos(0, 0)
+	if err := c.Draw(blk); err != nil {
+		return nil, err
+	}
+	images, err := convertutils.RenderCreatorToImages(c, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, errors.New("range has no content: " + ref)
+	}
+	return images[0], nil
+}
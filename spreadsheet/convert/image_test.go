@@ -0,0 +1,29 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/unidoc/unioffice/spreadsheet"
+)
+
+func TestConvertRangeToImageErrors(t *testing.T) {
+	wb := spreadsheet.New()
+	sheet := wb.AddSheet()
+	sheet.Cell("A1").SetString("a")
+	sheet.Row(2).SetHidden(true)
+	for _, ref := range []string{"", "A:B", "1:2", "A1:B2,C3:D4", "Sheet1!#REF!", "A2:C2"} {
+		if _, err := ConvertRangeToImage(&sheet, ref, nil); err == nil {
+			t.Errorf("expected an error rendering the range %q", ref)
+		}
+	}
+	if _, err := ConvertRangeToImage(&spreadsheet.Sheet{}, "A1", nil); err == nil {
+		t.Errorf("expected an error rendering an empty sheet")
+	}
+}
@@ -0,0 +1,1151 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"image"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unioffice/common/logger"
+	"github.com/unidoc/unioffice/internal/convertutils"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+	"github.com/unidoc/unipdf/v3/creator"
+)
+
+// PdfOptions contains the options for converting a workbook to PDF.
+type PdfOptions struct {
+	// Sheets are the names of the sheets to convert, in output order. If
+	// empty, all visible sheets are converted.
+	Sheets []string
+
+	// IgnorePrintArea prints the used range of each sheet even if a print
+	// area is defined.
+	IgnorePrintArea bool
+
+	// FileName is the path of the workbook file, substituted for the &F and
+	// &Z header and footer codes.
+	FileName string
+
+	// Time is substituted for the &D and &T header and footer codes, the
+	// current time is used if it is zero.
+	Time time.Time
+}
+
+// sheetIndex returns the position of a sheet in its workbook.
+func sheetIndex(s *spreadsheet.Sheet) int {
+	for i, ws := range s.Workbook().Sheets() {
+		if ws.X() == s.X() {
+			return i
+		}
+	}
+	return 0
+}
+
+// ConvertWorkbookToPdf converts all visible sheets of a workbook to a single
+// PDF file. Each sheet is paginated according to its page setup. This
+// package is beta, breaking changes can take place.
+func ConvertWorkbookToPdf(wb *spreadsheet.Workbook) *creator.Creator {
+	return ConvertWorkbookToPdfWithOptions(wb, nil)
+}
+
+// ConvertWorkbookToPdfWithOptions converts the sheets of a workbook to a
+// single PDF file. Each sheet is paginated the way Excel prints it: the print
+// area, paper size, orientation, margins, scaling or fit to page settings,
+// manual page breaks and page order of the sheet are respected, print title
+// rows and columns are repeated on every page and headers and footers are
+// drawn with their formatting and field codes. Page numbers continue across
+// sheets unless a sheet sets its own first page number. This package is beta,
+// breaking changes can take place.
+func ConvertWorkbookToPdfWithOptions(wb *spreadsheet.Workbook, opts *PdfOptions) *creator.Creator {
+	o := PdfOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Time.IsZero() {
+		o.Time = time.Now()
+	}
+	all := wb.Sheets()
+	var sheets []*spreadsheet.Sheet
+	var indexes []int
+	if len(o.Sheets) > 0 {
+		for _, name := range o.Sheets {
+			found := false
+			for i := range all {
+				if all[i].Name() == name {
+					sheets = append(sheets, &all[i])
+					indexes = append(indexes, i)
+					found = true
+					break
+				}
+			}
+			if !found {
+				logger.Log.Debug("sheet %s not found", name)
+			}
+		}
+	} else {
+		var states []*sml.CT_Sheet
+		if wb.X().Sheets != nil {
+			states = wb.X().Sheets.Sheet
+		}
+		for i := range all {
+			if i < len(states) && (states[i].StateAttr == sml.ST_SheetStateHidden || states[i].StateAttr == sml.ST_SheetStateVeryHidden) {
+				continue
+			}
+			sheets = append(sheets, &all[i])
+			indexes = append(indexes, i)
+		}
+	}
+	return convertSheetsToPdf(wb, sheets, indexes, &o)
+}
+
+func convertSheetsToPdf(wb *spreadsheet.Workbook, sheets []*spreadsheet.Sheet, indexes []int, o *PdfOptions) *creator.Creator {
+	c := creator.New()
+	var theme *dml.Theme
+	if len(wb.Themes()) > 0 {
+		theme = wb.Themes()[0]
+	}
+	var layouts []*sheetLayout
+	total := 0
+	for i, s := range sheets {
+		ctx := &convertContext{_gdbe: c, _fege: s, _gbff: wb, _edge: theme, _bda: &wb.StyleSheet}
+		l := newSheetLayout(ctx, indexes[i], o)
+		l.paginate()
+		layouts = append(layouts, l)
+		total += len(l.pages)
+	}
+	number := 1
+	for _, l := range layouts {
+		if ps := l.sheet.X().PageSetup; ps != nil && ps.UseFirstPageNumberAttr != nil && *ps.UseFirstPageNumberAttr && ps.FirstPageNumberAttr != nil {
+			number = int(*ps.FirstPageNumberAttr)
+		}
+		for i, p := range l.pages {
+			l.drawPage(p, i == 0, hfValues{page: number, pages: total, sheet: l.sheet.Name(), file: filepath.Base(o.FileName), path: o.FileName, time: o.Time})
+			number++
+		}
+	}
+	if total == 0 {
+		c.NewPage()
+	}
+	return c
+}
+
+// cellKey is the zero based row and column index of a cell.
+type cellKey struct {
+	row, col int
+}
+
+// printSpan is an inclusive range of zero based row or column indexes.
+type printSpan struct {
+	from, to int
+}
+
+// printRef is a print area or print titles reference. References to entire
+// rows or columns leave the other dimension open, it is clipped to the used
+// range of the sheet.
+type printRef struct {
+	rows, cols printSpan
+	wholeRows  bool
+	wholeCols  bool
+}
+
+// printCell is a cell of the sheet together with its resolved formatting.
+type printCell struct {
+	cell  spreadsheet.Cell
+	style *style
+	fill  creator.Color
+	empty bool
+
+	// text marks string values, which overflow into empty neighbors
+	text bool
+}
+
+// printPage is a page of a sheet. It shows the rows and columns of a band of
+// a print area, preceded by the print titles if the band starts after them.
+type printPage struct {
+	rows, cols       printSpan
+	rowBand, colBand int
+	area             int
+}
+
+// sheetLayout holds the measured grid and pagination of a sheet.
+type sheetLayout struct {
+	ctx   *convertContext
+	sheet *spreadsheet.Sheet
+	index int
+	opts  *PdfOptions
+
+	pageSize                   creator.PageSize
+	left, right, top, bottom   float64
+	headerMargin, footerMargin float64
+
+	// colWidths and rowHeights are in points, zero for hidden columns and
+	// rows, colX and rowY are their offsets from the sheet origin
+	colWidths, rowHeights []float64
+	colX, rowY            []float64
+
+	cells   map[cellKey]*printCell
+	merges  map[cellKey]cellKey
+	covered map[cellKey]bool
+	images  map[*anchor]image.Image
+
+	areas                 []printRef
+	explicitArea          bool
+	titleRows, titleCols  *printSpan
+	gridLines, headings   bool
+	hCentered, vCentered  bool
+	headingW, headingH    float64
+	scale                 float64
+	pages                 []*printPage
+	rowBreaks, colBreaks  map[int]bool
+	overThenDown, fitPage bool
+}
+
+func newSheetLayout(ctx *convertContext, index int, o *PdfOptions) *sheetLayout {
+	l := &sheetLayout{
+		ctx:     ctx,
+		sheet:   ctx._fege,
+		index:   index,
+		opts:    o,
+		cells:   map[cellKey]*printCell{},
+		merges:  map[cellKey]cellKey{},
+		covered: map[cellKey]bool{},
+		images:  map[*anchor]image.Image{},
+		scale:   1,
+	}
+	l.setupPage()
+	l.measure()
+	return l
+}
+
+// setupPage reads the paper size, orientation, margins and print options.
+func (l *sheetLayout) setupPage() {
+	ws := l.sheet.X()
+	size := _dgdc[1]
+	landscape := false
+	if ps := ws.PageSetup; ps != nil {
+		if ps.PaperSizeAttr != nil {
+			if s, ok := _dgdc[*ps.PaperSizeAttr]; ok {
+				size = s
+			}
+		}
+		if ps.PaperWidthAttr != nil && ps.PaperHeightAttr != nil {
+			w, h := parsePaperDimension(*ps.PaperWidthAttr), parsePaperDimension(*ps.PaperHeightAttr)
+			if w > 0 && h > 0 {
+				size = creator.PageSize{w, h}
+			}
+		}
+		landscape = ps.OrientationAttr == sml.ST_OrientationLandscape
+		l.overThenDown = ps.PageOrderAttr == sml.ST_PageOrderOverThenDown
+	}
+	if landscape && size[0] < size[1] {
+		size[0], size[1] = size[1], size[0]
+	}
+	l.pageSize = size
+
+	l.left, l.right, l.top, l.bottom = 0.7, 0.7, 0.75, 0.75
+	l.headerMargin, l.footerMargin = 0.3, 0.3
+	if pm := ws.PageMargins; pm != nil {
+		l.left, l.right, l.top, l.bottom = pm.LeftAttr, pm.RightAttr, pm.TopAttr, pm.BottomAttr
+		l.headerMargin, l.footerMargin = pm.HeaderAttr, pm.FooterAttr
+	}
+	for _, m := range []*float64{&l.left, &l.right, &l.top, &l.bottom, &l.headerMargin, &l.footerMargin} {
+		*m *= measurement.Inch
+	}
+
+	if po := ws.PrintOptions; po != nil {
+		l.gridLines = _fbac(po.GridLinesAttr)
+		l.headings = _fbac(po.HeadingsAttr)
+		l.hCentered = _fbac(po.HorizontalCenteredAttr)
+		l.vCentered = _fbac(po.VerticalCenteredAttr)
+	}
+	if pr := ws.SheetPr; pr != nil && pr.PageSetUpPr != nil {
+		l.fitPage = _fbac(pr.PageSetUpPr.FitToPageAttr)
+	}
+	l.rowBreaks = manualBreaks(ws.RowBreaks)
+	l.colBreaks = manualBreaks(ws.ColBreaks)
+}
+
+// parsePaperDimension parses a paper width or height such as "210mm" or
+// "8.5in" to points.
+func parsePaperDimension(s string) float64 {
+	s = strings.TrimSpace(s)
+	units := []struct {
+		suffix string
+		factor float64
+	}{{"mm", measurement.Millimeter}, {"cm", measurement.Centimeter}, {"in", measurement.Inch}, {"pt", measurement.Point}}
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), 64)
+			if err != nil {
+				return 0
+			}
+			return f * u.factor
+		}
+	}
+	return 0
+}
+
+// manualBreaks returns the indexes of the first rows or columns after the
+// manual page breaks.
+func manualBreaks(pb *sml.CT_PageBreak) map[int]bool {
+	breaks := map[int]bool{}
+	if pb == nil {
+		return breaks
+	}
+	for _, b := range pb.Brk {
+		if b.IdAttr != nil && (b.ManAttr == nil || *b.ManAttr) {
+			breaks[int(*b.IdAttr)] = true
+		}
+	}
+	return breaks
+}
+
+// measure collects the cells, merged regions and drawings of the sheet, the
+// print area and titles and the sizes of all rows and columns they cover.
+func (l *sheetLayout) measure() {
+	usedRows, usedCols := 0, 0
+	extend := func(row, col int) {
+		if row+1 > usedRows {
+			usedRows = row + 1
+		}
+		if col+1 > usedCols {
+			usedCols = col + 1
+		}
+	}
+
+	for _, r := range l.sheet.Rows() {
+		row := r.X()
+		var rowStyle *uint32
+		if _fbac(row.CustomFormatAttr) {
+			rowStyle = row.SAttr
+		}
+		for _, c := range r.Cells() {
+			ref, err := reference.ParseCellReference(c.Reference())
+			if err != nil {
+				logger.Log.Debug("Cannot parse a reference: %s", err)
+				continue
+			}
+			k := cellKey{int(ref.RowIdx) - 1, int(ref.ColumnIdx)}
+			idx := c.X().SAttr
+			if idx == nil {
+				idx = rowStyle
+			}
+			if idx == nil {
+				idx = l.columnStyle(k.col)
+			}
+			pc := &printCell{cell: c, style: l.ctx.getStyle(idx), fill: l.fillColor(idx)}
+			pc.empty = c.GetFormattedValue() == ""
+			switch c.X().TAttr {
+			case sml.ST_CellTypeS, sml.ST_CellTypeStr, sml.ST_CellTypeInlineStr:
+				pc.text = true
+			}
+			if pc.empty && pc.fill == nil && pc.style == nil {
+				continue
+			}
+			l.cells[k] = pc
+			if !pc.empty || pc.fill != nil {
+				extend(k.row, k.col)
+			}
+		}
+	}
+
+	for _, mc := range l.sheet.MergedCells() {
+		from, to, err := reference.ParseRangeReference(mc.Reference())
+		if err != nil {
+			logger.Log.Debug("error parsing merged cell: %s", err)
+			continue
+		}
+		tl := cellKey{int(from.RowIdx) - 1, int(from.ColumnIdx)}
+		br := cellKey{int(to.RowIdx) - 1, int(to.ColumnIdx)}
+		l.merges[tl] = br
+		for r := tl.row; r <= br.row; r++ {
+			for c := tl.col; c <= br.col; c++ {
+				if r != tl.row || c != tl.col {
+					l.covered[cellKey{r, c}] = true
+				}
+			}
+		}
+		if _, ok := l.cells[tl]; ok {
+			extend(br.row, br.col)
+		}
+	}
+
+	l.ctx.makeAnchors()
+	for _, a := range l.ctx._bbcb {
+		extend(a._cdb, a._effc)
+	}
+
+	if !l.opts.IgnorePrintArea {
+		l.areas = parsePrintRefs(l.definedName("_xlnm.Print_Area"))
+		l.explicitArea = len(l.areas) > 0
+	}
+	for _, ref := range parsePrintRefs(l.definedName("_xlnm.Print_Titles")) {
+		ref := ref
+		switch {
+		case ref.wholeRows:
+			l.titleRows = &ref.rows
+		case ref.wholeCols:
+			l.titleCols = &ref.cols
+		}
+	}
+
+	nRows, nCols := usedRows, usedCols
+	for i := range l.areas {
+		a := &l.areas[i]
+		if a.wholeRows {
+			a.cols = printSpan{0, usedCols - 1}
+		}
+		if a.wholeCols {
+			a.rows = printSpan{0, usedRows - 1}
+		}
+		if a.rows.to+1 > nRows {
+			nRows = a.rows.to + 1
+		}
+		if a.cols.to+1 > nCols {
+			nCols = a.cols.to + 1
+		}
+	}
+	if l.titleRows != nil && l.titleRows.to+1 > nRows {
+		nRows = l.titleRows.to + 1
+	}
+	if l.titleCols != nil && l.titleCols.to+1 > nCols {
+		nCols = l.titleCols.to + 1
+	}
+	if !l.explicitArea && usedRows > 0 && usedCols > 0 {
+		l.areas = []printRef{{rows: printSpan{0, usedRows - 1}, cols: printSpan{0, usedCols - 1}}}
+	}
+	valid := l.areas[:0]
+	for _, a := range l.areas {
+		if a.rows.from <= a.rows.to && a.cols.from <= a.cols.to {
+			valid = append(valid, a)
+		}
+	}
+	l.areas = valid
+
+	l.measureGrid(nRows, nCols)
+	if l.headings {
+		digits := len(strconv.Itoa(nRows))
+		l.headingW = float64(digits*7+12) * 0.75
+		l.headingH = l.defaultRowHeight()
+	}
+}
+
+func (l *sheetLayout) defaultRowHeight() float64 {
+	if fp := l.sheet.X().SheetFormatPr; fp != nil && fp.DefaultRowHeightAttr > 0 {
+		return fp.DefaultRowHeightAttr
+	}
+	return 15
+}
+
+// measureGrid computes the sizes and offsets of the first nRows rows and
+// nCols columns.
+func (l *sheetLayout) measureGrid(nRows, nCols int) {
+	ws := l.sheet.X()
+	defaultWidth := 8.43
+	if fp := ws.SheetFormatPr; fp != nil {
+		if fp.DefaultColWidthAttr != nil {
+			defaultWidth = *fp.DefaultColWidthAttr
+		} else if fp.BaseColWidthAttr != nil {
+			defaultWidth = float64(*fp.BaseColWidthAttr) + 0.71
+		}
+	}
+	l.colWidths = make([]float64, nCols)
+	for i := range l.colWidths {
+		l.colWidths[i] = float64(colWidthPx(defaultWidth)) * 0.75
+	}
+	for _, cols := range ws.Cols {
+		for _, col := range cols.Col {
+			for i := int(col.MinAttr) - 1; i < int(col.MaxAttr) && i < nCols; i++ {
+				if i < 0 {
+					continue
+				}
+				switch {
+				case _fbac(col.HiddenAttr):
+					l.colWidths[i] = 0
+				case col.WidthAttr != nil:
+					l.colWidths[i] = float64(colWidthPx(*col.WidthAttr)) * 0.75
+				}
+			}
+		}
+	}
+
+	defaultHeight := l.defaultRowHeight()
+	l.rowHeights = make([]float64, nRows)
+	for i := range l.rowHeights {
+		l.rowHeights[i] = defaultHeight
+	}
+	for _, r := range l.sheet.Rows() {
+		i := int(r.RowNumber()) - 1
+		if i < 0 || i >= nRows {
+			continue
+		}
+		switch {
+		case r.IsHidden():
+			l.rowHeights[i] = 0
+		case r.X().HtAttr != nil:
+			l.rowHeights[i] = *r.X().HtAttr
+		}
+	}
+
+	l.colX = offsets(l.colWidths)
+	l.rowY = offsets(l.rowHeights)
+}
+
+func offsets(sizes []float64) []float64 {
+	out := make([]float64, len(sizes)+1)
+	for i, s := range sizes {
+		out[i+1] = out[i] + s
+	}
+	return out
+}
+
+// columnStyle returns the style index of a column.
+func (l *sheetLayout) columnStyle(col int) *uint32 {
+	for _, cols := range l.sheet.X().Cols {
+		for _, c := range cols.Col {
+			if col >= int(c.MinAttr)-1 && col < int(c.MaxAttr) {
+				return c.StyleAttr
+			}
+		}
+	}
+	return nil
+}
+
+// fillColor returns the background color of a cell style, or nil if it is not
+// filled.
+func (l *sheetLayout) fillColor(idx *uint32) creator.Color {
+	if idx == nil {
+		return nil
+	}
+	f := l.ctx._bda.GetCellStyle(*idx).GetFill()
+	if f == nil || f.PatternFill == nil {
+		return nil
+	}
+	pf := f.PatternFill
+	if pf.PatternTypeAttr == sml.ST_PatternTypeUnset || pf.PatternTypeAttr == sml.ST_PatternTypeNone {
+		return nil
+	}
+	clr := pf.FgColor
+	if clr == nil {
+		clr = pf.BgColor
+	}
+	if clr == nil {
+		return nil
+	}
+	if s := l.ctx.getColorStringFromSmlColor(clr); s != nil {
+		return creator.ColorRGBFromHex(*s)
+	}
+	return nil
+}
+
+// definedName returns the content of a sheet-local defined name such as
+// _xlnm.Print_Area.
+func (l *sheetLayout) definedName(name string) string {
+	for _, dn := range l.ctx._gbff.DefinedNames() {
+		x := dn.X()
+		if strings.EqualFold(x.NameAttr, name) && x.LocalSheetIdAttr != nil && int(*x.LocalSheetIdAttr) == l.index {
+			return x.Content
+		}
+	}
+	return ""
+}
+
+// parsePrintRefs parses a comma separated list of range references such as
+// 'Sheet 1'!$A$1:$D$20,'Sheet 1'!$1:$2.
+func parsePrintRefs(content string) []printRef {
+	var refs []printRef
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, content[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, content[start:])
+	for _, part := range parts {
+		if i := strings.LastIndexByte(part, '!'); i >= 0 {
+			part = part[i+1:]
+		}
+		part = strings.ToUpper(strings.Replace(strings.TrimSpace(part), "$", "", -1))
+		if part == "" || part == "#REF" {
+			continue
+		}
+		from, to := part, part
+		if i := strings.IndexByte(part, ':'); i >= 0 {
+			from, to = part[:i], part[i+1:]
+		}
+		switch {
+		case isDigits(from) && isDigits(to):
+			r0, _ := strconv.Atoi(from)
+			r1, _ := strconv.Atoi(to)
+			if r0 < 1 || r1 < r0 {
+				continue
+			}
+			refs = append(refs, printRef{rows: printSpan{r0 - 1, r1 - 1}, wholeRows: true})
+		case isLetters(from) && isLetters(to):
+			c0, c1 := int(reference.ColumnToIndex(from)), int(reference.ColumnToIndex(to))
+			if c1 < c0 {
+				continue
+			}
+			refs = append(refs, printRef{cols: printSpan{c0, c1}, wholeCols: true})
+		default:
+			a, err := reference.ParseCellReference(from)
+			if err != nil {
+				continue
+			}
+			b, err := reference.ParseCellReference(to)
+			if err != nil {
+				continue
+			}
+			ref := printRef{
+				rows: printSpan{int(a.RowIdx) - 1, int(b.RowIdx) - 1},
+				cols: printSpan{int(a.ColumnIdx), int(b.ColumnIdx)},
+			}
+			if ref.rows.to < ref.rows.from {
+				ref.rows.from, ref.rows.to = ref.rows.to, ref.rows.from
+			}
+			if ref.cols.to < ref.cols.from {
+				ref.cols.from, ref.cols.to = ref.cols.to, ref.cols.from
+			}
+			if ref.rows.from < 0 {
+				continue
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isLetters(s string) bool {
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// available returns the printable width and height of a page in sheet units.
+func (l *sheetLayout) available() (float64, float64) {
+	w := (l.pageSize[0]-l.left-l.right)/l.scale - l.headingW
+	h := (l.pageSize[1]-l.top-l.bottom)/l.scale - l.headingH
+	return w, h
+}
+
+// paginate splits the print areas into pages. With fit to page the largest
+// scale at which the areas fit into the requested number of pages is chosen
+// and manual page breaks are ignored, as Excel does.
+func (l *sheetLayout) paginate() {
+	ps := l.sheet.X().PageSetup
+	if !l.fitPage {
+		if ps != nil && ps.ScaleAttr != nil {
+			l.scale = math.Min(math.Max(float64(*ps.ScaleAttr), 10), 400) / 100
+		}
+		l.pages = l.breakPages(true)
+		return
+	}
+
+	fitW, fitH := 1, 1
+	if ps != nil {
+		if ps.FitToWidthAttr != nil {
+			fitW = int(*ps.FitToWidthAttr)
+		}
+		if ps.FitToHeightAttr != nil {
+			fitH = int(*ps.FitToHeightAttr)
+		}
+	}
+	// start from the scale at which the total size fits and reduce it until
+	// the page breaks fall right
+	w, h := l.available()
+	percent := 100
+	for _, a := range l.areas {
+		aw := l.colX[a.cols.to+1] - l.colX[a.cols.from]
+		ah := l.rowY[a.rows.to+1] - l.rowY[a.rows.from]
+		if fitW > 0 && aw > 0 {
+			percent = minInt(percent, int(100*float64(fitW)*w/aw))
+		}
+		if fitH > 0 && ah > 0 {
+			percent = minInt(percent, int(100*float64(fitH)*h/ah))
+		}
+	}
+	for percent = maxInt(percent, 10); ; percent-- {
+		l.scale = float64(percent) / 100
+		l.pages = l.breakPages(false)
+		if percent == 10 || l.fits(fitW, fitH) {
+			return
+		}
+	}
+}
+
+func (l *sheetLayout) fits(fitW, fitH int) bool {
+	for _, p := range l.pages {
+		if (fitW > 0 && p.colBand >= fitW) || (fitH > 0 && p.rowBand >= fitH) {
+			return false
+		}
+	}
+	return true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// breakPages splits the print areas into pages at the current scale.
+func (l *sheetLayout) breakPages(manual bool) []*printPage {
+	if len(l.areas) == 0 {
+		// an empty sheet is printed as a blank page
+		return []*printPage{{rows: printSpan{0, -1}, cols: printSpan{0, -1}}}
+	}
+	w, h := l.available()
+	var rowBreaks, colBreaks map[int]bool
+	if manual {
+		rowBreaks, colBreaks = l.rowBreaks, l.colBreaks
+	}
+	var pages []*printPage
+	for ai, a := range l.areas {
+		rows := pageBands(l.rowHeights, a.rows, h, l.titleRows, rowBreaks)
+		cols := pageBands(l.colWidths, a.cols, w, l.titleCols, colBreaks)
+		add := func(ri, ci int) {
+			p := &printPage{rows: rows[ri], cols: cols[ci], rowBand: ri, colBand: ci, area: ai}
+			if l.explicitArea || l.hasContent(p) {
+				pages = append(pages, p)
+			}
+		}
+		if l.overThenDown {
+			for ri := range rows {
+				for ci := range cols {
+					add(ri, ci)
+				}
+			}
+		} else {
+			for ci := range cols {
+				for ri := range rows {
+					add(ri, ci)
+				}
+			}
+		}
+	}
+	if len(pages) == 0 {
+		a := l.areas[0]
+		pages = []*printPage{{rows: a.rows, cols: a.cols}}
+	}
+	return pages
+}
+
+// pageBands splits a span of rows or columns into bands that fit into avail.
+// Titles reduce the space of every band that starts after them.
+func pageBands(sizes []float64, span printSpan, avail float64, titles *printSpan, breaks map[int]bool) []printSpan {
+	titleSize := 0.0
+	if titles != nil {
+		for i := titles.from; i <= titles.to && i < len(sizes); i++ {
+			titleSize += sizes[i]
+		}
+		if titleSize >= avail {
+			titles = nil
+		}
+	}
+	space := func(start int) float64 {
+		if titles != nil && start > titles.to {
+			return avail - titleSize
+		}
+		return avail
+	}
+	var bands []printSpan
+	start, used := span.from, 0.0
+	for i := span.from; i <= span.to; i++ {
+		if i > start && (breaks[i] || used+sizes[i] > space(start)) {
+			bands = append(bands, printSpan{start, i - 1})
+			start, used = i, 0
+		}
+		used += sizes[i]
+	}
+	return append(bands, printSpan{start, span.to})
+}
+
+// hasContent reports whether a page shows any cell content, fill or drawing.
+func (l *sheetLayout) hasContent(p *printPage) bool {
+	in := func(row, col int) bool {
+		return row >= p.rows.from && row <= p.rows.to && col >= p.cols.from && col <= p.cols.to
+	}
+	for k, pc := range l.cells {
+		if in(k.row, k.col) && (!pc.empty || pc.fill != nil) {
+			return true
+		}
+	}
+	for _, a := range l.ctx._bbcb {
+		if a._agff <= p.rows.to && a._cdb >= p.rows.from && a._cge <= p.cols.to && a._effc >= p.cols.from {
+			return true
+		}
+	}
+	return false
+}
+
+// pageGrid is the grid of rows and columns printed on a page, including
+// repeated titles, with their offsets on the page.
+type pageGrid struct {
+	l      *sheetLayout
+	blk    *creator.Block
+	page   *printPage
+	rows   []int
+	cols   []int
+	xs, ys []float64
+}
+
+// drawPage renders a page. The grid is drawn into a block in sheet units which
+// is then scaled onto the printable area of the page.
+func (l *sheetLayout) drawPage(p *printPage, first bool, v hfValues) {
+	c := l.ctx._gdbe
+	c.SetPageSize(l.pageSize)
+	c.NewPage()
+
+	w, h := l.available()
+	blk := l.gridBlock(p, w+l.headingW, h+l.headingH, true)
+	if l.scale != 1 {
+		blk.Scale(l.scale, l.scale)
+	}
+	blk.SetPos(l.left, l.top)
+	if err := c.Draw(blk); err != nil {
+		logger.Log.Debug("Cannot draw a page: %s", err)
+	}
+	l.drawHeaderFooter(first, v)
+}
+
+// gridBlock draws the cells, headings and drawings of a page into a block of
+// the given size in sheet units. Print titles are repeated if the page starts
+// after them and the grid is centered if the page setup asks for it.
+func (l *sheetLayout) gridBlock(p *printPage, w, h float64, titles bool) *creator.Block {
+	g := &pageGrid{l: l, page: p}
+	if titles && l.titleRows != nil && p.rows.from > l.titleRows.to {
+		g.rows = appendSpan(g.rows, *l.titleRows)
+	}
+	g.rows = appendSpan(g.rows, p.rows)
+	if titles && l.titleCols != nil && p.cols.from > l.titleCols.to {
+		g.cols = appendSpan(g.cols, *l.titleCols)
+	}
+	g.cols = appendSpan(g.cols, p.cols)
+	g.xs = gridOffsets(l.colWidths, g.cols, l.headingW)
+	g.ys = gridOffsets(l.rowHeights, g.rows, l.headingH)
+
+	g.blk = creator.NewBlock(w, h)
+	var dx, dy float64
+	if last := g.xs[len(g.xs)-1]; l.hCentered && last < w {
+		dx = (w - last) / 2
+	}
+	if last := g.ys[len(g.ys)-1]; l.vCentered && last < h {
+		dy = (h - last) / 2
+	}
+	for i := range g.xs {
+		g.xs[i] += dx
+	}
+	for i := range g.ys {
+		g.ys[i] += dy
+	}
+	if len(g.rows) > 0 && len(g.cols) > 0 {
+		g.draw()
+	}
+	return g.blk
+}
+
+func appendSpan(list []int, s printSpan) []int {
+	for i := s.from; i <= s.to; i++ {
+		list = append(list, i)
+	}
+	return list
+}
+
+func gridOffsets(sizes []float64, list []int, start float64) []float64 {
+	out := make([]float64, len(list)+1)
+	out[0] = start
+	for i, idx := range list {
+		out[i+1] = out[i] + sizes[idx]
+	}
+	return out
+}
+
+// cellRect returns the position and size of the cell at position ri, ci of
+// the grid. Merged regions extend over the following rows and columns of the
+// grid that belong to them.
+func (g *pageGrid) cellRect(ri, ci int) (x, y, w, h float64) {
+	x, y = g.xs[ci], g.ys[ri]
+	endRi, endCi := ri, ci
+	if br, ok := g.l.merges[cellKey{g.rows[ri], g.cols[ci]}]; ok {
+		for endRi+1 < len(g.rows) && g.rows[endRi+1] == g.rows[endRi]+1 && g.rows[endRi+1] <= br.row {
+			endRi++
+		}
+		for endCi+1 < len(g.cols) && g.cols[endCi+1] == g.cols[endCi]+1 && g.cols[endCi+1] <= br.col {
+			endCi++
+		}
+	}
+	return x, y, g.xs[endCi+1] - x, g.ys[endRi+1] - y
+}
+
+// isEmpty reports whether text can overflow into a cell.
+func (g *pageGrid) isEmpty(row, col int) bool {
+	k := cellKey{row, col}
+	if g.l.covered[k] {
+		return false
+	}
+	if _, ok := g.l.merges[k]; ok {
+		return false
+	}
+	pc := g.l.cells[k]
+	return pc == nil || pc.empty
+}
+
+func (g *pageGrid) draw() {
+	type placed struct {
+		ri, ci     int
+		pc         *printCell
+		x, y, w, h float64
+	}
+	var cells []placed
+	for ri, row := range g.rows {
+		for ci, col := range g.cols {
+			k := cellKey{row, col}
+			if g.l.covered[k] {
+				continue
+			}
+			x, y, w, h := g.cellRect(ri, ci)
+			if w <= 0 || h <= 0 {
+				continue
+			}
+			cells = append(cells, placed{ri, ci, g.l.cells[k], x, y, w, h})
+		}
+	}
+
+	for _, pl := range cells {
+		if pl.pc != nil && pl.pc.fill != nil {
+			r := g.l.ctx._gdbe.NewRectangle(pl.x, pl.y, pl.w, pl.h)
+			r.SetFillColor(pl.pc.fill)
+			r.SetBorderWidth(0)
+			g.drawable(r)
+		}
+	}
+	if g.l.gridLines {
+		for _, pl := range cells {
+			g.rect(pl.x, pl.y, pl.w, pl.h)
+		}
+	}
+	for _, pl := range cells {
+		if pl.pc != nil && !pl.pc.empty {
+			g.drawText(pl.ri, pl.ci, pl.pc, pl.x, pl.y, pl.w, pl.h)
+		}
+	}
+	for _, pl := range cells {
+		if pl.pc == nil || pl.pc.style == nil {
+			continue
+		}
+		st := pl.pc.style
+		g.border(st._ccc, pl.x, pl.y, pl.x+pl.w, pl.y)
+		g.border(st._gfb, pl.x, pl.y+pl.h, pl.x+pl.w, pl.y+pl.h)
+		g.border(st._cdg, pl.x, pl.y, pl.x, pl.y+pl.h)
+		g.border(st._aaeb, pl.x+pl.w, pl.y, pl.x+pl.w, pl.y+pl.h)
+	}
+	if g.l.headings {
+		g.drawHeadings()
+	}
+	g.drawImages()
+}
+
+func (g *pageGrid) drawable(d creator.Drawable) {
+	if err := g.blk.Draw(d); err != nil {
+		logger.Log.Debug("Cannot draw: %s", err)
+	}
+}
+
+func (g *pageGrid) line(x0, y0, x1, y1, width float64, color creator.Color) {
+	ln := g.l.ctx._gdbe.NewLine(x0, y0, x1, y1)
+	ln.SetLineWidth(width)
+	ln.SetColor(color)
+	g.drawable(ln)
+}
+
+// rect draws the grid lines around a cell.
+func (g *pageGrid) rect(x, y, w, h float64) {
+	clr := creator.ColorRGBFromHex("#a6a6a6")
+	g.line(x, y, x+w, y, _df, clr)
+	g.line(x, y+h, x+w, y+h, _df, clr)
+	g.line(x, y, x, y+h, _df, clr)
+	g.line(x+w, y, x+w, y+h, _df, clr)
+}
+
+func (g *pageGrid) border(b *border, x0, y0, x1, y1 float64) {
+	if b == nil {
+		return
+	}
+	clr := b._dede
+	if clr == nil {
+		clr = creator.ColorBlack
+	}
+	g.line(x0, y0, x1, y1, b._aaebf, clr)
+}
+
+// drawText lays out and draws the content of a cell. Text that is not wrapped
+// overflows into adjacent empty cells, other content is clipped to the cell.
+func (g *pageGrid) drawText(ri, ci int, pc *printCell, x, y, w, h float64) {
+	ctx := g.l.ctx
+	st := pc.style
+	wrap := st != nil && st._dead
+	lines, typ := ctx.getContentFromCell(pc.cell, st, w, wrap)
+	cl := &cell{_fdbg: typ, _eafaa: w, _agfc: h, _dbc: lines}
+	halign := sml.ST_HorizontalAlignmentUnset
+	valign := sml.ST_VerticalAlignmentUnset
+	if st != nil {
+		cl._aeag = _fbac(st._bggd)
+		cl._bbe = _fbac(st._fgad)
+		halign, valign = st._cded, st._gadc
+	}
+	ctx.alignSymbolsHorizontally(cl, halign)
+	ctx.alignSymbolsVertically(cl, valign)
+
+	clipL, clipR := 0.0, w
+	_, merged := g.l.merges[cellKey{g.rows[ri], g.cols[ci]}]
+	if pc.text && !wrap && !merged {
+		row := g.rows[ri]
+		for j := ci - 1; j >= 0 && g.cols[j] == g.cols[j+1]-1 && g.isEmpty(row, g.cols[j]); j-- {
+			clipL -= g.xs[j+1] - g.xs[j]
+		}
+		for j := ci + 1; j < len(g.cols) && g.cols[j] == g.cols[j-1]+1 && g.isEmpty(row, g.cols[j]); j++ {
+			clipR += g.xs[j+1] - g.xs[j]
+		}
+	}
+	for _, ln := range cl._dbc {
+		for _, sym := range ln._dcadd {
+			if sym._bed < clipL || sym._bed+sym._bcbaa > clipR {
+				continue
+			}
+			p := ctx._gdbe.NewStyledParagraph()
+			p.SetPos(x+sym._bed, y+ln._dgab-sym._gcea-_dgf(0.5))
+			var chunk *creator.TextChunk
+			if sym._fbfb != "" {
+				chunk = p.AddExternalLink(sym._gcad, sym._fbfb)
+			} else {
+				chunk = p.Append(sym._gcad)
+			}
+			if sym._fcge != nil {
+				chunk.Style = *sym._fcge
+			}
+			g.drawable(p)
+		}
+	}
+}
+
+// drawHeadings draws the row numbers and column letters.
+func (g *pageGrid) drawHeadings() {
+	x0, y0 := g.xs[0]-g.l.headingW, g.ys[0]-g.l.headingH
+	g.rect(x0, y0, g.l.headingW, g.l.headingH)
+	for ci, col := range g.cols {
+		if w := g.xs[ci+1] - g.xs[ci]; w > 0 {
+			g.rect(g.xs[ci], y0, w, g.l.headingH)
+			g.centered(reference.IndexToColumn(uint32(col)), g.xs[ci], y0, w, g.l.headingH)
+		}
+	}
+	for ri, row := range g.rows {
+		if h := g.ys[ri+1] - g.ys[ri]; h > 0 {
+			g.rect(x0, g.ys[ri], g.l.headingW, h)
+			g.centered(strconv.Itoa(row+1), x0, g.ys[ri], g.l.headingW, h)
+		}
+	}
+}
+
+func (g *pageGrid) centered(text string, x, y, w, h float64) {
+	p := g.l.ctx._gdbe.NewStyledParagraph()
+	p.SetEnableWrap(false)
+	chunk := p.Append(text)
+	chunk.Style = *g.l.ctx.makeTextStyleFromCellStyle(g.l.ctx.getStyle(new(uint32)))
+	p.SetPos(x+(w-p.Width())/2, y+(h-p.Height())/2)
+	g.drawable(p)
+}
+
+// drawImages draws the parts of the pictures and charts of the sheet that lie
+// on the page. Drawings are only printed in the body of the page, not in the
+// repeated titles.
+func (g *pageGrid) drawImages() {
+	l := g.l
+	p := g.page
+	bx0, bx1 := l.colX[p.cols.from], l.colX[p.cols.to+1]
+	by0, by1 := l.rowY[p.rows.from], l.rowY[p.rows.to+1]
+	// the body starts after the repeated titles
+	ox := g.xs[len(g.cols)-(p.cols.to-p.cols.from+1)]
+	oy := g.ys[len(g.rows)-(p.rows.to-p.rows.from+1)]
+	for _, a := range l.ctx._bbcb {
+		ax0 := l.colX[a._cge] + measurement.FromEMU(a._eee)
+		ax1 := l.colX[a._effc] + measurement.FromEMU(a._gdgd)
+		ay0 := l.rowY[a._agff] + measurement.FromEMU(a._cbea)
+		ay1 := l.rowY[a._cdb] + measurement.FromEMU(a._cbfg)
+		if ax1 <= ax0 || ay1 <= ay0 {
+			continue
+		}
+		ix0, ix1 := math.Max(ax0, bx0), math.Min(ax1, bx1)
+		iy0, iy1 := math.Max(ay0, by0), math.Min(ay1, by1)
+		if ix1 <= ix0 || iy1 <= iy0 {
+			continue
+		}
+		img, ok := l.images[a]
+		if !ok {
+			img = l.ctx.imageFromAnchor(a, ax1-ax0, ay1-ay0)
+			l.images[a] = img
+		}
+		if img == nil {
+			continue
+		}
+		b := img.Bounds()
+		sx, sy := float64(b.Dx())/(ax1-ax0), float64(b.Dy())/(ay1-ay0)
+		crop := image.Rect(
+			b.Min.X+int((ix0-ax0)*sx), b.Min.Y+int((iy0-ay0)*sy),
+			b.Min.X+int(math.Ceil((ix1-ax0)*sx)), b.Min.Y+int(math.Ceil((iy1-ay0)*sy)),
+		)
+		part := img
+		if crop != b {
+			part = convertutils.CropImageByRect(img, crop)
+		}
+		pi, err := l.ctx._gdbe.NewImageFromGoImage(part)
+		if err != nil {
+			logger.Log.Debug("Cannot get an image: %s", err)
+			continue
+		}
+		pi.SetWidth(ix1 - ix0)
+		pi.SetHeight(iy1 - iy0)
+		pi.SetPos(ox+ix0-bx0, oy+iy0-by0)
+		g.drawable(pi)
+	}
+}
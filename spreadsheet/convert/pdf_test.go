@@ -0,0 +1,160 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet"
+)
+
+func TestParsePrintRefs(t *testing.T) {
+	td := []struct {
+		Inp string
+		Exp string
+	}{
+		{"Sheet1!$A$1:$D$20", "[{{0 19} {0 3} false false}]"},
+		{"'My, Sheet'!$1:$2,'My, Sheet'!$A:$B", "[{{0 1} {0 0} true false} {{0 0} {0 1} false true}]"},
+		{"Sheet1!$D$20:$A$1", "[{{0 19} {0 3} false false}]"},
+		{"Sheet1!#REF!", "[]"},
+		{"Sheet1!$B$3", "[{{2 2} {1 1} false false}]"},
+	}
+	for _, tc := range td {
+		if got := fmt.Sprint(parsePrintRefs(tc.Inp)); got != tc.Exp {
+			t.Errorf("expected %s to parse to %s, got %s", tc.Inp, tc.Exp, got)
+		}
+	}
+}
+
+func TestPageBands(t *testing.T) {
+	sizes := []float64{10, 10, 10, 10, 10, 10, 10, 10}
+	td := []struct {
+		Name   string
+		Span   printSpan
+		Avail  float64
+		Titles *printSpan
+		Breaks map[int]bool
+		Exp    string
+	}{
+		{"fits", printSpan{0, 7}, 100, nil, nil, "[{0 7}]"},
+		{"split", printSpan{0, 7}, 30, nil, nil, "[{0 2} {3 5} {6 7}]"},
+		{"manual break", printSpan{0, 7}, 100, nil, map[int]bool{3: true}, "[{0 2} {3 7}]"},
+		{"titles", printSpan{0, 7}, 30, &printSpan{0, 0}, nil, "[{0 2} {3 4} {5 6} {7 7}]"},
+		{"titles too large", printSpan{0, 7}, 30, &printSpan{0, 2}, nil, "[{0 2} {3 5} {6 7}]"},
+		{"area", printSpan{2, 5}, 20, nil, nil, "[{2 3} {4 5}]"},
+	}
+	for _, tc := range td {
+		if got := fmt.Sprint(pageBands(sizes, tc.Span, tc.Avail, tc.Titles, tc.Breaks)); got != tc.Exp {
+			t.Errorf("%s: expected bands %s, got %s", tc.Name, tc.Exp, got)
+		}
+	}
+}
+
+func TestParseHeaderFooter(t *testing.T) {
+	v := hfValues{
+		page: 2, pages: 5, sheet: "Data", file: "book.xlsx", path: "/tmp/book.xlsx",
+		time: time.Date(2024, 3, 6, 15, 4, 0, 0, time.UTC),
+	}
+	td := []struct {
+		Inp string
+		Exp [3]string
+	}{
+		{"&LPage &P of &N&C&A&R&D &T", [3]string{"Page 2 of 5", "Data", "3/6/2024 3:04 PM"}},
+		{"Total &P+10 &&", [3]string{"", "Total 12 &", ""}},
+		{"&R&\"Arial,Bold\"&14&F&Z&G", [3]string{"", "", "book.xlsx/tmp/"}},
+		{"&L&BBold&B &IItalic&C&KFF0000red&P-1", [3]string{"Bold Italic", "red1", ""}},
+	}
+	for _, tc := range td {
+		sections := parseHeaderFooter(tc.Inp, style{})
+		var got [3]string
+		for i, chunks := range sections {
+			var sb strings.Builder
+			for _, c := range chunks {
+				if c.field != 0 {
+					sb.WriteString(v.field(c.field, c.delta))
+				} else {
+					sb.WriteString(c.text)
+				}
+			}
+			got[i] = sb.String()
+		}
+		if got != tc.Exp {
+			t.Errorf("expected %s to give %q, got %q", tc.Inp, tc.Exp, got)
+		}
+	}
+
+	sections := parseHeaderFooter("&B&14&KFF0000x", style{})
+	if len(sections[1]) != 1 {
+		t.Fatalf("expected a single chunk, got %d", len(sections[1]))
+	}
+	st := sections[1][0].style
+	if !_fbac(st._gbdf) || st._fddg == nil || *st._fddg != 14 || st._dbe == nil || *st._dbe != "#FF0000" {
+		t.Errorf("expected bold 14pt red text")
+	}
+}
+
+// pagesSheet fills a sheet with rows numbered 1 to n in columns A and B.
+func pagesSheet(wb *spreadsheet.Workbook, n int) spreadsheet.Sheet {
+	sheet := wb.AddSheet()
+	for i := 1; i <= n; i++ {
+		sheet.Cell(fmt.Sprintf("A%d", i)).SetNumber(float64(i))
+		sheet.Cell(fmt.Sprintf("B%d", i)).SetString("row")
+	}
+	return sheet
+}
+
+func TestConvertWorkbookToPdf(t *testing.T) {
+	wb := spreadsheet.New()
+	pagesSheet(wb, 2)
+	long := pagesSheet(wb, 200)
+	hidden := pagesSheet(wb, 2)
+	wb.X().Sheets.Sheet[2].StateAttr = sml.ST_SheetStateHidden
+
+	c := ConvertWorkbookToPdf(wb)
+	if c == nil {
+		t.Fatalf("expected a PDF")
+	}
+	all := c.Context().Page
+
+	// a manual break adds a page to the long sheet
+	long.X().RowBreaks = sml.NewCT_PageBreak()
+	long.X().RowBreaks.Brk = append(long.X().RowBreaks.Brk, &sml.CT_Break{IdAttr: uint32Ptr(10)})
+	if got := ConvertWorkbookToPdf(wb).Context().Page; got != all+1 {
+		t.Errorf("expected %d pages with a manual break, got %d", all+1, got)
+	}
+
+	// a print area limits the long sheet to a single page
+	dn := wb.AddDefinedName("_xlnm.Print_Area", "'Sheet 2'!$A$1:$B$5")
+	dn.SetLocalSheetID(1)
+	if got := ConvertWorkbookToPdf(wb).Context().Page; got != 2 {
+		t.Errorf("expected 2 pages with a print area, got %d", got)
+	}
+
+	c = ConvertWorkbookToPdfWithOptions(wb, &PdfOptions{Sheets: []string{hidden.Name()}})
+	if got := c.Context().Page; got != 1 {
+		t.Errorf("expected 1 page for a hidden sheet that is asked for, got %d", got)
+	}
+}
+
+func TestConvertToPdf(t *testing.T) {
+	wb := spreadsheet.New()
+	sheet := pagesSheet(wb, 200)
+	c := ConvertToPdf(&sheet)
+	if c == nil {
+		t.Fatalf("expected a PDF")
+	}
+	if got := c.Context().Page; got < 2 {
+		t.Errorf("expected the sheet to span several pages, got %d", got)
+	}
+}
+
+func uint32Ptr(v uint32) *uint32 { return &v }
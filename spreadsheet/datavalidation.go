@@ -0,0 +1,302 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// DVCompareTypeTextLength restricts the length of the text of a cell.
+const DVCompareTypeTextLength = DVompareTypeTextLength
+
+// defaultValidationError is the message that Excel shows for invalid values
+// if the rule has no error message.
+const defaultValidationError = "This value doesn't match the data validation restrictions defined for this cell."
+
+// DataValidationViolation is a cell whose value violates a data validation
+// rule.
+type DataValidationViolation struct {
+	// Cell is the reference of the cell, e.g. B2.
+	Cell string
+	// Value is the formatted value of the cell.
+	Value string
+	// Validation is the rule that is violated.
+	Validation DataValidation
+	// Message is the error message of the rule, or the default message of
+	// Excel if it has none.
+	Message string
+}
+
+func (v DataValidationViolation) String() string {
+	return fmt.Sprintf("%s: %q: %s", v.Cell, v.Value, v.Message)
+}
+
+// DataValidations returns the data validation rules of the sheet.
+func (s *Sheet) DataValidations() []DataValidation {
+	if s.X().DataValidations == nil {
+		return nil
+	}
+	ret := []DataValidation{}
+	for _, dv := range s.X().DataValidations.DataValidation {
+		ret = append(ret, DataValidation{dv})
+	}
+	return ret
+}
+
+// ValidateData checks the current values of the cells against the data
+// validation rules of the sheet and returns the cells that violate them.
+// Formulas of the rules are evaluated with the formula engine, relative
+// references being relative to the top left cell of the ranges of a rule.
+// Only cells that exist in the sheet are checked, empty cells are reported
+// only if the rule doesn't allow blanks.
+func (s *Sheet) ValidateData() ([]DataValidationViolation, error) {
+	cells := map[[2]int]Cell{}
+	for _, row := range s.Rows() {
+		for _, c := range row.Cells() {
+			if c.X().RAttr == nil {
+				continue
+			}
+			if ref, err := reference.ParseCellReference(*c.X().RAttr); err == nil {
+				cells[[2]int{int(ref.RowIdx) - 1, int(ref.ColumnIdx)}] = c
+			}
+		}
+	}
+
+	ret := []DataValidationViolation{}
+	for _, dv := range s.DataValidations() {
+		rects, origin := parseSqref(dv.X().SqrefAttr)
+		if len(rects) == 0 {
+			continue
+		}
+		for _, rect := range rects {
+			for row := rect.row0; row <= rect.row1; row++ {
+				for col := rect.col0; col <= rect.col1; col++ {
+					cell, ok := cells[[2]int{row, col}]
+					if !ok {
+						continue
+					}
+					valid, err := dv.validate(s, cell, origin, row, col)
+					if err != nil {
+						return nil, fmt.Errorf("data validation of %s: %s", cellName(row, col), err)
+					}
+					if valid {
+						continue
+					}
+					msg := defaultValidationError
+					if dv.X().ErrorAttr != nil && *dv.X().ErrorAttr != "" {
+						msg = *dv.X().ErrorAttr
+					}
+					ret = append(ret, DataValidationViolation{
+						Cell:       cellName(row, col),
+						Value:      cell.GetFormattedValue(),
+						Validation: dv,
+						Message:    msg,
+					})
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+// Type returns the type of the data validation rule.
+func (d DataValidation) Type() sml.ST_DataValidationType { return d.X().TypeAttr }
+
+// SetCustom sets a rule that accepts the values for which a formula, such as
+// "ISNUMBER(A1)" for a rule that applies to a range starting at A1, is true.
+// Relative references are relative to the top left cell of the range.
+func (d DataValidation) SetCustom(formula string) {
+	d.clear()
+	d.X().TypeAttr = sml.ST_DataValidationTypeCustom
+	d.X().OperatorAttr = sml.ST_DataValidationOperatorUnset
+	d.X().Formula1 = unioffice.String(strings.TrimPrefix(formula, "="))
+	d.X().Formula2 = nil
+}
+
+// SetInputMessage sets the message that is shown when a cell of the range is
+// selected.
+func (d DataValidation) SetInputMessage(title, msg string) {
+	d.X().ShowInputMessageAttr = unioffice.Bool(true)
+	d.X().PromptTitleAttr = unioffice.String(title)
+	d.X().PromptAttr = unioffice.String(msg)
+}
+
+// SetErrorMessage sets the message that is shown when an invalid value is
+// entered.
+func (d DataValidation) SetErrorMessage(title, msg string) {
+	d.X().ShowErrorMessageAttr = unioffice.Bool(true)
+	d.X().ErrorTitleAttr = unioffice.String(title)
+	d.X().ErrorAttr = unioffice.String(msg)
+}
+
+// SetErrorStyle controls whether an invalid value is rejected, which is the
+// default, or can be entered after a warning or information message.
+func (d DataValidation) SetErrorStyle(s sml.ST_DataValidationErrorStyle) {
+	if s == sml.ST_DataValidationErrorStyleStop {
+		s = sml.ST_DataValidationErrorStyleUnset
+	}
+	d.X().ErrorStyleAttr = s
+}
+
+// SetShowDropDown controls whether the drop down of a list rule is shown.
+func (d DataValidation) SetShowDropDown(b bool) {
+	// the attribute is named the other way round, it hides the drop down
+	if b {
+		d.X().ShowDropDownAttr = nil
+	} else {
+		d.X().ShowDropDownAttr = unioffice.Bool(true)
+	}
+}
+
+// SetDate sets the first value of a date comparison.
+func (d DataValidationCompare) SetDate(t time.Time) { d.SetValue(dateFormula(t)) }
+
+// SetDate2 sets the second value of a date comparison, used by between and
+// not between.
+func (d DataValidationCompare) SetDate2(t time.Time) { d.SetValue2(dateFormula(t)) }
+
+// SetTime sets the first value of a time comparison to the time of day of t.
+func (d DataValidationCompare) SetTime(t time.Time) { d.SetValue(timeFormula(t)) }
+
+// SetTime2 sets the second value of a time comparison to the time of day of
+// t, used by between and not between.
+func (d DataValidationCompare) SetTime2(t time.Time) { d.SetValue2(timeFormula(t)) }
+
+func dateFormula(t time.Time) string {
+	return fmt.Sprintf("DATE(%d,%d,%d)", t.Year(), t.Month(), t.Day())
+}
+
+func timeFormula(t time.Time) string {
+	return fmt.Sprintf("TIME(%d,%d,%d)", t.Hour(), t.Minute(), t.Second())
+}
+
+// validate reports whether the value of a cell satisfies the rule.
+func (d DataValidation) validate(s *Sheet, cell Cell, origin tableRect, row, col int) (bool, error) {
+	x := d.X()
+	if cell.IsEmpty() {
+		return x.AllowBlankAttr != nil && *x.AllowBlankAttr, nil
+	}
+	eval := func(f *string) formula.Result {
+		if f == nil {
+			return formula.MakeEmptyResult()
+		}
+		return evalRelative(s, strings.TrimPrefix(*f, "="), origin, row, col)
+	}
+
+	switch x.TypeAttr {
+	case sml.ST_DataValidationTypeUnset, sml.ST_DataValidationTypeNone:
+		return true, nil
+	case sml.ST_DataValidationTypeCustom:
+		return isTrue(eval(x.Formula1)), nil
+	case sml.ST_DataValidationTypeList:
+		return d.inList(cell, eval)
+	}
+
+	var v float64
+	switch x.TypeAttr {
+	case sml.ST_DataValidationTypeTextLength:
+		v = float64(utf8.RuneCountInString(cell.GetString()))
+	case sml.ST_DataValidationTypeWhole, sml.ST_DataValidationTypeDecimal, sml.ST_DataValidationTypeDate, sml.ST_DataValidationTypeTime:
+		n, ok := cellNumber(cell)
+		if !ok || (x.TypeAttr == sml.ST_DataValidationTypeWhole && n != float64(int64(n))) {
+			return false, nil
+		}
+		v = n
+	default:
+		return false, fmt.Errorf("unsupported validation type %s", x.TypeAttr)
+	}
+
+	number := func(f *string) (float64, error) {
+		res := eval(f).AsNumber()
+		if res.Type != formula.ResultTypeNumber {
+			if f == nil {
+				return 0, fmt.Errorf("missing formula")
+			}
+			return 0, fmt.Errorf("formula %s is not a number", *f)
+		}
+		return res.ValueNumber, nil
+	}
+	v1, err := number(x.Formula1)
+	if err != nil {
+		return false, err
+	}
+	switch x.OperatorAttr {
+	case sml.ST_DataValidationOperatorEqual:
+		return v == v1, nil
+	case sml.ST_DataValidationOperatorNotEqual:
+		return v != v1, nil
+	case sml.ST_DataValidationOperatorLessThan:
+		return v < v1, nil
+	case sml.ST_DataValidationOperatorLessThanOrEqual:
+		return v <= v1, nil
+	case sml.ST_DataValidationOperatorGreaterThan:
+		return v > v1, nil
+	case sml.ST_DataValidationOperatorGreaterThanOrEqual:
+		return v >= v1, nil
+	}
+	// between is the default operator
+	v2, err := number(x.Formula2)
+	if err != nil {
+		return false, err
+	}
+	if v2 < v1 {
+		v1, v2 = v2, v1
+	}
+	between := v >= v1 && v <= v2
+	return between == (x.OperatorAttr != sml.ST_DataValidationOperatorNotBetween), nil
+}
+
+// inList reports whether the value of a cell is one of the values of a list
+// rule, which are given either as a quoted comma separated list or by a
+// formula such as a range reference.
+func (d DataValidation) inList(cell Cell, eval func(*string) formula.Result) (bool, error) {
+	f := d.X().Formula1
+	if f == nil {
+		return false, fmt.Errorf("missing list")
+	}
+	text := cell.GetString()
+	if l := *f; len(l) >= 2 && strings.HasPrefix(l, `"`) && strings.HasSuffix(l, `"`) {
+		for _, item := range strings.Split(l[1:len(l)-1], ",") {
+			if strings.EqualFold(item, text) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	res := eval(f)
+	if res.Type == formula.ResultTypeError {
+		return false, fmt.Errorf("list %s: %s", *f, res.ErrorMessage)
+	}
+	items := []formula.Result{res}
+	if res.Type == formula.ResultTypeList || res.Type == formula.ResultTypeArray {
+		items = res.ListValues()
+	}
+	n, isNumber := cellNumber(cell)
+	for _, item := range items {
+		switch item.Type {
+		case formula.ResultTypeNumber:
+			if isNumber && item.ValueNumber == n {
+				return true, nil
+			}
+		case formula.ResultTypeString:
+			if strings.EqualFold(item.ValueString, text) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
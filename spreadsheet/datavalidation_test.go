@@ -0,0 +1,131 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+func TestValidateData(t *testing.T) {
+	td := []struct {
+		Name   string
+		Values []interface{}
+		Setup  func(dv DataValidation)
+		Exp    string
+	}{
+		{"whole between", []interface{}{1, 5, 2.5, 11, "x"}, func(dv DataValidation) {
+			c := dv.SetComparison(DVCompareTypeWholeNumber, DVCompareOpBetween)
+			c.SetValue("10")
+			c.SetValue2("1")
+		}, "A3 A4 A5"},
+		{"decimal greater than a cell", []interface{}{5, 2, 1.5, 3}, func(dv DataValidation) {
+			dv.SetComparison(DVCompareTypeDecimal, DVCompareOpGreater).SetValue("$A$2")
+		}, "A2 A3"},
+		{"text length", []interface{}{"ab", "abc", "abcd"}, func(dv DataValidation) {
+			dv.SetComparison(DVompareTypeTextLength, DVCompareOpLessEqual).SetValue("3")
+		}, "A3"},
+		{"date", []interface{}{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			func(dv DataValidation) {
+				dv.SetComparison(DVCompareTypeDate, DVCompareOpGreaterEqual).SetDate(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+			}, "A1"},
+		{"listed values", []interface{}{"Red", "green", "blue", 1}, func(dv DataValidation) {
+			dv.SetList().SetValues([]string{"red", "green"})
+		}, "A3 A4"},
+		{"list range", []interface{}{"a", "b", "c", "b"}, func(dv DataValidation) {
+			dv.SetList().SetRange("$A$1:$A$2")
+		}, "A3"},
+		{"custom", []interface{}{2, 3, 4}, func(dv DataValidation) {
+			dv.SetCustom("=MOD(A1,2)=0")
+		}, "A2"},
+		{"blank not allowed", []interface{}{"", 1}, func(dv DataValidation) {
+			dv.SetComparison(DVCompareTypeWholeNumber, DVCompareOpGreater).SetValue("0")
+		}, "A1"},
+		{"blank allowed", []interface{}{"", 1}, func(dv DataValidation) {
+			dv.SetComparison(DVCompareTypeWholeNumber, DVCompareOpGreater).SetValue("0")
+			dv.SetAllowBlank(true)
+		}, ""},
+	}
+	for _, tc := range td {
+		wb := New()
+		sheet := wb.AddSheet()
+		for i, v := range tc.Values {
+			cell := sheet.Cell(fmt.Sprintf("A%d", i+1))
+			switch v := v.(type) {
+			case int:
+				cell.SetNumber(float64(v))
+			case float64:
+				cell.SetNumber(v)
+			case time.Time:
+				cell.SetDateWithStyle(v)
+			case string:
+				if v != "" {
+					cell.SetString(v)
+				}
+			}
+		}
+		dv := sheet.AddDataValidation()
+		dv.SetRange(fmt.Sprintf("A1:A%d", len(tc.Values)))
+		tc.Setup(dv)
+
+		violations, err := sheet.ValidateData()
+		if err != nil {
+			t.Errorf("%s: error validating: %s", tc.Name, err)
+			continue
+		}
+		var got []string
+		for _, v := range violations {
+			got = append(got, v.Cell)
+		}
+		if strings.Join(got, " ") != tc.Exp {
+			t.Errorf("%s: expected violations in %q, got %q", tc.Name, tc.Exp, strings.Join(got, " "))
+		}
+	}
+}
+
+func TestDataValidationMessages(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.Cell("A1").SetNumber(20)
+	dv := sheet.AddDataValidation()
+	dv.SetRange("A1:A5")
+	dv.SetComparison(DVCompareTypeWholeNumber, DVCompareOpLess).SetValue("10")
+	dv.SetInputMessage("Quantity", "Enter a quantity below 10")
+	dv.SetErrorMessage("Too many", "The quantity must be below 10")
+	dv.SetErrorStyle(sml.ST_DataValidationErrorStyleWarning)
+
+	violations, err := sheet.ValidateData()
+	if err != nil || len(violations) != 1 {
+		t.Fatalf("expected a violation, got %v %v", violations, err)
+	}
+	if s := violations[0].String(); s != `A1: "20": The quantity must be below 10` {
+		t.Errorf("unexpected violation %s", s)
+	}
+
+	rd := saveAndRead(t, wb)
+	dvs := rd.Sheets()[0].DataValidations()
+	if len(dvs) != 1 {
+		t.Fatalf("expected a data validation, got %d", len(dvs))
+	}
+	x := dvs[0].X()
+	if dvs[0].Type() != sml.ST_DataValidationTypeWhole || x.PromptTitleAttr == nil || *x.PromptTitleAttr != "Quantity" ||
+		x.PromptAttr == nil || *x.PromptAttr != "Enter a quantity below 10" || x.ErrorTitleAttr == nil ||
+		*x.ErrorTitleAttr != "Too many" || x.ErrorStyleAttr != sml.ST_DataValidationErrorStyleWarning {
+		t.Errorf("expected the messages to be preserved")
+	}
+
+	dvs[0].X().ErrorAttr = nil
+	violations, _ = rd.Sheets()[0].ValidateData()
+	if len(violations) != 1 || violations[0].Message != defaultValidationError {
+		t.Errorf("expected the default error message, got %v", violations)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// offsetCell parses a cell reference and applies the offset of shared
+// formulas to it as Cell does.
+func (e *evalContext) offsetCell(ref string) (reference.CellReference, bool) {
+	cr, err := reference.ParseCellReference(ref)
+	if err != nil {
+		return cr, false
+	}
+	if e._age != 0 && !cr.AbsoluteColumn {
+		cr.ColumnIdx += e._age
+	}
+	if e._ggf != 0 && !cr.AbsoluteRow {
+		cr.RowIdx += e._ggf
+	}
+	return cr, true
+}
+
+// sheetIndex indexes the rows, shared formulas and filtered rows of a sheet
+// so that SUBTOTAL and AGGREGATE don't scan the sheet data for each cell.
+type sheetIndex struct {
+	// numRows is the number of rows of the sheet data when the index was
+	// built, the index is rebuilt when rows are added or removed.
+	numRows int
+	rows    map[uint32]*sml.CT_Row
+	shared  map[uint32]string
+	// filters are the ranges of the autofilters of the sheet and its tables.
+	filters []tableRect
+}
+
+// index returns the index of the sheet, building it on first use.
+func (e *evalContext) index() *sheetIndex {
+	sd := e._beee._eage.SheetData
+	if e._dgcab != nil && e._dgcab.numRows == len(sd.Row) {
+		return e._dgcab
+	}
+	idx := &sheetIndex{
+		numRows: len(sd.Row),
+		rows:    make(map[uint32]*sml.CT_Row, len(sd.Row)),
+		shared:  map[uint32]string{},
+	}
+	for _, r := range sd.Row {
+		if r.RAttr == nil {
+			continue
+		}
+		idx.rows[*r.RAttr] = r
+		for _, c := range r.C {
+			if f := c.F; f != nil && f.TAttr == sml.ST_CellFormulaTypeShared && f.SiAttr != nil && f.Content != "" {
+				if _, ok := idx.shared[*f.SiAttr]; !ok {
+					idx.shared[*f.SiAttr] = f.Content
+				}
+			}
+		}
+	}
+	var filters []*sml.CT_AutoFilter
+	if af := e._beee._eage.AutoFilter; af != nil {
+		filters = append(filters, af)
+	}
+	for _, t := range e._beee.Tables() {
+		if t._bcfd.AutoFilter != nil {
+			filters = append(filters, t._bcfd.AutoFilter)
+		}
+	}
+	for _, af := range filters {
+		if af.RefAttr == nil {
+			continue
+		}
+		if rect, err := parseTableRect(*af.RefAttr); err == nil {
+			idx.filters = append(idx.filters, rect)
+		}
+	}
+	e._dgcab = idx
+	return idx
+}
+
+// RowHidden implements formula.SubtotalContext. Rows that are hidden within
+// the data rows of the autofilter of the sheet or of a table are filtered.
+func (e *evalContext) RowHidden(cellRef string) (hidden, filtered bool) {
+	cr, ok := e.offsetCell(cellRef)
+	if !ok {
+		return false, false
+	}
+	idx := e.index()
+	r := idx.rows[cr.RowIdx]
+	if r == nil || r.HiddenAttr == nil || !*r.HiddenAttr {
+		return false, false
+	}
+	row := int(cr.RowIdx) - 1
+	for _, rect := range idx.filters {
+		if row > rect.row0 && row <= rect.row1 {
+			return true, true
+		}
+	}
+	return true, false
+}
+
+// CellFormula implements formula.SubtotalContext. The cells of shared
+// formulas return the formula of the first cell, as written in that cell.
+func (e *evalContext) CellFormula(cellRef string) string {
+	cr, ok := e.offsetCell(cellRef)
+	if !ok {
+		return ""
+	}
+	idx := e.index()
+	r := idx.rows[cr.RowIdx]
+	if r == nil {
+		return ""
+	}
+	name := reference.IndexToColumn(cr.ColumnIdx) + strconv.Itoa(int(cr.RowIdx))
+	for _, c := range r.C {
+		if c.RAttr == nil || !strings.EqualFold(*c.RAttr, name) || c.F == nil {
+			continue
+		}
+		if c.F.Content == "" && c.F.TAttr == sml.ST_CellFormulaTypeShared && c.F.SiAttr != nil {
+			return idx.shared[*c.F.SiAttr]
+		}
+		return c.F.Content
+	}
+	return ""
+}
+
+// SheetIndex implements formula.WorkbookContext.
+func (e *evalContext) SheetIndex(name string) int {
+	if name == "" {
+		name = e._beee.Name()
+	}
+	for i, s := range e._beee._gccb.Sheets() {
+		if strings.EqualFold(s.Name(), name) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// SheetCount implements formula.WorkbookContext.
+func (e *evalContext) SheetCount() int { return len(e._beee._gccb.Sheets()) }
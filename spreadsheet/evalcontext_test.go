@@ -0,0 +1,102 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"testing"
+)
+
+// subtotalSheet returns a workbook whose first sheet holds the numbers 1 to 6
+// below a header in A1:A7 with an autofilter, a subtotal in A8, an error in A9
+// and 100 in A10. Rows 3 and 10 are hidden.
+func subtotalSheet() (*Workbook, Sheet) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.Cell("A1").SetString("Qty")
+	for i := 1; i <= 6; i++ {
+		sheet.Cell(fmt.Sprintf("A%d", i+1)).SetNumber(float64(i))
+	}
+	sheet.Cell("A8").SetFormulaRaw("SUBTOTAL(9,A2:A7)")
+	sheet.Cell("A9").SetFormulaRaw("1/0")
+	sheet.Cell("A10").SetNumber(100)
+	sheet.SetAutoFilter("A1:A7")
+	sheet.Row(3).SetHidden(true)
+	sheet.Row(10).SetHidden(true)
+	sheet.Cell("C1").SetString("Qty")
+	sheet.Cell("C2").SetString(">3")
+	wb.AddDefinedName("Nums", "'Sheet 1'!$A$2:$A$7")
+	wb.AddSheet()
+	return wb, sheet
+}
+
+func TestSubtotalHiddenRows(t *testing.T) {
+	_, sheet := subtotalSheet()
+	ctx := sheet.FormulaContext()
+	td := []struct {
+		Inp, Exp string
+	}{
+		// row 3 is filtered and A8 is a nested subtotal
+		{"SUBTOTAL(9,A2:A8)", "19"},
+		{"SUBTOTAL(109,A2:A8)", "19"},
+		{"SUBTOTAL(9,Nums)", "19"},
+		{"SUBTOTAL(9,A2:A10)", "#DIV/0!"},
+		// row 10 is hidden but not filtered
+		{"SUBTOTAL(9,A10:A11)", "100"},
+		{"SUBTOTAL(109,A10:A11)", "0"},
+		{"SUBTOTAL(2,A1:A10)", "6"},
+		{"SUBTOTAL(3,A1:A10)", "8"},
+		{"AGGREGATE(9,6,A2:A10)", "140"},
+		{"AGGREGATE(9,7,A2:A10)", "38"},
+		{"AGGREGATE(9,3,A2:A10)", "19"},
+		{"AGGREGATE(14,3,A2:A10,1)", "6"},
+		{"DSUM(A1:A7,\"Qty\",C1:C2)", "15"},
+		{"SHEET()", "1"},
+		{"SHEET(\"SHEET 2\")", "2"},
+		{"SHEETS()", "2"},
+	}
+	for _, tc := range td {
+		expectResult(t, ctx, tc.Inp, tc.Exp)
+	}
+}
+
+func TestEvalContextIndex(t *testing.T) {
+	wb, sheet := subtotalSheet()
+	ctx := sheet.FormulaContext().(*evalContext)
+	sheet.Cell("B2").SetFormulaShared("SUBTOTAL(9,$A$2:A2)", 2, 0)
+
+	if hidden, filtered := ctx.RowHidden("A3"); !hidden || !filtered {
+		t.Errorf("expected A3 to be filtered, got hidden %v filtered %v", hidden, filtered)
+	}
+	if hidden, filtered := ctx.RowHidden("A10"); !hidden || filtered {
+		t.Errorf("expected A10 to be hidden, got hidden %v filtered %v", hidden, filtered)
+	}
+	if hidden, _ := ctx.RowHidden("A2"); hidden {
+		t.Errorf("expected A2 to be visible")
+	}
+	for _, ref := range []string{"B2", "B3", "B4"} {
+		if got := ctx.CellFormula(ref); got != "SUBTOTAL(9,$A$2:A2)" {
+			t.Errorf("expected the shared formula for %s, got %q", ref, got)
+		}
+	}
+	if got := ctx.CellFormula("A8"); got != "SUBTOTAL(9,A2:A7)" {
+		t.Errorf("expected the formula of A8, got %q", got)
+	}
+
+	// rows added after the index was built are found
+	sheet.Cell("A20").SetFormulaRaw("SUM(A2:A7)")
+	if got := ctx.CellFormula("A20"); got != "SUM(A2:A7)" {
+		t.Errorf("expected the formula of A20, got %q", got)
+	}
+	if got := ctx.SheetIndex(""); got != 1 {
+		t.Errorf("expected sheet index 1, got %d", got)
+	}
+	if got := wb.Sheets()[1].FormulaContext().(*evalContext).SheetIndex(""); got != 2 {
+		t.Errorf("expected sheet index 2, got %d", got)
+	}
+}
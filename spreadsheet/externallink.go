@@ -0,0 +1,734 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/schema/soo/pkg/relationships"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+	"github.com/unidoc/unioffice/zippkg"
+)
+
+// ExternalLink is a link to another workbook that formulas or defined names
+// of the workbook refer to, e.g. with [1]Sheet1!A1 where 1 is the index of the
+// link. Excel stores the values of the referenced cells along with the link so
+// that formulas can be calculated without opening the linked workbook.
+type ExternalLink struct {
+	wb    *Workbook
+	x     *sml.ExternalLink
+	rels  common.Relationships
+	id    string
+	index int
+}
+
+// ExternalLinkResolver returns the workbook that an external link refers to,
+// so that formulas referring to it are calculated using its current values.
+// Returning a nil workbook or an error makes formulas use the values stored
+// with the link instead.
+type ExternalLinkResolver func(link ExternalLink) (*Workbook, error)
+
+// externalLinks holds the external link parts of a workbook, which are
+// numbered in the order they are read.
+type externalLinks struct {
+	parts    []*sml.ExternalLink
+	rels     []common.Relationships
+	resolver ExternalLinkResolver
+	resolved map[*sml.ExternalLink]*Workbook
+}
+
+// ExternalLinks returns the links to other workbooks in the order of their
+// indexes.
+func (wb *Workbook) ExternalLinks() []ExternalLink {
+	ret := []ExternalLink{}
+	if wb._febgc == nil || wb._feeg.ExternalReferences == nil {
+		return ret
+	}
+	dt := unioffice.DocTypeSpreadsheet
+	for i, ref := range wb._feeg.ExternalReferences.ExternalReference {
+		target := wb._bfdc.GetTargetByRelId(ref.IdAttr)
+		for j, p := range wb._febgc.parts {
+			if target == unioffice.RelativeFilename(dt, unioffice.OfficeDocumentType, unioffice.ExternalLinkType, j+1) {
+				ret = append(ret, ExternalLink{wb, p, wb._febgc.rels[j], ref.IdAttr, i + 1})
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// SetExternalLinkResolver sets the function used to open the workbooks that
+// external links refer to when formulas are calculated. Workbooks are
+// requested once per link and kept until the resolver is changed. A nil
+// resolver makes formulas use the values stored with the links.
+func (wb *Workbook) SetExternalLinkResolver(fn ExternalLinkResolver) {
+	if wb._febgc == nil {
+		wb._febgc = &externalLinks{}
+	}
+	wb._febgc.resolver = fn
+	wb._febgc.resolved = nil
+}
+
+// BreakLinks replaces the formulas that refer to other workbooks by their
+// values and removes all external links.
+func (wb *Workbook) BreakLinks() error {
+	for {
+		links := wb.ExternalLinks()
+		if len(links) == 0 {
+			return nil
+		}
+		if err := links[len(links)-1].Break(); err != nil {
+			return err
+		}
+	}
+}
+
+// externalLink returns the link for the workbook of a reference, which is
+// either the index of the link or the name of the linked file.
+func (wb *Workbook) externalLink(book string) (ExternalLink, bool) {
+	links := wb.ExternalLinks()
+	if n, err := strconv.Atoi(book); err == nil {
+		if n < 1 || n > len(links) {
+			return ExternalLink{}, false
+		}
+		return links[n-1], true
+	}
+	for _, l := range links {
+		if l.matches(book) {
+			return l, true
+		}
+	}
+	return ExternalLink{}, false
+}
+
+// X returns the inner wrapped XML type.
+func (l ExternalLink) X() *sml.ExternalLink { return l.x }
+
+// Index returns the index that formulas use to refer to the linked workbook,
+// e.g. 1 for [1]Sheet1!A1.
+func (l ExternalLink) Index() int { return l.index }
+
+// book returns the linked workbook, or nil for DDE and OLE links.
+func (l ExternalLink) book() *sml.CT_ExternalBook {
+	if l.x == nil || l.x.Choice == nil {
+		return nil
+	}
+	return l.x.Choice.ExternalBook
+}
+
+// relationship returns the relationship to the linked file.
+func (l ExternalLink) relationship() *relationships.Relationship {
+	b := l.book()
+	if b == nil {
+		return nil
+	}
+	for _, r := range l.rels.X().Relationship {
+		if r.IdAttr == b.IdAttr {
+			return r
+		}
+	}
+	return nil
+}
+
+// Target returns the path or URL of the linked workbook as stored in the
+// file, e.g. "Book2.xlsx" or "file:///C:\Data\Book2.xlsx".
+func (l ExternalLink) Target() string {
+	if r := l.relationship(); r != nil {
+		return r.TargetAttr
+	}
+	return ""
+}
+
+// SetTarget changes the workbook that the link refers to. The values stored
+// with the link are kept until the workbook is recalculated in Excel.
+func (l ExternalLink) SetTarget(target string) {
+	if b := l.book(); b != nil {
+		if r := l.relationship(); r != nil {
+			r.TargetAttr = target
+			r.TypeAttr = unioffice.ExternalLinkPathType
+			return
+		}
+		rel := l.rels.AddRelationship(target, unioffice.ExternalLinkPathType)
+		rel.X().TargetModeAttr = relationships.ST_TargetModeExternal
+		b.IdAttr = rel.ID()
+	}
+}
+
+// matches returns true if the file name of the link target is name.
+func (l ExternalLink) matches(name string) bool {
+	target := strings.Replace(l.Target(), "\\", "/", -1)
+	return strings.EqualFold(target, name) || strings.EqualFold(path.Base(target), name)
+}
+
+// SheetNames returns the names of the sheets of the linked workbook.
+func (l ExternalLink) SheetNames() []string {
+	ret := []string{}
+	if b := l.book(); b != nil && b.SheetNames != nil {
+		for _, n := range b.SheetNames.SheetName {
+			if n.ValAttr != nil {
+				ret = append(ret, *n.ValAttr)
+			}
+		}
+	}
+	return ret
+}
+
+// sheetIndex returns the index of the sheet with the given name, compared
+// case-insensitively like Excel does, or -1.
+func (l ExternalLink) sheetIndex(name string) int {
+	for i, n := range l.SheetNames() {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sheetData returns the stored values of the sheet with the given index.
+func (l ExternalLink) sheetData(idx int) *sml.CT_ExternalSheetData {
+	b := l.book()
+	if b == nil || b.SheetDataSet == nil {
+		return nil
+	}
+	for _, sd := range b.SheetDataSet.SheetData {
+		if int(sd.SheetIdAttr) == idx {
+			return sd
+		}
+	}
+	return nil
+}
+
+// CachedValues returns the values stored with the link for the cells of a
+// sheet of the linked workbook, by cell reference.
+func (l ExternalLink) CachedValues(sheet string) map[string]formula.Result {
+	ret := map[string]formula.Result{}
+	sd := l.sheetData(l.sheetIndex(sheet))
+	if sd == nil {
+		return ret
+	}
+	for _, row := range sd.Row {
+		for _, c := range row.Cell {
+			if c.RAttr != nil {
+				ret[*c.RAttr] = externalCellResult(c)
+			}
+		}
+	}
+	return ret
+}
+
+// CachedValue returns the value stored with the link for a cell of the linked
+// workbook. It returns false if no value is stored for the cell.
+func (l ExternalLink) CachedValue(sheet, cellRef string) (formula.Result, bool) {
+	c := externalSheetContext{sheet: l.sheetData(l.sheetIndex(sheet))}.cell(strings.Replace(cellRef, "$", "", -1))
+	if c == nil {
+		return formula.MakeEmptyResult(), false
+	}
+	return externalCellResult(c), true
+}
+
+// DefinedName returns what a defined name of the linked workbook refers to,
+// e.g. "Sheet1!$A$1". It returns false if the name is not stored with the
+// link.
+func (l ExternalLink) DefinedName(name string) (string, bool) {
+	b := l.book()
+	if b == nil || b.DefinedNames == nil {
+		return "", false
+	}
+	for _, dn := range b.DefinedNames.DefinedName {
+		if strings.EqualFold(dn.NameAttr, name) && dn.RefersToAttr != nil {
+			return strings.TrimPrefix(*dn.RefersToAttr, "="), true
+		}
+	}
+	return "", false
+}
+
+// Break replaces the formulas that refer to the linked workbook by their
+// values and removes the link. Defined names that refer to the linked
+// workbook are replaced by the value they refer to, or #REF! if it's not a
+// single value. References to links with a higher index are renumbered.
+func (l ExternalLink) Break() error {
+	wb := l.wb
+	if l.x == nil || wb == nil {
+		return errors.New("invalid external link")
+	}
+	refs := wb._feeg.ExternalReferences
+	if refs == nil || l.index < 1 || l.index > len(refs.ExternalReference) || refs.ExternalReference[l.index-1].IdAttr != l.id {
+		return fmt.Errorf("external link %d not found", l.index)
+	}
+
+	refersTo := func(f string) bool {
+		for _, b := range formula.ExternalBooks(f) {
+			if b == strconv.Itoa(l.index) || l.matches(b) {
+				return true
+			}
+		}
+		return false
+	}
+	renumber := func(f string) string {
+		return formula.ReplaceExternalBooks(f, func(b string) string {
+			if n, err := strconv.Atoi(b); err == nil && n > l.index {
+				return strconv.Itoa(n - 1)
+			}
+			return b
+		})
+	}
+
+	for _, sheet := range wb.Sheets() {
+		ws := sheet._eage
+		var cells []*sml.CT_Cell
+		broken := map[uint32]bool{}
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F == nil || c.F.Content == "" {
+					continue
+				}
+				if !refersTo(c.F.Content) {
+					c.F.Content = renumber(c.F.Content)
+					continue
+				}
+				if c.F.TAttr == sml.ST_CellFormulaTypeShared && c.F.SiAttr != nil {
+					broken[*c.F.SiAttr] = true
+				}
+				cells = append(cells, c)
+			}
+		}
+		if len(broken) > 0 {
+			for _, row := range ws.SheetData.Row {
+				for _, c := range row.C {
+					if c.F != nil && c.F.Content == "" && c.F.TAttr == sml.ST_CellFormulaTypeShared && c.F.SiAttr != nil && broken[*c.F.SiAttr] {
+						cells = append(cells, c)
+					}
+				}
+			}
+		}
+		// formulas without a cached value are evaluated while the link is
+		// still there, so that they keep the value of the linked workbook
+		ctx := sheet.FormulaContext()
+		ev := formula.NewEvaluator()
+		for _, c := range cells {
+			if c.V == nil && c.RAttr != nil {
+				cacheResult(c, ctx.Cell(*c.RAttr, ev))
+			}
+		}
+		for _, c := range cells {
+			removeFormula(c)
+		}
+	}
+
+	for _, dn := range wb.DefinedNames() {
+		content := dn.Content()
+		if !refersTo(content) {
+			dn.SetContent(renumber(content))
+			continue
+		}
+		dn.SetContent(wb.constantValue(content))
+	}
+
+	links := wb.ExternalLinks()
+	refs.ExternalReference = append(refs.ExternalReference[:l.index-1], refs.ExternalReference[l.index:]...)
+	if len(refs.ExternalReference) == 0 {
+		wb._feeg.ExternalReferences = nil
+	}
+	wb._bfdc.Remove(wb._bfdc.GetByRelId(l.id))
+	remaining := []ExternalLink{}
+	for _, o := range links {
+		if o.x != l.x {
+			remaining = append(remaining, o)
+		}
+	}
+	wb.setExternalLinks(remaining)
+	delete(wb._febgc.resolved, l.x)
+	return nil
+}
+
+// cacheResult stores the result of a formula as the cached value of a cell.
+func cacheResult(c *sml.CT_Cell, res formula.Result) {
+	switch res.Type {
+	case formula.ResultTypeNumber:
+		c.TAttr = sml.ST_CellTypeN
+	case formula.ResultTypeString:
+		c.TAttr = sml.ST_CellTypeStr
+	case formula.ResultTypeError:
+		c.TAttr = sml.ST_CellTypeE
+	default:
+		return
+	}
+	c.V = unioffice.String(res.Value())
+}
+
+// removeFormula replaces the formula of a cell by its cached value.
+func removeFormula(c *sml.CT_Cell) {
+	c.F = nil
+	switch {
+	case c.V == nil:
+		c.TAttr = sml.ST_CellTypeUnset
+	case c.TAttr == sml.ST_CellTypeStr:
+		c.TAttr = sml.ST_CellTypeInlineStr
+		c.Is = sml.NewCT_Rst()
+		c.Is.T = unioffice.String(*c.V)
+		c.V = nil
+	}
+}
+
+// constantValue returns the value of a formula as a constant for a defined
+// name, or #REF! if it doesn't evaluate to a single value.
+func (wb *Workbook) constantValue(content string) string {
+	sheets := wb.Sheets()
+	if len(sheets) == 0 {
+		return "#REF!"
+	}
+	res := formula.NewEvaluator().Eval(sheets[0].FormulaContext(), content)
+	switch res.Type {
+	case formula.ResultTypeNumber:
+		return strconv.FormatFloat(res.ValueNumber, 'g', -1, 64)
+	case formula.ResultTypeString:
+		return `"` + strings.Replace(res.ValueString, `"`, `""`, -1) + `"`
+	case formula.ResultTypeError:
+		if strings.HasPrefix(res.ValueString, "#") {
+			return res.ValueString
+		}
+	case formula.ResultTypeEmpty:
+		return "0"
+	}
+	return "#REF!"
+}
+
+// addExternalLinkPart is called when reading an external link part of a
+// workbook.
+func (wb *Workbook) addExternalLinkPart(dm *zippkg.DecodeMap, target, typ string, rel *relationships.Relationship) {
+	if wb._febgc == nil {
+		wb._febgc = &externalLinks{}
+	}
+	x := sml.NewExternalLink()
+	idx := uint32(len(wb._febgc.parts))
+	dm.AddTarget(target, x, typ, idx)
+	rels := common.NewRelationships()
+	dm.AddTarget(zippkg.RelationsPathFor(target), rels.X(), typ, idx)
+	wb._febgc.parts = append(wb._febgc.parts, x)
+	wb._febgc.rels = append(wb._febgc.rels, rels)
+	rel.TargetAttr = unioffice.RelativeFilename(unioffice.DocTypeSpreadsheet, unioffice.OfficeDocumentType, typ, len(wb._febgc.parts))
+}
+
+// prepareExternalLinks numbers the external link parts in the order of their
+// indexes before saving, dropping parts that are no longer referenced.
+func (wb *Workbook) prepareExternalLinks() {
+	if wb._febgc == nil {
+		return
+	}
+	dt := unioffice.DocTypeSpreadsheet
+	links := wb.ExternalLinks()
+	overrides := []string{}
+	for _, o := range wb.ContentTypes.X().Override {
+		if o.ContentTypeAttr == unioffice.ExternalLinkContentType {
+			overrides = append(overrides, o.PartNameAttr)
+		}
+	}
+	for _, o := range overrides {
+		wb.ContentTypes.RemoveOverride(o)
+	}
+	wb.setExternalLinks(links)
+	for i := range links {
+		wb.ContentTypes.AddOverride(unioffice.AbsoluteFilename(dt, unioffice.ExternalLinkType, i+1), unioffice.ExternalLinkContentType)
+	}
+}
+
+// setExternalLinks numbers the parts of the links in the given order,
+// dropping any other parts.
+func (wb *Workbook) setExternalLinks(links []ExternalLink) {
+	dt := unioffice.DocTypeSpreadsheet
+	wb._febgc.parts = nil
+	wb._febgc.rels = nil
+	for i, l := range links {
+		for _, r := range wb._bfdc.X().Relationship {
+			if r.IdAttr == l.id {
+				r.TargetAttr = unioffice.RelativeFilename(dt, unioffice.OfficeDocumentType, unioffice.ExternalLinkType, i+1)
+			}
+		}
+		wb._febgc.parts = append(wb._febgc.parts, l.x)
+		wb._febgc.rels = append(wb._febgc.rels, l.rels)
+	}
+}
+
+// writeExternalLinks writes the external link parts and their relationships.
+func (wb *Workbook) writeExternalLinks(z *zip.Writer) error {
+	if wb._febgc == nil {
+		return nil
+	}
+	dt := unioffice.DocTypeSpreadsheet
+	for i, p := range wb._febgc.parts {
+		fn := unioffice.AbsoluteFilename(dt, unioffice.ExternalLinkType, i+1)
+		if err := zippkg.MarshalXML(z, fn, p); err != nil {
+			return err
+		}
+		if !wb._febgc.rels[i].IsEmpty() {
+			if err := zippkg.MarshalXML(z, zippkg.RelationsPathFor(fn), wb._febgc.rels[i].X()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// externalSheet returns the context for evaluating references to a sheet of
+// another workbook, e.g. [1]Sheet1. It returns false if the name doesn't refer
+// to another workbook.
+func (e *evalContext) externalSheet(name string) (formula.Context, bool) {
+	book, sheet, ok := formula.ParseExternalSheet(name)
+	if !ok {
+		return nil, false
+	}
+	wb := e._beee._gccb
+	l, ok := wb.externalLink(book)
+	if !ok {
+		return formula.InvalidReferenceContext, true
+	}
+	if linked := wb.resolveExternalLink(l); linked != nil {
+		for _, s := range linked.Sheets() {
+			if strings.EqualFold(s.Name(), sheet) {
+				return &linkedSheetContext{Context: s.FormulaContext(), name: name, ev: formula.NewEvaluator()}, true
+			}
+		}
+		return formula.InvalidReferenceContext, true
+	}
+	idx := l.sheetIndex(sheet)
+	if idx < 0 {
+		return formula.InvalidReferenceContext, true
+	}
+	return externalSheetContext{epoch: wb.Epoch(), filename: l.Target(), name: name, sheet: l.sheetData(idx)}, true
+}
+
+// externalName returns the reference of a defined name of another workbook,
+// e.g. [1]!Rate. It returns false if the name doesn't refer to another
+// workbook.
+func (e *evalContext) externalName(name string) (formula.Reference, bool) {
+	book, definedName, ok := formula.ParseExternalName(name)
+	if !ok {
+		return formula.ReferenceInvalid, false
+	}
+	wb := e._beee._gccb
+	l, ok := wb.externalLink(book)
+	if !ok {
+		return formula.ReferenceInvalid, true
+	}
+	content, found := "", false
+	if linked := wb.resolveExternalLink(l); linked != nil {
+		for _, dn := range linked.DefinedNames() {
+			if strings.EqualFold(dn.Name(), definedName) {
+				content, found = dn.Content(), true
+				break
+			}
+		}
+	} else {
+		content, found = l.DefinedName(definedName)
+	}
+	sep := strings.LastIndex(content, "!")
+	if !found || sep < 0 {
+		return formula.ReferenceInvalid, true
+	}
+	sheet := strings.Replace(strings.Trim(content[:sep], "'"), "''", "'", -1)
+	return formula.MakeRangeReference("[" + book + "]" + sheet + content[sep:]), true
+}
+
+// resolveExternalLink returns the workbook that a link refers to from the
+// resolver, or nil if there is none.
+func (wb *Workbook) resolveExternalLink(l ExternalLink) *Workbook {
+	xl := wb._febgc
+	if xl == nil || xl.resolver == nil {
+		return nil
+	}
+	if linked, ok := xl.resolved[l.x]; ok {
+		return linked
+	}
+	linked, err := xl.resolver(l)
+	if err != nil {
+		linked = nil
+	}
+	if xl.resolved == nil {
+		xl.resolved = map[*sml.ExternalLink]*Workbook{}
+	}
+	xl.resolved[l.x] = linked
+	return linked
+}
+
+// linkedSheetContext evaluates references to a sheet of a workbook returned
+// by an ExternalLinkResolver. The cells of the linked workbook are evaluated
+// with their own evaluator, as its cache is keyed by sheet names that may
+// also exist in the workbook being evaluated.
+type linkedSheetContext struct {
+	formula.Context
+	name string
+	ev   formula.Evaluator
+}
+
+// Cell returns the result of evaluating a cell of the linked workbook.
+func (c *linkedSheetContext) Cell(ref string, ev formula.Evaluator) formula.Result {
+	key := c.name + "!" + ref
+	if r, ok := ev.GetFromCache(key); ok {
+		return r
+	}
+	r := c.Context.Cell(ref, c.ev)
+	ev.SetCache(key, r)
+	return r
+}
+
+// externalSheetContext evaluates references to a sheet of another workbook
+// using the values stored with the external link.
+type externalSheetContext struct {
+	epoch    time.Time
+	filename string
+	name     string
+	sheet    *sml.CT_ExternalSheetData
+}
+
+func (c externalSheetContext) cell(ref string) *sml.CT_ExternalCell {
+	if c.sheet == nil {
+		return nil
+	}
+	cr, err := reference.ParseCellReference(ref)
+	if err != nil {
+		return nil
+	}
+	for _, row := range c.sheet.Row {
+		if row.RAttr != cr.RowIdx {
+			continue
+		}
+		for _, x := range row.Cell {
+			if x.RAttr != nil && strings.EqualFold(*x.RAttr, cr.Column+strconv.Itoa(int(cr.RowIdx))) {
+				return x
+			}
+		}
+	}
+	return nil
+}
+
+// externalCellResult returns the value stored for a cell of another workbook.
+func externalCellResult(c *sml.CT_ExternalCell) formula.Result {
+	if c.V == nil {
+		return formula.MakeEmptyResult()
+	}
+	switch c.TAttr {
+	case sml.ST_CellTypeB:
+		return formula.MakeBoolResult(*c.V == "1")
+	case sml.ST_CellTypeE:
+		res := formula.MakeErrorResult("")
+		res.ValueString = *c.V
+		return res
+	case sml.ST_CellTypeS, sml.ST_CellTypeStr, sml.ST_CellTypeInlineStr:
+		return formula.MakeStringResult(*c.V)
+	}
+	if f, err := strconv.ParseFloat(*c.V, 64); err == nil {
+		return formula.MakeNumberResult(f)
+	}
+	return formula.MakeStringResult(*c.V)
+}
+
+// Cell returns the value stored for a cell.
+func (c externalSheetContext) Cell(ref string, ev formula.Evaluator) formula.Result {
+	x := c.cell(strings.Replace(ref, "$", "", -1))
+	if x == nil {
+		return formula.MakeEmptyResult()
+	}
+	return externalCellResult(x)
+}
+
+// Sheet returns an invalid context, as references within another workbook
+// are not evaluated.
+func (c externalSheetContext) Sheet(name string) formula.Context {
+	return formula.InvalidReferenceContext
+}
+
+// GetEpoch returns the epoch of the workbook that contains the link.
+func (c externalSheetContext) GetEpoch() time.Time { return c.epoch }
+
+// GetFilename returns the target of the link.
+func (c externalSheetContext) GetFilename() string { return c.filename }
+
+// GetWidth returns zero as column widths are not stored with the link.
+func (c externalSheetContext) GetWidth(colIdx int) float64 { return 0 }
+
+// GetFormat returns an empty format as formats are not stored with the link.
+func (c externalSheetContext) GetFormat(cellRef string) string { return "" }
+
+// GetLabelPrefix returns an empty prefix.
+func (c externalSheetContext) GetLabelPrefix(cellRef string) string { return "" }
+
+// GetLocked returns false.
+func (c externalSheetContext) GetLocked(cellRef string) bool { return false }
+
+// SetLocked is a no-op.
+func (c externalSheetContext) SetLocked(cellRef string, locked bool) {}
+
+// HasFormula returns false as only values are stored with the link.
+func (c externalSheetContext) HasFormula(cellRef string) bool { return false }
+
+// IsBool returns true if the value stored for a cell is a boolean.
+func (c externalSheetContext) IsBool(cellRef string) bool {
+	x := c.cell(strings.Replace(cellRef, "$", "", -1))
+	return x != nil && x.TAttr == sml.ST_CellTypeB
+}
+
+// IsDBCS returns false.
+func (c externalSheetContext) IsDBCS() bool { return false }
+
+// LastColumn returns the last column with a stored value in the given rows.
+func (c externalSheetContext) LastColumn(rowFrom, rowTo int) string {
+	last := uint32(0)
+	if c.sheet != nil {
+		for _, row := range c.sheet.Row {
+			if int(row.RAttr) < rowFrom || int(row.RAttr) > rowTo {
+				continue
+			}
+			for _, x := range row.Cell {
+				if x.RAttr == nil {
+					continue
+				}
+				if cr, err := reference.ParseCellReference(*x.RAttr); err == nil && cr.ColumnIdx > last {
+					last = cr.ColumnIdx
+				}
+			}
+		}
+	}
+	return reference.IndexToColumn(last)
+}
+
+// LastRow returns the last row with a stored value in the given column.
+func (c externalSheetContext) LastRow(col string) int {
+	last := 1
+	if c.sheet != nil {
+		for _, row := range c.sheet.Row {
+			for _, x := range row.Cell {
+				if x.RAttr == nil {
+					continue
+				}
+				if cr, err := reference.ParseCellReference(*x.RAttr); err == nil && strings.EqualFold(cr.Column, col) && int(cr.RowIdx) > last {
+					last = int(cr.RowIdx)
+				}
+			}
+		}
+	}
+	return last
+}
+
+// NamedRange returns an invalid reference, defined names of other workbooks
+// are resolved by the context of the formula.
+func (c externalSheetContext) NamedRange(ref string) formula.Reference {
+	return formula.ReferenceInvalid
+}
+
+// SetOffset is a no-op.
+func (c externalSheetContext) SetOffset(col, row uint32) {}
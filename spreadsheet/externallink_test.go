@@ -0,0 +1,202 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// addExternalLink adds a link to the workbook target, storing the values of
+// A1 and A2 of its sheet Data and the defined name Rate referring to A1.
+func addExternalLink(wb *Workbook, target string, a1 float64, a2 string) ExternalLink {
+	if wb._febgc == nil {
+		wb._febgc = &externalLinks{}
+	}
+	b := sml.NewCT_ExternalBook()
+	b.SheetNames = &sml.CT_ExternalSheetNames{SheetName: []*sml.CT_ExternalSheetName{{ValAttr: unioffice.String("Data")}}}
+	b.DefinedNames = &sml.CT_ExternalDefinedNames{DefinedName: []*sml.CT_ExternalDefinedName{
+		{NameAttr: "Rate", RefersToAttr: unioffice.String("=Data!$A$1")},
+	}}
+	b.SheetDataSet = &sml.CT_ExternalSheetDataSet{SheetData: []*sml.CT_ExternalSheetData{{Row: []*sml.CT_ExternalRow{
+		{RAttr: 1, Cell: []*sml.CT_ExternalCell{{RAttr: unioffice.String("A1"), V: unioffice.String(strconv.FormatFloat(a1, 'g', -1, 64))}}},
+		{RAttr: 2, Cell: []*sml.CT_ExternalCell{{RAttr: unioffice.String("A2"), TAttr: sml.ST_CellTypeStr, V: unioffice.String(a2)}}},
+	}}}}
+	x := sml.NewExternalLink()
+	x.Choice = &sml.CT_ExternalLinkChoice{ExternalBook: b}
+
+	n := len(wb._febgc.parts) + 1
+	rel := wb._bfdc.AddRelationship(unioffice.RelativeFilename(unioffice.DocTypeSpreadsheet, unioffice.OfficeDocumentType,
+		unioffice.ExternalLinkType, n), unioffice.ExternalLinkType)
+	if wb._feeg.ExternalReferences == nil {
+		wb._feeg.ExternalReferences = sml.NewCT_ExternalReferences()
+	}
+	wb._feeg.ExternalReferences.ExternalReference = append(wb._feeg.ExternalReferences.ExternalReference,
+		&sml.CT_ExternalReference{IdAttr: rel.ID()})
+	rels := common.NewRelationships()
+	wb._febgc.parts = append(wb._febgc.parts, x)
+	wb._febgc.rels = append(wb._febgc.rels, rels)
+	l := ExternalLink{wb, x, rels, rel.ID(), n}
+	l.SetTarget(target)
+	return l
+}
+
+// linkWorkbook returns a workbook with links to Book2.xlsx and Book3.xlsx and
+// formulas referring to them.
+func linkWorkbook(t *testing.T) *Workbook {
+	wb := New()
+	sheet := wb.AddSheet()
+	addExternalLink(wb, "Book2.xlsx", 2, "two")
+	addExternalLink(wb, "file:///C:/Data/Book3.xlsx", 3, "three")
+	for ref, f := range map[string]string{
+		"A1": "[1]Data!A1*10",
+		"A2": "[2]Data!$A$1+[1]!Rate",
+		"A3": "'[1]Data'!A2",
+		"A4": "[2]Data!A1*2",
+	} {
+		sheet.Cell(ref).SetFormulaRaw(f)
+	}
+	wb.AddDefinedName("Linked", "[2]Data!$A$1")
+	wb.AddDefinedName("Text", "[1]Data!$A$2")
+	return saveAndRead(t, wb)
+}
+
+func TestExternalLinksRoundTrip(t *testing.T) {
+	wb := linkWorkbook(t)
+	links := wb.ExternalLinks()
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(links))
+	}
+	l := links[1]
+	if l.Index() != 2 || l.Target() != "file:///C:/Data/Book3.xlsx" {
+		t.Errorf("expected the second link to Book3.xlsx, got %d %s", l.Index(), l.Target())
+	}
+	if names := l.SheetNames(); len(names) != 1 || names[0] != "Data" {
+		t.Errorf("expected the sheet Data, got %v", names)
+	}
+	if v, ok := l.CachedValue("data", "$A$1"); !ok || v.ValueNumber != 3 {
+		t.Errorf("expected the stored value 3, got %s", v.Value())
+	}
+	if _, ok := l.CachedValue("Data", "B1"); ok {
+		t.Errorf("expected no value for B1")
+	}
+	if vals := l.CachedValues("Data"); len(vals) != 2 || vals["A2"].ValueString != "three" {
+		t.Errorf("expected the stored values of A1 and A2, got %v", vals)
+	}
+	if dn, ok := l.DefinedName("rate"); !ok || dn != "Data!$A$1" {
+		t.Errorf("expected Rate to refer to Data!$A$1, got %q", dn)
+	}
+
+	ctx := wb.Sheets()[0].FormulaContext()
+	td := []struct {
+		Formula, Exp string
+	}{
+		{"[1]Data!A1*10", "20"},
+		{"[2]Data!$A$1+[1]!Rate", "5"},
+		{"'[1]Data'!A2", "two"},
+		{"[Book3.xlsx]Data!A1", "3"},
+		{"SUM([1]Data!A1:A2)", "2"},
+		{"Linked", "3"},
+		// missing sheets and names are errors like those of the workbook
+		{"[3]Data!A1", "#NAME?"},
+		{"[1]Missing!A1", "#NAME?"},
+		{"ISERROR([1]!Missing)", "1"},
+	}
+	for _, tc := range td {
+		expectResult(t, ctx, tc.Formula, tc.Exp)
+	}
+
+	l.SetTarget("Book4.xlsx")
+	rd := saveAndRead(t, wb)
+	if got := rd.ExternalLinks()[1].Target(); got != "Book4.xlsx" {
+		t.Errorf("expected the new target, got %s", got)
+	}
+}
+
+func TestExternalLinkResolver(t *testing.T) {
+	wb := linkWorkbook(t)
+	linked := New()
+	data := linked.AddSheet()
+	data.SetName("Data")
+	data.Cell("A1").SetNumber(100)
+	linked.AddDefinedName("Rate", "Data!$A$1")
+
+	requests := 0
+	wb.SetExternalLinkResolver(func(l ExternalLink) (*Workbook, error) {
+		requests++
+		if l.Target() == "Book2.xlsx" {
+			return linked, nil
+		}
+		return nil, errors.New("not found")
+	})
+	ctx := wb.Sheets()[0].FormulaContext()
+	expectResult(t, ctx, "[1]Data!A1*10", "1000")
+	expectResult(t, ctx, "[2]Data!A1+[1]!Rate", "103")
+	expectResult(t, ctx, "[1]Other!A1", "#NAME?")
+	if requests != 2 {
+		t.Errorf("expected each workbook to be requested once, got %d requests", requests)
+	}
+
+	wb.SetExternalLinkResolver(nil)
+	expectResult(t, ctx, "[1]Data!A1*10", "20")
+}
+
+func TestBreakLinks(t *testing.T) {
+	wb := linkWorkbook(t)
+	if err := wb.ExternalLinks()[0].Break(); err != nil {
+		t.Fatalf("error breaking link: %s", err)
+	}
+	sheet := wb.Sheets()[0]
+	for _, tc := range []struct{ Ref, Value, Formula string }{
+		{"A1", "20", ""},
+		{"A2", "5", ""},
+		{"A3", "two", ""},
+		{"A4", "", "[1]Data!A1*2"},
+	} {
+		c := sheet.Cell(tc.Ref)
+		if c.GetFormula() != tc.Formula {
+			t.Errorf("%s: expected the formula %q, got %q", tc.Ref, tc.Formula, c.GetFormula())
+		}
+		if tc.Formula == "" && c.GetString() != tc.Value {
+			t.Errorf("%s: expected the value %s, got %s", tc.Ref, tc.Value, c.GetString())
+		}
+	}
+	names := map[string]string{}
+	for _, dn := range wb.DefinedNames() {
+		names[dn.Name()] = dn.Content()
+	}
+	if names["Linked"] != "[1]Data!$A$1" || names["Text"] != `"two"` {
+		t.Errorf("expected the defined names to be renumbered and replaced, got %v", names)
+	}
+
+	rd := saveAndRead(t, wb)
+	links := rd.ExternalLinks()
+	if len(links) != 1 || links[0].Index() != 1 || links[0].Target() != "file:///C:/Data/Book3.xlsx" {
+		t.Fatalf("expected the link to Book3.xlsx to remain")
+	}
+	expectResult(t, rd.Sheets()[0].FormulaContext(), "A4", "6")
+
+	if err := rd.BreakLinks(); err != nil {
+		t.Fatalf("error breaking links: %s", err)
+	}
+	rd = saveAndRead(t, rd)
+	if len(rd.ExternalLinks()) != 0 || rd.X().ExternalReferences != nil {
+		t.Errorf("expected all links to be removed")
+	}
+	if got := rd.Sheets()[0].Cell("A4").GetString(); got != "6" || rd.Sheets()[0].Cell("A4").HasFormula() {
+		t.Errorf("expected the value 6, got %q", got)
+	}
+	if err := links[0].Break(); err == nil {
+		t.Errorf("expected an error breaking a removed link")
+	}
+}
@@ -0,0 +1,552 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/unidoc/unioffice"
+	sdr "github.com/unidoc/unioffice/schema/soo/dml/spreadsheetDrawing"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// FindOptions controls how Find and Replace search a workbook.
+type FindOptions struct {
+	// MatchCase makes the search case sensitive.
+	MatchCase bool
+	// WholeCell requires the text to match the entire content of a cell,
+	// comment, header or paragraph instead of a part of it.
+	WholeCell bool
+	// Regexp treats the text as a regular expression, in which case the
+	// replacement may refer to submatches as $1 or ${name}.
+	Regexp bool
+	// Formulas searches the formulas of cells containing one instead of their
+	// values. Formulas are only replaced when this is set, and only if the
+	// result is a valid formula.
+	Formulas bool
+	// Sheets limits the search to the sheets with the given names, all sheets
+	// are searched if it's empty.
+	Sheets []string
+
+	// Comments also searches the notes and threaded comments.
+	Comments bool
+	// HeadersFooters also searches the headers and footers.
+	HeadersFooters bool
+	// Shapes also searches the text of the shapes and text boxes drawn on the
+	// sheets.
+	Shapes bool
+}
+
+// FindLocation is the kind of content a match was found in.
+type FindLocation byte
+
+// FindLocation constants.
+const (
+	FindLocationCell FindLocation = iota
+	FindLocationComment
+	FindLocationHeaderFooter
+	FindLocationShape
+)
+
+// FindMatch is a location where Find or Replace matched the text.
+type FindMatch struct {
+	Sheet string
+	// Reference is the reference of the cell that matched or that the
+	// comment or shape is anchored to. It is empty for headers, footers and
+	// shapes that are not anchored to a cell.
+	Reference string
+	Location  FindLocation
+}
+
+// Find returns the locations of the workbook that contain the text. Numbers
+// are matched as they are displayed, and formula cells by their value unless
+// opts.Formulas is set.
+func (wb *Workbook) Find(text string, opts FindOptions) ([]FindMatch, error) {
+	f, err := newFinder(text, "", opts)
+	if err != nil {
+		return nil, err
+	}
+	return wb.find(f), nil
+}
+
+// Replace replaces the text everywhere it is found in the workbook and returns
+// the locations that were modified. Shared strings are never modified in
+// place, so cells outside of the searched sheets that use the same string
+// keep their text. Formatting of rich text is kept, with the replacement
+// taking the formatting of the run the match starts in. Booleans, errors and
+// the values of formula cells are not replaced.
+//
+// Headers and footers are matched including their formatting codes, such as
+// &P for the page number.
+func (wb *Workbook) Replace(text, replacement string, opts FindOptions) ([]FindMatch, error) {
+	f, err := newFinder(text, replacement, opts)
+	if err != nil {
+		return nil, err
+	}
+	f.replace = true
+	return wb.find(f), nil
+}
+
+// finder matches and replaces text for Find and Replace.
+type finder struct {
+	re          *regexp.Regexp
+	replacement string
+	opts        FindOptions
+	replace     bool
+	matches     []FindMatch
+	seen        map[FindMatch]struct{}
+}
+
+// textEdit replaces the bytes from start to end of a text.
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+func newFinder(text, replacement string, opts FindOptions) (*finder, error) {
+	if text == "" {
+		return nil, errors.New("empty search text")
+	}
+	pattern := text
+	if !opts.Regexp {
+		pattern = regexp.QuoteMeta(text)
+	}
+	if opts.WholeCell {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	if !opts.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &finder{re: re, replacement: replacement, opts: opts, seen: map[FindMatch]struct{}{}}, nil
+}
+
+// edits returns the edits that replace the non-empty matches in s.
+func (f *finder) edits(s string) []textEdit {
+	var edits []textEdit
+	for _, m := range f.re.FindAllStringSubmatchIndex(s, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+		r := f.replacement
+		if f.opts.Regexp {
+			r = string(f.re.ExpandString(nil, f.replacement, s, m))
+		}
+		edits = append(edits, textEdit{m[0], m[1], r})
+	}
+	return edits
+}
+
+func (f *finder) add(sheet, ref string, loc FindLocation) {
+	m := FindMatch{Sheet: sheet, Reference: ref, Location: loc}
+	if _, ok := f.seen[m]; ok {
+		return
+	}
+	f.seen[m] = struct{}{}
+	f.matches = append(f.matches, m)
+}
+
+func (wb *Workbook) find(f *finder) []FindMatch {
+	for _, s := range wb.Sheets() {
+		if len(f.opts.Sheets) > 0 && !containsString(f.opts.Sheets, s.Name()) {
+			continue
+		}
+		s := s
+		f.findCells(&s)
+		if f.opts.Comments {
+			f.findComments(&s)
+		}
+		if f.opts.HeadersFooters {
+			f.findHeadersFooters(&s)
+		}
+		if f.opts.Shapes {
+			f.findShapes(&s)
+		}
+	}
+	return f.matches
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *finder) findCells(s *Sheet) {
+	var shared map[uint32][]*sml.CT_Cell
+	for _, row := range s.Rows() {
+		for _, c := range row.Cells() {
+			x := c._cga
+			if x.F != nil && f.opts.Formulas {
+				if x.F.Content == "" {
+					// dependent cells of shared formulas are matched with
+					// the cell that contains the formula
+					continue
+				}
+				edits := f.edits(x.F.Content)
+				if len(edits) == 0 {
+					continue
+				}
+				if !f.replace {
+					f.add(s.Name(), c.Reference(), FindLocationCell)
+					continue
+				}
+				content := applyEdits(x.F.Content, edits)
+				if formula.ParseString(content) == nil {
+					continue
+				}
+				x.F.Content = content
+				x.V = nil
+				f.add(s.Name(), c.Reference(), FindLocationCell)
+				if x.F.TAttr == sml.ST_CellFormulaTypeShared && x.F.SiAttr != nil {
+					if shared == nil {
+						shared = sharedFormulaCells(s)
+					}
+					for _, d := range shared[*x.F.SiAttr] {
+						if d != x && d.RAttr != nil {
+							d.V = nil
+							f.add(s.Name(), *d.RAttr, FindLocationCell)
+						}
+					}
+				}
+				continue
+			}
+			if f.findCell(c) {
+				f.add(s.Name(), c.Reference(), FindLocationCell)
+			}
+		}
+	}
+}
+
+// findCell matches and replaces the value of a cell, returning true if it
+// matched.
+func (f *finder) findCell(c Cell) bool {
+	x := c._cga
+	if x.F != nil {
+		return !f.replace && len(f.edits(c.GetFormattedValue())) > 0
+	}
+	if rt, ok := c.GetRichText(); ok {
+		edits := f.edits(rt.Text())
+		if len(edits) == 0 {
+			return false
+		}
+		if !f.replace {
+			return true
+		}
+		if len(rt._cde.R) > 0 {
+			rt = c.EditRichText()
+			runs := make([]*string, len(rt._cde.R))
+			for i, r := range rt._cde.R {
+				runs[i] = &r.T
+			}
+			editRuns(runs, edits)
+		} else if x.TAttr == sml.ST_CellTypeInlineStr && x.Is != nil {
+			x.Is.T = unioffice.String(applyEdits(rt.Text(), edits))
+		} else {
+			c.SetString(applyEdits(rt.Text(), edits))
+		}
+		return true
+	}
+	if x.V == nil {
+		return false
+	}
+	switch {
+	case c.IsNumber():
+		if !f.replace {
+			// numbers are found as displayed, but replaced in their raw
+			// value as the displayed value depends on the number format
+			if len(f.edits(c.GetFormattedValue())) > 0 {
+				return true
+			}
+		}
+		edits := f.edits(*x.V)
+		if len(edits) == 0 {
+			return false
+		}
+		if f.replace {
+			v := applyEdits(*x.V, edits)
+			if n, err := strconv.ParseFloat(v, 64); err == nil {
+				c.SetNumber(n)
+			} else {
+				c.SetString(v)
+			}
+		}
+		return true
+	case !f.replace:
+		return len(f.edits(c.GetFormattedValue())) > 0
+	}
+	return false
+}
+
+// sharedFormulaCells returns the cells of a sheet that use a shared formula
+// by the index of the formula.
+func sharedFormulaCells(s *Sheet) map[uint32][]*sml.CT_Cell {
+	ret := map[uint32][]*sml.CT_Cell{}
+	for _, row := range s._eage.SheetData.Row {
+		for _, c := range row.C {
+			if c.F != nil && c.F.TAttr == sml.ST_CellFormulaTypeShared && c.F.SiAttr != nil {
+				ret[*c.F.SiAttr] = append(ret[*c.F.SiAttr], c)
+			}
+		}
+	}
+	return ret
+}
+
+func (f *finder) findComments(s *Sheet) {
+	notes := s.Comments()
+	if notes.X() != nil {
+		for _, n := range notes.Comments() {
+			if strings.HasPrefix(n.Author(), "tc=") {
+				// the note of a threaded comment follows the thread
+				continue
+			}
+			if f.findRichText(n.RichText()) {
+				f.add(s.Name(), n.CellReference(), FindLocationComment)
+			}
+		}
+	}
+	i := s.index()
+	if i < 0 || i >= len(s._gccb._dbfgc) || s._gccb._dbfgc[i] == nil {
+		return
+	}
+	tc := s.ThreadedComments()
+	for _, x := range tc.x.ThreadedComment {
+		c := ThreadedComment{tc, x}
+		text := c.Text()
+		edits := f.edits(text)
+		if len(edits) == 0 {
+			continue
+		}
+		ref := c.CellReference()
+		if root, ok := c.root(); ok {
+			ref = root.CellReference()
+		}
+		f.add(s.Name(), ref, FindLocationComment)
+		if !f.replace {
+			continue
+		}
+		if x.Mentions != nil {
+			for _, m := range x.Mentions.Mention {
+				m.StartIndexAttr = shiftRuneIndex(text, edits, m.StartIndexAttr)
+			}
+		}
+		c.SetText(applyEdits(text, edits))
+	}
+}
+
+// shiftRuneIndex returns the position of the character at index idx of text
+// after the edits are applied.
+func shiftRuneIndex(text string, edits []textEdit, idx uint32) uint32 {
+	pos := int64(idx)
+	for _, e := range edits {
+		start := int64(utf8.RuneCountInString(text[:e.start]))
+		if start >= int64(idx) {
+			break
+		}
+		pos += int64(utf8.RuneCountInString(e.text)) - int64(utf8.RuneCountInString(text[e.start:e.end]))
+	}
+	if pos < 0 {
+		return 0
+	}
+	return uint32(pos)
+}
+
+func (f *finder) findRichText(rt RichText) bool {
+	edits := f.edits(rt.Text())
+	if len(edits) == 0 {
+		return false
+	}
+	if f.replace {
+		if len(rt._cde.R) == 0 {
+			rt._cde.T = unioffice.String(applyEdits(rt.Text(), edits))
+		} else {
+			runs := make([]*string, len(rt._cde.R))
+			for i, r := range rt._cde.R {
+				runs[i] = &r.T
+			}
+			editRuns(runs, edits)
+		}
+	}
+	return true
+}
+
+func (f *finder) findHeadersFooters(s *Sheet) {
+	hf := s._eage.HeaderFooter
+	if hf == nil {
+		return
+	}
+	for _, t := range []*string{hf.OddHeader, hf.OddFooter, hf.EvenHeader, hf.EvenFooter, hf.FirstHeader, hf.FirstFooter} {
+		if t == nil {
+			continue
+		}
+		edits := f.edits(*t)
+		if len(edits) == 0 {
+			continue
+		}
+		f.add(s.Name(), "", FindLocationHeaderFooter)
+		if f.replace {
+			*t = applyEdits(*t, edits)
+		}
+	}
+}
+
+func (f *finder) findShapes(s *Sheet) {
+	dr, _ := s.GetDrawing()
+	if dr == nil {
+		return
+	}
+	for _, a := range dr.EG_Anchor {
+		var choice *sdr.EG_ObjectChoicesChoice
+		ref := ""
+		switch {
+		case a.TwoCellAnchor != nil:
+			choice = a.TwoCellAnchor.Choice
+			ref = markerReference(a.TwoCellAnchor.From)
+		case a.OneCellAnchor != nil:
+			choice = a.OneCellAnchor.Choice
+			ref = markerReference(a.OneCellAnchor.From)
+		case a.AbsoluteAnchor != nil:
+			choice = a.AbsoluteAnchor.Choice
+		}
+		if choice == nil {
+			continue
+		}
+		if f.findShape(choice.Sp) || f.findGroupShape(choice.GrpSp) {
+			f.add(s.Name(), ref, FindLocationShape)
+		}
+	}
+}
+
+func markerReference(m *sdr.CT_Marker) string {
+	if m == nil {
+		return ""
+	}
+	return reference.IndexToColumn(uint32(m.Col)) + strconv.Itoa(int(m.Row)+1)
+}
+
+func (f *finder) findGroupShape(g *sdr.CT_GroupShape) bool {
+	if g == nil {
+		return false
+	}
+	found := false
+	for _, c := range g.Choice {
+		for _, sp := range c.Sp {
+			if f.findShape(sp) {
+				found = true
+			}
+		}
+		for _, gs := range c.GrpSp {
+			if f.findGroupShape(gs) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// findShape matches and replaces the text of a shape, each line of each
+// paragraph is searched separately.
+func (f *finder) findShape(sp *sdr.CT_Shape) bool {
+	if sp == nil || sp.TxBody == nil {
+		return false
+	}
+	found := false
+	for _, p := range sp.TxBody.P {
+		var runs []*string
+		line := func() {
+			if f.findRuns(runs) {
+				found = true
+			}
+			runs = nil
+		}
+		for _, r := range p.EG_TextRun {
+			switch {
+			case r.R != nil:
+				runs = append(runs, &r.R.T)
+			case r.Fld != nil && r.Fld.T != nil:
+				runs = append(runs, r.Fld.T)
+			case r.Br != nil:
+				line()
+			}
+		}
+		line()
+	}
+	return found
+}
+
+func (f *finder) findRuns(runs []*string) bool {
+	sb := strings.Builder{}
+	for _, r := range runs {
+		sb.WriteString(*r)
+	}
+	edits := f.edits(sb.String())
+	if len(edits) == 0 {
+		return false
+	}
+	if f.replace {
+		editRuns(runs, edits)
+	}
+	return true
+}
+
+// applyEdits returns s with the edits applied, the edits must be ordered and
+// not overlap.
+func applyEdits(s string, edits []textEdit) string {
+	sb := strings.Builder{}
+	last := 0
+	for _, e := range edits {
+		sb.WriteString(s[last:e.start])
+		sb.WriteString(e.text)
+		last = e.end
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// editRuns applies the edits of the concatenated text of runs to the runs.
+// The replacement text is inserted in the run where a match starts, and the
+// matched text is removed from all runs it spans.
+func editRuns(runs []*string, edits []textEdit) {
+	for k := len(edits) - 1; k >= 0; k-- {
+		e := edits[k]
+		off := 0
+		inserted := false
+		for _, r := range runs {
+			t := *r
+			rs, re := off, off+len(t)
+			off = re
+			a, b := e.start, e.end
+			if a < rs {
+				a = rs
+			}
+			if b > re {
+				b = re
+			}
+			if a < b {
+				*r = t[:a-rs] + t[b-rs:]
+			}
+			if !inserted && e.start >= rs && e.start < re {
+				p := e.start - rs
+				*r = (*r)[:p] + e.text + (*r)[p:]
+				inserted = true
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	sdr "github.com/unidoc/unioffice/schema/soo/dml/spreadsheetDrawing"
+)
+
+// findWorkbook returns a workbook with text in cells, a note, a header and a
+// shape on the first sheet and the shared string "hello" on both sheets.
+func findWorkbook() *Workbook {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.Cell("A1").SetString("Hello World")
+	sheet.Cell("A2").SetString("hello")
+	sheet.Cell("A3").SetNumber(1234.5)
+	num, _ := wb.StyleSheet.GetOrCreateStyle(StyleDescriptor{NumberFormat: "#,##0.00"})
+	sheet.Cell("A3").SetStyle(num)
+	sheet.Cell("A4").SetFormulaRaw("SUM(A3,1)")
+	rt := sheet.Cell("A5").SetRichTextString()
+	run := rt.AddRun()
+	run.SetText("ab")
+	run.SetBold(true)
+	rt.AddRun().SetText("cd")
+	sheet.Comments().AddCommentWithStyle("B1", "Ann", "a note")
+	sheet.HeaderFooter().SetHeader(HeaderFooterOdd, HeaderFooterText{Right: "Page &P"})
+
+	dr := wb.AddDrawing()
+	sheet.SetDrawing(dr)
+	sp := sdr.NewCT_Shape()
+	sp.TxBody = dml.NewCT_TextBody()
+	p := dml.NewCT_TextParagraph()
+	for _, t := range []string{"a no", "te box"} {
+		r := dml.NewEG_TextRun()
+		r.R = dml.NewCT_RegularTextRun()
+		r.R.T = t
+		p.EG_TextRun = append(p.EG_TextRun, r)
+	}
+	sp.TxBody.P = append(sp.TxBody.P, p)
+	anchor := sdr.NewCT_TwoCellAnchor()
+	anchor.From = &sdr.CT_Marker{Col: 3, Row: 1}
+	anchor.Choice = &sdr.EG_ObjectChoicesChoice{Sp: sp}
+	dr.X().EG_Anchor = append(dr.X().EG_Anchor, &sdr.EG_Anchor{TwoCellAnchor: anchor})
+
+	other := wb.AddSheet()
+	other.Cell("A1").SetString("hello")
+	return wb
+}
+
+// matchList formats matches as sheet!reference, with the first letter of the
+// location for matches outside of cells.
+func matchList(matches []FindMatch) string {
+	parts := []string{}
+	for _, m := range matches {
+		s := m.Sheet + "!" + m.Reference
+		if m.Location != FindLocationCell {
+			s += "(" + string("CCHS"[m.Location]) + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestFind(t *testing.T) {
+	td := []struct {
+		Text string
+		Opts FindOptions
+		Exp  string
+	}{
+		{"hello", FindOptions{}, "Sheet 1!A1 Sheet 1!A2 Sheet 2!A1"},
+		{"hello", FindOptions{MatchCase: true}, "Sheet 1!A2 Sheet 2!A1"},
+		{"HELLO", FindOptions{WholeCell: true}, "Sheet 1!A2 Sheet 2!A1"},
+		{"hello", FindOptions{Sheets: []string{"Sheet 2"}}, "Sheet 2!A1"},
+		{"1,234.50", FindOptions{}, "Sheet 1!A3"},
+		{"1234.5", FindOptions{}, "Sheet 1!A3"},
+		{"SUM", FindOptions{}, ""},
+		{"SUM", FindOptions{Formulas: true}, "Sheet 1!A4"},
+		{"bc", FindOptions{}, "Sheet 1!A5"},
+		{`^h\w+o$`, FindOptions{Regexp: true}, "Sheet 1!A2 Sheet 2!A1"},
+		{"note", FindOptions{}, ""},
+		{"note", FindOptions{Comments: true, Shapes: true}, "Sheet 1!B1(C) Sheet 1!D2(S)"},
+		{"&P", FindOptions{HeadersFooters: true}, "Sheet 1!(H)"},
+	}
+	wb := findWorkbook()
+	for _, tc := range td {
+		matches, err := wb.Find(tc.Text, tc.Opts)
+		if err != nil {
+			t.Errorf("%s: error finding text: %s", tc.Text, err)
+			continue
+		}
+		if got := matchList(matches); got != tc.Exp {
+			t.Errorf("%s %+v: expected %q, got %q", tc.Text, tc.Opts, tc.Exp, got)
+		}
+	}
+
+	if _, err := wb.Find("", FindOptions{}); err == nil {
+		t.Errorf("expected an error for an empty search text")
+	}
+	if _, err := wb.Find("(", FindOptions{Regexp: true}); err == nil {
+		t.Errorf("expected an error for an invalid expression")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	wb := findWorkbook()
+	sheet := wb.Sheets()[0]
+	ann := wb.AddPerson("Ann", "", "")
+	bob := wb.AddPerson("Bob", "", "")
+	thread, _ := sheet.ThreadedComments().AddThread("C1", ann, "Ask @Bob now")
+	thread.AddMention(bob)
+
+	td := []struct {
+		Text, Replacement string
+		Opts              FindOptions
+		Exp               string
+	}{
+		{"hello", "bye", FindOptions{WholeCell: true, Sheets: []string{"Sheet 1"}}, "Sheet 1!A2"},
+		{`(\w+) (\w+)`, "$2 $1", FindOptions{Regexp: true}, "Sheet 1!A1"},
+		{"4.5", "4.75", FindOptions{}, "Sheet 1!A3"},
+		{"SUM", "MAX", FindOptions{Formulas: true}, "Sheet 1!A4"},
+		{"A3,", "A3,,(", FindOptions{Formulas: true}, ""},
+		{"bc", "X", FindOptions{}, "Sheet 1!A5"},
+		{"note", "memo", FindOptions{Comments: true, Shapes: true}, "Sheet 1!B1(C) Sheet 1!D2(S)"},
+		{"ask", "Please ask", FindOptions{Comments: true}, "Sheet 1!C1(C)"},
+		{"Page", "Seite", FindOptions{HeadersFooters: true}, "Sheet 1!(H)"},
+	}
+	for _, tc := range td {
+		matches, err := wb.Replace(tc.Text, tc.Replacement, tc.Opts)
+		if err != nil {
+			t.Fatalf("%s: error replacing text: %s", tc.Text, err)
+		}
+		if got := matchList(matches); got != tc.Exp {
+			t.Errorf("%s: expected %q, got %q", tc.Text, tc.Exp, got)
+		}
+	}
+
+	dr, _ := sheet.GetDrawing()
+	runs := dr.EG_Anchor[0].TwoCellAnchor.Choice.Sp.TxBody.P[0].EG_TextRun
+	if got := runs[0].R.T + "|" + runs[1].R.T; got != "a memo| box" {
+		t.Errorf("expected the replacement in the run the match starts in, got %q", got)
+	}
+	if got := sheet.Cell("A1").GetFormattedValue(); got != "World Hello" {
+		t.Errorf("expected the words to be swapped, got %q", got)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	for _, tc := range []struct{ Ref, Exp string }{
+		{"A2", "bye"},
+		{"A3", "1,234.75"},
+		{"A5", "aXd"},
+	} {
+		if got := rs.Cell(tc.Ref).GetFormattedValue(); got != tc.Exp {
+			t.Errorf("%s: expected %q, got %q", tc.Ref, tc.Exp, got)
+		}
+	}
+	if got := rd.Sheets()[1].Cell("A1").GetString(); got != "hello" {
+		t.Errorf("expected the shared string on the other sheet to be kept, got %q", got)
+	}
+	if got := rs.Cell("A4").GetFormula(); got != "MAX(A3,1)" {
+		t.Errorf("expected the formula to be replaced, got %q", got)
+	}
+	if rt, _ := rs.Cell("A5").GetRichText(); len(rt.Runs()) != 2 || !rt.Runs()[0].IsBold() || rt.Runs()[0].GetText() != "aX" {
+		t.Errorf("expected the formatting of the runs to be kept")
+	}
+	if note, _ := noteText(&rs, "B1"); !strings.Contains(note, "a memo") {
+		t.Errorf("expected the note to be replaced, got %q", note)
+	}
+	got, _ := rs.ThreadedComments().Thread("C1")
+	if m := got.Mentions(); got.Text() != "Please ask @Bob now" || len(m) != 1 || m[0].Start != 11 {
+		t.Errorf("expected the mention to move with the text, got %q", got.Text())
+	}
+	if got := rs.HeaderFooter().Header(HeaderFooterOdd).Right; got != "Seite &P" {
+		t.Errorf("expected the header to be replaced, got %q", got)
+	}
+}
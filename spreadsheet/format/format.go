@@ -30,12 +30,12 @@ package format ;import (_d "bytes";_c "fmt";_de "github.com/unidoc/unioffice/com
 
 // Value formats a value as a number or string depending on  if it appears to be
 // a number or string.
-func Value (v string ,f string )string {if IsNumber (v ){_cg ,_ :=_g .ParseFloat (v ,64);return Number (_cg ,f );};return String (v ,f );};
+func Value (v string ,f string )string {return ValueLocale (v ,f ,nil ) ;};
 
 // Number is used to format a number with a format string.  If the format
 // string is empty, then General number formatting is used which attempts to mimic
 // Excel's general formatting.
-func Number (v float64 ,f string )string {if f ==""||f =="\u0047e\u006e\u0065\u0072\u0061\u006c"||f =="\u0040"{return NumberGeneric (v );};_cf :=Parse (f );if len (_cf )==1{return _gac (v ,_cf [0],false );}else if len (_cf )> 1&&v < 0{return _gac (v ,_cf [1],true );}else if len (_cf )> 2&&v ==0{return _gac (v ,_cf [2],false );};return _gac (v ,_cf [0],false );};const _df ="\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u004c\u0069\u0074\u0065\u0072a\u006c\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0044\u0069\u0067\u0069\u0074\u0046\u006d\u0074\u0054y\u0070\u0065\u0044i\u0067\u0069\u0074\u004f\u0070\u0074\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0043o\u006d\u006d\u0061\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0044\u0065\u0063\u0069\u006da\u006c\u0046\u006d\u0074\u0054\u0079\u0070\u0065Pe\u0072\u0063e\u006e\u0074\u0046\u006d\u0074\u0054\u0079\u0070e\u0044\u006f\u006c\u006c\u0061\u0072\u0046\u006d\u0074Ty\u0070\u0065\u0044i\u0067\u0069\u0074\u004f\u0070\u0074\u0054\u0068\u006f\u0075\u0073\u0061n\u0064\u0073\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0055n\u0064\u0065\u0072\u0073c\u006f\u0072\u0065\u0046\u006d\u0074T\u0079\u0070\u0065\u0044\u0061\u0074\u0065\u0046\u006d\u0074\u0054y\u0070e\u0054\u0069\u006d\u0065\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0046\u0072\u0061\u0063t\u0069\u006f\u006e\u0046\u006dt\u0054\u0079\u0070\u0065\u0054e\u0078\u0074";const _ecf int =-1;const _cfc int =34;
+func Number (v float64 ,f string )string {return NumberLocale (v ,f ,nil ) ;};const _df ="\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u004c\u0069\u0074\u0065\u0072a\u006c\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0044\u0069\u0067\u0069\u0074\u0046\u006d\u0074\u0054y\u0070\u0065\u0044i\u0067\u0069\u0074\u004f\u0070\u0074\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0043o\u006d\u006d\u0061\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0044\u0065\u0063\u0069\u006da\u006c\u0046\u006d\u0074\u0054\u0079\u0070\u0065Pe\u0072\u0063e\u006e\u0074\u0046\u006d\u0074\u0054\u0079\u0070e\u0044\u006f\u006c\u006c\u0061\u0072\u0046\u006d\u0074Ty\u0070\u0065\u0044i\u0067\u0069\u0074\u004f\u0070\u0074\u0054\u0068\u006f\u0075\u0073\u0061n\u0064\u0073\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0055n\u0064\u0065\u0072\u0073c\u006f\u0072\u0065\u0046\u006d\u0074T\u0079\u0070\u0065\u0044\u0061\u0074\u0065\u0046\u006d\u0074\u0054y\u0070e\u0054\u0069\u006d\u0065\u0046\u006d\u0074\u0054\u0079\u0070\u0065\u0046\u0072\u0061\u0063t\u0069\u006f\u006e\u0046\u006dt\u0054\u0079\u0070\u0065\u0054e\u0078\u0074";const _ecf int =-1;const _cfc int =34;
 
 // Format is a parsed number format.
 type Format struct{Whole []Token ;Fractional []Token ;Exponent []Token ;IsExponential bool ;_gaf bool ;_fa bool ;_bd bool ;_a bool ;_ef bool ;_cca bool ;_ba int64 ;_fg int ;};func _fec (_gb ,_adf float64 ,_eff Format )[]byte {if len (_eff .Fractional )==0{return nil ;};_bb :=_g .AppendFloat (nil ,_gb ,'f',-1,64);if len (_bb )> 2{_bb =_bb [2:];}else {_bb =nil ;};_aa :=make ([]byte ,0,len (_bb ));_aa =append (_aa ,'.');_bgf :=0;_ce :for _dcd :=0;_dcd < len (_eff .Fractional );_dcd ++{_cbf :=_dcd ;_fade :=_eff .Fractional [_dcd ];switch _fade .Type {case FmtTypeDigit :if _cbf < len (_bb ){_aa =append (_aa ,_bb [_cbf ]);_bgf ++;}else {_aa =append (_aa ,'0');};case FmtTypeDigitOpt :if _cbf >=0{_aa =append (_aa ,_bb [_cbf ]);_bgf ++;}else {break _ce ;};case FmtTypeLiteral :_aa =append (_aa ,_fade .Literal );default:_de .Log .Debug ("\u0075\u006e\u0073\u0075\u0070\u0070o\u0072\u0074\u0065\u0064\u0020\u0074\u0079\u0070\u0065\u0020\u0069\u006e\u0020f\u0072\u0061\u0063\u0074\u0069\u006f\u006ea\u006c\u0020\u0025\u0076",_fade );};};return _aa ;};type Lexer struct{_eggc Format ;_eacb []Format ;};
@@ -48,11 +48,11 @@ func (_dg *Format )AddToken (t FmtType ,l []byte ){if _dg ._ef {_dg ._ef =false
 
 // String returns the string formatted according to the type.  In format strings
 // this is the fourth item, where '@' is used as a placeholder for text.
-func String (v string ,f string )string {_fc :=Parse (f );var _cfb Format ;if len (_fc )==1{_cfb =_fc [0];}else if len (_fc )==4{_cfb =_fc [3];};_gd :=false ;for _ ,_cge :=range _cfb .Whole {if _cge .Type ==FmtTypeText {_gd =true ;};};if !_gd {return v ;};_gc :=_d .Buffer {};for _ ,_bae :=range _cfb .Whole {switch _bae .Type {case FmtTypeLiteral :_gc .WriteByte (_bae .Literal );case FmtTypeText :_gc .WriteString (v );};};return _gc .String ();};
+func String (v string ,f string )string {return StringLocale (v ,f ,nil ) ;};
 
 // NumberGeneric formats the number with the generic format which attemps to
 // mimic Excel's general formatting.
-func NumberGeneric (v float64 )string {if _f .Abs (v )>=_bc ||_f .Abs (v )<=_cc &&v !=0{return _bba (v );};_ccc :=make ([]byte ,0,15);_ccc =_g .AppendFloat (_ccc ,v ,'f',-1,64);if len (_ccc )> 11{_caf :=_ccc [11]-'0';if _caf >=5&&_caf <=9{_ccc [10]++;_ccc =_ccc [0:11];_ccc =_fgc (_ccc );};_ccc =_ccc [0:11];}else if len (_ccc )==11{if _ccc [len (_ccc )-1]=='9'{_ccc [len (_ccc )-1]++;_ccc =_fgc (_ccc );};};_ccc =_bgfg (_ccc );return string (_ccc );};func (_abb *Lexer )nextFmt (){_abb ._eacb =append (_abb ._eacb ,_abb ._eggc );_abb ._eggc =Format {}};const _fab int =0;const _cc =1e-10;func Parse (s string )[]Format {_efg :=Lexer {};_efg .Lex (_bfg .NewReader (s ));_efg ._eacb =append (_efg ._eacb ,_efg ._eggc );return _efg ._eacb ;};
+func NumberGeneric (v float64 )string {return excelGeneral (v )};func (_abb *Lexer )nextFmt (){_abb ._eacb =append (_abb ._eacb ,_abb ._eggc );_abb ._eggc =Format {}};const _fab int =0;const _cc =1e-10;func Parse (s string )[]Format {_efg :=Lexer {};_efg .Lex (_bfg .NewReader (s ));_efg ._eacb =append (_efg ._eacb ,_efg ._eggc );return _efg ._eacb ;};
 
 // FmtType is the type of a format token.
 //go:generate stringer -type=FmtType
@@ -0,0 +1,354 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package format
+
+import (
+	"strings"
+)
+
+// Locale contains the regional settings used when formatting numbers and
+// dates, such as the decimal separator and the names of months and days.
+// Format codes are always written with the en-US separators, i.e. "#,##0.00",
+// and the locale determines how the formatted values look.
+type Locale struct {
+	// Name is the language tag of the locale, e.g. "de-DE".
+	Name string
+	// LCID is the Windows locale identifier used in format codes such as
+	// [$-407].
+	LCID uint32
+
+	DecimalSeparator string
+	GroupSeparator   string
+
+	// MonthNames and MonthAbbreviations start with January.
+	MonthNames         [12]string
+	MonthAbbreviations [12]string
+	// DayNames and DayAbbreviations start with Sunday.
+	DayNames         [7]string
+	DayAbbreviations [7]string
+	AM, PM           string
+
+	// ShortDateFormat is used for the built-in date format 14, LongDateFormat
+	// and LongTimeFormat for the system date and time formats [$-F800] and
+	// [$-F400].
+	ShortDateFormat string
+	LongDateFormat  string
+	LongTimeFormat  string
+}
+
+var englishMonths = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+var englishMonthAbbreviations = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+var englishDays = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+var englishDayAbbreviations = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+var numberedMonths = [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"}
+var chineseMonths = [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"}
+var chineseDays = [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+
+var locales = []*Locale{
+	{
+		Name: "en-US", LCID: 0x409,
+		DecimalSeparator: ".", GroupSeparator: ",",
+		MonthNames: englishMonths, MonthAbbreviations: englishMonthAbbreviations,
+		DayNames: englishDays, DayAbbreviations: englishDayAbbreviations,
+		AM: "AM", PM: "PM",
+		ShortDateFormat: "m/d/yyyy", LongDateFormat: "dddd, mmmm d, yyyy", LongTimeFormat: "h:mm:ss AM/PM",
+	},
+	{
+		Name: "en-GB", LCID: 0x809,
+		DecimalSeparator: ".", GroupSeparator: ",",
+		MonthNames: englishMonths, MonthAbbreviations: englishMonthAbbreviations,
+		DayNames: englishDays, DayAbbreviations: englishDayAbbreviations,
+		AM: "AM", PM: "PM",
+		ShortDateFormat: "dd/mm/yyyy", LongDateFormat: "dd mmmm yyyy", LongTimeFormat: "hh:mm:ss",
+	},
+	{
+		Name: "de-DE", LCID: 0x407,
+		DecimalSeparator: ",", GroupSeparator: ".",
+		MonthNames:         [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthAbbreviations: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		DayNames:           [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		DayAbbreviations:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		AM:                 "AM", PM: "PM",
+		ShortDateFormat: "dd.mm.yyyy", LongDateFormat: "dddd, d. mmmm yyyy", LongTimeFormat: "hh:mm:ss",
+	},
+	{
+		Name: "fr-FR", LCID: 0x40c,
+		DecimalSeparator: ",", GroupSeparator: "\u00a0",
+		MonthNames:         [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		MonthAbbreviations: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		DayNames:           [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		DayAbbreviations:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		AM:                 "AM", PM: "PM",
+		ShortDateFormat: "dd/mm/yyyy", LongDateFormat: "dddd d mmmm yyyy", LongTimeFormat: "hh:mm:ss",
+	},
+	{
+		Name: "es-ES", LCID: 0xc0a,
+		DecimalSeparator: ",", GroupSeparator: ".",
+		MonthNames:         [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		MonthAbbreviations: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		DayNames:           [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		DayAbbreviations:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		AM:                 "a. m.", PM: "p. m.",
+		ShortDateFormat: "dd/mm/yyyy", LongDateFormat: `dddd, d "de" mmmm "de" yyyy`, LongTimeFormat: "h:mm:ss",
+	},
+	{
+		Name: "it-IT", LCID: 0x410,
+		DecimalSeparator: ",", GroupSeparator: ".",
+		MonthNames:         [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		MonthAbbreviations: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		DayNames:           [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		DayAbbreviations:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		AM:                 "AM", PM: "PM",
+		ShortDateFormat: "dd/mm/yyyy", LongDateFormat: "dddd d mmmm yyyy", LongTimeFormat: "hh:mm:ss",
+	},
+	{
+		Name: "nl-NL", LCID: 0x413,
+		DecimalSeparator: ",", GroupSeparator: ".",
+		MonthNames:         [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		MonthAbbreviations: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		DayNames:           [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		DayAbbreviations:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		AM:                 "a.m.", PM: "p.m.",
+		ShortDateFormat: "d-m-yyyy", LongDateFormat: "dddd d mmmm yyyy", LongTimeFormat: "hh:mm:ss",
+	},
+	{
+		Name: "pt-BR", LCID: 0x416,
+		DecimalSeparator: ",", GroupSeparator: ".",
+		MonthNames:         [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		MonthAbbreviations: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		DayNames:           [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		DayAbbreviations:   [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		AM:                 "AM", PM: "PM",
+		ShortDateFormat: "dd/mm/yyyy", LongDateFormat: `dddd, d "de" mmmm "de" yyyy`, LongTimeFormat: "hh:mm:ss",
+	},
+	{
+		Name: "ja-JP", LCID: 0x411,
+		DecimalSeparator: ".", GroupSeparator: ",",
+		MonthNames: numberedMonths, MonthAbbreviations: numberedMonths,
+		DayNames:         [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		DayAbbreviations: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		AM:               "午前", PM: "午後",
+		ShortDateFormat: "yyyy/m/d", LongDateFormat: `yyyy"年"m"月"d"日"`, LongTimeFormat: "h:mm:ss",
+	},
+	{
+		Name: "zh-CN", LCID: 0x804,
+		DecimalSeparator: ".", GroupSeparator: ",",
+		MonthNames: chineseMonths, MonthAbbreviations: numberedMonths,
+		DayNames:         chineseDays,
+		DayAbbreviations: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		AM:               "上午", PM: "下午",
+		ShortDateFormat: "yyyy/m/d", LongDateFormat: `yyyy"年"m"月"d"日"`, LongTimeFormat: "h:mm:ss",
+	},
+	{
+		Name: "zh-TW", LCID: 0x404,
+		DecimalSeparator: ".", GroupSeparator: ",",
+		MonthNames: chineseMonths, MonthAbbreviations: numberedMonths,
+		DayNames:         chineseDays,
+		DayAbbreviations: [7]string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		AM:               "上午", PM: "下午",
+		ShortDateFormat: "yyyy/m/d", LongDateFormat: `yyyy"年"m"月"d"日"`, LongTimeFormat: "AM/PM hh:mm:ss",
+	},
+	{
+		Name: "ko-KR", LCID: 0x412,
+		DecimalSeparator: ".", GroupSeparator: ",",
+		MonthNames:         [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		MonthAbbreviations: [12]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
+		DayNames:           [7]string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		DayAbbreviations:   [7]string{"일", "월", "화", "수", "목", "금", "토"},
+		AM:                 "오전", PM: "오후",
+		ShortDateFormat: "yyyy-mm-dd", LongDateFormat: `yyyy"년" m"월" d"일" dddd`, LongTimeFormat: "AM/PM h:mm:ss",
+	},
+}
+
+// DefaultLocale returns the en-US locale, which is used when no locale is
+// given.
+func DefaultLocale() *Locale { return locales[0] }
+
+// Locales returns the built-in locales.
+func Locales() []*Locale {
+	return append([]*Locale(nil), locales...)
+}
+
+// LookupLocale returns the built-in locale with a language tag such as
+// "de-DE", or the first locale of a language such as "de". It returns nil if
+// there is no such locale.
+func LookupLocale(name string) *Locale {
+	name = strings.Replace(name, "_", "-", -1)
+	for _, l := range locales {
+		if strings.EqualFold(l.Name, name) {
+			return l
+		}
+	}
+	for _, l := range locales {
+		if lang := strings.SplitN(l.Name, "-", 2)[0]; strings.EqualFold(lang, name) {
+			return l
+		}
+	}
+	return nil
+}
+
+// LookupLCID returns the built-in locale with a Windows locale identifier such
+// as 0x407, or nil if there is no such locale. Locales of the same language
+// are used for identifiers of other countries, e.g. de-DE for de-AT.
+func LookupLCID(lcid uint32) *Locale {
+	lcid &= 0xffff
+	for _, l := range locales {
+		if l.LCID == lcid {
+			return l
+		}
+	}
+	// the low ten bits are the language
+	for _, l := range locales {
+		if l.LCID&0x3ff == lcid&0x3ff {
+			return l
+		}
+	}
+	return nil
+}
+
+// language returns the language of the locale, e.g. "de".
+func (l *Locale) language() string {
+	return strings.ToLower(strings.SplitN(l.Name, "-", 2)[0])
+}
+
+// numerals are the digits and the units of the ideographic numbers used by
+// the [DBNum1] and [DBNum2] format codes.
+type numerals struct {
+	digits [10]string
+	// zero is written once for each run of zeros within a number, unless
+	// it's empty
+	zero string
+	// units are ten, hundred and thousand, and tenThousand and hundredMillion
+	// separate groups of four digits
+	units                       [3]string
+	tenThousand, hundredMillion string
+	// omitOne omits the digit one before ten, hundred and thousand, as in
+	// Japanese
+	omitOne bool
+}
+
+var dbnumNumerals = map[string][2]numerals{
+	"zh-CN": {
+		{digits: [10]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}, zero: "零", units: [3]string{"十", "百", "千"}, tenThousand: "万", hundredMillion: "亿"},
+		{digits: [10]string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖"}, zero: "零", units: [3]string{"拾", "佰", "仟"}, tenThousand: "万", hundredMillion: "亿"},
+	},
+	"zh-TW": {
+		{digits: [10]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}, zero: "零", units: [3]string{"十", "百", "千"}, tenThousand: "萬", hundredMillion: "億"},
+		{digits: [10]string{"零", "壹", "貳", "參", "肆", "伍", "陸", "柒", "捌", "玖"}, zero: "零", units: [3]string{"拾", "佰", "仟"}, tenThousand: "萬", hundredMillion: "億"},
+	},
+	"ja": {
+		{digits: [10]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}, units: [3]string{"十", "百", "千"}, tenThousand: "万", hundredMillion: "億", omitOne: true},
+		{digits: [10]string{"〇", "壱", "弐", "参", "四", "伍", "六", "七", "八", "九"}, units: [3]string{"拾", "百", "阡"}, tenThousand: "萬", hundredMillion: "億"},
+	},
+	"ko": {
+		{digits: [10]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}, units: [3]string{"十", "百", "千"}, tenThousand: "万", hundredMillion: "億", omitOne: true},
+		{digits: [10]string{"零", "壹", "貳", "參", "四", "伍", "六", "七", "八", "九"}, units: [3]string{"拾", "百", "阡"}, tenThousand: "萬", hundredMillion: "億"},
+	},
+}
+
+// numerals returns the numerals of the locale for [DBNum1] (kind 1) and
+// [DBNum2] (kind 2). Locales without ideographic numerals use the simplified
+// Chinese ones.
+func (l *Locale) numerals(kind int) numerals {
+	n, ok := dbnumNumerals[l.Name]
+	if !ok {
+		n, ok = dbnumNumerals[l.language()]
+	}
+	if !ok {
+		n = dbnumNumerals["zh-CN"]
+	}
+	return n[kind-1]
+}
+
+// dbnum converts the ASCII digits of s for the [DBNum1], [DBNum2] and [DBNum3]
+// format codes. If units is set, each run of digits is written as a number
+// with units, e.g. 一百二十三, otherwise digit by digit, as for years and
+// decimals.
+func (l *Locale) dbnum(s string, kind int, units bool) string {
+	if kind < 1 || kind > 3 {
+		return s
+	}
+	sb := strings.Builder{}
+	for i := 0; i < len(s); {
+		if s[i] < '0' || s[i] > '9' {
+			sb.WriteByte(s[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		digits := s[i:j]
+		i = j
+		if kind == 3 {
+			// full width digits
+			for _, d := range digits {
+				sb.WriteRune('０' + d - '0')
+			}
+			continue
+		}
+		n := l.numerals(kind)
+		if !units || len(digits) > 12 {
+			for _, d := range digits {
+				sb.WriteString(n.digits[d-'0'])
+			}
+			continue
+		}
+		sb.WriteString(n.number(digits))
+	}
+	return sb.String()
+}
+
+// number writes a run of digits as a number with units.
+func (n numerals) number(digits string) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return n.digits[0]
+	}
+	sb := strings.Builder{}
+	zero := false
+	for i := 0; i < len(digits); i++ {
+		d := digits[i] - '0'
+		pos := len(digits) - 1 - i
+		if d == 0 {
+			zero = true
+		} else {
+			if zero && sb.Len() > 0 {
+				sb.WriteString(n.zero)
+			}
+			zero = false
+			unit := pos % 4
+			if !(d == 1 && unit > 0 && n.omitOne) {
+				sb.WriteString(n.digits[d])
+			}
+			if unit > 0 {
+				sb.WriteString(n.units[unit-1])
+			}
+		}
+		if pos%4 == 0 && pos > 0 {
+			// write the unit of the group unless all its digits are zero
+			group := digits[max(0, i-3) : i+1]
+			if strings.Trim(group, "0") != "" {
+				if pos == 4 {
+					sb.WriteString(n.tenThousand)
+				} else {
+					sb.WriteString(n.hundredMillion)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,1402 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package format
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NumberLocale formats a number with a format code like Excel does, using the
+// separators and names of a locale. If the locale is nil, en-US is used.
+//
+// The following format codes are supported. The examples show the value, the
+// format code and the formatted value for the en-US locale unless noted
+// otherwise.
+//
+//	Sections      -5 with `0.0;(0.0);"zero"` is "(5.0)", 0 is "zero"
+//	Conditions    150 with `[>100]"big";[<0]"neg";0` is "big", -3 is "neg"
+//	Colors        5 with `[Red]0` is "5", see NumberColor for the color
+//	Digits        5.5 with `000.00` is "005.50", with `#.##` is "5.5" and with `??.??` is " 5.5 "
+//	Grouping      1234567.891 with `#,##0.00` is "1,234,567.89" and "1.234.567,89" for de-DE
+//	Scaling       1234567 with `#,##0,"K"` is "1,235K" and with `0.0,,` is "1.2"
+//	Percent       0.125 with `0.0%` is "12.5%"
+//	Scientific    12345 with `0.00E+00` is "1.23E+04" and with `##0.0E+0` is "12.3E+3"
+//	Fractions     5.25 with `# ?/?` is "5 1/4", 0.3 with `?/8` is "2/8" and 1.5 with `?/?` is "3/2"
+//	Dates         45000 with `yyyy-mm-dd dddd` is "2023-03-15 Wednesday" and with `d mmmm` is "15 März" for de-DE
+//	Times         0.75 with `h:mm AM/PM` is "6:00 PM" and 0.5000058 with `hh:mm:ss.00` is "12:00:00.50"
+//	Elapsed time  1.5 with `[h]:mm` is "36:00" and with `[mm]:ss` is "2160:00"
+//	Locales       45000 with `[$-407]mmmm` is "März", with `[$-F800]` the long date of the locale
+//	Currencies    5 with `[$€-x-sysdec]#,##0.00` is "€5.00" and "€5,00" for de-DE
+//	Text          "abc" with `0;-0;0;"Text: "@` is "Text: abc"
+//	Fill and skip 5 with `_(0_)` is " 5 " and with `*-0` is "5"
+//	Literals      5 with `\#0"pcs"` is "#5pcs"
+//	DBNum         123 with `[DBNum1]General` is "一百二十三" and with `[DBNum2]0` is "壹佰贰拾叁"
+//
+// Dates use the 1900 date system of Excel, including its leap day on
+// February 29th, 1900. Negative dates and dates after 9999 are shown as a
+// row of number signs as Excel does. Fill characters are not repeated
+// because there is no column width to fill.
+func NumberLocale(v float64, f string, loc *Locale) string {
+	if loc == nil {
+		loc = DefaultLocale()
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "#NUM!"
+	}
+	if f == "" {
+		return generalNumber(v, loc)
+	}
+	sections := parseSections(f)
+	s, negative := chooseSection(sections, v)
+	if s == nil {
+		return generalNumber(v, loc)
+	}
+	return s.number(v, negative, loc)
+}
+
+// StringLocale formats text with the text section of a format code, which is
+// the fourth section or a last section containing the @ placeholder. Text is
+// returned unchanged if there is no text section.
+func StringLocale(v string, f string, loc *Locale) string {
+	if loc == nil {
+		loc = DefaultLocale()
+	}
+	sections := parseSections(f)
+	s := textSection(sections)
+	if s == nil {
+		return v
+	}
+	return s.text(v, loc)
+}
+
+// ValueLocale formats a value as a number or as text depending on whether it
+// appears to be a number.
+func ValueLocale(v string, f string, loc *Locale) string {
+	if IsNumber(v) {
+		n, _ := strconv.ParseFloat(v, 64)
+		return NumberLocale(n, f, loc)
+	}
+	return StringLocale(v, f, loc)
+}
+
+// NumberColor returns the color of the section of a format code that is used
+// to format a number, e.g. "Red" for -5 with `0;[Red]-0`, or an empty string
+// if the section has no color. Numbered colors are returned as "Color10".
+func NumberColor(v float64, f string) string {
+	s, _ := chooseSection(parseSections(f), v)
+	if s == nil {
+		return ""
+	}
+	return s.color
+}
+
+// hashes is shown instead of dates that can't be displayed.
+const hashes = "########"
+
+type itemKind byte
+
+const (
+	itemLiteral   itemKind = iota
+	itemDigit              // 0, # or ?
+	itemDecimal            // .
+	itemComma              // ,
+	itemPercent            // %
+	itemExponent           // E+ or E-
+	itemSlash              // /
+	itemText               // @
+	itemGeneral            // General
+	itemDate               // date and time codes such as yyyy, mm, [h] or AM/PM
+	itemSubsecond          // .00 after seconds
+)
+
+// item is a part of a section of a format code.
+type item struct {
+	kind itemKind
+	text string
+}
+
+// condition is a condition such as [>100] that selects a section.
+type condition struct {
+	op    string
+	value float64
+}
+
+func (c *condition) matches(v float64) bool {
+	switch c.op {
+	case "<":
+		return v < c.value
+	case "<=":
+		return v <= c.value
+	case ">":
+		return v > c.value
+	case ">=":
+		return v >= c.value
+	case "=":
+		return v == c.value
+	case "<>":
+		return v != c.value
+	}
+	return false
+}
+
+// negativeOnly returns true if the condition only matches negative numbers,
+// in which case the section formats the absolute value.
+func (c *condition) negativeOnly() bool {
+	return c.op == "<" && c.value <= 0 || c.op == "<=" && c.value < 0
+}
+
+// section is one of the up to four sections of a format code, which are
+// separated by semicolons.
+type section struct {
+	items  []item
+	cond   *condition
+	color  string
+	locale *Locale
+	dbnum  int
+
+	date, hasText, general bool
+}
+
+var colorNames = []string{"Black", "Blue", "Cyan", "Green", "Magenta", "Red", "White", "Yellow"}
+
+// parseSections splits a format code into its sections.
+func parseSections(f string) []*section {
+	var sections []*section
+	start := 0
+	for i := 0; i < len(f); i++ {
+		switch f[i] {
+		case '"':
+			if j := strings.IndexByte(f[i+1:], '"'); j >= 0 {
+				i += j + 1
+			} else {
+				i = len(f)
+			}
+		case '\\', '!', '_', '*':
+			i++
+		case '[':
+			if j := strings.IndexByte(f[i:], ']'); j >= 0 {
+				i += j
+			}
+		case ';':
+			sections = append(sections, parseSection(f[start:i]))
+			start = i + 1
+		}
+	}
+	return append(sections, parseSection(f[start:]))
+}
+
+// parseSection parses a single section of a format code.
+func parseSection(f string) *section {
+	s := &section{}
+	lastDate := ""
+	for i := 0; i < len(f); {
+		r, size := utf8.DecodeRuneInString(f[i:])
+		lower := unicode.ToLower(r)
+		switch {
+		case r == '"':
+			end := strings.IndexByte(f[i+1:], '"')
+			if end < 0 {
+				end = len(f) - i - 1
+			}
+			s.literal(f[i+1 : i+1+end])
+			i += end + 2
+			continue
+		case r == '\\' || r == '!':
+			_, n := utf8.DecodeRuneInString(f[i+size:])
+			s.literal(f[i+size : i+size+n])
+			i += size + n
+			continue
+		case r == '_':
+			// a space as wide as the next character
+			_, n := utf8.DecodeRuneInString(f[i+size:])
+			s.literal(" ")
+			i += size + n
+			continue
+		case r == '*':
+			// fill the column with the next character
+			_, n := utf8.DecodeRuneInString(f[i+size:])
+			i += size + n
+			continue
+		case r == '[':
+			end := strings.IndexByte(f[i:], ']')
+			if end < 0 {
+				end = len(f) - i
+			}
+			s.bracket(f[i+1 : i+end])
+			if lastCode := s.lastDateCode(); lastCode != "" {
+				lastDate = lastCode
+			}
+			i += end + 1
+			continue
+		case r == '0' || r == '#' || r == '?':
+			s.items = append(s.items, item{kind: itemDigit, text: string(r)})
+		case r == '.':
+			if (lastDate == "s" || lastDate == "[s]") && i+1 < len(f) && f[i+1] == '0' {
+				n := 1
+				for i+n < len(f) && f[i+n] == '0' {
+					n++
+				}
+				s.items = append(s.items, item{kind: itemSubsecond, text: f[i+1 : i+n]})
+				i += n
+				continue
+			}
+			s.items = append(s.items, item{kind: itemDecimal})
+		case r == ',':
+			s.items = append(s.items, item{kind: itemComma})
+		case r == '%':
+			s.items = append(s.items, item{kind: itemPercent})
+		case r == '/':
+			s.items = append(s.items, item{kind: itemSlash})
+		case r == '@':
+			s.hasText = true
+			s.items = append(s.items, item{kind: itemText})
+		case lower == 'e' && i+1 < len(f) && (f[i+1] == '+' || f[i+1] == '-'):
+			s.items = append(s.items, item{kind: itemExponent, text: f[i : i+2]})
+			i += 2
+			continue
+		case len(f)-i >= 7 && strings.EqualFold(f[i:i+7], "general"):
+			s.general = true
+			s.items = append(s.items, item{kind: itemGeneral})
+			i += 7
+			continue
+		case len(f)-i >= 5 && strings.EqualFold(f[i:i+5], "am/pm"):
+			s.dateItem(f[i : i+5])
+			lastDate = "ampm"
+			i += 5
+			continue
+		case len(f)-i >= 3 && strings.EqualFold(f[i:i+3], "a/p"):
+			s.dateItem(f[i : i+3])
+			lastDate = "ampm"
+			i += 3
+			continue
+		case strings.ContainsRune("ymdhsebg", lower) || lower == 'a' && len(f)-i >= 3 && strings.EqualFold(f[i:i+3], "aaa"):
+			n := 1
+			for i+n < len(f) && unicode.ToLower(rune(f[i+n])) == lower {
+				n++
+			}
+			code := strings.ToLower(f[i : i+n])
+			s.dateItem(code)
+			lastDate = code[:1]
+			i += n
+			continue
+		default:
+			s.literal(string(r))
+		}
+		i += size
+	}
+	return s
+}
+
+func (s *section) literal(text string) {
+	s.items = append(s.items, item{kind: itemLiteral, text: text})
+}
+
+func (s *section) dateItem(code string) {
+	s.date = true
+	s.items = append(s.items, item{kind: itemDate, text: code})
+}
+
+// lastDateCode returns the kind of the last item if it's an elapsed time.
+func (s *section) lastDateCode() string {
+	if len(s.items) == 0 {
+		return ""
+	}
+	last := s.items[len(s.items)-1]
+	if last.kind != itemDate || !strings.HasPrefix(last.text, "[") {
+		return ""
+	}
+	return "[" + last.text[1:2] + "]"
+}
+
+// bracket handles the contents of square brackets in a format code.
+func (s *section) bracket(b string) {
+	lower := strings.ToLower(b)
+	switch {
+	case strings.HasPrefix(b, "$"):
+		s.currency(b[1:])
+	case len(b) > 0 && strings.IndexByte("<>=", b[0]) >= 0:
+		op := b[:1]
+		if len(b) > 1 && (b[1] == '=' || b[1] == '>') {
+			op = b[:2]
+		}
+		if v, err := strconv.ParseFloat(strings.TrimSpace(b[len(op):]), 64); err == nil {
+			s.cond = &condition{op: op, value: v}
+		}
+	case strings.HasPrefix(lower, "dbnum"):
+		s.dbnum, _ = strconv.Atoi(b[5:])
+	case strings.HasPrefix(lower, "color"):
+		if _, err := strconv.Atoi(b[5:]); err == nil {
+			s.color = "Color" + b[5:]
+		}
+	case lower != "" && (strings.Trim(lower, "h") == "" || strings.Trim(lower, "m") == "" || strings.Trim(lower, "s") == ""):
+		s.dateItem("[" + lower + "]")
+	default:
+		for _, c := range colorNames {
+			if strings.EqualFold(b, c) {
+				s.color = c
+			}
+		}
+	}
+}
+
+// currency handles [$sym-locale] codes, which show a currency symbol and may
+// select a locale, e.g. [$€-407], [$-409] or [$€-x-sysdec].
+func (s *section) currency(b string) {
+	sym, loc := b, ""
+	if i := strings.IndexByte(b, '-'); i >= 0 {
+		sym, loc = b[:i], b[i+1:]
+	}
+	if sym != "" {
+		s.literal(sym)
+	}
+	switch strings.ToLower(loc) {
+	case "x-sysdate":
+		s.items = append(s.items, item{kind: itemDate, text: "sysdate"})
+		s.date = true
+		return
+	case "x-systime":
+		s.items = append(s.items, item{kind: itemDate, text: "systime"})
+		s.date = true
+		return
+	}
+	id, err := strconv.ParseUint(loc, 16, 32)
+	if err != nil {
+		return
+	}
+	switch id & 0xffff {
+	case 0xf800:
+		s.items = append(s.items, item{kind: itemDate, text: "sysdate"})
+		s.date = true
+	case 0xf400:
+		s.items = append(s.items, item{kind: itemDate, text: "systime"})
+		s.date = true
+	default:
+		if l := LookupLCID(uint32(id)); l != nil {
+			s.locale = l
+		}
+	}
+}
+
+// chooseSection returns the section used to format a number and whether the
+// number should be shown with a minus sign.
+func chooseSection(sections []*section, v float64) (*section, bool) {
+	numeric := sections
+	if n := len(sections); n > 1 && n < 4 && sections[n-1].hasText || n > 4 {
+		numeric = sections[:n-1]
+	}
+	if len(numeric) > 3 {
+		numeric = numeric[:3]
+	}
+	if len(numeric) == 1 && numeric[0].hasText && !numeric[0].date && !numeric[0].general && !numeric[0].hasPlaceholders() {
+		// a text only format such as @
+		return nil, v < 0
+	}
+	first := numeric[0]
+	if first.cond != nil || len(numeric) > 1 && numeric[1].cond != nil {
+		for i, s := range numeric {
+			if i == 2 {
+				break
+			}
+			if s.cond != nil && s.cond.matches(v) {
+				return s, v < 0 && !s.cond.negativeOnly()
+			}
+		}
+		var s *section
+		switch {
+		case len(numeric) == 3:
+			s = numeric[2]
+		case len(numeric) == 2 && numeric[1].cond == nil:
+			s = numeric[1]
+		default:
+			return nil, v < 0
+		}
+		return s, v < 0
+	}
+	switch {
+	case len(numeric) == 1 || v > 0:
+		return first, v < 0
+	case v < 0:
+		return numeric[1], false
+	case len(numeric) == 3:
+		return numeric[2], false
+	}
+	return first, false
+}
+
+// textSection returns the section used to format text.
+func textSection(sections []*section) *section {
+	if len(sections) >= 4 {
+		return sections[3]
+	}
+	if last := sections[len(sections)-1]; last.hasText {
+		return last
+	}
+	return nil
+}
+
+// text formats text with the section.
+func (s *section) text(v string, loc *Locale) string {
+	sb := strings.Builder{}
+	for _, it := range s.items {
+		switch it.kind {
+		case itemText:
+			sb.WriteString(v)
+		case itemLiteral, itemDigit, itemExponent, itemDate:
+			sb.WriteString(it.text)
+		case itemDecimal:
+			sb.WriteByte('.')
+		case itemComma:
+			sb.WriteByte(',')
+		case itemPercent:
+			sb.WriteByte('%')
+		case itemSlash:
+			sb.WriteByte('/')
+		}
+	}
+	return sb.String()
+}
+
+// number formats a number with the section.
+func (s *section) number(v float64, negative bool, loc *Locale) string {
+	if s.date {
+		return s.formatDate(v, loc)
+	}
+	v = math.Abs(v)
+	var out string
+	switch {
+	case s.general && !s.hasPlaceholders():
+		out = s.formatGeneral(v, loc)
+	default:
+		out = s.formatNumber(v, loc)
+	}
+	if s.dbnum > 0 {
+		names := loc
+		if s.locale != nil {
+			names = s.locale
+		}
+		out = s.convertDBNum(out, loc.DecimalSeparator, names)
+	}
+	if negative {
+		out = "-" + out
+	}
+	return out
+}
+
+func (s *section) hasPlaceholders() bool {
+	for _, it := range s.items {
+		if it.kind == itemDigit {
+			return true
+		}
+	}
+	return false
+}
+
+// formatGeneral formats a section such as `"Total: "General`.
+func (s *section) formatGeneral(v float64, loc *Locale) string {
+	sb := strings.Builder{}
+	for _, it := range s.items {
+		switch it.kind {
+		case itemGeneral:
+			sb.WriteString(generalNumber(v, loc))
+		case itemLiteral, itemDate:
+			sb.WriteString(it.text)
+		case itemDecimal:
+			sb.WriteString(loc.DecimalSeparator)
+		case itemComma:
+			sb.WriteString(loc.GroupSeparator)
+		case itemPercent:
+			sb.WriteByte('%')
+		case itemSlash:
+			sb.WriteByte('/')
+		}
+	}
+	return sb.String()
+}
+
+// generalNumber formats a number with the General format and the decimal
+// separator of a locale.
+func generalNumber(v float64, loc *Locale) string {
+	out := excelGeneral(v)
+	if loc.DecimalSeparator != "." {
+		out = strings.Replace(out, ".", loc.DecimalSeparator, 1)
+	}
+	return out
+}
+
+// excelGeneral formats a number like the General format of Excel in a column
+// of the default width. Numbers are shown with up to 11 characters, 12 with a
+// minus sign, and in scientific notation with up to six significant digits if
+// they don't fit, e.g. 1E+20 and 1.23456E-07.
+func excelGeneral(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+	width := 11
+	if v < 0 {
+		width = 12
+	}
+	e := decimalExponent(math.Abs(v))
+	out := ""
+	switch {
+	case e >= -4 && e <= -1:
+		out = toPrecision(v, 10+e)
+	case e >= -9 && e <= 9:
+		out = trimDecimals(strconv.FormatFloat(v, 'f', 12, 64))
+		if len(out) > width {
+			out = toPrecision(v, 10)
+		}
+		if len(out) > width {
+			out = strconv.FormatFloat(v, 'e', 5, 64)
+		}
+	case e == 10:
+		out = strconv.FormatFloat(v, 'f', 10, 64)[:width+1]
+	default:
+		out = trimDecimals(strconv.FormatFloat(v, 'f', 11, 64))
+		if len(out) > width || out == "0" || out == "-0" {
+			out = toPrecision(v, 6)
+		}
+	}
+	out = trimDecimals(out)
+	i := strings.IndexByte(out, 'e')
+	if i < 0 {
+		return out
+	}
+	// the mantissa has no trailing zeros and the exponent at least two
+	// digits
+	exp, _ := strconv.Atoi(out[i+1:])
+	sign := "+"
+	if exp < 0 {
+		sign, exp = "-", -exp
+	}
+	digits := strconv.Itoa(exp)
+	if len(digits) < 2 {
+		digits = "0" + digits
+	}
+	return trimDecimals(out[:i]) + "E" + sign + digits
+}
+
+// toPrecision formats a number with p significant digits, in scientific
+// notation if its exponent is below -6 or at least p.
+func toPrecision(v float64, p int) string {
+	s := strconv.FormatFloat(v, 'e', p-1, 64)
+	e, _ := strconv.Atoi(s[strings.IndexByte(s, 'e')+1:])
+	if e < -6 || e >= p {
+		return s
+	}
+	return strconv.FormatFloat(v, 'f', p-1-e, 64)
+}
+
+// trimDecimals removes trailing zeros after the decimal point and the point
+// itself if no decimals remain.
+func trimDecimals(s string) string {
+	if !strings.Contains(s, ".") || strings.ContainsAny(s, "eE") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// convertDBNum converts the digits of a formatted number for [DBNum] codes.
+// The integer part is written with units and the decimals digit by digit.
+func (s *section) convertDBNum(out, decimal string, loc *Locale) string {
+	if i := strings.Index(out, decimal); i >= 0 {
+		return loc.dbnum(out[:i], s.dbnum, true) + decimal + loc.dbnum(out[i+len(decimal):], s.dbnum, false)
+	}
+	return loc.dbnum(out, s.dbnum, true)
+}
+
+// numberLayout describes the placeholders of a section for numbers.
+type numberLayout struct {
+	// integer, fraction and exponent are the indexes of the items of the
+	// integer part, the decimals and the exponent
+	integer, decimals, exponent []int
+	decimal                     int // index of the decimal point or -1
+	exp                         int // index of the exponent or -1
+	group                       bool
+	scale, percent              int
+	// numerator and denominator are the indexes of the items of a fraction,
+	// and fixed is a literal denominator such as 8 in `# ?/8`
+	numerator, denominator []int
+	slash                  int
+	fixed                  int64
+}
+
+func (s *section) layout() numberLayout {
+	l := numberLayout{decimal: -1, exp: -1, slash: -1}
+	items := s.items
+	for i, it := range items {
+		switch it.kind {
+		case itemPercent:
+			l.percent++
+		case itemDecimal:
+			if l.decimal < 0 && l.exp < 0 {
+				l.decimal = i
+			}
+		case itemExponent:
+			if l.exp < 0 {
+				l.exp = i
+			}
+		case itemSlash:
+			if l.slash < 0 && l.exp < 0 && l.decimal < 0 && i > 0 && items[i-1].kind == itemDigit {
+				l.slash = i
+			}
+		}
+	}
+	if l.slash >= 0 {
+		i := l.slash - 1
+		for i >= 0 && items[i].kind == itemDigit {
+			l.numerator = append([]int{i}, l.numerator...)
+			i--
+		}
+		// the integer part is separated from the numerator by literals
+		for j := 0; j < i; j++ {
+			if items[j].kind == itemDigit {
+				l.integer = append(l.integer, j)
+			}
+		}
+		// a denominator starting with a digit other than zero is fixed, e.g.
+		// 8 in # ?/8 or 100 in # ?/100
+		digits := ""
+	denominator:
+		for j := l.slash + 1; j < len(items); j++ {
+			it := items[j]
+			switch {
+			case isDenominatorDigit(it) && len(l.denominator) == 0:
+				digits += it.text
+				items[j] = item{kind: itemLiteral, text: it.text}
+			case it.kind == itemDigit && digits == "":
+				l.denominator = append(l.denominator, j)
+			default:
+				break denominator
+			}
+		}
+		l.fixed, _ = strconv.ParseInt(digits, 10, 64)
+		if len(l.denominator) == 0 && l.fixed == 0 {
+			// not a fraction after all
+			l.slash, l.numerator, l.integer = -1, nil, nil
+		} else {
+			s.scaleCommas(&l, l.slash)
+			return l
+		}
+	}
+	end := len(items)
+	if l.exp >= 0 {
+		end = l.exp
+		for j := l.exp + 1; j < len(items); j++ {
+			if items[j].kind == itemDigit {
+				l.exponent = append(l.exponent, j)
+			}
+		}
+	}
+	for j := 0; j < end; j++ {
+		if items[j].kind != itemDigit {
+			continue
+		}
+		if l.decimal >= 0 && j > l.decimal {
+			l.decimals = append(l.decimals, j)
+		} else {
+			l.integer = append(l.integer, j)
+		}
+	}
+	s.scaleCommas(&l, end)
+	return l
+}
+
+// scaleCommas determines whether commas group thousands or scale the number.
+// A comma between digits of the integer part enables grouping, commas after
+// the last digit divide the number by a thousand each.
+func (s *section) scaleCommas(l *numberLayout, end int) {
+	firstDigit, lastDigit := -1, -1
+	for j := 0; j < end; j++ {
+		if s.items[j].kind == itemDigit {
+			if firstDigit < 0 {
+				firstDigit = j
+			}
+			lastDigit = j
+		}
+	}
+	intEnd := end
+	if l.decimal >= 0 {
+		intEnd = l.decimal
+	}
+	for j := 0; j < end; j++ {
+		if s.items[j].kind != itemComma {
+			continue
+		}
+		switch {
+		case firstDigit >= 0 && j > firstDigit && j < intEnd && hasDigitBetween(s.items, j, intEnd):
+			l.group = true
+			s.items[j].kind = itemLiteral
+			s.items[j].text = ""
+		case lastDigit >= 0 && j > lastDigit && onlyCommasBetween(s.items, lastDigit, j):
+			l.scale++
+			s.items[j].kind = itemLiteral
+			s.items[j].text = ""
+		}
+	}
+}
+
+func isDenominatorDigit(it item) bool {
+	return it.kind == itemLiteral && len(it.text) == 1 && it.text >= "1" && it.text <= "9" ||
+		it.kind == itemDigit && it.text == "0"
+}
+
+func hasDigitBetween(items []item, from, to int) bool {
+	for j := from; j < to; j++ {
+		if items[j].kind == itemDigit {
+			return true
+		}
+	}
+	return false
+}
+
+func onlyCommasBetween(items []item, from, to int) bool {
+	for j := from + 1; j < to; j++ {
+		if items[j].kind != itemComma && !(items[j].kind == itemLiteral && items[j].text == "") {
+			return false
+		}
+	}
+	return true
+}
+
+// formatNumber formats a number with the digit placeholders of the section.
+func (s *section) formatNumber(v float64, loc *Locale) string {
+	items := append([]item(nil), s.items...)
+	sc := &section{items: items}
+	l := sc.layout()
+	for i := 0; i < l.percent; i++ {
+		v *= 100
+	}
+	for i := 0; i < l.scale; i++ {
+		v /= 1000
+	}
+
+	out := make([]string, len(items))
+	handled := make([]bool, len(items))
+	switch {
+	case l.slash >= 0:
+		sc.fillFraction(v, l, out, handled, loc)
+	case l.exp >= 0:
+		sc.fillExponent(v, l, out, handled, loc)
+	default:
+		intDigits, decDigits := roundDecimal(v, len(l.decimals))
+		sc.fillInteger(intDigits, l, out, handled, loc)
+		fillDecimals(decDigits, l, items, out, handled)
+	}
+	sb := strings.Builder{}
+	for i, it := range items {
+		if handled[i] {
+			sb.WriteString(out[i])
+			continue
+		}
+		switch it.kind {
+		case itemLiteral, itemDate, itemExponent:
+			sb.WriteString(it.text)
+		case itemDecimal:
+			if i == l.decimal {
+				sb.WriteString(loc.DecimalSeparator)
+			} else {
+				sb.WriteByte('.')
+			}
+		case itemComma:
+			sb.WriteByte(',')
+		case itemPercent:
+			sb.WriteByte('%')
+		case itemSlash:
+			sb.WriteByte('/')
+		case itemGeneral:
+			sb.WriteString(generalNumber(v, loc))
+		}
+	}
+	return sb.String()
+}
+
+// pad returns what a placeholder shows without a digit.
+func pad(placeholder string) string {
+	switch placeholder {
+	case "0":
+		return "0"
+	case "?":
+		return " "
+	}
+	return ""
+}
+
+// fillInteger fills the placeholders of the integer part with the digits of
+// the integer, grouping thousands if needed.
+func (s *section) fillInteger(digits string, l numberLayout, out []string, handled []bool, loc *Locale) {
+	digits = strings.TrimLeft(digits, "0")
+	n := len(l.integer)
+	if n == 0 {
+		// digits are shown even without placeholders, e.g. 5.5 with .00
+		if digits != "" {
+			pos := l.decimal
+			if pos < 0 {
+				pos = len(s.items)
+			}
+			if pos < len(out) {
+				out[pos] = groupDigits(digits, l.group, loc) + loc.DecimalSeparator
+				handled[pos] = true
+			}
+		}
+		return
+	}
+	// each placeholder shows one digit, the first shows all remaining ones
+	shown := make([]string, n)
+	for k := 0; k < n; k++ {
+		r := n - 1 - k
+		if r < len(digits) {
+			shown[k] = string(digits[len(digits)-1-r])
+		} else {
+			shown[k] = pad(s.items[l.integer[k]].text)
+		}
+	}
+	if len(digits) > n {
+		shown[0] = digits[:len(digits)-n] + shown[0]
+	}
+	if l.group {
+		// insert separators counting the characters shown from the right
+		count := 0
+		for k := n - 1; k >= 0; k-- {
+			chars := []rune(shown[k])
+			sb := make([]string, 0, len(chars))
+			for c := len(chars) - 1; c >= 0; c-- {
+				if count > 0 && count%3 == 0 {
+					if chars[c] == ' ' {
+						sb = append(sb, " ")
+					} else {
+						sb = append(sb, loc.GroupSeparator)
+					}
+				}
+				sb = append(sb, string(chars[c]))
+				count++
+			}
+			for i, j := 0, len(sb)-1; i < j; i, j = i+1, j-1 {
+				sb[i], sb[j] = sb[j], sb[i]
+			}
+			shown[k] = strings.Join(sb, "")
+		}
+	}
+	for k, idx := range l.integer {
+		out[idx] = shown[k]
+		handled[idx] = true
+	}
+}
+
+func groupDigits(digits string, group bool, loc *Locale) string {
+	if !group {
+		return digits
+	}
+	sb := strings.Builder{}
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteString(loc.GroupSeparator)
+		}
+		sb.WriteByte(digits[i])
+	}
+	return sb.String()
+}
+
+// fillDecimals fills the placeholders of the decimals. Trailing zeros are
+// dropped for # and shown as spaces for ?.
+func fillDecimals(digits string, l numberLayout, items []item, out []string, handled []bool) {
+	last := len(l.decimals) - 1
+	for last >= 0 && items[l.decimals[last]].text != "0" && digits[last] == '0' {
+		last--
+	}
+	for k, idx := range l.decimals {
+		handled[idx] = true
+		if k <= last {
+			out[idx] = digits[k : k+1]
+		} else {
+			out[idx] = pad(items[idx].text)
+		}
+	}
+}
+
+// fillExponent fills the placeholders of a number in scientific notation.
+func (s *section) fillExponent(v float64, l numberLayout, out []string, handled []bool, loc *Locale) {
+	intCount := len(l.integer)
+	engineering := false
+	for _, idx := range l.integer {
+		if s.items[idx].text == "#" {
+			engineering = true
+		}
+	}
+	exp := 0
+	var intDigits, decDigits string
+	if v == 0 {
+		intDigits, decDigits = roundDecimal(0, len(l.decimals))
+	} else {
+		mag := decimalExponent(v)
+		for attempt := 0; attempt < 2; attempt++ {
+			switch {
+			case engineering && intCount > 0:
+				exp = floorDiv(mag, intCount) * intCount
+			case intCount > 0:
+				exp = mag - intCount + 1
+			default:
+				exp = mag + 1
+			}
+			intDigits, decDigits = roundDecimal(v/math.Pow10(exp), len(l.decimals))
+			// rounding may add a digit, e.g. 9.99 to 10.0
+			if len(strings.TrimLeft(intDigits, "0")) <= max(intCount, 1) || attempt == 1 {
+				break
+			}
+			mag++
+		}
+	}
+	s.fillInteger(intDigits, l, out, handled, loc)
+	fillDecimals(decDigits, l, s.items, out, handled)
+
+	sign := ""
+	if exp < 0 {
+		sign = "-"
+	} else if s.items[l.exp].text[1] == '+' {
+		sign = "+"
+	}
+	out[l.exp] = s.items[l.exp].text[:1] + sign
+	handled[l.exp] = true
+	digits := strconv.Itoa(absInt(exp))
+	n := len(l.exponent)
+	for k, idx := range l.exponent {
+		r := n - 1 - k
+		if r < len(digits) {
+			out[idx] = string(digits[len(digits)-1-r])
+		} else {
+			out[idx] = pad(s.items[idx].text)
+		}
+		if k == 0 && len(digits) > n {
+			out[idx] = digits[:len(digits)-n] + out[idx]
+		}
+		handled[idx] = true
+	}
+}
+
+// fillFraction fills the placeholders of a fraction such as # ?/?.
+func (s *section) fillFraction(v float64, l numberLayout, out []string, handled []bool, loc *Locale) {
+	whole := 0.0
+	frac := v
+	if len(l.integer) > 0 {
+		whole = math.Floor(v)
+		frac = v - whole
+	}
+	var num, den int64
+	if l.fixed > 0 {
+		den = l.fixed
+		num = int64(math.Floor(frac*float64(den) + 0.5))
+	} else {
+		maxDen := int64(math.Pow10(len(l.denominator))) - 1
+		num, den = approximate(frac, maxDen)
+	}
+	if len(l.integer) > 0 && num == den && den > 0 {
+		whole++
+		num = 0
+	}
+	if len(l.integer) > 0 {
+		digits, _ := roundDecimal(whole, 0)
+		if whole == 0 && num == 0 {
+			digits = "0"
+		}
+		s.fillInteger(digits, l, out, handled, loc)
+	}
+	if num == 0 && len(l.integer) > 0 {
+		// the fraction is left blank
+		for _, idx := range append(append([]int(nil), l.numerator...), l.denominator...) {
+			out[idx] = pad(s.items[idx].text)
+			if out[idx] == "0" {
+				out[idx] = " "
+			}
+			handled[idx] = true
+		}
+		out[l.slash] = " "
+		handled[l.slash] = true
+		for j := l.slash + 1; l.fixed > 0 && j < len(s.items) && s.items[j].kind == itemLiteral && s.items[j].text >= "0" && s.items[j].text <= "9"; j++ {
+			out[j] = " "
+			handled[j] = true
+		}
+		return
+	}
+	// the numerator is aligned to the right, the denominator to the left
+	numDigits := strconv.FormatInt(num, 10)
+	n := len(l.numerator)
+	for k, idx := range l.numerator {
+		r := n - 1 - k
+		if r < len(numDigits) {
+			out[idx] = string(numDigits[len(numDigits)-1-r])
+		} else {
+			out[idx] = pad(s.items[idx].text)
+		}
+		if k == 0 && len(numDigits) > n {
+			out[idx] = numDigits[:len(numDigits)-n] + out[idx]
+		}
+		handled[idx] = true
+	}
+	if l.fixed > 0 {
+		return
+	}
+	denDigits := strconv.FormatInt(den, 10)
+	for k, idx := range l.denominator {
+		if k < len(denDigits) {
+			out[idx] = string(denDigits[k])
+		} else {
+			out[idx] = pad(s.items[idx].text)
+		}
+		if k == len(l.denominator)-1 && len(denDigits) > len(l.denominator) {
+			out[idx] += denDigits[k+1:]
+		}
+		handled[idx] = true
+	}
+}
+
+// approximate returns the fraction closest to v, which is between 0 and 1,
+// with a denominator of at most maxDen, using continued fractions.
+func approximate(v float64, maxDen int64) (int64, int64) {
+	if maxDen < 1 {
+		maxDen = 1
+	}
+	whole := math.Floor(v)
+	x := v - whole
+	p0, q0, p1, q1 := int64(0), int64(1), int64(1), int64(0)
+	for i := 0; i < 64; i++ {
+		a := int64(math.Floor(x))
+		p2, q2 := a*p1+p0, a*q1+q0
+		if q2 > maxDen {
+			// the best semiconvergent within the limit
+			k := (maxDen - q0) / q1
+			ps, qs := k*p1+p0, k*q1+q0
+			if math.Abs(float64(ps)/float64(qs)-v+whole) < math.Abs(float64(p1)/float64(q1)-v+whole) {
+				p1, q1 = ps, qs
+			}
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, p2, q2
+		f := x - float64(a)
+		if f < 1e-12 {
+			break
+		}
+		x = 1 / f
+	}
+	if q1 == 0 {
+		return 0, 1
+	}
+	return p1 + int64(whole)*q1, q1
+}
+
+// roundDecimal rounds a non-negative number half away from zero to a number
+// of decimals using 15 significant digits like Excel, and returns the digits
+// of the integer part and the decimals.
+func roundDecimal(v float64, places int) (string, string) {
+	s := strconv.FormatFloat(v, 'e', 14, 64)
+	e := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[e+1:])
+	digits := []byte(s[:1] + s[2:e])
+	point := exp + 1
+	if point < 0 {
+		digits = append([]byte(strings.Repeat("0", -point)), digits...)
+		point = 0
+	}
+	for len(digits) < point+places+1 {
+		digits = append(digits, '0')
+	}
+	keep := point + places
+	round := digits[keep] >= '5'
+	digits = digits[:keep]
+	for i := keep - 1; round && i >= 0; i-- {
+		if digits[i] == '9' {
+			digits[i] = '0'
+			continue
+		}
+		digits[i]++
+		round = false
+	}
+	if round {
+		digits = append([]byte{'1'}, digits...)
+		point++
+	}
+	intDigits := strings.TrimLeft(string(digits[:point]), "0")
+	return intDigits, string(digits[point:])
+}
+
+// decimalExponent returns the exponent of a positive number in scientific
+// notation.
+func decimalExponent(v float64) int {
+	s := strconv.FormatFloat(v, 'e', 14, 64)
+	exp, _ := strconv.Atoi(s[strings.IndexByte(s, 'e')+1:])
+	return exp
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// dateParts are the parts of a date and time serial number.
+type dateParts struct {
+	year, month, day, weekday int
+	hour, minute, second      int
+	// subsecond is in milliseconds
+	subsecond int
+	// total is the time since day zero in milliseconds, for elapsed times
+	total int64
+}
+
+// maxSerial is the serial number of December 31st, 9999.
+const maxSerial = 2958466
+
+// datePartsOf splits a serial number into its date and time parts, rounding
+// to the number of decimals of the seconds.
+func datePartsOf(v float64, decimals int) (dateParts, bool) {
+	if v < 0 || v >= maxSerial {
+		return dateParts{}, false
+	}
+	unit := int64(1000)
+	for i := 0; i < decimals && i < 3; i++ {
+		unit /= 10
+	}
+	ms := int64(math.Floor(v*86400000/float64(unit)+0.5)) * unit
+	p := dateParts{total: ms}
+	days := ms / 86400000
+	rem := ms % 86400000
+	p.hour = int(rem / 3600000)
+	p.minute = int(rem / 60000 % 60)
+	p.second = int(rem / 1000 % 60)
+	p.subsecond = int(rem % 1000)
+	// Excel thinks 1900 was a leap year and that January 1st, 1900 was a
+	// Sunday
+	p.weekday = int((days + 6) % 7)
+	switch {
+	case days == 0:
+		p.year, p.month, p.day = 1900, 1, 0
+	case days == 60:
+		p.year, p.month, p.day = 1900, 2, 29
+	default:
+		if days < 60 {
+			days++
+		}
+		t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(days))
+		p.year, p.month, p.day = t.Year(), int(t.Month()), t.Day()
+	}
+	return p, true
+}
+
+// formatDate formats a serial number with the date and time codes of the
+// section.
+func (s *section) formatDate(v float64, loc *Locale) string {
+	items := s.expandSystemFormats(loc)
+	names := loc
+	if s.locale != nil {
+		names = s.locale
+	}
+	decimals := 0
+	ampm := false
+	for _, it := range items {
+		switch {
+		case it.kind == itemSubsecond && len(it.text) > decimals:
+			decimals = len(it.text)
+		case it.kind == itemDate && (strings.EqualFold(it.text, "am/pm") || strings.EqualFold(it.text, "a/p")):
+			ampm = true
+		}
+	}
+	p, ok := datePartsOf(v, decimals)
+	if !ok {
+		return hashes
+	}
+	minutes := minuteItems(items)
+	num := func(n, width int, digitwise bool) string {
+		out := strconv.Itoa(n)
+		for len(out) < width {
+			out = "0" + out
+		}
+		if s.dbnum > 0 {
+			out = names.dbnum(out, s.dbnum, !digitwise)
+		}
+		return out
+	}
+	sb := strings.Builder{}
+	for i, it := range items {
+		switch it.kind {
+		case itemLiteral, itemDigit, itemExponent:
+			sb.WriteString(it.text)
+		case itemDecimal:
+			sb.WriteByte('.')
+		case itemComma:
+			sb.WriteByte(',')
+		case itemPercent:
+			sb.WriteByte('%')
+		case itemSlash:
+			sb.WriteByte('/')
+		case itemText:
+		case itemSubsecond:
+			ms := strconv.Itoa(p.subsecond + 1000)[1:]
+			sb.WriteString(loc.DecimalSeparator)
+			sb.WriteString(ms[:len(it.text)])
+		case itemDate:
+			code := it.text
+			lower := strings.ToLower(code)
+			switch {
+			case lower == "am/pm" || lower == "a/p":
+				mark := names.AM
+				if p.hour >= 12 {
+					mark = names.PM
+				}
+				if lower == "a/p" {
+					mark = string([]rune(mark)[:1])
+					if code[0] == 'a' || code[0] == 'p' {
+						mark = strings.ToLower(mark)
+					}
+				} else if code == "am/pm" {
+					mark = strings.ToLower(mark)
+				}
+				sb.WriteString(mark)
+			case strings.HasPrefix(code, "["):
+				var n int64
+				switch code[1] {
+				case 'h':
+					n = p.total / 3600000
+				case 'm':
+					n = p.total / 60000
+				case 's':
+					n = p.total / 1000
+				}
+				sb.WriteString(num(int(n), len(code)-2, false))
+			case code[0] == 'y' || code[0] == 'e':
+				if len(code) <= 2 && code[0] == 'y' {
+					sb.WriteString(num(p.year%100, 2, true))
+				} else {
+					sb.WriteString(num(p.year, 0, true))
+				}
+			case code[0] == 'b':
+				if len(code) <= 2 {
+					sb.WriteString(num((p.year+543)%100, 2, true))
+				} else {
+					sb.WriteString(num(p.year+543, 0, true))
+				}
+			case code[0] == 'g':
+				// eras are only used by calendars that aren't supported
+			case code[0] == 'm' && minutes[i]:
+				sb.WriteString(num(p.minute, min(len(code), 2), false))
+			case code[0] == 'm':
+				switch len(code) {
+				case 1, 2:
+					sb.WriteString(num(p.month, len(code), false))
+				case 3:
+					sb.WriteString(names.MonthAbbreviations[p.month-1])
+				case 5:
+					sb.WriteString(string([]rune(names.MonthNames[p.month-1])[:1]))
+				default:
+					sb.WriteString(names.MonthNames[p.month-1])
+				}
+			case code[0] == 'd':
+				switch len(code) {
+				case 1, 2:
+					sb.WriteString(num(p.day, len(code), false))
+				case 3:
+					sb.WriteString(names.DayAbbreviations[p.weekday])
+				default:
+					sb.WriteString(names.DayNames[p.weekday])
+				}
+			case code[0] == 'a':
+				if len(code) == 3 {
+					sb.WriteString(names.DayAbbreviations[p.weekday])
+				} else {
+					sb.WriteString(names.DayNames[p.weekday])
+				}
+			case code[0] == 'h':
+				h := p.hour
+				if ampm {
+					h %= 12
+					if h == 0 {
+						h = 12
+					}
+				}
+				sb.WriteString(num(h, min(len(code), 2), false))
+			case code[0] == 's':
+				sb.WriteString(num(p.second, min(len(code), 2), false))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// expandSystemFormats returns the items of the section, or those of the long
+// date or time format of the locale for the system date and time codes, which
+// replace the rest of the section.
+func (s *section) expandSystemFormats(loc *Locale) []item {
+	names := loc
+	if s.locale != nil {
+		names = s.locale
+	}
+	for _, it := range s.items {
+		switch {
+		case it.kind != itemDate:
+		case it.text == "sysdate":
+			return parseSection(names.LongDateFormat).items
+		case it.text == "systime":
+			return parseSection(names.LongTimeFormat).items
+		}
+	}
+	return s.items
+}
+
+// minuteItems returns which m and mm codes are minutes rather than months,
+// which is the case if they follow hours or precede seconds.
+func minuteItems(items []item) map[int]bool {
+	minutes := map[int]bool{}
+	prev := ""
+	for i, it := range items {
+		if it.kind != itemDate {
+			continue
+		}
+		code := it.text
+		if code[0] == 'm' && len(code) <= 2 {
+			if prev == "h" {
+				minutes[i] = true
+			} else {
+				for _, next := range items[i+1:] {
+					if next.kind != itemDate {
+						continue
+					}
+					if next.text[0] == 's' || strings.HasPrefix(next.text, "[s") {
+						minutes[i] = true
+					}
+					break
+				}
+			}
+		}
+		switch {
+		case code[0] == 'h' || strings.HasPrefix(code, "[h"):
+			prev = "h"
+		case strings.EqualFold(code, "am/pm") || strings.EqualFold(code, "a/p"):
+		default:
+			prev = code[:1]
+		}
+	}
+	return minutes
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
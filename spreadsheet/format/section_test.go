@@ -0,0 +1,153 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package format
+
+import "testing"
+
+// numberVectors are format codes with the values Excel displays for them.
+var numberVectors = []struct {
+	Locale string
+	Value  float64
+	Format string
+	Exp    string
+}{
+	// general
+	{"", 0, "General", "0"},
+	{"", 1234.5, "General", "1234.5"},
+	{"", -1234.5, "General", "-1234.5"},
+	{"", 0.1 + 0.2, "General", "0.3"},
+	{"", 1.0 / 3, "General", "0.333333333"},
+	{"", 123456789.123, "General", "123456789.1"},
+	{"", 99999999999, "General", "99999999999"},
+	{"", 1e11, "General", "1E+11"},
+	{"", 123456789012, "General", "1.23457E+11"},
+	{"", 1e15, "General", "1E+15"},
+	{"", 1e20, "General", "1E+20"},
+	{"", -1e20, "General", "-1E+20"},
+	{"", 0.0001, "General", "0.0001"},
+	{"", 0.000123456789012, "General", "0.000123457"},
+	{"", 1.23456e-7, "General", "1.23456E-07"},
+	{"", 1e-10, "General", "1E-10"},
+	{"", 1.5e-300, "General", "1.5E-300"},
+	{"de-DE", 1234.5, "General", "1234,5"},
+	{"de-DE", 1.5e20, "General", "1,5E+20"},
+	{"", 1234.5, "", "1234.5"},
+
+	// elapsed time
+	{"", 1.5, "[h]:mm:ss", "36:00:00"},
+	{"", 0.0625, "[h]:mm:ss", "1:30:00"},
+	{"", 0.999999, "[h]:mm:ss", "24:00:00"},
+	{"", 2.75, "[mm]:ss", "3960:00"},
+	{"", 1.000011574, "[ss]", "86401"},
+	{"", 1.5, "[h]:mm", "36:00"},
+	{"", -0.5, "[h]:mm:ss", "########"},
+
+	// fractions
+	{"", 5.25, "# ?/?", "5 1/4"},
+	{"", -5.25, "# ?/?", "-5 1/4"},
+	{"", 3.14159, "# ??/??", "3 14/99"},
+	{"", 0.5, "# ?/?", " 1/2"},
+	{"", 5, "# ?/?", "5    "},
+	{"", 0.25, "# ??/??", "  1/4 "},
+	{"", 1.5, "?/?", "3/2"},
+	{"", 0.3, "?/8", "2/8"},
+
+	// scientific
+	{"", 12345, "0.00E+00", "1.23E+04"},
+	{"", -12345, "0.00E+00", "-1.23E+04"},
+	{"", 0.000123, "0.00E+00", "1.23E-04"},
+	{"", 0, "0.00E+00", "0.00E+00"},
+	{"", 1e100, "0.00E+00", "1.00E+100"},
+	{"", 12345, "##0.0E+0", "12.3E+3"},
+	{"", 1e-5, "0.0E+0", "1.0E-5"},
+	{"de-DE", 12345, "0.00E+00", "1,23E+04"},
+
+	// conditions
+	{"", 150, `[>100]"big";[<0]"neg";0`, "big"},
+	{"", -3, `[>100]"big";[<0]"neg";0`, "neg"},
+	{"", 5, `[>100]"big";[<0]"neg";0`, "5"},
+	{"", 5, "[<=10]0.0;#,##0", "5.0"},
+	{"", 12345, "[<=10]0.0;#,##0", "12,345"},
+
+	// colors
+	{"", 5, "[Red]0;[Blue]-0", "5"},
+	{"", -5, "[Red]0;[Blue]-0", "-5"},
+	{"", 5, "[Color10]0.0", "5.0"},
+
+	// locales and currencies
+	{"", 45000, "[$-409]mmmm d, yyyy", "March 15, 2023"},
+	{"", 45000, "[$-407]mmmm", "März"},
+	{"", 45000, "[$-407]dddd", "Mittwoch"},
+	{"", 1234.5, "[$€-407]#,##0.00", "€1,234.50"},
+	{"de-DE", 1234.5, "[$€-407]#,##0.00", "€1.234,50"},
+	{"", 1234.5, "#,##0.00 [$€-407]", "1,234.50 €"},
+	{"de-DE", 1234567.891, "#,##0.00", "1.234.567,89"},
+
+	// text sections don't apply to numbers
+	{"", 5, `0;-0;0;"Text: "@`, "5"},
+	{"", 5, "@", "5"},
+
+	// skipped widths and fill characters
+	{"", 5, "_(0_)", " 5 "},
+	{"", 5, "#,##0_);(#,##0)", "5 "},
+	{"", -5, "#,##0_);(#,##0)", "(5)"},
+	{"", 5, "_-0", " 5"},
+	{"", 5, "*-0", "5"},
+	{"", 5, "0*x", "5"},
+	{"", 5, `\#0"pcs"`, "#5pcs"},
+}
+
+func TestNumberVectors(t *testing.T) {
+	for _, tc := range numberVectors {
+		loc := LookupLocale(tc.Locale)
+		if got := NumberLocale(tc.Value, tc.Format, loc); got != tc.Exp {
+			t.Errorf("%s: expected %v with %s to be %q, got %q", tc.Locale, tc.Value, tc.Format, tc.Exp, got)
+		}
+	}
+}
+
+func TestNumberColorVectors(t *testing.T) {
+	td := []struct {
+		Value  float64
+		Format string
+		Exp    string
+	}{
+		{5, "[Red]0;[Blue]-0", "Red"},
+		{-5, "[Red]0;[Blue]-0", "Blue"},
+		{0, "[Red]0;[Blue]-0", "Red"},
+		{5, "[Color10]0.0", "Color10"},
+		{150, `[>100][Green]0;0`, "Green"},
+		{5, `[>100][Green]0;0`, ""},
+	}
+	for _, tc := range td {
+		if got := NumberColor(tc.Value, tc.Format); got != tc.Exp {
+			t.Errorf("expected the color of %v with %s to be %q, got %q", tc.Value, tc.Format, tc.Exp, got)
+		}
+	}
+}
+
+func TestStringVectors(t *testing.T) {
+	td := []struct {
+		Value  string
+		Format string
+		Exp    string
+	}{
+		{"abc", `"Text: "@`, "Text: abc"},
+		{"abc", `0;-0;0;@" units"`, "abc units"},
+		{"abc", "@@", "abcabc"},
+		{"abc", "_(@_)", " abc "},
+		{"abc", "@*-", "abc"},
+		{"abc", "0.00", "abc"},
+		{"abc", `0;-0;0`, "abc"},
+	}
+	for _, tc := range td {
+		if got := String(tc.Value, tc.Format); got != tc.Exp {
+			t.Errorf("expected %q with %s to be %q, got %q", tc.Value, tc.Format, tc.Exp, got)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import "strings"
+
+// Precedent is a cell, range or name that a formula refers to.
+type Precedent struct {
+	// Type is ReferenceTypeCell, ReferenceTypeRange,
+	// ReferenceTypeHorizontalRange, ReferenceTypeVerticalRange or
+	// ReferenceTypeNamedRange for defined names and structured references.
+	Type ReferenceType
+	// Sheet is the unquoted name of the sheet, empty for references to the
+	// sheet of the formula. Sheets and names of other workbooks include the
+	// workbook in brackets, e.g. [1]Sheet1.
+	Sheet string
+	// Ref is the reference as written without the sheet, e.g. A1, $B$1:C3,
+	// 1:3, A:C, Name or Table1[Qty].
+	Ref string
+}
+
+// String returns the precedent as it appears in a formula.
+func (p Precedent) String() string {
+	if p.Sheet == "" {
+		return p.Ref
+	}
+	return formatSheetName(p.Sheet) + "!" + p.Ref
+}
+
+// volatileFunctions are recalculated by Excel whenever any cell changes.
+var volatileFunctions = map[string]bool{
+	"CELL":        true,
+	"INDIRECT":    true,
+	"INFO":        true,
+	"NOW":         true,
+	"OFFSET":      true,
+	"RAND":        true,
+	"RANDARRAY":   true,
+	"RANDBETWEEN": true,
+	"TODAY":       true,
+}
+
+// IsVolatileFunction returns true if Excel recalculates a function whenever
+// any cell of the workbook changes, e.g. NOW or OFFSET.
+func IsVolatileFunction(name string) bool {
+	return volatileFunctions[strings.ToUpper(strings.TrimPrefix(name, "_xlfn."))]
+}
+
+// IsSupportedFunction returns true if a function is implemented by the
+// evaluator. The name is not case sensitive, e.g. sum or _xlfn.floor.math.
+func IsSupportedFunction(name string) bool {
+	name = strings.ToUpper(name)
+	if strings.HasPrefix(name, "_XLFN.") {
+		name = "_xlfn." + name[len("_XLFN."):]
+	}
+	return LookupFunction(name) != nil || LookupFunctionComplex(name) != nil
+}
+
+// Precedents returns the cells, ranges and names that an expression refers to
+// in the order they appear, without duplicates.
+func Precedents(e Expression) []Precedent {
+	var res []Precedent
+	seen := map[Precedent]bool{}
+	add := func(p Precedent) {
+		if !seen[p] {
+			seen[p] = true
+			res = append(res, p)
+		}
+	}
+	Inspect(e, func(e Expression) bool {
+		p, ok := precedent(e)
+		if ok {
+			add(p)
+		}
+		// the cells of ranges are not precedents of their own
+		return !ok
+	})
+	return res
+}
+
+// precedent returns the precedent for a reference expression.
+func precedent(e Expression) (Precedent, bool) {
+	switch x := e.(type) {
+	case CellRef:
+		return Precedent{Type: ReferenceTypeCell, Ref: x._ecg}, true
+	case Range:
+		from, fok := x._bgaad.(CellRef)
+		to, tok := x._eeebc.(CellRef)
+		if fok && tok {
+			return Precedent{Type: ReferenceTypeRange, Ref: from._ecg + ":" + to._ecg}, true
+		}
+	case HorizontalRange:
+		return Precedent{Type: ReferenceTypeHorizontalRange, Ref: x.String()}, true
+	case VerticalRange:
+		return Precedent{Type: ReferenceTypeVerticalRange, Ref: x.String()}, true
+	case NamedRangeRef:
+		if i := strings.LastIndexByte(x._ffead, '!'); i > 0 {
+			return Precedent{Type: ReferenceTypeNamedRange, Sheet: x._ffead[:i], Ref: x._ffead[i+1:]}, true
+		}
+		return Precedent{Type: ReferenceTypeNamedRange, Ref: x._ffead}, true
+	case StructuredRef:
+		return Precedent{Type: ReferenceTypeNamedRange, Ref: x._text}, true
+	case PrefixExpr:
+		return prefixedPrecedent(x._fefed, x._dcec)
+	case *PrefixExpr:
+		return prefixedPrecedent(x._fefed, x._dcec)
+	case PrefixRangeExpr:
+		return prefixedPrecedent(x._aecbe, NewRange(x._acdg, x._adeff))
+	case PrefixHorizontalRange:
+		return prefixedPrecedent(x._edfe, HorizontalRange{_cbgge: x._ddaba, _faff: x._aageg})
+	case PrefixVerticalRange:
+		return prefixedPrecedent(x._feccb, VerticalRange{_ccdcd: x._gadf, _ggfec: x._agbc})
+	}
+	return Precedent{}, false
+}
+
+func prefixedPrecedent(pfx, e Expression) (Precedent, bool) {
+	p, ok := precedent(e)
+	if !ok {
+		return p, false
+	}
+	p.Sheet = sheetName(pfx)
+	return p, true
+}
+
+func sheetName(e Expression) string {
+	switch x := e.(type) {
+	case SheetPrefixExpr:
+		return x._dcecf
+	case *SheetPrefixExpr:
+		return x._dcecf
+	}
+	return e.String()
+}
+
+// Functions returns the names of the functions that an expression calls in
+// the order they appear, without duplicates.
+func Functions(e Expression) []string {
+	var res []string
+	seen := map[string]bool{}
+	Inspect(e, func(e Expression) bool {
+		if fn, ok := e.(FunctionCall); ok && !seen[fn._aebg] {
+			seen[fn._aebg] = true
+			res = append(res, fn._aebg)
+		}
+		return e != nil
+	})
+	return res
+}
+
+// VolatileFunctions returns the names of the volatile functions that an
+// expression calls, see IsVolatileFunction.
+func VolatileFunctions(e Expression) []string {
+	var res []string
+	for _, fn := range Functions(e) {
+		if IsVolatileFunction(fn) {
+			res = append(res, fn)
+		}
+	}
+	return res
+}
+
+// UnsupportedFunctions returns the names of the functions that an expression
+// calls and that the evaluator doesn't implement. Such calls evaluate to an
+// error.
+func UnsupportedFunctions(e Expression) []string {
+	var res []string
+	for _, fn := range Functions(e) {
+		if !IsSupportedFunction(fn) {
+			res = append(res, fn)
+		}
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrecedents(t *testing.T) {
+	td := []struct {
+		Inp string
+		Exp []Precedent
+	}{
+		{"1+2", nil},
+		{"SUM(A1,$B$2:C3,A1)", []Precedent{
+			{ReferenceTypeCell, "", "A1"},
+			{ReferenceTypeRange, "", "$B$2:C3"},
+		}},
+		{"Sheet2!D4+'My Sheet'!1:3+Sheet1!A:C+A:A", []Precedent{
+			{ReferenceTypeCell, "Sheet2", "D4"},
+			{ReferenceTypeHorizontalRange, "My Sheet", "1:3"},
+			{ReferenceTypeVerticalRange, "Sheet1", "A:C"},
+			{ReferenceTypeVerticalRange, "", "A:A"},
+		}},
+		{"Rate*Table1[Qty]+Sheet1!A1:B2", []Precedent{
+			{ReferenceTypeNamedRange, "", "Rate"},
+			{ReferenceTypeNamedRange, "", "Table1[Qty]"},
+			{ReferenceTypeRange, "Sheet1", "A1:B2"},
+		}},
+		{"[1]Data!A1+[1]!Rate", []Precedent{
+			{ReferenceTypeCell, "[1]Data", "A1"},
+			{ReferenceTypeNamedRange, "[1]", "Rate"},
+		}},
+	}
+	for _, tc := range td {
+		if got := Precedents(ParseString(tc.Inp)); !reflect.DeepEqual(got, tc.Exp) {
+			t.Errorf("%s: expected %v, got %v", tc.Inp, tc.Exp, got)
+		}
+	}
+
+	for _, tc := range []struct {
+		P   Precedent
+		Exp string
+	}{
+		{Precedent{ReferenceTypeCell, "", "A1"}, "A1"},
+		{Precedent{ReferenceTypeRange, "My Sheet", "A1:B2"}, "'My Sheet'!A1:B2"},
+		{Precedent{ReferenceTypeNamedRange, "[1]", "Rate"}, "[1]!Rate"},
+	} {
+		if got := tc.P.String(); got != tc.Exp {
+			t.Errorf("expected %s, got %s", tc.Exp, got)
+		}
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	e := ParseString("IF(NOW()>A1,SUM(B1:B2,SUM(1)),_xlfn.FLOOR.MATH(RAND()),FOO(1))")
+	if got, exp := Functions(e), []string{"IF", "NOW", "SUM", "_xlfn.FLOOR.MATH", "RAND", "FOO"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected the functions %v, got %v", exp, got)
+	}
+	if got, exp := VolatileFunctions(e), []string{"NOW", "RAND"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected the volatile functions %v, got %v", exp, got)
+	}
+	if got, exp := UnsupportedFunctions(e), []string{"FOO"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected the unsupported functions %v, got %v", exp, got)
+	}
+
+	td := []struct {
+		Name                string
+		Volatile, Supported bool
+	}{
+		{"NOW", true, true},
+		{"offset", true, true},
+		{"_xlfn.RANDARRAY", true, false},
+		{"SUM", false, true},
+		{"_xlfn.floor.math", false, true},
+		{"NOSUCH", false, false},
+	}
+	for _, tc := range td {
+		if IsVolatileFunction(tc.Name) != tc.Volatile {
+			t.Errorf("%s: expected volatile to be %v", tc.Name, tc.Volatile)
+		}
+		if IsSupportedFunction(tc.Name) != tc.Supported {
+			t.Errorf("%s: expected supported to be %v", tc.Name, tc.Supported)
+		}
+	}
+}
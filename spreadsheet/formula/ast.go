@@ -0,0 +1,211 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+// A Visitor's Visit method is invoked for each expression encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children of the
+// expression with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(e Expression) (w Visitor)
+}
+
+// Walk traverses an expression tree in depth-first order. It starts by calling
+// v.Visit(e); e must not be nil. If the visitor w returned by v.Visit(e) is not
+// nil, Walk is invoked recursively with visitor w for each of the children of
+// e, followed by a call of w.Visit(nil).
+func Walk(v Visitor, e Expression) {
+	if v = v.Visit(e); v == nil {
+		return
+	}
+	for _, c := range Children(e) {
+		if c != nil {
+			Walk(v, c)
+		}
+	}
+	v.Visit(nil)
+}
+
+type inspector func(Expression) bool
+
+func (f inspector) Visit(e Expression) Visitor {
+	if f(e) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses an expression tree in depth-first order. It starts by
+// calling f(e); e must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the children of e, followed by a call of f(nil).
+func Inspect(e Expression, f func(Expression) bool) {
+	Walk(inspector(f), e)
+}
+
+// Children returns the direct sub-expressions of an expression, e.g. the
+// operands of a BinaryExpr or the arguments of a FunctionCall. Constants, cell
+// references and names have no children. Empty arguments such as the second
+// argument of SUM(A1,,B1) are returned as EmptyExpr.
+func Children(e Expression) []Expression {
+	switch x := e.(type) {
+	case BinaryExpr:
+		return []Expression{x._ba, x._af}
+	case FunctionCall:
+		return x._ebeeae
+	case Negate:
+		return []Expression{x._ebfdf}
+	case Range:
+		return []Expression{x._bgaad, x._eeebc}
+	case PrefixExpr:
+		return []Expression{x._fefed, x._dcec}
+	case *PrefixExpr:
+		return []Expression{x._fefed, x._dcec}
+	case PrefixRangeExpr:
+		return []Expression{x._aecbe, x._acdg, x._adeff}
+	case PrefixHorizontalRange:
+		return []Expression{x._edfe}
+	case PrefixVerticalRange:
+		return []Expression{x._feccb}
+	case ConstArrayExpr:
+		return arrayElements(x._cdg)
+	case *ConstArrayExpr:
+		return arrayElements(x._cdg)
+	}
+	return nil
+}
+
+func arrayElements(rows [][]Expression) []Expression {
+	var elems []Expression
+	for _, row := range rows {
+		elems = append(elems, row...)
+	}
+	return elems
+}
+
+// Rewrite returns a copy of an expression tree in which every expression has
+// been replaced by the result of f. The tree is rewritten bottom up, so f is
+// called with expressions whose children were already rewritten. Returning the
+// argument leaves an expression unchanged.
+func Rewrite(e Expression, f func(Expression) Expression) Expression {
+	if e == nil {
+		return nil
+	}
+	rw := func(c Expression) Expression { return Rewrite(c, f) }
+	switch x := e.(type) {
+	case BinaryExpr:
+		e = NewBinaryExpr(rw(x._ba), x._ad, rw(x._af))
+	case FunctionCall:
+		var args []Expression
+		for _, a := range x._ebeeae {
+			args = append(args, rw(a))
+		}
+		e = NewFunction(x._aebg, args)
+	case Negate:
+		e = NewNegate(rw(x._ebfdf))
+	case Range:
+		e = NewRange(rw(x._bgaad), rw(x._eeebc))
+	case PrefixExpr:
+		e = NewPrefixExpr(rw(x._fefed), rw(x._dcec))
+	case *PrefixExpr:
+		e = NewPrefixExpr(rw(x._fefed), rw(x._dcec))
+	case PrefixRangeExpr:
+		e = NewPrefixRangeExpr(rw(x._aecbe), rw(x._acdg), rw(x._adeff))
+	case PrefixHorizontalRange:
+		e = PrefixHorizontalRange{_edfe: rw(x._edfe), _ddaba: x._ddaba, _aageg: x._aageg}
+	case PrefixVerticalRange:
+		e = PrefixVerticalRange{_feccb: rw(x._feccb), _gadf: x._gadf, _agbc: x._agbc}
+	case ConstArrayExpr:
+		e = NewConstArrayExpr(rewriteRows(x._cdg, rw))
+	case *ConstArrayExpr:
+		e = NewConstArrayExpr(rewriteRows(x._cdg, rw))
+	}
+	return f(e)
+}
+
+func rewriteRows(rows [][]Expression, rw func(Expression) Expression) [][]Expression {
+	res := make([][]Expression, len(rows))
+	for i, row := range rows {
+		res[i] = make([]Expression, len(row))
+		for j, c := range row {
+			res[i][j] = rw(c)
+		}
+	}
+	return res
+}
+
+// Op returns the operator of the binary expression.
+func (b BinaryExpr) Op() BinOpType { return b._ad }
+
+// Operands returns the left and right hand side of the binary expression.
+func (b BinaryExpr) Operands() (lhs, rhs Expression) { return b._ba, b._af }
+
+// Name returns the name of the function as written in the formula, e.g. SUM or
+// _xlfn.FLOOR.MATH.
+func (f FunctionCall) Name() string { return f._aebg }
+
+// Args returns the arguments of the function call.
+func (f FunctionCall) Args() []Expression { return f._ebeeae }
+
+// Operand returns the negated expression.
+func (n Negate) Operand() Expression { return n._ebfdf }
+
+// Bounds returns the first and last cell of the range.
+func (r Range) Bounds() (from, to Expression) { return r._bgaad, r._eeebc }
+
+// Prefix returns the sheet of the expression, a SheetPrefixExpr.
+func (p PrefixExpr) Prefix() Expression { return p._fefed }
+
+// Expr returns the expression that is evaluated on the sheet.
+func (p PrefixExpr) Expr() Expression { return p._dcec }
+
+// Prefix returns the sheet of the range, a SheetPrefixExpr.
+func (p PrefixRangeExpr) Prefix() Expression { return p._aecbe }
+
+// Bounds returns the first and last cell of the range.
+func (p PrefixRangeExpr) Bounds() (from, to Expression) { return p._acdg, p._adeff }
+
+// Prefix returns the sheet of the range, a SheetPrefixExpr.
+func (p PrefixHorizontalRange) Prefix() Expression { return p._edfe }
+
+// Rows returns the first and last row of the range, e.g. 1 and 3 for 1:3.
+func (p PrefixHorizontalRange) Rows() (first, last int) { return p._ddaba, p._aageg }
+
+// Prefix returns the sheet of the range, a SheetPrefixExpr.
+func (p PrefixVerticalRange) Prefix() Expression { return p._feccb }
+
+// Columns returns the first and last column of the range, e.g. A and C for
+// A:C.
+func (p PrefixVerticalRange) Columns() (first, last string) { return p._gadf, p._agbc }
+
+// Rows returns the first and last row of the range, e.g. 1 and 3 for 1:3.
+func (h HorizontalRange) Rows() (first, last int) { return h._cbgge, h._faff }
+
+// Columns returns the first and last column of the range, e.g. A and C for
+// A:C.
+func (v VerticalRange) Columns() (first, last string) { return v._ccdcd, v._ggfec }
+
+// Name returns the unquoted name of the sheet. Sheets of other workbooks
+// include the workbook in brackets, e.g. [1]Sheet1.
+func (s SheetPrefixExpr) Name() string { return s._dcecf }
+
+// Name returns the defined name.
+func (n NamedRangeRef) Name() string { return n._ffead }
+
+// Rows returns the elements of the array constant by rows.
+func (c ConstArrayExpr) Rows() [][]Expression { return c._cdg }
+
+// Value returns the value of the number.
+func (n Number) Value() float64 { return n._fcfe }
+
+// Value returns the value of the string, without quotes.
+func (s String) Value() string { return s._dfcbb }
+
+// Value returns the value of the boolean.
+func (b Bool) Value() bool { return b._cf }
+
+// Value returns the error value, e.g. #N/A.
+func (e Error) Value() string { return e._aebf }
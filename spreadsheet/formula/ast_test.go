@@ -0,0 +1,121 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recorder is a Visitor that records the expressions it visits, with a nil
+// expression written as ".".
+type recorder struct {
+	visits []string
+}
+
+func (r *recorder) Visit(e Expression) Visitor {
+	if e == nil {
+		r.visits = append(r.visits, ".")
+		return nil
+	}
+	r.visits = append(r.visits, Format(e))
+	return r
+}
+
+func TestWalk(t *testing.T) {
+	td := []struct {
+		Inp, Exp string
+	}{
+		{"A1", "A1 ."},
+		{"1+A1", "1+A1 1 . A1 . ."},
+		{"SUM(A1,,2)", "SUM(A1,,2) A1 .  . 2 . ."},
+		{"-A1", "-A1 A1 . ."},
+		{"Sheet1!A1:B2", "Sheet1!A1:B2 Sheet1 . A1 . B2 . ."},
+		{"{1,2;3}", "{1,2;3} 1 . 2 . 3 . ."},
+	}
+	for _, tc := range td {
+		r := &recorder{}
+		Walk(r, ParseString(tc.Inp))
+		if got := strings.Join(r.visits, " "); got != tc.Exp {
+			t.Errorf("%s: expected %q, got %q", tc.Inp, tc.Exp, got)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	e := ParseString("IF(A1>0,SUM(B1:B3),-C1)")
+	types := []string{}
+	Inspect(e, func(e Expression) bool {
+		if e == nil {
+			return false
+		}
+		types = append(types, strings.TrimPrefix(fmt.Sprintf("%T", e), "formula."))
+		// the bounds of ranges are not visited
+		_, isRange := e.(Range)
+		return !isRange
+	})
+	exp := "FunctionCall BinaryExpr CellRef Number FunctionCall Range Negate CellRef"
+	if got := strings.Join(types, " "); got != exp {
+		t.Errorf("expected %s, got %s", exp, got)
+	}
+
+	fn := e.(FunctionCall)
+	if fn.Name() != "IF" || len(fn.Args()) != 3 || len(Children(fn)) != 3 {
+		t.Errorf("expected IF with 3 arguments")
+	}
+	cmp := fn.Args()[0].(BinaryExpr)
+	if lhs, rhs := cmp.Operands(); cmp.Op() != BinOpTypeGT || lhs.String() != "A1" || rhs.(Number).Value() != 0 {
+		t.Errorf("expected A1>0, got %s", Format(cmp))
+	}
+	if neg := fn.Args()[2].(Negate); neg.Operand().String() != "C1" {
+		t.Errorf("expected -C1, got %s", Format(neg))
+	}
+	if Children(NewCellRef("A1")) != nil {
+		t.Errorf("expected a cell reference to have no children")
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	// move every reference one column to the right
+	shift := func(e Expression) Expression {
+		if c, ok := e.(CellRef); ok {
+			ref := c.String()
+			return NewCellRef(string(ref[0]+1) + ref[1:])
+		}
+		return e
+	}
+	td := []struct {
+		Inp, Exp string
+	}{
+		{"A1+1", "B1+1"},
+		{"SUM(A1:B2,C3)*-D4", "SUM(B1:C2,D3)*-E4"},
+		{"Sheet1!A1+'My Sheet'!B1:B2", "Sheet1!B1+'My Sheet'!C1:C2"},
+		{"{1,2}", "{1,2}"},
+	}
+	for _, tc := range td {
+		e := ParseString(tc.Inp)
+		if got := Format(Rewrite(e, shift)); got != tc.Exp {
+			t.Errorf("%s: expected %s, got %s", tc.Inp, tc.Exp, got)
+		}
+		if got := Format(e); got != tc.Inp {
+			t.Errorf("%s: expected the expression to be unchanged, got %s", tc.Inp, got)
+		}
+	}
+
+	// replacing whole function calls
+	e := Rewrite(ParseString("SUM(A1,NOW())"), func(e Expression) Expression {
+		if fn, ok := e.(FunctionCall); ok && fn.Name() == "NOW" {
+			return NewNumber("45000")
+		}
+		return e
+	})
+	if got := Format(e); got != "SUM(A1,45000)" {
+		t.Errorf("expected SUM(A1,45000), got %s", got)
+	}
+}
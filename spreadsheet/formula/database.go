@@ -0,0 +1,182 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import "strings"
+
+func init() {
+	RegisterFunction("DAVERAGE", Daverage)
+	RegisterFunction("DCOUNT", Dcount)
+	RegisterFunction("DCOUNTA", Dcounta)
+	RegisterFunction("DGET", Dget)
+	RegisterFunction("DMAX", Dmax)
+	RegisterFunction("DMIN", Dmin)
+	RegisterFunction("DPRODUCT", Dproduct)
+	RegisterFunction("DSTDEV", Dstdev)
+	RegisterFunction("DSTDEVP", Dstdevp)
+	RegisterFunction("DSUM", Dsum)
+	RegisterFunction("DVAR", Dvar)
+	RegisterFunction("DVARP", Dvarp)
+}
+
+// Daverage implements the Excel DAVERAGE function.
+func Daverage(args []Result) Result { return database(args, "DAVERAGE", aggAverage) }
+
+// Dcount implements the Excel DCOUNT function. If the field is omitted it
+// counts the matching records.
+func Dcount(args []Result) Result { return database(args, "DCOUNT", aggCount) }
+
+// Dcounta implements the Excel DCOUNTA function. If the field is omitted it
+// counts the matching records.
+func Dcounta(args []Result) Result { return database(args, "DCOUNTA", aggCountA) }
+
+// Dmax implements the Excel DMAX function.
+func Dmax(args []Result) Result { return database(args, "DMAX", aggMax) }
+
+// Dmin implements the Excel DMIN function.
+func Dmin(args []Result) Result { return database(args, "DMIN", aggMin) }
+
+// Dproduct implements the Excel DPRODUCT function.
+func Dproduct(args []Result) Result { return database(args, "DPRODUCT", aggProduct) }
+
+// Dstdev implements the Excel DSTDEV function.
+func Dstdev(args []Result) Result { return database(args, "DSTDEV", aggStdevS) }
+
+// Dstdevp implements the Excel DSTDEVP function.
+func Dstdevp(args []Result) Result { return database(args, "DSTDEVP", aggStdevP) }
+
+// Dsum implements the Excel DSUM function.
+func Dsum(args []Result) Result { return database(args, "DSUM", aggSum) }
+
+// Dvar implements the Excel DVAR function.
+func Dvar(args []Result) Result { return database(args, "DVAR", aggVarS) }
+
+// Dvarp implements the Excel DVARP function.
+func Dvarp(args []Result) Result { return database(args, "DVARP", aggVarP) }
+
+// Dget implements the Excel DGET function, it returns the field of the single
+// record that matches the criteria. It returns #VALUE! if no record matches
+// and #NUM! if more than one does.
+func Dget(args []Result) Result {
+	values, err := databaseValues(args, "DGET", false)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	switch len(values) {
+	case 0:
+		return MakeErrorResult("DGET found no matching record")
+	case 1:
+		return values[0]
+	}
+	return MakeErrorResultType(ErrorTypeNum, "DGET found more than one matching record")
+}
+
+// database applies an aggregate function to the field of the records that
+// match the criteria.
+func database(args []Result, fn string, agg int) Result {
+	values, err := databaseValues(args, fn, agg == aggCount || agg == aggCountA)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	return aggregate(agg, values, 0)
+}
+
+// databaseValues returns the field of the records of a database that match
+// the criteria, the arguments of the database functions. The database is a
+// range whose first row holds the field names and the field is a name or a
+// 1-based column number. The first row of the criteria holds field names and
+// each following row a set of conditions that must all be met, a record
+// matches if it meets the conditions of any row. The conditions are written
+// as in COUNTIF, text without a comparison operator matches the values that
+// begin with the text. If the field is omitted and omitField is true, the
+// number 1 is returned for each matching record.
+func databaseValues(args []Result, fn string, omitField bool) ([]Result, Result) {
+	if len(args) != 3 {
+		return nil, MakeErrorResult(fn + " requires three arguments")
+	}
+	db, crit := _dacf(args[0]), _dacf(args[2])
+	if len(db) == 0 || len(crit) == 0 {
+		return nil, MakeErrorResult(fn + " requires a database and criteria range")
+	}
+	header := db[0]
+	col := -1
+	switch f := args[1]; f.Type {
+	case ResultTypeEmpty:
+		if !omitField {
+			return nil, MakeErrorResult(fn + " requires a field")
+		}
+	case ResultTypeNumber:
+		if col = int(f.ValueNumber) - 1; col < 0 || col >= len(header) {
+			return nil, MakeErrorResult(fn + " field is out of range")
+		}
+	case ResultTypeString:
+		if col = fieldIndex(header, f.ValueString); col < 0 {
+			return nil, MakeErrorResult(fn + " field " + f.ValueString + " not found")
+		}
+	default:
+		return nil, MakeErrorResult(fn + " requires a field name or number")
+	}
+
+	// conditions by criteria row and database column, -1 for criteria
+	// fields that aren't in the database
+	type condition struct {
+		col  int
+		crit *criteriaParsed
+	}
+	var rows [][]condition
+	for _, row := range crit[1:] {
+		var conds []condition
+		for i, c := range row {
+			if c.Type == ResultTypeEmpty || c.Type == ResultTypeString && c.ValueString == "" || i >= len(crit[0]) {
+				continue
+			}
+			if c.Type == ResultTypeString && !strings.ContainsAny(c.ValueString[:1], "=<>") {
+				c = MakeStringResult(c.ValueString + "*")
+			}
+			conds = append(conds, condition{fieldIndex(header, crit[0][i].Value()), _cacde(c)})
+		}
+		rows = append(rows, conds)
+	}
+
+	var values []Result
+	for _, rec := range db[1:] {
+		match := len(rows) == 0
+		for _, conds := range rows {
+			ok := true
+			for _, c := range conds {
+				if c.col < 0 || c.col >= len(rec) || !_acedgd(rec[c.col], c.crit) {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				match = true
+				break
+			}
+		}
+		switch {
+		case !match:
+		case col < 0:
+			values = append(values, MakeNumberResult(1))
+		case col < len(rec):
+			values = append(values, rec[col])
+		}
+	}
+	return values, MakeEmptyResult()
+}
+
+// fieldIndex returns the column of a field name, compared case-insensitively,
+// or -1.
+func fieldIndex(header []Result, name string) int {
+	for i, h := range header {
+		if h.Type != ResultTypeEmpty && strings.EqualFold(strings.TrimSpace(h.Value()), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
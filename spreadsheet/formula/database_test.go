@@ -0,0 +1,43 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDatabaseFunctions(t *testing.T) {
+	const db = `{"Name","Qty";"apple",3;"apricot",4;"pear",5}`
+	td := []formulaTest{
+		{`DSUM(DB,"Qty",{"Name";"ap"})`, "7"},
+		{`DSUM(DB,2,{"Name";"ap"})`, "7"},
+		{`DSUM(DB,"Qty",{"Name","Qty";"ap",">3";"pear",""})`, "9"},
+		{`DSUM(DB,"Qty",{"Qty";">=4"})`, "9"},
+		{`DAVERAGE(DB,"Qty",{"Name";"ap"})`, "3.5"},
+		{`DCOUNT(DB,"Qty",{"Name";"p"})`, "1"},
+		{`DCOUNT(DB,,{"Name";"ap"})`, "2"},
+		{`DCOUNTA(DB,"Name",{"Qty";">0"})`, "3"},
+		{`DMAX(DB,"Qty",{"Name";"ap"})`, "4"},
+		{`DMIN(DB,"Qty",{"Name";"ap"})`, "3"},
+		{`DPRODUCT(DB,"Qty",{"Qty";">0"})`, "60"},
+		{`DSTDEV(DB,"Qty",{"Qty";">0"})`, "1"},
+		{`DSTDEVP(DB,"Qty",{"Qty";">0"})`, "0.816496580927726"},
+		{`DVAR(DB,"Qty",{"Qty";">0"})`, "1"},
+		{`DVARP(DB,"Qty",{"Qty";">0"})`, "0.666666666666667"},
+		{`DGET(DB,"Qty",{"Name";"pear"})`, "5"},
+		{`DGET(DB,"Qty",{"Name";"ap"})`, "#NUM!"},
+		{`DGET(DB,"Qty",{"Name";"zz"})`, "#VALUE!"},
+		{`DSUM(DB,"Price",{"Name";"ap"})`, "#VALUE!"},
+		{`DSUM(DB,3,{"Name";"ap"})`, "#VALUE!"},
+	}
+	for i := range td {
+		td[i].Inp = strings.Replace(td[i].Inp, "DB", db, 1)
+	}
+	runFormulaTests(t, td)
+}
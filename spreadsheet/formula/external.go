@@ -0,0 +1,227 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// externalRef is a reference to another workbook within the text of a
+// formula, e.g. [1]Sheet1!A1, '[1]My Sheet'!A1 or [1]!Name.
+type externalRef struct {
+	// start and end delimit the workbook and sheet, including the quotes of
+	// quoted sheet names, but not the exclamation mark. For defined names
+	// end is the end of the name.
+	start, end int
+	// bookStart and bookEnd delimit the workbook between the brackets.
+	bookStart, bookEnd int
+	// name is the unquoted text between start and end.
+	name string
+	// definedName is set for references to defined names such as [1]!Name.
+	definedName bool
+}
+
+// scanExternalRefs returns the references to other workbooks in a formula.
+func scanExternalRefs(s string) []externalRef {
+	var refs []externalRef
+	nameStart := -1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			end := closingQuote(s, i)
+			if end < 0 {
+				return refs
+			}
+			i = end
+			nameStart = -1
+			continue
+		case c == '\'':
+			end := closingQuote(s, i)
+			if end < 0 {
+				return refs
+			}
+			if end+1 < len(s) && s[end+1] == '!' {
+				name := strings.Replace(s[i+1:end], "''", "'", -1)
+				if open := strings.IndexByte(s[i:end], '['); open > 0 {
+					if close := strings.IndexByte(s[i+open:end], ']'); close > 0 {
+						refs = append(refs, externalRef{start: i, end: end + 1, bookStart: i + open + 1, bookEnd: i + open + close, name: name})
+					}
+				}
+			}
+			i = end
+			nameStart = -1
+			continue
+		case c == '[':
+			end := closingBracket(s, i)
+			if end < 0 {
+				return refs
+			}
+			if nameStart < 0 && isExternalRef(s, end) {
+				k := end + 1
+				for k < len(s) && isTableNameChar(rune(s[k])) {
+					k++
+				}
+				ref := externalRef{start: i, end: k, bookStart: i + 1, bookEnd: end}
+				switch {
+				case k == end+1 && k < len(s) && s[k] == '!':
+					// a defined name of the other workbook
+					k++
+					for k < len(s) && (isTableNameChar(rune(s[k])) || s[k] >= utf8.RuneSelf) {
+						k++
+					}
+					ref.end = k
+					ref.definedName = true
+				case k == len(s) || s[k] != '!':
+					i = end
+					continue
+				}
+				ref.name = s[ref.start:ref.end]
+				refs = append(refs, ref)
+				i = ref.end - 1
+				nameStart = -1
+				continue
+			}
+			// structured references, which may contain nested brackets
+			i = end
+			nameStart = -1
+			continue
+		}
+		if isTableNameChar(rune(c)) || c >= utf8.RuneSelf {
+			if nameStart < 0 {
+				nameStart = i
+			}
+		} else {
+			nameStart = -1
+		}
+	}
+	return refs
+}
+
+// closingQuote returns the index of the quote that closes the string or
+// quoted name starting at index i, skipping doubled quotes, or -1 if there is
+// none.
+func closingQuote(s string, i int) int {
+	q := s[i]
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != q {
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == q {
+			j++
+			continue
+		}
+		return j
+	}
+	return -1
+}
+
+// refToken is a reference that is found before the formula is passed to the
+// formula lexer, as the brackets of the reference may contain any text.
+type refToken struct {
+	start, end int
+	typ        tokenType
+	text       string
+}
+
+// refTokens returns the structured references and the references to other
+// workbooks of a formula in order. A reference to a sheet of another workbook
+// such as [1]Sheet1!A1 is a sheet token with the text [1]Sheet1, followed by
+// the cell, and a reference to a defined name such as [1]!Name is a name
+// token. Structured references are checked with valid as in
+// scanStructuredRefs.
+func refTokens(s string, valid func(text string) bool) []refToken {
+	var toks []refToken
+	for _, span := range scanStructuredRefs(s, valid) {
+		toks = append(toks, refToken{span[0], span[1], tokenStructuredRef, s[span[0]:span[1]]})
+	}
+	for _, ref := range scanExternalRefs(s) {
+		if ref.definedName {
+			toks = append(toks, refToken{ref.start, ref.end, generatedToken("tokenNamedRange"), ref.name})
+		} else {
+			// the sheet token includes the exclamation mark
+			toks = append(toks, refToken{ref.start, ref.end + 1, generatedToken("tokenSheet"), ref.name})
+		}
+	}
+	sort.Slice(toks, func(i, j int) bool { return toks[i].start < toks[j].start })
+	kept := toks[:0]
+	last := 0
+	for _, t := range toks {
+		if t.start >= last {
+			kept = append(kept, t)
+			last = t.end
+		}
+	}
+	return kept
+}
+
+// ParseExternalSheet splits the name of a sheet of another workbook, as it
+// appears in references such as [1]Sheet1!A1 or 'C:\Data\[Book2.xlsx]Sheet1'!A1,
+// into the workbook between the brackets and the sheet name. Workbooks are
+// stored in files as the index of the external link, e.g. "1", but formulas
+// may also name the file. It returns false if the name doesn't refer to
+// another workbook.
+func ParseExternalSheet(name string) (book, sheet string, ok bool) {
+	open := strings.IndexByte(name, '[')
+	if open < 0 {
+		return "", "", false
+	}
+	close := strings.IndexByte(name[open:], ']')
+	if close < 0 {
+		return "", "", false
+	}
+	return name[open+1 : open+close], name[open+close+1:], true
+}
+
+// ParseExternalName splits a reference to a defined name of another workbook
+// such as [1]!Rate into the workbook and the name. It returns false if the
+// name doesn't refer to another workbook.
+func ParseExternalName(name string) (book, definedName string, ok bool) {
+	book, rest, ok := ParseExternalSheet(name)
+	if !ok || !strings.HasPrefix(rest, "!") {
+		return "", "", false
+	}
+	return book, rest[1:], true
+}
+
+// ExternalBooks returns the workbooks that a formula refers to, as they appear
+// between the brackets of references such as [1]Sheet1!A1. Each workbook is
+// returned once, in the order of its first reference.
+func ExternalBooks(formula string) []string {
+	var books []string
+	seen := map[string]struct{}{}
+	for _, ref := range scanExternalRefs(formula) {
+		b := formula[ref.bookStart:ref.bookEnd]
+		if _, ok := seen[b]; !ok {
+			seen[b] = struct{}{}
+			books = append(books, b)
+		}
+	}
+	return books
+}
+
+// ReplaceExternalBooks returns the formula with the workbook of each reference
+// to another workbook replaced by the result of fn, e.g. to renumber the
+// references when an external link is removed.
+func ReplaceExternalBooks(formula string, fn func(book string) string) string {
+	refs := scanExternalRefs(formula)
+	if len(refs) == 0 {
+		return formula
+	}
+	sb := strings.Builder{}
+	last := 0
+	for _, ref := range refs {
+		sb.WriteString(formula[last:ref.bookStart])
+		sb.WriteString(fn(formula[ref.bookStart:ref.bookEnd]))
+		last = ref.bookEnd
+	}
+	sb.WriteString(formula[last:])
+	return sb.String()
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseExternalSheet(t *testing.T) {
+	td := []struct {
+		Name        string
+		Book, Sheet string
+		Ok          bool
+	}{
+		{"[1]Sheet1", "1", "Sheet1", true},
+		{"[Book2.xlsx]My Sheet", "Book2.xlsx", "My Sheet", true},
+		{`C:\Data\[Book2.xlsx]Sheet1`, "Book2.xlsx", "Sheet1", true},
+		{"[1]!Rate", "1", "!Rate", true},
+		{"Sheet1", "", "", false},
+		{"[1Sheet1", "", "", false},
+	}
+	for _, tc := range td {
+		book, sheet, ok := ParseExternalSheet(tc.Name)
+		if book != tc.Book || sheet != tc.Sheet || ok != tc.Ok {
+			t.Errorf("%s: expected %q %q %v, got %q %q %v", tc.Name, tc.Book, tc.Sheet, tc.Ok, book, sheet, ok)
+		}
+	}
+
+	if book, name, ok := ParseExternalName("[2]!Rate"); !ok || book != "2" || name != "Rate" {
+		t.Errorf("expected the name Rate of book 2, got %q %q", book, name)
+	}
+	if _, _, ok := ParseExternalName("[2]Sheet1"); ok {
+		t.Errorf("expected a sheet not to be a defined name")
+	}
+}
+
+func TestExternalBooks(t *testing.T) {
+	td := []struct {
+		Formula string
+		Exp     []string
+	}{
+		{"SUM(A1:B2)", nil},
+		{"[1]Sheet1!A1*2", []string{"1"}},
+		{"'[2]My Sheet'!B2+[1]Sheet1!A1+[2]Data!C3", []string{"2", "1"}},
+		{"[Book3.xlsx]!Rate+'C:\\Data\\[Book4.xlsx]Sheet1'!A1", []string{"Book3.xlsx", "Book4.xlsx"}},
+		{`"[1]Sheet1!A1"&Table1[Col]&Table1[[#This Row],[Col]]`, nil},
+	}
+	for _, tc := range td {
+		if got := ExternalBooks(tc.Formula); !reflect.DeepEqual(got, tc.Exp) {
+			t.Errorf("%s: expected %v, got %v", tc.Formula, tc.Exp, got)
+		}
+	}
+
+	renumber := func(b string) string {
+		if n, err := strconv.Atoi(b); err == nil && n > 1 {
+			return strconv.Itoa(n - 1)
+		}
+		return b
+	}
+	for _, tc := range []struct{ Formula, Exp string }{
+		{"[2]Sheet1!A1+[3]!Rate", "[1]Sheet1!A1+[2]!Rate"},
+		{"'[2]My Sheet'!A1&\"[2]\"", "'[1]My Sheet'!A1&\"[2]\""},
+		{"[Book2.xlsx]Sheet1!A1", "[Book2.xlsx]Sheet1!A1"},
+		{"A1", "A1"},
+	} {
+		if got := ReplaceExternalBooks(tc.Formula, renumber); got != tc.Exp {
+			t.Errorf("%s: expected %s, got %s", tc.Formula, tc.Exp, got)
+		}
+	}
+}
+
+func TestParseExternalReferences(t *testing.T) {
+	for _, f := range []string{
+		"[1]Sheet1!A1*2",
+		"SUM('[1]My Sheet'!A1:B3)",
+		"[1]!Rate*[2]Data!$C$3",
+		"'C:\\Data\\[Book2.xlsx]Sheet1'!A1",
+	} {
+		if ParseString(f) == nil {
+			t.Errorf("error parsing %s", f)
+		}
+	}
+}
+
+func TestLexExternalReferences(t *testing.T) {
+	sheet, name := generatedToken("tokenSheet"), generatedToken("tokenNamedRange")
+	td := []struct {
+		Inp  string
+		Exp  []string
+		Typs []tokenType
+	}{
+		{"[1]Sheet1!A1*2", []string{"[1]Sheet1", "A1", "*", "2"}, []tokenType{sheet}},
+		{"'C:\\Data\\[Book2.xlsx]It''s'!A1", []string{"C:\\Data\\[Book2.xlsx]It's", "A1"}, []tokenType{sheet}},
+		{"[1]!Rate+Sales[Qty]", []string{"[1]!Rate", "+", "Sales[Qty]"}, []tokenType{name, generatedToken("tokenPlus"), tokenStructuredRef}},
+		// names that look like the text of a reference are kept as they are
+		{"_xlx_5b315d216e+1", []string{"_xlx_5b315d216e", "+", "1"}, []tokenType{name}},
+	}
+	for _, tc := range td {
+		var got []string
+		var typs []tokenType
+		for n := range LexReader(strings.NewReader(tc.Inp)) {
+			if n._dgfdea < _ecaa {
+				break
+			}
+			got = append(got, n._acfe)
+			typs = append(typs, n._dgfdea)
+		}
+		if !reflect.DeepEqual(got, tc.Exp) {
+			t.Errorf("%s: expected the tokens %q, got %q", tc.Inp, tc.Exp, got)
+		}
+		if len(typs) < len(tc.Typs) || !reflect.DeepEqual(typs[:len(tc.Typs)], tc.Typs) {
+			t.Errorf("%s: expected the token types %v, got %v", tc.Inp, tc.Typs, typs)
+		}
+	}
+
+	if got := ParseString("_xlx_5b315d216e").String(); got != "_xlx_5b315d216e" {
+		t.Errorf("expected a name to be kept as it is, got %s", got)
+	}
+	if got := ParseString("[1]!Rate").String(); got != "[1]!Rate" {
+		t.Errorf("expected a name of another workbook to be kept, got %s", got)
+	}
+}
@@ -76,7 +76,7 @@ func (_gagfe PrefixExpr )Eval (ctx Context ,ev Evaluator )Result {_fgdf :=_gagfe
 func Couppcd (args []Result )Result {_ggfg ,_daee :=_gcbc (args ,"\u0043O\u0055\u0050\u0050\u0043\u0044");if _daee .Type ==ResultTypeError {return _daee ;};_fga :=_cdb (_ggfg ._bce );_cbggg :=_cdb (_ggfg ._dgf );_cfcg :=_ggfg ._fdef ;_deeb :=_ggfg ._fab ;_fbbf :=_aed (_fga ,_cbggg ,_cfcg ,_deeb );_begc ,_edd ,_beeg :=_fbbf .Date ();return MakeNumberResult (_decg (_begc ,int (_edd ),_beeg ));};const _geea =57368;var _aceed =map[string ]bool {"\u0049F\u0045\u0052\u0052\u004f\u0052":true ,"\u0049\u0046\u004e\u0041":true ,"\u005f\u0078\u006c\u0066\u006e\u002e\u0049\u0046\u004e\u0041":true ,"\u0049\u0053\u0045R\u0052":true ,"\u0049S\u0045\u0052\u0052\u004f\u0052":true ,"\u0049\u0053\u004e\u0041":true ,"\u0049\u0053\u0052E\u0046":true };func _gefca (_fegfg string )string {_fegfg =_ea .Replace (_fegfg ,"\u000a","\u005c\u006e",-1);_fegfg =_ea .Replace (_fegfg ,"\u000d","\u005c\u0072",-1);_fegfg =_ea .Replace (_fegfg ,"\u0009","\u005c\u0074",-1);return _fegfg ;};
 
 // ISEVEN is an implementation of the Excel ISEVEN() function.
-func IsEven (args []Result )Result {if len (args )!=1{return MakeErrorResult ("\u0049\u0053\u0045VE\u004e\u0028\u0029\u0020\u0061\u0063\u0063\u0065\u0070t\u0073 \u0061 \u0073i\u006e\u0067\u006c\u0065\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};if args [0].Type !=ResultTypeNumber {return MakeErrorResult ("\u0049\u0053\u0045\u0056\u0045\u004e \u0061\u0063\u0063\u0065\u0070\u0074\u0073\u0020\u0061\u0020\u006e\u0075\u006de\u0072\u0069\u0063\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_ddfc :=int (args [0].ValueNumber );return MakeBoolResult (_ddfc ==_ddfc /2*2);};func (_fcefd *Lexer )lex (_dfff _bg .Reader ){_caff ,_acbg ,_cfda :=0,0,0;_eeac :=-1;_aabeg ,_adda ,_abbc :=0,0,0;_ =_abbc ;_fbgda :=1;_ =_fbgda ;_adef :=make ([]byte ,4096);_aadfd :=false ;for !_aadfd {_dgcda :=0;if _aabeg > 0{_dgcda =_acbg -_aabeg ;};_acbg =0;_ggdf ,_gffce :=_dfff .Read (_adef [_dgcda :]);if _ggdf ==0||_gffce !=nil {_aadfd =true ;};_cfda =_ggdf +_dgcda ;if _cfda < len (_adef ){_eeac =_cfda ;};{_caff =_ebaaa ;_aabeg =0;_adda =0;_abbc =0;};{var _gbegf int ;var _cfcef uint ;if _acbg ==_cfda {goto _dcbed ;};if _caff ==0{goto _gged ;};_fbgcb :_gbegf =int (_aeaf [_caff ]);_cfcef =uint (_ggae [_gbegf ]);_gbegf ++;for ;_cfcef > 0;_cfcef --{_gbegf ++;switch _ggae [_gbegf -1]{case 2:_aabeg =_acbg ;};};switch _caff {case 30:switch _adef [_acbg ]{case 34:goto _dbgf ;case 35:goto _fgbe ;case 36:goto _ebeb ;case 38:goto _gcaf ;case 39:goto _cfdg ;case 40:goto _fdbag ;case 41:goto _cgbb ;case 42:goto _fdbaf ;case 43:goto _egfd ;case 44:goto _agae ;case 45:goto _dgdcd ;case 47:goto _cadg ;case 58:goto _effce ;case 59:goto _dgee ;case 60:goto _gcaa ;case 61:goto _afab ;case 62:goto _fdefe ;case 63:goto _fbcd ;case 70:goto _fbcdc ;case 84:goto _afggba ;case 92:goto _bdgfd ;case 94:goto _ebde ;case 95:goto _ceegg ;case 123:goto _cdegg ;case 125:goto _gdaadg ;};switch {case _adef [_acbg ]< 65:switch {case _adef [_acbg ]> 37:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cbegg ;};case _adef [_acbg ]>=33:goto _fbcd ;};case _adef [_acbg ]> 90:switch {case _adef [_acbg ]> 93:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _ddce ;};case _adef [_acbg ]>=91:goto _fbcd ;};default:goto _defe ;};goto _fbgab ;case 1:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _ggdd ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _ggdd ;};case _adef [_acbg ]> 45:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _ggdd ;};case _adef [_acbg ]>=58:goto _ggdd ;};default:goto _ggdd ;};goto _fbgab ;case 0:goto _gged ;case 2:if _adef [_acbg ]==34{goto _cebd ;};goto _dbgf ;case 31:if _adef [_acbg ]==34{goto _dbgf ;};goto _gdag ;case 3:switch _adef [_acbg ]{case 78:goto _ffcf ;case 82:goto _eegb ;};goto _fbcd ;case 4:switch _adef [_acbg ]{case 47:goto _egcc ;case 85:goto _geca ;};goto _fbcd ;case 5:if _adef [_acbg ]==65{goto _acagg ;};goto _fbcd ;case 6:switch _adef [_acbg ]{case 76:goto _gfbe ;case 77:goto _fceg ;};goto _fbcd ;case 7:if _adef [_acbg ]==76{goto _fceg ;};goto _fbcd ;case 8:if _adef [_acbg ]==33{goto _acagg ;};goto _fbcd ;case 9:if _adef [_acbg ]==69{goto _dbbgd ;};goto _fbcd ;case 10:if _adef [_acbg ]==70{goto _aafgg ;};goto _fbcd ;case 11:if _adef [_acbg ]==33{goto _eadeg ;};goto _fbcd ;case 12:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _fbcd ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _fbcd ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]< 65:if 58<=_adef [_acbg ]&&_adef [_acbg ]<=63{goto _fbcd ;};case _adef [_acbg ]> 90:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _fbcd ;};default:goto _gbadf ;};default:goto _cfagg ;};goto _fbgab ;case 13:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _fbcd ;case 58:goto _begcd ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _fbcd ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _fbcd ;};case _adef [_acbg ]>=59:goto _fbcd ;};default:goto _cfagg ;};goto _fbgab ;case 14:if _adef [_acbg ]==36{goto _aafb ;};if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cdcea ;};goto _ggdd ;case 15:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cdcea ;};goto _ggdd ;case 32:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cdcea ;};goto _acaab ;case 16:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 47:goto _fbcd ;case 58:goto _ccga ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 45:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _ggad ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]> 90:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _fbcd ;};case _adef [_acbg ]>=65:goto _gbadf ;};default:goto _fbcd ;};goto _fbgab ;case 17:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _ggdd ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _ggdd ;};case _adef [_acbg ]>=34:goto _ggdd ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _ggdd ;};case _adef [_acbg ]>=58:goto _ggdd ;};default:goto _ggad ;};goto _fbgab ;case 33:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _cgce ;case 123:goto _cgce ;case 125:goto _cgce ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _cgce ;};case _adef [_acbg ]>=34:goto _cgce ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cgce ;};case _adef [_acbg ]>=58:goto _cgce ;};default:goto _ggad ;};goto _fbgab ;case 18:if _adef [_acbg ]==36{goto _feggc ;};if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dgfee ;};goto _ggdd ;case 19:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dgfee ;};goto _ggdd ;case 34:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dgfee ;};goto _aged ;case 20:switch _adef [_acbg ]{case 39:goto _fbcd ;case 42:goto _fbcd ;case 47:goto _fbcd ;case 58:goto _fbcd ;case 63:goto _fbcd ;};if 91<=_adef [_acbg ]&&_adef [_acbg ]<=93{goto _fbcd ;};goto _adab ;case 21:switch _adef [_acbg ]{case 39:goto _fdgde ;case 42:goto _fbcd ;case 47:goto _fbcd ;case 58:goto _fbcd ;case 63:goto _fbcd ;};if 91<=_adef [_acbg ]&&_adef [_acbg ]<=93{goto _fbcd ;};goto _adab ;case 22:if _adef [_acbg ]==33{goto _abbea ;};goto _fbcd ;case 35:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _ggaec ;case 58:goto _begcd ;case 101:goto _gegcf ;case 123:goto _cdabg ;case 125:goto _cdabg ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=47{goto _cdabg ;};case _adef [_acbg ]>=34:goto _cdabg ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cdabg ;};case _adef [_acbg ]>=59:goto _cdabg ;};default:goto _cbegg ;};goto _fbgab ;case 36:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _cdabg ;case 101:goto _gegcf ;case 123:goto _cdabg ;case 125:goto _cdabg ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _cdabg ;};case _adef [_acbg ]>=34:goto _cdabg ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cdabg ;};case _adef [_acbg ]>=58:goto _cdabg ;};default:goto _ggaec ;};goto _fbgab ;case 23:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _efega ;case 123:goto _efega ;case 125:goto _efega ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _efega ;};case _adef [_acbg ]>=34:goto _efega ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _efega ;};case _adef [_acbg ]>=58:goto _efega ;};default:goto _eggaf ;};goto _fbgab ;case 37:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _cdabg ;case 123:goto _cdabg ;case 125:goto _cdabg ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _cdabg ;};case _adef [_acbg ]>=34:goto _cdabg ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cdabg ;};case _adef [_acbg ]>=58:goto _cdabg ;};default:goto _eggaf ;};goto _fbgab ;case 38:switch _adef [_acbg ]{case 61:goto _bccbb ;case 62:goto _geffe ;};goto _gccc ;case 39:if _adef [_acbg ]==61{goto _fbgb ;};goto _ggcd ;case 24:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 40:switch _adef [_acbg ]{case 33:goto _dcfb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _aecag ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _aecag ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 41:switch _adef [_acbg ]{case 46:goto _cfbaa ;case 92:goto _cfbaa ;case 95:goto _cfbaa ;};switch {case _adef [_acbg ]< 65:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cfbaa ;};case _adef [_acbg ]> 90:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _cfbaa ;};default:goto _cfbaa ;};goto _bgdc ;case 42:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 43:switch _adef [_acbg ]{case 33:goto _dcfb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _cgce ;case 125:goto _cgce ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _cgce ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};default:goto _cgce ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _aecag ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _cgce ;};default:goto _cgce ;};goto _fbgab ;case 44:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _ggdd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _ggdd ;};default:goto _ggdd ;};goto _fbgab ;case 25:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 65:goto _gdgfe ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 66<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 45:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 76:goto _adaa ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 46:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 83:goto _gffcd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 47:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 69:goto _ddae ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 26:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 79:goto _bgddf ;case 82:goto _agcd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 48:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 68:goto _egfeb ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 49:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 79:goto _baga ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 50:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 85:goto _gffcd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 27:switch _adef [_acbg ]{case 46:goto _cfbaa ;case 92:goto _cfbaa ;case 95:goto _cfbaa ;};switch {case _adef [_acbg ]< 65:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cfbaa ;};case _adef [_acbg ]> 90:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _cfbaa ;};default:goto _cfbaa ;};goto _fbcd ;case 28:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 120:goto _gedf ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _fbcd ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 51:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 108:goto _gcdeb ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 52:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 102:goto _cefde ;case 110:goto _fbcbe ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 53:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 110:goto _ecbdbb ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 54:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _bgaa ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 55:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _dbdd ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dbdd ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 56:switch _adef [_acbg ]{case 33:goto _dcfb ;case 40:goto _gdgec ;case 46:goto _dbdd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _dbdd ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dbdd ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 57:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 109:goto _bafe ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 58:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gecb ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 59:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _efdg ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _ggdd ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _ggdd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _efdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _ggdd ;};default:goto _ggdd ;};goto _fbgab ;case 29:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _fbcd ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;};_fbcd :_caff =0;goto _egecb ;_fbgab :_caff =1;goto _egecb ;_dbgf :_caff =2;goto _egecb ;_fgbe :_caff =3;goto _egecb ;_ffcf :_caff =4;goto _egecb ;_egcc :_caff =5;goto _egecb ;_geca :_caff =6;goto _egecb ;_gfbe :_caff =7;goto _egecb ;_fceg :_caff =8;goto _egecb ;_eegb :_caff =9;goto _egecb ;_dbbgd :_caff =10;goto _egecb ;_aafgg :_caff =11;goto _egecb ;_ebeb :_caff =12;goto _egecb ;_cfagg :_caff =13;goto _egecb ;_begcd :_caff =14;goto _egecb ;_aafb :_caff =15;goto _egecb ;_gbadf :_caff =16;goto _egecb ;_aggb :_caff =17;goto _egecb ;_ccga :_caff =18;goto _egecb ;_feggc :_caff =19;goto _egecb ;_cfdg :_caff =20;goto _egecb ;_adab :_caff =21;goto _egecb ;_fdgde :_caff =22;goto _egecb ;_gegcf :_caff =23;goto _egecb ;_defe :_caff =24;goto _egecb ;_fbcdc :_caff =25;goto _egecb ;_afggba :_caff =26;goto _egecb ;_bdgfd :_caff =27;goto _egecb ;_ceegg :_caff =28;goto _egecb ;_ddce :_caff =29;goto _egecb ;_ggdd :_caff =30;goto _fcbf ;_dcfb :_caff =30;goto _dgcad ;_acagg :_caff =30;goto _cggaa ;_eadeg :_caff =30;goto _dbcdf ;_abbea :_caff =30;goto _dfgcc ;_efega :_caff =30;goto _gcbba ;_fbgg :_caff =30;goto _eebcg ;_gcaf :_caff =30;goto _ebbeg ;_fdbag :_caff =30;goto _bdac ;_cgbb :_caff =30;goto _ccfa ;_fdbaf :_caff =30;goto _cfcgg ;_egfd :_caff =30;goto _dbde ;_agae :_caff =30;goto _ebeg ;_dgdcd :_caff =30;goto _fcag ;_cadg :_caff =30;goto _gbfgd ;_effce :_caff =30;goto _cbgec ;_dgee :_caff =30;goto _abbeb ;_afab :_caff =30;goto _gcccc ;_ebde :_caff =30;goto _gcfgg ;_cdegg :_caff =30;goto _debfg ;_gdaadg :_caff =30;goto _cefaeg ;_gdag :_caff =30;goto _dade ;_acaab :_caff =30;goto _gcfae ;_cgce :_caff =30;goto _aagbf ;_aged :_caff =30;goto _efdc ;_cdabg :_caff =30;goto _gcfc ;_gccc :_caff =30;goto _bedd ;_bccbb :_caff =30;goto _acbgg ;_geffe :_caff =30;goto _egefb ;_ggcd :_caff =30;goto _fgec ;_fbgb :_caff =30;goto _cffdg ;_bgdc :_caff =30;goto _dce ;_gdgec :_caff =30;goto _eacc ;_cebd :_caff =31;goto _caafd ;_cdcea :_caff =32;goto _egecb ;_ggad :_caff =33;goto _gacee ;_dgfee :_caff =34;goto _egecb ;_cbegg :_caff =35;goto _cdcc ;_ggaec :_caff =36;goto _cdcc ;_eggaf :_caff =37;goto _cdcc ;_gcaa :_caff =38;goto _egecb ;_fdefe :_caff =39;goto _egecb ;_aecag :_caff =40;goto _fgab ;_cfbaa :_caff =41;goto _egecb ;_gbgdg :_caff =42;goto _fgab ;_eabe :_caff =43;goto _gacee ;_dged :_caff =44;goto _fgab ;_ddae :_caff =44;goto _afadf ;_baga :_caff =44;goto _aebcb ;_gdgfe :_caff =45;goto _fgab ;_adaa :_caff =46;goto _fgab ;_gffcd :_caff =47;goto _fgab ;_bgddf :_caff =48;goto _fgab ;_egfeb :_caff =49;goto _fgab ;_agcd :_caff =50;goto _fgab ;_gedf :_caff =51;goto _fgab ;_gcdeb :_caff =52;goto _fgab ;_cefde :_caff =53;goto _fgab ;_ecbdbb :_caff =54;goto _fgab ;_bgaa :_caff =55;goto _fgab ;_dbdd :_caff =56;goto _fgab ;_fbcbe :_caff =57;goto _fgab ;_bafe :_caff =58;goto _fgab ;_gecb :_caff =59;goto _fgab ;_efdg :_caff =59;goto _fccb ;_cggaa :_gbegf =3;goto _dace ;_dbcdf :_gbegf =5;goto _dace ;_dgcad :_gbegf =7;goto _dace ;_dfgcc :_gbegf =9;goto _dace ;_eebcg :_gbegf =11;goto _dace ;_eacc :_gbegf =13;goto _dace ;_ebbeg :_gbegf =15;goto _dace ;_debfg :_gbegf =17;goto _dace ;_cefaeg :_gbegf =19;goto _dace ;_bdac :_gbegf =21;goto _dace ;_ccfa :_gbegf =23;goto _dace ;_dbde :_gbegf =25;goto _dace ;_fcag :_gbegf =27;goto _dace ;_cfcgg :_gbegf =29;goto _dace ;_gbfgd :_gbegf =31;goto _dace ;_gcfgg :_gbegf =33;goto _dace ;_gcccc :_gbegf =35;goto _dace ;_acbgg :_gbegf =37;goto _dace ;_cffdg :_gbegf =39;goto _dace ;_egefb :_gbegf =41;goto _dace ;_cbgec :_gbegf =43;goto _dace ;_abbeb :_gbegf =45;goto _dace ;_ebeg :_gbegf =47;goto _dace ;_gcfc :_gbegf =49;goto _dace ;_aagbf :_gbegf =51;goto _dace ;_gcfae :_gbegf =53;goto _dace ;_efdc :_gbegf =55;goto _dace ;_dce :_gbegf =57;goto _dace ;_dade :_gbegf =59;goto _dace ;_bedd :_gbegf =61;goto _dace ;_fgec :_gbegf =63;goto _dace ;_gcbba :_gbegf =65;goto _dace ;_fcbf :_gbegf =67;goto _dace ;_afadf :_gbegf =72;goto _dace ;_cdcc :_gbegf =75;goto _dace ;_gacee :_gbegf =78;goto _dace ;_aebcb :_gbegf =81;goto _dace ;_fccb :_gbegf =84;goto _dace ;_fgab :_gbegf =87;goto _dace ;_caafd :_gbegf =90;goto _dace ;_dace :_cfcef =uint (_ggae [_gbegf ]);_gbegf ++;for ;_cfcef > 0;_cfcef --{_gbegf ++;switch _ggae [_gbegf -1]{case 3:_adda =_acbg +1;case 4:_abbc =1;case 5:_abbc =2;case 6:_abbc =3;case 7:_abbc =4;case 8:_abbc =11;case 9:_abbc =14;case 10:_abbc =15;case 11:_adda =_acbg +1;{_fcefd .emit (_eecea ,_adef [_aabeg :_adda ]);};case 12:_adda =_acbg +1;{_fcefd .emit (_fdgc ,_adef [_aabeg :_adda ]);};case 13:_adda =_acbg +1;{_fcefd .emit (_debg ,_adef [_aabeg :_adda -1]);};case 14:_adda =_acbg +1;{_fcefd .emit (_debg ,_adef [_aabeg +1:_adda -2]);};case 15:_adda =_acbg +1;{_fcefd .emit (_ecbb ,_adef [_aabeg :_adda -1]);};case 16:_adda =_acbg +1;{_fcefd .emit (_ecbb ,_adef [_aabeg :_adda -1]);};case 17:_adda =_acbg +1;{_fcefd .emit (_fbcfb ,_adef [_aabeg :_adda ]);};case 18:_adda =_acbg +1;{_fcefd .emit (_gddd ,_adef [_aabeg :_adda ]);};case 19:_adda =_acbg +1;{_fcefd .emit (_fgeg ,_adef [_aabeg :_adda ]);};case 20:_adda =_acbg +1;{_fcefd .emit (_badd ,_adef [_aabeg :_adda ]);};case 21:_adda =_acbg +1;{_fcefd .emit (_ecbfa ,_adef [_aabeg :_adda ]);};case 22:_adda =_acbg +1;{_fcefd .emit (_egada ,_adef [_aabeg :_adda ]);};case 23:_adda =_acbg +1;{_fcefd .emit (_bdgfb ,_adef [_aabeg :_adda ]);};case 24:_adda =_acbg +1;{_fcefd .emit (_begf ,_adef [_aabeg :_adda ]);};case 25:_adda =_acbg +1;{_fcefd .emit (_abgc ,_adef [_aabeg :_adda ]);};case 26:_adda =_acbg +1;{_fcefd .emit (_geea ,_adef [_aabeg :_adda ]);};case 27:_adda =_acbg +1;{_fcefd .emit (_eceg ,_adef [_aabeg :_adda ]);};case 28:_adda =_acbg +1;{_fcefd .emit (_dcgb ,_adef [_aabeg :_adda ]);};case 29:_adda =_acbg +1;{_fcefd .emit (_dgeg ,_adef [_aabeg :_adda ]);};case 30:_adda =_acbg +1;{_fcefd .emit (_edbf ,_adef [_aabeg :_adda ]);};case 31:_adda =_acbg +1;{_fcefd .emit (_eggaa ,_adef [_aabeg :_adda ]);};case 32:_adda =_acbg +1;{_fcefd .emit (_cbfef ,_adef [_aabeg :_adda ]);};case 33:_adda =_acbg +1;{_fcefd .emit (_cbdf ,_adef [_aabeg :_adda ]);};case 34:_adda =_acbg ;_acbg --;{_fcefd .emit (_cdggf ,_adef [_aabeg :_adda ]);};case 35:_adda =_acbg ;_acbg --;{_fcefd .emit (_faacc ,_adef [_aabeg :_adda ]);};case 36:_adda =_acbg ;_acbg --;{_fcefd .emit (_ecaa ,_adef [_aabeg :_adda ]);};case 37:_adda =_acbg ;_acbg --;{_fcefd .emit (_deaf ,_adef [_aabeg :_adda ]);};case 38:_adda =_acbg ;_acbg --;{_fcefd .emit (_eagf ,_adef [_aabeg :_adda ]);};case 39:_adda =_acbg ;_acbg --;{_fcefd .emit (_cbgbb ,_adef [_aabeg +1:_adda -1]);};case 40:_adda =_acbg ;_acbg --;{_fcefd .emit (_ggegg ,_adef [_aabeg :_adda ]);};case 41:_adda =_acbg ;_acbg --;{_fcefd .emit (_dddca ,_adef [_aabeg :_adda ]);};case 42:_acbg =(_adda )-1;{_fcefd .emit (_cdggf ,_adef [_aabeg :_adda ]);};case 43:switch _abbc {case 0:{_caff =0;goto _egecb ;};case 1:{_acbg =(_adda )-1;_fcefd .emit (_ccdba ,_adef [_aabeg :_adda ]);};case 2:{_acbg =(_adda )-1;_fcefd .emit (_cdggf ,_adef [_aabeg :_adda ]);};case 3:{_acbg =(_adda )-1;_fcefd .emit (_faacc ,_adef [_aabeg :_adda ]);};case 4:{_acbg =(_adda )-1;_fcefd .emit (_cbfdd ,_adef [_aabeg :_adda ]);};case 11:{_acbg =(_adda )-1;_fcefd .emit (_ccgbd ,_adef [_aabeg :_adda ]);};case 14:{_acbg =(_adda )-1;_fcefd .emit (_eagf ,_adef [_aabeg :_adda ]);};case 15:{_acbg =(_adda )-1;_fcefd .emit (_cbgbb ,_adef [_aabeg +1:_adda -1]);};};};};goto _egecb ;_egecb :_gbegf =int (_ffacfe [_caff ]);_cfcef =uint (_ggae [_gbegf ]);_gbegf ++;for ;_cfcef > 0;_cfcef --{_gbegf ++;switch _ggae [_gbegf -1]{case 0:_aabeg =0;case 1:_abbc =0;};};if _caff ==0{goto _gged ;};if _acbg ++;_acbg !=_cfda {goto _fbgcb ;};_dcbed :{};if _acbg ==_eeac {switch _caff {case 1:goto _ggdd ;case 2:goto _ggdd ;case 31:goto _gdag ;case 14:goto _ggdd ;case 15:goto _ggdd ;case 32:goto _acaab ;case 17:goto _ggdd ;case 33:goto _cgce ;case 18:goto _ggdd ;case 19:goto _ggdd ;case 34:goto _aged ;case 35:goto _cdabg ;case 36:goto _cdabg ;case 23:goto _efega ;case 37:goto _cdabg ;case 38:goto _gccc ;case 39:goto _ggcd ;case 40:goto _bgdc ;case 41:goto _bgdc ;case 42:goto _bgdc ;case 43:goto _cgce ;case 44:goto _ggdd ;case 45:goto _bgdc ;case 46:goto _bgdc ;case 47:goto _bgdc ;case 48:goto _bgdc ;case 49:goto _bgdc ;case 50:goto _bgdc ;case 51:goto _bgdc ;case 52:goto _bgdc ;case 53:goto _bgdc ;case 54:goto _bgdc ;case 55:goto _bgdc ;case 56:goto _bgdc ;case 57:goto _bgdc ;case 58:goto _bgdc ;case 59:goto _ggdd ;};};_gged :{};};if _aabeg > 0{copy (_adef [0:],_adef [_aabeg :]);};};_ =_eeac ;if _caff ==_dgcfg {_fcefd .emit (_fbebc ,nil );};close (_fcefd ._aabcee );};
+func IsEven (args []Result )Result {if len (args )!=1{return MakeErrorResult ("\u0049\u0053\u0045VE\u004e\u0028\u0029\u0020\u0061\u0063\u0063\u0065\u0070t\u0073 \u0061 \u0073i\u006e\u0067\u006c\u0065\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};if args [0].Type !=ResultTypeNumber {return MakeErrorResult ("\u0049\u0053\u0045\u0056\u0045\u004e \u0061\u0063\u0063\u0065\u0070\u0074\u0073\u0020\u0061\u0020\u006e\u0075\u006de\u0072\u0069\u0063\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_ddfc :=int (args [0].ValueNumber );return MakeBoolResult (_ddfc ==_ddfc /2*2);};func (_fcefd *Lexer )_gbfeca (_dfff _bg .Reader ){_caff ,_acbg ,_cfda :=0,0,0;_eeac :=-1;_aabeg ,_adda ,_abbc :=0,0,0;_ =_abbc ;_fbgda :=1;_ =_fbgda ;_adef :=make ([]byte ,4096);_aadfd :=false ;for !_aadfd {_dgcda :=0;if _aabeg > 0{_dgcda =_acbg -_aabeg ;};_acbg =0;_ggdf ,_gffce :=_dfff .Read (_adef [_dgcda :]);if _ggdf ==0||_gffce !=nil {_aadfd =true ;};_cfda =_ggdf +_dgcda ;if _cfda < len (_adef ){_eeac =_cfda ;};{_caff =_ebaaa ;_aabeg =0;_adda =0;_abbc =0;};{var _gbegf int ;var _cfcef uint ;if _acbg ==_cfda {goto _dcbed ;};if _caff ==0{goto _gged ;};_fbgcb :_gbegf =int (_aeaf [_caff ]);_cfcef =uint (_ggae [_gbegf ]);_gbegf ++;for ;_cfcef > 0;_cfcef --{_gbegf ++;switch _ggae [_gbegf -1]{case 2:_aabeg =_acbg ;};};switch _caff {case 30:switch _adef [_acbg ]{case 34:goto _dbgf ;case 35:goto _fgbe ;case 36:goto _ebeb ;case 38:goto _gcaf ;case 39:goto _cfdg ;case 40:goto _fdbag ;case 41:goto _cgbb ;case 42:goto _fdbaf ;case 43:goto _egfd ;case 44:goto _agae ;case 45:goto _dgdcd ;case 47:goto _cadg ;case 58:goto _effce ;case 59:goto _dgee ;case 60:goto _gcaa ;case 61:goto _afab ;case 62:goto _fdefe ;case 63:goto _fbcd ;case 70:goto _fbcdc ;case 84:goto _afggba ;case 92:goto _bdgfd ;case 94:goto _ebde ;case 95:goto _ceegg ;case 123:goto _cdegg ;case 125:goto _gdaadg ;};switch {case _adef [_acbg ]< 65:switch {case _adef [_acbg ]> 37:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cbegg ;};case _adef [_acbg ]>=33:goto _fbcd ;};case _adef [_acbg ]> 90:switch {case _adef [_acbg ]> 93:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _ddce ;};case _adef [_acbg ]>=91:goto _fbcd ;};default:goto _defe ;};goto _fbgab ;case 1:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _ggdd ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _ggdd ;};case _adef [_acbg ]> 45:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _ggdd ;};case _adef [_acbg ]>=58:goto _ggdd ;};default:goto _ggdd ;};goto _fbgab ;case 0:goto _gged ;case 2:if _adef [_acbg ]==34{goto _cebd ;};goto _dbgf ;case 31:if _adef [_acbg ]==34{goto _dbgf ;};goto _gdag ;case 3:switch _adef [_acbg ]{case 78:goto _ffcf ;case 82:goto _eegb ;};goto _fbcd ;case 4:switch _adef [_acbg ]{case 47:goto _egcc ;case 85:goto _geca ;};goto _fbcd ;case 5:if _adef [_acbg ]==65{goto _acagg ;};goto _fbcd ;case 6:switch _adef [_acbg ]{case 76:goto _gfbe ;case 77:goto _fceg ;};goto _fbcd ;case 7:if _adef [_acbg ]==76{goto _fceg ;};goto _fbcd ;case 8:if _adef [_acbg ]==33{goto _acagg ;};goto _fbcd ;case 9:if _adef [_acbg ]==69{goto _dbbgd ;};goto _fbcd ;case 10:if _adef [_acbg ]==70{goto _aafgg ;};goto _fbcd ;case 11:if _adef [_acbg ]==33{goto _eadeg ;};goto _fbcd ;case 12:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _fbcd ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _fbcd ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]< 65:if 58<=_adef [_acbg ]&&_adef [_acbg ]<=63{goto _fbcd ;};case _adef [_acbg ]> 90:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _fbcd ;};default:goto _gbadf ;};default:goto _cfagg ;};goto _fbgab ;case 13:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _fbcd ;case 58:goto _begcd ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _fbcd ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _fbcd ;};case _adef [_acbg ]>=59:goto _fbcd ;};default:goto _cfagg ;};goto _fbgab ;case 14:if _adef [_acbg ]==36{goto _aafb ;};if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cdcea ;};goto _ggdd ;case 15:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cdcea ;};goto _ggdd ;case 32:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cdcea ;};goto _acaab ;case 16:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 47:goto _fbcd ;case 58:goto _ccga ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 45:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _ggad ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]> 90:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _fbcd ;};case _adef [_acbg ]>=65:goto _gbadf ;};default:goto _fbcd ;};goto _fbgab ;case 17:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _ggdd ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _ggdd ;};case _adef [_acbg ]>=34:goto _ggdd ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _ggdd ;};case _adef [_acbg ]>=58:goto _ggdd ;};default:goto _ggad ;};goto _fbgab ;case 33:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _cgce ;case 123:goto _cgce ;case 125:goto _cgce ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _cgce ;};case _adef [_acbg ]>=34:goto _cgce ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cgce ;};case _adef [_acbg ]>=58:goto _cgce ;};default:goto _ggad ;};goto _fbgab ;case 18:if _adef [_acbg ]==36{goto _feggc ;};if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dgfee ;};goto _ggdd ;case 19:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dgfee ;};goto _ggdd ;case 34:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dgfee ;};goto _aged ;case 20:switch _adef [_acbg ]{case 39:goto _fbcd ;case 42:goto _fbcd ;case 47:goto _fbcd ;case 58:goto _fbcd ;case 63:goto _fbcd ;};if 91<=_adef [_acbg ]&&_adef [_acbg ]<=93{goto _fbcd ;};goto _adab ;case 21:switch _adef [_acbg ]{case 39:goto _fdgde ;case 42:goto _fbcd ;case 47:goto _fbcd ;case 58:goto _fbcd ;case 63:goto _fbcd ;};if 91<=_adef [_acbg ]&&_adef [_acbg ]<=93{goto _fbcd ;};goto _adab ;case 22:if _adef [_acbg ]==33{goto _abbea ;};goto _fbcd ;case 35:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _ggaec ;case 58:goto _begcd ;case 101:goto _gegcf ;case 123:goto _cdabg ;case 125:goto _cdabg ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=47{goto _cdabg ;};case _adef [_acbg ]>=34:goto _cdabg ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cdabg ;};case _adef [_acbg ]>=59:goto _cdabg ;};default:goto _cbegg ;};goto _fbgab ;case 36:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _cdabg ;case 101:goto _gegcf ;case 123:goto _cdabg ;case 125:goto _cdabg ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _cdabg ;};case _adef [_acbg ]>=34:goto _cdabg ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cdabg ;};case _adef [_acbg ]>=58:goto _cdabg ;};default:goto _ggaec ;};goto _fbgab ;case 23:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _efega ;case 123:goto _efega ;case 125:goto _efega ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _efega ;};case _adef [_acbg ]>=34:goto _efega ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _efega ;};case _adef [_acbg ]>=58:goto _efega ;};default:goto _eggaf ;};goto _fbgab ;case 37:switch _adef [_acbg ]{case 33:goto _dcfb ;case 47:goto _cdabg ;case 123:goto _cdabg ;case 125:goto _cdabg ;};switch {case _adef [_acbg ]< 48:switch {case _adef [_acbg ]> 35:if 37<=_adef [_acbg ]&&_adef [_acbg ]<=45{goto _cdabg ;};case _adef [_acbg ]>=34:goto _cdabg ;};case _adef [_acbg ]> 57:switch {case _adef [_acbg ]> 63:if 91<=_adef [_acbg ]&&_adef [_acbg ]<=94{goto _cdabg ;};case _adef [_acbg ]>=58:goto _cdabg ;};default:goto _eggaf ;};goto _fbgab ;case 38:switch _adef [_acbg ]{case 61:goto _bccbb ;case 62:goto _geffe ;};goto _gccc ;case 39:if _adef [_acbg ]==61{goto _fbgb ;};goto _ggcd ;case 24:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 40:switch _adef [_acbg ]{case 33:goto _dcfb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _aecag ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _aecag ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 41:switch _adef [_acbg ]{case 46:goto _cfbaa ;case 92:goto _cfbaa ;case 95:goto _cfbaa ;};switch {case _adef [_acbg ]< 65:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cfbaa ;};case _adef [_acbg ]> 90:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _cfbaa ;};default:goto _cfbaa ;};goto _bgdc ;case 42:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 43:switch _adef [_acbg ]{case 33:goto _dcfb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _cgce ;case 125:goto _cgce ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _cgce ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};default:goto _cgce ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _aecag ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _cgce ;};default:goto _cgce ;};goto _fbgab ;case 44:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _ggdd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _ggdd ;};default:goto _ggdd ;};goto _fbgab ;case 25:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 65:goto _gdgfe ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 66<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 45:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 76:goto _adaa ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 46:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 83:goto _gffcd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 47:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 69:goto _ddae ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 26:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 79:goto _bgddf ;case 82:goto _agcd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 48:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 68:goto _egfeb ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 49:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 79:goto _baga ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 50:switch _adef [_acbg ]{case 33:goto _dcfb ;case 36:goto _aggb ;case 40:goto _fbgg ;case 46:goto _aecag ;case 58:goto _ccga ;case 85:goto _gffcd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 59:switch {case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _eabe ;};case _adef [_acbg ]>=34:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dged ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 27:switch _adef [_acbg ]{case 46:goto _cfbaa ;case 92:goto _cfbaa ;case 95:goto _cfbaa ;};switch {case _adef [_acbg ]< 65:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _cfbaa ;};case _adef [_acbg ]> 90:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _cfbaa ;};default:goto _cfbaa ;};goto _fbcd ;case 28:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 120:goto _gedf ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _fbcd ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;case 51:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 108:goto _gcdeb ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 52:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 102:goto _cefde ;case 110:goto _fbcbe ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 53:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 110:goto _ecbdbb ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 54:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _bgaa ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 55:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _dbdd ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dbdd ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 56:switch _adef [_acbg ]{case 33:goto _dcfb ;case 40:goto _gdgec ;case 46:goto _dbdd ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _dbdd ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _dbdd ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 57:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 109:goto _bafe ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 58:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gecb ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _bgdc ;case 125:goto _bgdc ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _bgdc ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _bgdc ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _bgdc ;};default:goto _bgdc ;};goto _fbgab ;case 59:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _efdg ;case 123:goto _ggdd ;case 125:goto _ggdd ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _ggdd ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _ggdd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _efdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _ggdd ;};default:goto _ggdd ;};goto _fbgab ;case 29:switch _adef [_acbg ]{case 33:goto _dcfb ;case 46:goto _gbgdg ;case 92:goto _cfbaa ;case 95:goto _gbgdg ;case 123:goto _fbcd ;case 125:goto _fbcd ;};switch {case _adef [_acbg ]< 58:switch {case _adef [_acbg ]< 37:if 34<=_adef [_acbg ]&&_adef [_acbg ]<=35{goto _fbcd ;};case _adef [_acbg ]> 47:if 48<=_adef [_acbg ]&&_adef [_acbg ]<=57{goto _gbgdg ;};default:goto _fbcd ;};case _adef [_acbg ]> 63:switch {case _adef [_acbg ]< 91:if 65<=_adef [_acbg ]&&_adef [_acbg ]<=90{goto _gbgdg ;};case _adef [_acbg ]> 94:if 97<=_adef [_acbg ]&&_adef [_acbg ]<=122{goto _gbgdg ;};default:goto _fbcd ;};default:goto _fbcd ;};goto _fbgab ;};_fbcd :_caff =0;goto _egecb ;_fbgab :_caff =1;goto _egecb ;_dbgf :_caff =2;goto _egecb ;_fgbe :_caff =3;goto _egecb ;_ffcf :_caff =4;goto _egecb ;_egcc :_caff =5;goto _egecb ;_geca :_caff =6;goto _egecb ;_gfbe :_caff =7;goto _egecb ;_fceg :_caff =8;goto _egecb ;_eegb :_caff =9;goto _egecb ;_dbbgd :_caff =10;goto _egecb ;_aafgg :_caff =11;goto _egecb ;_ebeb :_caff =12;goto _egecb ;_cfagg :_caff =13;goto _egecb ;_begcd :_caff =14;goto _egecb ;_aafb :_caff =15;goto _egecb ;_gbadf :_caff =16;goto _egecb ;_aggb :_caff =17;goto _egecb ;_ccga :_caff =18;goto _egecb ;_feggc :_caff =19;goto _egecb ;_cfdg :_caff =20;goto _egecb ;_adab :_caff =21;goto _egecb ;_fdgde :_caff =22;goto _egecb ;_gegcf :_caff =23;goto _egecb ;_defe :_caff =24;goto _egecb ;_fbcdc :_caff =25;goto _egecb ;_afggba :_caff =26;goto _egecb ;_bdgfd :_caff =27;goto _egecb ;_ceegg :_caff =28;goto _egecb ;_ddce :_caff =29;goto _egecb ;_ggdd :_caff =30;goto _fcbf ;_dcfb :_caff =30;goto _dgcad ;_acagg :_caff =30;goto _cggaa ;_eadeg :_caff =30;goto _dbcdf ;_abbea :_caff =30;goto _dfgcc ;_efega :_caff =30;goto _gcbba ;_fbgg :_caff =30;goto _eebcg ;_gcaf :_caff =30;goto _ebbeg ;_fdbag :_caff =30;goto _bdac ;_cgbb :_caff =30;goto _ccfa ;_fdbaf :_caff =30;goto _cfcgg ;_egfd :_caff =30;goto _dbde ;_agae :_caff =30;goto _ebeg ;_dgdcd :_caff =30;goto _fcag ;_cadg :_caff =30;goto _gbfgd ;_effce :_caff =30;goto _cbgec ;_dgee :_caff =30;goto _abbeb ;_afab :_caff =30;goto _gcccc ;_ebde :_caff =30;goto _gcfgg ;_cdegg :_caff =30;goto _debfg ;_gdaadg :_caff =30;goto _cefaeg ;_gdag :_caff =30;goto _dade ;_acaab :_caff =30;goto _gcfae ;_cgce :_caff =30;goto _aagbf ;_aged :_caff =30;goto _efdc ;_cdabg :_caff =30;goto _gcfc ;_gccc :_caff =30;goto _bedd ;_bccbb :_caff =30;goto _acbgg ;_geffe :_caff =30;goto _egefb ;_ggcd :_caff =30;goto _fgec ;_fbgb :_caff =30;goto _cffdg ;_bgdc :_caff =30;goto _dce ;_gdgec :_caff =30;goto _eacc ;_cebd :_caff =31;goto _caafd ;_cdcea :_caff =32;goto _egecb ;_ggad :_caff =33;goto _gacee ;_dgfee :_caff =34;goto _egecb ;_cbegg :_caff =35;goto _cdcc ;_ggaec :_caff =36;goto _cdcc ;_eggaf :_caff =37;goto _cdcc ;_gcaa :_caff =38;goto _egecb ;_fdefe :_caff =39;goto _egecb ;_aecag :_caff =40;goto _fgab ;_cfbaa :_caff =41;goto _egecb ;_gbgdg :_caff =42;goto _fgab ;_eabe :_caff =43;goto _gacee ;_dged :_caff =44;goto _fgab ;_ddae :_caff =44;goto _afadf ;_baga :_caff =44;goto _aebcb ;_gdgfe :_caff =45;goto _fgab ;_adaa :_caff =46;goto _fgab ;_gffcd :_caff =47;goto _fgab ;_bgddf :_caff =48;goto _fgab ;_egfeb :_caff =49;goto _fgab ;_agcd :_caff =50;goto _fgab ;_gedf :_caff =51;goto _fgab ;_gcdeb :_caff =52;goto _fgab ;_cefde :_caff =53;goto _fgab ;_ecbdbb :_caff =54;goto _fgab ;_bgaa :_caff =55;goto _fgab ;_dbdd :_caff =56;goto _fgab ;_fbcbe :_caff =57;goto _fgab ;_bafe :_caff =58;goto _fgab ;_gecb :_caff =59;goto _fgab ;_efdg :_caff =59;goto _fccb ;_cggaa :_gbegf =3;goto _dace ;_dbcdf :_gbegf =5;goto _dace ;_dgcad :_gbegf =7;goto _dace ;_dfgcc :_gbegf =9;goto _dace ;_eebcg :_gbegf =11;goto _dace ;_eacc :_gbegf =13;goto _dace ;_ebbeg :_gbegf =15;goto _dace ;_debfg :_gbegf =17;goto _dace ;_cefaeg :_gbegf =19;goto _dace ;_bdac :_gbegf =21;goto _dace ;_ccfa :_gbegf =23;goto _dace ;_dbde :_gbegf =25;goto _dace ;_fcag :_gbegf =27;goto _dace ;_cfcgg :_gbegf =29;goto _dace ;_gbfgd :_gbegf =31;goto _dace ;_gcfgg :_gbegf =33;goto _dace ;_gcccc :_gbegf =35;goto _dace ;_acbgg :_gbegf =37;goto _dace ;_cffdg :_gbegf =39;goto _dace ;_egefb :_gbegf =41;goto _dace ;_cbgec :_gbegf =43;goto _dace ;_abbeb :_gbegf =45;goto _dace ;_ebeg :_gbegf =47;goto _dace ;_gcfc :_gbegf =49;goto _dace ;_aagbf :_gbegf =51;goto _dace ;_gcfae :_gbegf =53;goto _dace ;_efdc :_gbegf =55;goto _dace ;_dce :_gbegf =57;goto _dace ;_dade :_gbegf =59;goto _dace ;_bedd :_gbegf =61;goto _dace ;_fgec :_gbegf =63;goto _dace ;_gcbba :_gbegf =65;goto _dace ;_fcbf :_gbegf =67;goto _dace ;_afadf :_gbegf =72;goto _dace ;_cdcc :_gbegf =75;goto _dace ;_gacee :_gbegf =78;goto _dace ;_aebcb :_gbegf =81;goto _dace ;_fccb :_gbegf =84;goto _dace ;_fgab :_gbegf =87;goto _dace ;_caafd :_gbegf =90;goto _dace ;_dace :_cfcef =uint (_ggae [_gbegf ]);_gbegf ++;for ;_cfcef > 0;_cfcef --{_gbegf ++;switch _ggae [_gbegf -1]{case 3:_adda =_acbg +1;case 4:_abbc =1;case 5:_abbc =2;case 6:_abbc =3;case 7:_abbc =4;case 8:_abbc =11;case 9:_abbc =14;case 10:_abbc =15;case 11:_adda =_acbg +1;{_fcefd .emit (_eecea ,_adef [_aabeg :_adda ]);};case 12:_adda =_acbg +1;{_fcefd .emit (_fdgc ,_adef [_aabeg :_adda ]);};case 13:_adda =_acbg +1;{_fcefd .emit (_debg ,_adef [_aabeg :_adda -1]);};case 14:_adda =_acbg +1;{_fcefd .emit (_debg ,_adef [_aabeg +1:_adda -2]);};case 15:_adda =_acbg +1;{_fcefd .emit (_ecbb ,_adef [_aabeg :_adda -1]);};case 16:_adda =_acbg +1;{_fcefd .emit (_ecbb ,_adef [_aabeg :_adda -1]);};case 17:_adda =_acbg +1;{_fcefd .emit (_fbcfb ,_adef [_aabeg :_adda ]);};case 18:_adda =_acbg +1;{_fcefd .emit (_gddd ,_adef [_aabeg :_adda ]);};case 19:_adda =_acbg +1;{_fcefd .emit (_fgeg ,_adef [_aabeg :_adda ]);};case 20:_adda =_acbg +1;{_fcefd .emit (_badd ,_adef [_aabeg :_adda ]);};case 21:_adda =_acbg +1;{_fcefd .emit (_ecbfa ,_adef [_aabeg :_adda ]);};case 22:_adda =_acbg +1;{_fcefd .emit (_egada ,_adef [_aabeg :_adda ]);};case 23:_adda =_acbg +1;{_fcefd .emit (_bdgfb ,_adef [_aabeg :_adda ]);};case 24:_adda =_acbg +1;{_fcefd .emit (_begf ,_adef [_aabeg :_adda ]);};case 25:_adda =_acbg +1;{_fcefd .emit (_abgc ,_adef [_aabeg :_adda ]);};case 26:_adda =_acbg +1;{_fcefd .emit (_geea ,_adef [_aabeg :_adda ]);};case 27:_adda =_acbg +1;{_fcefd .emit (_eceg ,_adef [_aabeg :_adda ]);};case 28:_adda =_acbg +1;{_fcefd .emit (_dcgb ,_adef [_aabeg :_adda ]);};case 29:_adda =_acbg +1;{_fcefd .emit (_dgeg ,_adef [_aabeg :_adda ]);};case 30:_adda =_acbg +1;{_fcefd .emit (_edbf ,_adef [_aabeg :_adda ]);};case 31:_adda =_acbg +1;{_fcefd .emit (_eggaa ,_adef [_aabeg :_adda ]);};case 32:_adda =_acbg +1;{_fcefd .emit (_cbfef ,_adef [_aabeg :_adda ]);};case 33:_adda =_acbg +1;{_fcefd .emit (_cbdf ,_adef [_aabeg :_adda ]);};case 34:_adda =_acbg ;_acbg --;{_fcefd .emit (_cdggf ,_adef [_aabeg :_adda ]);};case 35:_adda =_acbg ;_acbg --;{_fcefd .emit (_faacc ,_adef [_aabeg :_adda ]);};case 36:_adda =_acbg ;_acbg --;{_fcefd .emit (_ecaa ,_adef [_aabeg :_adda ]);};case 37:_adda =_acbg ;_acbg --;{_fcefd .emit (_deaf ,_adef [_aabeg :_adda ]);};case 38:_adda =_acbg ;_acbg --;{_fcefd .emit (_eagf ,_adef [_aabeg :_adda ]);};case 39:_adda =_acbg ;_acbg --;{_fcefd .emit (_cbgbb ,_adef [_aabeg +1:_adda -1]);};case 40:_adda =_acbg ;_acbg --;{_fcefd .emit (_ggegg ,_adef [_aabeg :_adda ]);};case 41:_adda =_acbg ;_acbg --;{_fcefd .emit (_dddca ,_adef [_aabeg :_adda ]);};case 42:_acbg =(_adda )-1;{_fcefd .emit (_cdggf ,_adef [_aabeg :_adda ]);};case 43:switch _abbc {case 0:{_caff =0;goto _egecb ;};case 1:{_acbg =(_adda )-1;_fcefd .emit (_ccdba ,_adef [_aabeg :_adda ]);};case 2:{_acbg =(_adda )-1;_fcefd .emit (_cdggf ,_adef [_aabeg :_adda ]);};case 3:{_acbg =(_adda )-1;_fcefd .emit (_faacc ,_adef [_aabeg :_adda ]);};case 4:{_acbg =(_adda )-1;_fcefd .emit (_cbfdd ,_adef [_aabeg :_adda ]);};case 11:{_acbg =(_adda )-1;_fcefd .emit (_ccgbd ,_adef [_aabeg :_adda ]);};case 14:{_acbg =(_adda )-1;_fcefd .emit (_eagf ,_adef [_aabeg :_adda ]);};case 15:{_acbg =(_adda )-1;_fcefd .emit (_cbgbb ,_adef [_aabeg +1:_adda -1]);};};};};goto _egecb ;_egecb :_gbegf =int (_ffacfe [_caff ]);_cfcef =uint (_ggae [_gbegf ]);_gbegf ++;for ;_cfcef > 0;_cfcef --{_gbegf ++;switch _ggae [_gbegf -1]{case 0:_aabeg =0;case 1:_abbc =0;};};if _caff ==0{goto _gged ;};if _acbg ++;_acbg !=_cfda {goto _fbgcb ;};_dcbed :{};if _acbg ==_eeac {switch _caff {case 1:goto _ggdd ;case 2:goto _ggdd ;case 31:goto _gdag ;case 14:goto _ggdd ;case 15:goto _ggdd ;case 32:goto _acaab ;case 17:goto _ggdd ;case 33:goto _cgce ;case 18:goto _ggdd ;case 19:goto _ggdd ;case 34:goto _aged ;case 35:goto _cdabg ;case 36:goto _cdabg ;case 23:goto _efega ;case 37:goto _cdabg ;case 38:goto _gccc ;case 39:goto _ggcd ;case 40:goto _bgdc ;case 41:goto _bgdc ;case 42:goto _bgdc ;case 43:goto _cgce ;case 44:goto _ggdd ;case 45:goto _bgdc ;case 46:goto _bgdc ;case 47:goto _bgdc ;case 48:goto _bgdc ;case 49:goto _bgdc ;case 50:goto _bgdc ;case 51:goto _bgdc ;case 52:goto _bgdc ;case 53:goto _bgdc ;case 54:goto _bgdc ;case 55:goto _bgdc ;case 56:goto _bgdc ;case 57:goto _bgdc ;case 58:goto _bgdc ;case 59:goto _ggdd ;};};_gged :{};};if _aabeg > 0{copy (_adef [0:],_adef [_aabeg :]);};};_ =_eeac ;if _caff ==_dgcfg {_fcefd .emit (_fbebc ,nil );};};
 
 // Eval evaluates and returns a number.
 func (_aaga Number )Eval (ctx Context ,ev Evaluator )Result {return MakeNumberResult (_aaga ._fcfe )};
@@ -129,7 +129,7 @@ type Bool struct{_cf bool };const (_ byte =iota ;_fafdf ;_dgbg ;_debfe ;_acaaa ;
 func NewHorizontalRange (v string )Expression {_bdgc :=_ea .Split (v ,"\u003a");if len (_bdgc )!=2{return nil ;};_ddec ,_ :=_dd .Atoi (_bdgc [0]);_ebcaf ,_ :=_dd .Atoi (_bdgc [1]);if _ddec > _ebcaf {_ddec ,_ebcaf =_ebcaf ,_ddec ;};return HorizontalRange {_cbgge :_ddec ,_faff :_ebcaf };};const _bdgfb =57365;var _cacg int64 =_def (1900,_ee .January ,1);func _agde (_caee ,_ebaa _ee .Time )bool {_fagg :=_caee .Unix ();_cab :=_ebaa .Unix ();_dee :=_caee .Year ();_dbee :=_def (_dee ,_ee .March ,1);if _eabc (_dee )&&_fagg < _dbee &&_cab >=_dbee {return true ;};var _bdbgc =_ebaa .Year ();var _gebd =_def (_bdbgc ,_ee .March ,1);return (_eabc (_bdbgc )&&_cab >=_gebd &&_fagg < _gebd );};func _afaf (_febe []Result )Result {_bcfe :=_febe [0].ValueList ;_cgeg :=len (_bcfe );switch len (_febe ){case 1:_fbbcd :=[]Result {};for _ ,_ggbe :=range _bcfe {_fbbcd =append (_fbbcd ,MakeBoolResult (_ggbe .ValueNumber !=0));};return MakeListResult (_fbbcd );case 2:_cedb :=_febe [1];switch _cedb .Type {case ResultTypeNumber ,ResultTypeString ,ResultTypeEmpty :_bddd :=[]Result {};for _ ,_dbga :=range _bcfe {var _fbfcf Result ;if _dbga .ValueNumber ==0{_fbfcf =MakeBoolResult (false );}else {_fbfcf =_cedb ;};_bddd =append (_bddd ,_fbfcf );};return MakeListResult (_bddd );case ResultTypeList :_affd :=_cdbdb (_cedb ,_cgeg );_eebb :=[]Result {};for _bcgc ,_ecac :=range _bcfe {var _bfbd Result ;if _ecac .ValueNumber ==0{_bfbd =MakeBoolResult (false );}else {_bfbd =_affd [_bcgc ];};_eebb =append (_eebb ,_bfbd );};return MakeListResult (_eebb );case ResultTypeArray :_ddgee :=_ceeeb (_cedb ,len (_cedb .ValueArray ),_cgeg );_cfbf :=[][]Result {};for _ ,_cggf :=range _ddgee {_cbgbf :=[]Result {};for _afdbb ,_debfb :=range _bcfe {var _cacad Result ;if _debfb .ValueNumber ==0{_cacad =MakeBoolResult (false );}else {_cacad =_cggf [_afdbb ];};_cbgbf =append (_cbgbf ,_cacad );};_cfbf =append (_cfbf ,_cbgbf );};return MakeArrayResult (_cfbf );};case 3:_fdaaa :=_febe [1];_fbaf :=_febe [2];_dedeg :=_fdfd (_fdaaa );_cdeeg :=_fdfd (_fbaf );if _dedeg &&_cdeeg {_cbec :=[]Result {};for _ ,_geff :=range _bcfe {var _cdga Result ;if _geff .ValueNumber ==0{_cdga =_fbaf ;}else {_cdga =_fdaaa ;};_cbec =append (_cbec ,_cdga );};return MakeListResult (_cbec );};if _fdaaa .Type !=ResultTypeArray &&_fbaf .Type !=ResultTypeArray {_gbed :=_cdbdb (_fdaaa ,_cgeg );_ddgdbc :=_cdbdb (_fbaf ,_cgeg );_fdbe :=[]Result {};for _cfbe ,_dcag :=range _bcfe {var _dfgbf Result ;if _dcag .ValueNumber ==0{_dfgbf =_ddgdbc [_cfbe ];}else {_dfgbf =_gbed [_cfbe ];};_fdbe =append (_fdbe ,_dfgbf );};return MakeListResult (_fdbe );};_cabdb ,_bfca :=len (_fdaaa .ValueArray ),len (_fbaf .ValueArray );_dbgbf ,_abcg :=_cabdb ,_bfca ;if _bfca > _dbgbf {_dbgbf ,_abcg =_abcg ,_dbgbf ;};_ffde :=_ceeeb (_fdaaa ,_dbgbf ,_cgeg );_gabc :=_ceeeb (_fbaf ,_dbgbf ,_cgeg );_fcfg :=[][]Result {};for _bdcab :=0;_bdcab < _dbgbf ;_bdcab ++{_ecddb :=[]Result {};for _agff ,_eced :=range _bcfe {var _cacd Result ;if _eced .ValueNumber ==0{if _bdcab < _bfca {_cacd =_gabc [_bdcab ][_agff ];}else {_cacd =MakeErrorResultType (ErrorTypeNA ,"");};}else {if _bdcab < _cabdb {_cacd =_ffde [_bdcab ][_agff ];}else {_cacd =MakeErrorResultType (ErrorTypeNA ,"");};};_ecddb =append (_ecddb ,_cacd );};_fcfg =append (_fcfg ,_ecddb );};return MakeArrayResult (_fcfg );};return MakeErrorResult ("");};func _adebg (_cdac yyLexer )int {return _aege ().Parse (_cdac )};
 
 // Min is an implementation of the Excel MIN() function.
-func Min (args []Result )Result {return _edeb (args ,false )};const _eceg =57369;func init (){_abd ();RegisterFunction ("\u0044\u0041\u0054\u0045",Date );RegisterFunction ("\u0044A\u0054\u0045\u0044\u0049\u0046",DateDif );RegisterFunction ("\u0044A\u0054\u0045\u0056\u0041\u004c\u0055E",DateValue );RegisterFunction ("\u0044\u0041\u0059",Day );RegisterFunction ("\u0044\u0041\u0059\u0053",Days );RegisterFunction ("\u005f\u0078\u006c\u0066\u006e\u002e\u0044\u0041\u0059\u0053",Days );RegisterFunction ("\u0045\u0044\u0041T\u0045",Edate );RegisterFunction ("\u0045O\u004d\u004f\u004e\u0054\u0048",Eomonth );RegisterFunction ("\u004d\u0049\u004e\u0055\u0054\u0045",Minute );RegisterFunction ("\u004d\u004f\u004eT\u0048",Month );RegisterFunction ("\u004e\u004f\u0057",Now );RegisterFunction ("\u0054\u0049\u004d\u0045",Time );RegisterFunction ("\u0054I\u004d\u0045\u0056\u0041\u004c\u0055E",TimeValue );RegisterFunction ("\u0054\u004f\u0044A\u0059",Today );RegisterFunctionComplex ("\u0059\u0045\u0041\u0052",Year );RegisterFunction ("\u0059\u0045\u0041\u0052\u0046\u0052\u0041\u0043",YearFrac );};var _dgdfa =[...]int {0,-2,1,2,0,0,0,0,11,12,13,14,0,16,5,6,7,8,22,0,24,46,0,26,25,29,30,31,0,0,0,0,0,0,0,0,0,0,0,0,3,0,0,0,18,20,9,10,0,0,23,32,33,47,0,49,51,34,35,36,37,38,39,40,41,42,43,44,45,0,17,0,0,15,27,0,48,53,4,19,21,28,50,52};type Expression interface{Eval (_eae Context ,_bfgd Evaluator )Result ;Reference (_dagd Context ,_ffdg Evaluator )Reference ;String ()string ;Update (_bdb *_ef .UpdateQuery )Expression ;};
+func Min (args []Result )Result {return _edeb (args ,false )};const _eceg =57369;func init (){_abd ();RegisterFunction ("\u0044\u0041\u0054\u0045",Date );RegisterFunction ("\u0044A\u0054\u0045\u0044\u0049\u0046",DateDif );RegisterFunction ("\u0044A\u0054\u0045\u0056\u0041\u004c\u0055E",DateValue );RegisterFunction ("\u0044\u0041\u0059",Day );RegisterFunction ("\u0044\u0041\u0059\u0053",Days );RegisterFunction ("\u005f\u0078\u006c\u0066\u006e\u002e\u0044\u0041\u0059\u0053",Days );RegisterFunction ("\u0045\u0044\u0041T\u0045",Edate );RegisterFunction ("\u0045O\u004d\u004f\u004e\u0054\u0048",Eomonth );RegisterFunction ("\u004d\u0049\u004e\u0055\u0054\u0045",Minute );RegisterFunction ("\u004d\u004f\u004eT\u0048",Month );RegisterFunction ("\u004e\u004f\u0057",Now );RegisterFunction ("\u0054\u0049\u004d\u0045",Time );RegisterFunction ("\u0054I\u004d\u0045\u0056\u0041\u004c\u0055E",TimeValue );RegisterFunction ("\u0054\u004f\u0044A\u0059",Today );RegisterFunctionComplex ("\u0059\u0045\u0041\u0052",Year );RegisterFunction ("\u0059\u0045\u0041\u0052\u0046\u0052\u0041\u0043",YearFrac );};var _dgdfa =[...]int {0,-2,1,2,0,0,0,0,11,12,13,14,0,16,5,6,7,8,22,0,24,46,0,26,25,29,30,31,54,0,0,0,0,0,0,0,0,0,0,0,0,3,0,0,0,18,20,9,10,0,0,23,32,33,47,0,49,51,34,35,36,37,38,39,40,41,42,43,44,45,0,17,0,0,15,27,0,48,53,4,19,21,28,50,52,};type Expression interface{Eval (_eae Context ,_bfgd Evaluator )Result ;Reference (_dagd Context ,_ffdg Evaluator )Reference ;String ()string ;Update (_bdb *_ef .UpdateQuery )Expression ;};
 
 // And is an implementation of the Excel AND() function.
 func And (args []Result )Result {if len (args )==0{return MakeErrorResult ("\u0041\u004e\u0044 r\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0061t\u0020l\u0065a\u0073t\u0020\u006f\u006e\u0065\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_bgbf :=true ;for _ ,_ggeabb :=range args {_ggeabb =_ggeabb .AsNumber ();switch _ggeabb .Type {case ResultTypeList ,ResultTypeArray :_dacg :=And (_ggeabb .ListValues ());if _dacg .Type ==ResultTypeError {return _dacg ;};if _dacg .ValueNumber ==0{_bgbf =false ;};case ResultTypeNumber :if _ggeabb .ValueNumber ==0{_bgbf =false ;};case ResultTypeString :return MakeErrorResult ("\u0041\u004e\u0044\u0020\u0064\u006f\u0065\u0073\u006e\u0027t\u0020\u006f\u0070\u0065\u0072\u0061\u0074e\u0020\u006f\u006e\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0073");case ResultTypeError :return _ggeabb ;default:return MakeErrorResult ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u0061\u0072\u0067u\u006de\u006e\u0074\u0020\u0074\u0079\u0070\u0065\u0020\u0069\u006e\u0020\u0041\u004e\u0044");};};return MakeBoolResult (_bgbf );};
@@ -141,7 +141,7 @@ func Tbillprice (args []Result )Result {if len (args )!=3{return MakeErrorResult
 func Rri (args []Result )Result {if len (args )!=3{return MakeErrorResult ("\u0052\u0052\u0049\u0020r\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0074\u0068r\u0065e\u0020\u0061\u0072\u0067\u0075\u006d\u0065n\u0074\u0073");};if args [0].Type !=ResultTypeNumber {return MakeErrorResult ("\u0052\u0052I\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073\u0020\u006eu\u006d\u0062\u0065\u0072\u0020\u006f\u0066\u0020\u0070\u0065\u0072\u0069\u006f\u0064\u0073\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_edfc :=args [0].ValueNumber ;if _edfc <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0052R\u0049\u0020r\u0065\u0071\u0075i\u0072\u0065\u0073\u0020\u006e\u0075\u006db\u0065\u0072\u0020\u006f\u0066\u0020p\u0065\u0072\u0069\u006f\u0064\u0073\u0020\u0074\u006f\u0020\u0062e\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065");};if args [1].Type !=ResultTypeNumber {return MakeErrorResult ("\u0052\u0052\u0049\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073 p\u0072\u0065\u0073\u0065\u006e\u0074 \u0076\u0061\u006c\u0075\u0065\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006db\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006de\u006e\u0074");};_effee :=args [1].ValueNumber ;if _effee <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0052\u0052\u0049\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0070\u0072\u0065\u0073\u0065\u006et\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065");};if args [2].Type !=ResultTypeNumber {return MakeErrorResult ("R\u0052\u0049\u0020\u0072\u0065\u0071\u0075\u0069\u0072e\u0073\u0020\u0066\u0075\u0074\u0075\u0072e \u0076\u0061\u006c\u0075e\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075mb\u0065\u0072 \u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_bgba :=args [2].ValueNumber ;if _bgba < 0{return MakeErrorResultType (ErrorTypeNum ,"\u0052R\u0049\u0020r\u0065\u0071\u0075\u0069r\u0065\u0073\u0020f\u0075\u0074\u0075\u0072\u0065\u0020\u0076\u0061\u006cue\u0020\u0074\u006f \u0062\u0065 \u006e\u006f\u006e\u0020\u006e\u0065g\u0061\u0074i\u0076\u0065");};return MakeNumberResult (_cd .Pow (_bgba /_effee ,1/_edfc )-1);};
 
 // Irr implements the Excel IRR function.
-func Irr (args []Result )Result {_egad :=len (args );if _egad ==0||_egad > 2{return MakeErrorResult ("\u0049\u0052\u0052\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073\u0020\u006f\u006e\u0065\u0020\u006f\u0072\u0020t\u0077\u006f\u0020\u0061\u0072\u0067\u0075m\u0065\u006e\u0074\u0073");};if args [0].Type !=ResultTypeList &&args [0].Type !=ResultTypeArray {return MakeErrorResult ("\u0049\u0052\u0052\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020v\u0061\u006c\u0075\u0065\u0073\u0020t\u006f\u0020\u0062\u0065\u0020\u006f\u0066\u0020\u0061\u0072\u0072\u0061\u0079 \u0074\u0079\u0070\u0065");};_aaa :=_dacf (args [0]);_dddc :=[]float64 {};for _ ,_bdbf :=range _aaa {for _ ,_fbgcf :=range _bdbf {if _fbgcf .Type ==ResultTypeNumber &&!_fbgcf .IsBoolean {_dddc =append (_dddc ,_fbgcf .ValueNumber );};};};_ceeee :=len (_dddc );if len (_dddc )< 2{return MakeErrorResultType (ErrorTypeNum ,"");};_gbbc :=0.1;if _egad ==2&&args [1].Type !=ResultTypeEmpty {if args [1].Type !=ResultTypeNumber {return MakeErrorResult ("I\u0052\u0052\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0067\u0075\u0065\u0073\u0073\u0020t\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065r \u0061\u0072\u0067u\u006de\u006e\u0074");};_gbbc =args [1].ValueNumber ;if _gbbc <=-1{return MakeErrorResult ("\u0049\u0052R\u0020\u0072\u0065\u0071u\u0069\u0072e\u0073\u0020\u0067\u0075\u0065\u0073\u0073\u0020t\u006f\u0020\u0062\u0065\u0020\u006d\u006f\u0072\u0065\u0020\u0074\u0068a\u006e\u0020\u002d\u0031");};};_bfgg :=[]float64 {};for _edcf :=0;_edcf < _ceeee ;_edcf ++{if _edcf ==0{_bfgg =append (_bfgg ,0);}else {_bfgg =append (_bfgg ,_bfgg [_edcf -1]+365);};};return _fcec (_dddc ,_bfgg ,_gbbc );};func (_ffgeb *yyParserImpl )Parse (yylex yyLexer )int {_dcfa :=_ee .Now ();var _afgcaf int ;var _abfb yySymType ;var _gcgd []yySymType ;_ =_gcgd ;_acgac :=_ffgeb ._agaf [:];Nerrs :=0;Errflag :=0;_fdfg :=0;_ffgeb ._acegd =-1;_bacgg :=-1;defer func (){_fdfg =-1;_ffgeb ._acegd =-1;_bacgg =-1}();_dccf :=-1;goto _agccg ;_ffec :return 0;_eagff :return 1;_agccg :if _afaeg (_dcfa ){_db .Log .Error ("\u0050\u0061\u0072\u0073\u0065\u0020\u0074\u0069\u006d\u0065\u006f\u0075\u0074");goto _eagff ;};if _dbged >=4{_cb .Printf ("\u0063\u0068\u0061\u0072\u0020\u0025\u0076\u0020\u0069n\u0020\u0025\u0076\u000a",_gecg (_bacgg ),_bbff (_fdfg ));};_dccf ++;if _dccf >=len (_acgac ){_gdfd :=make ([]yySymType ,len (_acgac )*2);copy (_gdfd ,_acgac );_acgac =_gdfd ;};_acgac [_dccf ]=_abfb ;_acgac [_dccf ]._ggbb =_fdfg ;_bagca :if _afaeg (_dcfa ){_db .Log .Error ("\u0050\u0061\u0072\u0073\u0065\u0020\u0074\u0069\u006d\u0065\u006f\u0075\u0074");goto _eagff ;};_afgcaf =_bfcgd [_fdfg ];if _afgcaf <=_ddfde {goto _efegb ;};if _ffgeb ._acegd < 0{_ffgeb ._acegd ,_bacgg =_ceba (yylex ,&_ffgeb ._dbffd );};_afgcaf +=_bacgg ;if _afgcaf < 0||_afgcaf >=_bdbb {goto _efegb ;};_afgcaf =_ebbgg [_afgcaf ];if _fagea [_afgcaf ]==_bacgg {_ffgeb ._acegd =-1;_bacgg =-1;_abfb =_ffgeb ._dbffd ;_fdfg =_afgcaf ;if Errflag > 0{Errflag --;};goto _agccg ;};_efegb :if _afaeg (_dcfa ){_db .Log .Error ("\u0050\u0061\u0072\u0073\u0065\u0020\u0074\u0069\u006d\u0065\u006f\u0075\u0074");goto _eagff ;};_afgcaf =_dgdfa [_fdfg ];if _afgcaf ==-2{if _ffgeb ._acegd < 0{_ffgeb ._acegd ,_bacgg =_ceba (yylex ,&_ffgeb ._dbffd );};_eeded :=0;for {if _eceb [_eeded +0]==-1&&_eceb [_eeded +1]==_fdfg {break ;};_eeded +=2;};for _eeded +=2;;_eeded +=2{_afgcaf =_eceb [_eeded +0];if _afgcaf < 0||_afgcaf ==_bacgg {break ;};};_afgcaf =_eceb [_eeded +1];if _afgcaf < 0{goto _ffec ;};};if _afgcaf ==0{switch Errflag {case 0:yylex .Error (_eaafc (_fdfg ,_bacgg ));Nerrs ++;if _dbged >=1{_cb .Printf ("\u0025\u0073",_bbff (_fdfg ));_cb .Printf ("\u0020\u0073\u0061\u0077\u0020\u0025\u0073\u000a",_gecg (_bacgg ));};fallthrough;case 1,2:Errflag =3;for _dccf >=0{_afgcaf =_bfcgd [_acgac [_dccf ]._ggbb ]+_gdgc ;if _afgcaf >=0&&_afgcaf < _bdbb {_fdfg =_ebbgg [_afgcaf ];if _fagea [_fdfg ]==_gdgc {goto _agccg ;};};if _dbged >=2{_cb .Printf ("\u0065\u0072r\u006f\u0072\u0020\u0072\u0065\u0063\u006f\u0076\u0065\u0072\u0079\u0020\u0070\u006f\u0070\u0073\u0020\u0073\u0074\u0061\u0074\u0065 %\u0064\u000a",_acgac [_dccf ]._ggbb );};_dccf --;};goto _eagff ;case 3:if _dbged >=2{_cb .Printf ("e\u0072\u0072\u006f\u0072\u0020\u0072e\u0063\u006f\u0076\u0065\u0072\u0079\u0020\u0064\u0069s\u0063\u0061\u0072d\u0073 \u0025\u0073\u000a",_gecg (_bacgg ));};if _bacgg ==_eaafg {goto _eagff ;};_ffgeb ._acegd =-1;_bacgg =-1;goto _bagca ;};};if _dbged >=2{_cb .Printf ("\u0072e\u0064u\u0063\u0065\u0020\u0025\u0076 \u0069\u006e:\u000a\u0009\u0025\u0076\u000a",_afgcaf ,_bbff (_fdfg ));};_affb :=_afgcaf ;_fgdc :=_dccf ;_ =_fgdc ;_dccf -=_ddfe [_afgcaf ];if _dccf +1>=len (_acgac ){_fgff :=make ([]yySymType ,len (_acgac )*2);copy (_fgff ,_acgac );_acgac =_fgff ;};_abfb =_acgac [_dccf +1];_afgcaf =_dgfa [_afgcaf ];_egea :=_cdbaa [_afgcaf ];_cabce :=_egea +_acgac [_dccf ]._ggbb +1;if _cabce >=_bdbb {_fdfg =_ebbgg [_egea ];}else {_fdfg =_ebbgg [_cabce ];if _fagea [_fdfg ]!=-_afgcaf {_fdfg =_ebbgg [_egea ];};};switch _affb {case 1:_gcgd =_acgac [_fgdc -1:_fgdc +1];{yylex .(*plex )._addfd =_abfb ._fadga ;};case 3:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =_gcgd [2]._fadga ;};case 4:_gcgd =_acgac [_fgdc -4:_fgdc +1];{};case 5:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewBool (_gcgd [1]._dfee ._acfe );};case 6:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewNumber (_gcgd [1]._dfee ._acfe );};case 7:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewString (_gcgd [1]._dfee ._acfe );};case 8:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewError (_gcgd [1]._dfee ._acfe );};case 9:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =_gcgd [2]._fadga ;};case 10:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewNegate (_gcgd [2]._fadga );};case 15:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =_gcgd [2]._fadga ;};case 17:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewConstArrayExpr (_gcgd [2]._afadd );};case 18:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._afadd =append (_abfb ._afadd ,_gcgd [1]._cageb );};case 19:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._afadd =append (_gcgd [1]._afadd ,_gcgd [3]._cageb );};case 20:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._cageb =append (_abfb ._cageb ,_gcgd [1]._fadga );};case 21:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._cageb =append (_gcgd [1]._cageb ,_gcgd [3]._fadga );};case 23:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewPrefixExpr (_gcgd [1]._fadga ,_gcgd [2]._fadga );};case 25:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewSheetPrefixExpr (_gcgd [1]._dfee ._acfe );};case 26:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewCellRef (_gcgd [1]._dfee ._acfe );};case 27:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewRange (_gcgd [1]._fadga ,_gcgd [3]._fadga );};case 28:_gcgd =_acgac [_fgdc -4:_fgdc +1];{_abfb ._fadga =NewPrefixRangeExpr (_gcgd [1]._fadga ,_gcgd [2]._fadga ,_gcgd [4]._fadga );};case 29:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewNamedRangeRef (_gcgd [1]._dfee ._acfe );};case 30:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewHorizontalRange (_gcgd [1]._dfee ._acfe );};case 31:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewVerticalRange (_gcgd [1]._dfee ._acfe );};case 32:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewPrefixHorizontalRange (_gcgd [1]._fadga ,_gcgd [2]._dfee ._acfe );};case 33:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewPrefixVerticalRange (_gcgd [1]._fadga ,_gcgd [2]._dfee ._acfe );};case 34:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypePlus ,_gcgd [3]._fadga );};case 35:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeMinus ,_gcgd [3]._fadga );};case 36:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeMult ,_gcgd [3]._fadga );};case 37:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeDiv ,_gcgd [3]._fadga );};case 38:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeExp ,_gcgd [3]._fadga );};case 39:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeLT ,_gcgd [3]._fadga );};case 40:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeGT ,_gcgd [3]._fadga );};case 41:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeLEQ ,_gcgd [3]._fadga );};case 42:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeGEQ ,_gcgd [3]._fadga );};case 43:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeEQ ,_gcgd [3]._fadga );};case 44:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeNE ,_gcgd [3]._fadga );};case 45:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeConcat ,_gcgd [3]._fadga );};case 47:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewFunction (_gcgd [1]._dfee ._acfe ,nil );};case 48:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewFunction (_gcgd [1]._dfee ._acfe ,_gcgd [2]._cageb );};case 49:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._cageb =append (_abfb ._cageb ,_gcgd [1]._fadga );};case 50:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._cageb =append (_gcgd [1]._cageb ,_gcgd [3]._fadga );};case 53:_gcgd =_acgac [_fgdc -0:_fgdc +1];{_abfb ._fadga =NewEmptyExpr ();};};goto _agccg ;};
+func Irr (args []Result )Result {_egad :=len (args );if _egad ==0||_egad > 2{return MakeErrorResult ("\u0049\u0052\u0052\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073\u0020\u006f\u006e\u0065\u0020\u006f\u0072\u0020t\u0077\u006f\u0020\u0061\u0072\u0067\u0075m\u0065\u006e\u0074\u0073");};if args [0].Type !=ResultTypeList &&args [0].Type !=ResultTypeArray {return MakeErrorResult ("\u0049\u0052\u0052\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020v\u0061\u006c\u0075\u0065\u0073\u0020t\u006f\u0020\u0062\u0065\u0020\u006f\u0066\u0020\u0061\u0072\u0072\u0061\u0079 \u0074\u0079\u0070\u0065");};_aaa :=_dacf (args [0]);_dddc :=[]float64 {};for _ ,_bdbf :=range _aaa {for _ ,_fbgcf :=range _bdbf {if _fbgcf .Type ==ResultTypeNumber &&!_fbgcf .IsBoolean {_dddc =append (_dddc ,_fbgcf .ValueNumber );};};};_ceeee :=len (_dddc );if len (_dddc )< 2{return MakeErrorResultType (ErrorTypeNum ,"");};_gbbc :=0.1;if _egad ==2&&args [1].Type !=ResultTypeEmpty {if args [1].Type !=ResultTypeNumber {return MakeErrorResult ("I\u0052\u0052\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0067\u0075\u0065\u0073\u0073\u0020t\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065r \u0061\u0072\u0067u\u006de\u006e\u0074");};_gbbc =args [1].ValueNumber ;if _gbbc <=-1{return MakeErrorResult ("\u0049\u0052R\u0020\u0072\u0065\u0071u\u0069\u0072e\u0073\u0020\u0067\u0075\u0065\u0073\u0073\u0020t\u006f\u0020\u0062\u0065\u0020\u006d\u006f\u0072\u0065\u0020\u0074\u0068a\u006e\u0020\u002d\u0031");};};_bfgg :=[]float64 {};for _edcf :=0;_edcf < _ceeee ;_edcf ++{if _edcf ==0{_bfgg =append (_bfgg ,0);}else {_bfgg =append (_bfgg ,_bfgg [_edcf -1]+365);};};return _fcec (_dddc ,_bfgg ,_gbbc );};func (_ffgeb *yyParserImpl )Parse (yylex yyLexer )int {_dcfa :=_ee .Now ();var _afgcaf int ;var _abfb yySymType ;var _gcgd []yySymType ;_ =_gcgd ;_acgac :=_ffgeb ._agaf [:];Nerrs :=0;Errflag :=0;_fdfg :=0;_ffgeb ._acegd =-1;_bacgg :=-1;defer func (){_fdfg =-1;_ffgeb ._acegd =-1;_bacgg =-1}();_dccf :=-1;goto _agccg ;_ffec :return 0;_eagff :return 1;_agccg :if _afaeg (_dcfa ){_db .Log .Error ("\u0050\u0061\u0072\u0073\u0065\u0020\u0074\u0069\u006d\u0065\u006f\u0075\u0074");goto _eagff ;};if _dbged >=4{_cb .Printf ("\u0063\u0068\u0061\u0072\u0020\u0025\u0076\u0020\u0069n\u0020\u0025\u0076\u000a",_gecg (_bacgg ),_bbff (_fdfg ));};_dccf ++;if _dccf >=len (_acgac ){_gdfd :=make ([]yySymType ,len (_acgac )*2);copy (_gdfd ,_acgac );_acgac =_gdfd ;};_acgac [_dccf ]=_abfb ;_acgac [_dccf ]._ggbb =_fdfg ;_bagca :if _afaeg (_dcfa ){_db .Log .Error ("\u0050\u0061\u0072\u0073\u0065\u0020\u0074\u0069\u006d\u0065\u006f\u0075\u0074");goto _eagff ;};_afgcaf =_bfcgd [_fdfg ];if _afgcaf <=_ddfde {goto _efegb ;};if _ffgeb ._acegd < 0{_ffgeb ._acegd ,_bacgg =_ceba (yylex ,&_ffgeb ._dbffd );};_afgcaf +=_bacgg ;if _afgcaf < 0||_afgcaf >=_bdbb {goto _efegb ;};_afgcaf =_ebbgg [_afgcaf ];if _fagea [_afgcaf ]==_bacgg {_ffgeb ._acegd =-1;_bacgg =-1;_abfb =_ffgeb ._dbffd ;_fdfg =_afgcaf ;if Errflag > 0{Errflag --;};goto _agccg ;};_efegb :if _afaeg (_dcfa ){_db .Log .Error ("\u0050\u0061\u0072\u0073\u0065\u0020\u0074\u0069\u006d\u0065\u006f\u0075\u0074");goto _eagff ;};_afgcaf =_dgdfa [_fdfg ];if _afgcaf ==-2{if _ffgeb ._acegd < 0{_ffgeb ._acegd ,_bacgg =_ceba (yylex ,&_ffgeb ._dbffd );};_eeded :=0;for {if _eceb [_eeded +0]==-1&&_eceb [_eeded +1]==_fdfg {break ;};_eeded +=2;};for _eeded +=2;;_eeded +=2{_afgcaf =_eceb [_eeded +0];if _afgcaf < 0||_afgcaf ==_bacgg {break ;};};_afgcaf =_eceb [_eeded +1];if _afgcaf < 0{goto _ffec ;};};if _afgcaf ==0{switch Errflag {case 0:yylex .Error (_eaafc (_fdfg ,_bacgg ));Nerrs ++;if _dbged >=1{_cb .Printf ("\u0025\u0073",_bbff (_fdfg ));_cb .Printf ("\u0020\u0073\u0061\u0077\u0020\u0025\u0073\u000a",_gecg (_bacgg ));};fallthrough;case 1,2:Errflag =3;for _dccf >=0{_afgcaf =_bfcgd [_acgac [_dccf ]._ggbb ]+_gdgc ;if _afgcaf >=0&&_afgcaf < _bdbb {_fdfg =_ebbgg [_afgcaf ];if _fagea [_fdfg ]==_gdgc {goto _agccg ;};};if _dbged >=2{_cb .Printf ("\u0065\u0072r\u006f\u0072\u0020\u0072\u0065\u0063\u006f\u0076\u0065\u0072\u0079\u0020\u0070\u006f\u0070\u0073\u0020\u0073\u0074\u0061\u0074\u0065 %\u0064\u000a",_acgac [_dccf ]._ggbb );};_dccf --;};goto _eagff ;case 3:if _dbged >=2{_cb .Printf ("e\u0072\u0072\u006f\u0072\u0020\u0072e\u0063\u006f\u0076\u0065\u0072\u0079\u0020\u0064\u0069s\u0063\u0061\u0072d\u0073 \u0025\u0073\u000a",_gecg (_bacgg ));};if _bacgg ==_eaafg {goto _eagff ;};_ffgeb ._acegd =-1;_bacgg =-1;goto _bagca ;};};if _dbged >=2{_cb .Printf ("\u0072e\u0064u\u0063\u0065\u0020\u0025\u0076 \u0069\u006e:\u000a\u0009\u0025\u0076\u000a",_afgcaf ,_bbff (_fdfg ));};_affb :=_afgcaf ;_fgdc :=_dccf ;_ =_fgdc ;_dccf -=_ddfe [_afgcaf ];if _dccf +1>=len (_acgac ){_fgff :=make ([]yySymType ,len (_acgac )*2);copy (_fgff ,_acgac );_acgac =_fgff ;};_abfb =_acgac [_dccf +1];_afgcaf =_dgfa [_afgcaf ];_egea :=_cdbaa [_afgcaf ];_cabce :=_egea +_acgac [_dccf ]._ggbb +1;if _cabce >=_bdbb {_fdfg =_ebbgg [_egea ];}else {_fdfg =_ebbgg [_cabce ];if _fagea [_fdfg ]!=-_afgcaf {_fdfg =_ebbgg [_egea ];};};switch _affb {case 1:_gcgd =_acgac [_fgdc -1:_fgdc +1];{yylex .(*plex )._addfd =_abfb ._fadga ;};case 3:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =_gcgd [2]._fadga ;};case 4:_gcgd =_acgac [_fgdc -4:_fgdc +1];{};case 5:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewBool (_gcgd [1]._dfee ._acfe );};case 6:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewNumber (_gcgd [1]._dfee ._acfe );};case 7:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewString (_gcgd [1]._dfee ._acfe );};case 8:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewError (_gcgd [1]._dfee ._acfe );};case 9:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =_gcgd [2]._fadga ;};case 10:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewNegate (_gcgd [2]._fadga );};case 15:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =_gcgd [2]._fadga ;};case 17:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewConstArrayExpr (_gcgd [2]._afadd );};case 18:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._afadd =append (_abfb ._afadd ,_gcgd [1]._cageb );};case 19:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._afadd =append (_gcgd [1]._afadd ,_gcgd [3]._cageb );};case 20:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._cageb =append (_abfb ._cageb ,_gcgd [1]._fadga );};case 21:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._cageb =append (_gcgd [1]._cageb ,_gcgd [3]._fadga );};case 23:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewPrefixExpr (_gcgd [1]._fadga ,_gcgd [2]._fadga );};case 25:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewSheetPrefixExpr (_gcgd [1]._dfee ._acfe );};case 26:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewCellRef (_gcgd [1]._dfee ._acfe );};case 27:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewRange (_gcgd [1]._fadga ,_gcgd [3]._fadga );};case 28:_gcgd =_acgac [_fgdc -4:_fgdc +1];{_abfb ._fadga =NewPrefixRangeExpr (_gcgd [1]._fadga ,_gcgd [2]._fadga ,_gcgd [4]._fadga );};case 29:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewNamedRangeRef (_gcgd [1]._dfee ._acfe );};case 30:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewHorizontalRange (_gcgd [1]._dfee ._acfe );};case 31:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewVerticalRange (_gcgd [1]._dfee ._acfe );};case 32:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewPrefixHorizontalRange (_gcgd [1]._fadga ,_gcgd [2]._dfee ._acfe );};case 33:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewPrefixVerticalRange (_gcgd [1]._fadga ,_gcgd [2]._dfee ._acfe );};case 34:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypePlus ,_gcgd [3]._fadga );};case 35:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeMinus ,_gcgd [3]._fadga );};case 36:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeMult ,_gcgd [3]._fadga );};case 37:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeDiv ,_gcgd [3]._fadga );};case 38:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeExp ,_gcgd [3]._fadga );};case 39:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeLT ,_gcgd [3]._fadga );};case 40:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeGT ,_gcgd [3]._fadga );};case 41:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeLEQ ,_gcgd [3]._fadga );};case 42:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeGEQ ,_gcgd [3]._fadga );};case 43:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeEQ ,_gcgd [3]._fadga );};case 44:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeNE ,_gcgd [3]._fadga );};case 45:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewBinaryExpr (_gcgd [1]._fadga ,BinOpTypeConcat ,_gcgd [3]._fadga );};case 47:_gcgd =_acgac [_fgdc -2:_fgdc +1];{_abfb ._fadga =NewFunction (_gcgd [1]._dfee ._acfe ,nil );};case 48:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._fadga =NewFunction (_gcgd [1]._dfee ._acfe ,_gcgd [2]._cageb );};case 49:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._cageb =append (_abfb ._cageb ,_gcgd [1]._fadga );};case 50:_gcgd =_acgac [_fgdc -3:_fgdc +1];{_abfb ._cageb =append (_gcgd [1]._cageb ,_gcgd [3]._fadga );};case 53:_gcgd =_acgac [_fgdc -0:_fgdc +1];{_abfb ._fadga =NewEmptyExpr ();};case 54:_gcgd =_acgac [_fgdc -1:_fgdc +1];{_abfb ._fadga =NewStructuredRef (_gcgd [1]._dfee ._acfe );};};goto _agccg ;};
 
 // Concat is an implementation of the Excel CONCAT() and deprecated CONCATENATE() function.
 func Concat (args []Result )Result {_cdgd :=_ca .Buffer {};for _ ,_eeba :=range args {switch _eeba .Type {case ResultTypeString :_cdgd .WriteString (_eeba .ValueString );case ResultTypeNumber :var _ecde string ;if _eeba .IsBoolean {if _eeba .ValueNumber ==0{_ecde ="\u0046\u0041\u004cS\u0045";}else {_ecde ="\u0054\u0052\u0055\u0045";};}else {_ecde =_eeba .AsString ().ValueString ;};_cdgd .WriteString (_ecde );default:return MakeErrorResult ("\u0043\u004f\u004e\u0043\u0041T\u0028\u0029\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0061r\u0067\u0075\u006d\u0065\u006e\u0074\u0073\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0073");};};return MakeStringResult (_cdgd .String ());};
@@ -179,7 +179,7 @@ func Multinomial (args []Result )Result {if len (args )< 1{return MakeErrorResul
 func Today (args []Result )Result {if len (args )> 0{return MakeErrorResult ("\u0054\u004f\u0044A\u0059\u0020\u0064\u006fe\u0073\u006e\u0027\u0074\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_fagb :=_ee .Now ();_ ,_bad :=_fagb .Zone ();_feabd :=_ggee (_cacg ,_fagb .Unix ()+int64 (_bad ))+1;return MakeNumberResult (_feabd );};var _eb =map[string ]*_gd .Regexp {};
 
 // Substitute is an implementation of the Excel SUBSTITUTE function.
-func Substitute (args []Result )Result {_dffe :=len (args );if _dffe !=3&&_dffe !=4{return MakeErrorResult ("\u0053\u0055\u0042\u0053\u0054\u0049\u0054U\u0054\u0045\u0020r\u0065\u0071\u0075\u0069r\u0065\u0073\u0020\u0074\u0068\u0072\u0065\u0065\u0020\u006f\u0072\u0020\u0066\u006f\u0075\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_cdfdd ,_eafed :=_ccdeb (args [0],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u0074\u0065\u0078\u0074");if _eafed .Type ==ResultTypeError {return _eafed ;};_eedb ,_eafed :=_ccdeb (args [1],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u006f\u006c\u0064\u0020\u0074\u0065\u0078\u0074");if _eafed .Type ==ResultTypeError {return _eafed ;};_dbecf ,_eafed :=_ccdeb (args [2],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u006e\u0065\u0077\u0020\u0074\u0065\u0078\u0074");if _eafed .Type ==ResultTypeError {return _eafed ;};_dgagc :=0;if _dffe ==3{return MakeStringResult (_ea .Replace (_cdfdd ,_eedb ,_dbecf ,-1));}else {_bbgfe ,_ccega :=_gdgf (args [3],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u0069\u006e\u0073t\u0061\u006e\u0063\u0065\u005f\u006e\u0075\u006d");if _ccega .Type ==ResultTypeError {return _ccega ;};_dgagc =int (_bbgfe );if _dgagc < 1{return MakeErrorResult ("\u0069\u006es\u0074\u0061\u006e\u0063e\u005f\u006eu\u006d\u0020\u0073\u0068\u006f\u0075\u006c\u0064 \u0062\u0065\u0020\u006d\u006f\u0072\u0065\u0020\u0074\u0068\u0061\u006e \u007a\u0065\u0072\u006f");};_gfbcg :=_cdfdd ;_ggeg :=_dgagc ;_eefeb :=-1;_caega :=len (_eedb );_eacde :=0;for {_ggeg --;_ebabc :=_ea .Index (_gfbcg ,_eedb );if _ebabc ==-1{_eefeb =-1;break ;}else {_eefeb =_ebabc +_eacde ;if _ggeg ==0{break ;};_bffd :=_caega +_ebabc ;_eacde +=_bffd ;_gfbcg =_gfbcg [_bffd :];};};if _eefeb ==-1{return MakeStringResult (_cdfdd );}else {_cddfa :=_cdfdd [:_eefeb ];_aefcb :=_cdfdd [_eefeb +_caega :];return MakeStringResult (_cddfa +_dbecf +_aefcb );};};};var _gdbfb =[...]int {1};
+func Substitute (args []Result )Result {_dffe :=len (args );if _dffe !=3&&_dffe !=4{return MakeErrorResult ("\u0053\u0055\u0042\u0053\u0054\u0049\u0054U\u0054\u0045\u0020r\u0065\u0071\u0075\u0069r\u0065\u0073\u0020\u0074\u0068\u0072\u0065\u0065\u0020\u006f\u0072\u0020\u0066\u006f\u0075\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_cdfdd ,_eafed :=_ccdeb (args [0],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u0074\u0065\u0078\u0074");if _eafed .Type ==ResultTypeError {return _eafed ;};_eedb ,_eafed :=_ccdeb (args [1],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u006f\u006c\u0064\u0020\u0074\u0065\u0078\u0074");if _eafed .Type ==ResultTypeError {return _eafed ;};_dbecf ,_eafed :=_ccdeb (args [2],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u006e\u0065\u0077\u0020\u0074\u0065\u0078\u0074");if _eafed .Type ==ResultTypeError {return _eafed ;};_dgagc :=0;if _dffe ==3{return MakeStringResult (_ea .Replace (_cdfdd ,_eedb ,_dbecf ,-1));}else {_bbgfe ,_ccega :=_gdgf (args [3],"\u0053\u0055\u0042\u0053\u0054\u0049\u0054\u0055\u0054\u0045","\u0069\u006e\u0073t\u0061\u006e\u0063\u0065\u005f\u006e\u0075\u006d");if _ccega .Type ==ResultTypeError {return _ccega ;};_dgagc =int (_bbgfe );if _dgagc < 1{return MakeErrorResult ("\u0069\u006es\u0074\u0061\u006e\u0063e\u005f\u006eu\u006d\u0020\u0073\u0068\u006f\u0075\u006c\u0064 \u0062\u0065\u0020\u006d\u006f\u0072\u0065\u0020\u0074\u0068\u0061\u006e \u007a\u0065\u0072\u006f");};_gfbcg :=_cdfdd ;_ggeg :=_dgagc ;_eefeb :=-1;_caega :=len (_eedb );_eacde :=0;for {_ggeg --;_ebabc :=_ea .Index (_gfbcg ,_eedb );if _ebabc ==-1{_eefeb =-1;break ;}else {_eefeb =_ebabc +_eacde ;if _ggeg ==0{break ;};_bffd :=_caega +_ebabc ;_eacde +=_bffd ;_gfbcg =_gfbcg [_bffd :];};};if _eefeb ==-1{return MakeStringResult (_cdfdd );}else {_cddfa :=_cdfdd [:_eefeb ];_aefcb :=_cdfdd [_eefeb +_caega :];return MakeStringResult (_cddfa +_dbecf +_aefcb );};};};var _gdbfb =[...]int {1,};
 
 // Quotient is an implementation of the Excel QUOTIENT function that returns the
 // integer portion of division.
@@ -211,7 +211,7 @@ func (_adabf *plex )Error (s string ){_db .Log .Debug ("\u0070a\u0072s\u0065\u00
 func NewPrefixRangeExpr (pfx ,from ,to Expression )Expression {_bgea ,_eadc ,_ddgbe :=_egca (from ,to );if _ddgbe !=nil {_db .Log .Debug (_ddgbe .Error ());return NewError (_ddgbe .Error ());};return PrefixRangeExpr {_aecbe :pfx ,_acdg :_bgea ,_adeff :_eadc };};type evCache struct{_fa map[string ]Result ;_gg *_ge .Mutex ;};
 
 // Row implements the Excel ROW function.
-func Row (args []Result )Result {if len (args )< 1{return MakeErrorResult ("\u0052O\u0057\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073 \u006fn\u0065 \u0061\u0072\u0067\u0075\u006d\u0065\u006et");};_agcc :=args [0].Ref ;if _agcc .Type !=ReferenceTypeCell {return MakeErrorResult ("\u0052\u004f\u0057\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073 a\u006e\u0020\u0061\u0072\u0067\u0075m\u0065\u006e\u0074\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006f\u0066\u0020\u0074\u0079p\u0065\u0020\u0072\u0065\u0066\u0065\u0072\u0065n\u0063\u0065");};_dega ,_ebfb :=_f .ParseCellReference (_agcc .Value );if _ebfb !=nil {return MakeErrorResult ("I\u006e\u0063\u006f\u0072re\u0063t\u0020\u0072\u0065\u0066\u0065r\u0065\u006e\u0063\u0065\u003a\u0020"+_agcc .Value );};return MakeNumberResult (float64 (_dega .RowIdx ));};var _bgbcg =[...]string {"\u0024\u0065\u006e\u0064","\u0065\u0072\u0072o\u0072","\u0024\u0075\u006e\u006b","t\u006fk\u0065\u006e\u0048\u006f\u0072\u0069\u007a\u006fn\u0074\u0061\u006c\u0052an\u0067\u0065","\u0074o\u006be\u006e\u0056\u0065\u0072\u0074i\u0063\u0061l\u0052\u0061\u006e\u0067\u0065","\u0074\u006f\u006b\u0065\u006e\u0052\u0065\u0073\u0065\u0072\u0076\u0065d\u004e\u0061\u006d\u0065","\u0074\u006f\u006be\u006e\u0044\u0044\u0045\u0043\u0061\u006c\u006c","\u0074\u006f\u006b\u0065\u006e\u004c\u0065\u0078\u0045\u0072\u0072\u006f\u0072","\u0074o\u006be\u006e\u004e\u0061\u006d\u0065\u0064\u0052\u0061\u006e\u0067\u0065","\u0074o\u006b\u0065\u006e\u0042\u006f\u006fl","t\u006f\u006b\u0065\u006e\u004e\u0075\u006d\u0062\u0065\u0072","t\u006f\u006b\u0065\u006e\u0053\u0074\u0072\u0069\u006e\u0067","\u0074\u006f\u006b\u0065\u006e\u0045\u0072\u0072\u006f\u0072","\u0074\u006f\u006b\u0065\u006e\u0045\u0072\u0072\u006f\u0072\u0052\u0065\u0066","\u0074\u006f\u006b\u0065\u006e\u0053\u0068\u0065\u0065\u0074","\u0074o\u006b\u0065\u006e\u0043\u0065\u006cl","t\u006fk\u0065\u006e\u0046\u0075\u006e\u0063\u0074\u0069o\u006e\u0042\u0075\u0069lt\u0069\u006e","t\u006f\u006b\u0065\u006e\u004c\u0042\u0072\u0061\u0063\u0065","t\u006f\u006b\u0065\u006e\u0052\u0042\u0072\u0061\u0063\u0065","t\u006f\u006b\u0065\u006e\u004c\u0050\u0061\u0072\u0065\u006e","t\u006f\u006b\u0065\u006e\u0052\u0050\u0061\u0072\u0065\u006e","\u0074o\u006b\u0065\u006e\u0050\u006c\u0075s","\u0074\u006f\u006b\u0065\u006e\u004d\u0069\u006e\u0075\u0073","\u0074o\u006b\u0065\u006e\u004d\u0075\u006ct","\u0074\u006f\u006b\u0065\u006e\u0044\u0069\u0076","\u0074\u006f\u006b\u0065\u006e\u0045\u0078\u0070","\u0074o\u006b\u0065\u006e\u0045\u0051","\u0074o\u006b\u0065\u006e\u004c\u0054","\u0074o\u006b\u0065\u006e\u0047\u0054","\u0074\u006f\u006b\u0065\u006e\u004c\u0045\u0051","\u0074\u006f\u006b\u0065\u006e\u0047\u0045\u0051","\u0074o\u006b\u0065\u006e\u004e\u0045","\u0074\u006f\u006b\u0065\u006e\u0043\u006f\u006c\u006f\u006e","\u0074\u006f\u006b\u0065\u006e\u0043\u006f\u006d\u006d\u0061","\u0074\u006f\u006b\u0065\u006e\u0041\u006d\u0070\u0065r\u0073\u0061\u006e\u0064","\u0074o\u006b\u0065\u006e\u0053\u0065\u006di"};
+func Row (args []Result )Result {if len (args )< 1{return MakeErrorResult ("\u0052O\u0057\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073 \u006fn\u0065 \u0061\u0072\u0067\u0075\u006d\u0065\u006et");};_agcc :=args [0].Ref ;if _agcc .Type !=ReferenceTypeCell {return MakeErrorResult ("\u0052\u004f\u0057\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073 a\u006e\u0020\u0061\u0072\u0067\u0075m\u0065\u006e\u0074\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006f\u0066\u0020\u0074\u0079p\u0065\u0020\u0072\u0065\u0066\u0065\u0072\u0065n\u0063\u0065");};_dega ,_ebfb :=_f .ParseCellReference (_agcc .Value );if _ebfb !=nil {return MakeErrorResult ("I\u006e\u0063\u006f\u0072re\u0063t\u0020\u0072\u0065\u0066\u0065r\u0065\u006e\u0063\u0065\u003a\u0020"+_agcc .Value );};return MakeNumberResult (float64 (_dega .RowIdx ));};var _bgbcg =[...]string {"\u0024\u0065\u006e\u0064","\u0065\u0072\u0072o\u0072","\u0024\u0075\u006e\u006b","t\u006fk\u0065\u006e\u0048\u006f\u0072\u0069\u007a\u006fn\u0074\u0061\u006c\u0052an\u0067\u0065","\u0074o\u006be\u006e\u0056\u0065\u0072\u0074i\u0063\u0061l\u0052\u0061\u006e\u0067\u0065","\u0074\u006f\u006b\u0065\u006e\u0052\u0065\u0073\u0065\u0072\u0076\u0065d\u004e\u0061\u006d\u0065","\u0074\u006f\u006be\u006e\u0044\u0044\u0045\u0043\u0061\u006c\u006c","\u0074\u006f\u006b\u0065\u006e\u004c\u0065\u0078\u0045\u0072\u0072\u006f\u0072","\u0074o\u006be\u006e\u004e\u0061\u006d\u0065\u0064\u0052\u0061\u006e\u0067\u0065","\u0074o\u006b\u0065\u006e\u0042\u006f\u006fl","t\u006f\u006b\u0065\u006e\u004e\u0075\u006d\u0062\u0065\u0072","t\u006f\u006b\u0065\u006e\u0053\u0074\u0072\u0069\u006e\u0067","\u0074\u006f\u006b\u0065\u006e\u0045\u0072\u0072\u006f\u0072","\u0074\u006f\u006b\u0065\u006e\u0045\u0072\u0072\u006f\u0072\u0052\u0065\u0066","\u0074\u006f\u006b\u0065\u006e\u0053\u0068\u0065\u0065\u0074","\u0074o\u006b\u0065\u006e\u0043\u0065\u006cl","t\u006fk\u0065\u006e\u0046\u0075\u006e\u0063\u0074\u0069o\u006e\u0042\u0075\u0069lt\u0069\u006e","t\u006f\u006b\u0065\u006e\u004c\u0042\u0072\u0061\u0063\u0065","t\u006f\u006b\u0065\u006e\u0052\u0042\u0072\u0061\u0063\u0065","t\u006f\u006b\u0065\u006e\u004c\u0050\u0061\u0072\u0065\u006e","t\u006f\u006b\u0065\u006e\u0052\u0050\u0061\u0072\u0065\u006e","\u0074o\u006b\u0065\u006e\u0050\u006c\u0075s","\u0074\u006f\u006b\u0065\u006e\u004d\u0069\u006e\u0075\u0073","\u0074o\u006b\u0065\u006e\u004d\u0075\u006ct","\u0074\u006f\u006b\u0065\u006e\u0044\u0069\u0076","\u0074\u006f\u006b\u0065\u006e\u0045\u0078\u0070","\u0074o\u006b\u0065\u006e\u0045\u0051","\u0074o\u006b\u0065\u006e\u004c\u0054","\u0074o\u006b\u0065\u006e\u0047\u0054","\u0074\u006f\u006b\u0065\u006e\u004c\u0045\u0051","\u0074\u006f\u006b\u0065\u006e\u0047\u0045\u0051","\u0074o\u006b\u0065\u006e\u004e\u0045","\u0074\u006f\u006b\u0065\u006e\u0043\u006f\u006c\u006f\u006e","\u0074\u006f\u006b\u0065\u006e\u0043\u006f\u006d\u006d\u0061","\u0074\u006f\u006b\u0065\u006e\u0041\u006d\u0070\u0065r\u0073\u0061\u006e\u0064","\u0074o\u006b\u0065\u006e\u0053\u0065\u006di","\u0074\u006f\u006b\u0065\u006e\u0053\u0074\u0072\u0075\u0063\u0074\u0075\u0072\u0065\u0064\u0052\u0065\u0066"};
 
 // Oddlprice implements the Excel ODDLPRICE function.
 func Oddlprice (args []Result )Result {if len (args )!=8&&len (args )!=9{return MakeErrorResult ("\u004f\u0044\u0044L\u0050\u0052\u0049\u0043\u0045\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0065\u0069\u0067\u0068\u0074\u0020\u006f\u0072\u0020\u006e\u0069\u006e\u0065\u0020a\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_bedb ,_dbgg ,_bbfc :=_fcfd (args [0],args [1],"\u004fD\u0044\u004c\u0050\u0052\u0049\u0043E");if _bbfc .Type ==ResultTypeError {return _bbfc ;};_fgaga ,_bbfc :=_bgg (args [2],"\u0069\u0073\u0073\u0075\u0065\u0020\u0064\u0061\u0074\u0065","\u004fD\u0044\u004c\u0050\u0052\u0049\u0043E");if _bbfc .Type ==ResultTypeError {return _bbfc ;};if _fgaga >=_bedb {return MakeErrorResultType (ErrorTypeNum ,"\u004c\u0061\u0073\u0074\u0020i\u006e\u0074\u0065\u0072\u0065\u0073\u0074\u0020\u0064\u0061\u0074\u0065\u0020s\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065\u0020\u0062\u0065\u0066\u006f\u0072\u0065\u0020\u0073\u0065\u0074\u0074\u006c\u0065\u006d\u0065\u006e\u0074\u0020\u0064\u0061\u0074e");};_deca :=args [3];if _deca .Type !=ResultTypeNumber {return MakeErrorResult ("\u004f\u0044\u0044\u004c\u0050\u0052\u0049\u0043\u0045\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0072\u0061\u0074\u0065\u0020o\u0066\u0020\u0074\u0079\u0070e\u0020\u006eu\u006d\u0062\u0065\u0072");};_abgg :=_deca .ValueNumber ;if _abgg < 0{return MakeErrorResultType (ErrorTypeNum ,"R\u0061\u0074\u0065\u0020\u0073\u0068o\u0075\u006c\u0064\u0020\u0062\u0065\u0020\u006e\u006fn\u0020\u006e\u0065g\u0061t\u0069\u0076\u0065");};_aaaf :=args [4];if _aaaf .Type !=ResultTypeNumber {return MakeErrorResult ("\u004f\u0044\u0044\u004c\u0050\u0052\u0049\u0043\u0045\u0020\u0072\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0079i\u0065\u006c\u0064\u0020\u006f\u0066\u0020\u0074\u0079\u0070\u0065\u0020\u006eu\u006d\u0062\u0065\u0072");};_ffcde :=_aaaf .ValueNumber ;if _ffcde < 0{return MakeErrorResultType (ErrorTypeNum ,"\u0059\u0069\u0065\u006cd\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065 \u006eo\u006e\u0020\u006e\u0065\u0067\u0061\u0074i\u0076\u0065");};_feaac :=args [5];if _feaac .Type !=ResultTypeNumber {return MakeErrorResult ("\u004fD\u0044\u004cP\u0052\u0049\u0043\u0045 \u0072\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0072\u0065\u0064\u0065mp\u0074\u0069\u006fn\u0020\u006ff\u0020\u0074\u0079\u0070\u0065\u0020n\u0075\u006db\u0065\u0072");};_edec :=_feaac .ValueNumber ;if _edec < 0{return MakeErrorResultType (ErrorTypeNum ,"\u0059\u0069\u0065\u006cd\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065 \u006eo\u006e\u0020\u006e\u0065\u0067\u0061\u0074i\u0076\u0065");};_bgeg :=args [6];if _bgeg .Type !=ResultTypeNumber {return MakeErrorResult ("\u004f\u0044\u0044\u004c\u0050\u0052\u0049C\u0045\u0020\u0072e\u0071\u0075\u0069\u0072e\u0073\u0020\u0066\u0072\u0065\u0071\u0075\u0065\u006e\u0063\u0079\u0020\u006f\u0066\u0020\u0074\u0079\u0070\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072");};_dgdc :=float64 (int (_bgeg .ValueNumber ));if !_egcf (_dgdc ){return MakeErrorResultType (ErrorTypeNum ,"\u0049n\u0063\u006f\u0072\u0072e\u0063\u0074\u0020\u0066\u0072e\u0071u\u0065n\u0063\u0065\u0020\u0076\u0061\u006c\u0075e");};_edce :=0;if len (args )==8&&args [7].Type !=ResultTypeEmpty {_bdaa :=args [7];if _bdaa .Type !=ResultTypeNumber {return MakeErrorResult ("\u004f\u0044\u0044\u004c\u0050\u0052\u0049\u0043\u0045\u0020\u0072\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0062a\u0073\u0069\u0073\u0020\u006f\u0066\u0020\u0074\u0079\u0070\u0065\u0020\u006eu\u006d\u0062\u0065\u0072");};_edce =int (_bdaa .ValueNumber );if !_dca (_edce ){return MakeErrorResultType (ErrorTypeNum ,"I\u006e\u0063\u006f\u0072\u0072\u0065c\u0074\u0020\u0062\u0061\u0073\u0069s\u0020\u0076\u0061\u006c\u0075\u0065\u0020f\u006f\u0072\u0020\u004f\u0044\u0044\u004c\u0050\u0052\u0049C\u0045");};};_cafe ,_bbfc :=_bgae (_fgaga ,_dbgg ,_edce );if _bbfc .Type ==ResultTypeError {return _bbfc ;};_cafe *=_dgdc ;_fge ,_bbfc :=_bgae (_bedb ,_dbgg ,_edce );if _bbfc .Type ==ResultTypeError {return _bbfc ;};_fge *=_dgdc ;_defbc ,_bbfc :=_bgae (_fgaga ,_bedb ,_edce );if _bbfc .Type ==ResultTypeError {return _bbfc ;};_defbc *=_dgdc ;_cdce :=_edec +_cafe *100*_abgg /_dgdc ;_cdce /=_fge *_ffcde /_dgdc +1;_cdce -=_defbc *100*_abgg /_dgdc ;return MakeNumberResult (_cdce );};
@@ -294,7 +294,7 @@ func (_cbgbc *ivr )GetEpoch ()_ee .Time {return _ee .Time {}};func _feca (_fdecb
 func (_daf Number )String ()string {return _dd .FormatFloat (_daf ._fcfe ,'f',-1,64)};
 
 // LastColumn returns empty string for the invalid reference context.
-func (_fbdd *ivr )LastColumn (rowFrom ,rowTo int )string {return ""};func _agbgb (_addfe Result )Result {if _addfe .Type ==ResultTypeEmpty {return _addfe ;};_afgg :=_addfe .AsString ();if _afgg .Type !=ResultTypeString {return MakeErrorResult ("\u004c\u004f\u0057\u0045\u0052\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065s\u0020\u0061\u0020\u0073\u0069\u006eg\u006c\u0065\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};if _addfe .IsBoolean {if _afgg .ValueString =="\u0031"{return MakeStringResult ("\u0074\u0072\u0075\u0065");}else if _afgg .ValueString =="\u0030"{return MakeStringResult ("\u0066\u0061\u006cs\u0065");}else {return MakeErrorResult ("\u0049\u006e\u0063\u006fr\u0072\u0065\u0063\u0074\u0020\u0061\u0072\u0067\u0075\u006de\u006et\u0020\u0066\u006f\u0072\u0020\u004c\u004fW\u0045\u0052");};}else {return MakeStringResult (_ea .ToLower (_afgg .ValueString ));};};func _gfbce (_dfaaf float64 )float64 {_gfeae :=float64 (1);for _aeaa :=float64 (2);_aeaa <=_dfaaf ;_aeaa ++{_gfeae *=_aeaa ;};return _gfeae ;};const _cdcg ="\u0028\u0028\u005b0\u002d\u0039\u005d\u0029\u002b\u0029\u003a\u0028\u0028\u005b\u0030\u002d\u0039\u005d\u0029\u002b\u005c\u002e\u0028\u005b\u0030\u002d\u0039\u005d\u0029\u002b\u0029\u0028\u0020(\u0061\u006d\u007c\u0070\u006d\u0029\u0029\u003f";var _ebbgg =[...]int {45,3,44,32,18,40,72,46,47,30,31,32,39,48,28,29,30,31,32,75,39,49,32,56,50,70,23,39,76,57,58,59,60,61,62,63,64,65,66,67,68,77,71,69,54,43,13,19,21,55,82,11,78,9,74,28,29,30,31,32,37,33,34,35,36,38,1,20,39,10,2,8,0,80,79,0,0,0,83,0,81,73,28,29,30,31,32,37,33,34,35,36,38,0,0,39,28,29,30,31,32,37,33,34,35,36,38,26,27,39,51,52,25,14,15,16,17,0,24,23,22,41,23,12,0,6,7,26,27,0,42,0,25,14,15,16,17,0,24,23,22,5,0,12,0,6,7,26,27,0,4,0,25,14,15,16,17,0,24,23,22,41,0,12,53,6,7,26,27,0,0,0,25,14,15,16,17,0,24,23,22,41,0,12,0,6,7};func _dec (_acc string ,_ed *_ef .UpdateQuery )string {_cg ,_aea :=_f .ParseCellReference (_acc );if _aea !=nil {return "\u0023\u0052\u0045F\u0021";};if _ed .UpdateType ==_ef .UpdateActionRemoveColumn {_df :=_ed .ColumnIdx ;_egc :=_cg .ColumnIdx ;if _egc < _df {return _acc ;}else if _egc ==_df {return "\u0023\u0052\u0045F\u0021";}else {return _cg .Update (_ef .UpdateActionRemoveColumn ).String ();};};return _acc ;};func _afaeg (_cgbc _ee .Time )bool {return _ee .Now ().Sub (_cgbc )>=_ggdcg };func _eggad (_cfee []Result )(bool ,Result ){for _ ,_fgad :=range _cfee {if _fgad .Type ==ResultTypeError {return true ,_fgad ;};};return false ,MakeEmptyResult ();};
+func (_fbdd *ivr )LastColumn (rowFrom ,rowTo int )string {return ""};func _agbgb (_addfe Result )Result {if _addfe .Type ==ResultTypeEmpty {return _addfe ;};_afgg :=_addfe .AsString ();if _afgg .Type !=ResultTypeString {return MakeErrorResult ("\u004c\u004f\u0057\u0045\u0052\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065s\u0020\u0061\u0020\u0073\u0069\u006eg\u006c\u0065\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};if _addfe .IsBoolean {if _afgg .ValueString =="\u0031"{return MakeStringResult ("\u0074\u0072\u0075\u0065");}else if _afgg .ValueString =="\u0030"{return MakeStringResult ("\u0066\u0061\u006cs\u0065");}else {return MakeErrorResult ("\u0049\u006e\u0063\u006fr\u0072\u0065\u0063\u0074\u0020\u0061\u0072\u0067\u0075\u006de\u006et\u0020\u0066\u006f\u0072\u0020\u004c\u004fW\u0045\u0052");};}else {return MakeStringResult (_ea .ToLower (_afgg .ValueString ));};};func _gfbce (_dfaaf float64 )float64 {_gfeae :=float64 (1);for _aeaa :=float64 (2);_aeaa <=_dfaaf ;_aeaa ++{_gfeae *=_aeaa ;};return _gfeae ;};const _cdcg ="\u0028\u0028\u005b0\u002d\u0039\u005d\u0029\u002b\u0029\u003a\u0028\u0028\u005b\u0030\u002d\u0039\u005d\u0029\u002b\u005c\u002e\u0028\u005b\u0030\u002d\u0039\u005d\u0029\u002b\u0029\u0028\u0020(\u0061\u006d\u007c\u0070\u006d\u0029\u0029\u003f";var _ebbgg =[...]int {46,3,45,73,18,41,76,47,48,31,32,33,71,49,29,30,31,32,33,50,40,33,23,57,51,55,44,40,13,72,58,59,60,61,62,63,64,65,66,67,68,69,19,21,70,26,27,56,83,11,25,14,15,16,17,75,24,23,22,42,33,12,54,6,7,52,53,77,9,40,1,20,10,2,81,80,8,23,28,84,78,82,26,27,0,0,0,25,14,15,16,17,0,24,23,22,42,0,12,0,6,7,0,0,0,43,0,0,0,0,0,26,27,0,0,28,25,14,15,16,17,0,24,23,22,5,0,12,0,6,7,0,0,0,4,0,0,0,0,0,26,27,0,0,28,25,14,15,16,17,0,24,23,22,42,0,12,0,6,7,0,0,0,0,0,0,0,0,0,0,0,79,0,28,29,30,31,32,33,38,34,35,36,37,39,0,0,40,74,29,30,31,32,33,38,34,35,36,37,39,0,0,40,29,30,31,32,33,38,34,35,36,37,39,0,0,40,};func _dec (_acc string ,_ed *_ef .UpdateQuery )string {_cg ,_aea :=_f .ParseCellReference (_acc );if _aea !=nil {return "\u0023\u0052\u0045F\u0021";};if _ed .UpdateType ==_ef .UpdateActionRemoveColumn {_df :=_ed .ColumnIdx ;_egc :=_cg .ColumnIdx ;if _egc < _df {return _acc ;}else if _egc ==_df {return "\u0023\u0052\u0045F\u0021";}else {return _cg .Update (_ef .UpdateActionRemoveColumn ).String ();};};return _acc ;};func _afaeg (_cgbc _ee .Time )bool {return _ee .Now ().Sub (_cgbc )>=_ggdcg };func _eggad (_cfee []Result )(bool ,Result ){for _ ,_fgad :=range _cfee {if _fgad .Type ==ResultTypeError {return true ,_fgad ;};};return false ,MakeEmptyResult ();};
 
 // Eval evaluates and returns the result of a function call.
 func (_aacg FunctionCall )Eval (ctx Context ,ev Evaluator )Result {_ffggb :=LookupFunction (_aacg ._aebg );if _ffggb !=nil {_fbab :=make ([]Result ,len (_aacg ._ebeeae ));for _fgfb ,_abbe :=range _aacg ._ebeeae {_fbab [_fgfb ]=_abbe .Eval (ctx ,ev );_fbab [_fgfb ].Ref =_abbe .Reference (ctx ,ev );};if _ ,_bgggf :=_aceed [_aacg ._aebg ];!_bgggf {if _geabe ,_eabb :=_eggad (_fbab );_geabe {return _eabb ;};};return _ffggb (_fbab );};_fceed :=LookupFunctionComplex (_aacg ._aebg );if _fceed !=nil {_bfeb :=make ([]Result ,len (_aacg ._ebeeae ));for _cfaa ,_edcgb :=range _aacg ._ebeeae {_bfeb [_cfaa ]=_edcgb .Eval (ctx ,ev );_bfeb [_cfaa ].Ref =_edcgb .Reference (ctx ,ev );};if _ ,_ccdb :=_aceed [_aacg ._aebg ];!_ccdb {if _ffeaa ,_bccg :=_eggad (_bfeb );_ffeaa {return _bccg ;};};return _fceed (ctx ,ev ,_bfeb );};return MakeErrorResult ("\u0075\u006e\u006b\u006e\u006f\u0077\u006e\u0020\u0066\u0075\u006e\u0063t\u0069\u006f\u006e\u0020"+_aacg ._aebg );};
@@ -370,7 +370,7 @@ SetOffset (_deb ,_fda uint32 );};
 func NewEvaluator ()Evaluator {_bcf :=&defEval {};_bcf .evCache =_dfg ();return _bcf };
 
 // Syd implements the Excel SYD function.
-func Syd (args []Result )Result {if len (args )!=4{return MakeErrorResult ("S\u0059\u0044\u0020\u0072\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0066\u006f\u0075\u0072 \u0061\u0072\u0067u\u006de\u006e\u0074\u0073");};if args [0].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020c\u006f\u0073\u0074\u0020\u0074\u006f \u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_abac :=args [0].ValueNumber ;if args [1].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044 \u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0073\u0061\u006c\u0076\u0061\u0067\u0065\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072 \u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_eaeaf :=args [1].ValueNumber ;if args [2].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020l\u0069\u0066\u0065\u0020\u0074\u006f \u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_afae :=args [2].ValueNumber ;if _afae <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0053\u0059\u0044\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u006c\u0069f\u0065 \u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065");};if args [3].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0070\u0065\u0072\u0069\u006f\u0064 \u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_ffdac :=args [3].ValueNumber ;if _ffdac <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0053\u0059\u0044 r\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0070e\u0072i\u006fd\u0020t\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065");};if _ffdac > _afae {return MakeErrorResultType (ErrorTypeNum ,"\u0053\u0059\u0044\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073\u0020\u0070\u0065\u0072\u0069\u006f\u0064\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0065q\u0075\u0061\u006c\u0020\u006f\u0072\u0020\u006c\u0065\u0073\u0073\u0020\u0074\u0068a\u006e \u006c\u0069\u0066\u0065");};_gaff :=(_abac -_eaeaf )*(_afae -_ffdac +1)*2;_ffaf :=_afae *(_afae +1);return MakeNumberResult (_gaff /_ffaf );};const _begf =57366;var _ddfe =[...]int {0,1,1,2,4,1,1,1,1,2,2,1,1,1,1,3,1,3,1,3,1,3,1,2,1,1,1,3,4,1,1,1,2,2,3,3,3,3,3,3,3,3,3,3,3,3,1,2,3,1,3,1,1,0};const _cdggf =57353;
+func Syd (args []Result )Result {if len (args )!=4{return MakeErrorResult ("S\u0059\u0044\u0020\u0072\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0066\u006f\u0075\u0072 \u0061\u0072\u0067u\u006de\u006e\u0074\u0073");};if args [0].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020c\u006f\u0073\u0074\u0020\u0074\u006f \u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_abac :=args [0].ValueNumber ;if args [1].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044 \u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0073\u0061\u006c\u0076\u0061\u0067\u0065\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072 \u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_eaeaf :=args [1].ValueNumber ;if args [2].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020l\u0069\u0066\u0065\u0020\u0074\u006f \u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_afae :=args [2].ValueNumber ;if _afae <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0053\u0059\u0044\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u006c\u0069f\u0065 \u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065");};if args [3].Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0059\u0044\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0070\u0065\u0072\u0069\u006f\u0064 \u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_ffdac :=args [3].ValueNumber ;if _ffdac <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0053\u0059\u0044 r\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0070e\u0072i\u006fd\u0020t\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065");};if _ffdac > _afae {return MakeErrorResultType (ErrorTypeNum ,"\u0053\u0059\u0044\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073\u0020\u0070\u0065\u0072\u0069\u006f\u0064\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0065q\u0075\u0061\u006c\u0020\u006f\u0072\u0020\u006c\u0065\u0073\u0073\u0020\u0074\u0068a\u006e \u006c\u0069\u0066\u0065");};_gaff :=(_abac -_eaeaf )*(_afae -_ffdac +1)*2;_ffaf :=_afae *(_afae +1);return MakeNumberResult (_gaff /_ffaf );};const _begf =57366;var _ddfe =[...]int {0,1,1,2,4,1,1,1,1,2,2,1,1,1,1,3,1,3,1,3,1,3,1,2,1,1,1,3,4,1,1,1,2,2,3,3,3,3,3,3,3,3,3,3,3,3,1,2,3,1,3,1,1,0,1,};const _cdggf =57353;
 
 // Update updates the FunctionCall references after removing a row/column.
 func (_acbd FunctionCall )Update (q *_ef .UpdateQuery )Expression {_aaefgb :=[]Expression {};for _ ,_addeb :=range _acbd ._ebeeae {_cagba :=_addeb .Update (q );_aaefgb =append (_aaefgb ,_cagba );};return FunctionCall {_aebg :_acbd ._aebg ,_ebeeae :_aaefgb };};
@@ -426,7 +426,7 @@ func VLookup (args []Result )Result {_bbfa :=len (args );if _bbfa < 3{return Mak
 type Error struct{_aebf string };
 
 // Yielddisc implements the Excel YIELDDISC function.
-func Yielddisc (args []Result )Result {_dgcd :=len (args );if _dgcd !=4&&_dgcd !=5{return MakeErrorResult ("\u0059\u0049\u0045\u004c\u0044D\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020f\u006f\u0075\u0072\u0020\u006f\u0072\u0020\u0066\u0069\u0076\u0065\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_cgaed ,_addf ,_dbfbg :=_fcfd (args [0],args [1],"\u0059I\u0045\u004c\u0044\u0044\u0049\u0053C");if _dbfbg .Type ==ResultTypeError {return _dbfbg ;};if args [2].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0049\u0045\u004c\u0044\u0044\u0049S\u0043\u0020\u0072e\u0071\u0075\u0069\u0072e\u0073\u0020\u0070\u0072\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_adaf :=args [2].ValueNumber ;if _adaf <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0059\u0049E\u004c\u0044\u0044\u0049\u0053C\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073\u0020\u0070\u0072\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};if args [3].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0049\u0045\u004c\u0044D\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020r\u0065\u0064\u0065\u006d\u0070\u0074\u0069\u006f\u006e\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006et");};_bbdbf :=args [3].ValueNumber ;if _bbdbf <=0{return MakeErrorResultType (ErrorTypeNum ,"YI\u0045\u004cD\u0044\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073\u0020\u0072\u0065\u0064\u0065\u006d\u0070\u0074\u0069\u006f\u006e\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076e\u0020n\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072g\u0075m\u0065\u006et");};_gdaec :=0;if _dgcd ==5&&args [4].Type !=ResultTypeEmpty {if args [4].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0049E\u004c\u0044\u0044\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0062\u0061\u0073\u0069\u0073\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_gdaec =int (args [4].ValueNumber );if !_dca (_gdaec ){return MakeErrorResultType (ErrorTypeNum ,"\u0049\u006e\u0063\u006f\u0072\u0072\u0065\u0063\u0074\u0020\u0062\u0061\u0073\u0069\u0073\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074 \u0066\u006f\u0072\u0020\u0059I\u0045\u004cD\u0044\u0049\u0053\u0043");};};_dffg ,_dbfbg :=_bgae (_cgaed ,_addf ,_gdaec );if _dbfbg .Type ==ResultTypeError {return _dbfbg ;};return MakeNumberResult ((_bbdbf /_adaf -1)/_dffg );};type tokenType int ;func _bff (_gdae BinOpType ,_bc []Result ,_eff Result )Result {_ffe :=[]Result {};switch _eff .Type {case ResultTypeNumber :_bae :=_eff .ValueNumber ;for _dbe :=range _bc {_gfa :=_bc [_dbe ].AsNumber ();if _gfa .Type !=ResultTypeNumber {return MakeErrorResult ("\u006e\u006f\u006e\u002d\u006e\u0075\u006e\u006d\u0065\u0072\u0069\u0063\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0069\u006e\u0020\u0062\u0069n\u0061\u0072\u0079\u0020\u006fp\u0065\u0072a\u0074\u0069\u006f\u006e");};switch _gdae {case BinOpTypePlus :_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber +_bae ));case BinOpTypeMinus :_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber -_bae ));case BinOpTypeMult :_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber *_bae ));case BinOpTypeDiv :if _bae ==0{return MakeErrorResultType (ErrorTypeDivideByZero ,"");};_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber /_bae ));case BinOpTypeExp :_ffe =append (_ffe ,MakeNumberResult (_cd .Pow (_gfa .ValueNumber ,_bae )));case BinOpTypeLT :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber < _bae ));case BinOpTypeGT :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber > _bae ));case BinOpTypeEQ :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber ==_bae ));case BinOpTypeLEQ :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber <=_bae ));case BinOpTypeGEQ :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber >=_bae ));case BinOpTypeNE :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber !=_bae ));default:return MakeErrorResult (_cb .Sprintf ("\u0075\u006es\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u006c\u0069\u0073\u0074\u0020\u0062\u0069\u006e\u0061\u0072\u0079\u0020\u006fp \u0025\u0073",_gdae ));};};case ResultTypeString :_dag :=_eff .ValueString ;for _eg :=range _bc {_ag :=_bc [_eg ].AsString ();if _ag .Type !=ResultTypeString {return MakeErrorResult ("\u006e\u006f\u006e\u002d\u006e\u0075\u006e\u006d\u0065\u0072\u0069\u0063\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0069\u006e\u0020\u0062\u0069n\u0061\u0072\u0079\u0020\u006fp\u0065\u0072a\u0074\u0069\u006f\u006e");};switch _gdae {case BinOpTypeLT :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString < _dag ));case BinOpTypeGT :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString > _dag ));case BinOpTypeEQ :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString ==_dag ));case BinOpTypeLEQ :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString <=_dag ));case BinOpTypeGEQ :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString >=_dag ));case BinOpTypeNE :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString !=_dag ));default:return MakeErrorResult (_cb .Sprintf ("\u0075\u006es\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u006c\u0069\u0073\u0074\u0020\u0062\u0069\u006e\u0061\u0072\u0079\u0020\u006fp \u0025\u0073",_gdae ));};};default:return MakeErrorResult ("\u006e\u006f\u006e\u002d\u006e\u0075\u006e\u006d\u0065\u0072\u0069c\u0020\u0061\u006e\u0064\u0020\u006e\u006f\u006e-\u0073t\u0072\u0069\u006e\u0067\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0069\u006e\u0020\u0062\u0069\u006e\u0061r\u0079\u0020\u006f\u0070\u0065\u0072\u0061\u0074\u0069\u006f\u006e");};return MakeListResult (_ffe );};var _aacb =[...]int {0};var (_dbged =0;_edag =false ;);
+func Yielddisc (args []Result )Result {_dgcd :=len (args );if _dgcd !=4&&_dgcd !=5{return MakeErrorResult ("\u0059\u0049\u0045\u004c\u0044D\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020f\u006f\u0075\u0072\u0020\u006f\u0072\u0020\u0066\u0069\u0076\u0065\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_cgaed ,_addf ,_dbfbg :=_fcfd (args [0],args [1],"\u0059I\u0045\u004c\u0044\u0044\u0049\u0053C");if _dbfbg .Type ==ResultTypeError {return _dbfbg ;};if args [2].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0049\u0045\u004c\u0044\u0044\u0049S\u0043\u0020\u0072e\u0071\u0075\u0069\u0072e\u0073\u0020\u0070\u0072\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_adaf :=args [2].ValueNumber ;if _adaf <=0{return MakeErrorResultType (ErrorTypeNum ,"\u0059\u0049E\u004c\u0044\u0044\u0049\u0053C\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073\u0020\u0070\u0072\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};if args [3].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0049\u0045\u004c\u0044D\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020r\u0065\u0064\u0065\u006d\u0070\u0074\u0069\u006f\u006e\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006et");};_bbdbf :=args [3].ValueNumber ;if _bbdbf <=0{return MakeErrorResultType (ErrorTypeNum ,"YI\u0045\u004cD\u0044\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0073\u0020\u0072\u0065\u0064\u0065\u006d\u0070\u0074\u0069\u006f\u006e\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0070\u006f\u0073\u0069\u0074\u0069\u0076e\u0020n\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072g\u0075m\u0065\u006et");};_gdaec :=0;if _dgcd ==5&&args [4].Type !=ResultTypeEmpty {if args [4].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0049E\u004c\u0044\u0044\u0049\u0053\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0062\u0061\u0073\u0069\u0073\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_gdaec =int (args [4].ValueNumber );if !_dca (_gdaec ){return MakeErrorResultType (ErrorTypeNum ,"\u0049\u006e\u0063\u006f\u0072\u0072\u0065\u0063\u0074\u0020\u0062\u0061\u0073\u0069\u0073\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074 \u0066\u006f\u0072\u0020\u0059I\u0045\u004cD\u0044\u0049\u0053\u0043");};};_dffg ,_dbfbg :=_bgae (_cgaed ,_addf ,_gdaec );if _dbfbg .Type ==ResultTypeError {return _dbfbg ;};return MakeNumberResult ((_bbdbf /_adaf -1)/_dffg );};type tokenType int ;func _bff (_gdae BinOpType ,_bc []Result ,_eff Result )Result {_ffe :=[]Result {};switch _eff .Type {case ResultTypeNumber :_bae :=_eff .ValueNumber ;for _dbe :=range _bc {_gfa :=_bc [_dbe ].AsNumber ();if _gfa .Type !=ResultTypeNumber {return MakeErrorResult ("\u006e\u006f\u006e\u002d\u006e\u0075\u006e\u006d\u0065\u0072\u0069\u0063\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0069\u006e\u0020\u0062\u0069n\u0061\u0072\u0079\u0020\u006fp\u0065\u0072a\u0074\u0069\u006f\u006e");};switch _gdae {case BinOpTypePlus :_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber +_bae ));case BinOpTypeMinus :_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber -_bae ));case BinOpTypeMult :_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber *_bae ));case BinOpTypeDiv :if _bae ==0{return MakeErrorResultType (ErrorTypeDivideByZero ,"");};_ffe =append (_ffe ,MakeNumberResult (_gfa .ValueNumber /_bae ));case BinOpTypeExp :_ffe =append (_ffe ,MakeNumberResult (_cd .Pow (_gfa .ValueNumber ,_bae )));case BinOpTypeLT :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber < _bae ));case BinOpTypeGT :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber > _bae ));case BinOpTypeEQ :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber ==_bae ));case BinOpTypeLEQ :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber <=_bae ));case BinOpTypeGEQ :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber >=_bae ));case BinOpTypeNE :_ffe =append (_ffe ,MakeBoolResult (_gfa .ValueNumber !=_bae ));default:return MakeErrorResult (_cb .Sprintf ("\u0075\u006es\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u006c\u0069\u0073\u0074\u0020\u0062\u0069\u006e\u0061\u0072\u0079\u0020\u006fp \u0025\u0073",_gdae ));};};case ResultTypeString :_dag :=_eff .ValueString ;for _eg :=range _bc {_ag :=_bc [_eg ].AsString ();if _ag .Type !=ResultTypeString {return MakeErrorResult ("\u006e\u006f\u006e\u002d\u006e\u0075\u006e\u006d\u0065\u0072\u0069\u0063\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0069\u006e\u0020\u0062\u0069n\u0061\u0072\u0079\u0020\u006fp\u0065\u0072a\u0074\u0069\u006f\u006e");};switch _gdae {case BinOpTypeLT :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString < _dag ));case BinOpTypeGT :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString > _dag ));case BinOpTypeEQ :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString ==_dag ));case BinOpTypeLEQ :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString <=_dag ));case BinOpTypeGEQ :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString >=_dag ));case BinOpTypeNE :_ffe =append (_ffe ,MakeBoolResult (_ag .ValueString !=_dag ));default:return MakeErrorResult (_cb .Sprintf ("\u0075\u006es\u0075\u0070\u0070\u006f\u0072\u0074\u0065\u0064\u0020\u006c\u0069\u0073\u0074\u0020\u0062\u0069\u006e\u0061\u0072\u0079\u0020\u006fp \u0025\u0073",_gdae ));};};default:return MakeErrorResult ("\u006e\u006f\u006e\u002d\u006e\u0075\u006e\u006d\u0065\u0072\u0069c\u0020\u0061\u006e\u0064\u0020\u006e\u006f\u006e-\u0073t\u0072\u0069\u006e\u0067\u0020\u0076\u0061\u006c\u0075\u0065\u0020\u0069\u006e\u0020\u0062\u0069\u006e\u0061r\u0079\u0020\u006f\u0070\u0065\u0072\u0061\u0074\u0069\u006f\u006e");};return MakeListResult (_ffe );};var _aacb =[...]int {0,};var (_dbged =0;_edag =false ;);
 
 // MakeErrorResult constructs a #VALUE! error with a given extra error message.
 // The error message is for debugging formula evaluation only and is not stored
@@ -468,7 +468,7 @@ func SumIf (args []Result )Result {if len (args )< 3{return MakeErrorResult ("\u
 func Trim (args []Result )Result {if len (args )!=1{return MakeErrorResult ("\u0054\u0052\u0049\u004d\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0061\u0020\u0073\u0069\u006e\u0067\u006c\u0065\u0020\u0073t\u0072\u0069\u006e\u0067\u0020a\u0072\u0067u\u006d\u0065\u006e\u0074");};_eefaa :=args [0].AsString ();if _eefaa .Type !=ResultTypeString {return MakeErrorResult ("\u0054\u0052\u0049\u004d\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0061\u0020\u0073\u0069\u006e\u0067\u006c\u0065\u0020\u0073t\u0072\u0069\u006e\u0067\u0020a\u0072\u0067u\u006d\u0065\u006e\u0074");};_cgbag :=_ca .Buffer {};_bffce :=false ;_edfac :=false ;_ggde :=0;for _ ,_fcgef :=range _eefaa .ValueString {_accb :=_fcgef ==' ';if _accb {if !_bffce {continue ;};if !_edfac {_ggde ++;_cgbag .WriteRune (_fcgef );};}else {_ggde =0;_bffce =true ;_cgbag .WriteRune (_fcgef );};_edfac =_accb ;};_cgbag .Truncate (_cgbag .Len ()-_ggde );return MakeStringResult (_cgbag .String ());};func _dfb (){_bgca =_gd .MustCompile ("\u005e\u0030\u002b\u0024");_eeeg =_gd .MustCompile ("\u005e\u0028\u0028\u0023|0\u0029\u002b\u002c\u0029\u002b\u0028\u0023\u007c\u0030\u0029\u002b\u0028\u003b\u007c$\u0029");_dgaa =_gd .MustCompile ("\u005e\u0028\u0023\u007c\u0030\u007c\u002c\u0029\u002a\u005f\u005c\u0029\u003b");_cbbg =_gd .MustCompile ("\u005e\u0030\u002b\u005c\u002e\u0028\u0030\u002b\u0029\u0024");_fcbg =_gd .MustCompile ("\u005e\u0028\u0028\u0023\u007c\u0030\u0029\u002b\u002c\u0029+\u0028\u0023\u007c\u0030\u0029\u002b\u005c.\u0028\u0030\u002b\u0029\u002e\u002a\u0028\u003b\u007c\u0024\u0029");_gggc =_gd .MustCompile ("^\u0028\u005f\u007c\u002d\u007c\u0020)\u002b\u005c\u002a\u0020\u0023\u002b\u002c\u0023\u002b0\u005c\u002e\u00280\u002b)\u002e\u002a\u003b");_cgebb =_gd .MustCompile ("\u005e\u0028\u0028\u0023\u007c\u0030)\u002b\u002c\u0029\u002b\u0028\u0023\u007c\u0030\u0029\u002b\u005c\u002e\u0028(\u0023\u007c\u0030\u0029\u002b\u0029\u005f\\\u0029\u002e\u002a\u003b");_gbfc =_gd .MustCompile ("\u005e\u0028\u0023\u007c0)\u002b\u005c\u002e\u0028\u0028\u0023\u007c\u0030\u0029\u002b\u0029\u0025\u0024");_faac =_gd .MustCompile ("\u005c\u005b\u005c$\u005c\u0024\u002d\u002e+\u005c\u005d\u0028\u005c\u002a\u0020\u0029?\u0028\u0023\u007c\u0030\u0029\u002b\u002c\u0028\u0023\u007c\u0030\u0029\u002b\u003b");_bcbf =_gd .MustCompile ("\u005c[\u005c\u0024\\\u0024\u002d\u002e+\u005c\u005d\u0028\u005c\u002a\u0020\u0029?\u0028\u0023\u007c\u0030\u0029\u002b,\u0028\u0023\u007c\u0030\u0029\u002b\u005c\u002e\u0028\u0028\u0023|\u0030\u007c\u002d\u0029\u002b\u0029\u002e\u002a\u003b");_gefc =_gd .MustCompile ("\u005e(\u0028\u0023|\u0030\u0029\u002b,\u0029\u002b\u0028\u0023\u007c\u0030\u0029+\u0028\u005c\u002e\u0028\u0028\u0023|\u0030\u007c\u002d\u0029\u002b\u0029\u0029\u003f\u002e\u002b\u005c[\u005c\u0024\u002e\u002b\u005c\u005d\u002e\u002a\u003b");_ebaab =_gd .MustCompile ("\u005e\u004d\u002b(\u002f\u007c\u0020\u007c\u002c\u007c\u0022\u007c"+_bbfe +_bbfe +"\u0029\u002b\u0044\u002b\u0028\u002f\u007c\u0020\u007c\u002c\u007c\u0022\u007c"+_bbfe +_bbfe +"\u0029\u002b\u0059+\u0024");_ecef =_gd .MustCompile ("\u005e\u0044\u002b\u0028\u002f\u007c\u0020\u007c\u005c\u002e\u007c\u0022\u007c"+_bbfe +_bbfe +"\u0029\u002b\u004d\u002b\u0028\u002f\u007c\u0020\u007c\\\u002e\u007c\u0022\u007c"+_bbfe +_bbfe +"\u0029\u002b\u0059+\u0024");_aebce =_gd .MustCompile ("\u005e\u0028\u0023|\u0030\u0029\u002b\u005c.\u0028\u0028\u0023\u007c\u0030\u0029\u002a)\u0045\u005c\u002b\u0028\u0023\u007c\u0030\u0029\u002b\u0028\u003b\u007c\u0024\u0029");_eabac =_gd .MustCompile ("\u005e.\u002a\u005f\u005c\u0029\u002e\u002a;");};
 
 // String returns a string representation of a named range.
-func (_bafeb NamedRangeRef )String ()string {return _bafeb ._ffead };var _aaede =[...]int {2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30,31,32,33,34,35,36};
+func (_bafeb NamedRangeRef )String ()string {return _bafeb ._ffead };var _aaede =[...]int {2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30,31,32,33,34,35,36,37,};
 
 // YearFrac is an implementation of the Excel YEARFRAC() function.
 func YearFrac (args []Result )Result {_adf :=len (args );if (_adf !=2&&_adf !=3)||args [0].Type !=ResultTypeNumber ||args [1].Type !=ResultTypeNumber {return MakeErrorResult ("Y\u0045\u0041\u0052\u0046\u0052\u0041\u0043\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020tw\u006f\u0020\u006f\u0072 \u0074\u0068\u0072\u0065\u0065\u0020\u006e\u0075\u006dbe\u0072\u0020a\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_eded :=0;if _adf ==3&&args [2].Type !=ResultTypeEmpty {if args [2].Type !=ResultTypeNumber {return MakeErrorResult ("Y\u0045\u0041\u0052\u0046\u0052\u0041\u0043\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020ba\u0073\u0069\u0073\u0020a\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0020\u0074o \u0062\u0065 \u0061\u0020\u006e\u0075\u006d\u0062\u0065\u0072");};_eded =int (args [2].ValueNumber );if !_dca (_eded ){return MakeErrorResultType (ErrorTypeNum ,"\u0049\u006ec\u006f\u0072\u0072\u0065c\u0074\u0020b\u0061\u0073\u0069\u0073\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074\u0020\u0066\u006f\u0072\u0020\u0059\u0045\u0041R\u0046\u0052\u0041\u0043");};};if args [0].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0045\u0041\u0052\u0046\u0052\u0041\u0043\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020s\u0074\u0061\u0072\u0074\u0020\u0064\u0061t\u0065\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006db\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074");};_badga :=args [0].ValueNumber ;if args [1].Type !=ResultTypeNumber {return MakeErrorResult ("\u0059\u0045\u0041\u0052\u0046\u0052\u0041\u0043 \u0072\u0065\u0071ui\u0072\u0065\u0073\u0020\u0065\u006ed\u0020\u0064\u0061\u0074\u0065\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006db\u0065\u0072\u0020\u0061\u0072\u0067\u0075\u006de\u006e\u0074");};_cccg :=args [1].ValueNumber ;_bdfb ,_gage :=_bgae (_badga ,_cccg ,_eded );if _gage .Type ==ResultTypeError {return _gage ;};return MakeNumberResult (_bdfb );};
@@ -562,7 +562,7 @@ type FunctionComplex func (_degac Context ,_dfgdf Evaluator ,_febfe []Result )Re
 func (_egde Result )Value ()string {switch _egde .Type {case ResultTypeNumber :_bafbf :=_dd .FormatFloat (_egde .ValueNumber ,'f',-1,64);if len (_bafbf )> 12{_cbeb :=12;for _befg :=_cbeb ;_befg > 0&&_bafbf [_befg ]=='0';_befg --{_cbeb --;};_bafbf =_bafbf [0:_cbeb +1];};return _bafbf ;case ResultTypeError :return _egde .ValueString ;case ResultTypeString :return _egde .ValueString ;case ResultTypeList :if len (_egde .ValueList )==0{return "";};return _egde .ValueList [0].Value ();case ResultTypeArray :if len (_egde .ValueArray )==0||len (_egde .ValueArray [0])==0{return "";};return _egde .ValueArray [0][0].Value ();case ResultTypeEmpty :return "";default:return "\u0075\u006e\u0068\u0061nd\u006c\u0065\u0064\u0020\u0072\u0065\u0073\u0075\u006c\u0074\u0020\u0076\u0061\u006cu\u0065";};};const _cdea ="\u0052\u0065\u0073\u0075\u006c\u0074\u0054\u0079\u0070\u0065U\u006e\u006b\u006e\u006f\u0077\u006e\u0052\u0065\u0073u\u006c\u0074\u0054y\u0070\u0065\u004e\u0075\u006d\u0062\u0065\u0072\u0052\u0065s\u0075\u006c\u0074\u0054\u0079\u0070\u0065\u0053\u0074\u0072\u0069\u006e\u0067\u0052\u0065\u0073\u0075\u006c\u0074\u0054\u0079\u0070\u0065\u004c\u0069\u0073\u0074\u0052\u0065\u0073\u0075lt\u0054\u0079p\u0065\u0041r\u0072\u0061\u0079\u0052\u0065\u0073\u0075\u006c\u0074\u0054\u0079\u0070\u0065\u0045\u0072\u0072\u006f\u0072\u0052\u0065\u0073\u0075\u006c\u0074\u0054\u0079\u0070\u0065\u0045\u006d\u0070\u0074\u0079";
 
 // Choose implements the Excel CHOOSE function.
-func Choose (args []Result )Result {if len (args )< 2{return MakeErrorResult ("\u0043\u0048O\u004f\u0053\u0045\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0074\u0077\u006f\u0020\u0061\u0072\u0067\u0075\u006den\u0074\u0073");};_ecga :=args [0];if _ecga .Type !=ResultTypeNumber {return MakeErrorResult ("\u0043H\u004f\u004fS\u0045\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020f\u0069\u0072\u0073\u0074\u0020\u0061r\u0067\u0075\u006d\u0065\u006e\u0074\u0020\u006f\u0066\u0020\u0074y\u0070\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072");};_gebac :=int (_ecga .ValueNumber );if _gebac < 1{return MakeErrorResult ("\u0049\u006e\u0064\u0065\u0078\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065 \u0061 \u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065\u0020\u0076\u0061\u006c\u0075\u0065");};if len (args )<=_gebac {return MakeErrorResult ("\u0049\u006e\u0064\u0065\u0078\u0020\u0073\u0068\u006f\u0075\u006cd\u0020\u0062\u0065\u0020\u006c\u0065\u0073\u0073 \u006fr\u0020\u0065\u0071\u0075\u0061\u006c\u0020\u0074\u006f\u0020\u0074\u0068\u0065\u0020\u006e\u0075\u006d\u0062e\u0072\u0020\u006f\u0066\u0020\u0076\u0061\u006c\u0075\u0065\u0073");};return args [_gebac ];};const _debg =57357;const _gffag int =30;const _ggegg =57370;func _bgggd (_ecaeec Context ,_bfdcf Evaluator ,_egbec ,_cgage string )Result {_aaeg ,_geggc :=_f .ParseCellReference (_egbec );if _geggc !=nil {return MakeErrorResult (_cb .Sprintf ("\u0075\u006e\u0061bl\u0065\u0020\u0074\u006f\u0020\u0070\u0061\u0072\u0073e\u0020r\u0061n\u0067e\u0020\u0025\u0073\u003a\u0020\u0065\u0072\u0072\u006f\u0072\u0020\u0025\u0073",_egbec ,_geggc .Error ()));};_bdcg ,_ddagd :=_aaeg .ColumnIdx ,_aaeg .RowIdx ;_gcfe ,_bacggc :=_f .ParseCellReference (_cgage );if _bacggc !=nil {return MakeErrorResult (_cb .Sprintf ("\u0075\u006e\u0061bl\u0065\u0020\u0074\u006f\u0020\u0070\u0061\u0072\u0073e\u0020r\u0061n\u0067e\u0020\u0025\u0073\u003a\u0020\u0065\u0072\u0072\u006f\u0072\u0020\u0025\u0073",_cgage ,_bacggc .Error ()));};_ggfed ,_bagg :=_gcfe .ColumnIdx ,_gcfe .RowIdx ;_aaega :=[][]Result {};for _eged :=_ddagd ;_eged <=_bagg ;_eged ++{_bebd :=[]Result {};for _cfafe :=_bdcg ;_cfafe <=_ggfed ;_cfafe ++{_facb :=_ecaeec .Cell (_cb .Sprintf ("\u0025\u0073\u0025\u0064",_f .IndexToColumn (_cfafe ),_eged ),_bfdcf );_bebd =append (_bebd ,_facb );};_aaega =append (_aaega ,_bebd );};if len (_aaega )==1{if len (_aaega [0])==1{return _aaega [0][0];};return MakeListResult (_aaega [0]);};return MakeArrayResult (_aaega );};func (_badee *ivr )SetOffset (col ,row uint32 ){};
+func Choose (args []Result )Result {if len (args )< 2{return MakeErrorResult ("\u0043\u0048O\u004f\u0053\u0045\u0020\u0072\u0065\u0071\u0075\u0069\u0072\u0065\u0073\u0020\u0074\u0077\u006f\u0020\u0061\u0072\u0067\u0075\u006den\u0074\u0073");};_ecga :=args [0];if _ecga .Type !=ResultTypeNumber {return MakeErrorResult ("\u0043H\u004f\u004fS\u0045\u0020\u0072e\u0071\u0075\u0069\u0072\u0065\u0073\u0020f\u0069\u0072\u0073\u0074\u0020\u0061r\u0067\u0075\u006d\u0065\u006e\u0074\u0020\u006f\u0066\u0020\u0074y\u0070\u0065\u0020\u006e\u0075\u006d\u0062\u0065\u0072");};_gebac :=int (_ecga .ValueNumber );if _gebac < 1{return MakeErrorResult ("\u0049\u006e\u0064\u0065\u0078\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065 \u0061 \u0070\u006f\u0073\u0069\u0074\u0069\u0076\u0065\u0020\u0076\u0061\u006c\u0075\u0065");};if len (args )<=_gebac {return MakeErrorResult ("\u0049\u006e\u0064\u0065\u0078\u0020\u0073\u0068\u006f\u0075\u006cd\u0020\u0062\u0065\u0020\u006c\u0065\u0073\u0073 \u006fr\u0020\u0065\u0071\u0075\u0061\u006c\u0020\u0074\u006f\u0020\u0074\u0068\u0065\u0020\u006e\u0075\u006d\u0062e\u0072\u0020\u006f\u0066\u0020\u0076\u0061\u006c\u0075\u0065\u0073");};return args [_gebac ];};const _debg =57357;const _gffag int =30;const _ggegg =57370;func _bgggd (_ecaeec Context ,_bfdcf Evaluator ,_egbec ,_cgage string )Result {_aaeg ,_geggc :=_f .ParseCellReference (_egbec );if _geggc !=nil {return MakeErrorResult (_cb .Sprintf ("\u0075\u006e\u0061bl\u0065\u0020\u0074\u006f\u0020\u0070\u0061\u0072\u0073e\u0020r\u0061n\u0067e\u0020\u0025\u0073\u003a\u0020\u0065\u0072\u0072\u006f\u0072\u0020\u0025\u0073",_egbec ,_geggc .Error ()));};_bdcg ,_ddagd :=_aaeg .ColumnIdx ,_aaeg .RowIdx ;_gcfe ,_bacggc :=_f .ParseCellReference (_cgage );if _bacggc !=nil {return MakeErrorResult (_cb .Sprintf ("\u0075\u006e\u0061bl\u0065\u0020\u0074\u006f\u0020\u0070\u0061\u0072\u0073e\u0020r\u0061n\u0067e\u0020\u0025\u0073\u003a\u0020\u0065\u0072\u0072\u006f\u0072\u0020\u0025\u0073",_cgage ,_bacggc .Error ()));};_ggfed ,_bagg :=_gcfe .ColumnIdx ,_gcfe .RowIdx ;_aaega :=[][]Result {};for _eged :=_ddagd ;_eged <=_bagg ;_eged ++{_bebd :=[]Result {};for _cfafe :=_bdcg ;_cfafe <=_ggfed ;_cfafe ++{_facb :=_ecaeec .Cell (_f .CellReference {RowIdx :_eged ,Column :_f .IndexToColumn (_cfafe ),AbsoluteColumn :_aaeg .AbsoluteColumn &&_gcfe .AbsoluteColumn ,AbsoluteRow :_aaeg .AbsoluteRow &&_gcfe .AbsoluteRow }.String (),_bfdcf );_bebd =append (_bebd ,_facb );};_aaega =append (_aaega ,_bebd );};if len (_aaega )==1{if len (_aaega [0])==1{return _aaega [0][0];};return MakeListResult (_aaega [0]);};return MakeArrayResult (_aaega );};func (_badee *ivr )SetOffset (col ,row uint32 ){};
 
 // Eval evaluates and returns the result of a formula.
 func (_ace *defEval )Eval (ctx Context ,formula string )Result {_egd :=ParseString (formula );_abg :=make (chan Result );go func (){if _egd ==nil {_abg <-MakeErrorResult (_cb .Sprintf ("\u0075\u006e\u0061\u0062\u006c\u0065\u0020\u0074\u006f\u0020\u0070a\u0072\u0073\u0065\u0020\u0066\u006f\u0072\u006d\u0075\u006ca\u0020\u0025\u0073",formula ));}else {_ace .checkLastEvalIsRef (ctx ,_egd );_abg <-_egd .Eval (ctx ,_ace );};}();select{case _cgef :=<-_abg :return _cgef ;case <-_ee .After (_bccee ):_db .Log .Debug ("\u0055\u006e\u0069\u004ff\u0066\u0069\u0063\u0065\u0020\u0065\u0076\u0061\u006c\u0075a\u0074i\u006f\u006e\u0020\u0074\u0069\u006d\u0065o\u0075\u0074");return MakeNumberResult (0);};};func _baag (_gee ,_dbge float64 ,_ccb ,_efaed int )(float64 ,Result ){_gbfa ,_aegf :=_cdb (_gee ),_cdb (_dbge );if _aegf .After (_gbfa ){_ebg :=_aed (_gbfa ,_aegf ,_ccb ,_efaed );_bddf :=(_aegf .Year ()-_ebg .Year ())*12+int (_aegf .Month ())-int (_ebg .Month ());return float64 (_bddf *_ccb )/12.0,_fcc ;};return 0,MakeErrorResultType (ErrorTypeNum ,"\u0053\u0065t\u0074\u006c\u0065\u006d\u0065\u006e\u0074\u0020\u0064\u0061\u0074\u0065\u0020\u0073\u0068\u006f\u0075\u006c\u0064\u0020\u0062\u0065\u0020\u0062\u0065\u0066\u006f\u0072\u0065\u0020\u006d\u0061\u0074\u0075\u0072\u0069\u0074\u0079\u0020\u0064\u0061\u0074\u0065");};
@@ -877,10 +877,10 @@ type EmptyExpr struct{};
 func (_cccd PrefixRangeExpr )Reference (ctx Context ,ev Evaluator )Reference {_ffccd :=_cccd ._aecbe .Reference (ctx ,ev );_abage :=_cccd ._acdg .Reference (ctx ,ev );_dfab :=_cccd ._adeff .Reference (ctx ,ev );if _ffccd .Type ==ReferenceTypeSheet &&_abage .Type ==ReferenceTypeCell &&_dfab .Type ==ReferenceTypeCell {return MakeRangeReference (_ddcfc (_ffccd ,_abage ,_dfab ));};return ReferenceInvalid ;};
 
 // VerticalRange is a range expression that when evaluated returns a list of Results from references like AA:IJ (all cells from columns AA to IJ).
-type VerticalRange struct{_ccdcd ,_ggfec string };var _bfcgd =[...]int {123,-1000,-1000,74,163,103,163,163,-1000,-1000,-1000,-1000,163,-1000,-1000,-1000,-1000,-1000,-12,106,-1000,-1000,143,-1000,-1000,-1000,-1000,-1000,163,163,163,163,163,163,163,163,163,163,163,163,74,163,163,6,-28,74,-15,-15,60,10,-14,-1000,-1000,-1000,7,-1000,74,-15,-15,-23,-23,-1000,-8,-8,-8,-8,-8,-8,-4,33,-1000,163,163,-1000,-1000,10,-1000,163,-1000,-28,74,-1000,-1000,74};
+type VerticalRange struct{_ccdcd ,_ggfec string };var _bfcgd =[...]int {107,-1000,-1000,181,136,78,136,136,-1000,-1000,-1000,-1000,136,-1000,-1000,-1000,-1000,-1000,-14,61,-1000,-1000,41,-1000,-1000,-1000,-1000,-1000,-1000,136,136,136,136,136,136,136,136,136,136,136,136,181,136,136,-7,-31,181,-15,-15,167,6,-27,-1000,-1000,-1000,46,-1000,181,-15,-15,34,34,-1000,-8,-8,-8,-8,-8,-8,-5,152,-1000,136,136,-1000,-1000,6,-1000,136,-1000,-31,181,-1000,-1000,181,};
 
 // Eval evaluates a range with prefix returning a list of results or an error.
-func (_aagdf PrefixRangeExpr )Eval (ctx Context ,ev Evaluator )Result {_ggfd :=_aagdf ._aecbe .Reference (ctx ,ev );_fbade :=_aagdf ._acdg .Reference (ctx ,ev );_cbgee :=_aagdf ._adeff .Reference (ctx ,ev );switch _ggfd .Type {case ReferenceTypeSheet :if _eadf (_ggfd ,ctx ){return MakeErrorResultType (ErrorTypeName ,_cb .Sprintf ("\u0053h\u0065e\u0074\u0020\u0025\u0073\u0020n\u006f\u0074 \u0066\u006f\u0075\u006e\u0064",_ggfd .Value ));};_bege :=_ddcfc (_ggfd ,_fbade ,_cbgee );if _fbade .Type ==ReferenceTypeCell &&_cbgee .Type ==ReferenceTypeCell {if _ggagc ,_fgae :=ev .GetFromCache (_bege );_fgae {return _ggagc ;}else {_eeaae :=_bgggd (ctx .Sheet (_ggfd .Value ),ev ,_fbade .Value ,_cbgee .Value );ev .SetCache (_bege ,_eeaae );return _eeaae ;};};return MakeErrorResult ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072a\u006e\u0067\u0065\u0020"+_bege );default:return MakeErrorResult (_cb .Sprintf ("\u006e\u006f\u0020\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0020\u0066\u006f\u0072\u0020r\u0065f\u0065\u0072\u0065\u006e\u0063\u0065\u0020\u0074\u0079\u0070\u0065\u0020\u0025\u0073",_ggfd .Type ));};};var _eceb =[...]int {-1,1,1,-1,-2,0};func _eabc (_gfcd int )bool {if _gfcd ==_gfcd /400*400{return true ;};if _gfcd ==_gfcd /100*100{return false ;};return _gfcd ==_gfcd /4*4;};type cmpResult int8 ;
+func (_aagdf PrefixRangeExpr )Eval (ctx Context ,ev Evaluator )Result {_ggfd :=_aagdf ._aecbe .Reference (ctx ,ev );_fbade :=_aagdf ._acdg .Reference (ctx ,ev );_cbgee :=_aagdf ._adeff .Reference (ctx ,ev );switch _ggfd .Type {case ReferenceTypeSheet :if _eadf (_ggfd ,ctx ){return MakeErrorResultType (ErrorTypeName ,_cb .Sprintf ("\u0053h\u0065e\u0074\u0020\u0025\u0073\u0020n\u006f\u0074 \u0066\u006f\u0075\u006e\u0064",_ggfd .Value ));};_bege :=_ddcfc (_ggfd ,_fbade ,_cbgee );if _fbade .Type ==ReferenceTypeCell &&_cbgee .Type ==ReferenceTypeCell {if _ggagc ,_fgae :=ev .GetFromCache (_bege );_fgae {return _ggagc ;}else {_eeaae :=_bgggd (ctx .Sheet (_ggfd .Value ),ev ,_fbade .Value ,_cbgee .Value );ev .SetCache (_bege ,_eeaae );return _eeaae ;};};return MakeErrorResult ("\u0069\u006e\u0076\u0061\u006c\u0069\u0064\u0020\u0072a\u006e\u0067\u0065\u0020"+_bege );default:return MakeErrorResult (_cb .Sprintf ("\u006e\u006f\u0020\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0020\u0066\u006f\u0072\u0020r\u0065f\u0065\u0072\u0065\u006e\u0063\u0065\u0020\u0074\u0079\u0070\u0065\u0020\u0025\u0073",_ggfd .Type ));};};var _eceb =[...]int {-1,1,1,-1,-2,0,};func _eabc (_gfcd int )bool {if _gfcd ==_gfcd /400*400{return true ;};if _gfcd ==_gfcd /100*100{return false ;};return _gfcd ==_gfcd /4*4;};type cmpResult int8 ;
 
 // Ceiling is an implementation of the CEILING function which
 // returns the ceiling of a number.
@@ -909,7 +909,7 @@ func NewPrefixHorizontalRange (pfx Expression ,v string )Expression {_gcdd :=_ea
 
 // Median implements the MEDIAN function that returns the median of a range of
 // values.
-func Median (args []Result )Result {if len (args )==0{return MakeErrorResult ("\u004d\u0045D\u0049\u0041\u004e\u0020r\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0061\u0074\u0020l\u0065\u0061\u0073\u0074\u0020\u006f\u006e\u0065\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_bafb :=_fegcb (args );_e .Float64s (_bafb );var _gbda float64 ;if len (_bafb )%2==0{_gbda =(_bafb [len (_bafb )/2-1]+_bafb [len (_bafb )/2])/2;}else {_gbda =_bafb [len (_bafb )/2];};return MakeNumberResult (_gbda );};var _dgfa =[...]int {0,7,3,3,3,8,8,8,8,1,1,1,2,2,2,2,2,14,15,15,17,17,4,4,4,13,5,6,6,6,6,6,6,6,12,12,12,12,12,12,12,12,12,12,12,12,9,9,9,16,16,11,10,10};func _fe (_efa BinOpType ,_ce ,_fc [][]Result )Result {_cdc :=[][]Result {};for _cac :=range _ce {_ff :=_gc (_efa ,_ce [_cac ],_fc [_cac ]);if _ff .Type ==ResultTypeError {return _ff ;};_cdc =append (_cdc ,_ff .ValueList );};return MakeArrayResult (_cdc );};
+func Median (args []Result )Result {if len (args )==0{return MakeErrorResult ("\u004d\u0045D\u0049\u0041\u004e\u0020r\u0065\u0071u\u0069\u0072\u0065\u0073\u0020\u0061\u0074\u0020l\u0065\u0061\u0073\u0074\u0020\u006f\u006e\u0065\u0020\u0061\u0072\u0067u\u006d\u0065\u006e\u0074");};_bafb :=_fegcb (args );_e .Float64s (_bafb );var _gbda float64 ;if len (_bafb )%2==0{_gbda =(_bafb [len (_bafb )/2-1]+_bafb [len (_bafb )/2])/2;}else {_gbda =_bafb [len (_bafb )/2];};return MakeNumberResult (_gbda );};var _dgfa =[...]int {0,7,3,3,3,8,8,8,8,1,1,1,2,2,2,2,2,14,15,15,17,17,4,4,4,13,5,6,6,6,6,6,6,6,12,12,12,12,12,12,12,12,12,12,12,12,9,9,9,16,16,11,10,10,6,};func _fe (_efa BinOpType ,_ce ,_fc [][]Result )Result {_cdc :=[][]Result {};for _cac :=range _ce {_ff :=_gc (_efa ,_ce [_cac ],_fc [_cac ]);if _ff .Type ==ResultTypeError {return _ff ;};_cdc =append (_cdc ,_ff .ValueList );};return MakeArrayResult (_cdc );};
 
 // Lookup implements the LOOKUP function that returns a matching value from a
 // column, or from the same index in a second column.
@@ -932,7 +932,7 @@ func IsRef (ctx Context ,ev Evaluator ,args []Result )Result {if len (args )!=1{
 func Dollarde (args []Result )Result {_bgfa ,_gbdf ,_cded :=_aagg (args ,"\u0044\u004f\u004c\u004c\u0041\u0052\u0044\u0045");if _cded .Type ==ResultTypeError {return _cded ;};if _gbdf < 1{return MakeErrorResultType (ErrorTypeDivideByZero ,"\u0044\u004f\u004c\u004c\u0041\u0052\u0044\u0045\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073\u0020\u0066\u0072a\u0063t\u0069\u006f\u006e\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u0065\u0071\u0075\u0061\u006c\u0020\u006f\u0072 \u006d\u006f\u0072\u0065\u0020\u0074\u0068\u0061\u006e\u0020\u0031");};if _bgfa ==0{return MakeNumberResult (0);};_ddcf :=_bgfa < 0;if _ddcf {_bgfa =-_bgfa ;};_bdfc :=args [0].Value ();_acef :=_ea .Split (_bdfc ,"\u002e");_ebbcb :=float64 (int (_bgfa ));_agbg :=_acef [1];_feef :=len (_agbg );_dbgb :=int (_cd .Log10 (_gbdf ))+1;_bcda :=float64 (_dbgb -_feef );_agad ,_bacc :=_dd .ParseFloat (_agbg ,64);if _bacc !=nil {return MakeErrorResult ("I\u006e\u0063\u006f\u0072\u0072\u0065\u0063\u0074\u0020\u0066\u0072\u0061\u0063\u0074\u0069\u006f\u006e\u0020a\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0020\u0066\u006fr \u0044\u004f\u004cL\u0041R\u0044\u0045");};_agad *=_cd .Pow (10,_bcda );_ffebg :=_ebbcb +_agad /_gbdf ;if _ddcf {_ffebg =-_ffebg ;};return MakeNumberResult (_ffebg );};var _ggae []byte =[]byte {0,1,2,1,11,1,12,1,13,1,14,1,15,1,16,1,17,1,18,1,19,1,20,1,21,1,22,1,23,1,24,1,25,1,26,1,27,1,28,1,29,1,30,1,31,1,32,1,33,1,34,1,35,1,36,1,37,1,38,1,39,1,40,1,41,1,42,1,43,2,0,1,2,3,4,2,3,5,2,3,6,2,3,7,2,3,8,2,3,9,2,3,10};var _dfc float64 =25569.0;
 
 // MakeEmptyResult is ued when parsing an empty argument.
-func MakeEmptyResult ()Result {return Result {Type :ResultTypeEmpty }};var _fagea =[...]int {-1000,-7,-3,-1,27,18,22,23,-2,-8,-4,-9,20,-14,10,11,12,13,-5,-13,-6,-12,17,16,15,9,4,5,22,23,24,25,26,28,29,30,31,27,32,35,-1,18,27,-15,-17,-1,-1,-1,-1,33,-5,4,5,21,-16,-11,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,19,36,34,21,-5,33,21,34,19,-17,-1,-5,-10,-1};
+func MakeEmptyResult ()Result {return Result {Type :ResultTypeEmpty }};var _fagea =[...]int {-1000,-7,-3,-1,27,18,22,23,-2,-8,-4,-9,20,-14,10,11,12,13,-5,-13,-6,-12,17,16,15,9,4,5,37,22,23,24,25,26,28,29,30,31,27,32,35,-1,18,27,-15,-17,-1,-1,-1,-1,33,-5,4,5,21,-16,-11,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,-1,19,36,34,21,-5,33,21,34,19,-17,-1,-5,-10,-1,};
 
 // Reference returns an invalid reference for EmptyExpr.
 func (_dac EmptyExpr )Reference (ctx Context ,ev Evaluator )Reference {return ReferenceInvalid };
@@ -1017,7 +1017,7 @@ func Value (args []Result )Result {if len (args )!=1{return MakeErrorResult ("\u
 func SeriesSum (args []Result )Result {if len (args )!=4{return MakeErrorResult ("\u0053\u0045\u0052\u0049\u0045\u0053\u0053\u0055\u004d\u0028\u0029\u0020\u0072\u0065\u0071u\u0069r\u0065\u0073\u0020\u0034\u0020\u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073");};_edgab :=args [0].AsNumber ();_aebab :=args [1].AsNumber ();_gdacg :=args [2].AsNumber ();_deagf :=args [3].ListValues ();if _edgab .Type !=ResultTypeNumber ||_aebab .Type !=ResultTypeNumber ||_gdacg .Type !=ResultTypeNumber {return MakeErrorResult ("\u0053\u0045\u0052\u0049\u0045\u0053S\u0055\u004d\u0028)\u0020\u0072\u0065q\u0075\u0069\u0072\u0065\u0073\u0020\u0066\u0069\u0072\u0073t\u0020\u0074\u0068\u0072\u0065e \u0061\u0072\u0067\u0075\u006d\u0065\u006e\u0074\u0073\u0020\u0074\u006f\u0020\u0062\u0065\u0020\u006e\u0075\u006d\u0065\u0072\u0069\u0063");};_dccb :=float64 (0);for _dgea ,_badf :=range _deagf {_dccb +=_badf .ValueNumber *_cd .Pow (_edgab .ValueNumber ,_aebab .ValueNumber +float64 (_dgea )*_gdacg .ValueNumber );};return MakeNumberResult (_dccb );};
 
 // Cumprinc implements the Excel CUMPRINC function.
-func Cumprinc (args []Result )Result {_dfgb ,_cace :=_bcb (args ,"\u0043\u0055\u004d\u0050\u0052\u0049\u004e\u0043");if _cace .Type ==ResultTypeError {return _cace ;};_aeca :=_dfgb ._cdee ;_agea :=_dfgb ._dgdf ;_dbgc :=_dfgb ._eeab ;_ecbd :=_dfgb ._bdgg ;_gfaf :=_dfgb ._ceaf ;_ceff :=_dfgb ._fbgd ;_abcb :=_aeda (_aeca ,_agea ,_dbgc ,0,_ceff );_cgea :=0.0;if _ecbd ==1{if _ceff ==0{_cgea =_abcb +_dbgc *_aeca ;}else {_cgea =_abcb ;};_ecbd ++;};for _bfef :=_ecbd ;_bfef <=_gfaf ;_bfef ++{if _ceff ==1{_cgea +=_abcb -(_bdce (_aeca ,_bfef -2,_abcb ,_dbgc ,1)-_abcb )*_aeca ;}else {_cgea +=_abcb -_bdce (_aeca ,_bfef -1,_abcb ,_dbgc ,0)*_aeca ;};};return MakeNumberResult (_cgea );};func (_abfgg *noCache )GetFromCache (key string )(Result ,bool ){return _fcc ,false };const _bdbb =187;type criteriaRegex struct{_bfbg byte ;_cgfbcc string ;};
+func Cumprinc (args []Result )Result {_dfgb ,_cace :=_bcb (args ,"\u0043\u0055\u004d\u0050\u0052\u0049\u004e\u0043");if _cace .Type ==ResultTypeError {return _cace ;};_aeca :=_dfgb ._cdee ;_agea :=_dfgb ._dgdf ;_dbgc :=_dfgb ._eeab ;_ecbd :=_dfgb ._bdgg ;_gfaf :=_dfgb ._ceaf ;_ceff :=_dfgb ._fbgd ;_abcb :=_aeda (_aeca ,_agea ,_dbgc ,0,_ceff );_cgea :=0.0;if _ecbd ==1{if _ceff ==0{_cgea =_abcb +_dbgc *_aeca ;}else {_cgea =_abcb ;};_ecbd ++;};for _bfef :=_ecbd ;_bfef <=_gfaf ;_bfef ++{if _ceff ==1{_cgea +=_abcb -(_bdce (_aeca ,_bfef -2,_abcb ,_dbgc ,1)-_abcb )*_aeca ;}else {_cgea +=_abcb -_bdce (_aeca ,_bfef -1,_abcb ,_dbgc ,0)*_aeca ;};};return MakeNumberResult (_cgea );};func (_abfgg *noCache )GetFromCache (key string )(Result ,bool ){return _fcc ,false };const _bdbb =217;type criteriaRegex struct{_bfbg byte ;_cgfbcc string ;};
 
 // Eval evaluates the binary expression using the context given.
 func (_gb BinaryExpr )Eval (ctx Context ,ev Evaluator )Result {_gbc :=_gb ._ba .Eval (ctx ,ev );if _gbc .Type ==ResultTypeError {return _gbc ;};_caf :=_gb ._af .Eval (ctx ,ev );if _caf .Type ==ResultTypeError {return _caf ;};if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeArray {if !_da (_gbc .ValueArray ,_caf .ValueArray ){return MakeErrorResult ("l\u0068\u0073\u002f\u0072\u0068\u0073 \u0073\u0068\u006f\u0075\u006c\u0064 \u0068\u0061\u0076\u0065\u0020\u0073\u0061m\u0065\u0020\u0064\u0069\u006d\u0065\u006e\u0073\u0069\u006fn\u0073");};return _fe (_gb ._ad ,_gbc .ValueArray ,_caf .ValueArray );}else if _gbc .Type ==ResultTypeList {if len (_gbc .ValueList )!=len (_caf .ValueList ){return MakeErrorResult ("l\u0068\u0073\u002f\u0072\u0068\u0073 \u0073\u0068\u006f\u0075\u006c\u0064 \u0068\u0061\u0076\u0065\u0020\u0073\u0061m\u0065\u0020\u0064\u0069\u006d\u0065\u006e\u0073\u0069\u006fn\u0073");};return _gc (_gb ._ad ,_gbc .ValueList ,_caf .ValueList );};}else if _gbc .Type ==ResultTypeArray &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return _fg (_gb ._ad ,_gbc .ValueArray ,_caf );}else if _gbc .Type ==ResultTypeList &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return _bff (_gb ._ad ,_gbc .ValueList ,_caf );};switch _gb ._ad {case BinOpTypePlus :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeNumberResult (_gbc .ValueNumber +_caf .ValueNumber );};};case BinOpTypeMinus :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeNumberResult (_gbc .ValueNumber -_caf .ValueNumber );};};case BinOpTypeMult :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeNumberResult (_gbc .ValueNumber *_caf .ValueNumber );};};case BinOpTypeDiv :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {if _caf .ValueNumber ==0{return MakeErrorResultType (ErrorTypeDivideByZero ,"\u0064\u0069\u0076\u0069\u0064\u0065\u0020\u0062\u0079 \u007a\u0065\u0072\u006f");};return MakeNumberResult (_gbc .ValueNumber /_caf .ValueNumber );};};case BinOpTypeExp :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeNumberResult (_cd .Pow (_gbc .ValueNumber ,_caf .ValueNumber ));};};case BinOpTypeLT :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeBoolResult (_gbc .ValueNumber < _caf .ValueNumber );};if _gbc .Type ==ResultTypeString {return MakeBoolResult (_gbc .ValueString < _caf .ValueString );};if _gbc .Type ==ResultTypeEmpty {return MakeBoolResult (false );};}else if _gbc .Type ==ResultTypeString &&_caf .Type ==ResultTypeNumber {return MakeBoolResult (false );}else if _gbc .Type ==ResultTypeNumber &&_caf .Type ==ResultTypeString {return MakeBoolResult (true );}else if _gbc .Type ==ResultTypeEmpty &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return MakeBoolResult (true );}else if (_gbc .Type ==ResultTypeNumber ||_gbc .Type ==ResultTypeString )&&_caf .Type ==ResultTypeEmpty {return MakeBoolResult (false );};case BinOpTypeGT :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeBoolResult (_gbc .ValueNumber > _caf .ValueNumber );};if _gbc .Type ==ResultTypeString {return MakeBoolResult (_gbc .ValueString > _caf .ValueString );};if _gbc .Type ==ResultTypeEmpty {return MakeBoolResult (false );};}else if _gbc .Type ==ResultTypeString &&_caf .Type ==ResultTypeNumber {return MakeBoolResult (true );}else if _gbc .Type ==ResultTypeNumber &&_caf .Type ==ResultTypeString {return MakeBoolResult (false );}else if _gbc .Type ==ResultTypeEmpty &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return MakeBoolResult (false );}else if (_gbc .Type ==ResultTypeNumber ||_gbc .Type ==ResultTypeString )&&_caf .Type ==ResultTypeEmpty {return MakeBoolResult (true );};case BinOpTypeEQ :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeBoolResult (_gbc .ValueNumber ==_caf .ValueNumber );};if _gbc .Type ==ResultTypeString {return MakeBoolResult (_gbc .ValueString ==_caf .ValueString );};if _gbc .Type ==ResultTypeEmpty {return MakeBoolResult (true );};}else if (_gbc .Type ==ResultTypeString &&_caf .Type ==ResultTypeNumber )||(_gbc .Type ==ResultTypeNumber &&_caf .Type ==ResultTypeString ){return MakeBoolResult (false );}else if _gbc .Type ==ResultTypeEmpty &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return MakeBoolResult (_efb (_caf ));}else if (_gbc .Type ==ResultTypeNumber ||_gbc .Type ==ResultTypeString )&&_caf .Type ==ResultTypeEmpty {return MakeBoolResult (_efb (_gbc ));};case BinOpTypeNE :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeBoolResult (_gbc .ValueNumber !=_caf .ValueNumber );};if _gbc .Type ==ResultTypeString {return MakeBoolResult (_gbc .ValueString !=_caf .ValueString );};if _gbc .Type ==ResultTypeEmpty {return MakeBoolResult (false );};}else if (_gbc .Type ==ResultTypeString &&_caf .Type ==ResultTypeNumber )||(_gbc .Type ==ResultTypeNumber &&_caf .Type ==ResultTypeString ){return MakeBoolResult (true );}else if _gbc .Type ==ResultTypeEmpty &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return MakeBoolResult (!_efb (_caf ));}else if (_gbc .Type ==ResultTypeNumber ||_gbc .Type ==ResultTypeString )&&_caf .Type ==ResultTypeEmpty {return MakeBoolResult (!_efb (_gbc ));};case BinOpTypeLEQ :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeBoolResult (_gbc .ValueNumber <=_caf .ValueNumber );};if _gbc .Type ==ResultTypeString {return MakeBoolResult (_gbc .ValueString <=_caf .ValueString );};if _gbc .Type ==ResultTypeEmpty {return MakeBoolResult (true );};}else if _gbc .Type ==ResultTypeString &&_caf .Type ==ResultTypeNumber {return MakeBoolResult (false );}else if _gbc .Type ==ResultTypeNumber &&_caf .Type ==ResultTypeString {return MakeBoolResult (true );}else if _gbc .Type ==ResultTypeEmpty &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return MakeBoolResult (_efb (_caf ));}else if (_gbc .Type ==ResultTypeNumber ||_gbc .Type ==ResultTypeString )&&_caf .Type ==ResultTypeEmpty {return MakeBoolResult (_efb (_gbc ));};case BinOpTypeGEQ :if _gbc .Type ==_caf .Type {if _gbc .Type ==ResultTypeNumber {return MakeBoolResult (_gbc .ValueNumber >=_caf .ValueNumber );};if _gbc .Type ==ResultTypeString {return MakeBoolResult (_gbc .ValueString >=_caf .ValueString );};if _gbc .Type ==ResultTypeEmpty {return MakeBoolResult (true );};}else if _gbc .Type ==ResultTypeString &&_caf .Type ==ResultTypeNumber {return MakeBoolResult (true );}else if _gbc .Type ==ResultTypeNumber &&_caf .Type ==ResultTypeString {return MakeBoolResult (false );}else if _gbc .Type ==ResultTypeEmpty &&(_caf .Type ==ResultTypeNumber ||_caf .Type ==ResultTypeString ){return MakeBoolResult (_efb (_caf ));}else if (_gbc .Type ==ResultTypeNumber ||_gbc .Type ==ResultTypeString )&&_caf .Type ==ResultTypeEmpty {return MakeBoolResult (_efb (_gbc ));};case BinOpTypeConcat :return MakeStringResult (_gbc .Value ()+_caf .Value ());};return MakeErrorResult ("u\u006e\u0073\u0075\u0070po\u0072t\u0065\u0064\u0020\u0062\u0069n\u0061\u0072\u0079\u0020\u006f\u0070");};
@@ -1160,7 +1160,7 @@ func Count (args []Result )Result {return MakeNumberResult (_ecgb (args ,_cgegb
 
 // BinOpType is the binary operation operator type
 //go:generate stringer -type=BinOpType
-type BinOpType byte ;func (_bfee *Lexer )emit (_ccca tokenType ,_afggc []byte ){if _daacd {_cb .Println ("\u0065\u006d\u0069\u0074",_ccca ,_gefca (string (_afggc )));};_bfee ._aabcee <-&node {_ccca ,string (_afggc )};};var _cdbaa =[...]int {0,0,71,70,69,4,67,66,53,51,50,49,48,47,46,45,44,2};
+type BinOpType byte ;func (_bfee *Lexer )emit (_ccca tokenType ,_afggc []byte ){if _daacd {_cb .Println ("\u0065\u006d\u0069\u0074",_ccca ,_gefca (string (_afggc )));};_bfee ._aabcee <-&node {_ccca ,string (_afggc )};};var _cdbaa =[...]int {0,0,76,73,72,4,71,70,68,49,48,47,43,42,28,26,25,2,};
 
 // Even is an implementation of the Excel EVEN() that rounds a number to the
 // nearest even integer.
@@ -0,0 +1,40 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// formulaTest is a formula and its expected value, numbers are compared with
+// a small tolerance and other values by their text.
+type formulaTest struct {
+	Inp string
+	Exp string
+}
+
+// runFormulaTests evaluates formulas that don't refer to cells.
+func runFormulaTests(t *testing.T, td []formulaTest) {
+	t.Helper()
+	ev := NewEvaluator()
+	for _, tc := range td {
+		res := ev.Eval(InvalidReferenceContext, tc.Inp)
+		if !resultMatches(res, tc.Exp) {
+			t.Errorf("expected %s = %s, got %q (%s)", tc.Inp, tc.Exp, res.Value(), res.ErrorMessage)
+		}
+	}
+}
+
+func resultMatches(res Result, exp string) bool {
+	if x, err := strconv.ParseFloat(exp, 64); err == nil && res.Type == ResultTypeNumber {
+		return math.Abs(res.ValueNumber-x) <= 1e-9*math.Max(1, math.Abs(x))
+	}
+	return res.Value() == exp
+}
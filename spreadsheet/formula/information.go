@@ -0,0 +1,208 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// WorkbookContext is implemented by contexts that know the sheets of the
+// workbook. SHEET, SHEETS and INFO("numfile") use it, with contexts that don't
+// implement it they evaluate to #N/A.
+type WorkbookContext interface {
+	// SheetIndex returns the 1-based position of the sheet with the given
+	// name, compared case-insensitively, or of the sheet of the context if
+	// name is empty. It returns 0 if there is no such sheet.
+	SheetIndex(name string) int
+	// SheetCount returns the number of sheets of the workbook.
+	SheetCount() int
+}
+
+func init() {
+	RegisterFunctionComplex("INFO", Info)
+	RegisterFunction("TYPE", Type)
+	RegisterFunction("ERROR.TYPE", ErrorTypeOf)
+	RegisterFunction("N", N)
+	RegisterFunctionComplex("SHEET", Sheet)
+	RegisterFunctionComplex("_xlfn.SHEET", Sheet)
+	RegisterFunctionComplex("SHEETS", Sheets)
+	RegisterFunctionComplex("_xlfn.SHEETS", Sheets)
+	// these functions inspect error values or the references of their
+	// arguments instead of returning errors
+	for _, fn := range []string{"TYPE", "ERROR.TYPE", "SHEET", "_xlfn.SHEET", "SHEETS", "_xlfn.SHEETS"} {
+		_aceed[fn] = true
+	}
+}
+
+// Info implements the Excel INFO function for the types directory, numfile,
+// origin, osversion, recalc, release and system.
+func Info(ctx Context, ev Evaluator, args []Result) Result {
+	if len(args) != 1 {
+		return MakeErrorResult("INFO requires one argument")
+	}
+	if args[0].Type != ResultTypeString {
+		return MakeErrorResult("INFO requires a text argument")
+	}
+	switch strings.ToLower(args[0].ValueString) {
+	case "directory":
+		fn := ctx.GetFilename()
+		if fn == "" {
+			return MakeErrorResultType(ErrorTypeNA, "workbook has no file name")
+		}
+		dir, err := filepath.Abs(filepath.Dir(fn))
+		if err != nil {
+			return MakeErrorResultType(ErrorTypeNA, err.Error())
+		}
+		return MakeStringResult(dir + string(filepath.Separator))
+	case "numfile":
+		wc, ok := ctx.(WorkbookContext)
+		if !ok {
+			return MakeErrorResultType(ErrorTypeNA, "number of sheets is not known")
+		}
+		return MakeNumberResult(float64(wc.SheetCount()))
+	case "origin":
+		return MakeStringResult("$A:$A$1")
+	case "osversion":
+		return MakeStringResult(runtime.GOOS + " " + runtime.GOARCH)
+	case "recalc":
+		return MakeStringResult("Automatic")
+	case "release":
+		return MakeStringResult("16.0")
+	case "system":
+		if runtime.GOOS == "darwin" {
+			return MakeStringResult("mac")
+		}
+		return MakeStringResult("pcdos")
+	}
+	return MakeErrorResult("invalid INFO type " + args[0].ValueString)
+}
+
+// Type implements the Excel TYPE function, it returns 1 for numbers and empty
+// values, 2 for text, 4 for booleans, 16 for errors and 64 for arrays.
+func Type(args []Result) Result {
+	if len(args) != 1 {
+		return MakeErrorResult("TYPE requires one argument")
+	}
+	switch r := args[0]; r.Type {
+	case ResultTypeString:
+		return MakeNumberResult(2)
+	case ResultTypeError:
+		return MakeNumberResult(16)
+	case ResultTypeList, ResultTypeArray:
+		return MakeNumberResult(64)
+	case ResultTypeNumber:
+		if r.IsBoolean {
+			return MakeNumberResult(4)
+		}
+	}
+	return MakeNumberResult(1)
+}
+
+var errorTypeNumbers = map[string]float64{
+	"#NULL!":  1,
+	"#DIV/0!": 2,
+	"#VALUE!": 3,
+	"#REF!":   4,
+	"#NAME?":  5,
+	"#NUM!":   6,
+	"#N/A":    7,
+	"#SPILL!": 9,
+}
+
+// ErrorTypeOf implements the Excel ERROR.TYPE function, it returns the number
+// of an error value, 1 for #NULL! to 7 for #N/A, and #N/A for other values.
+func ErrorTypeOf(args []Result) Result {
+	if len(args) != 1 {
+		return MakeErrorResult("ERROR.TYPE requires one argument")
+	}
+	if args[0].Type == ResultTypeError {
+		if n, ok := errorTypeNumbers[args[0].ValueString]; ok {
+			return MakeNumberResult(n)
+		}
+	}
+	return MakeErrorResultType(ErrorTypeNA, "ERROR.TYPE requires an error value")
+}
+
+// N implements the Excel N function, it returns numbers as they are, 1 and 0
+// for booleans, errors as they are and 0 for other values. The first value of
+// a range or array is used.
+func N(args []Result) Result {
+	if len(args) != 1 {
+		return MakeErrorResult("N requires one argument")
+	}
+	r := args[0]
+	if rows := _dacf(r); len(rows) > 0 && len(rows[0]) > 0 {
+		r = rows[0][0]
+	}
+	switch r.Type {
+	case ResultTypeNumber:
+		return MakeNumberResult(r.ValueNumber)
+	case ResultTypeError:
+		return r
+	}
+	return MakeNumberResult(0)
+}
+
+// Sheet implements the Excel SHEET function, it returns the position of the
+// sheet of a reference, of a sheet name or, without arguments, of the sheet of
+// the formula.
+func Sheet(ctx Context, ev Evaluator, args []Result) Result {
+	wc, ok := ctx.(WorkbookContext)
+	if !ok {
+		return MakeErrorResultType(ErrorTypeNA, "sheets are not known")
+	}
+	name := ""
+	switch {
+	case len(args) == 0:
+	case len(args) > 1:
+		return MakeErrorResult("SHEET requires zero or one argument")
+	case args[0].Ref.Type == ReferenceTypeNamedRange:
+		nr := ctx.NamedRange(args[0].Ref.Value)
+		if nr.Type == ReferenceTypeInvalid {
+			return MakeErrorResultType(ErrorTypeRef, "invalid name "+args[0].Ref.Value)
+		}
+		name, _ = splitSheetReference(nr.Value)
+	case args[0].Ref.Type != ReferenceTypeInvalid && args[0].Ref.Type != ReferenceTypeSheet:
+		name, _ = splitSheetReference(args[0].Ref.Value)
+	case args[0].Type == ResultTypeString:
+		name = args[0].ValueString
+		if name == "" {
+			return MakeErrorResultType(ErrorTypeNA, "SHEET requires a sheet name")
+		}
+	case args[0].Type == ResultTypeError:
+		return args[0]
+	default:
+		return MakeErrorResult("SHEET requires a reference or a sheet name")
+	}
+	idx := wc.SheetIndex(name)
+	if idx == 0 {
+		return MakeErrorResultType(ErrorTypeNA, "sheet "+name+" not found")
+	}
+	return MakeNumberResult(float64(idx))
+}
+
+// Sheets implements the Excel SHEETS function, it returns the number of sheets
+// of the workbook, or 1 for a reference as references span a single sheet.
+func Sheets(ctx Context, ev Evaluator, args []Result) Result {
+	switch {
+	case len(args) > 1:
+		return MakeErrorResult("SHEETS requires zero or one argument")
+	case len(args) == 1:
+		if args[0].Ref.Type == ReferenceTypeInvalid {
+			return MakeErrorResultType(ErrorTypeRef, "SHEETS requires a reference")
+		}
+		return MakeNumberResult(1)
+	}
+	wc, ok := ctx.(WorkbookContext)
+	if !ok {
+		return MakeErrorResultType(ErrorTypeNA, "sheets are not known")
+	}
+	return MakeNumberResult(float64(wc.SheetCount()))
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import "testing"
+
+func TestInformationFunctions(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"TYPE(1)", "1"},
+		{`TYPE("a")`, "2"},
+		{"TYPE(TRUE)", "4"},
+		{"TYPE(1/0)", "16"},
+		{"TYPE({1,2})", "64"},
+		{"ERROR.TYPE(1/0)", "2"},
+		{"ERROR.TYPE(NA())", "7"},
+		{`ERROR.TYPE(VALUE("a"))`, "3"},
+		{"ERROR.TYPE(1)", "#N/A"},
+		{"N(5)", "5"},
+		{"N(TRUE)", "1"},
+		{`N("a")`, "0"},
+		{`INFO("recalc")`, "Automatic"},
+		{`INFO("release")`, "16.0"},
+		{`INFO("numfile")`, "#N/A"},
+		{`INFO("bogus")`, "#VALUE!"},
+		// the context doesn't know the sheets of a workbook
+		{"SHEET()", "#N/A"},
+		{"SHEETS()", "#N/A"},
+	})
+}
@@ -0,0 +1,558 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale describes how formulas are written in a localized version of Excel,
+// e.g. =SUMME(A1;1,5) in German for =SUM(A1,1.5). Files always store formulas
+// in the en-US form, and Localize and Delocalize translate between the two.
+type Locale struct {
+	// Name is the language tag of the locale, e.g. "de-DE".
+	Name string
+
+	ArgumentSeparator    string
+	DecimalSeparator     string
+	ArrayColumnSeparator string
+	ArrayRowSeparator    string
+
+	// Functions maps the en-US function names to the localized ones. Functions
+	// that aren't in the map keep their en-US name.
+	Functions map[string]string
+	// Errors maps error values such as #N/A to the localized ones.
+	Errors map[string]string
+	// TableItems maps the item specifiers of structured references such as
+	// #This Row to the localized ones.
+	TableItems  map[string]string
+	True, False string
+}
+
+// futureFunctions are functions that were added in later versions of Excel and
+// are stored with the _xlfn. prefix.
+var futureFunctions = []string{"AGGREGATE", "CONCAT", "DAYS", "IFNA", "IFS", "ISOWEEKNUM", "MAXIFS", "MINIFS", "SWITCH", "TEXTJOIN", "XLOOKUP", "XMATCH"}
+
+var formulaLocales = []*Locale{
+	{
+		Name:              "de-DE",
+		ArgumentSeparator: ";", DecimalSeparator: ",", ArrayColumnSeparator: ".", ArrayRowSeparator: ";",
+		True: "WAHR", False: "FALSCH",
+		Errors:     map[string]string{"#N/A": "#NV", "#VALUE!": "#WERT!", "#REF!": "#BEZUG!", "#NUM!": "#ZAHL!"},
+		TableItems: map[string]string{"#All": "#Alle", "#Data": "#Daten", "#Headers": "#Kopfzeilen", "#Totals": "#Ergebnisse", "#This Row": "#Diese Zeile"},
+		Functions: map[string]string{
+			"ABS": "ABS", "AGGREGATE": "AGGREGAT", "AND": "UND", "AVERAGE": "MITTELWERT", "AVERAGEIF": "MITTELWERTWENN",
+			"AVERAGEIFS": "MITTELWERTWENNS", "CEILING": "OBERGRENZE", "CHAR": "ZEICHEN", "CHOOSE": "WAHL", "CODE": "CODE",
+			"COLUMN": "SPALTE", "COLUMNS": "SPALTEN", "CONCAT": "TEXTKETTE", "CONCATENATE": "VERKETTEN", "COUNT": "ANZAHL",
+			"COUNTA": "ANZAHL2", "COUNTBLANK": "ANZAHLLEEREZELLEN", "COUNTIF": "ZÄHLENWENN", "COUNTIFS": "ZÄHLENWENNS",
+			"DATE": "DATUM", "DAY": "TAG", "DAYS": "TAGE", "EDATE": "EDATUM", "EOMONTH": "MONATSENDE", "EXACT": "IDENTISCH",
+			"FALSE": "FALSCH", "FIND": "FINDEN", "FLOOR": "UNTERGRENZE", "FV": "ZW", "HLOOKUP": "WVERWEIS", "HOUR": "STUNDE",
+			"IF": "WENN", "IFERROR": "WENNFEHLER", "IFNA": "WENNNV", "IFS": "WENNS", "INDEX": "INDEX", "INDIRECT": "INDIREKT",
+			"INT": "GANZZAHL", "IRR": "IKV", "ISBLANK": "ISTLEER", "ISERROR": "ISTFEHLER", "ISNA": "ISTNV",
+			"ISNUMBER": "ISTZAHL", "ISOWEEKNUM": "ISOKALENDERWOCHE", "ISTEXT": "ISTTEXT", "LARGE": "KGRÖSSTE", "LEFT": "LINKS",
+			"LEN": "LÄNGE", "LOOKUP": "VERWEIS", "LOWER": "KLEIN", "MATCH": "VERGLEICH", "MAX": "MAX", "MAXIFS": "MAXWENNS",
+			"MEDIAN": "MEDIAN", "MID": "TEIL", "MIN": "MIN", "MINIFS": "MINWENNS", "MINUTE": "MINUTE", "MOD": "REST",
+			"MONTH": "MONAT", "NA": "NV", "NETWORKDAYS": "NETTOARBEITSTAGE", "NOT": "NICHT", "NOW": "JETZT", "NPV": "NBW",
+			"OFFSET": "BEREICH.VERSCHIEBEN", "OR": "ODER", "PI": "PI", "PMT": "RMZ", "POWER": "POTENZ", "PRODUCT": "PRODUKT",
+			"PROPER": "GROSS2", "PV": "BW", "RAND": "ZUFALLSZAHL", "RANDBETWEEN": "ZUFALLSBEREICH", "RANK": "RANG",
+			"RATE": "ZINS", "REPLACE": "ERSETZEN", "REPT": "WIEDERHOLEN", "RIGHT": "RECHTS", "ROUND": "RUNDEN",
+			"ROUNDDOWN": "ABRUNDEN", "ROUNDUP": "AUFRUNDEN", "ROW": "ZEILE", "ROWS": "ZEILEN", "SEARCH": "SUCHEN",
+			"SECOND": "SEKUNDE", "SMALL": "KKLEINSTE", "SQRT": "WURZEL", "STDEV": "STABW", "SUBSTITUTE": "WECHSELN",
+			"SUBTOTAL": "TEILERGEBNIS", "SUM": "SUMME", "SUMIF": "SUMMEWENN", "SUMIFS": "SUMMEWENNS",
+			"SUMPRODUCT": "SUMMENPRODUKT", "SWITCH": "ERSTERWERT", "TEXT": "TEXT", "TEXTJOIN": "TEXTVERKETTEN",
+			"TODAY": "HEUTE", "TRANSPOSE": "MTRANS", "TRIM": "GLÄTTEN", "TRUE": "WAHR", "TRUNC": "KÜRZEN", "UPPER": "GROSS",
+			"VALUE": "WERT", "VLOOKUP": "SVERWEIS", "WEEKDAY": "WOCHENTAG", "WEEKNUM": "KALENDERWOCHE",
+			"WORKDAY": "ARBEITSTAG", "XLOOKUP": "XVERWEIS", "YEAR": "JAHR",
+		},
+	},
+	{
+		Name:              "fr-FR",
+		ArgumentSeparator: ";", DecimalSeparator: ",", ArrayColumnSeparator: ".", ArrayRowSeparator: ";",
+		True: "VRAI", False: "FAUX",
+		Errors:     map[string]string{"#VALUE!": "#VALEUR!", "#NUM!": "#NOMBRE!", "#NAME?": "#NOM?", "#NULL!": "#NUL!"},
+		TableItems: map[string]string{"#All": "#Tout", "#Data": "#Données", "#Headers": "#En-têtes", "#Totals": "#Totaux", "#This Row": "#Cette ligne"},
+		Functions: map[string]string{
+			"ABS": "ABS", "AGGREGATE": "AGREGAT", "AND": "ET", "AVERAGE": "MOYENNE", "AVERAGEIF": "MOYENNE.SI",
+			"AVERAGEIFS": "MOYENNE.SI.ENS", "CEILING": "PLAFOND", "CHAR": "CAR", "CHOOSE": "CHOISIR", "CODE": "CODE",
+			"COLUMN": "COLONNE", "COLUMNS": "COLONNES", "CONCAT": "CONCAT", "CONCATENATE": "CONCATENER", "COUNT": "NB",
+			"COUNTA": "NBVAL", "COUNTBLANK": "NB.VIDE", "COUNTIF": "NB.SI", "COUNTIFS": "NB.SI.ENS", "DATE": "DATE",
+			"DAY": "JOUR", "DAYS": "JOURS", "EDATE": "MOIS.DECALER", "EOMONTH": "FIN.MOIS", "EXACT": "EXACT", "FALSE": "FAUX",
+			"FIND": "TROUVE", "FLOOR": "PLANCHER", "FV": "VC", "HLOOKUP": "RECHERCHEH", "HOUR": "HEURE", "IF": "SI",
+			"IFERROR": "SIERREUR", "IFNA": "SI.NON.DISP", "IFS": "SI.CONDITIONS", "INDEX": "INDEX", "INDIRECT": "INDIRECT",
+			"INT": "ENT", "IRR": "TRI", "ISBLANK": "ESTVIDE", "ISERROR": "ESTERREUR", "ISNA": "ESTNA", "ISNUMBER": "ESTNUM",
+			"ISOWEEKNUM": "NO.SEMAINE.ISO", "ISTEXT": "ESTTEXTE", "LARGE": "GRANDE.VALEUR", "LEFT": "GAUCHE", "LEN": "NBCAR",
+			"LOOKUP": "RECHERCHE", "LOWER": "MINUSCULE", "MATCH": "EQUIV", "MAX": "MAX", "MAXIFS": "MAX.SI.ENS",
+			"MEDIAN": "MEDIANE", "MID": "STXT", "MIN": "MIN", "MINIFS": "MIN.SI.ENS", "MINUTE": "MINUTE", "MOD": "MOD",
+			"MONTH": "MOIS", "NA": "NA", "NETWORKDAYS": "NB.JOURS.OUVRES", "NOT": "NON", "NOW": "MAINTENANT", "NPV": "VAN",
+			"OFFSET": "DECALER", "OR": "OU", "PI": "PI", "PMT": "VPM", "POWER": "PUISSANCE", "PRODUCT": "PRODUIT",
+			"PROPER": "NOMPROPRE", "PV": "VA", "RAND": "ALEA", "RANDBETWEEN": "ALEA.ENTRE.BORNES", "RANK": "RANG",
+			"RATE": "TAUX", "REPLACE": "REMPLACER", "REPT": "REPT", "RIGHT": "DROITE", "ROUND": "ARRONDI",
+			"ROUNDDOWN": "ARRONDI.INF", "ROUNDUP": "ARRONDI.SUP", "ROW": "LIGNE", "ROWS": "LIGNES", "SEARCH": "CHERCHE",
+			"SECOND": "SECONDE", "SMALL": "PETITE.VALEUR", "SQRT": "RACINE", "STDEV": "ECARTYPE", "SUBSTITUTE": "SUBSTITUE",
+			"SUBTOTAL": "SOUS.TOTAL", "SUM": "SOMME", "SUMIF": "SOMME.SI", "SUMIFS": "SOMME.SI.ENS",
+			"SUMPRODUCT": "SOMMEPROD", "SWITCH": "SI.MULTIPLE", "TEXT": "TEXTE", "TEXTJOIN": "JOINDRE.TEXTE",
+			"TODAY": "AUJOURDHUI", "TRANSPOSE": "TRANSPOSE", "TRIM": "SUPPRESPACE", "TRUE": "VRAI", "TRUNC": "TRONQUE",
+			"UPPER": "MAJUSCULE", "VALUE": "CNUM", "VLOOKUP": "RECHERCHEV", "WEEKDAY": "JOURSEM", "WEEKNUM": "NO.SEMAINE",
+			"WORKDAY": "SERIE.JOUR.OUVRE", "XLOOKUP": "RECHERCHEX", "YEAR": "ANNEE",
+		},
+	},
+	{
+		Name:              "es-ES",
+		ArgumentSeparator: ";", DecimalSeparator: ",", ArrayColumnSeparator: "\\", ArrayRowSeparator: ";",
+		True: "VERDADERO", False: "FALSO",
+		Errors:     map[string]string{"#VALUE!": "#¡VALOR!", "#REF!": "#¡REF!", "#DIV/0!": "#¡DIV/0!", "#NUM!": "#¡NUM!", "#NAME?": "#¿NOMBRE?", "#NULL!": "#¡NULO!"},
+		TableItems: map[string]string{"#All": "#Todo", "#Data": "#Datos", "#Headers": "#Encabezados", "#Totals": "#Totales", "#This Row": "#Esta fila"},
+		Functions: map[string]string{
+			"ABS": "ABS", "AGGREGATE": "AGREGAR", "AND": "Y", "AVERAGE": "PROMEDIO", "AVERAGEIF": "PROMEDIO.SI",
+			"AVERAGEIFS": "PROMEDIO.SI.CONJUNTO", "CEILING": "MULTIPLO.SUPERIOR", "CHAR": "CARACTER", "CHOOSE": "ELEGIR",
+			"CODE": "CODIGO", "COLUMN": "COLUMNA", "COLUMNS": "COLUMNAS", "CONCAT": "CONCAT", "CONCATENATE": "CONCATENAR",
+			"COUNT": "CONTAR", "COUNTA": "CONTARA", "COUNTBLANK": "CONTAR.BLANCO", "COUNTIF": "CONTAR.SI",
+			"COUNTIFS": "CONTAR.SI.CONJUNTO", "DATE": "FECHA", "DAY": "DIA", "DAYS": "DIAS", "EDATE": "FECHA.MES",
+			"EOMONTH": "FIN.MES", "EXACT": "IGUAL", "FALSE": "FALSO", "FIND": "ENCONTRAR", "FLOOR": "MULTIPLO.INFERIOR",
+			"FV": "VF", "HLOOKUP": "BUSCARH", "HOUR": "HORA", "IF": "SI", "IFERROR": "SI.ERROR", "IFNA": "SI.ND",
+			"IFS": "SI.CONJUNTO", "INDEX": "INDICE", "INDIRECT": "INDIRECTO", "INT": "ENTERO", "IRR": "TIR",
+			"ISBLANK": "ESBLANCO", "ISERROR": "ESERROR", "ISNA": "ESNOD", "ISNUMBER": "ESNUMERO",
+			"ISOWEEKNUM": "ISO.NUM.DE.SEMANA", "ISTEXT": "ESTEXTO", "LARGE": "K.ESIMO.MAYOR", "LEFT": "IZQUIERDA",
+			"LEN": "LARGO", "LOOKUP": "BUSCAR", "LOWER": "MINUSC", "MATCH": "COINCIDIR", "MAX": "MAX",
+			"MAXIFS": "MAX.SI.CONJUNTO", "MEDIAN": "MEDIANA", "MID": "EXTRAE", "MIN": "MIN", "MINIFS": "MIN.SI.CONJUNTO",
+			"MINUTE": "MINUTO", "MOD": "RESIDUO", "MONTH": "MES", "NA": "NOD", "NETWORKDAYS": "DIAS.LAB", "NOT": "NO",
+			"NOW": "AHORA", "NPV": "VNA", "OFFSET": "DESREF", "OR": "O", "PI": "PI", "PMT": "PAGO", "POWER": "POTENCIA",
+			"PRODUCT": "PRODUCTO", "PROPER": "NOMPROPIO", "PV": "VA", "RAND": "ALEATORIO", "RANDBETWEEN": "ALEATORIO.ENTRE",
+			"RANK": "JERARQUIA", "RATE": "TASA", "REPLACE": "REEMPLAZAR", "REPT": "REPETIR", "RIGHT": "DERECHA",
+			"ROUND": "REDONDEAR", "ROUNDDOWN": "REDONDEAR.MENOS", "ROUNDUP": "REDONDEAR.MAS", "ROW": "FILA", "ROWS": "FILAS",
+			"SEARCH": "HALLAR", "SECOND": "SEGUNDO", "SMALL": "K.ESIMO.MENOR", "SQRT": "RAIZ", "STDEV": "DESVEST",
+			"SUBSTITUTE": "SUSTITUIR", "SUBTOTAL": "SUBTOTALES", "SUM": "SUMA", "SUMIF": "SUMAR.SI",
+			"SUMIFS": "SUMAR.SI.CONJUNTO", "SUMPRODUCT": "SUMAPRODUCTO", "SWITCH": "CAMBIAR", "TEXT": "TEXTO",
+			"TEXTJOIN": "UNIRCADENAS", "TODAY": "HOY", "TRANSPOSE": "TRANSPONER", "TRIM": "ESPACIOS", "TRUE": "VERDADERO",
+			"TRUNC": "TRUNCAR", "UPPER": "MAYUSC", "VALUE": "VALOR", "VLOOKUP": "BUSCARV", "WEEKDAY": "DIASEM",
+			"WEEKNUM": "NUM.DE.SEMANA", "WORKDAY": "DIA.LAB", "XLOOKUP": "BUSCARX", "YEAR": "AÑO",
+		},
+	},
+	{
+		Name:              "it-IT",
+		ArgumentSeparator: ";", DecimalSeparator: ",", ArrayColumnSeparator: ".", ArrayRowSeparator: ";",
+		True: "VERO", False: "FALSO",
+		Errors:     map[string]string{"#N/A": "#N/D", "#VALUE!": "#VALORE!", "#REF!": "#RIF!", "#NAME?": "#NOME?", "#NULL!": "#NULLO!"},
+		TableItems: map[string]string{"#All": "#Tutti", "#Data": "#Dati", "#Headers": "#Intestazioni", "#Totals": "#Totali", "#This Row": "#Questa riga"},
+		Functions: map[string]string{
+			"ABS": "ASS", "AGGREGATE": "AGGREGA", "AND": "E", "AVERAGE": "MEDIA", "AVERAGEIF": "MEDIA.SE",
+			"AVERAGEIFS": "MEDIA.PIÙ.SE", "CEILING": "ARROTONDA.ECCESSO", "CHAR": "CODICE.CARATT", "CHOOSE": "SCEGLI",
+			"CODE": "CODICE", "COLUMN": "RIF.COLONNA", "COLUMNS": "COLONNE", "CONCAT": "CONCAT", "CONCATENATE": "CONCATENA",
+			"COUNT": "CONTA.NUMERI", "COUNTA": "CONTA.VALORI", "COUNTBLANK": "CONTA.VUOTE", "COUNTIF": "CONTA.SE",
+			"COUNTIFS": "CONTA.PIÙ.SE", "DATE": "DATA", "DAY": "GIORNO", "DAYS": "GIORNI", "EDATE": "DATA.MESE",
+			"EOMONTH": "FINE.MESE", "EXACT": "IDENTICO", "FALSE": "FALSO", "FIND": "TROVA", "FLOOR": "ARROTONDA.DIFETTO",
+			"FV": "VAL.FUT", "HLOOKUP": "CERCA.ORIZZ", "HOUR": "ORA", "IF": "SE", "IFERROR": "SE.ERRORE",
+			"IFNA": "SE.NON.DISP.", "IFS": "PIÙ.SE", "INDEX": "INDICE", "INDIRECT": "INDIRETTO", "INT": "INT",
+			"IRR": "TIR.COST", "ISBLANK": "VAL.VUOTO", "ISERROR": "VAL.ERRORE", "ISNA": "VAL.NON.DISP",
+			"ISNUMBER": "VAL.NUMERO", "ISOWEEKNUM": "NUM.SETTIMANA.ISO", "ISTEXT": "VAL.TESTO", "LARGE": "GRANDE",
+			"LEFT": "SINISTRA", "LEN": "LUNGHEZZA", "LOOKUP": "CERCA", "LOWER": "MINUSC", "MATCH": "CONFRONTA", "MAX": "MAX",
+			"MAXIFS": "MAX.PIÙ.SE", "MEDIAN": "MEDIANA", "MID": "STRINGA.ESTRAI", "MIN": "MIN", "MINIFS": "MIN.PIÙ.SE",
+			"MINUTE": "MINUTO", "MOD": "RESTO", "MONTH": "MESE", "NA": "NON.DISP", "NETWORKDAYS": "GIORNI.LAVORATIVI.TOT",
+			"NOT": "NON", "NOW": "ADESSO", "NPV": "VAN", "OFFSET": "SCARTO", "OR": "O", "PI": "PI.GRECO", "PMT": "RATA",
+			"POWER": "POTENZA", "PRODUCT": "PRODOTTO", "PROPER": "MAIUSC.INIZ", "PV": "VA", "RAND": "CASUALE",
+			"RANDBETWEEN": "CASUALE.TRA", "RANK": "RANGO", "RATE": "TASSO", "REPLACE": "RIMPIAZZA", "REPT": "RIPETI",
+			"RIGHT": "DESTRA", "ROUND": "ARROTONDA", "ROUNDDOWN": "ARROTONDA.PER.DIF", "ROUNDUP": "ARROTONDA.PER.ECC",
+			"ROW": "RIF.RIGA", "ROWS": "RIGHE", "SEARCH": "RICERCA", "SECOND": "SECONDO", "SMALL": "PICCOLO",
+			"SQRT": "RADQ", "STDEV": "DEV.ST", "SUBSTITUTE": "SOSTITUISCI", "SUBTOTAL": "SUBTOTALE", "SUM": "SOMMA",
+			"SUMIF": "SOMMA.SE", "SUMIFS": "SOMMA.PIÙ.SE", "SUMPRODUCT": "MATR.SOMMA.PRODOTTO", "SWITCH": "SWITCH",
+			"TEXT": "TESTO", "TEXTJOIN": "TESTO.UNISCI", "TODAY": "OGGI", "TRANSPOSE": "MATR.TRASPOSTA",
+			"TRIM": "ANNULLA.SPAZI", "TRUE": "VERO", "TRUNC": "TRONCA", "UPPER": "MAIUSC", "VALUE": "VALORE",
+			"VLOOKUP": "CERCA.VERT", "WEEKDAY": "GIORNO.SETTIMANA", "WEEKNUM": "NUM.SETTIMANA",
+			"WORKDAY": "GIORNO.LAVORATIVO", "XLOOKUP": "CERCA.X", "YEAR": "ANNO",
+		},
+	},
+}
+
+// Locales returns the built-in formula locales.
+func Locales() []*Locale {
+	return append([]*Locale(nil), formulaLocales...)
+}
+
+// LookupLocale returns the built-in formula locale with a language tag such
+// as "de-DE" or a language such as "de", or nil if there is no such locale.
+func LookupLocale(name string) *Locale {
+	name = strings.Replace(name, "_", "-", -1)
+	for _, l := range formulaLocales {
+		if strings.EqualFold(l.Name, name) || strings.EqualFold(strings.SplitN(l.Name, "-", 2)[0], name) {
+			return l
+		}
+	}
+	return nil
+}
+
+// Localize translates a formula from the en-US form stored in files to the
+// localized form, e.g. SUM(A1,1.5) to SUMME(A1;1,5) for de-DE. String
+// literals, sheet names, defined names and references are left untouched.
+func Localize(formula string, loc *Locale) string {
+	if loc == nil {
+		return formula
+	}
+	t := translation{
+		argument:  ",",
+		decimal:   ".",
+		column:    ",",
+		row:       ";",
+		functions: loc.Functions,
+		errors:    loc.Errors,
+		items:     upperKeys(loc.TableItems),
+		booleans:  map[string]string{"TRUE": loc.True, "FALSE": loc.False},
+		to:        loc,
+	}
+	t.stripPrefix = true
+	return t.translate(formula)
+}
+
+// Delocalize translates a formula from the localized form to the en-US form
+// stored in files, e.g. SUMME(A1;1,5) to SUM(A1,1.5) for de-DE. Function
+// names that were added in later versions of Excel get the _xlfn. prefix.
+// The result can be passed to Cell.SetFormulaRaw.
+func Delocalize(formula string, loc *Locale) string {
+	if loc == nil {
+		return formula
+	}
+	t := translation{
+		argument:  loc.ArgumentSeparator,
+		decimal:   loc.DecimalSeparator,
+		column:    loc.ArrayColumnSeparator,
+		row:       loc.ArrayRowSeparator,
+		functions: invert(loc.Functions),
+		errors:    invert(loc.Errors),
+		items:     invert(loc.TableItems),
+		booleans:  map[string]string{strings.ToUpper(loc.True): "TRUE", strings.ToUpper(loc.False): "FALSE"},
+		to:        &Locale{ArgumentSeparator: ",", DecimalSeparator: ".", ArrayColumnSeparator: ",", ArrayRowSeparator: ";"},
+	}
+	t.addPrefix = true
+	return t.translate(formula)
+}
+
+func invert(m map[string]string) map[string]string {
+	inv := make(map[string]string, len(m))
+	for k, v := range m {
+		inv[strings.ToUpper(v)] = k
+	}
+	return inv
+}
+
+func upperKeys(m map[string]string) map[string]string {
+	up := make(map[string]string, len(m))
+	for k, v := range m {
+		up[strings.ToUpper(k)] = v
+	}
+	return up
+}
+
+// translation translates a formula from one form to another.
+type translation struct {
+	// argument, decimal, column and row are the separators of the formula
+	argument, decimal, column, row string
+	// functions, errors, items and booleans map upper case names of the
+	// formula
+	functions, errors, items, booleans map[string]string
+	// to holds the separators of the result
+	to *Locale
+
+	stripPrefix, addPrefix bool
+}
+
+// errorValues are the error values of en-US formulas.
+var errorValues = []string{"#NULL!", "#DIV/0!", "#VALUE!", "#REF!", "#NAME?", "#NUM!", "#N/A", "#GETTING_DATA"}
+
+// translate translates the tokens of a formula one by one. Tokens that don't
+// depend on the locale, such as strings, sheet names and references, are
+// copied as is.
+func (t translation) translate(f string) string {
+	sb := strings.Builder{}
+	arrays := 0
+	for _, tok := range t.tokenize(f) {
+		text := tok._acfe
+		switch tok._dgfdea {
+		case tokenLBrace:
+			arrays++
+		case tokenRBrace:
+			arrays--
+		case tokenComma:
+			if arrays > 0 {
+				text = t.to.ArrayColumnSeparator
+			} else {
+				text = t.to.ArgumentSeparator
+			}
+		case tokenSemi:
+			text = t.to.ArrayRowSeparator
+		case tokenNumber:
+			text = t.number(text)
+		case tokenError:
+			if e, ok := t.errors[strings.ToUpper(text)]; ok {
+				text = e
+			}
+		case tokenBool:
+			text = t.booleans[strings.ToUpper(text)]
+		case tokenFunctionBuiltin:
+			text = t.function(text)
+		case tokenStructuredRef:
+			text = t.structuredRef(text)
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// The tokens of the formula lexer that translations distinguish.
+const (
+	tokenNamedRange      tokenType = _eagf
+	tokenBool            tokenType = _ccdba
+	tokenNumber          tokenType = _cdggf
+	tokenString          tokenType = _cbgbb
+	tokenError           tokenType = _eecea
+	tokenSheet           tokenType = _debg
+	tokenFunctionBuiltin tokenType = _ecbb
+	tokenLBrace          tokenType = _gddd
+	tokenRBrace          tokenType = _fgeg
+	tokenLParen          tokenType = _badd
+	tokenRParen          tokenType = _ecbfa
+	tokenComma           tokenType = _cbdf
+	tokenSemi            tokenType = _cbfef
+)
+
+// tokenize splits a formula into the tokens of the formula lexer, keeping the
+// text of each token as it appears in the formula. Unlike the lexer it reads
+// localized formulas, using the separators of the translation to tell them
+// from numbers, and returns the text between tokens such as white space as
+// tokens of type 0. Comma tokens stand for argument and array column
+// separators and semicolon tokens for array row separators.
+func (t translation) tokenize(f string) []*node {
+	var toks []*node
+	// the references are found as the lexer finds them, but the item
+	// specifiers of structured references may be localized
+	refs := refTokens(f, nil)
+	arrays := 0
+	for i := 0; i < len(f); {
+		if len(refs) > 0 && refs[0].start == i {
+			toks = append(toks, &node{refs[0].typ, f[i:refs[0].end]})
+			i = refs[0].end
+			refs = refs[1:]
+			continue
+		}
+		c := f[i]
+		typ, n := tokenType(0), 1
+		switch {
+		case c == '"' || c == '\'':
+			end := closingQuote(f, i)
+			if end < 0 {
+				end = len(f) - 1
+			}
+			typ, n = tokenString, end+1-i
+			if c == '\'' {
+				typ = tokenSheet
+			}
+		case c == '[':
+			// brackets that aren't part of a reference
+			end := closingBracket(f, i)
+			if end < 0 {
+				end = len(f) - 1
+			}
+			n = end + 1 - i
+		case c == '{':
+			typ = tokenLBrace
+			arrays++
+		case c == '}':
+			typ = tokenRBrace
+			arrays--
+		case c == '(':
+			typ = tokenLParen
+		case c == ')':
+			typ = tokenRParen
+		case c == '#':
+			if l := t.errorValue(f[i:]); l > 0 {
+				typ, n = tokenError, l
+			}
+		case arrays > 0 && strings.HasPrefix(f[i:], t.column):
+			typ, n = tokenComma, len(t.column)
+		case arrays > 0 && strings.HasPrefix(f[i:], t.row):
+			typ, n = tokenSemi, len(t.row)
+		case arrays == 0 && strings.HasPrefix(f[i:], t.argument):
+			typ, n = tokenComma, len(t.argument)
+		case (i == 0 || !isNameChar(f[i-1])) && (isDigit(c) || t.isDecimal(f[i:])):
+			typ, n = tokenNumber, t.numberLength(f[i:])
+		case isNameStart(f, i):
+			typ, n = t.name(f, i)
+		}
+		toks = append(toks, &node{typ, f[i : i+n]})
+		i += n
+	}
+	return toks
+}
+
+// isDecimal returns true if f starts with a decimal separator followed by a
+// digit.
+func (t translation) isDecimal(f string) bool {
+	return strings.HasPrefix(f, t.decimal) && len(f) > len(t.decimal) && isDigit(f[len(t.decimal)])
+}
+
+// numberLength returns the length of the number literal at the start of f.
+func (t translation) numberLength(f string) int {
+	i := 0
+	for i < len(f) && isDigit(f[i]) {
+		i++
+	}
+	if t.isDecimal(f[i:]) {
+		i += len(t.decimal)
+		for i < len(f) && isDigit(f[i]) {
+			i++
+		}
+	}
+	// the exponent, e.g. 1E+3
+	if i+2 < len(f) && (f[i] == 'E' || f[i] == 'e') && (f[i+1] == '+' || f[i+1] == '-') && isDigit(f[i+2]) {
+		i += 2
+		for i < len(f) && isDigit(f[i]) {
+			i++
+		}
+	}
+	return i
+}
+
+// number replaces the decimal separator of a number literal.
+func (t translation) number(n string) string {
+	return strings.Replace(n, t.decimal, t.to.DecimalSeparator, 1)
+}
+
+// name returns the type and the length of the function name, boolean, sheet
+// name or other name such as a reference or defined name starting at index i.
+func (t translation) name(f string, i int) (tokenType, int) {
+	end := i
+	for end < len(f) {
+		r, size := utf8.DecodeRuneInString(f[end:])
+		if r < utf8.RuneSelf && !isNameChar(byte(r)) || r >= utf8.RuneSelf && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		end += size
+	}
+	next := byte(0)
+	if end < len(f) {
+		next = f[end]
+	}
+	switch {
+	case next == '(':
+		return tokenFunctionBuiltin, end - i
+	case next == '!':
+		return tokenSheet, end - i
+	}
+	if b, ok := t.booleans[strings.ToUpper(f[i:end])]; ok && b != "" {
+		return tokenBool, end - i
+	}
+	return tokenNamedRange, end - i
+}
+
+// structuredRef translates the item specifiers of a structured reference,
+// e.g. Sales[[#This Row],[Qty]] to Sales[[#Diese Zeile];[Qty]] for de-DE,
+// and the separators between its bracketed items. Column names are copied as
+// is.
+func (t translation) structuredRef(ref string) string {
+	sb := strings.Builder{}
+	depth := 0
+	for i := 0; i < len(ref); i++ {
+		c := ref[i]
+		switch {
+		case depth > 0 && c == '\'' && i+1 < len(ref):
+			// an escaped character of a column name
+			sb.WriteString(ref[i : i+2])
+			i++
+			continue
+		case c == '[' && strings.HasPrefix(ref[i+1:], "#"):
+			if end := strings.IndexByte(ref[i:], ']'); end > 0 {
+				item := ref[i+1 : i+end]
+				if repl, ok := t.items[strings.ToUpper(item)]; ok {
+					item = repl
+				}
+				sb.WriteString("[" + item + "]")
+				i += end
+				continue
+			}
+			depth++
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case depth == 1 && strings.HasPrefix(ref[i:], t.argument) &&
+			strings.HasPrefix(strings.TrimLeft(ref[i+len(t.argument):], " "), "["):
+			sb.WriteString(t.to.ArgumentSeparator)
+			i += len(t.argument) - 1
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// function translates a function name, handling the prefixes of functions
+// added in later versions of Excel.
+func (t translation) function(name string) string {
+	prefix := ""
+	bare := name
+	for _, p := range []string{"_xlfn._xlws.", "_xlfn.", "_xlws."} {
+		if len(name) > len(p) && strings.EqualFold(name[:len(p)], p) {
+			prefix, bare = name[:len(p)], name[len(p):]
+			break
+		}
+	}
+	repl, ok := t.functions[strings.ToUpper(bare)]
+	if !ok {
+		return name
+	}
+	switch {
+	case t.stripPrefix:
+		return repl
+	case t.addPrefix && prefix == "" && isFutureFunction(repl):
+		return "_xlfn." + repl
+	}
+	return prefix + repl
+}
+
+func isFutureFunction(name string) bool {
+	for _, fn := range futureFunctions {
+		if fn == name {
+			return true
+		}
+	}
+	for _, fn := range SupportedFunctions() {
+		if strings.HasPrefix(fn, "_xlfn.") && fn[len("_xlfn."):] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// errorValue returns the length of the error value at the start of f, or 0 if
+// there is none.
+func (t translation) errorValue(f string) int {
+	best := ""
+	for from := range t.errors {
+		if len(from) > len(best) && len(f) >= len(from) && strings.EqualFold(f[:len(from)], from) {
+			best = from
+		}
+	}
+	for _, from := range errorValues {
+		if len(from) > len(best) && strings.HasPrefix(f, from) {
+			best = from
+		}
+	}
+	return len(best)
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c) || c == '_' || c == '.' || c == '\\' || c == '$' || c == '?' || c >= utf8.RuneSelf
+}
+
+// isNameStart returns true if a name, function or reference starts at index i.
+func isNameStart(f string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(f[i:])
+	return r == '_' || r == '\\' || r == '$' || unicode.IsLetter(r)
+}
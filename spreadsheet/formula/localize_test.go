@@ -0,0 +1,123 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	td := []struct {
+		Locale, Inp, Exp string
+	}{
+		{"de-DE", "SUM(A1,1.5)", "SUMME(A1;1,5)"},
+		{"de-DE", `IF(A1>0, "a,b", FALSE)`, `WENN(A1>0; "a,b"; FALSCH)`},
+		{"de-DE", "{1.5,2;3,4}", "{1,5.2;3.4}"},
+		{"de-DE", "IFERROR(1/0,#N/A)+#DIV/0!", "WENNFEHLER(1/0;#NV)+#DIV/0!"},
+		{"de-DE", "_xlfn.CONCAT('My, Sheet'!A1,Data!B2)", "TEXTKETTE('My, Sheet'!A1;Data!B2)"},
+		{"de-DE", "1.5E+3*TRUE", "1,5E+3*WAHR"},
+		{"de-DE", "SUM([1]Sheet1!A1,'C:\\[Book2.xlsx]A,B'!B2,[1]!Rate)", "SUMME([1]Sheet1!A1;'C:\\[Book2.xlsx]A,B'!B2;[1]!Rate)"},
+		{"de-DE", "SUM(Table1[[#This Row],[a,b]])", "SUMME(Table1[[#Diese Zeile];[a,b]])"},
+		{"de-DE", "Sales[[#Headers],[#Data],[Qty]:[Price]]", "Sales[[#Kopfzeilen];[#Daten];[Qty]:[Price]]"},
+		{"de-DE", "SUM(Sales[#Totals],[@Qty])", "SUMME(Sales[#Ergebnisse];[@Qty])"},
+		{"de-DE", "Sales[[#All], [x'[1']]]", "Sales[[#Alle]; [x'[1']]]"},
+		{"fr-FR", "SUM(Table1[[#This Row],[a,b]])", "SOMME(Table1[[#Cette ligne];[a,b]])"},
+		{"fr-FR", "COUNTIF(Sales[#Headers],#VALUE!)", "NB.SI(Sales[#En-têtes];#VALEUR!)"},
+		{"es-ES", "SUM(Table1[[#This Row],[a,b]])", "SUMA(Table1[[#Esta fila];[a,b]])"},
+		{"es-ES", "{1,2;3.5,4}+YEAR(1)", "{1\\2;3,5\\4}+AÑO(1)"},
+		{"it-IT", "SUM(Table1[[#This Row],[a,b]])", "SOMMA(Table1[[#Questa riga];[a,b]])"},
+		{"it-IT", "SUMIFS(Sales[[#Totals],[Qty]],A1,\">1\")", "SOMMA.PIÙ.SE(Sales[[#Totali];[Qty]];A1;\">1\")"},
+	}
+	for _, tc := range td {
+		loc := LookupLocale(tc.Locale)
+		if loc == nil {
+			t.Fatalf("expected the locale %s", tc.Locale)
+		}
+		if got := Localize(tc.Inp, loc); got != tc.Exp {
+			t.Errorf("%s: expected %s to localize to %s, got %s", tc.Locale, tc.Inp, tc.Exp, got)
+		}
+		if got := Delocalize(tc.Exp, loc); got != tc.Inp {
+			t.Errorf("%s: expected %s to delocalize to %s, got %s", tc.Locale, tc.Exp, tc.Inp, got)
+		}
+	}
+}
+
+func TestDelocalizeCase(t *testing.T) {
+	loc := LookupLocale("de")
+	td := []struct {
+		Inp, Exp string
+	}{
+		{"summe(Table1[[#diese zeile];[a]])", "SUM(Table1[[#This Row],[a]])"},
+		{"wahr+Falsch", "TRUE+FALSE"},
+		{"TEXTKETTE(1;2)", "_xlfn.CONCAT(1,2)"},
+	}
+	for _, tc := range td {
+		if got := Delocalize(tc.Inp, loc); got != tc.Exp {
+			t.Errorf("expected %s to delocalize to %s, got %s", tc.Inp, tc.Exp, got)
+		}
+	}
+}
+
+// TestTokenizeLikeLexer compares the tokens that translations distinguish with
+// the tokens of the formula lexer for en-US formulas.
+func TestTokenizeLikeLexer(t *testing.T) {
+	compared := map[tokenType]bool{tokenBool: true, tokenNumber: true, tokenString: true, tokenError: true, tokenSheet: true,
+		tokenFunctionBuiltin: true, tokenLBrace: true, tokenRBrace: true, tokenRParen: true, tokenComma: true, tokenSemi: true,
+		tokenStructuredRef: true}
+	tr := translation{argument: ",", decimal: ".", column: ",", row: ";", booleans: map[string]string{"TRUE": "TRUE", "FALSE": "FALSE"}}
+	for _, f := range []string{
+		"SUM(A1,1.5)",
+		`IF(A1>0,"a,""b""",FALSE)`,
+		"{1.5,2;3,4}",
+		"IFERROR(1/0,#N/A)+#REF!",
+		"_xlfn.CONCAT('My, Sheet'!A1,Data!B2)",
+		"'C:\\Data\\[Book2.xlsx]My Sheet'!A1*TRUE",
+		"SUM(Table1[[#This Row],[a,b]],[@Qty])",
+		"[1]Sheet1!$A$1+[1]!Rate",
+		"ROUND(A1,2)&\"x\"",
+		"-0.25*Rate^2",
+		"Sheet1!A1:B2",
+		"MAX(1,MIN(2,3))",
+	} {
+		var lexed []string
+		for n := range LexReader(strings.NewReader(f)) {
+			if n._dgfdea == generatedToken("tokenLexError") {
+				t.Errorf("error lexing %s", f)
+			}
+			if n._dgfdea == generatedToken("tokenErrorRef") {
+				// translations treat #REF! as any other error value
+				n._dgfdea = tokenError
+			}
+			if compared[n._dgfdea] {
+				lexed = append(lexed, fmt.Sprintf("%d %s", n._dgfdea, n._acfe))
+			}
+		}
+		var toks []string
+		for _, n := range tr.tokenize(f) {
+			text := n._acfe
+			switch n._dgfdea {
+			case tokenString:
+				// the lexer drops the quotes of strings and sheet names
+				text = text[1 : len(text)-1]
+			case tokenSheet:
+				text = strings.TrimSuffix(text, "!")
+				if strings.HasPrefix(text, "'") {
+					text = strings.Replace(text[1:len(text)-1], "''", "'", -1)
+				}
+			}
+			if compared[n._dgfdea] {
+				toks = append(toks, fmt.Sprintf("%d %s", n._dgfdea, text))
+			}
+		}
+		if strings.Join(toks, "|") != strings.Join(lexed, "|") {
+			t.Errorf("%s: expected the tokens %q, got %q", f, lexed, toks)
+		}
+	}
+}
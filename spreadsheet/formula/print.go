@@ -0,0 +1,214 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// operator precedences, higher binds tighter
+const (
+	precComparison = iota + 1
+	precConcat
+	precAdditive
+	precMultiplicative
+	precExponent
+	precNegate
+	precOperand
+)
+
+var binOpText = map[BinOpType]string{
+	BinOpTypePlus:   "+",
+	BinOpTypeMinus:  "-",
+	BinOpTypeMult:   "*",
+	BinOpTypeDiv:    "/",
+	BinOpTypeExp:    "^",
+	BinOpTypeLT:     "<",
+	BinOpTypeGT:     ">",
+	BinOpTypeEQ:     "=",
+	BinOpTypeLEQ:    "<=",
+	BinOpTypeGEQ:    ">=",
+	BinOpTypeNE:     "<>",
+	BinOpTypeConcat: "&",
+}
+
+// Format returns the text of an expression in a normalized form without the
+// leading equal sign, e.g. SUM(A1,'My Sheet'!B1:B3)*(1+2). Whitespace is
+// removed, parentheses are only kept where the order of evaluation requires
+// them and sheet names are quoted where needed. The result can be parsed with
+// ParseString and stored with Cell.SetFormulaRaw.
+func Format(e Expression) string {
+	sb := strings.Builder{}
+	format(&sb, e, 0)
+	return sb.String()
+}
+
+func precedence(e Expression) int {
+	switch x := e.(type) {
+	case BinaryExpr:
+		switch x._ad {
+		case BinOpTypeLT, BinOpTypeGT, BinOpTypeEQ, BinOpTypeLEQ, BinOpTypeGEQ, BinOpTypeNE:
+			return precComparison
+		case BinOpTypeConcat:
+			return precConcat
+		case BinOpTypePlus, BinOpTypeMinus:
+			return precAdditive
+		case BinOpTypeMult, BinOpTypeDiv:
+			return precMultiplicative
+		case BinOpTypeExp:
+			return precExponent
+		}
+	case Negate:
+		return precNegate
+	case Number:
+		if x._fcfe < 0 {
+			return precNegate
+		}
+	}
+	return precOperand
+}
+
+// format writes an expression, in parentheses if it binds less tightly than
+// min.
+func format(sb *strings.Builder, e Expression, min int) {
+	if e == nil {
+		return
+	}
+	if precedence(e) < min {
+		sb.WriteByte('(')
+		format(sb, e, 0)
+		sb.WriteByte(')')
+		return
+	}
+	switch x := e.(type) {
+	case BinaryExpr:
+		// all operators are left associative
+		p := precedence(x)
+		lhs := p
+		if precedence(x._ba) == precNegate && p != precAdditive && p != precComparison {
+			// a leading minus applies to the whole concatenation, product
+			// or power in formulas such as -A1*2, so a negated left operand
+			// of these is kept in parentheses, e.g. (-2)^2
+			lhs = precOperand
+		}
+		format(sb, x._ba, lhs)
+		sb.WriteString(binOpText[x._ad])
+		format(sb, x._af, p+1)
+	case Negate:
+		sb.WriteByte('-')
+		format(sb, x._ebfdf, precNegate)
+	case FunctionCall:
+		sb.WriteString(x._aebg)
+		sb.WriteByte('(')
+		for i, a := range x._ebeeae {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			format(sb, a, 0)
+		}
+		sb.WriteByte(')')
+	case Number:
+		sb.WriteString(strconv.FormatFloat(x._fcfe, 'f', -1, 64))
+	case String:
+		sb.WriteString(`"` + strings.Replace(x._dfcbb, `"`, `""`, -1) + `"`)
+	case Bool:
+		sb.WriteString(x.String())
+	case Error:
+		sb.WriteString(x._aebf)
+	case EmptyExpr:
+	case Range:
+		format(sb, x._bgaad, precOperand)
+		sb.WriteByte(':')
+		format(sb, x._eeebc, precOperand)
+	case SheetPrefixExpr, *SheetPrefixExpr:
+		sb.WriteString(formatSheetName(sheetName(x)))
+	case PrefixExpr:
+		formatPrefixed(sb, x._fefed, x._dcec)
+	case *PrefixExpr:
+		formatPrefixed(sb, x._fefed, x._dcec)
+	case PrefixRangeExpr:
+		formatPrefixed(sb, x._aecbe, NewRange(x._acdg, x._adeff))
+	case PrefixHorizontalRange:
+		formatPrefixed(sb, x._edfe, HorizontalRange{_cbgge: x._ddaba, _faff: x._aageg})
+	case PrefixVerticalRange:
+		formatPrefixed(sb, x._feccb, VerticalRange{_ccdcd: x._gadf, _ggfec: x._agbc})
+	case ConstArrayExpr:
+		formatArray(sb, x._cdg)
+	case *ConstArrayExpr:
+		formatArray(sb, x._cdg)
+	default:
+		// cell references, whole rows and columns, names and structured
+		// references
+		sb.WriteString(e.String())
+	}
+}
+
+func formatPrefixed(sb *strings.Builder, pfx, e Expression) {
+	sb.WriteString(formatSheetName(sheetName(pfx)))
+	sb.WriteByte('!')
+	format(sb, e, precOperand)
+}
+
+func formatArray(sb *strings.Builder, rows [][]Expression) {
+	sb.WriteByte('{')
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteByte(';')
+		}
+		for j, c := range row {
+			if j > 0 {
+				sb.WriteByte(',')
+			}
+			format(sb, c, 0)
+		}
+	}
+	sb.WriteByte('}')
+}
+
+// formatSheetName returns a sheet name as it appears before the exclamation
+// mark of a reference, quoted if it isn't a plain name.
+func formatSheetName(name string) string {
+	sheet := name
+	if strings.HasPrefix(name, "[") {
+		if i := strings.IndexByte(name, ']'); i > 0 {
+			sheet = name[i+1:]
+		}
+	}
+	if sheet == "" && sheet != name || sheet != "" && !needsQuotes(sheet) {
+		// plain names and workbooks of external names such as [1]!Name
+		return name
+	}
+	return "'" + strings.Replace(name, "'", "''", -1) + "'"
+}
+
+// needsQuotes returns true if a sheet name contains characters other than
+// letters, digits, underscores and periods, starts with a digit or looks like
+// a cell reference.
+func needsQuotes(name string) bool {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' || i == 0 && unicode.IsDigit(r) {
+			return true
+		}
+	}
+	upper := strings.ToUpper(name)
+	letters := strings.IndexFunc(upper, unicode.IsDigit)
+	if letters > 0 && letters <= 3 && strings.IndexFunc(upper[letters:], func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+		// e.g. A1 or XFD1048576
+		return strings.IndexFunc(upper[:letters], func(r rune) bool { return r < 'A' || r > 'Z' }) < 0
+	}
+	if len(upper) > 1 && upper[0] == 'R' && (isDigit(upper[1]) || upper[1] == 'C') {
+		// e.g. R1C1 or RC
+		rest := strings.TrimLeft(upper[1:], "0123456789")
+		if rest == "" || rest[0] == 'C' && strings.Trim(rest[1:], "0123456789") == "" {
+			return true
+		}
+	}
+	return upper == "TRUE" || upper == "FALSE"
+}
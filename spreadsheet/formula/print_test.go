@@ -0,0 +1,83 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	td := []struct {
+		Inp, Exp string
+	}{
+		{"((A1))", "A1"},
+		{"(1+2)*3", "(1+2)*3"},
+		{"(1*2)+3", "1*2+3"},
+		{"1-(2-3)", "1-(2-3)"},
+		{"(1-2)-3", "1-2-3"},
+		{"2^3^2", "2^3^2"},
+		{"2^(3^2)", "2^(3^2)"},
+		{"(1&2)=3", "1&2=3"},
+		{"1&(2=3)", "1&(2=3)"},
+		{"A1<>B1", "A1<>B1"},
+		{"-1+2", "-1+2"},
+		{"(-A1)-2", "-A1-2"},
+		{"(-2)^2", "(-2)^2"},
+		{"(-A1)&2", "(-A1)&2"},
+		{"-(1+2)", "-(1+2)"},
+		{"-A1*2", "-(A1*2)"},
+		{"1+-2", "1+-2"},
+		{"1.50", "1.5"},
+		{"TRUE", "TRUE"},
+		{"#N/A", "#N/A"},
+		{`"a""b"&"c"`, `"a""b"&"c"`},
+		{"{1,2;3,4}", "{1,2;3,4}"},
+		{"SUM(A1,,B1)", "SUM(A1,,B1)"},
+		{"SUM((A1),B1:B3)", "SUM(A1,B1:B3)"},
+		{"$A$1+B$2", "$A$1+B$2"},
+		{"1:3", "1:3"},
+		{"A:C", "A:C"},
+		{"Table1[Qty]", "Table1[Qty]"},
+		{"Sheet1!A1:B2", "Sheet1!A1:B2"},
+		{"'Sheet1'!A1", "Sheet1!A1"},
+		{"'Data.2024'!A1", "Data.2024!A1"},
+		{"'My Sheet'!1:3", "'My Sheet'!1:3"},
+		{"Sheet1!A:C", "Sheet1!A:C"},
+		{"'A1'!B2", "'A1'!B2"},
+		{"'R1C1'!A1", "'R1C1'!A1"},
+		{"'RC'!A1", "'RC'!A1"},
+		{"'TRUE'!A1", "'TRUE'!A1"},
+		{"'2024'!A1", "'2024'!A1"},
+		{"[1]Sheet1!A1", "[1]Sheet1!A1"},
+		{"'[1]My Sheet'!A1", "'[1]My Sheet'!A1"},
+		{"[1]!Rate", "[1]!Rate"},
+	}
+	for _, tc := range td {
+		e := ParseString(tc.Inp)
+		if e == nil {
+			t.Errorf("error parsing %s", tc.Inp)
+			continue
+		}
+		got := Format(e)
+		if got != tc.Exp {
+			t.Errorf("%s: expected %s, got %s", tc.Inp, tc.Exp, got)
+		}
+		// the formatted formula is parsed to the same expression
+		if again := ParseString(got); again == nil || Format(again) != got {
+			t.Errorf("%s: expected %s to be stable", tc.Inp, got)
+		}
+	}
+}
+
+func TestFormatEvaluatesTheSame(t *testing.T) {
+	for _, f := range []string{"-1+2", "(-2)^2", "-(1+2)*3", "2^3^2", "1-(2-3)", "1&2=\"12\"", "10/(2*5)"} {
+		exp := NewEvaluator().Eval(InvalidReferenceContext, f)
+		got := NewEvaluator().Eval(InvalidReferenceContext, Format(ParseString(f)))
+		if got.Value() != exp.Value() {
+			t.Errorf("%s: expected %s, got %s", f, exp.Value(), got.Value())
+		}
+	}
+}
@@ -0,0 +1,463 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+	"github.com/unidoc/unioffice/spreadsheet/update"
+)
+
+// TableInfo describes a table that structured references are resolved
+// against.
+type TableInfo struct {
+	// Name is the name of the table.
+	Name string
+	// Sheet is the name of the sheet that contains the table.
+	Sheet string
+	// Ref is the range of the table including the header and totals rows,
+	// e.g. "A1:D10".
+	Ref string
+	// Columns are the names of the columns from left to right.
+	Columns []string
+	// HeaderRows and TotalsRows are the number of header and totals rows, zero
+	// or one.
+	HeaderRows, TotalsRows int
+}
+
+// TableContext is implemented by contexts that can resolve structured
+// references such as Sales[Amount] or [@Qty]. Contexts that don't implement it
+// evaluate structured references to #REF!.
+type TableContext interface {
+	// Table returns the table with the given name, compared
+	// case-insensitively. If name is empty it returns the table that contains
+	// the cell being evaluated.
+	Table(name string) (TableInfo, bool)
+	// CurrentCell returns the reference of the cell being evaluated, e.g. "C5",
+	// or an empty string if it is not known.
+	CurrentCell() string
+}
+
+// TableItem is a special item specifier of a structured reference.
+type TableItem byte
+
+// TableItem values, they can be combined e.g. TableItemHeaders|TableItemData
+// for Sales[[#Headers],[#Data]].
+const (
+	TableItemData TableItem = 1 << iota
+	TableItemHeaders
+	TableItemTotals
+	TableItemAll = TableItemHeaders | TableItemData | TableItemTotals
+)
+
+var tableItemNames = map[string]TableItem{
+	"#all":      TableItemAll,
+	"#data":     TableItemData,
+	"#headers":  TableItemHeaders,
+	"#totals":   TableItemTotals,
+	"#this row": TableItemData,
+}
+
+// StructuredRef is a reference to the cells of a table by table and column
+// names, e.g. Sales[Amount], Sales[[#Totals],[Amount]], Sales[[Qty]:[Price]]
+// or [@Qty]. It is resolved against the table definitions each time it is
+// evaluated, so formulas keep referring to the right cells when a table is
+// resized.
+type StructuredRef struct {
+	_text    string
+	_table   string
+	_items   TableItem
+	_thisRow bool
+	_first   string
+	_last    string
+}
+
+// NewStructuredRef constructs a new structured reference expression from its
+// text, e.g. "Sales[Amount]".
+func NewStructuredRef(s string) Expression {
+	ref, err := parseStructuredRef(s)
+	if err != nil {
+		return NewError(err.Error())
+	}
+	return ref
+}
+
+// TableName returns the name of the referenced table, which is empty for
+// references to the table that contains the formula such as [@Qty].
+func (s StructuredRef) TableName() string { return s._table }
+
+// Items returns the special items that are referenced, TableItemData if none
+// are given.
+func (s StructuredRef) Items() TableItem { return s._items }
+
+// IsThisRow reports whether the reference is limited to the row of the cell
+// that contains the formula, as with [@Qty] or [[#This Row],[Qty]].
+func (s StructuredRef) IsThisRow() bool { return s._thisRow }
+
+// Columns returns the first and last referenced column names, which are empty
+// if the reference spans all columns.
+func (s StructuredRef) Columns() (first, last string) { return s._first, s._last }
+
+// Eval evaluates the structured reference to the referenced cells.
+func (s StructuredRef) Eval(ctx Context, ev Evaluator) Result {
+	expr, err := s.resolve(ctx)
+	if err != nil {
+		return MakeErrorResultType(ErrorTypeRef, err.Error())
+	}
+	return expr.Eval(ctx, ev)
+}
+
+// Reference returns the range or cell the structured reference refers to.
+func (s StructuredRef) Reference(ctx Context, ev Evaluator) Reference {
+	expr, err := s.resolve(ctx)
+	if err != nil {
+		return ReferenceInvalid
+	}
+	return expr.Reference(ctx, ev)
+}
+
+// String returns a string representation of the structured reference.
+func (s StructuredRef) String() string { return s._text }
+
+// Update returns the same object as structured references refer to tables by
+// name and are not affected by moving or removing cells.
+func (s StructuredRef) Update(q *update.UpdateQuery) Expression { return s }
+
+// resolve returns a cell or range expression for the cells that are
+// referenced in the current state of the table.
+func (s StructuredRef) resolve(ctx Context) (Expression, error) {
+	tc, ok := ctx.(TableContext)
+	if !ok {
+		return nil, errors.New("tables are not supported by the context")
+	}
+	t, ok := tc.Table(s._table)
+	if !ok {
+		if s._table == "" {
+			return nil, errors.New("formula is not in a table")
+		}
+		return nil, fmt.Errorf("table %s not found", s._table)
+	}
+	from, to, err := reference.ParseRangeReference(t.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	col0, col1 := from.ColumnIdx, to.ColumnIdx
+	if s._first != "" {
+		first, last := columnIndex(t.Columns, s._first), columnIndex(t.Columns, s._last)
+		if first < 0 || last < 0 {
+			return nil, fmt.Errorf("column not found in table %s", t.Name)
+		}
+		if first > last {
+			first, last = last, first
+		}
+		col0, col1 = from.ColumnIdx+uint32(first), from.ColumnIdx+uint32(last)
+	}
+
+	var row0, row1 uint32
+	dataFrom, dataTo := from.RowIdx+uint32(t.HeaderRows), to.RowIdx-uint32(t.TotalsRows)
+	switch {
+	case s._thisRow:
+		cur, err := reference.ParseCellReference(tc.CurrentCell())
+		if err != nil || cur.RowIdx < dataFrom || cur.RowIdx > dataTo {
+			return nil, errors.New("formula is not in a data row of the table")
+		}
+		row0, row1 = cur.RowIdx, cur.RowIdx
+	case s._items == TableItemHeaders && t.HeaderRows == 0:
+		return nil, fmt.Errorf("table %s has no header row", t.Name)
+	case s._items == TableItemTotals && t.TotalsRows == 0:
+		return nil, fmt.Errorf("table %s has no totals row", t.Name)
+	default:
+		row0, row1 = dataFrom, dataTo
+		if s._items&TableItemHeaders != 0 {
+			row0 = from.RowIdx
+		} else if s._items&TableItemData == 0 {
+			row0 = dataTo + 1
+		}
+		if s._items&TableItemTotals != 0 {
+			row1 = to.RowIdx
+		} else if s._items&TableItemData == 0 {
+			row1 = dataFrom - 1
+		}
+	}
+	if row1 < row0 {
+		return nil, fmt.Errorf("table %s has no data rows", t.Name)
+	}
+
+	sheet := NewSheetPrefixExpr(t.Sheet)
+	first := NewCellRef(fmt.Sprintf("%s%d", reference.IndexToColumn(col0), row0))
+	if row0 == row1 && col0 == col1 {
+		return NewPrefixExpr(sheet, first), nil
+	}
+	last := NewCellRef(fmt.Sprintf("%s%d", reference.IndexToColumn(col1), row1))
+	return NewPrefixRangeExpr(sheet, first, last), nil
+}
+
+func columnIndex(columns []string, name string) int {
+	for i, c := range columns {
+		if strings.EqualFold(c, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseStructuredRef parses a structured reference such as Sales[Amount].
+func parseStructuredRef(text string) (StructuredRef, error) {
+	ref := StructuredRef{_text: text, _items: TableItemData}
+	open := strings.IndexByte(text, '[')
+	if open < 0 || text[len(text)-1] != ']' {
+		return ref, fmt.Errorf("invalid structured reference %s", text)
+	}
+	ref._table = text[:open]
+	spec := strings.TrimSpace(text[open+1 : len(text)-1])
+
+	at := strings.HasPrefix(spec, "@")
+	if at {
+		ref._thisRow = true
+		spec = strings.TrimSpace(spec[1:])
+		if spec != "" && spec[0] != '[' {
+			ref._first = unescapeTableColumnName(spec)
+			ref._last = ref._first
+			return ref, nil
+		}
+	}
+	if spec == "" {
+		return ref, nil
+	}
+	if spec[0] != '[' {
+		if strings.HasPrefix(spec, "#") {
+			return ref, ref.addItem(spec)
+		}
+		ref._first = unescapeTableColumnName(spec)
+		ref._last = ref._first
+		return ref, nil
+	}
+
+	// a list of bracketed specifiers, separated by commas, with the column
+	// names last and optionally joined to a range with a colon
+	items, columns, rng := false, 0, false
+	for spec != "" {
+		end := closingBracket(spec, 0)
+		if end < 0 {
+			return ref, fmt.Errorf("invalid structured reference %s", text)
+		}
+		part := strings.TrimSpace(spec[1:end])
+		spec = strings.TrimSpace(spec[end+1:])
+		if strings.HasPrefix(part, "#") {
+			if columns > 0 {
+				return ref, fmt.Errorf("invalid structured reference %s", text)
+			}
+			if !items {
+				ref._items = 0
+			}
+			items = true
+			if err := ref.addItem(part); err != nil {
+				return ref, err
+			}
+		} else {
+			name := unescapeTableColumnName(part)
+			switch {
+			case columns == 0:
+				ref._first, ref._last = name, name
+			case columns == 1 && rng:
+				ref._last = name
+			default:
+				return ref, fmt.Errorf("invalid structured reference %s", text)
+			}
+			columns++
+		}
+		if spec == "" {
+			break
+		}
+		switch spec[0] {
+		case ',':
+		case ':':
+			if columns != 1 || rng {
+				return ref, fmt.Errorf("invalid structured reference %s", text)
+			}
+			rng = true
+		default:
+			return ref, fmt.Errorf("invalid structured reference %s", text)
+		}
+		spec = strings.TrimSpace(spec[1:])
+		if spec == "" || spec[0] != '[' {
+			return ref, fmt.Errorf("invalid structured reference %s", text)
+		}
+	}
+	if rng && columns != 2 {
+		return ref, fmt.Errorf("invalid structured reference %s", text)
+	}
+	if at && items || ref._thisRow && ref._items != TableItemData {
+		return ref, fmt.Errorf("invalid structured reference %s", text)
+	}
+	switch ref._items {
+	case TableItemData, TableItemHeaders, TableItemTotals, TableItemAll,
+		TableItemHeaders | TableItemData, TableItemData | TableItemTotals:
+	default:
+		return ref, fmt.Errorf("invalid combination of items in %s", text)
+	}
+	return ref, nil
+}
+
+func (s *StructuredRef) addItem(item string) error {
+	key := strings.ToLower(strings.Join(strings.Fields(item), " "))
+	v, ok := tableItemNames[key]
+	if !ok {
+		return fmt.Errorf("unknown table item %s", item)
+	}
+	if key == "#this row" {
+		s._thisRow = true
+	}
+	s._items |= v
+	return nil
+}
+
+// closingBracket returns the index of the bracket that closes the one at
+// index i, skipping over nested brackets and characters escaped with a single
+// quote, or -1 if there is none.
+func closingBracket(s string, i int) int {
+	depth := 0
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func unescapeTableColumnName(name string) string {
+	if !strings.Contains(name, "'") {
+		return name
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\'' && i+1 < len(name) {
+			i++
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// tokenStructuredRef is the token of a structured reference such as
+// Sales[Amount] or [@Qty], the value is the text of the reference.
+var tokenStructuredRef = generatedToken("tokenStructuredRef")
+
+// generatedToken returns the token that the parser generator assigned to the
+// token with the given name in the grammar. Tokens are numbered in the order
+// of the generated token names, starting after the three predefined ones.
+func generatedToken(name string) tokenType {
+	for i, n := range _bgbcg {
+		if n == name && i >= 3 {
+			return tokenType(_ecaa + i - 3)
+		}
+	}
+	panic("formula: no token " + name + " in the parser")
+}
+
+// lex tokenizes a formula. Structured references and references to other
+// workbooks are found first, as their brackets may contain any text, and the
+// text around them is passed to the formula lexer.
+func (l *Lexer) lex(r io.Reader) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		l.emit(_fbebc, nil)
+		close(l._aabcee)
+		return
+	}
+	s := string(buf)
+	last := 0
+	for _, t := range refTokens(s, isStructuredRef) {
+		if t.start > last {
+			l._gbfeca(strings.NewReader(s[last:t.start]))
+		}
+		l.emit(t.typ, []byte(t.text))
+		last = t.end
+	}
+	if last < len(s) || last == 0 {
+		l._gbfeca(strings.NewReader(s[last:]))
+	}
+	close(l._aabcee)
+}
+
+func isStructuredRef(text string) bool {
+	_, err := parseStructuredRef(text)
+	return err == nil
+}
+
+// scanStructuredRefs returns the start and end offsets of the structured
+// references in a formula. The text of each candidate, e.g. Sales[Qty], is
+// passed to valid, and if valid is nil all of them are returned.
+func scanStructuredRefs(s string, valid func(text string) bool) [][2]int {
+	if !strings.Contains(s, "[") {
+		return nil
+	}
+	var spans [][2]int
+	nameStart := -1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '"' || c == '\'' {
+			// strings and quoted sheet names
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				break
+			}
+			i += end + 1
+			nameStart = -1
+			continue
+		}
+		if c == '[' {
+			if end := closingBracket(s, i); end > 0 && !isExternalRef(s, end) {
+				start := i
+				if nameStart >= 0 {
+					start = nameStart
+				}
+				if valid == nil || valid(s[start:end+1]) {
+					spans = append(spans, [2]int{start, end + 1})
+					i = end
+					nameStart = -1
+					continue
+				}
+			}
+		}
+		if isTableNameChar(rune(c)) || c >= utf8.RuneSelf {
+			if nameStart < 0 {
+				nameStart = i
+			}
+		} else {
+			nameStart = -1
+		}
+	}
+	return spans
+}
+
+// isExternalRef reports whether the bracket that closes at index end belongs
+// to a reference to another workbook such as [1]Sheet1!A1 or [1]!Name.
+func isExternalRef(s string, end int) bool {
+	return end+1 < len(s) && (isTableNameChar(rune(s[end+1])) || s[end+1] == '\'' || s[end+1] == '!')
+}
+
+func isTableNameChar(c rune) bool {
+	return c == '_' || c == '.' || c == '\\' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLexStructuredRefs(t *testing.T) {
+	td := []struct {
+		Inp  string
+		Refs []string
+	}{
+		{"SUM(Sales[Qty])", []string{"Sales[Qty]"}},
+		{"[@Qty]*[@Price]", []string{"[@Qty]", "[@Price]"}},
+		{"Sales[[#This Row],[a,b]]+1", []string{"Sales[[#This Row],[a,b]]"}},
+		{"SUM(Sales[[Qty]:[Price]])", []string{"Sales[[Qty]:[Price]]"}},
+		{`"Sales[Qty]"&'[x]'!A1`, nil},
+		{"[1]Sheet1!A1+[1]!Name", nil},
+		{"_xlsr_53616c65735b5174795d", nil},
+	}
+	for _, tc := range td {
+		var refs []string
+		for n := range LexReader(strings.NewReader(tc.Inp)) {
+			if n._dgfdea == tokenStructuredRef {
+				refs = append(refs, n._acfe)
+			}
+		}
+		if strings.Join(refs, " ") != strings.Join(tc.Refs, " ") {
+			t.Errorf("expected structured references %q in %s, got %q", tc.Refs, tc.Inp, refs)
+		}
+	}
+}
+
+func TestParseStructuredRefs(t *testing.T) {
+	td := []struct {
+		Inp     string
+		Table   string
+		Items   TableItem
+		ThisRow bool
+		First   string
+		Last    string
+	}{
+		{"Sales[Qty]", "Sales", TableItemData, false, "Qty", "Qty"},
+		{"Sales[]", "Sales", TableItemData, false, "", ""},
+		{"[@Qty]", "", TableItemData, true, "Qty", "Qty"},
+		{"Sales[[#Headers],[#Data],[Qty]]", "Sales", TableItemHeaders | TableItemData, false, "Qty", "Qty"},
+		{"Sales[[#Totals],[Qty]:[Price]]", "Sales", TableItemTotals, false, "Qty", "Price"},
+		{"Sales[[#This Row],[a'[b]:[c]]", "Sales", TableItemData, true, "a[b", "c"},
+	}
+	for _, tc := range td {
+		ref, ok := Parse(strings.NewReader(tc.Inp)).(StructuredRef)
+		if !ok {
+			t.Errorf("expected %s to parse to a structured reference", tc.Inp)
+			continue
+		}
+		first, last := ref.Columns()
+		if ref.TableName() != tc.Table || ref.Items() != tc.Items || ref.IsThisRow() != tc.ThisRow ||
+			first != tc.First || last != tc.Last {
+			t.Errorf("unexpected parse of %s: table %q items %d this row %v columns %q:%q",
+				tc.Inp, ref.TableName(), ref.Items(), ref.IsThisRow(), first, last)
+		}
+		if ref.String() != tc.Inp {
+			t.Errorf("expected %s to print unchanged, got %s", tc.Inp, ref.String())
+		}
+	}
+
+	// structured references are operands like any other reference
+	bin, ok := Parse(strings.NewReader("=Sales[Qty]*[@Price]+1")).(BinaryExpr)
+	if !ok {
+		t.Fatalf("expected a binary expression")
+	}
+	if s := bin.String(); s != "Sales[Qty]*[@Price]+1" {
+		t.Errorf("expected Sales[Qty]*[@Price]+1, got %s", s)
+	}
+
+	// names aren't mistaken for encoded structured references
+	name := "_xlsr_53616c65735b5174795d"
+	if _, ok := Parse(strings.NewReader(name)).(NamedRangeRef); !ok {
+		t.Errorf("expected %s to parse to a named range", name)
+	}
+}
+
+func TestGeneratedTokens(t *testing.T) {
+	// the parser must translate every token back to the symbol of its name
+	for _, name := range _bgbcg[3:] {
+		tok := int(generatedToken(name)) - _eddfd
+		if tok < 0 || tok >= len(_aaede) || _aaede[tok] < 1 || _bgbcg[_aaede[tok]-1] != name {
+			t.Errorf("expected %s to be a token of the parser", name)
+		}
+	}
+	// and the lexer must emit the tokens of the parser
+	td := []struct {
+		Inp    string
+		Tokens []string
+	}{
+		{"1+Sales[Qty]", []string{"tokenNumber", "tokenPlus", "tokenStructuredRef"}},
+		{"SUM(Sheet1!A1;TRUE)", []string{"tokenFunctionBuiltin", "tokenSheet", "tokenCell", "tokenSemi", "tokenBool", "tokenRParen"}},
+		{`"a"&#N/A`, []string{"tokenString", "tokenAmpersand", "tokenError"}},
+	}
+	for _, tc := range td {
+		var toks []string
+		for n := range LexReader(strings.NewReader(tc.Inp)) {
+			if n._dgfdea < _ecaa {
+				break
+			}
+			toks = append(toks, _bgbcg[int(n._dgfdea)-_ecaa+3])
+		}
+		if strings.Join(toks, " ") != strings.Join(tc.Tokens, " ") {
+			t.Errorf("expected the tokens %v for %s, got %v", tc.Tokens, tc.Inp, toks)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for a token that isn't in the parser")
+		}
+	}()
+	generatedToken("tokenMissing")
+}
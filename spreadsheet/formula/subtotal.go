@@ -0,0 +1,517 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// SubtotalContext is implemented by contexts that know the hidden rows and the
+// formulas of a sheet. SUBTOTAL and AGGREGATE use it to skip hidden rows and
+// nested subtotals. With contexts that don't implement it no rows are hidden,
+// and as nested subtotals can't be told apart from other formulas, a range
+// that contains formulas evaluates to #VALUE! unless the options of AGGREGATE
+// include nested subtotals.
+type SubtotalContext interface {
+	// RowHidden returns whether the row of a cell is hidden and whether it is
+	// hidden by a filter, i.e. it is a data row of the autofilter of the sheet
+	// or of a table.
+	RowHidden(cellRef string) (hidden, filtered bool)
+	// CellFormula returns the formula of a cell without the leading equal
+	// sign, or an empty string if the cell doesn't contain a formula.
+	CellFormula(cellRef string) string
+}
+
+func init() {
+	RegisterFunctionComplex("SUBTOTAL", Subtotal)
+	RegisterFunctionComplex("AGGREGATE", Aggregate)
+	RegisterFunctionComplex("_xlfn.AGGREGATE", Aggregate)
+	// error values in the ranges are handled by the functions themselves, so
+	// that they can be ignored in hidden rows or by AGGREGATE options
+	for _, fn := range []string{"SUBTOTAL", "AGGREGATE", "_xlfn.AGGREGATE"} {
+		_aceed[fn] = true
+	}
+}
+
+// aggregate function numbers shared by SUBTOTAL, AGGREGATE and the database
+// functions
+const (
+	aggAverage = iota + 1
+	aggCount
+	aggCountA
+	aggMax
+	aggMin
+	aggProduct
+	aggStdevS
+	aggStdevP
+	aggSum
+	aggVarS
+	aggVarP
+	aggMedian
+	aggMode
+	aggLarge
+	aggSmall
+	aggPercentileInc
+	aggQuartileInc
+	aggPercentileExc
+	aggQuartileExc
+)
+
+// subtotalOptions control which cells are included by SUBTOTAL and AGGREGATE.
+type subtotalOptions struct {
+	// skipHidden skips all hidden rows, skipFiltered only the rows hidden by
+	// a filter.
+	skipHidden, skipFiltered bool
+	skipNested               bool
+	skipErrors               bool
+	// keepErrors passes error values to COUNT and COUNTA, which count them
+	// as values that aren't numbers
+	keepErrors bool
+}
+
+// Subtotal implements the Excel SUBTOTAL function. Function numbers 1 to 11
+// skip rows hidden by a filter, 101 to 111 skip all hidden rows. Cells that
+// contain SUBTOTAL or AGGREGATE formulas are not included so that subtotals
+// aren't counted twice.
+func Subtotal(ctx Context, ev Evaluator, args []Result) Result {
+	if len(args) < 2 {
+		return MakeErrorResult("SUBTOTAL requires at least two arguments")
+	}
+	if args[0].Type == ResultTypeError {
+		return args[0]
+	}
+	fn, ok := integerArg(args[0])
+	if !ok {
+		return MakeErrorResult("SUBTOTAL requires a numeric function number")
+	}
+	opts := subtotalOptions{skipFiltered: true, skipNested: true}
+	if fn > 100 {
+		fn -= 100
+		opts.skipHidden = true
+	}
+	if fn < aggAverage || fn > aggVarP {
+		return MakeErrorResult(fmt.Sprintf("invalid SUBTOTAL function number %d", fn))
+	}
+	opts.keepErrors = fn == aggCount || fn == aggCountA
+	var values []Result
+	for _, a := range args[1:] {
+		vals, err := subtotalValues(ctx, ev, a, opts)
+		if err.Type == ResultTypeError {
+			return err
+		}
+		values = append(values, vals...)
+	}
+	return aggregate(fn, values, 0)
+}
+
+// Aggregate implements the Excel AGGREGATE function in both its reference
+// form AGGREGATE(function, options, ref1, ...) for function numbers 1 to 13 and
+// its array form AGGREGATE(function, options, array, k) for 14 to 19. The
+// options 0 to 7 select whether nested subtotals, hidden rows and error values
+// are ignored.
+func Aggregate(ctx Context, ev Evaluator, args []Result) Result {
+	if len(args) < 3 {
+		return MakeErrorResult("AGGREGATE requires at least three arguments")
+	}
+	for _, a := range args[:2] {
+		if a.Type == ResultTypeError {
+			return a
+		}
+	}
+	fn, ok := integerArg(args[0])
+	if !ok || fn < aggAverage || fn > aggQuartileExc {
+		return MakeErrorResult("AGGREGATE requires a function number from 1 to 19")
+	}
+	opt := 0
+	if args[1].Type != ResultTypeEmpty {
+		if opt, ok = integerArg(args[1]); !ok || opt < 0 || opt > 7 {
+			return MakeErrorResult("AGGREGATE requires options from 0 to 7")
+		}
+	}
+	opts := subtotalOptions{
+		skipNested: opt < 4,
+		skipHidden: opt%2 == 1,
+		skipErrors: opt%4 >= 2,
+		keepErrors: fn == aggCount || fn == aggCountA,
+	}
+	refs, k := args[2:], 0.0
+	if fn >= aggLarge {
+		if len(args) != 4 {
+			return MakeErrorResult(fmt.Sprintf("AGGREGATE function %d requires an array and k", fn))
+		}
+		refs = args[2:3]
+		kr := args[3].AsNumber()
+		if kr.Type == ResultTypeError {
+			return kr
+		}
+		if kr.Type != ResultTypeNumber {
+			return MakeErrorResult("AGGREGATE requires a numeric k")
+		}
+		k = kr.ValueNumber
+	}
+	var values []Result
+	for _, a := range refs {
+		vals, err := subtotalValues(ctx, ev, a, opts)
+		if err.Type == ResultTypeError {
+			return err
+		}
+		values = append(values, vals...)
+	}
+	return aggregate(fn, values, k)
+}
+
+// subtotalValues returns the values of the cells that an argument refers to,
+// without the cells that are skipped by the options. Arguments that are not
+// references, e.g. arrays computed by the formula, are used as they are. The
+// first error value that isn't skipped is returned as the second result.
+func subtotalValues(ctx Context, ev Evaluator, arg Result, opts subtotalOptions) ([]Result, Result) {
+	var values []Result
+	add := func(v Result) Result {
+		if v.Type == ResultTypeError && !opts.keepErrors {
+			if opts.skipErrors {
+				return MakeEmptyResult()
+			}
+			return v
+		}
+		values = append(values, v)
+		return MakeEmptyResult()
+	}
+
+	sheetCtx, cells, ok := referenceCells(ctx, arg.Ref)
+	if !ok {
+		for _, row := range resultRows(arg) {
+			for _, v := range row {
+				if err := add(v); err.Type == ResultTypeError {
+					return nil, err
+				}
+			}
+		}
+		return values, MakeEmptyResult()
+	}
+	sc, _ := sheetCtx.(SubtotalContext)
+	for _, ref := range cells {
+		if sc != nil {
+			if hidden, filtered := sc.RowHidden(ref); hidden && opts.skipHidden || filtered && opts.skipFiltered {
+				continue
+			}
+			if opts.skipNested && isSubtotalFormula(sc.CellFormula(ref)) {
+				continue
+			}
+		} else if opts.skipNested && sheetCtx.HasFormula(ref) {
+			return nil, MakeErrorResult("nested subtotals can't be detected in " + ref)
+		}
+		if err := add(sheetCtx.Cell(ref, ev)); err.Type == ResultTypeError {
+			return nil, err
+		}
+	}
+	return values, MakeEmptyResult()
+}
+
+// referenceCells returns the context of the sheet of a reference and the
+// cells that it refers to by rows, e.g. A1, B1, A2, B2 for A1:B2.
+func referenceCells(ctx Context, ref Reference) (Context, []string, bool) {
+	value := ref.Value
+	switch ref.Type {
+	case ReferenceTypeNamedRange:
+		nr := ctx.NamedRange(value)
+		if nr.Type != ReferenceTypeCell && nr.Type != ReferenceTypeRange {
+			return nil, nil, false
+		}
+		value = nr.Value
+	case ReferenceTypeCell, ReferenceTypeRange, ReferenceTypeHorizontalRange, ReferenceTypeVerticalRange:
+	default:
+		return nil, nil, false
+	}
+
+	sheet, area := splitSheetReference(value)
+	if sheet != "" {
+		ctx = ctx.Sheet(sheet)
+	}
+	area = strings.Replace(area, "$", "", -1)
+	from, to := area, area
+	if i := strings.IndexByte(area, ':'); i >= 0 {
+		from, to = area[:i], area[i+1:]
+	}
+	switch ref.Type {
+	case ReferenceTypeHorizontalRange:
+		first, ferr := parsePositiveInt(from)
+		last, lerr := parsePositiveInt(to)
+		if ferr != nil || lerr != nil {
+			return nil, nil, false
+		}
+		from, to = _fadgd(ctx, first, last)
+	case ReferenceTypeVerticalRange:
+		from, to = _fefc(ctx, from, to)
+	}
+
+	fc, ferr := reference.ParseCellReference(from)
+	tc, terr := reference.ParseCellReference(to)
+	if ferr != nil || terr != nil {
+		return nil, nil, false
+	}
+	if fc.RowIdx > tc.RowIdx {
+		fc.RowIdx, tc.RowIdx = tc.RowIdx, fc.RowIdx
+	}
+	if fc.ColumnIdx > tc.ColumnIdx {
+		fc.ColumnIdx, tc.ColumnIdx = tc.ColumnIdx, fc.ColumnIdx
+	}
+	var cells []string
+	for row := fc.RowIdx; row <= tc.RowIdx; row++ {
+		for col := fc.ColumnIdx; col <= tc.ColumnIdx; col++ {
+			cells = append(cells, fmt.Sprintf("%s%d", reference.IndexToColumn(col), row))
+		}
+	}
+	return ctx, cells, true
+}
+
+// splitSheetReference splits a reference such as 'My Sheet'!A1:B2 into the
+// unquoted sheet name and the area.
+func splitSheetReference(ref string) (sheet, area string) {
+	i := strings.LastIndexByte(ref, '!')
+	if i < 0 {
+		return "", ref
+	}
+	sheet = ref[:i]
+	if len(sheet) > 1 && sheet[0] == '\'' && sheet[len(sheet)-1] == '\'' {
+		sheet = strings.Replace(sheet[1:len(sheet)-1], "''", "'", -1)
+	}
+	return sheet, ref[i+1:]
+}
+
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err == nil && n < 1 {
+		err = fmt.Errorf("invalid row %s", s)
+	}
+	return n, err
+}
+
+// subtotalFormulas caches whether formulas call SUBTOTAL or AGGREGATE, the
+// same formulas are checked for each subtotal that includes their cells.
+var subtotalFormulas = struct {
+	sync.Mutex
+	m map[string]bool
+}{m: map[string]bool{}}
+
+// isSubtotalFormula returns true if a formula calls SUBTOTAL or AGGREGATE.
+func isSubtotalFormula(f string) bool {
+	upper := strings.ToUpper(f)
+	if !strings.Contains(upper, "SUBTOTAL") && !strings.Contains(upper, "AGGREGATE") {
+		return false
+	}
+	subtotalFormulas.Lock()
+	res, ok := subtotalFormulas.m[f]
+	subtotalFormulas.Unlock()
+	if ok {
+		return res
+	}
+	if expr := ParseString(f); expr != nil {
+		for _, fn := range Functions(expr) {
+			switch strings.ToUpper(strings.TrimPrefix(fn, "_xlfn.")) {
+			case "SUBTOTAL", "AGGREGATE":
+				res = true
+			}
+		}
+	}
+	subtotalFormulas.Lock()
+	subtotalFormulas.m[f] = res
+	subtotalFormulas.Unlock()
+	return res
+}
+
+// resultRows returns the values of a result by rows, a single value for
+// results that aren't lists or arrays.
+func resultRows(r Result) [][]Result {
+	switch r.Type {
+	case ResultTypeList, ResultTypeArray:
+		return _dacf(r)
+	}
+	return [][]Result{{r}}
+}
+
+// integerArg returns the integer part of a numeric argument.
+func integerArg(r Result) (int, bool) {
+	r = r.AsNumber()
+	if r.Type != ResultTypeNumber {
+		return 0, false
+	}
+	return int(r.ValueNumber), true
+}
+
+// aggregate applies an aggregate function to values. Only numbers are
+// included except for aggCountA which counts all values that aren't empty. k
+// is the argument of the functions from aggLarge to aggQuartileExc.
+func aggregate(fn int, values []Result, k float64) Result {
+	var nums []float64
+	counta := 0
+	for _, v := range values {
+		if v.Type != ResultTypeEmpty {
+			counta++
+		}
+		if v.Type == ResultTypeNumber && !v.IsBoolean {
+			nums = append(nums, v.ValueNumber)
+		}
+	}
+	n := float64(len(nums))
+	switch fn {
+	case aggCount:
+		return MakeNumberResult(n)
+	case aggCountA:
+		return MakeNumberResult(float64(counta))
+	case aggSum:
+		return MakeNumberResult(sum(nums))
+	case aggAverage:
+		if len(nums) == 0 {
+			return MakeErrorResultType(ErrorTypeDivideByZero, "AVERAGE of no numbers")
+		}
+		return MakeNumberResult(sum(nums) / n)
+	case aggMax, aggMin:
+		if len(nums) == 0 {
+			return MakeNumberResult(0)
+		}
+		res := nums[0]
+		for _, x := range nums[1:] {
+			if fn == aggMax && x > res || fn == aggMin && x < res {
+				res = x
+			}
+		}
+		return MakeNumberResult(res)
+	case aggProduct:
+		if len(nums) == 0 {
+			return MakeNumberResult(0)
+		}
+		res := 1.0
+		for _, x := range nums {
+			res *= x
+		}
+		return MakeNumberResult(res)
+	case aggStdevS, aggStdevP, aggVarS, aggVarP:
+		sample := fn == aggStdevS || fn == aggVarS
+		if len(nums) == 0 || sample && len(nums) == 1 {
+			return MakeErrorResultType(ErrorTypeDivideByZero, "not enough numbers for variance")
+		}
+		v := variance(nums, sample)
+		if fn == aggStdevS || fn == aggStdevP {
+			v = math.Sqrt(v)
+		}
+		return MakeNumberResult(v)
+	case aggMedian:
+		if len(nums) == 0 {
+			return MakeErrorResultType(ErrorTypeNum, "MEDIAN of no numbers")
+		}
+		return MakeNumberResult(percentile(sorted(nums), 0.5))
+	case aggMode:
+		return mode(nums)
+	case aggLarge, aggSmall:
+		i := int(math.Ceil(k))
+		if i < 1 || i > len(nums) {
+			return MakeErrorResultType(ErrorTypeNum, "k is out of range")
+		}
+		s := sorted(nums)
+		if fn == aggLarge {
+			return MakeNumberResult(s[len(s)-i])
+		}
+		return MakeNumberResult(s[i-1])
+	case aggPercentileInc, aggQuartileInc:
+		if fn == aggQuartileInc {
+			if k = math.Floor(k); k < 0 || k > 4 {
+				return MakeErrorResultType(ErrorTypeNum, "quart must be from 0 to 4")
+			}
+			k /= 4
+		}
+		if len(nums) == 0 || k < 0 || k > 1 {
+			return MakeErrorResultType(ErrorTypeNum, "k must be from 0 to 1")
+		}
+		return MakeNumberResult(percentile(sorted(nums), k))
+	case aggPercentileExc, aggQuartileExc:
+		if fn == aggQuartileExc {
+			if k = math.Floor(k); k < 1 || k > 3 {
+				return MakeErrorResultType(ErrorTypeNum, "quart must be from 1 to 3")
+			}
+			k /= 4
+		}
+		// the rank is interpolated between the numbers at positions 1 to n
+		// of n+1 equal intervals
+		rank := k*(n+1) - 1
+		if len(nums) == 0 || k <= 0 || k >= 1 || rank < 0 || rank > n-1 {
+			return MakeErrorResultType(ErrorTypeNum, "k is out of range")
+		}
+		return MakeNumberResult(interpolate(sorted(nums), rank))
+	}
+	return MakeErrorResult(fmt.Sprintf("invalid function number %d", fn))
+}
+
+func sum(nums []float64) float64 {
+	res := 0.0
+	for _, x := range nums {
+		res += x
+	}
+	return res
+}
+
+// variance returns the sample or population variance of nums.
+func variance(nums []float64, sample bool) float64 {
+	n := float64(len(nums))
+	mean := sum(nums) / n
+	ss := 0.0
+	for _, x := range nums {
+		ss += (x - mean) * (x - mean)
+	}
+	if sample {
+		return ss / (n - 1)
+	}
+	return ss / n
+}
+
+func sorted(nums []float64) []float64 {
+	s := append([]float64(nil), nums...)
+	sort.Float64s(s)
+	return s
+}
+
+// percentile returns the k-th percentile of sorted numbers, interpolating
+// between the numbers at positions 0 to n-1.
+func percentile(s []float64, k float64) float64 {
+	return interpolate(s, k*float64(len(s)-1))
+}
+
+func interpolate(s []float64, rank float64) float64 {
+	i := int(rank)
+	if i >= len(s)-1 {
+		return s[len(s)-1]
+	}
+	return s[i] + (rank-float64(i))*(s[i+1]-s[i])
+}
+
+// mode returns the most frequent number, the first one in case of a tie.
+func mode(nums []float64) Result {
+	counts := map[float64]int{}
+	maxCount := 0
+	for _, x := range nums {
+		counts[x]++
+		if counts[x] > maxCount {
+			maxCount = counts[x]
+		}
+	}
+	if maxCount < 2 {
+		return MakeErrorResultType(ErrorTypeNA, "no number occurs more than once")
+	}
+	for _, x := range nums {
+		if counts[x] == maxCount {
+			return MakeNumberResult(x)
+		}
+	}
+	return MakeErrorResultType(ErrorTypeNA, "no number occurs more than once")
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import "testing"
+
+func TestSubtotal(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"SUBTOTAL(1,{1,2,3,4})", "2.5"},
+		{"SUBTOTAL(2,{1,2,3,4})", "4"},
+		{"SUBTOTAL(4,{1,2,3,4})", "4"},
+		{"SUBTOTAL(5,{1,2,3,4})", "1"},
+		{"SUBTOTAL(6,{1,2,3,4})", "24"},
+		{"SUBTOTAL(7,{1,2,3,4})", "1.29099444873581"},
+		{"SUBTOTAL(8,{1,2,3,4})", "1.11803398874989"},
+		{"SUBTOTAL(9,{1,2,3,4})", "10"},
+		{"SUBTOTAL(10,{1,2,3,4})", "1.66666666666667"},
+		{"SUBTOTAL(11,{1,2,3,4})", "1.25"},
+		{"SUBTOTAL(109,{1,2,3,4},{5})", "15"},
+		{"SUBTOTAL(1,{})", "#VALUE!"},
+		{"SUBTOTAL(12,{1})", "#VALUE!"},
+		{"SUBTOTAL(1/0,{1})", "#DIV/0!"},
+	})
+}
+
+func TestAggregate(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"AGGREGATE(9,4,{1,2,3,4})", "10"},
+		{"AGGREGATE(12,4,{1,2,3,4})", "2.5"},
+		{"AGGREGATE(13,4,{1,2,2,4})", "2"},
+		{"AGGREGATE(14,4,{1,2,3,4},2)", "3"},
+		{"AGGREGATE(15,4,{1,2,3,4},2)", "2"},
+		{"AGGREGATE(16,4,{1,2,3,4},0.3)", "1.9"},
+		{"AGGREGATE(17,4,{1,2,3,4},1)", "1.75"},
+		{"AGGREGATE(18,4,{1,2,3,4},0.3)", "1.5"},
+		{"AGGREGATE(19,4,{1,2,3,4},1)", "1.25"},
+		{"AGGREGATE(14,4,{1,2,3,4},5)", "#NUM!"},
+		{"AGGREGATE(14,4,{1,2,3,4})", "#VALUE!"},
+		{"AGGREGATE(20,4,{1})", "#VALUE!"},
+		{"AGGREGATE(9,8,{1})", "#VALUE!"},
+	})
+}
+
+// formulaCellContext is a context that doesn't implement SubtotalContext,
+// its cells hold numbers or formulas.
+type formulaCellContext struct {
+	ivr
+	values   map[string]float64
+	formulas map[string]string
+}
+
+func (c *formulaCellContext) Cell(ref string, ev Evaluator) Result {
+	if f, ok := c.formulas[ref]; ok {
+		return ev.Eval(c, f)
+	}
+	if v, ok := c.values[ref]; ok {
+		return MakeNumberResult(v)
+	}
+	return MakeEmptyResult()
+}
+
+func (c *formulaCellContext) HasFormula(ref string) bool {
+	_, ok := c.formulas[ref]
+	return ok
+}
+
+func (c *formulaCellContext) Sheet(name string) Context { return c }
+
+func TestSubtotalWithoutSubtotalContext(t *testing.T) {
+	ctx := &formulaCellContext{
+		values:   map[string]float64{"A1": 1, "A2": 2},
+		formulas: map[string]string{"A3": "SUBTOTAL(9,A1:A2)"},
+	}
+	ev := NewEvaluator()
+	td := []formulaTest{
+		// the nested subtotal in A3 can't be detected
+		{"SUBTOTAL(9,A1:A3)", "#VALUE!"},
+		{"AGGREGATE(9,0,A1:A3)", "#VALUE!"},
+		{"SUBTOTAL(9,A1:A2)", "3"},
+		{"AGGREGATE(9,4,A1:A3)", "6"},
+	}
+	for _, tc := range td {
+		if res := ev.Eval(ctx, tc.Inp); !resultMatches(res, tc.Exp) {
+			t.Errorf("expected %s = %s, got %q (%s)", tc.Inp, tc.Exp, res.Value(), res.ErrorMessage)
+		}
+	}
+}
+
+func TestIsSubtotalFormula(t *testing.T) {
+	td := []struct {
+		Inp string
+		Exp bool
+	}{
+		{"SUBTOTAL(9,A1:A2)", true},
+		{"1+_xlfn.AGGREGATE(9,0,A1)", true},
+		{"SUM(A1:A2)", false},
+		{`"SUBTOTAL"&A1`, false},
+		{"", false},
+	}
+	for i := 0; i < 2; i++ {
+		// the second pass uses the cached results
+		for _, tc := range td {
+			if got := isSubtotalFormula(tc.Inp); got != tc.Exp {
+				t.Errorf("expected isSubtotalFormula(%q) = %v, got %v", tc.Inp, tc.Exp, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,411 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+func init() {
+	RegisterFunction("WEEKDAY", Weekday)
+	RegisterFunction("WEEKNUM", Weeknum)
+	RegisterFunction("ISOWEEKNUM", IsoWeeknum)
+	RegisterFunction("_xlfn.ISOWEEKNUM", IsoWeeknum)
+	RegisterFunction("HOUR", Hour)
+	RegisterFunction("SECOND", Second)
+	RegisterFunction("WORKDAY", Workday)
+	RegisterFunction("WORKDAY.INTL", WorkdayIntl)
+	RegisterFunction("_xlfn.WORKDAY.INTL", WorkdayIntl)
+	RegisterFunction("NETWORKDAYS", Networkdays)
+	RegisterFunction("NETWORKDAYS.INTL", NetworkdaysIntl)
+	RegisterFunction("_xlfn.NETWORKDAYS.INTL", NetworkdaysIntl)
+}
+
+// maxDateSerial is the serial number of 9999-12-31, the last date of Excel.
+const maxDateSerial = 2958465
+
+// dateSerialArg returns the integer part of a date argument given as a serial
+// number or as text. The error result has type ResultTypeError.
+func dateSerialArg(r Result, fn string) (int, Result) {
+	var v float64
+	switch r.Type {
+	case ResultTypeEmpty:
+	case ResultTypeError:
+		return 0, r
+	case ResultTypeNumber:
+		if r.IsBoolean {
+			return 0, MakeErrorResult("incorrect date for " + fn)
+		}
+		v = r.ValueNumber
+	case ResultTypeString:
+		d := DateValue([]Result{r})
+		if d.Type == ResultTypeError {
+			return 0, MakeErrorResult("incorrect date for " + fn)
+		}
+		v = d.ValueNumber
+	default:
+		return 0, MakeErrorResult("incorrect date for " + fn)
+	}
+	if v < 0 || v >= maxDateSerial+1 {
+		return 0, MakeErrorResultType(ErrorTypeNum, "date out of range for "+fn)
+	}
+	return int(v), MakeEmptyResult()
+}
+
+// weekdayIndex returns the day of the week of a date serial, 0 for Monday to 6
+// for Sunday. Serial 1 is Sunday 1900-01-01 as in Excel, which treats 1900 as a
+// leap year.
+func weekdayIndex(serial int) int {
+	return ((serial-2)%7 + 7) % 7
+}
+
+// Weekday implements the Excel WEEKDAY function. The return type 1 (default)
+// numbers the days from Sunday = 1, 2 from Monday = 1, 3 from Monday = 0 and 11
+// to 17 from Monday = 1 to Sunday = 1.
+func Weekday(args []Result) Result {
+	if len(args) != 1 && len(args) != 2 {
+		return MakeErrorResult("WEEKDAY requires one or two arguments")
+	}
+	serial, err := dateSerialArg(args[0], "WEEKDAY")
+	if err.Type == ResultTypeError {
+		return err
+	}
+	typ := 1
+	if len(args) == 2 && args[1].Type != ResultTypeEmpty {
+		var ok bool
+		if typ, ok = integerArg(args[1]); !ok {
+			return MakeErrorResult("WEEKDAY requires a numeric return type")
+		}
+	}
+	day := weekdayIndex(serial)
+	switch {
+	case typ == 1:
+		return MakeNumberResult(float64((day+1)%7 + 1))
+	case typ == 2:
+		return MakeNumberResult(float64(day + 1))
+	case typ == 3:
+		return MakeNumberResult(float64(day))
+	case typ >= 11 && typ <= 17:
+		return MakeNumberResult(float64((day-(typ-11)+7)%7 + 1))
+	}
+	return MakeErrorResultType(ErrorTypeNum, fmt.Sprintf("invalid WEEKDAY return type %d", typ))
+}
+
+// Weeknum implements the Excel WEEKNUM function. The week that contains
+// January 1 is week 1, weeks start on Sunday for return type 1 (default) and
+// 17, on Monday for 2 and 11 and on Tuesday to Saturday for 12 to 16. Return
+// type 21 numbers the weeks as ISOWEEKNUM.
+func Weeknum(args []Result) Result {
+	if len(args) != 1 && len(args) != 2 {
+		return MakeErrorResult("WEEKNUM requires one or two arguments")
+	}
+	serial, err := dateSerialArg(args[0], "WEEKNUM")
+	if err.Type == ResultTypeError {
+		return err
+	}
+	typ := 1
+	if len(args) == 2 && args[1].Type != ResultTypeEmpty {
+		var ok bool
+		if typ, ok = integerArg(args[1]); !ok {
+			return MakeErrorResult("WEEKNUM requires a numeric return type")
+		}
+	}
+	var start int
+	switch {
+	case typ == 1:
+		start = 6
+	case typ == 2:
+		start = 0
+	case typ >= 11 && typ <= 17:
+		start = typ - 11
+	case typ == 21:
+		return IsoWeeknum(args[:1])
+	default:
+		return MakeErrorResultType(ErrorTypeNum, fmt.Sprintf("invalid WEEKNUM return type %d", typ))
+	}
+	jan1 := yearStartSerial(serial)
+	offset := (weekdayIndex(jan1) - start + 7) % 7
+	// serial 0 is January 0, 1900, which falls in week 0
+	return MakeNumberResult(float64((serial - jan1 + offset + 7) / 7))
+}
+
+// yearStartSerial returns the serial number of January 1 of the year of a date
+// serial. Serials up to 366 are in 1900, which has 366 days in Excel as it
+// counts the nonexistent February 29, 1900, and negative serials in 1899.
+func yearStartSerial(serial int) int {
+	switch {
+	case serial < 0:
+		return -364
+	case serial <= 366:
+		return 1
+	}
+	year := _cdb(float64(serial)).Year()
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return int(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Sub(epoch).Hours() / 24)
+}
+
+// IsoWeeknum implements the Excel ISOWEEKNUM function, it returns the ISO 8601
+// week number of a date.
+func IsoWeeknum(args []Result) Result {
+	if len(args) != 1 {
+		return MakeErrorResult("ISOWEEKNUM requires one argument")
+	}
+	serial, err := dateSerialArg(args[0], "ISOWEEKNUM")
+	if err.Type == ResultTypeError {
+		return err
+	}
+	// the ISO week belongs to the year of its Thursday
+	thursday := serial - weekdayIndex(serial) + 3
+	return MakeNumberResult(float64((thursday-yearStartSerial(thursday))/7 + 1))
+}
+
+// timeOfDay returns the time of a date and time argument in seconds, rounded
+// to the nearest second.
+func timeOfDay(args []Result, fn string) (int, Result) {
+	if len(args) != 1 {
+		return 0, MakeErrorResult(fn + " requires one argument")
+	}
+	var v float64
+	switch r := args[0]; r.Type {
+	case ResultTypeEmpty:
+	case ResultTypeError:
+		return 0, r
+	case ResultTypeNumber:
+		v = r.ValueNumber
+	case ResultTypeString:
+		t := TimeValue([]Result{r})
+		if t.Type == ResultTypeError {
+			return 0, MakeErrorResult("incorrect time for " + fn)
+		}
+		v = t.ValueNumber
+	default:
+		return 0, MakeErrorResult("incorrect time for " + fn)
+	}
+	if v < 0 {
+		return 0, MakeErrorResultType(ErrorTypeNum, fn+" requires a non negative time")
+	}
+	secs := int(math.Round((v - math.Floor(v)) * 86400))
+	return secs % 86400, MakeEmptyResult()
+}
+
+// Hour implements the Excel HOUR function.
+func Hour(args []Result) Result {
+	secs, err := timeOfDay(args, "HOUR")
+	if err.Type == ResultTypeError {
+		return err
+	}
+	return MakeNumberResult(float64(secs / 3600))
+}
+
+// Second implements the Excel SECOND function.
+func Second(args []Result) Result {
+	secs, err := timeOfDay(args, "SECOND")
+	if err.Type == ResultTypeError {
+		return err
+	}
+	return MakeNumberResult(float64(secs % 60))
+}
+
+// weekendMask returns the weekend days, indexed from Monday = 0, of the
+// weekend argument of WORKDAY.INTL and NETWORKDAYS.INTL. It is a number from 1
+// (Saturday and Sunday, the default) to 7 for two day weekends, 11 (Sunday) to
+// 17 (Saturday) for one day weekends, or a string of seven 0s and 1s from
+// Monday to Sunday where 1 is a weekend day, e.g. "0000011".
+func weekendMask(r Result, fn string) ([7]bool, Result) {
+	var mask [7]bool
+	switch r.Type {
+	case ResultTypeEmpty:
+		mask[5], mask[6] = true, true
+	case ResultTypeError:
+		return mask, r
+	case ResultTypeNumber:
+		code := int(r.ValueNumber)
+		switch {
+		case code >= 1 && code <= 7:
+			mask[(code+4)%7], mask[(code+5)%7] = true, true
+		case code >= 11 && code <= 17:
+			mask[(code-5)%7] = true
+		default:
+			return mask, MakeErrorResultType(ErrorTypeNum, fmt.Sprintf("invalid weekend %d for %s", code, fn))
+		}
+	case ResultTypeString:
+		s := r.ValueString
+		if len(s) != 7 {
+			return mask, MakeErrorResult("invalid weekend " + s + " for " + fn)
+		}
+		for i := range s {
+			switch s[i] {
+			case '0':
+			case '1':
+				mask[i] = true
+			default:
+				return mask, MakeErrorResult("invalid weekend " + s + " for " + fn)
+			}
+		}
+	default:
+		return mask, MakeErrorResult("invalid weekend for " + fn)
+	}
+	return mask, MakeEmptyResult()
+}
+
+// holidaySet returns the dates of the holidays argument of the workday
+// functions, a date or a range or array of dates.
+func holidaySet(r Result, fn string) (map[int]bool, Result) {
+	holidays := map[int]bool{}
+	for _, row := range resultRows(r) {
+		for _, v := range row {
+			if v.Type == ResultTypeEmpty {
+				continue
+			}
+			serial, err := dateSerialArg(v, fn)
+			if err.Type == ResultTypeError {
+				return nil, err
+			}
+			holidays[serial] = true
+		}
+	}
+	return holidays, MakeEmptyResult()
+}
+
+// optionalArg returns the argument at index i or an empty result if there
+// are fewer arguments.
+func optionalArg(args []Result, i int) Result {
+	if i < len(args) {
+		return args[i]
+	}
+	return MakeEmptyResult()
+}
+
+// Workday implements the Excel WORKDAY function, it returns the date that is a
+// number of working days before or after a start date, skipping Saturdays,
+// Sundays and holidays.
+func Workday(args []Result) Result {
+	if len(args) < 2 || len(args) > 3 {
+		return MakeErrorResult("WORKDAY requires two or three arguments")
+	}
+	return workday(args[0], args[1], MakeEmptyResult(), optionalArg(args, 2), "WORKDAY")
+}
+
+// WorkdayIntl implements the Excel WORKDAY.INTL function, it is WORKDAY with
+// custom weekend days.
+func WorkdayIntl(args []Result) Result {
+	if len(args) < 2 || len(args) > 4 {
+		return MakeErrorResult("WORKDAY.INTL requires two to four arguments")
+	}
+	return workday(args[0], args[1], optionalArg(args, 2), optionalArg(args, 3), "WORKDAY.INTL")
+}
+
+func workday(startArg, daysArg, weekendArg, holidaysArg Result, fn string) Result {
+	start, err := dateSerialArg(startArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	days := daysArg.AsNumber()
+	if days.Type == ResultTypeError {
+		return days
+	}
+	if days.Type != ResultTypeNumber {
+		return MakeErrorResult(fn + " requires a numeric number of days")
+	}
+	weekend, err := weekendMask(weekendArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	if weekend == [7]bool{true, true, true, true, true, true, true} {
+		return MakeErrorResult(fn + " requires at least one working day in a week")
+	}
+	holidays, err := holidaySet(holidaysArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+
+	n := int(days.ValueNumber)
+	if n > maxDateSerial || n < -maxDateSerial {
+		return MakeErrorResultType(ErrorTypeNum, fn+" result is out of range")
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	d := start
+	for n > 0 {
+		d += step
+		if d < 0 || d > maxDateSerial {
+			return MakeErrorResultType(ErrorTypeNum, fn+" result is out of range")
+		}
+		if !weekend[weekdayIndex(d)] && !holidays[d] {
+			n--
+		}
+	}
+	return MakeNumberResult(float64(d))
+}
+
+// Networkdays implements the Excel NETWORKDAYS function, it returns the number
+// of working days between two dates including both, excluding Saturdays,
+// Sundays and holidays. The result is negative if the end date is before the
+// start date.
+func Networkdays(args []Result) Result {
+	if len(args) < 2 || len(args) > 3 {
+		return MakeErrorResult("NETWORKDAYS requires two or three arguments")
+	}
+	return networkdays(args[0], args[1], MakeEmptyResult(), optionalArg(args, 2), "NETWORKDAYS")
+}
+
+// NetworkdaysIntl implements the Excel NETWORKDAYS.INTL function, it is
+// NETWORKDAYS with custom weekend days.
+func NetworkdaysIntl(args []Result) Result {
+	if len(args) < 2 || len(args) > 4 {
+		return MakeErrorResult("NETWORKDAYS.INTL requires two to four arguments")
+	}
+	return networkdays(args[0], args[1], optionalArg(args, 2), optionalArg(args, 3), "NETWORKDAYS.INTL")
+}
+
+func networkdays(startArg, endArg, weekendArg, holidaysArg Result, fn string) Result {
+	start, err := dateSerialArg(startArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	end, err := dateSerialArg(endArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	weekend, err := weekendMask(weekendArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+	holidays, err := holidaySet(holidaysArg, fn)
+	if err.Type == ResultTypeError {
+		return err
+	}
+
+	sign := 1
+	if end < start {
+		sign, start, end = -1, end, start
+	}
+	perWeek := 0
+	for _, w := range weekend {
+		if !w {
+			perWeek++
+		}
+	}
+	total := end - start + 1
+	count := total / 7 * perWeek
+	for d := start + total/7*7; d <= end; d++ {
+		if !weekend[weekdayIndex(d)] {
+			count++
+		}
+	}
+	for d := range holidays {
+		if d >= start && d <= end && !weekend[weekdayIndex(d)] {
+			count--
+		}
+	}
+	return MakeNumberResult(float64(sign * count))
+}
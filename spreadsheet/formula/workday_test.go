@@ -0,0 +1,85 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package formula
+
+import "testing"
+
+func TestWeekday(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"WEEKDAY(45000)", "4"},
+		{"WEEKDAY(45000,2)", "3"},
+		{"WEEKDAY(45000,3)", "2"},
+		{"WEEKDAY(45000,11)", "3"},
+		{"WEEKDAY(45000,17)", "4"},
+		{"WEEKDAY(1)", "1"},
+		{"WEEKDAY(0)", "7"},
+		{"WEEKDAY(45000,4)", "#NUM!"},
+		{"WEEKDAY(-1)", "#NUM!"},
+	})
+}
+
+func TestWeeknum(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"WEEKNUM(45000)", "11"},
+		{"WEEKNUM(44933,2)", "2"},
+		{"WEEKNUM(44927)", "1"},
+		{"WEEKNUM(44927,2)", "1"},
+		{"WEEKNUM(44928,2)", "2"},
+		{"WEEKNUM(45000,21)", "11"},
+		{"WEEKNUM(45000,3)", "#NUM!"},
+		// dates before March 1, 1900 where Excel counts February 29, 1900
+		{"WEEKNUM(0)", "0"},
+		{"WEEKNUM(1)", "1"},
+		{"WEEKNUM(2,2)", "2"},
+		{"WEEKNUM(7)", "1"},
+		{"WEEKNUM(8)", "2"},
+		{"WEEKNUM(60)", "9"},
+		{"WEEKNUM(61)", "9"},
+		{"WEEKNUM(366)", "53"},
+		{"WEEKNUM(367)", "1"},
+	})
+}
+
+func TestIsoWeeknum(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"ISOWEEKNUM(44927)", "52"},
+		{"ISOWEEKNUM(45291)", "52"},
+		{"ISOWEEKNUM(45292)", "1"},
+		{"ISOWEEKNUM(46022)", "1"},
+		{"ISOWEEKNUM(1)", "52"},
+		{"ISOWEEKNUM(2)", "1"},
+	})
+}
+
+func TestHourSecond(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"HOUR(0.75)", "18"},
+		{"HOUR(45000.5)", "12"},
+		{"SECOND(0.5+15/86400)", "15"},
+		{`HOUR("6:45 PM")`, "18"},
+	})
+}
+
+func TestWorkday(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"WORKDAY(45000,5)", "45007"},
+		{"WORKDAY(45000,-5)", "44993"},
+		{"WORKDAY(45000,5,{45001,45002})", "45009"},
+		{"WORKDAY.INTL(45000,5,7)", "45007"},
+		{"WORKDAY(45000,0)", "45000"},
+	})
+}
+
+func TestNetworkdays(t *testing.T) {
+	runFormulaTests(t, []formulaTest{
+		{"NETWORKDAYS(44927,44957)", "22"},
+		{"NETWORKDAYS(44957,44927)", "-22"},
+		{"NETWORKDAYS(44927,44957,{44927,44928,44929})", "20"},
+		{"NETWORKDAYS.INTL(44927,44957,11)", "26"},
+	})
+}
@@ -0,0 +1,23 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import "github.com/unidoc/unioffice/spreadsheet/formula"
+
+// GetFormulaLocale returns the formula of the cell as it is shown in a
+// localized version of Excel, e.g. SUMME(A1;B1) for de-DE. If the locale is
+// nil, the formula is returned as stored.
+func (c Cell) GetFormulaLocale(loc *formula.Locale) string {
+	return formula.Localize(c.GetFormula(), loc)
+}
+
+// SetFormulaLocale sets the formula of the cell from its localized form, e.g.
+// SUMME(A1;B1) for de-DE. The formula is stored in the en-US form.
+func (c Cell) SetFormulaLocale(f string, loc *formula.Locale) {
+	c.SetFormulaRaw(formula.Delocalize(f, loc))
+}
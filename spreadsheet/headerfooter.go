@@ -0,0 +1,414 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/pkg/relationships"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/vmldrawing"
+)
+
+// HeaderFooterPage selects the pages a header or footer is printed on.
+type HeaderFooterPage byte
+
+// HeaderFooterPage constants.
+const (
+	// HeaderFooterOdd is the header or footer of all pages, or of the odd
+	// pages if the even pages have their own.
+	HeaderFooterOdd HeaderFooterPage = iota
+	HeaderFooterEven
+	HeaderFooterFirst
+)
+
+// HeaderFooterSection is one of the three sections of a header or footer.
+type HeaderFooterSection byte
+
+// HeaderFooterSection constants.
+const (
+	HeaderFooterLeft HeaderFooterSection = iota
+	HeaderFooterCenter
+	HeaderFooterRight
+)
+
+// Codes that are replaced by a value when a header or footer is printed.
+const (
+	HeaderFooterPageNumber = "&P"
+	HeaderFooterPageCount  = "&N"
+	HeaderFooterDate       = "&D"
+	HeaderFooterTime       = "&T"
+	HeaderFooterFileName   = "&F"
+	HeaderFooterFilePath   = "&Z"
+	HeaderFooterSheetName  = "&A"
+	HeaderFooterPicture    = "&G"
+)
+
+// HeaderFooterText is the text of the sections of a header or footer. The
+// text can contain the field codes such as HeaderFooterPageNumber and the
+// formatting codes of Excel, such as &B for bold or &14 for a font size of 14
+// points. A literal ampersand is written as &&.
+type HeaderFooterText struct {
+	Left, Center, Right string
+}
+
+// String returns the header or footer text in the form stored in the file,
+// e.g. "&LConfidential&RPage &P of &N".
+func (t HeaderFooterText) String() string {
+	s := ""
+	if t.Left != "" {
+		s += "&L" + t.Left
+	}
+	if t.Center != "" {
+		s += "&C" + t.Center
+	}
+	if t.Right != "" {
+		s += "&R" + t.Right
+	}
+	return s
+}
+
+func (t *HeaderFooterText) section(sec HeaderFooterSection) *string {
+	switch sec {
+	case HeaderFooterLeft:
+		return &t.Left
+	case HeaderFooterRight:
+		return &t.Right
+	}
+	return &t.Center
+}
+
+// ParseHeaderFooterText splits the text of a header or footer into its
+// sections. Text before the first section code belongs to the center
+// section.
+func ParseHeaderFooterText(s string) HeaderFooterText {
+	t := HeaderFooterText{}
+	sec := t.section(HeaderFooterCenter)
+	start := 0
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != '&' {
+			continue
+		}
+		switch s[i+1] {
+		case 'L', 'C', 'R':
+			*sec += s[start:i]
+			sec = t.section(HeaderFooterSection(strings.IndexByte("LCR", s[i+1])))
+			start = i + 2
+			i++
+		case '"':
+			// font names are quoted and may contain ampersands
+			if end := strings.IndexByte(s[i+2:], '"'); end >= 0 {
+				i += end + 2
+			}
+		default:
+			i++
+		}
+	}
+	*sec += s[start:]
+	return t
+}
+
+// HeaderFooter is the header and footer printed on the pages of a sheet.
+type HeaderFooter struct {
+	s *Sheet
+}
+
+// HeaderFooter returns the header and footer of the sheet.
+func (s *Sheet) HeaderFooter() HeaderFooter { return HeaderFooter{s} }
+
+// X returns the inner wrapped XML type, creating it if necessary.
+func (h HeaderFooter) X() *sml.CT_HeaderFooter {
+	if h.s._eage.HeaderFooter == nil {
+		h.s._eage.HeaderFooter = sml.NewCT_HeaderFooter()
+	}
+	return h.s._eage.HeaderFooter
+}
+
+func (h HeaderFooter) text(page HeaderFooterPage, footer bool) *string {
+	x := h.s._eage.HeaderFooter
+	if x == nil {
+		return nil
+	}
+	return *h.field(x, page, footer)
+}
+
+func (h HeaderFooter) field(x *sml.CT_HeaderFooter, page HeaderFooterPage, footer bool) **string {
+	switch {
+	case page == HeaderFooterEven && footer:
+		return &x.EvenFooter
+	case page == HeaderFooterEven:
+		return &x.EvenHeader
+	case page == HeaderFooterFirst && footer:
+		return &x.FirstFooter
+	case page == HeaderFooterFirst:
+		return &x.FirstHeader
+	case footer:
+		return &x.OddFooter
+	}
+	return &x.OddHeader
+}
+
+func (h HeaderFooter) setText(page HeaderFooterPage, footer bool, text string) {
+	x := h.X()
+	f := h.field(x, page, footer)
+	if text == "" {
+		*f = nil
+		return
+	}
+	*f = unioffice.String(text)
+	switch page {
+	case HeaderFooterEven:
+		x.DifferentOddEvenAttr = unioffice.Bool(true)
+	case HeaderFooterFirst:
+		x.DifferentFirstAttr = unioffice.Bool(true)
+	}
+}
+
+// Header returns the text of the header of the given pages.
+func (h HeaderFooter) Header(page HeaderFooterPage) HeaderFooterText {
+	if t := h.text(page, false); t != nil {
+		return ParseHeaderFooterText(*t)
+	}
+	return HeaderFooterText{}
+}
+
+// SetHeader sets the text of the header of the given pages. Setting the
+// header of the even or the first page makes it differ from the others.
+func (h HeaderFooter) SetHeader(page HeaderFooterPage, t HeaderFooterText) {
+	h.setText(page, false, t.String())
+}
+
+// Footer returns the text of the footer of the given pages.
+func (h HeaderFooter) Footer(page HeaderFooterPage) HeaderFooterText {
+	if t := h.text(page, true); t != nil {
+		return ParseHeaderFooterText(*t)
+	}
+	return HeaderFooterText{}
+}
+
+// SetFooter sets the text of the footer of the given pages. Setting the
+// footer of the even or the first page makes it differ from the others.
+func (h HeaderFooter) SetFooter(page HeaderFooterPage, t HeaderFooterText) {
+	h.setText(page, true, t.String())
+}
+
+// DifferentOddEven returns true if the even pages have their own header and
+// footer.
+func (h HeaderFooter) DifferentOddEven() bool {
+	x := h.s._eage.HeaderFooter
+	return x != nil && boolValue(x.DifferentOddEvenAttr)
+}
+
+// SetDifferentOddEven controls if the even pages have their own header and
+// footer.
+func (h HeaderFooter) SetDifferentOddEven(b bool) { h.X().DifferentOddEvenAttr = boolAttr(b) }
+
+// DifferentFirst returns true if the first page has its own header and
+// footer.
+func (h HeaderFooter) DifferentFirst() bool {
+	x := h.s._eage.HeaderFooter
+	return x != nil && boolValue(x.DifferentFirstAttr)
+}
+
+// SetDifferentFirst controls if the first page has its own header and footer.
+func (h HeaderFooter) SetDifferentFirst(b bool) { h.X().DifferentFirstAttr = boolAttr(b) }
+
+// SetScaleWithDocument controls if the header and footer are scaled with the
+// sheet when it is printed at another scale, which is the default.
+func (h HeaderFooter) SetScaleWithDocument(b bool) { h.X().ScaleWithDocAttr = unioffice.Bool(b) }
+
+// SetAlignWithMargins controls if the header and footer are aligned with the
+// left and right page margins, which is the default.
+func (h HeaderFooter) SetAlignWithMargins(b bool) { h.X().AlignWithMarginsAttr = unioffice.Bool(b) }
+
+// SetHeaderImage sets the picture shown in a section of the header of the
+// given pages, and adds the picture code to the text of the section if it is
+// missing. The image must have been added to the workbook with AddImage.
+func (h HeaderFooter) SetHeaderImage(page HeaderFooterPage, sec HeaderFooterSection, img common.ImageRef, width, height measurement.Distance) error {
+	return h.setImage(page, sec, false, img, width, height)
+}
+
+// SetFooterImage sets the picture shown in a section of the footer of the
+// given pages, and adds the picture code to the text of the section if it is
+// missing. The image must have been added to the workbook with AddImage.
+func (h HeaderFooter) SetFooterImage(page HeaderFooterPage, sec HeaderFooterSection, img common.ImageRef, width, height measurement.Distance) error {
+	return h.setImage(page, sec, true, img, width, height)
+}
+
+// RemoveHeaderImage removes the picture of a section of the header of the
+// given pages.
+func (h HeaderFooter) RemoveHeaderImage(page HeaderFooterPage, sec HeaderFooterSection) {
+	h.removeImage(page, sec, false)
+}
+
+// RemoveFooterImage removes the picture of a section of the footer of the
+// given pages.
+func (h HeaderFooter) RemoveFooterImage(page HeaderFooterPage, sec HeaderFooterSection) {
+	h.removeImage(page, sec, true)
+}
+
+// imageShapeID returns the id of the shape of the picture of a section, such
+// as "LH" for the left of the header or "CFFIRST" for the center of the
+// footer of the first page.
+func imageShapeID(page HeaderFooterPage, sec HeaderFooterSection, footer bool) string {
+	id := string("LCR"[sec])
+	if footer {
+		id += "F"
+	} else {
+		id += "H"
+	}
+	switch page {
+	case HeaderFooterEven:
+		id += "EVEN"
+	case HeaderFooterFirst:
+		id += "FIRST"
+	}
+	return id
+}
+
+func (h HeaderFooter) setImage(page HeaderFooterPage, sec HeaderFooterSection, footer bool, img common.ImageRef, width, height measurement.Distance) error {
+	wb := h.s._gccb
+	idx := -1
+	for i, ir := range wb.Images {
+		if ir == img {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return errors.New("image must be added to the workbook with AddImage")
+	}
+	if sec > HeaderFooterRight {
+		return fmt.Errorf("invalid header and footer section %d", sec)
+	}
+	h.removeImage(page, sec, footer)
+
+	drawing, rels := h.s.headerFooterDrawing(true)
+	rel := rels.AddRelationship(fmt.Sprintf("../media/image%d.%s", idx+1, img.Format()), unioffice.ImageType)
+	spid := 1025
+	for _, shape := range drawing.Shape {
+		if shape.SpidAttr == nil {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(*shape.SpidAttr, "_x0000_s")); err == nil && n >= spid {
+			spid = n + 1
+		}
+	}
+	shape := vmldrawing.NewImageShape(imageShapeID(page, sec, footer), rel.ID(), spid,
+		float64(width/measurement.Point), float64(height/measurement.Point))
+	drawing.Shape = append(drawing.Shape, shape)
+
+	t := ParseHeaderFooterText(h.textOf(page, footer))
+	if s := t.section(sec); !strings.Contains(*s, HeaderFooterPicture) {
+		*s += HeaderFooterPicture
+	}
+	h.setText(page, footer, t.String())
+	return nil
+}
+
+func (h HeaderFooter) removeImage(page HeaderFooterPage, sec HeaderFooterSection, footer bool) {
+	drawing, rels := h.s.headerFooterDrawing(false)
+	if drawing == nil {
+		return
+	}
+	id := imageShapeID(page, sec, footer)
+	kept := drawing.Shape[:0]
+	for _, shape := range drawing.Shape {
+		if shape.IdAttr == nil || *shape.IdAttr != id {
+			kept = append(kept, shape)
+			continue
+		}
+		for _, el := range shape.EG_ShapeElements {
+			if el.Imagedata != nil && el.Imagedata.RelidAttr != nil {
+				rels.Remove(rels.GetByRelId(*el.Imagedata.RelidAttr))
+			}
+		}
+	}
+	if len(kept) == len(drawing.Shape) {
+		return
+	}
+	drawing.Shape = kept
+
+	t := ParseHeaderFooterText(h.textOf(page, footer))
+	s := t.section(sec)
+	*s = strings.Replace(*s, HeaderFooterPicture, "", -1)
+	h.setText(page, footer, t.String())
+}
+
+func (h HeaderFooter) textOf(page HeaderFooterPage, footer bool) string {
+	if t := h.text(page, footer); t != nil {
+		return *t
+	}
+	return ""
+}
+
+// headerFooterDrawing returns the drawing with the pictures of the header and
+// footer of the sheet and its relationships, creating it if requested.
+func (s *Sheet) headerFooterDrawing(create bool) (*vmldrawing.Container, common.Relationships) {
+	wb := s._gccb
+	rels := s.relationships()
+	if hf := s._eage.LegacyDrawingHF; hf != nil {
+		target := rels.GetTargetByRelId(hf.IdAttr)
+		if m := vmlDrawingTarget.FindStringSubmatch(target); m != nil {
+			idx, _ := strconv.Atoi(m[1])
+			if idx >= 1 && idx <= len(wb._bcag) {
+				drawing := wb._bcag[idx-1]
+				return drawing, wb.vmlRelationships(drawing)
+			}
+		}
+	}
+	if !create {
+		return nil, common.Relationships{}
+	}
+	drawing := vmldrawing.NewImageDrawing()
+	wb._bcag = append(wb._bcag, drawing)
+	// the relationships may be shared with a copy of the sheet
+	rels.X().Relationship = append([]*relationships.Relationship{}, rels.X().Relationship...)
+	rel := rels.AddAutoRelationship(unioffice.DocTypeSpreadsheet, unioffice.WorksheetType, len(wb._bcag), unioffice.VMLDrawingType)
+	s._eage.LegacyDrawingHF = sml.NewCT_LegacyDrawing()
+	s._eage.LegacyDrawingHF.IdAttr = rel.ID()
+	return drawing, wb.vmlRelationships(drawing)
+}
+
+// vmlRelationships returns the relationships of a VML drawing, such as those
+// to the pictures of a header, creating them if necessary.
+func (wb *Workbook) vmlRelationships(c *vmldrawing.Container) common.Relationships {
+	if rels, ok := wb._cgbad[c]; ok {
+		return rels
+	}
+	rels := common.NewRelationships()
+	wb.setVMLRelationships(c, rels)
+	return rels
+}
+
+func (wb *Workbook) setVMLRelationships(c *vmldrawing.Container, rels common.Relationships) {
+	if wb._cgbad == nil {
+		wb._cgbad = map[*vmldrawing.Container]common.Relationships{}
+	}
+	wb._cgbad[c] = rels
+}
+
+// copyVMLRelationships copies the relationships of a VML drawing to its
+// copy.
+func (wb *Workbook) copyVMLRelationships(from, to *vmldrawing.Container) {
+	rels, ok := wb._cgbad[from]
+	if !ok {
+		return
+	}
+	cp := common.NewRelationships()
+	for _, r := range rels.X().Relationship {
+		rel := *r
+		cp.X().Relationship = append(cp.X().Relationship, &rel)
+	}
+	wb.setVMLRelationships(to, cp)
+}
@@ -0,0 +1,205 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/measurement"
+)
+
+func TestParseHeaderFooterText(t *testing.T) {
+	td := []struct {
+		Inp string
+		Exp HeaderFooterText
+	}{
+		{"", HeaderFooterText{}},
+		{"Title", HeaderFooterText{Center: "Title"}},
+		{"&LConfidential&RPage &P of &N", HeaderFooterText{Left: "Confidential", Right: "Page &P of &N"}},
+		{"&C&BBold&B &&L&R&D", HeaderFooterText{Center: "&BBold&B &&L", Right: "&D"}},
+		{`&L&"Arial,&Bold"Name&C&14Big`, HeaderFooterText{Left: `&"Arial,&Bold"Name`, Center: "&14Big"}},
+		{"&RRight&LLeft", HeaderFooterText{Left: "Left", Right: "Right"}},
+	}
+	for _, tc := range td {
+		if got := ParseHeaderFooterText(tc.Inp); got != tc.Exp {
+			t.Errorf("%q: expected %+v, got %+v", tc.Inp, tc.Exp, got)
+		}
+	}
+	txt := HeaderFooterText{Left: "a", Right: HeaderFooterPageNumber}
+	if got := txt.String(); got != "&La&R&P" {
+		t.Errorf("expected &La&R&P, got %s", got)
+	}
+}
+
+func TestHeaderFooterRoundTrip(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	hf := sheet.HeaderFooter()
+	odd := HeaderFooterText{Left: HeaderFooterSheetName, Right: "Page " + HeaderFooterPageNumber + " of " + HeaderFooterPageCount}
+	even := HeaderFooterText{Center: "Even"}
+	first := HeaderFooterText{Center: "&BReport&B"}
+	hf.SetHeader(HeaderFooterOdd, odd)
+	hf.SetFooter(HeaderFooterEven, even)
+	hf.SetHeader(HeaderFooterFirst, first)
+	if !hf.DifferentOddEven() || !hf.DifferentFirst() {
+		t.Errorf("expected the even and first pages to differ")
+	}
+
+	rd := saveAndRead(t, wb)
+	got := rd.Sheets()[0].HeaderFooter()
+	if got.Header(HeaderFooterOdd) != odd || got.Footer(HeaderFooterEven) != even || got.Header(HeaderFooterFirst) != first {
+		t.Errorf("expected the headers and footers to be preserved")
+	}
+	if got.Footer(HeaderFooterOdd) != (HeaderFooterText{}) {
+		t.Errorf("expected no footer on the odd pages")
+	}
+	if *got.X().OddHeader != "&L&A&RPage &P of &N" {
+		t.Errorf("unexpected header %s", *got.X().OddHeader)
+	}
+	got.SetHeader(HeaderFooterOdd, HeaderFooterText{})
+	got.SetDifferentFirst(false)
+	if got.X().OddHeader != nil || got.DifferentFirst() {
+		t.Errorf("expected the header to be removed")
+	}
+}
+
+func TestHeaderFooterImage(t *testing.T) {
+	buf := bytes.Buffer{}
+	png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)))
+	data := buf.Bytes()
+
+	wb := New()
+	sheet := wb.AddSheet()
+	img, err := wb.AddImage(common.Image{Size: image.Point{2, 2}, Format: "png", Data: &data})
+	if err != nil {
+		t.Fatalf("error adding image: %s", err)
+	}
+	hf := sheet.HeaderFooter()
+	hf.SetHeader(HeaderFooterOdd, HeaderFooterText{Left: "Logo "})
+	if err := hf.SetHeaderImage(HeaderFooterOdd, HeaderFooterLeft, img, 40*measurement.Point, 20*measurement.Point); err != nil {
+		t.Fatalf("error setting header image: %s", err)
+	}
+	if err := hf.SetFooterImage(HeaderFooterFirst, HeaderFooterRight, img, 40*measurement.Point, 20*measurement.Point); err != nil {
+		t.Fatalf("error setting footer image: %s", err)
+	}
+	if err := hf.SetFooterImage(HeaderFooterOdd, HeaderFooterRight, common.ImageRef{}, 1, 1); err == nil {
+		t.Errorf("expected an error for an image that isn't part of the workbook")
+	}
+	// setting the picture again replaces it
+	hf.SetHeaderImage(HeaderFooterOdd, HeaderFooterLeft, img, 80*measurement.Point, 40*measurement.Point)
+	if got := hf.Header(HeaderFooterOdd).Left; got != "Logo &G" {
+		t.Errorf("expected the picture code to be added once, got %q", got)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	drawing, rels := rs.headerFooterDrawing(false)
+	if drawing == nil {
+		t.Fatalf("expected a drawing with the pictures")
+	}
+	ids := map[string]bool{}
+	for _, shape := range drawing.Shape {
+		if shape.IdAttr != nil {
+			ids[*shape.IdAttr] = true
+		}
+	}
+	if len(ids) != 2 || !ids["LH"] || !ids["RFFIRST"] {
+		t.Errorf("expected the pictures LH and RFFIRST, got %v", ids)
+	}
+	if len(rels.X().Relationship) != 2 {
+		t.Errorf("expected a relationship per picture, got %d", len(rels.X().Relationship))
+	}
+	if got := rs.HeaderFooter().Footer(HeaderFooterFirst).Right; got != HeaderFooterPicture {
+		t.Errorf("expected the picture code in the footer, got %q", got)
+	}
+
+	hf = rs.HeaderFooter()
+	hf.RemoveHeaderImage(HeaderFooterOdd, HeaderFooterLeft)
+	if got := hf.Header(HeaderFooterOdd).Left; got != "Logo " {
+		t.Errorf("expected the picture code to be removed, got %q", got)
+	}
+	if len(drawing.Shape) != 1 || len(rels.X().Relationship) != 1 {
+		t.Errorf("expected the picture and its relationship to be removed")
+	}
+}
+
+func TestHeaderFooterImageMovedSheets(t *testing.T) {
+	buf := bytes.Buffer{}
+	png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)))
+	data := buf.Bytes()
+
+	wb := New()
+	img, err := wb.AddImage(common.Image{Size: image.Point{2, 2}, Format: "png", Data: &data})
+	if err != nil {
+		t.Fatalf("error adding image: %s", err)
+	}
+	first := wb.AddSheet()
+	first.SetName("First")
+	first.Comments().AddCommentWithStyle("A1", "Ann", "first note")
+	second := wb.AddSheet()
+	second.SetName("Second")
+	second.HeaderFooter().SetHeaderImage(HeaderFooterOdd, HeaderFooterLeft, img, 40*measurement.Point, 20*measurement.Point)
+	third := wb.AddSheet()
+	third.SetName("Third")
+	third.Comments().AddCommentWithStyle("C3", "Ann", "third note")
+	third.HeaderFooter().SetFooterImage(HeaderFooterOdd, HeaderFooterRight, img, 40*measurement.Point, 20*measurement.Point)
+
+	shapes := func(s *Sheet) map[string]bool {
+		ids := map[string]bool{}
+		drawing, _ := s.headerFooterDrawing(false)
+		if drawing == nil {
+			return ids
+		}
+		for _, shape := range drawing.Shape {
+			if shape.IdAttr != nil {
+				ids[*shape.IdAttr] = true
+			}
+		}
+		return ids
+	}
+	check := func(name string, rd *Workbook, order ...string) {
+		t.Helper()
+		sheets := rd.Sheets()
+		if len(sheets) != len(order) {
+			t.Fatalf("%s: expected %d sheets, got %d", name, len(order), len(sheets))
+		}
+		for i, s := range sheets {
+			if s.Name() != order[i] {
+				t.Fatalf("%s: expected the sheet %s at %d, got %s", name, order[i], i, s.Name())
+			}
+			ids := shapes(&sheets[i])
+			switch s.Name() {
+			case "Second":
+				if len(ids) != 1 || !ids["LH"] {
+					t.Errorf("%s: expected the header picture on Second, got %v", name, ids)
+				}
+			case "Third":
+				if len(ids) != 1 || !ids["RF"] {
+					t.Errorf("%s: expected the footer picture on Third, got %v", name, ids)
+				}
+				if note, ok := noteText(&sheets[i], "C3"); !ok || !strings.Contains(note, "third note") {
+					t.Errorf("%s: expected the note of Third, got %q", name, note)
+				}
+			}
+		}
+	}
+
+	if err := wb.RemoveSheet(0); err != nil {
+		t.Fatalf("error removing sheet: %s", err)
+	}
+	rd := saveAndRead(t, wb)
+	check("removed", rd, "Second", "Third")
+	rd = reverseSheets(t, rd)
+	check("reordered", rd, "Third", "Second")
+	check("saved", saveAndRead(t, rd), "Third", "Second")
+}
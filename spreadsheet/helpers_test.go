@@ -0,0 +1,52 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+)
+
+func init() {
+	// round trip tests save workbooks without a license key
+	_becd = true
+}
+
+// saveAndRead saves a workbook and reads it back.
+func saveAndRead(t *testing.T, wb *Workbook) *Workbook {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := wb.Save(&buf); err != nil {
+		t.Fatalf("error saving workbook: %s", err)
+	}
+	rd, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("error reading workbook: %s", err)
+	}
+	return rd
+}
+
+// expectResult evaluates a formula, numbers are compared with a small
+// tolerance and other values by their text.
+func expectResult(t *testing.T, ctx formula.Context, f, exp string) {
+	t.Helper()
+	res := formula.NewEvaluator().Eval(ctx, f)
+	if x, err := strconv.ParseFloat(exp, 64); err == nil && res.Type == formula.ResultTypeNumber {
+		if math.Abs(res.ValueNumber-x) > 1e-9*math.Max(1, math.Abs(x)) {
+			t.Errorf("expected %s = %s, got %v", f, exp, res.ValueNumber)
+		}
+		return
+	}
+	if res.Value() != exp {
+		t.Errorf("expected %s = %s, got %q (%s)", f, exp, res.Value(), res.ErrorMessage)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/schema/urn/schemas_microsoft_com/office/excel"
+	"github.com/unidoc/unioffice/schema/urn/schemas_microsoft_com/vml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+	"github.com/unidoc/unioffice/vmldrawing"
+)
+
+// Text returns the text of the comment without formatting.
+func (c Comment) Text() string {
+	if c._gbbd.Text == nil {
+		return ""
+	}
+	return RichText{c._gbbd.Text}.Text()
+}
+
+// RichText returns the formatted text of the comment.
+func (c Comment) RichText() RichText {
+	if c._gbbd.Text == nil {
+		c._gbbd.Text = sml.NewCT_Rst()
+	}
+	return RichText{c._gbbd.Text}
+}
+
+// IsVisible returns true if the note box of the comment is always shown, and
+// not only when the mouse is over the cell.
+func (c Comment) IsVisible() bool {
+	shape := c.shape()
+	if shape == nil || shape.StyleAttr == nil {
+		return false
+	}
+	return styleProperty(*shape.StyleAttr, "visibility") == "visible"
+}
+
+// SetVisible controls whether the note box of the comment is always shown.
+func (c Comment) SetVisible(b bool) error {
+	shape := c.shape()
+	if shape == nil {
+		return fmt.Errorf("no shape for comment at %s", c.CellReference())
+	}
+	visibility := "hidden"
+	if b {
+		visibility = "visible"
+	}
+	style := ""
+	if shape.StyleAttr != nil {
+		style = *shape.StyleAttr
+	}
+	style = setStyleProperty(style, "visibility", visibility)
+	shape.StyleAttr = &style
+	if cd := shapeClientData(shape); cd != nil {
+		cd.Visible = sharedTypes.ST_TrueFalseBlankUnset
+		if b {
+			cd.Visible = sharedTypes.ST_TrueFalseBlankT
+		}
+	}
+	return nil
+}
+
+// SetSize sets the size of the note box of the comment. The box keeps its top
+// left corner and is anchored to the cells it covers, based on the column
+// widths and row heights of the sheet.
+func (c Comment) SetSize(width, height measurement.Distance) error {
+	shape := c.shape()
+	if shape == nil {
+		return fmt.Errorf("no shape for comment at %s", c.CellReference())
+	}
+	style := ""
+	if shape.StyleAttr != nil {
+		style = *shape.StyleAttr
+	}
+	style = setStyleProperty(style, "width", strconv.FormatFloat(float64(width/measurement.Point), 'f', -1, 64)+"pt")
+	style = setStyleProperty(style, "height", strconv.FormatFloat(float64(height/measurement.Point), 'f', -1, 64)+"pt")
+	shape.StyleAttr = &style
+
+	cd := shapeClientData(shape)
+	sheet, ok := Comments{c._dcf, c._bed}.sheet()
+	if cd == nil || cd.Anchor == nil || !ok {
+		return nil
+	}
+	anchor := []int{}
+	for _, v := range strings.Split(*cd.Anchor, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid anchor %s", *cd.Anchor)
+		}
+		anchor = append(anchor, n)
+	}
+	if len(anchor) != 8 {
+		return fmt.Errorf("invalid anchor %s", *cd.Anchor)
+	}
+	// the anchor is the column, x offset, row and y offset in pixels of the top
+	// left and bottom right corners
+	anchor[4], anchor[5] = advance(anchor[0], anchor[1], int(width/measurement.Pixel96+0.5), sheet.columnPixels)
+	anchor[6], anchor[7] = advance(anchor[2], anchor[3], int(height/measurement.Pixel96+0.5), sheet.rowPixels)
+	parts := make([]string, len(anchor))
+	for i, v := range anchor {
+		parts[i] = strconv.Itoa(v)
+	}
+	a := strings.Join(parts, ", ")
+	cd.Anchor = &a
+	return nil
+}
+
+// advance returns the cell and offset that are px pixels after the given cell
+// and offset.
+func advance(cell, offset, px int, size func(int) int) (int, int) {
+	px += offset
+	for i := 0; i < 16384; i++ {
+		s := size(cell)
+		if px < s {
+			break
+		}
+		px -= s
+		cell++
+	}
+	return cell, px
+}
+
+// columnPixels returns the width of a column in pixels, col being zero based.
+func (s *Sheet) columnPixels(col int) int {
+	width := s.defaultColumnWidth()
+	for _, cols := range s._eage.Cols {
+		for _, c := range cols.Col {
+			if uint32(col+1) < c.MinAttr || uint32(col+1) > c.MaxAttr {
+				continue
+			}
+			if c.HiddenAttr != nil && *c.HiddenAttr {
+				return 0
+			}
+			if c.WidthAttr != nil {
+				width = *c.WidthAttr
+			}
+		}
+	}
+	// the width is in characters of the default font including 5 pixels of
+	// padding, a character of Calibri 11pt being 7 pixels wide
+	return int(width*7 + 0.5)
+}
+
+// rowPixels returns the height of a row in pixels, row being zero based.
+func (s *Sheet) rowPixels(row int) int {
+	height := 15.0
+	if s._eage.SheetFormatPr != nil && s._eage.SheetFormatPr.DefaultRowHeightAttr != 0 {
+		height = s._eage.SheetFormatPr.DefaultRowHeightAttr
+	}
+	for _, r := range s._eage.SheetData.Row {
+		if r.RAttr == nil || *r.RAttr != uint32(row+1) {
+			continue
+		}
+		if r.HiddenAttr != nil && *r.HiddenAttr {
+			return 0
+		}
+		if r.HtAttr != nil {
+			height = *r.HtAttr
+		}
+		break
+	}
+	return int(height*96/72 + 0.5)
+}
+
+// shape returns the VML shape that displays the note box of the comment.
+func (c Comment) shape() *vml.Shape {
+	drawing := Comments{c._dcf, c._bed}.vmlDrawing()
+	if drawing == nil {
+		return nil
+	}
+	ref, err := reference.ParseCellReference(c._gbbd.RefAttr)
+	if err != nil {
+		return nil
+	}
+	return noteShape(drawing, int64(ref.RowIdx-1), int64(ref.ColumnIdx))
+}
+
+// noteShape returns the shape of the note of a cell, row and col being zero
+// based.
+func noteShape(drawing *vmldrawing.Container, row, col int64) *vml.Shape {
+	for _, shape := range drawing.Shape {
+		cd := shapeClientData(shape)
+		if cd == nil || cd.ObjectTypeAttr != excel.ST_ObjectTypeNote {
+			continue
+		}
+		if cd.Row != nil && *cd.Row == row && cd.Column != nil && *cd.Column == col {
+			return shape
+		}
+	}
+	return nil
+}
+
+// removeNoteShape removes the shape of the note of a cell from a drawing.
+func removeNoteShape(drawing *vmldrawing.Container, row, col int64) {
+	shape := noteShape(drawing, row, col)
+	for i, s := range drawing.Shape {
+		if s == shape {
+			drawing.Shape = append(drawing.Shape[:i], drawing.Shape[i+1:]...)
+			return
+		}
+	}
+}
+
+func shapeClientData(shape *vml.Shape) *excel.ClientData {
+	for _, el := range shape.EG_ShapeElements {
+		if el.ClientData != nil {
+			return el.ClientData
+		}
+	}
+	return nil
+}
+
+// styleProperty returns the value of a property of a VML style attribute.
+func styleProperty(style, name string) string {
+	for _, p := range strings.Split(style, ";") {
+		kv := strings.SplitN(p, ":", 2)
+		if len(kv) == 2 && strings.TrimSpace(kv[0]) == name {
+			return strings.TrimSpace(kv[1])
+		}
+	}
+	return ""
+}
+
+// setStyleProperty sets the value of a property of a VML style attribute,
+// keeping the other properties.
+func setStyleProperty(style, name, value string) string {
+	props := []string{}
+	found := false
+	for _, p := range strings.Split(style, ";") {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		kv := strings.SplitN(p, ":", 2)
+		if strings.TrimSpace(kv[0]) == name {
+			p = name + ":" + value
+			found = true
+		}
+		props = append(props, p)
+	}
+	if !found {
+		props = append(props, name+":"+value)
+	}
+	return strings.Join(props, ";")
+}
+
+// sheet returns the sheet the comments belong to.
+func (c Comments) sheet() (Sheet, bool) {
+	if c._ebg == nil {
+		return Sheet{}, false
+	}
+	for i, cm := range c._ebg._efcda {
+		if cm == c._bae && cm != nil {
+			return Sheet{c._ebg, c._ebg._feeg.Sheets.Sheet[i], c._ebg._dcfb[i]}, true
+		}
+	}
+	return Sheet{}, false
+}
+
+// existingComments returns the comments of the sheet if it has any, unlike
+// Comments it doesn't create them.
+func (s *Sheet) existingComments() (Comments, bool) {
+	for i, ws := range s._gccb._dcfb {
+		if ws == s._eage && s._gccb._efcda[i] != nil {
+			return Comments{s._gccb, s._gccb._efcda[i]}, true
+		}
+	}
+	return Comments{}, false
+}
+
+// vmlDrawing returns the VML drawing that holds the note shapes of the sheet
+// the comments belong to.
+func (c Comments) vmlDrawing() *vmldrawing.Container {
+	if s, ok := c.sheet(); ok {
+		if d := s.vmlDrawing(); d != nil {
+			return d
+		}
+	}
+	if c._ebg != nil && len(c._ebg._bcag) > 0 {
+		return c._ebg._bcag[0]
+	}
+	return nil
+}
+
+var vmlDrawingTarget = regexp.MustCompile(`vmlDrawing(\d+)\.vml$`)
+
+// vmlDrawing returns the legacy VML drawing of the sheet, which holds the
+// shapes of notes and form controls.
+func (s *Sheet) vmlDrawing() *vmldrawing.Container {
+	if s._eage.LegacyDrawing == nil {
+		return nil
+	}
+	for _, r := range s.relationships().X().Relationship {
+		if r.IdAttr != s._eage.LegacyDrawing.IdAttr {
+			continue
+		}
+		m := vmlDrawingTarget.FindStringSubmatch(r.TargetAttr)
+		if m == nil {
+			return nil
+		}
+		idx, _ := strconv.Atoi(m[1])
+		if idx < 1 || idx > len(s._gccb._bcag) {
+			return nil
+		}
+		return s._gccb._bcag[idx-1]
+	}
+	return nil
+}
@@ -0,0 +1,496 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// maxOutlineLevel is the deepest level of nested groups supported by Excel.
+const maxOutlineLevel = 7
+
+const (
+	maxRows    = 1048576
+	maxColumns = 16384
+)
+
+// OutlineLevel returns the outline level of the row, zero if it isn't part of
+// a group.
+func (r Row) OutlineLevel() uint8 { return rowLevel(r._cbge) }
+
+// IsCollapsed returns true if the row is the summary row of a collapsed group.
+func (r Row) IsCollapsed() bool {
+	return r._cbge.CollapsedAttr != nil && *r._cbge.CollapsedAttr
+}
+
+// OutlineLevel returns the outline level of the column, zero if it isn't part
+// of a group.
+func (c Column) OutlineLevel() uint8 { return colLevel(c._abeb) }
+
+// IsHidden returns true if the column is hidden.
+func (c Column) IsHidden() bool { return c._abeb.HiddenAttr != nil && *c._abeb.HiddenAttr }
+
+// IsCollapsed returns true if the column is the summary column of a collapsed
+// group.
+func (c Column) IsCollapsed() bool {
+	return c._abeb.CollapsedAttr != nil && *c._abeb.CollapsedAttr
+}
+
+func rowLevel(r *sml.CT_Row) uint8 {
+	if r == nil || r.OutlineLevelAttr == nil {
+		return 0
+	}
+	return *r.OutlineLevelAttr
+}
+
+func colLevel(c *sml.CT_Col) uint8 {
+	if c == nil || c.OutlineLevelAttr == nil {
+		return 0
+	}
+	return *c.OutlineLevelAttr
+}
+
+func levelAttr(l uint8) *uint8 {
+	if l == 0 {
+		return nil
+	}
+	return &l
+}
+
+func boolAttr(b bool) *bool {
+	if !b {
+		return nil
+	}
+	return unioffice.Bool(true)
+}
+
+// OutlineSummary returns whether the summary rows of row groups are below the
+// groups and the summary columns of column groups are to their right, which is
+// the default.
+func (s *Sheet) OutlineSummary() (below, right bool) {
+	below, right = true, true
+	if s._eage.SheetPr == nil || s._eage.SheetPr.OutlinePr == nil {
+		return
+	}
+	op := s._eage.SheetPr.OutlinePr
+	if op.SummaryBelowAttr != nil {
+		below = *op.SummaryBelowAttr
+	}
+	if op.SummaryRightAttr != nil {
+		right = *op.SummaryRightAttr
+	}
+	return
+}
+
+// SetOutlineSummary controls whether the summary rows of row groups are below
+// the groups or above them, and whether the summary columns of column groups
+// are to their right or to their left. The summary row or column holds the
+// button that collapses and expands the group.
+func (s *Sheet) SetOutlineSummary(below, right bool) {
+	if s._eage.SheetPr == nil {
+		s._eage.SheetPr = sml.NewCT_SheetPr()
+	}
+	if s._eage.SheetPr.OutlinePr == nil {
+		s._eage.SheetPr.OutlinePr = sml.NewCT_OutlinePr()
+	}
+	op := s._eage.SheetPr.OutlinePr
+	op.SummaryBelowAttr = nil
+	if !below {
+		op.SummaryBelowAttr = unioffice.Bool(false)
+	}
+	op.SummaryRightAttr = nil
+	if !right {
+		op.SummaryRightAttr = unioffice.Bool(false)
+	}
+}
+
+func checkOutlineRange(first, last, max uint32) error {
+	if first < 1 || first > last || last > max {
+		return fmt.Errorf("invalid range %d-%d", first, last)
+	}
+	return nil
+}
+
+// GroupRows adds the rows first to last, which are one based, to a group
+// nested in the groups they are already part of, and collapses the group if
+// collapsed is true.
+func (s *Sheet) GroupRows(first, last uint32, collapsed bool) error {
+	if err := checkOutlineRange(first, last, maxRows); err != nil {
+		return err
+	}
+	rows := s.rowMap()
+	for i := first; i <= last; i++ {
+		if rowLevel(rows[i]) >= maxOutlineLevel {
+			return fmt.Errorf("row %d is already in %d nested groups", i, maxOutlineLevel)
+		}
+	}
+	for i := first; i <= last; i++ {
+		row := s.Row(i).X()
+		row.OutlineLevelAttr = levelAttr(rowLevel(row) + 1)
+	}
+	s.updateOutlineLevels()
+	if collapsed {
+		return s.SetRowGroupCollapsed(first, last, true)
+	}
+	return nil
+}
+
+// UngroupRows removes the rows first to last, which are one based, from the
+// innermost group they are part of. A collapsed group is expanded first.
+func (s *Sheet) UngroupRows(first, last uint32) error {
+	if err := checkOutlineRange(first, last, maxRows); err != nil {
+		return err
+	}
+	o := s.rowOutline()
+	if summary, ok := o.summary(first, last); ok && o.isCollapsed(summary) {
+		if err := s.SetRowGroupCollapsed(first, last, false); err != nil {
+			return err
+		}
+	}
+	for _, row := range s._eage.SheetData.Row {
+		if row.RAttr == nil || *row.RAttr < first || *row.RAttr > last {
+			continue
+		}
+		if l := rowLevel(row); l > 0 {
+			row.OutlineLevelAttr = levelAttr(l - 1)
+		}
+	}
+	s.updateOutlineLevels()
+	return nil
+}
+
+// SetRowGroupCollapsed collapses or expands the group made of the rows first
+// to last, which are one based. Collapsing hides the rows, expanding shows
+// them again except for those in nested groups that are still collapsed.
+func (s *Sheet) SetRowGroupCollapsed(first, last uint32, collapsed bool) error {
+	if err := checkOutlineRange(first, last, maxRows); err != nil {
+		return err
+	}
+	return s.rowOutline().collapse(first, last, collapsed)
+}
+
+// GroupColumns adds the columns first to last, which are one based, to a
+// group nested in the groups they are already part of, and collapses the
+// group if collapsed is true.
+func (s *Sheet) GroupColumns(first, last uint32, collapsed bool) error {
+	if err := checkOutlineRange(first, last, maxColumns); err != nil {
+		return err
+	}
+	cols := s.columnRange(first, last)
+	for _, c := range cols {
+		if colLevel(c) >= maxOutlineLevel {
+			return fmt.Errorf("column %d is already in %d nested groups", c.MinAttr, maxOutlineLevel)
+		}
+	}
+	for _, c := range cols {
+		c.OutlineLevelAttr = levelAttr(colLevel(c) + 1)
+	}
+	s.updateOutlineLevels()
+	if collapsed {
+		return s.SetColumnGroupCollapsed(first, last, true)
+	}
+	return nil
+}
+
+// UngroupColumns removes the columns first to last, which are one based, from
+// the innermost group they are part of. A collapsed group is expanded first.
+func (s *Sheet) UngroupColumns(first, last uint32) error {
+	if err := checkOutlineRange(first, last, maxColumns); err != nil {
+		return err
+	}
+	o := s.columnOutline()
+	if summary, ok := o.summary(first, last); ok && o.isCollapsed(summary) {
+		if err := s.SetColumnGroupCollapsed(first, last, false); err != nil {
+			return err
+		}
+	}
+	for _, c := range s.columnRange(first, last) {
+		if l := colLevel(c); l > 0 {
+			c.OutlineLevelAttr = levelAttr(l - 1)
+		}
+	}
+	s.updateOutlineLevels()
+	return nil
+}
+
+// SetColumnGroupCollapsed collapses or expands the group made of the columns
+// first to last, which are one based. Collapsing hides the columns, expanding
+// shows them again except for those in nested groups that are still
+// collapsed.
+func (s *Sheet) SetColumnGroupCollapsed(first, last uint32, collapsed bool) error {
+	if err := checkOutlineRange(first, last, maxColumns); err != nil {
+		return err
+	}
+	s.columnRange(first, last)
+	return s.columnOutline().collapse(first, last, collapsed)
+}
+
+// updateOutlineLevels records the deepest outline levels of the rows and
+// columns in the sheet format properties, which Excel uses to display the
+// outline buttons.
+func (s *Sheet) updateOutlineLevels() {
+	rowMax, colMax := uint8(0), uint8(0)
+	for _, r := range s._eage.SheetData.Row {
+		if l := rowLevel(r); l > rowMax {
+			rowMax = l
+		}
+	}
+	for _, cols := range s._eage.Cols {
+		for _, c := range cols.Col {
+			if l := colLevel(c); l > colMax {
+				colMax = l
+			}
+		}
+	}
+	if s._eage.SheetFormatPr == nil {
+		if rowMax == 0 && colMax == 0 {
+			return
+		}
+		s._eage.SheetFormatPr = sml.NewCT_SheetFormatPr()
+		s._eage.SheetFormatPr.DefaultRowHeightAttr = 15
+	}
+	s._eage.SheetFormatPr.OutlineLevelRowAttr = levelAttr(rowMax)
+	s._eage.SheetFormatPr.OutlineLevelColAttr = levelAttr(colMax)
+}
+
+// outline gives access to the outline properties of the rows or the columns
+// of a sheet by one based index.
+type outline struct {
+	max          uint32
+	below        bool
+	level        func(i uint32) uint8
+	setHidden    func(i uint32, b bool)
+	isCollapsed  func(i uint32) bool
+	setCollapsed func(i uint32, b bool)
+}
+
+// summary returns the index of the summary row or column of a group.
+func (o outline) summary(first, last uint32) (uint32, bool) {
+	if o.below {
+		return last + 1, last < o.max
+	}
+	return first - 1, first > 1
+}
+
+func (o outline) collapse(first, last uint32, collapsed bool) error {
+	level := uint8(maxOutlineLevel + 1)
+	for i := first; i <= last; i++ {
+		if l := o.level(i); l < level {
+			level = l
+		}
+	}
+	if level == 0 {
+		return errors.New("range is not grouped")
+	}
+	if summary, ok := o.summary(first, last); ok {
+		o.setCollapsed(summary, collapsed)
+	}
+	if collapsed {
+		for i := first; i <= last; i++ {
+			o.setHidden(i, true)
+		}
+		return nil
+	}
+	o.expand(first, last, level)
+	return nil
+}
+
+// expand shows the indexes first to last which are at the given level, and
+// those of nested groups unless the nested group is collapsed.
+func (o outline) expand(first, last uint32, level uint8) {
+	for i := first; i <= last; {
+		if o.level(i) <= level {
+			o.setHidden(i, false)
+			i++
+			continue
+		}
+		j, nested := i, o.level(i)
+		for j < last && o.level(j+1) > level {
+			j++
+			if l := o.level(j); l < nested {
+				nested = l
+			}
+		}
+		if summary, ok := o.summary(i, j); ok && o.isCollapsed(summary) {
+			for k := i; k <= j; k++ {
+				o.setHidden(k, true)
+			}
+		} else {
+			o.expand(i, j, nested)
+		}
+		i = j + 1
+	}
+}
+
+// rowMap returns the rows of the sheet by row number.
+func (s *Sheet) rowMap() map[uint32]*sml.CT_Row {
+	rows := map[uint32]*sml.CT_Row{}
+	for _, r := range s._eage.SheetData.Row {
+		if r.RAttr != nil {
+			rows[*r.RAttr] = r
+		}
+	}
+	return rows
+}
+
+func (s *Sheet) rowOutline() outline {
+	below, _ := s.OutlineSummary()
+	rows := s.rowMap()
+	row := func(i uint32) *sml.CT_Row {
+		if r, ok := rows[i]; ok {
+			return r
+		}
+		r := s.Row(i).X()
+		rows[i] = r
+		return r
+	}
+	return outline{
+		max:   maxRows,
+		below: below,
+		level: func(i uint32) uint8 { return rowLevel(rows[i]) },
+		setHidden: func(i uint32, b bool) {
+			row(i).HiddenAttr = boolAttr(b)
+		},
+		isCollapsed: func(i uint32) bool {
+			r := rows[i]
+			return r != nil && r.CollapsedAttr != nil && *r.CollapsedAttr
+		},
+		setCollapsed: func(i uint32, b bool) {
+			if _, ok := rows[i]; ok || b {
+				row(i).CollapsedAttr = boolAttr(b)
+			}
+		},
+	}
+}
+
+func (s *Sheet) columnOutline() outline {
+	_, right := s.OutlineSummary()
+	col := func(i uint32) *sml.CT_Col {
+		for _, cols := range s._eage.Cols {
+			for _, c := range cols.Col {
+				if i >= c.MinAttr && i <= c.MaxAttr {
+					return c
+				}
+			}
+		}
+		return nil
+	}
+	return outline{
+		max:   maxColumns,
+		below: right,
+		level: func(i uint32) uint8 { return colLevel(col(i)) },
+		setHidden: func(i uint32, b bool) {
+			if c := col(i); c != nil {
+				c.HiddenAttr = boolAttr(b)
+			}
+		},
+		isCollapsed: func(i uint32) bool {
+			c := col(i)
+			return c != nil && c.CollapsedAttr != nil && *c.CollapsedAttr
+		},
+		setCollapsed: func(i uint32, b bool) {
+			if col(i) != nil || b {
+				s.columnRange(i, i)[0].CollapsedAttr = boolAttr(b)
+			}
+		},
+	}
+}
+
+// ColumnRange returns the columns from first to last, which are one based like
+// the index passed to Column. Column definitions spanning the bounds of the
+// range are split and missing ones are added with the default width, so that
+// the returned columns can be formatted without affecting other columns.
+func (s *Sheet) ColumnRange(first, last uint32) []Column {
+	ret := []Column{}
+	if checkOutlineRange(first, last, maxColumns) != nil {
+		return ret
+	}
+	for _, c := range s.columnRange(first, last) {
+		ret = append(ret, Column{c})
+	}
+	return ret
+}
+
+// columnRange splits the column definitions of the sheet at the bounds of the
+// range first to last and returns the definitions covering the range, adding
+// the missing ones.
+func (s *Sheet) columnRange(first, last uint32) []*sml.CT_Col {
+	all := []*sml.CT_Col{}
+	for _, cols := range s._eage.Cols {
+		for _, c := range cols.Col {
+			if c.MaxAttr < first || c.MinAttr > last {
+				all = append(all, c)
+				continue
+			}
+			if c.MinAttr < first {
+				before := *c
+				before.MaxAttr = first - 1
+				all = append(all, &before)
+				c.MinAttr = first
+			}
+			if c.MaxAttr > last {
+				after := *c
+				after.MinAttr = last + 1
+				all = append(all, &after)
+				c.MaxAttr = last
+			}
+			all = append(all, c)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].MinAttr < all[j].MinAttr })
+
+	ret := []*sml.CT_Col{}
+	next := first
+	addMissing := func(max uint32) {
+		if next > max {
+			return
+		}
+		c := sml.NewCT_Col()
+		c.MinAttr = next
+		c.MaxAttr = max
+		c.WidthAttr = unioffice.Float64(s.defaultColumnWidth())
+		all = append(all, c)
+		ret = append(ret, c)
+	}
+	for _, c := range all {
+		if c.MaxAttr < first || c.MinAttr > last {
+			continue
+		}
+		addMissing(c.MinAttr - 1)
+		ret = append(ret, c)
+		next = c.MaxAttr + 1
+	}
+	addMissing(last)
+	sort.Slice(all, func(i, j int) bool { return all[i].MinAttr < all[j].MinAttr })
+
+	if len(s._eage.Cols) == 0 {
+		s._eage.Cols = []*sml.CT_Cols{sml.NewCT_Cols()}
+	}
+	s._eage.Cols = s._eage.Cols[:1]
+	s._eage.Cols[0].Col = all
+	return ret
+}
+
+// defaultColumnWidth returns the width of the columns of the sheet without
+// width, in characters of the default font.
+func (s *Sheet) defaultColumnWidth() float64 {
+	width := 9.140625
+	if s._eage.SheetFormatPr != nil {
+		if s._eage.SheetFormatPr.DefaultColWidthAttr != nil {
+			width = *s._eage.SheetFormatPr.DefaultColWidthAttr
+		} else if s._eage.SheetFormatPr.BaseColWidthAttr != nil {
+			width = float64(*s._eage.SheetFormatPr.BaseColWidthAttr) + 5.0/7
+		}
+	}
+	return width
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// rowState describes rows 1 to n by their outline level, followed by h if the
+// row is hidden and c if it is collapsed.
+func rowState(sheet *Sheet, n uint32) string {
+	parts := []string{}
+	for i := uint32(1); i <= n; i++ {
+		r := sheet.Row(i)
+		s := fmt.Sprint(r.OutlineLevel())
+		if r.IsHidden() {
+			s += "h"
+		}
+		if r.IsCollapsed() {
+			s += "c"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// columnState describes columns 1 to n like rowState.
+func columnState(sheet *Sheet, n uint32) string {
+	parts := []string{}
+	for i := uint32(1); i <= n; i++ {
+		c := sheet.ColumnRange(i, i)[0]
+		s := fmt.Sprint(c.OutlineLevel())
+		if c.IsHidden() {
+			s += "h"
+		}
+		if c.IsCollapsed() {
+			s += "c"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestGroupRows(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	td := []struct {
+		Name string
+		Op   func() error
+		Exp  string
+	}{
+		{"group", func() error { return sheet.GroupRows(2, 5, false) }, "0 1 1 1 1 0"},
+		{"nest", func() error { return sheet.GroupRows(3, 4, false) }, "0 1 2 2 1 0"},
+		{"collapse inner", func() error { return sheet.SetRowGroupCollapsed(3, 4, true) }, "0 1 2h 2h 1c 0"},
+		{"collapse outer", func() error { return sheet.SetRowGroupCollapsed(2, 5, true) }, "0 1h 2h 2h 1hc 0c"},
+		{"expand outer", func() error { return sheet.SetRowGroupCollapsed(2, 5, false) }, "0 1 2h 2h 1c 0"},
+		{"ungroup inner", func() error { return sheet.UngroupRows(3, 4) }, "0 1 1 1 1 0"},
+		{"ungroup outer", func() error { return sheet.UngroupRows(2, 5) }, "0 0 0 0 0 0"},
+	}
+	for _, tc := range td {
+		if err := tc.Op(); err != nil {
+			t.Fatalf("%s: %s", tc.Name, err)
+		}
+		if got := rowState(&sheet, 6); got != tc.Exp {
+			t.Errorf("%s: expected rows %s, got %s", tc.Name, tc.Exp, got)
+		}
+	}
+
+	for _, tc := range []struct{ First, Last uint32 }{{0, 2}, {3, 2}, {1, maxRows + 1}} {
+		if err := sheet.GroupRows(tc.First, tc.Last, false); err == nil {
+			t.Errorf("expected an error grouping rows %d-%d", tc.First, tc.Last)
+		}
+	}
+	if err := sheet.SetRowGroupCollapsed(2, 3, true); err == nil {
+		t.Errorf("expected an error collapsing rows that aren't grouped")
+	}
+	for i := 0; i < maxOutlineLevel; i++ {
+		sheet.GroupRows(10, 11, false)
+	}
+	if err := sheet.GroupRows(10, 11, false); err == nil {
+		t.Errorf("expected an error nesting more than %d groups", maxOutlineLevel)
+	}
+}
+
+func TestGroupSummaryAbove(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.SetOutlineSummary(false, false)
+	if below, right := sheet.OutlineSummary(); below || right {
+		t.Errorf("expected summaries above and to the left")
+	}
+	sheet.GroupRows(2, 3, true)
+	if got := rowState(&sheet, 4); got != "0c 1h 1h 0" {
+		t.Errorf("expected the summary row above the group, got %s", got)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	if below, right := rs.OutlineSummary(); below || right {
+		t.Errorf("expected the summary settings to be preserved")
+	}
+	if got := rowState(&rs, 4); got != "0c 1h 1h 0" {
+		t.Errorf("expected the group to be preserved, got %s", got)
+	}
+	if f := rs.X().SheetFormatPr; f == nil || f.OutlineLevelRowAttr == nil || *f.OutlineLevelRowAttr != 1 {
+		t.Errorf("expected the outline level of the rows to be recorded")
+	}
+}
+
+func TestGroupColumns(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	// a column definition spanning the group is split
+	sheet.ColumnRange(1, 6)
+
+	if err := sheet.GroupColumns(2, 4, false); err != nil {
+		t.Fatalf("error grouping columns: %s", err)
+	}
+	sheet.GroupColumns(3, 3, true)
+	if got := columnState(&sheet, 6); got != "0 1 2h 1c 0 0" {
+		t.Errorf("expected columns 0 1 2h 1c 0 0, got %s", got)
+	}
+	sheet.SetColumnGroupCollapsed(2, 4, true)
+	if got := columnState(&sheet, 6); got != "0 1h 2h 1hc 0c 0" {
+		t.Errorf("expected columns 0 1h 2h 1hc 0c 0, got %s", got)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	if got := columnState(&rs, 6); got != "0 1h 2h 1hc 0c 0" {
+		t.Errorf("expected the groups to be preserved, got %s", got)
+	}
+	if f := rs.X().SheetFormatPr; f == nil || f.OutlineLevelColAttr == nil || *f.OutlineLevelColAttr != 2 {
+		t.Errorf("expected the outline level of the columns to be recorded")
+	}
+
+	// the inner group stays collapsed with its own summary column
+	rs.UngroupColumns(2, 4)
+	if got := columnState(&rs, 6); got != "0 0 1h 0c 0 0" {
+		t.Errorf("expected the outer group to be removed, got %s", got)
+	}
+}
+
+func TestColumnRange(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.ColumnRange(1, 10)
+	sheet.ColumnRange(3, 4)
+	cols := sheet.X().Cols[0].Col
+	spans := []string{}
+	for _, c := range cols {
+		spans = append(spans, fmt.Sprintf("%d-%d", c.MinAttr, c.MaxAttr))
+	}
+	if got := strings.Join(spans, " "); got != "1-2 3-4 5-10" {
+		t.Errorf("expected the columns to be split into 1-2 3-4 5-10, got %s", got)
+	}
+	if got := sheet.ColumnRange(4, 3); len(got) != 0 {
+		t.Errorf("expected no columns for an invalid range")
+	}
+}
+
+func TestAutoFitColumns(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.Cell("A1").SetString("short")
+	sheet.Cell("B1").SetString("a much longer text in a cell")
+	sheet.Cell("C1").SetString("a much longer text in a cell")
+	bold, _ := wb.StyleSheet.GetOrCreateStyle(StyleDescriptor{Font: &FontDescriptor{Bold: true}})
+	sheet.Cell("C1").SetStyle(bold)
+	sheet.Cell("D1").SetString("x")
+	sheet.Cell("D2").SetString("a much longer text in a hidden row")
+	sheet.Row(2).SetHidden(true)
+	sheet.Cell("E1").SetString("line\na much longer second line")
+	sheet.Cell("F1").SetString("a much longer text in a merged cell")
+	sheet.AddMergedCells("F1", "G1")
+	sheet.Cell("H1").SetNumber(1234.5)
+	num, _ := wb.StyleSheet.GetOrCreateStyle(StyleDescriptor{NumberFormat: "#,##0.00000"})
+	sheet.Cell("H1").SetStyle(num)
+	sheet.Cell("I1").SetString("not fitted")
+
+	sheet.AutoFitColumns("A", "B", "c", "D", "E", "F", "H")
+	width := func(col uint32) float64 {
+		c := sheet.ColumnRange(col, col)[0].X()
+		if c.WidthAttr == nil || c.BestFitAttr == nil || !*c.BestFitAttr {
+			return 0
+		}
+		return *c.WidthAttr
+	}
+	a, b, c, d, e, f, h, i := width(1), width(2), width(3), width(4), width(5), width(6), width(8), width(9)
+	if a <= 0 || b <= a {
+		t.Errorf("expected a longer text to give a wider column, got %v and %v", a, b)
+	}
+	if c <= b {
+		t.Errorf("expected bold text to be wider, got %v and %v", b, c)
+	}
+	if d <= 0 || d >= a {
+		t.Errorf("expected hidden rows to be ignored, got %v", d)
+	}
+	if e >= b || e <= a {
+		t.Errorf("expected the longest line to be measured, got %v", e)
+	}
+	if f != 0 {
+		t.Errorf("expected a column with only merged cells to keep its width, got %v", f)
+	}
+	if h <= a || h >= b {
+		t.Errorf("expected the formatted number to be measured, got %v", h)
+	}
+	if i != 0 {
+		t.Errorf("expected a column that isn't given to keep its width, got %v", i)
+	}
+}
@@ -0,0 +1,567 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// PaperSize is the size of the paper a sheet is printed on.
+type PaperSize uint32
+
+// PaperSize constants, the values are those of the paperSize attribute.
+const (
+	PaperSizeLetter     PaperSize = 1
+	PaperSizeTabloid    PaperSize = 3
+	PaperSizeLegal      PaperSize = 5
+	PaperSizeExecutive  PaperSize = 7
+	PaperSizeA3         PaperSize = 8
+	PaperSizeA4         PaperSize = 9
+	PaperSizeA5         PaperSize = 11
+	PaperSizeB4         PaperSize = 12
+	PaperSizeB5         PaperSize = 13
+	PaperSizeEnvelope10 PaperSize = 20
+	PaperSizeEnvelopeDL PaperSize = 27
+)
+
+// Names of the defined names that hold the print area and print titles of a
+// sheet.
+const (
+	printAreaName   = "_xlnm.Print_Area"
+	printTitlesName = "_xlnm.Print_Titles"
+)
+
+// PageSetup is the page layout a sheet is printed with.
+type PageSetup struct {
+	x *sml.Worksheet
+}
+
+// PageMargins are the margins of a printed page. The header and footer
+// margins are the distances of the header and footer from the edges of the
+// page.
+type PageMargins struct {
+	Left, Right    measurement.Distance
+	Top, Bottom    measurement.Distance
+	Header, Footer measurement.Distance
+}
+
+// DefaultPageMargins are the margins of a new sheet in Excel.
+var DefaultPageMargins = PageMargins{
+	Left:   0.7 * measurement.Inch,
+	Right:  0.7 * measurement.Inch,
+	Top:    0.75 * measurement.Inch,
+	Bottom: 0.75 * measurement.Inch,
+	Header: 0.3 * measurement.Inch,
+	Footer: 0.3 * measurement.Inch,
+}
+
+// PageSetup returns the page layout of the sheet.
+func (s *Sheet) PageSetup() PageSetup { return PageSetup{s._eage} }
+
+// X returns the inner wrapped XML type, creating it if necessary.
+func (p PageSetup) X() *sml.CT_PageSetup {
+	if p.x.PageSetup == nil {
+		p.x.PageSetup = sml.NewCT_PageSetup()
+	}
+	return p.x.PageSetup
+}
+
+// Orientation returns the orientation of the printed pages.
+func (p PageSetup) Orientation() sml.ST_Orientation {
+	if p.x.PageSetup == nil || p.x.PageSetup.OrientationAttr == sml.ST_OrientationUnset {
+		return sml.ST_OrientationDefault
+	}
+	return p.x.PageSetup.OrientationAttr
+}
+
+// SetOrientation sets the orientation of the printed pages.
+func (p PageSetup) SetOrientation(o sml.ST_Orientation) { p.X().OrientationAttr = o }
+
+// PaperSize returns the paper size, Letter if it is not set.
+func (p PageSetup) PaperSize() PaperSize {
+	if p.x.PageSetup == nil || p.x.PageSetup.PaperSizeAttr == nil {
+		return PaperSizeLetter
+	}
+	return PaperSize(*p.x.PageSetup.PaperSizeAttr)
+}
+
+// SetPaperSize sets the paper size.
+func (p PageSetup) SetPaperSize(size PaperSize) {
+	p.X().PaperSizeAttr = unioffice.Uint32(uint32(size))
+}
+
+// Scale returns the scale in percent the sheet is printed at when it is not
+// fit to a number of pages.
+func (p PageSetup) Scale() uint32 {
+	if p.x.PageSetup == nil || p.x.PageSetup.ScaleAttr == nil {
+		return 100
+	}
+	return *p.x.PageSetup.ScaleAttr
+}
+
+// SetScale sets the scale in percent, from 10 to 400, the sheet is printed
+// at and stops fitting it to a number of pages.
+func (p PageSetup) SetScale(percent uint32) error {
+	if percent < 10 || percent > 400 {
+		return errors.New("scale must be between 10 and 400 percent")
+	}
+	p.X().ScaleAttr = unioffice.Uint32(percent)
+	p.setFitToPage(false)
+	return nil
+}
+
+// FitToPages returns the number of pages wide and tall the sheet is scaled
+// to fit when printed, and whether it is fit to pages rather than scaled. A
+// count of zero means that the number of pages in that direction is not
+// limited.
+func (p PageSetup) FitToPages() (width, height uint32, ok bool) {
+	pr := p.x.SheetPr
+	if pr == nil || pr.PageSetUpPr == nil || pr.PageSetUpPr.FitToPageAttr == nil || !*pr.PageSetUpPr.FitToPageAttr {
+		return 0, 0, false
+	}
+	width, height = 1, 1
+	if p.x.PageSetup != nil {
+		if p.x.PageSetup.FitToWidthAttr != nil {
+			width = *p.x.PageSetup.FitToWidthAttr
+		}
+		if p.x.PageSetup.FitToHeightAttr != nil {
+			height = *p.x.PageSetup.FitToHeightAttr
+		}
+	}
+	return width, height, true
+}
+
+// SetFitToPages scales the printed sheet to fit the given number of pages
+// wide and tall. A count of zero leaves the number of pages in that direction
+// unlimited, e.g. SetFitToPages(1, 0) fits all columns on one page.
+func (p PageSetup) SetFitToPages(width, height uint32) {
+	ps := p.X()
+	ps.FitToWidthAttr = unioffice.Uint32(width)
+	ps.FitToHeightAttr = unioffice.Uint32(height)
+	p.setFitToPage(true)
+}
+
+func (p PageSetup) setFitToPage(fit bool) {
+	if !fit && (p.x.SheetPr == nil || p.x.SheetPr.PageSetUpPr == nil) {
+		return
+	}
+	if p.x.SheetPr == nil {
+		p.x.SheetPr = sml.NewCT_SheetPr()
+	}
+	if p.x.SheetPr.PageSetUpPr == nil {
+		p.x.SheetPr.PageSetUpPr = sml.NewCT_PageSetUpPr()
+	}
+	if fit {
+		p.x.SheetPr.PageSetUpPr.FitToPageAttr = unioffice.Bool(true)
+	} else {
+		p.x.SheetPr.PageSetUpPr.FitToPageAttr = nil
+	}
+}
+
+// FirstPageNumber returns the number of the first printed page, and false if
+// the pages are numbered automatically.
+func (p PageSetup) FirstPageNumber() (uint32, bool) {
+	ps := p.x.PageSetup
+	if ps == nil || ps.FirstPageNumberAttr == nil || ps.UseFirstPageNumberAttr == nil || !*ps.UseFirstPageNumberAttr {
+		return 0, false
+	}
+	return *ps.FirstPageNumberAttr, true
+}
+
+// SetFirstPageNumber sets the number of the first printed page.
+func (p PageSetup) SetFirstPageNumber(n uint32) {
+	ps := p.X()
+	ps.FirstPageNumberAttr = unioffice.Uint32(n)
+	ps.UseFirstPageNumberAttr = unioffice.Bool(true)
+}
+
+// SetPageOrder sets the order in which the pages of a sheet that is larger
+// than a page in both directions are printed.
+func (p PageSetup) SetPageOrder(o sml.ST_PageOrder) { p.X().PageOrderAttr = o }
+
+// SetBlackAndWhite controls if the sheet is printed in black and white.
+func (p PageSetup) SetBlackAndWhite(b bool) { p.X().BlackAndWhiteAttr = unioffice.Bool(b) }
+
+// SetDraft controls if the sheet is printed in draft quality.
+func (p PageSetup) SetDraft(b bool) { p.X().DraftAttr = unioffice.Bool(b) }
+
+// Margins returns the page margins, the defaults of Excel if they are not
+// set.
+func (p PageSetup) Margins() PageMargins {
+	m := p.x.PageMargins
+	if m == nil {
+		return DefaultPageMargins
+	}
+	return PageMargins{
+		Left:   measurement.Distance(m.LeftAttr) * measurement.Inch,
+		Right:  measurement.Distance(m.RightAttr) * measurement.Inch,
+		Top:    measurement.Distance(m.TopAttr) * measurement.Inch,
+		Bottom: measurement.Distance(m.BottomAttr) * measurement.Inch,
+		Header: measurement.Distance(m.HeaderAttr) * measurement.Inch,
+		Footer: measurement.Distance(m.FooterAttr) * measurement.Inch,
+	}
+}
+
+// SetMargins sets the page margins.
+func (p PageSetup) SetMargins(m PageMargins) {
+	if p.x.PageMargins == nil {
+		p.x.PageMargins = sml.NewCT_PageMargins()
+	}
+	pm := p.x.PageMargins
+	pm.LeftAttr = inches(m.Left)
+	pm.RightAttr = inches(m.Right)
+	pm.TopAttr = inches(m.Top)
+	pm.BottomAttr = inches(m.Bottom)
+	pm.HeaderAttr = inches(m.Header)
+	pm.FooterAttr = inches(m.Footer)
+}
+
+// inches converts a distance to inches, rounded to remove the error of the
+// conversion from other units.
+func inches(d measurement.Distance) float64 {
+	return math.Round(float64(d/measurement.Inch)*1e6) / 1e6
+}
+
+func (p PageSetup) printOptions() *sml.CT_PrintOptions {
+	if p.x.PrintOptions == nil {
+		p.x.PrintOptions = sml.NewCT_PrintOptions()
+	}
+	return p.x.PrintOptions
+}
+
+// PrintGridLines returns true if the gridlines are printed.
+func (p PageSetup) PrintGridLines() bool {
+	return p.x.PrintOptions != nil && boolValue(p.x.PrintOptions.GridLinesAttr)
+}
+
+// SetPrintGridLines controls if the gridlines are printed.
+func (p PageSetup) SetPrintGridLines(b bool) {
+	po := p.printOptions()
+	po.GridLinesAttr = unioffice.Bool(b)
+	po.GridLinesSetAttr = unioffice.Bool(true)
+}
+
+// PrintHeadings returns true if the row and column headings are printed.
+func (p PageSetup) PrintHeadings() bool {
+	return p.x.PrintOptions != nil && boolValue(p.x.PrintOptions.HeadingsAttr)
+}
+
+// SetPrintHeadings controls if the row and column headings are printed.
+func (p PageSetup) SetPrintHeadings(b bool) { p.printOptions().HeadingsAttr = unioffice.Bool(b) }
+
+// CenterHorizontally returns true if the sheet is centered horizontally on
+// the page.
+func (p PageSetup) CenterHorizontally() bool {
+	return p.x.PrintOptions != nil && boolValue(p.x.PrintOptions.HorizontalCenteredAttr)
+}
+
+// SetCenterHorizontally controls if the sheet is centered horizontally on
+// the page.
+func (p PageSetup) SetCenterHorizontally(b bool) {
+	p.printOptions().HorizontalCenteredAttr = unioffice.Bool(b)
+}
+
+// CenterVertically returns true if the sheet is centered vertically on the
+// page.
+func (p PageSetup) CenterVertically() bool {
+	return p.x.PrintOptions != nil && boolValue(p.x.PrintOptions.VerticalCenteredAttr)
+}
+
+// SetCenterVertically controls if the sheet is centered vertically on the
+// page.
+func (p PageSetup) SetCenterVertically(b bool) {
+	p.printOptions().VerticalCenteredAttr = unioffice.Bool(b)
+}
+
+// InsertRowBreak inserts a manual page break above a row, so that the row is
+// the first of a new page.
+func (s *Sheet) InsertRowBreak(row uint32) error {
+	if row < 2 || row > maxRows {
+		return fmt.Errorf("invalid row %d for a page break", row)
+	}
+	s._eage.RowBreaks = insertBreak(s._eage.RowBreaks, row-1, maxColumns-1)
+	return nil
+}
+
+// RemoveRowBreak removes the manual page break above a row.
+func (s *Sheet) RemoveRowBreak(row uint32) {
+	s._eage.RowBreaks = removeBreak(s._eage.RowBreaks, row-1)
+}
+
+// RowBreaks returns the rows that start a new page because of a manual page
+// break, in ascending order.
+func (s *Sheet) RowBreaks() []uint32 {
+	rows := []uint32{}
+	for _, id := range manualBreaks(s._eage.RowBreaks) {
+		rows = append(rows, id+1)
+	}
+	return rows
+}
+
+// InsertColumnBreak inserts a manual page break left of a column (e.g. "D"),
+// so that the column is the first of a new page.
+func (s *Sheet) InsertColumnBreak(column string) error {
+	idx := reference.ColumnToIndex(strings.ToUpper(column))
+	if idx < 1 || idx >= maxColumns {
+		return fmt.Errorf("invalid column %s for a page break", column)
+	}
+	s._eage.ColBreaks = insertBreak(s._eage.ColBreaks, idx, maxRows-1)
+	return nil
+}
+
+// RemoveColumnBreak removes the manual page break left of a column.
+func (s *Sheet) RemoveColumnBreak(column string) {
+	s._eage.ColBreaks = removeBreak(s._eage.ColBreaks, reference.ColumnToIndex(strings.ToUpper(column)))
+}
+
+// ColumnBreaks returns the columns that start a new page because of a manual
+// page break, in ascending order.
+func (s *Sheet) ColumnBreaks() []string {
+	cols := []string{}
+	for _, id := range manualBreaks(s._eage.ColBreaks) {
+		cols = append(cols, reference.IndexToColumn(id))
+	}
+	return cols
+}
+
+// insertBreak adds a manual break with the given id, the index of the first
+// row or column of the new page, to a list of breaks.
+func insertBreak(pb *sml.CT_PageBreak, id, max uint32) *sml.CT_PageBreak {
+	if pb == nil {
+		pb = sml.NewCT_PageBreak()
+	}
+	found := false
+	for _, b := range pb.Brk {
+		if b.IdAttr != nil && *b.IdAttr == id {
+			b.ManAttr = unioffice.Bool(true)
+			found = true
+		}
+	}
+	if !found {
+		b := sml.NewCT_Break()
+		b.IdAttr = unioffice.Uint32(id)
+		b.MaxAttr = unioffice.Uint32(max)
+		b.ManAttr = unioffice.Bool(true)
+		pb.Brk = append(pb.Brk, b)
+		sort.SliceStable(pb.Brk, func(i, j int) bool { return breakID(pb.Brk[i]) < breakID(pb.Brk[j]) })
+	}
+	updateBreakCounts(pb)
+	return pb
+}
+
+func removeBreak(pb *sml.CT_PageBreak, id uint32) *sml.CT_PageBreak {
+	if pb == nil {
+		return nil
+	}
+	kept := pb.Brk[:0]
+	for _, b := range pb.Brk {
+		if breakID(b) != id {
+			kept = append(kept, b)
+		}
+	}
+	pb.Brk = kept
+	if len(pb.Brk) == 0 {
+		return nil
+	}
+	updateBreakCounts(pb)
+	return pb
+}
+
+func manualBreaks(pb *sml.CT_PageBreak) []uint32 {
+	ids := []uint32{}
+	if pb == nil {
+		return ids
+	}
+	for _, b := range pb.Brk {
+		if boolValue(b.ManAttr) {
+			ids = append(ids, breakID(b))
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func breakID(b *sml.CT_Break) uint32 {
+	if b.IdAttr == nil {
+		return 0
+	}
+	return *b.IdAttr
+}
+
+func updateBreakCounts(pb *sml.CT_PageBreak) {
+	manual := uint32(0)
+	for _, b := range pb.Brk {
+		if boolValue(b.ManAttr) {
+			manual++
+		}
+	}
+	pb.CountAttr = unioffice.Uint32(uint32(len(pb.Brk)))
+	pb.ManualBreakCountAttr = unioffice.Uint32(manual)
+}
+
+var (
+	printRowsRef    = regexp.MustCompile(`^\$?(\d+):\$?(\d+)$`)
+	printColumnsRef = regexp.MustCompile(`^\$?([A-Za-z]+):\$?([A-Za-z]+)$`)
+)
+
+// SetPrintArea sets the range of cells that is printed, e.g. "A1:F40".
+// Several ranges that are printed on separate pages are separated by commas,
+// e.g. "A1:F40,H1:K20".
+func (s *Sheet) SetPrintArea(ref string) error {
+	parts := []string{}
+	for _, r := range strings.Split(ref, ",") {
+		r = strings.Replace(strings.TrimSpace(r), "$", "", -1)
+		if _, _, err := reference.ParseRangeReference(r); err != nil {
+			if _, err := reference.ParseCellReference(r); err != nil {
+				return fmt.Errorf("invalid print area %s", ref)
+			}
+		}
+		parts = append(parts, s.RangeReference(r))
+	}
+	return s.setBuiltinName(printAreaName, strings.Join(parts, ","))
+}
+
+// PrintArea returns the print area of the sheet, as a formula such as
+// 'Sheet 1'!$A$1:$F$40, or an empty string if the whole sheet is printed.
+func (s *Sheet) PrintArea() string {
+	if dn, ok := s.builtinName(printAreaName); ok {
+		return dn.Content()
+	}
+	return ""
+}
+
+// ClearPrintArea removes the print area so that the whole sheet is printed.
+func (s *Sheet) ClearPrintArea() {
+	if dn, ok := s.builtinName(printAreaName); ok {
+		s._gccb.RemoveDefinedName(dn)
+	}
+}
+
+// SetPrintTitles sets the rows (e.g. "1:2") and columns (e.g. "A:B") that
+// are repeated on every printed page. Either can be empty, and the titles are
+// removed if both are.
+func (s *Sheet) SetPrintTitles(rows, columns string) error {
+	quoted := "'" + s.Name() + "'!"
+	parts := []string{}
+	if columns != "" {
+		m := printColumnsRef.FindStringSubmatch(strings.TrimSpace(columns))
+		if m == nil {
+			return fmt.Errorf("invalid title columns %s", columns)
+		}
+		parts = append(parts, fmt.Sprintf("%s$%s:$%s", quoted, strings.ToUpper(m[1]), strings.ToUpper(m[2])))
+	}
+	if rows != "" {
+		m := printRowsRef.FindStringSubmatch(strings.TrimSpace(rows))
+		if m == nil {
+			return fmt.Errorf("invalid title rows %s", rows)
+		}
+		parts = append(parts, fmt.Sprintf("%s$%s:$%s", quoted, m[1], m[2]))
+	}
+	if len(parts) == 0 {
+		if dn, ok := s.builtinName(printTitlesName); ok {
+			s._gccb.RemoveDefinedName(dn)
+		}
+		return nil
+	}
+	return s.setBuiltinName(printTitlesName, strings.Join(parts, ","))
+}
+
+// PrintTitles returns the rows (e.g. "1:2") and columns (e.g. "A:B") that
+// are repeated on every printed page.
+func (s *Sheet) PrintTitles() (rows, columns string) {
+	dn, ok := s.builtinName(printTitlesName)
+	if !ok {
+		return "", ""
+	}
+	for _, part := range strings.Split(dn.Content(), ",") {
+		if i := strings.LastIndexByte(part, '!'); i >= 0 {
+			part = part[i+1:]
+		}
+		part = strings.TrimSpace(part)
+		if m := printRowsRef.FindStringSubmatch(part); m != nil {
+			rows = m[1] + ":" + m[2]
+		} else if m := printColumnsRef.FindStringSubmatch(part); m != nil {
+			columns = m[1] + ":" + m[2]
+		}
+	}
+	return rows, columns
+}
+
+// copySheetPrintNames copies the print area and print titles of a sheet to
+// the copy of it that was added last.
+func (wb *Workbook) copySheetPrintNames(ind int) {
+	sheets := wb._feeg.Sheets.Sheet
+	last := len(sheets) - 1
+	quoted := "'" + sheets[last].NameAttr + "'!"
+	for _, dn := range wb.DefinedNames() {
+		id := dn.X().LocalSheetIdAttr
+		if id == nil || int(*id) != ind || dn.Name() != printAreaName && dn.Name() != printTitlesName {
+			continue
+		}
+		parts := strings.Split(dn.Content(), ",")
+		for i, part := range parts {
+			if j := strings.LastIndexByte(part, '!'); j >= 0 {
+				parts[i] = quoted + part[j+1:]
+			}
+		}
+		cp := wb.AddDefinedName(dn.Name(), strings.Join(parts, ","))
+		cp.SetLocalSheetID(uint32(last))
+	}
+}
+
+// index returns the position of the sheet in the workbook, or -1 if it has
+// been removed.
+func (s *Sheet) index() int {
+	for i, ws := range s._gccb._dcfb {
+		if ws == s._eage {
+			return i
+		}
+	}
+	return -1
+}
+
+// builtinName returns the defined name local to the sheet with the given
+// name, such as _xlnm.Print_Area.
+func (s *Sheet) builtinName(name string) (DefinedName, bool) {
+	idx := s.index()
+	for _, dn := range s._gccb.DefinedNames() {
+		id := dn.X().LocalSheetIdAttr
+		if dn.Name() == name && id != nil && int(*id) == idx {
+			return dn, true
+		}
+	}
+	return DefinedName{}, false
+}
+
+func (s *Sheet) setBuiltinName(name, content string) error {
+	idx := s.index()
+	if idx < 0 {
+		return errors.New("sheet is not part of the workbook")
+	}
+	dn, ok := s.builtinName(name)
+	if !ok {
+		dn = s._gccb.AddDefinedName(name, content)
+		dn.SetLocalSheetID(uint32(idx))
+	}
+	dn.SetContent(content)
+	return nil
+}
+
+// boolValue returns the value of an optional boolean attribute that defaults to
+// false.
+func boolValue(b *bool) bool { return b != nil && *b }
@@ -0,0 +1,169 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+func TestPageSetupRoundTrip(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	ps := sheet.PageSetup()
+	if ps.Margins() != DefaultPageMargins || ps.Scale() != 100 {
+		t.Errorf("expected the defaults of Excel for a new sheet")
+	}
+	if _, _, ok := ps.FitToPages(); ok {
+		t.Errorf("expected a new sheet not to be fit to pages")
+	}
+	ps.SetOrientation(sml.ST_OrientationLandscape)
+	ps.SetPaperSize(PaperSizeA4)
+	ps.SetFitToPages(1, 0)
+	ps.SetFirstPageNumber(3)
+	ps.SetPrintGridLines(true)
+	ps.SetCenterHorizontally(true)
+	margins := PageMargins{
+		Left: 2 * measurement.Centimeter, Right: 2 * measurement.Centimeter,
+		Top: measurement.Inch, Bottom: measurement.Inch,
+		Header: 0.5 * measurement.Inch, Footer: 0.5 * measurement.Inch,
+	}
+	ps.SetMargins(margins)
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	got := rs.PageSetup()
+	if got.Orientation() != sml.ST_OrientationLandscape || got.PaperSize() != PaperSizeA4 {
+		t.Errorf("expected a landscape A4 page")
+	}
+	if w, h, ok := got.FitToPages(); !ok || w != 1 || h != 0 {
+		t.Errorf("expected the sheet to be fit to one page wide, got %d by %d", w, h)
+	}
+	if n, ok := got.FirstPageNumber(); !ok || n != 3 {
+		t.Errorf("expected the first page number 3, got %d", n)
+	}
+	if !got.PrintGridLines() || got.PrintHeadings() || !got.CenterHorizontally() || got.CenterVertically() {
+		t.Errorf("expected the print options to be preserved")
+	}
+	if m := got.Margins(); m.Top != margins.Top || m.Header != margins.Header ||
+		inches(m.Left) != inches(margins.Left) {
+		t.Errorf("expected the margins %v, got %v", margins, m)
+	}
+
+	// scaling replaces fitting to pages
+	if err := got.SetScale(75); err != nil {
+		t.Fatalf("error setting scale: %s", err)
+	}
+	if _, _, ok := got.FitToPages(); ok || got.Scale() != 75 {
+		t.Errorf("expected the sheet to be scaled to 75%%")
+	}
+	for _, scale := range []uint32{9, 401} {
+		if err := got.SetScale(scale); err == nil {
+			t.Errorf("expected an error for the scale %d", scale)
+		}
+	}
+}
+
+func TestPageBreaks(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	for _, row := range []uint32{30, 10, 20, 10} {
+		if err := sheet.InsertRowBreak(row); err != nil {
+			t.Fatalf("error inserting a break above row %d: %s", row, err)
+		}
+	}
+	sheet.InsertColumnBreak("h")
+	sheet.InsertColumnBreak("C")
+	for _, row := range []uint32{0, 1, maxRows + 1} {
+		if err := sheet.InsertRowBreak(row); err == nil {
+			t.Errorf("expected an error for a break above row %d", row)
+		}
+	}
+	if err := sheet.InsertColumnBreak("A"); err == nil {
+		t.Errorf("expected an error for a break left of column A")
+	}
+	sheet.RemoveRowBreak(20)
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	if got := rs.RowBreaks(); !reflect.DeepEqual(got, []uint32{10, 30}) {
+		t.Errorf("expected breaks above rows 10 and 30, got %v", got)
+	}
+	if got := rs.ColumnBreaks(); !reflect.DeepEqual(got, []string{"C", "H"}) {
+		t.Errorf("expected breaks left of C and H, got %v", got)
+	}
+	if rb := rs.X().RowBreaks; *rb.CountAttr != 2 || *rb.ManualBreakCountAttr != 2 || *rb.Brk[0].IdAttr != 9 {
+		t.Errorf("expected two manual breaks with zero based ids")
+	}
+	rs.RemoveColumnBreak("C")
+	rs.RemoveColumnBreak("H")
+	if rs.X().ColBreaks != nil {
+		t.Errorf("expected the column breaks to be removed")
+	}
+}
+
+func TestPrintAreaAndTitles(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.SetName("Sales 2024")
+	other := wb.AddSheet()
+
+	if err := sheet.SetPrintArea("A1:F40, $H$1:$K$20"); err != nil {
+		t.Fatalf("error setting print area: %s", err)
+	}
+	if err := sheet.SetPrintTitles("1:$2", "a:B"); err != nil {
+		t.Fatalf("error setting print titles: %s", err)
+	}
+	other.SetPrintArea("B2")
+	for _, tc := range []struct{ Rows, Columns string }{{"A:B", ""}, {"", "1:2"}} {
+		if err := sheet.SetPrintTitles(tc.Rows, tc.Columns); err == nil {
+			t.Errorf("expected an error for the titles %q and %q", tc.Rows, tc.Columns)
+		}
+	}
+	if err := sheet.SetPrintArea("not a range"); err == nil {
+		t.Errorf("expected an error for an invalid print area")
+	}
+
+	if _, err := wb.CopySheet(0, "Copy"); err != nil {
+		t.Fatalf("error copying sheet: %s", err)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	if got := rs.PrintArea(); got != "'Sales 2024'!$A$1:$F$40,'Sales 2024'!$H$1:$K$20" {
+		t.Errorf("unexpected print area %s", got)
+	}
+	if rows, cols := rs.PrintTitles(); rows != "1:2" || cols != "A:B" {
+		t.Errorf("expected the titles 1:2 and A:B, got %s and %s", rows, cols)
+	}
+	if got := rd.Sheets()[1].PrintArea(); got != "'Sheet 2'!$B$2" {
+		t.Errorf("expected the print area of the second sheet, got %s", got)
+	}
+	cp := rd.Sheets()[2]
+	if got := cp.PrintArea(); got != "'Copy'!$A$1:$F$40,'Copy'!$H$1:$K$20" {
+		t.Errorf("expected the print area to be copied, got %s", got)
+	}
+	if rows, cols := cp.PrintTitles(); rows != "1:2" || cols != "A:B" {
+		t.Errorf("expected the titles to be copied, got %s and %s", rows, cols)
+	}
+
+	rs.ClearPrintArea()
+	rs.SetPrintTitles("", "")
+	if rs.PrintArea() != "" {
+		t.Errorf("expected the print area to be removed")
+	}
+	if rows, cols := rs.PrintTitles(); rows != "" || cols != "" {
+		t.Errorf("expected the titles to be removed")
+	}
+	if cp.PrintArea() == "" {
+		t.Errorf("expected the print area of the copy to remain")
+	}
+}
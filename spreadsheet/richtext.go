@@ -0,0 +1,255 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// GetRichText returns the rich text of a cell containing a shared or inline
+// string. It returns false if the cell doesn't contain a string. A string
+// without formatting is returned as a single run without properties.
+//
+// The rich text of a shared string may be used by several cells, use
+// EditRichText to modify the text of a single cell.
+func (c Cell) GetRichText() (RichText, bool) {
+	switch c._cga.TAttr {
+	case sml.ST_CellTypeInlineStr:
+		if c._cga.Is != nil {
+			return RichText{c._cga.Is}, true
+		}
+		if c._cga.V != nil {
+			return RichText{&sml.CT_Rst{T: unioffice.String(*c._cga.V)}}, true
+		}
+	case sml.ST_CellTypeS:
+		if c._cga.V == nil {
+			return RichText{}, false
+		}
+		id, err := strconv.Atoi(*c._cga.V)
+		if err != nil {
+			return RichText{}, false
+		}
+		sst := c._ea.SharedStrings.X()
+		if id < 0 || id >= len(sst.Si) {
+			return RichText{}, false
+		}
+		return RichText{sst.Si[id]}, true
+	case sml.ST_CellTypeStr:
+		if c._cga.V != nil {
+			return RichText{&sml.CT_Rst{T: unioffice.String(*c._cga.V)}}, true
+		}
+	}
+	return RichText{}, false
+}
+
+// EditRichText returns the rich text of a cell for modification. A shared
+// string is copied into an inline string of the cell, so that other cells
+// using the same shared string are not modified. Any other value is replaced
+// by an inline string containing the text of the cell as a single run.
+func (c Cell) EditRichText() RichText {
+	var rst *sml.CT_Rst
+	if c._cga.TAttr == sml.ST_CellTypeInlineStr && c._cga.Is != nil {
+		rst = c._cga.Is
+	} else if rt, ok := c.GetRichText(); ok {
+		rst = copyRst(rt.X())
+	} else {
+		rst = &sml.CT_Rst{T: unioffice.String(c.GetString())}
+	}
+	if rst.T != nil && len(rst.R) == 0 {
+		rst.R = []*sml.CT_RElt{{T: *rst.T}}
+		rst.T = nil
+	}
+	if c._cga.Is != rst {
+		c.clearValue()
+		c._cga.TAttr = sml.ST_CellTypeInlineStr
+		c._cga.Is = rst
+	}
+	return RichText{rst}
+}
+
+// copyRst returns a copy of a rich text whose runs can be modified without
+// modifying the original.
+func copyRst(rst *sml.CT_Rst) *sml.CT_Rst {
+	ret := &sml.CT_Rst{}
+	if rst.T != nil {
+		ret.T = unioffice.String(*rst.T)
+	}
+	for _, r := range rst.R {
+		cr := &sml.CT_RElt{T: r.T}
+		if r.RPr != nil {
+			rpr := *r.RPr
+			cr.RPr = &rpr
+		}
+		ret.R = append(ret.R, cr)
+	}
+	ret.RPh = rst.RPh
+	ret.PhoneticPr = rst.PhoneticPr
+	return ret
+}
+
+// Runs returns the runs of the rich text. A text without runs is returned as
+// a single run without properties that is not part of the rich text, use
+// Cell.EditRichText to modify it.
+func (r RichText) Runs() []RichTextRun {
+	if r._cde == nil {
+		return nil
+	}
+	if len(r._cde.R) == 0 {
+		if r._cde.T == nil {
+			return nil
+		}
+		return []RichTextRun{{&sml.CT_RElt{T: *r._cde.T}}}
+	}
+	ret := []RichTextRun{}
+	for _, run := range r._cde.R {
+		ret = append(ret, RichTextRun{run})
+	}
+	return ret
+}
+
+// Text returns the text of the rich text without formatting.
+func (r RichText) Text() string {
+	if r._cde == nil {
+		return ""
+	}
+	if len(r._cde.R) == 0 {
+		if r._cde.T == nil {
+			return ""
+		}
+		return *r._cde.T
+	}
+	sb := strings.Builder{}
+	for _, run := range r._cde.R {
+		sb.WriteString(run.T)
+	}
+	return sb.String()
+}
+
+// InsertRun inserts a new run of text before the run at index idx, or
+// appends it if idx is not less than the number of runs.
+func (r RichText) InsertRun(idx int) RichTextRun {
+	if idx < 0 || idx >= len(r._cde.R) {
+		return r.AddRun()
+	}
+	run := sml.NewCT_RElt()
+	r._cde.R = append(r._cde.R, nil)
+	copy(r._cde.R[idx+1:], r._cde.R[idx:])
+	r._cde.R[idx] = run
+	return RichTextRun{run}
+}
+
+// RemoveRun removes a run from the rich text.
+func (r RichText) RemoveRun(run RichTextRun) {
+	for i, rr := range r._cde.R {
+		if rr == run._dcfc {
+			copy(r._cde.R[i:], r._cde.R[i+1:])
+			r._cde.R = r._cde.R[:len(r._cde.R)-1]
+			return
+		}
+	}
+}
+
+// GetText returns the text of the run.
+func (r RichTextRun) GetText() string { return r._dcfc.T }
+
+func isSet(b *sml.CT_BooleanProperty) bool {
+	return b != nil && (b.ValAttr == nil || *b.ValAttr)
+}
+
+// IsBold returns true if the run is displayed in bold.
+func (r RichTextRun) IsBold() bool { return r._dcfc.RPr != nil && isSet(r._dcfc.RPr.B) }
+
+// IsItalic returns true if the run is displayed in italic.
+func (r RichTextRun) IsItalic() bool { return r._dcfc.RPr != nil && isSet(r._dcfc.RPr.I) }
+
+// IsStrikeThrough returns true if the run is struck through.
+func (r RichTextRun) IsStrikeThrough() bool {
+	return r._dcfc.RPr != nil && isSet(r._dcfc.RPr.Strike)
+}
+
+// GetUnderline returns the underline of the run, or ST_UnderlineValuesUnset if
+// it isn't underlined.
+func (r RichTextRun) GetUnderline() sml.ST_UnderlineValues {
+	if r._dcfc.RPr == nil || r._dcfc.RPr.U == nil {
+		return sml.ST_UnderlineValuesUnset
+	}
+	if r._dcfc.RPr.U.ValAttr == sml.ST_UnderlineValuesUnset {
+		// an underline element without a value is a single underline
+		return sml.ST_UnderlineValuesSingle
+	}
+	return r._dcfc.RPr.U.ValAttr
+}
+
+// GetColor returns the color of the run, or nil if it has none. Use
+// Workbook.ResolveColor to convert it to an RGB color.
+func (r RichTextRun) GetColor() *sml.CT_Color {
+	if r._dcfc.RPr == nil {
+		return nil
+	}
+	return r._dcfc.RPr.Color
+}
+
+// GetSize returns the text size of the run, or zero if it has none.
+func (r RichTextRun) GetSize() measurement.Distance {
+	if r._dcfc.RPr == nil || r._dcfc.RPr.Sz == nil {
+		return 0
+	}
+	return measurement.Distance(r._dcfc.RPr.Sz.ValAttr) * measurement.Point
+}
+
+// GetFont returns the font name of the run, or an empty string if it has none.
+func (r RichTextRun) GetFont() string {
+	if r._dcfc.RPr == nil || r._dcfc.RPr.RFont == nil {
+		return ""
+	}
+	return r._dcfc.RPr.RFont.ValAttr
+}
+
+// GetVerticalAlign returns the vertical alignment of the run, used for
+// superscript and subscript text.
+func (r RichTextRun) GetVerticalAlign() sharedTypes.ST_VerticalAlignRun {
+	if r._dcfc.RPr == nil || r._dcfc.RPr.VertAlign == nil {
+		return sharedTypes.ST_VerticalAlignRunUnset
+	}
+	return r._dcfc.RPr.VertAlign.ValAttr
+}
+
+// SetStrikeThrough controls if the run is struck through.
+func (r RichTextRun) SetStrikeThrough(b bool) {
+	r.ensureRpr()
+	r._dcfc.RPr.Strike = sml.NewCT_BooleanProperty()
+	r._dcfc.RPr.Strike.ValAttr = unioffice.Bool(b)
+}
+
+// SetVerticalAlign sets the vertical alignment of the run, used for
+// superscript and subscript text.
+func (r RichTextRun) SetVerticalAlign(a sharedTypes.ST_VerticalAlignRun) {
+	r.ensureRpr()
+	if a == sharedTypes.ST_VerticalAlignRunUnset {
+		r._dcfc.RPr.VertAlign = nil
+		return
+	}
+	r._dcfc.RPr.VertAlign = sml.NewCT_VerticalAlignFontProperty()
+	r._dcfc.RPr.VertAlign.ValAttr = a
+}
+
+// SetThemeColor sets the text color to a theme color, lightened or darkened
+// by tint which ranges from -1 to 1.
+func (r RichTextRun) SetThemeColor(t ThemeColor, tint float64) {
+	r.ensureRpr()
+	r._dcfc.RPr.Color = NewThemeColor(t, tint)
+}
+
+// ClearProperties removes all formatting from the run.
+func (r RichTextRun) ClearProperties() { r._dcfc.RPr = nil }
@@ -0,0 +1,129 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"testing"
+
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+func TestRichTextRoundTrip(t *testing.T) {
+	td := []struct {
+		Text  string
+		Setup func(r RichTextRun)
+		Check func(r RichTextRun) bool
+	}{
+		{"plain ", func(r RichTextRun) {}, func(r RichTextRun) bool {
+			return !r.IsBold() && !r.IsItalic() && r.GetUnderline() == sml.ST_UnderlineValuesUnset && r.GetColor() == nil
+		}},
+		{"bold ", func(r RichTextRun) { r.SetBold(true) }, func(r RichTextRun) bool { return r.IsBold() && !r.IsItalic() }},
+		{"italic ", func(r RichTextRun) { r.SetItalic(true) }, func(r RichTextRun) bool { return r.IsItalic() }},
+		{"struck ", func(r RichTextRun) { r.SetStrikeThrough(true) }, func(r RichTextRun) bool { return r.IsStrikeThrough() }},
+		{"underlined ", func(r RichTextRun) { r.SetUnderline(sml.ST_UnderlineValuesDouble) }, func(r RichTextRun) bool {
+			return r.GetUnderline() == sml.ST_UnderlineValuesDouble
+		}},
+		{"red ", func(r RichTextRun) { r.SetColor(color.Red) }, func(r RichTextRun) bool {
+			return r.GetColor() != nil && r.GetColor().RgbAttr != nil && *r.GetColor().RgbAttr == *color.Red.AsRGBAString()
+		}},
+		{"accent ", func(r RichTextRun) { r.SetThemeColor(ThemeColorAccent2, -0.25) }, func(r RichTextRun) bool {
+			c := r.GetColor()
+			return c != nil && c.ThemeAttr != nil && *c.ThemeAttr == uint32(ThemeColorAccent2) && c.TintAttr != nil && *c.TintAttr == -0.25
+		}},
+		{"large ", func(r RichTextRun) { r.SetSize(14 * measurement.Point); r.SetFont("Arial") }, func(r RichTextRun) bool {
+			return r.GetSize() == 14*measurement.Point && r.GetFont() == "Arial"
+		}},
+		{"2", func(r RichTextRun) { r.SetVerticalAlign(sharedTypes.ST_VerticalAlignRunSuperscript) }, func(r RichTextRun) bool {
+			return r.GetVerticalAlign() == sharedTypes.ST_VerticalAlignRunSuperscript
+		}},
+	}
+
+	wb := New()
+	sheet := wb.AddSheet()
+	rt := sheet.Cell("A1").SetRichTextString()
+	exp := ""
+	for _, tc := range td {
+		run := rt.AddRun()
+		run.SetText(tc.Text)
+		tc.Setup(run)
+		exp += tc.Text
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	got, ok := rs.Cell("A1").GetRichText()
+	if !ok {
+		t.Fatalf("expected rich text")
+	}
+	if got.Text() != exp {
+		t.Errorf("expected text %q, got %q", exp, got.Text())
+	}
+	runs := got.Runs()
+	if len(runs) != len(td) {
+		t.Fatalf("expected %d runs, got %d", len(td), len(runs))
+	}
+	for i, tc := range td {
+		if runs[i].GetText() != tc.Text || !tc.Check(runs[i]) {
+			t.Errorf("run %q: properties weren't preserved", tc.Text)
+		}
+	}
+}
+
+func TestEditRichText(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	a1, a2 := sheet.Cell("A1"), sheet.Cell("A2")
+	a1.SetString("shared")
+	a2.SetString("shared")
+	sheet.Cell("A3").SetNumber(1.5)
+
+	// a string without formatting is a single run
+	rt, ok := a1.GetRichText()
+	if !ok || len(rt.Runs()) != 1 || rt.Runs()[0].GetText() != "shared" || rt.Runs()[0].IsBold() {
+		t.Errorf("expected a single plain run")
+	}
+	if _, ok := sheet.Cell("A3").GetRichText(); ok {
+		t.Errorf("expected no rich text for a number")
+	}
+
+	// editing a shared string doesn't change the other cells using it
+	rt = a1.EditRichText()
+	rt.Runs()[0].SetBold(true)
+	rt.AddRun().SetText(" text")
+	rt.InsertRun(0).SetText("a ")
+	if a1.GetString() != "a shared text" || a2.GetString() != "shared" {
+		t.Errorf("expected only A1 to change, got %q and %q", a1.GetString(), a2.GetString())
+	}
+	if shared, _ := a2.GetRichText(); shared.Runs()[0].IsBold() {
+		t.Errorf("expected the shared string to stay plain")
+	}
+
+	rt.RemoveRun(rt.Runs()[1])
+	if got := rt.Text(); got != "a  text" {
+		t.Errorf("expected the run to be removed, got %q", got)
+	}
+
+	num := sheet.Cell("A3").EditRichText()
+	if num.Text() != "1.5" {
+		t.Errorf("expected the number as text, got %q", num.Text())
+	}
+	num.Runs()[0].ClearProperties()
+	num.Runs()[0].SetItalic(true)
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	if got := rs.Cell("A1").GetString(); got != "a  text" {
+		t.Errorf("expected the edited text to be saved, got %q", got)
+	}
+	if got, _ := rs.Cell("A3").GetRichText(); len(got.Runs()) != 1 || !got.Runs()[0].IsItalic() {
+		t.Errorf("expected an italic run")
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/schemas.microsoft.com/office/spreadsheetml/x14"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// SparklineGroup is a group of sparklines that share their type, colors and
+// axis settings. Sparklines are stored in the extension list of the
+// worksheet and are only shown by Excel 2010 and later.
+type SparklineGroup struct {
+	x *x14.CT_SparklineGroup
+}
+
+// Sparkline is a single sparkline of a group.
+type Sparkline struct {
+	x *x14.CT_Sparkline
+}
+
+// X returns the inner wrapped XML type.
+func (s Sparkline) X() *x14.CT_Sparkline { return s.x }
+
+// DataRange returns the range of the values shown, e.g. "Sheet1!A2:F2".
+func (s Sparkline) DataRange() string {
+	if s.x.F == nil {
+		return ""
+	}
+	return *s.x.F
+}
+
+// Location returns the cell that displays the sparkline.
+func (s Sparkline) Location() string { return s.x.Sqref }
+
+// AddSparklineGroup adds a group of sparklines of the given type to the sheet.
+// Add the sparklines to the group with AddSparkline. The group has the
+// default colors of Excel.
+func (s *Sheet) AddSparklineGroup(typ x14.ST_SparklineType) SparklineGroup {
+	groups := s.sparklineGroups(true)
+	g := x14.NewCT_SparklineGroup()
+	g.TypeAttr = typ
+	if typ == x14.ST_SparklineTypeLine {
+		g.TypeAttr = x14.ST_SparklineTypeUnset
+	}
+	g.DisplayEmptyCellsAsAttr = x14.ST_DispBlanksAsGap
+	sg := SparklineGroup{g}
+	sg.SetSeriesColor(color.RGB(0x37, 0x56, 0x92))
+	sg.SetNegativeColor(color.RGB(0xD0, 0x00, 0x00))
+	sg.SetAxisColor(color.Black)
+	sg.SetMarkersColor(color.RGB(0xD0, 0x00, 0x00))
+	sg.SetFirstColor(color.RGB(0xD0, 0x00, 0x00))
+	sg.SetLastColor(color.RGB(0xD0, 0x00, 0x00))
+	sg.SetHighColor(color.RGB(0xD0, 0x00, 0x00))
+	sg.SetLowColor(color.RGB(0xD0, 0x00, 0x00))
+	groups.SparklineGroup = append(groups.SparklineGroup, g)
+	return sg
+}
+
+// SparklineGroups returns the sparkline groups of the sheet.
+func (s *Sheet) SparklineGroups() []SparklineGroup {
+	groups := s.sparklineGroups(false)
+	if groups == nil {
+		return nil
+	}
+	ret := []SparklineGroup{}
+	for _, g := range groups.SparklineGroup {
+		ret = append(ret, SparklineGroup{g})
+	}
+	return ret
+}
+
+// RemoveSparklineGroup removes a sparkline group from the sheet. The
+// extension that held the groups is removed with the last group, other
+// extensions are left as they are.
+func (s *Sheet) RemoveSparklineGroup(g SparklineGroup) error {
+	groups := s.sparklineGroups(false)
+	if groups == nil {
+		return ErrorNotFound
+	}
+	for i, o := range groups.SparklineGroup {
+		if o == g.x {
+			copy(groups.SparklineGroup[i:], groups.SparklineGroup[i+1:])
+			groups.SparklineGroup = groups.SparklineGroup[:len(groups.SparklineGroup)-1]
+			if len(groups.SparklineGroup) == 0 {
+				s.removeExtension(x14.SparklineGroupsExtURI)
+			}
+			return nil
+		}
+	}
+	return ErrorNotFound
+}
+
+// sparklineGroups returns the sparkline groups extension of the sheet,
+// creating it if create is set and it doesn't exist yet.
+func (s *Sheet) sparklineGroups(create bool) *x14.SparklineGroups {
+	ws := s.X()
+	if ws.ExtLst != nil {
+		for _, ext := range ws.ExtLst.Ext {
+			if ext.UriAttr == nil || *ext.UriAttr != x14.SparklineGroupsExtURI {
+				continue
+			}
+			if groups, ok := ext.Any.(*x14.SparklineGroups); ok {
+				return groups
+			}
+		}
+	}
+	if !create {
+		return nil
+	}
+	if ws.ExtLst == nil {
+		ws.ExtLst = sml.NewCT_ExtensionList()
+	}
+	groups := x14.NewSparklineGroups()
+	ext := sml.NewCT_Extension()
+	ext.UriAttr = unioffice.String(x14.SparklineGroupsExtURI)
+	ext.Any = groups
+	ws.ExtLst.Ext = append(ws.ExtLst.Ext, ext)
+	return groups
+}
+
+// removeExtension removes the extension with the given URI from the extension
+// list of the sheet.
+func (s *Sheet) removeExtension(uri string) {
+	ws := s.X()
+	if ws.ExtLst == nil {
+		return
+	}
+	exts := ws.ExtLst.Ext[:0]
+	for _, ext := range ws.ExtLst.Ext {
+		if ext.UriAttr == nil || *ext.UriAttr != uri {
+			exts = append(exts, ext)
+		}
+	}
+	ws.ExtLst.Ext = exts
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+	}
+}
+
+// X returns the inner wrapped XML type.
+func (g SparklineGroup) X() *x14.CT_SparklineGroup { return g.x }
+
+// AddSparkline adds a sparkline that shows the values of dataRange, e.g.
+// "Sheet1!A2:F2", in the cell location, e.g. "G2". The data range must
+// include the sheet name.
+func (g SparklineGroup) AddSparkline(dataRange, location string) (Sparkline, error) {
+	if dataRange == "" || location == "" {
+		return Sparkline{}, errors.New("sparkline requires a data range and a location")
+	}
+	sp := x14.NewCT_Sparkline()
+	sp.F = unioffice.String(dataRange)
+	sp.Sqref = location
+	if g.x.Sparklines == nil {
+		g.x.Sparklines = x14.NewCT_Sparklines()
+	}
+	g.x.Sparklines.Sparkline = append(g.x.Sparklines.Sparkline, sp)
+	return Sparkline{sp}, nil
+}
+
+// Sparklines returns the sparklines of the group.
+func (g SparklineGroup) Sparklines() []Sparkline {
+	if g.x.Sparklines == nil {
+		return nil
+	}
+	ret := []Sparkline{}
+	for _, sp := range g.x.Sparklines.Sparkline {
+		ret = append(ret, Sparkline{sp})
+	}
+	return ret
+}
+
+// Type returns the type of the sparklines.
+func (g SparklineGroup) Type() x14.ST_SparklineType {
+	if g.x.TypeAttr == x14.ST_SparklineTypeUnset {
+		return x14.ST_SparklineTypeLine
+	}
+	return g.x.TypeAttr
+}
+
+// SetType sets the type of the sparklines to line, column or win/loss
+// (x14.ST_SparklineTypeStacked).
+func (g SparklineGroup) SetType(t x14.ST_SparklineType) {
+	if t == x14.ST_SparklineTypeLine {
+		t = x14.ST_SparklineTypeUnset
+	}
+	g.x.TypeAttr = t
+}
+
+// SetLineWeight sets the width of lines in points, 0.75 by default.
+func (g SparklineGroup) SetLineWeight(w float64) { g.x.LineWeightAttr = unioffice.Float64(w) }
+
+func sparklineColor(c color.Color) *sml.CT_Color {
+	x := sml.NewCT_Color()
+	x.RgbAttr = c.AsRGBAString()
+	return x
+}
+
+// SetSeriesColor sets the color of the lines or columns.
+func (g SparklineGroup) SetSeriesColor(c color.Color) { g.x.ColorSeries = sparklineColor(c) }
+
+// SetNegativeColor sets the color of negative points.
+func (g SparklineGroup) SetNegativeColor(c color.Color) { g.x.ColorNegative = sparklineColor(c) }
+
+// SetAxisColor sets the color of the horizontal axis.
+func (g SparklineGroup) SetAxisColor(c color.Color) { g.x.ColorAxis = sparklineColor(c) }
+
+// SetMarkersColor sets the color of the markers of line sparklines.
+func (g SparklineGroup) SetMarkersColor(c color.Color) { g.x.ColorMarkers = sparklineColor(c) }
+
+// SetFirstColor sets the color of the first point.
+func (g SparklineGroup) SetFirstColor(c color.Color) { g.x.ColorFirst = sparklineColor(c) }
+
+// SetLastColor sets the color of the last point.
+func (g SparklineGroup) SetLastColor(c color.Color) { g.x.ColorLast = sparklineColor(c) }
+
+// SetHighColor sets the color of the highest point.
+func (g SparklineGroup) SetHighColor(c color.Color) { g.x.ColorHigh = sparklineColor(c) }
+
+// SetLowColor sets the color of the lowest point.
+func (g SparklineGroup) SetLowColor(c color.Color) { g.x.ColorLow = sparklineColor(c) }
+
+func optionalBool(b bool) *bool {
+	if !b {
+		return nil
+	}
+	return unioffice.Bool(true)
+}
+
+// SetShowMarkers controls whether line sparklines show markers on all points.
+func (g SparklineGroup) SetShowMarkers(b bool) { g.x.MarkersAttr = optionalBool(b) }
+
+// SetShowHigh controls whether the highest point is highlighted.
+func (g SparklineGroup) SetShowHigh(b bool) { g.x.HighAttr = optionalBool(b) }
+
+// SetShowLow controls whether the lowest point is highlighted.
+func (g SparklineGroup) SetShowLow(b bool) { g.x.LowAttr = optionalBool(b) }
+
+// SetShowFirst controls whether the first point is highlighted.
+func (g SparklineGroup) SetShowFirst(b bool) { g.x.FirstAttr = optionalBool(b) }
+
+// SetShowLast controls whether the last point is highlighted.
+func (g SparklineGroup) SetShowLast(b bool) { g.x.LastAttr = optionalBool(b) }
+
+// SetShowNegative controls whether negative points are highlighted.
+func (g SparklineGroup) SetShowNegative(b bool) { g.x.NegativeAttr = optionalBool(b) }
+
+// SetShowAxis controls whether the horizontal axis is shown. It is only drawn
+// if the values cross zero.
+func (g SparklineGroup) SetShowAxis(b bool) { g.x.DisplayXAxisAttr = optionalBool(b) }
+
+// SetShowHidden controls whether values in hidden rows and columns are shown.
+func (g SparklineGroup) SetShowHidden(b bool) { g.x.DisplayHiddenAttr = optionalBool(b) }
+
+// SetRightToLeft controls whether the points are plotted from right to left.
+func (g SparklineGroup) SetRightToLeft(b bool) { g.x.RightToLeftAttr = optionalBool(b) }
+
+// SetDisplayEmptyCellsAs sets how empty cells are shown: as gaps, as zero or
+// by connecting the points around them with a line (span).
+func (g SparklineGroup) SetDisplayEmptyCellsAs(d x14.ST_DispBlanksAs) {
+	g.x.DisplayEmptyCellsAsAttr = d
+}
+
+// SetDateAxis uses the dates in the range ref, e.g. "Sheet1!A1:F1", to space
+// the points of all sparklines in the group. An empty ref spaces the points
+// evenly.
+func (g SparklineGroup) SetDateAxis(ref string) {
+	if ref == "" {
+		g.x.DateAxisAttr = nil
+		g.x.F = nil
+		return
+	}
+	g.x.DateAxisAttr = unioffice.Bool(true)
+	g.x.F = unioffice.String(ref)
+}
+
+// SetMinAxisType sets whether the minimum of the vertical axis is chosen for
+// each sparkline (individual), for all sparklines of the group (group) or set
+// with SetMinAxis (custom).
+func (g SparklineGroup) SetMinAxisType(t x14.ST_SparklineAxisMinMax) {
+	if t == x14.ST_SparklineAxisMinMaxIndividual {
+		t = x14.ST_SparklineAxisMinMaxUnset
+	}
+	g.x.MinAxisTypeAttr = t
+	if t != x14.ST_SparklineAxisMinMaxCustom {
+		g.x.ManualMinAttr = nil
+	}
+}
+
+// SetMaxAxisType sets whether the maximum of the vertical axis is chosen for
+// each sparkline (individual), for all sparklines of the group (group) or set
+// with SetMaxAxis (custom).
+func (g SparklineGroup) SetMaxAxisType(t x14.ST_SparklineAxisMinMax) {
+	if t == x14.ST_SparklineAxisMinMaxIndividual {
+		t = x14.ST_SparklineAxisMinMaxUnset
+	}
+	g.x.MaxAxisTypeAttr = t
+	if t != x14.ST_SparklineAxisMinMaxCustom {
+		g.x.ManualMaxAttr = nil
+	}
+}
+
+// SetMinAxis sets a fixed minimum for the vertical axis.
+func (g SparklineGroup) SetMinAxis(v float64) {
+	g.x.MinAxisTypeAttr = x14.ST_SparklineAxisMinMaxCustom
+	g.x.ManualMinAttr = unioffice.Float64(v)
+}
+
+// SetMaxAxis sets a fixed maximum for the vertical axis.
+func (g SparklineGroup) SetMaxAxis(v float64) {
+	g.x.MaxAxisTypeAttr = x14.ST_SparklineAxisMinMaxCustom
+	g.x.ManualMaxAttr = unioffice.Float64(v)
+}
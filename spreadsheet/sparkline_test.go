@@ -0,0 +1,169 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/schemas.microsoft.com/office/spreadsheetml/x14"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+func TestSparklineRoundTrip(t *testing.T) {
+	td := []struct {
+		Type  x14.ST_SparklineType
+		Data  string
+		Loc   string
+		Setup func(g SparklineGroup)
+		Check func(g SparklineGroup) bool
+	}{
+		{x14.ST_SparklineTypeLine, "'Sheet 1'!A1:E1", "F1",
+			func(g SparklineGroup) { g.SetShowMarkers(true); g.SetLineWeight(1.5) },
+			func(g SparklineGroup) bool {
+				return g.X().MarkersAttr != nil && *g.X().MarkersAttr &&
+					g.X().LineWeightAttr != nil && *g.X().LineWeightAttr == 1.5
+			}},
+		{x14.ST_SparklineTypeColumn, "'Sheet 1'!A2:E2", "F2",
+			func(g SparklineGroup) { g.SetShowHigh(true); g.SetHighColor(color.RGB(0, 0xFF, 0)) },
+			func(g SparklineGroup) bool {
+				return g.X().HighAttr != nil && *g.X().HighAttr &&
+					g.X().ColorHigh != nil && *g.X().ColorHigh.RgbAttr == "ff00ff00"
+			}},
+		{x14.ST_SparklineTypeStacked, "'Sheet 1'!A3:E3", "F3",
+			func(g SparklineGroup) { g.SetMinAxis(-1); g.SetMaxAxisType(x14.ST_SparklineAxisMinMaxGroup) },
+			func(g SparklineGroup) bool {
+				return g.X().MinAxisTypeAttr == x14.ST_SparklineAxisMinMaxCustom &&
+					g.X().ManualMinAttr != nil && *g.X().ManualMinAttr == -1 &&
+					g.X().MaxAxisTypeAttr == x14.ST_SparklineAxisMinMaxGroup
+			}},
+		{x14.ST_SparklineTypeLine, "'Sheet 1'!A4:E4", "F4",
+			func(g SparklineGroup) {
+				g.SetDateAxis("'Sheet 1'!A5:E5")
+				g.SetDisplayEmptyCellsAs(x14.ST_DispBlanksAsZero)
+			},
+			func(g SparklineGroup) bool {
+				return g.X().F != nil && *g.X().F == "'Sheet 1'!A5:E5" &&
+					g.X().DisplayEmptyCellsAsAttr == x14.ST_DispBlanksAsZero
+			}},
+	}
+
+	wb := New()
+	sheet := wb.AddSheet()
+	for _, tc := range td {
+		g := sheet.AddSparklineGroup(tc.Type)
+		if _, err := g.AddSparkline(tc.Data, tc.Loc); err != nil {
+			t.Fatalf("error adding sparkline: %s", err)
+		}
+		tc.Setup(g)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	groups := rs.SparklineGroups()
+	if len(groups) != len(td) {
+		t.Fatalf("expected %d sparkline groups, got %d", len(td), len(groups))
+	}
+	for i, tc := range td {
+		g := groups[i]
+		if g.Type() != tc.Type {
+			t.Errorf("group %d: expected type %s, got %s", i, tc.Type, g.Type())
+		}
+		lines := g.Sparklines()
+		if len(lines) != 1 || lines[0].DataRange() != tc.Data || lines[0].Location() != tc.Loc {
+			t.Errorf("group %d: expected a sparkline of %s in %s", i, tc.Data, tc.Loc)
+		}
+		if !tc.Check(g) {
+			t.Errorf("group %d: settings weren't preserved", i)
+		}
+	}
+
+	// removing the last group drops the extension
+	for _, g := range groups {
+		if err := rs.RemoveSparklineGroup(g); err != nil {
+			t.Fatalf("error removing sparkline group: %s", err)
+		}
+	}
+	if len(rs.SparklineGroups()) != 0 {
+		t.Errorf("expected no sparkline groups")
+	}
+	if ext := rs.X().ExtLst; ext != nil {
+		for _, e := range ext.Ext {
+			if e.UriAttr != nil && *e.UriAttr == x14.SparklineGroupsExtURI {
+				t.Errorf("expected the sparkline extension to be removed")
+			}
+		}
+	}
+	if err := rs.RemoveSparklineGroup(groups[0]); err != ErrorNotFound {
+		t.Errorf("expected ErrorNotFound removing a group twice, got %v", err)
+	}
+}
+
+func TestAddSparklineEmpty(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	g := sheet.AddSparklineGroup(x14.ST_SparklineTypeLine)
+	if _, err := g.AddSparkline("", "F1"); err == nil {
+		t.Errorf("expected an error for an empty data range")
+	}
+	if _, err := g.AddSparkline("'Sheet 1'!A1:E1", ""); err == nil {
+		t.Errorf("expected an error for an empty location")
+	}
+}
+
+func TestSparklinesKeepOtherExtensions(t *testing.T) {
+	const uri = "{00000000-0000-0000-0000-000000000001}"
+	hasForeign := func(sheet Sheet) bool {
+		if sheet.X().ExtLst == nil {
+			return false
+		}
+		for _, e := range sheet.X().ExtLst.Ext {
+			if e.UriAttr != nil && *e.UriAttr == uri {
+				a, ok := e.Any.(*unioffice.XSDAny)
+				return ok && a.XMLName.Local == "custom" && string(a.Data) == "kept"
+			}
+		}
+		return false
+	}
+
+	wb := New()
+	sheet := wb.AddSheet()
+	ext := sml.NewCT_Extension()
+	ext.UriAttr = unioffice.String(uri)
+	ext.Any = &unioffice.XSDAny{XMLName: xml.Name{Space: "urn:example", Local: "custom"}, Data: []byte("kept")}
+	sheet.X().ExtLst = sml.NewCT_ExtensionList()
+	sheet.X().ExtLst.Ext = append(sheet.X().ExtLst.Ext, ext)
+
+	first := sheet.AddSparklineGroup(x14.ST_SparklineTypeLine)
+	first.AddSparkline("'Sheet 1'!A1:E1", "F1")
+	second := sheet.AddSparklineGroup(x14.ST_SparklineTypeColumn)
+	second.AddSparkline("'Sheet 1'!A2:E2", "F2")
+	if err := sheet.RemoveSparklineGroup(first); err != nil {
+		t.Fatalf("error removing sparkline group: %s", err)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	if !hasForeign(rs) {
+		t.Errorf("expected the other extension to survive adding sparklines")
+	}
+	if groups := rs.SparklineGroups(); len(groups) != 1 || groups[0].Type() != x14.ST_SparklineTypeColumn {
+		t.Fatalf("expected the column sparklines to remain")
+	}
+	if err := rs.RemoveSparklineGroup(rs.SparklineGroups()[0]); err != nil {
+		t.Fatalf("error removing sparkline group: %s", err)
+	}
+
+	rd = saveAndRead(t, rd)
+	rs = rd.Sheets()[0]
+	if !hasForeign(rs) || len(rs.X().ExtLst.Ext) != 1 {
+		t.Errorf("expected only the other extension to remain")
+	}
+}
@@ -15,7 +15,7 @@
 // UniDoc End User License Agreement (EULA) that is available at:
 // https://unidoc.io/eula/
 // A trial license code for evaluation can be obtained at https://unidoc.io.
-package spreadsheet ;import (_ba "archive/zip";_aa "bytes";_ad "errors";_bf "fmt";_a "github.com/unidoc/unioffice";_c "github.com/unidoc/unioffice/chart";_dfc "github.com/unidoc/unioffice/color";_bcb "github.com/unidoc/unioffice/common";_gbc "github.com/unidoc/unioffice/common/logger";_af "github.com/unidoc/unioffice/common/tempstorage";_fd "github.com/unidoc/unioffice/internal/license";_f "github.com/unidoc/unioffice/measurement";_ed "github.com/unidoc/unioffice/schema/soo/dml";_bda "github.com/unidoc/unioffice/schema/soo/dml/chart";_fg "github.com/unidoc/unioffice/schema/soo/dml/spreadsheetDrawing";_adb "github.com/unidoc/unioffice/schema/soo/pkg/relationships";_fb "github.com/unidoc/unioffice/schema/soo/sml";_e "github.com/unidoc/unioffice/spreadsheet/format";_fa "github.com/unidoc/unioffice/spreadsheet/formula";_db "github.com/unidoc/unioffice/spreadsheet/reference";_ce "github.com/unidoc/unioffice/spreadsheet/update";_ff "github.com/unidoc/unioffice/vmldrawing";_gd "github.com/unidoc/unioffice/zippkg";_ga "image";_bc "image/jpeg";_de "io";_gbg "math";_df "math/big";_d "os";_b "path";_be "path/filepath";_ae "regexp";_bd "sort";_gb "strconv";_gg "strings";_bg "time";);func (_ffeaf *Workbook )onNewRelationship (_ggbe *_gd .DecodeMap ,_ddbg ,_bfba string ,_gdaf []*_ba .File ,_cbgcg *_adb .Relationship ,_aagg _gd .Target )error {_gbgb :=_a .DocTypeSpreadsheet ;switch _bfba {case _a .OfficeDocumentType :_ffeaf ._feeg =_fb .NewWorkbook ();_ggbe .AddTarget (_ddbg ,_ffeaf ._feeg ,_bfba ,0);_ffeaf ._bfdc =_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_ffeaf ._bfdc .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .CorePropertiesType :_ggbe .AddTarget (_ddbg ,_ffeaf .CoreProperties .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .CustomPropertiesType :_ggbe .AddTarget (_ddbg ,_ffeaf .CustomProperties .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .ExtendedPropertiesType :_ggbe .AddTarget (_ddbg ,_ffeaf .AppProperties .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .WorksheetType :_aacga :=_fb .NewWorksheet ();_beea :=uint32 (len (_ffeaf ._dcfb ));_ffeaf ._dcfb =append (_ffeaf ._dcfb ,_aacga );_ggbe .AddTarget (_ddbg ,_aacga ,_bfba ,_beea );_ddec :=_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_ddec .X (),_bfba ,0);_ffeaf ._bbab =append (_ffeaf ._bbab ,_ddec );_ffeaf ._efcda =append (_ffeaf ._efcda ,nil );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._dcfb ));case _a .StylesType :_ffeaf .StyleSheet =NewStyleSheet (_ffeaf );_ggbe .AddTarget (_ddbg ,_ffeaf .StyleSheet .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .ThemeType :_gede :=_ed .NewTheme ();_ffeaf ._ebafd =append (_ffeaf ._ebafd ,_gede );_ggbe .AddTarget (_ddbg ,_gede ,_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._ebafd ));case _a .SharedStringsType :_ffeaf .SharedStrings =NewSharedStrings ();_ggbe .AddTarget (_ddbg ,_ffeaf .SharedStrings .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .ThumbnailType :for _cacecd ,_fecce :=range _gdaf {if _fecce ==nil {continue ;};if _fecce .Name ==_ddbg {_ecdd ,_cdbd :=_fecce .Open ();if _cdbd !=nil {return _bf .Errorf ("e\u0072\u0072\u006f\u0072\u0020\u0072e\u0061\u0064\u0069\u006e\u0067\u0020\u0074\u0068\u0075m\u0062\u006e\u0061i\u006c:\u0020\u0025\u0073",_cdbd );};_ffeaf .Thumbnail ,_ ,_cdbd =_ga .Decode (_ecdd );_ecdd .Close ();if _cdbd !=nil {return _bf .Errorf ("\u0065\u0072\u0072\u006fr\u0020\u0064\u0065\u0063\u006f\u0064\u0069\u006e\u0067\u0020t\u0068u\u006d\u0062\u006e\u0061\u0069\u006c\u003a \u0025\u0073",_cdbd );};_gdaf [_cacecd ]=nil ;};};case _a .ImageType :for _fdbe ,_babbd :=range _ffeaf ._ebegb {_cggf :=_b .Clean (_ddbg );if _cggf ==_fdbe {_cbgcg .TargetAttr =_babbd ;return nil ;};};_gbab :=_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf .Images )+1);for _egad ,_ecgeg :=range _gdaf {if _ecgeg ==nil {continue ;};if _ecgeg .Name ==_b .Clean (_ddbg ){_gabce ,_dgdg :=_gd .ExtractToDiskTmp (_ecgeg ,_ffeaf .TmpPath );if _dgdg !=nil {return _dgdg ;};_ebbgb ,_dgdg :=_bcb .ImageFromStorage (_gabce );if _dgdg !=nil {return _dgdg ;};_aegg :=_bcb .MakeImageRef (_ebbgb ,&_ffeaf .DocBase ,_ffeaf ._bfdc );_aegg .SetTarget (_gbab );_ffeaf ._ebegb [_ecgeg .Name ]=_gbab ;_ffeaf .Images =append (_ffeaf .Images ,_aegg );_gdaf [_egad ]=nil ;};};_cbgcg .TargetAttr =_gbab ;case _a .DrawingType :_dgefe :=_fg .NewWsDr ();_eefa :=uint32 (len (_ffeaf ._dfecb ));_ggbe .AddTarget (_ddbg ,_dgefe ,_bfba ,_eefa );_ffeaf ._dfecb =append (_ffeaf ._dfecb ,_dgefe );_aeba :=_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_aeba .X (),_bfba ,_eefa );_ffeaf ._adfbe =append (_ffeaf ._adfbe ,_aeba );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._dfecb ));case _a .VMLDrawingType :_egca :=_ff .NewContainer ();_bbadc :=uint32 (len (_ffeaf ._bcag ));_ggbe .AddTarget (_ddbg ,_egca ,_bfba ,_bbadc );_ffeaf ._bcag =append (_ffeaf ._bcag ,_egca );case _a .CommentsType :_ffeaf ._efcda [_aagg .Index ]=_fb .NewComments ();_ggbe .AddTarget (_ddbg ,_ffeaf ._efcda [_aagg .Index ],_bfba ,_aagg .Index );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._efcda ));case _a .ChartType :_fbadg :=_bda .NewChartSpace ();_beca :=uint32 (len (_ffeaf ._dcfbf ));_ggbe .AddTarget (_ddbg ,_fbadg ,_bfba ,_beca );_ffeaf ._dcfbf =append (_ffeaf ._dcfbf ,_fbadg );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._dcfbf ));_ffeaf ._dcabe [_cbgcg .TargetAttr ]=_fbadg ;case _a .TableType :_edge :=_fb .NewTable ();_gaca :=uint32 (len (_ffeaf ._cgfcd ));_ggbe .AddTarget (_ddbg ,_edge ,_bfba ,_gaca );_ffeaf ._cgfcd =append (_ffeaf ._cgfcd ,_edge );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._cgfcd ));default:_gbc .Log .Debug ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065d\u0020\u0072\u0065\u006c\u0061\u0074\u0069o\u006e\u0073\u0068\u0069\u0070\u0020\u0025\u0073\u0020\u0025\u0073",_ddbg ,_bfba );};return nil ;};
+package spreadsheet ;import (_ba "archive/zip";_aa "bytes";_ad "errors";_bf "fmt";_a "github.com/unidoc/unioffice";_c "github.com/unidoc/unioffice/chart";_dfc "github.com/unidoc/unioffice/color";_bcb "github.com/unidoc/unioffice/common";_gbc "github.com/unidoc/unioffice/common/logger";_af "github.com/unidoc/unioffice/common/tempstorage";_fd "github.com/unidoc/unioffice/internal/license";_f "github.com/unidoc/unioffice/measurement";_ed "github.com/unidoc/unioffice/schema/soo/dml";_bda "github.com/unidoc/unioffice/schema/soo/dml/chart";_fg "github.com/unidoc/unioffice/schema/soo/dml/spreadsheetDrawing";_adb "github.com/unidoc/unioffice/schema/soo/pkg/relationships";_fb "github.com/unidoc/unioffice/schema/soo/sml";_e "github.com/unidoc/unioffice/spreadsheet/format";_fa "github.com/unidoc/unioffice/spreadsheet/formula";_db "github.com/unidoc/unioffice/spreadsheet/reference";_ce "github.com/unidoc/unioffice/spreadsheet/update";_ff "github.com/unidoc/unioffice/vmldrawing";_bfcgd "github.com/unidoc/unioffice/schema/schemas.microsoft.com/office/spreadsheetml/threadedcomments";_gd "github.com/unidoc/unioffice/zippkg";_ga "image";_bc "image/jpeg";_de "io";_gbg "math";_df "math/big";_d "os";_b "path";_be "path/filepath";_ae "regexp";_bd "sort";_gb "strconv";_gg "strings";_bg "time";);func (_ffeaf *Workbook )onNewRelationship (_ggbe *_gd .DecodeMap ,_ddbg ,_bfba string ,_gdaf []*_ba .File ,_cbgcg *_adb .Relationship ,_aagg _gd .Target )error {_gbgb :=_a .DocTypeSpreadsheet ;switch _bfba {case _a .OfficeDocumentType :_ffeaf ._feeg =_fb .NewWorkbook ();_ggbe .AddTarget (_ddbg ,_ffeaf ._feeg ,_bfba ,0);_ffeaf ._bfdc =_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_ffeaf ._bfdc .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .CorePropertiesType :_ggbe .AddTarget (_ddbg ,_ffeaf .CoreProperties .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .CustomPropertiesType :_ggbe .AddTarget (_ddbg ,_ffeaf .CustomProperties .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .ExtendedPropertiesType :_ggbe .AddTarget (_ddbg ,_ffeaf .AppProperties .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .WorksheetType :_aacga :=_fb .NewWorksheet ();_beea :=uint32 (len (_ffeaf ._dcfb ));_ffeaf ._dcfb =append (_ffeaf ._dcfb ,_aacga );_ggbe .AddTarget (_ddbg ,_aacga ,_bfba ,_beea );_ddec :=_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_ddec .X (),_bfba ,_beea );_ffeaf ._bbab =append (_ffeaf ._bbab ,_ddec );_ffeaf ._efcda =append (_ffeaf ._efcda ,nil );_ffeaf ._dbfgc =append (_ffeaf ._dbfgc ,nil );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._dcfb ));case _a .StylesType :_ffeaf .StyleSheet =NewStyleSheet (_ffeaf );_ggbe .AddTarget (_ddbg ,_ffeaf .StyleSheet .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .ThemeType :_gede :=_ed .NewTheme ();_ffeaf ._ebafd =append (_ffeaf ._ebafd ,_gede );_ggbe .AddTarget (_ddbg ,_gede ,_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._ebafd ));case _a .SharedStringsType :_ffeaf .SharedStrings =NewSharedStrings ();_ggbe .AddTarget (_ddbg ,_ffeaf .SharedStrings .X (),_bfba ,0);_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,0);case _a .ThumbnailType :for _cacecd ,_fecce :=range _gdaf {if _fecce ==nil {continue ;};if _fecce .Name ==_ddbg {_ecdd ,_cdbd :=_fecce .Open ();if _cdbd !=nil {return _bf .Errorf ("e\u0072\u0072\u006f\u0072\u0020\u0072e\u0061\u0064\u0069\u006e\u0067\u0020\u0074\u0068\u0075m\u0062\u006e\u0061i\u006c:\u0020\u0025\u0073",_cdbd );};_ffeaf .Thumbnail ,_ ,_cdbd =_ga .Decode (_ecdd );_ecdd .Close ();if _cdbd !=nil {return _bf .Errorf ("\u0065\u0072\u0072\u006fr\u0020\u0064\u0065\u0063\u006f\u0064\u0069\u006e\u0067\u0020t\u0068u\u006d\u0062\u006e\u0061\u0069\u006c\u003a \u0025\u0073",_cdbd );};_gdaf [_cacecd ]=nil ;};};case _a .ImageType :for _fdbe ,_babbd :=range _ffeaf ._ebegb {_cggf :=_b .Clean (_ddbg );if _cggf ==_fdbe {_cbgcg .TargetAttr =_babbd ;return nil ;};};_gbab :=_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf .Images )+1);for _egad ,_ecgeg :=range _gdaf {if _ecgeg ==nil {continue ;};if _ecgeg .Name ==_b .Clean (_ddbg ){_gabce ,_dgdg :=_gd .ExtractToDiskTmp (_ecgeg ,_ffeaf .TmpPath );if _dgdg !=nil {return _dgdg ;};_ebbgb ,_dgdg :=_bcb .ImageFromStorage (_gabce );if _dgdg !=nil {return _dgdg ;};_aegg :=_bcb .MakeImageRef (_ebbgb ,&_ffeaf .DocBase ,_ffeaf ._bfdc );_aegg .SetTarget (_gbab );_ffeaf ._ebegb [_ecgeg .Name ]=_gbab ;_ffeaf .Images =append (_ffeaf .Images ,_aegg );_gdaf [_egad ]=nil ;};};_cbgcg .TargetAttr =_gbab ;case _a .DrawingType :_dgefe :=_fg .NewWsDr ();_eefa :=uint32 (len (_ffeaf ._dfecb ));_ggbe .AddTarget (_ddbg ,_dgefe ,_bfba ,_eefa );_ffeaf ._dfecb =append (_ffeaf ._dfecb ,_dgefe );_aeba :=_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_aeba .X (),_bfba ,_eefa );_ffeaf ._adfbe =append (_ffeaf ._adfbe ,_aeba );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._dfecb ));case _a .VMLDrawingType :_egca :=_ff .NewContainer ();_bbadc :=uint32 (len (_ffeaf ._bcag ));_ggbe .AddTarget (_ddbg ,_egca ,_bfba ,_bbadc );_ffeaf ._bcag =append (_ffeaf ._bcag ,_egca );_gfacb :=_bcb .NewRelationships ();_ggbe .AddTarget (_gd .RelationsPathFor (_ddbg ),_gfacb .X (),_bfba ,_bbadc );_ffeaf .setVMLRelationships (_egca ,_gfacb );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._bcag ));case _a .CommentsType :_ffeaf ._efcda [_aagg .Index ]=_fb .NewComments ();_ggbe .AddTarget (_ddbg ,_ffeaf ._efcda [_aagg .Index ],_bfba ,_aagg .Index );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,int (_aagg .Index )+1);case _a .ThreadedCommentsType :_ffeaf ._dbfgc [_aagg .Index ]=_bfcgd .NewThreadedComments ();_ggbe .AddTarget (_ddbg ,_ffeaf ._dbfgc [_aagg .Index ],_bfba ,_aagg .Index );case _a .PersonType :_ffeaf ._cagfe =_bfcgd .NewPersonList ();_ggbe .AddTarget (_ddbg ,_ffeaf ._cagfe ,_bfba ,0);case _a .ExternalLinkType :_ffeaf .addExternalLinkPart (_ggbe ,_ddbg ,_bfba ,_cbgcg );case _a .ChartType :_fbadg :=_bda .NewChartSpace ();_beca :=uint32 (len (_ffeaf ._dcfbf ));_ggbe .AddTarget (_ddbg ,_fbadg ,_bfba ,_beca );_ffeaf ._dcfbf =append (_ffeaf ._dcfbf ,_fbadg );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._dcfbf ));_ffeaf ._dcabe [_cbgcg .TargetAttr ]=_fbadg ;case _a .TableType :_edge :=_fb .NewTable ();_gaca :=uint32 (len (_ffeaf ._cgfcd ));_ggbe .AddTarget (_ddbg ,_edge ,_bfba ,_gaca );_ffeaf ._cgfcd =append (_ffeaf ._cgfcd ,_edge );_cbgcg .TargetAttr =_a .RelativeFilename (_gbgb ,_aagg .Typ ,_bfba ,len (_ffeaf ._cgfcd ));default:_gbc .Log .Debug ("\u0075\u006e\u0073\u0075\u0070\u0070\u006f\u0072\u0074\u0065d\u0020\u0072\u0065\u006c\u0061\u0074\u0069o\u006e\u0073\u0068\u0069\u0070\u0020\u0025\u0073\u0020\u0025\u0073",_ddbg ,_bfba );};return nil ;};
 
 // AddComment adds a new comment and returns a RichText which will contain the
 // styled comment text.
@@ -29,7 +29,7 @@ func (_bgga *Workbook )DefinedNames ()[]DefinedName {if _bgga ._feeg .DefinedNam
 func (_adcb *Sheet )InsertRow (rowNum int )Row {_daaa :=uint32 (rowNum );for _ ,_agea :=range _adcb .Rows (){if _agea ._cbge .RAttr !=nil &&*_agea ._cbge .RAttr >=_daaa {*_agea ._cbge .RAttr ++;for _ ,_ecbe :=range _agea .Cells (){_ggceg ,_ddc :=_db .ParseCellReference (_ecbe .Reference ());if _ddc !=nil {continue ;};_ggceg .RowIdx ++;_ecbe ._cga .RAttr =_a .String (_ggceg .String ());};};};for _ ,_dcac :=range _adcb .MergedCells (){_geg ,_ccgb ,_dbee :=_db .ParseRangeReference (_dcac .Reference ());if _dbee !=nil {continue ;};if int (_geg .RowIdx )>=rowNum {_geg .RowIdx ++;};if int (_ccgb .RowIdx )>=rowNum {_ccgb .RowIdx ++;};_ebab :=_bf .Sprintf ("\u0025\u0073\u003a%\u0073",_geg ,_ccgb );_dcac .SetReference (_ebab );};return _adcb .AddNumberedRow (_daaa );};const _dgf ="\u00320\u0030\u0036\u002d\u00301\u002d\u0030\u0032\u0054\u00315\u003a0\u0034:\u0030\u0035\u005a\u0030\u0037\u003a\u00300";
 
 // IsEmpty checks if the cell style contains nothing.
-func (_bccc CellStyle )IsEmpty ()bool {return _bccc ._bcd ==nil ||_bccc ._cfc ==nil ||_bccc ._cba ==nil ||_bccc ._cba .Xf ==nil ;};type evalContext struct{_beee *Sheet ;_age ,_ggf uint32 ;_bgce map[string ]struct{};};
+func (_bccc CellStyle )IsEmpty ()bool {return _bccc ._bcd ==nil ||_bccc ._cfc ==nil ||_bccc ._cba ==nil ||_bccc ._cba .Xf ==nil ;};type evalContext struct{_beee *Sheet ;_age ,_ggf uint32 ;_bgce map[string ]struct{};_ffbe string ;_dgcab *sheetIndex ;};
 
 // SetStyle applies a style to the cell.  This style is referenced in the
 // generated XML via CellStyle.Index().
@@ -116,7 +116,7 @@ func (_abebg *evalContext )GetEpoch ()_bg .Time {return _abebg ._beee ._gccb .Ep
 func (_fefd ConditionalFormatting )AddRule ()ConditionalFormattingRule {_ggce :=_fb .NewCT_CfRule ();_fefd ._bgag .CfRule =append (_fefd ._bgag .CfRule ,_ggce );_edb :=ConditionalFormattingRule {_ggce };_edb .InitializeDefaults ();_edb .SetPriority (int32 (len (_fefd ._bgag .CfRule )+1));return _edb ;};
 
 // Save writes the workbook out to a writer in the zipped xlsx format.
-func (_adgca *Workbook )Save (w _de .Writer )error {const _ebeag ="\u0073\u0070\u0072\u0065ad\u0073\u0068\u0065\u0065\u0074\u003a\u0077\u0062\u002e\u0053\u0061\u0076\u0065";if !_fd .GetLicenseKey ().IsLicensed ()&&!_becd {_bf .Println ("\u0055\u006e\u006ci\u0063\u0065\u006e\u0073e\u0064\u0020\u0076\u0065\u0072\u0073\u0069o\u006e\u0020\u006f\u0066\u0020\u0055\u006e\u0069\u004f\u0066\u0066\u0069\u0063\u0065");_bf .Println ("\u002d\u0020\u0047e\u0074\u0020\u0061\u0020\u0074\u0072\u0069\u0061\u006c\u0020\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0020\u006f\u006e\u0020\u0068\u0074\u0074\u0070\u0073\u003a\u002f\u002fu\u006e\u0069\u0064\u006f\u0063\u002e\u0069\u006f");return _ad .New ("\u0075\u006e\u0069\u006f\u0066\u0066\u0069\u0063\u0065\u0020\u006ci\u0063\u0065\u006e\u0073\u0065\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0064");};if len (_adgca ._ceaca )==0{_gfaf ,_eaae :=_fd .GenRefId ("\u0073\u0077");if _eaae !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_eaae );return _eaae ;};_adgca ._ceaca =_gfaf ;};if _fcce :=_fd .Track (_adgca ._ceaca ,_ebeag );_fcce !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_fcce );return _fcce ;};_beffc :=_ba .NewWriter (w );defer _beffc .Close ();_gcecb :=_a .DocTypeSpreadsheet ;if _cdff :=_gd .MarshalXML (_beffc ,_a .BaseRelsFilename ,_adgca .Rels .X ());_cdff !=nil {return _cdff ;};if _ebbg :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .ExtendedPropertiesType ,_adgca .AppProperties .X ());_ebbg !=nil {return _ebbg ;};if _aabb :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .CorePropertiesType ,_adgca .CoreProperties .X ());_aabb !=nil {return _aabb ;};_eaafa :=_a .AbsoluteFilename (_gcecb ,_a .OfficeDocumentType ,0);if _cgcf :=_gd .MarshalXML (_beffc ,_eaafa ,_adgca ._feeg );_cgcf !=nil {return _cgcf ;};if _fcgac :=_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_eaafa ),_adgca ._bfdc .X ());_fcgac !=nil {return _fcgac ;};if _bdcd :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .StylesType ,_adgca .StyleSheet .X ());_bdcd !=nil {return _bdcd ;};for _ddeagf ,_cage :=range _adgca ._ebafd {if _ggea :=_gd .MarshalXMLByTypeIndex (_beffc ,_gcecb ,_a .ThemeType ,_ddeagf +1,_cage );_ggea !=nil {return _ggea ;};};for _egbd ,_geedg :=range _adgca ._dcfb {_geedg .Dimension .RefAttr =Sheet {_adgca ,nil ,_geedg }.Extents ();_edde :=_a .AbsoluteFilename (_gcecb ,_a .WorksheetType ,_egbd +1);_gd .MarshalXML (_beffc ,_edde ,_geedg );_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_edde ),_adgca ._bbab [_egbd ].X ());};if _cbgg :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .SharedStringsType ,_adgca .SharedStrings .X ());_cbgg !=nil {return _cbgg ;};if _adgca .CustomProperties .X ()!=nil {if _bedb :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .CustomPropertiesType ,_adgca .CustomProperties .X ());_bedb !=nil {return _bedb ;};};if _adgca .Thumbnail !=nil {_cbgfd :=_a .AbsoluteFilename (_gcecb ,_a .ThumbnailType ,0);_bbed ,_fdfa :=_beffc .Create (_cbgfd );if _fdfa !=nil {return _fdfa ;};if _geec :=_bc .Encode (_bbed ,_adgca .Thumbnail ,nil );_geec !=nil {return _geec ;};};for _gdda ,_abgg :=range _adgca ._dcfbf {_gfae :=_a .AbsoluteFilename (_gcecb ,_a .ChartType ,_gdda +1);_gd .MarshalXML (_beffc ,_gfae ,_abgg );};for _cgac ,_dagb :=range _adgca ._cgfcd {_bcdg :=_a .AbsoluteFilename (_gcecb ,_a .TableType ,_cgac +1);_gd .MarshalXML (_beffc ,_bcdg ,_dagb );};for _dbaa ,_gcabf :=range _adgca ._dfecb {_ffbg :=_a .AbsoluteFilename (_gcecb ,_a .DrawingType ,_dbaa +1);_gd .MarshalXML (_beffc ,_ffbg ,_gcabf );if !_adgca ._adfbe [_dbaa ].IsEmpty (){_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_ffbg ),_adgca ._adfbe [_dbaa ].X ());};};for _fefef ,_ffef :=range _adgca ._bcag {_gd .MarshalXML (_beffc ,_a .AbsoluteFilename (_gcecb ,_a .VMLDrawingType ,_fefef +1),_ffef );};for _caf ,_ffffg :=range _adgca .Images {if _faeg :=_bcb .AddImageToZip (_beffc ,_ffffg ,_caf +1,_a .DocTypeSpreadsheet );_faeg !=nil {return _faeg ;};};if _gegbb :=_gd .MarshalXML (_beffc ,_a .ContentTypesFilename ,_adgca .ContentTypes .X ());_gegbb !=nil {return _gegbb ;};for _gfac ,_dbce :=range _adgca ._efcda {if _dbce ==nil {continue ;};_gd .MarshalXML (_beffc ,_a .AbsoluteFilename (_gcecb ,_a .CommentsType ,_gfac +1),_dbce );};if _faecg :=_adgca .WriteExtraFiles (_beffc );_faecg !=nil {return _faecg ;};return _beffc .Close ();};
+func (_adgca *Workbook )Save (w _de .Writer )error {const _ebeag ="\u0073\u0070\u0072\u0065ad\u0073\u0068\u0065\u0065\u0074\u003a\u0077\u0062\u002e\u0053\u0061\u0076\u0065";if !_fd .GetLicenseKey ().IsLicensed ()&&!_becd {_bf .Println ("\u0055\u006e\u006ci\u0063\u0065\u006e\u0073e\u0064\u0020\u0076\u0065\u0072\u0073\u0069o\u006e\u0020\u006f\u0066\u0020\u0055\u006e\u0069\u004f\u0066\u0066\u0069\u0063\u0065");_bf .Println ("\u002d\u0020\u0047e\u0074\u0020\u0061\u0020\u0074\u0072\u0069\u0061\u006c\u0020\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0020\u006f\u006e\u0020\u0068\u0074\u0074\u0070\u0073\u003a\u002f\u002fu\u006e\u0069\u0064\u006f\u0063\u002e\u0069\u006f");return _ad .New ("\u0075\u006e\u0069\u006f\u0066\u0066\u0069\u0063\u0065\u0020\u006ci\u0063\u0065\u006e\u0073\u0065\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0064");};if _adgca ._eedb {_adgca .StyleSheet .Compact ();};_adgca .prepareSheets ();_adgca .prepareComments ();_adgca .prepareExternalLinks ();if len (_adgca ._ceaca )==0{_gfaf ,_eaae :=_fd .GenRefId ("\u0073\u0077");if _eaae !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_eaae );return _eaae ;};_adgca ._ceaca =_gfaf ;};if _fcce :=_fd .Track (_adgca ._ceaca ,_ebeag );_fcce !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_fcce );return _fcce ;};_beffc :=_ba .NewWriter (w );defer _beffc .Close ();_gcecb :=_a .DocTypeSpreadsheet ;if _cdff :=_gd .MarshalXML (_beffc ,_a .BaseRelsFilename ,_adgca .Rels .X ());_cdff !=nil {return _cdff ;};if _ebbg :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .ExtendedPropertiesType ,_adgca .AppProperties .X ());_ebbg !=nil {return _ebbg ;};if _aabb :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .CorePropertiesType ,_adgca .CoreProperties .X ());_aabb !=nil {return _aabb ;};_eaafa :=_a .AbsoluteFilename (_gcecb ,_a .OfficeDocumentType ,0);if _cgcf :=_gd .MarshalXML (_beffc ,_eaafa ,_adgca ._feeg );_cgcf !=nil {return _cgcf ;};if _fcgac :=_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_eaafa ),_adgca ._bfdc .X ());_fcgac !=nil {return _fcgac ;};if _bdcd :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .StylesType ,_adgca .StyleSheet .X ());_bdcd !=nil {return _bdcd ;};for _ddeagf ,_cage :=range _adgca ._ebafd {if _ggea :=_gd .MarshalXMLByTypeIndex (_beffc ,_gcecb ,_a .ThemeType ,_ddeagf +1,_cage );_ggea !=nil {return _ggea ;};};for _egbd ,_geedg :=range _adgca ._dcfb {_geedg .Dimension .RefAttr =Sheet {_adgca ,nil ,_geedg }.Extents ();_edde :=_a .AbsoluteFilename (_gcecb ,_a .WorksheetType ,_egbd +1);_gd .MarshalXML (_beffc ,_edde ,_geedg );_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_edde ),_adgca ._bbab [_egbd ].X ());};if _cbgg :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .SharedStringsType ,_adgca .SharedStrings .X ());_cbgg !=nil {return _cbgg ;};if _adgca .CustomProperties .X ()!=nil {if _bedb :=_gd .MarshalXMLByType (_beffc ,_gcecb ,_a .CustomPropertiesType ,_adgca .CustomProperties .X ());_bedb !=nil {return _bedb ;};};if _adgca .Thumbnail !=nil {_cbgfd :=_a .AbsoluteFilename (_gcecb ,_a .ThumbnailType ,0);_bbed ,_fdfa :=_beffc .Create (_cbgfd );if _fdfa !=nil {return _fdfa ;};if _geec :=_bc .Encode (_bbed ,_adgca .Thumbnail ,nil );_geec !=nil {return _geec ;};};for _gdda ,_abgg :=range _adgca ._dcfbf {_gfae :=_a .AbsoluteFilename (_gcecb ,_a .ChartType ,_gdda +1);_gd .MarshalXML (_beffc ,_gfae ,_abgg );};for _cgac ,_dagb :=range _adgca ._cgfcd {_bcdg :=_a .AbsoluteFilename (_gcecb ,_a .TableType ,_cgac +1);_gd .MarshalXML (_beffc ,_bcdg ,_dagb );};for _dbaa ,_gcabf :=range _adgca ._dfecb {_ffbg :=_a .AbsoluteFilename (_gcecb ,_a .DrawingType ,_dbaa +1);_gd .MarshalXML (_beffc ,_ffbg ,_gcabf );if !_adgca ._adfbe [_dbaa ].IsEmpty (){_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_ffbg ),_adgca ._adfbe [_dbaa ].X ());};};for _fefef ,_ffef :=range _adgca ._bcag {_eabgd :=_a .AbsoluteFilename (_gcecb ,_a .VMLDrawingType ,_fefef +1);_gd .MarshalXML (_beffc ,_eabgd ,_ffef );if _fgbbe ,_cdbcb :=_adgca ._cgbad [_ffef ];_cdbcb &&!_fgbbe .IsEmpty (){_gd .MarshalXML (_beffc ,_gd .RelationsPathFor (_eabgd ),_fgbbe .X ());};};for _caf ,_ffffg :=range _adgca .Images {if _faeg :=_bcb .AddImageToZip (_beffc ,_ffffg ,_caf +1,_a .DocTypeSpreadsheet );_faeg !=nil {return _faeg ;};};if _gegbb :=_gd .MarshalXML (_beffc ,_a .ContentTypesFilename ,_adgca .ContentTypes .X ());_gegbb !=nil {return _gegbb ;};for _gfac ,_dbce :=range _adgca ._efcda {if _dbce ==nil {continue ;};_gd .MarshalXML (_beffc ,_a .AbsoluteFilename (_gcecb ,_a .CommentsType ,_gfac +1),_dbce );};if _fegc :=_adgca .writeThreadedComments (_beffc );_fegc !=nil {return _fegc ;};if _dfbce :=_adgca .writeExternalLinks (_beffc );_dfbce !=nil {return _dfbce ;};if _faecg :=_adgca .WriteExtraFiles (_beffc );_faecg !=nil {return _faecg ;};return _beffc .Close ();};
 
 // SetRotation configures the cell to be rotated.
 func (_gdc CellStyle )SetRotation (deg uint8 ){if _gdc ._cfc .Alignment ==nil {_gdc ._cfc .Alignment =_fb .NewCT_CellAlignment ();};_gdc ._cfc .ApplyAlignmentAttr =_a .Bool (true );_gdc ._cfc .Alignment .TextRotationAttr =_a .Uint8 (deg );};
@@ -195,7 +195,7 @@ func (_dbcg Cell )SetCachedFormulaResult (s string ){_dbcg ._cga .V =&s };
 func (_dbdc ConditionalFormattingRule )SetDataBar ()DataBarScale {_dbdc .clear ();_dbdc .SetType (_fb .ST_CfTypeDataBar );_dbdc ._agd .DataBar =_fb .NewCT_DataBar ();_gcff :=DataBarScale {_dbdc ._agd .DataBar };_gcff .SetShowValue (true );_gcff .SetMinLength (10);_gcff .SetMaxLength (90);return _gcff ;};
 
 // AddView adds a sheet view.
-func (_gaed *Sheet )AddView ()SheetView {if _gaed ._eage .SheetViews ==nil {_gaed ._eage .SheetViews =_fb .NewCT_SheetViews ();};_ffgg :=_fb .NewCT_SheetView ();_gaed ._eage .SheetViews .SheetView =append (_gaed ._eage .SheetViews .SheetView ,_ffgg );return SheetView {_ffgg };};func (_bdb *evalContext )NamedRange (ref string )_fa .Reference {for _ ,_dde :=range _bdb ._beee ._gccb .DefinedNames (){if _dde .Name ()==ref {return _fa .MakeRangeReference (_dde .Content ());};};for _ ,_faa :=range _bdb ._beee ._gccb .Tables (){if _faa .Name ()==ref {return _fa .MakeRangeReference (_bf .Sprintf ("\u0025\u0073\u0021%\u0073",_bdb ._beee .Name (),_faa .Reference ()));};};return _fa .ReferenceInvalid ;};
+func (_gaed *Sheet )AddView ()SheetView {if _gaed ._eage .SheetViews ==nil {_gaed ._eage .SheetViews =_fb .NewCT_SheetViews ();};_ffgg :=_fb .NewCT_SheetView ();_gaed ._eage .SheetViews .SheetView =append (_gaed ._eage .SheetViews .SheetView ,_ffgg );return SheetView {_ffgg };};func (_bdb *evalContext )NamedRange (ref string )_fa .Reference {if _cgdfb ,_afcab :=_bdb .externalName (ref );_afcab {return _cgdfb ;};for _ ,_dde :=range _bdb ._beee ._gccb .DefinedNames (){if _dde .Name ()==ref {return _fa .MakeRangeReference (_dde .Content ());};};if _faa ,_ffag :=_bdb .tableRange (ref );_ffag {return _faa ;};return _fa .ReferenceInvalid ;};
 
 // SetBorder is a helper function for creating borders across multiple cells. In
 // the OOXML spreadsheet format, a border applies to a single cell.  To draw a
@@ -334,7 +334,7 @@ func (_bgceg *Workbook )Epoch ()_bg .Time {if _bgceg .Uses1904Dates (){_bg .Date
 func (_ffad *evalContext )GetLocked (cellRef string )bool {return _ffad ._beee .Cell (cellRef ).getLocked ();};func (_fgg Font )Index ()uint32 {for _adcf ,_ffea :=range _fgg ._fcdf .Fonts .Font {if _fgg ._ebgf ==_ffea {return uint32 (_adcf );};};return 0;};func (_bad Cell )getLabelPrefix ()string {if _bad ._cga .SAttr ==nil {return "";};_aegc :=*_bad ._cga .SAttr ;_cc :=_bad ._ea .StyleSheet .GetCellStyle (_aegc );switch _cc ._cfc .Alignment .HorizontalAttr {case _fb .ST_HorizontalAlignmentLeft :return "\u0027";case _fb .ST_HorizontalAlignmentRight :return "\u0022";case _fb .ST_HorizontalAlignmentCenter :return "\u005e";case _fb .ST_HorizontalAlignmentFill :return "\u005c";default:return "";};};
 
 // AddNumberFormat adds a new blank number format to the stylesheet.
-func (_eafbc StyleSheet )AddNumberFormat ()NumberFormat {if _eafbc ._cfdc .NumFmts ==nil {_eafbc ._cfdc .NumFmts =_fb .NewCT_NumFmts ();};_bcaa :=_fb .NewCT_NumFmt ();_bcaa .NumFmtIdAttr =uint32 (200+len (_eafbc ._cfdc .NumFmts .NumFmt ));_eafbc ._cfdc .NumFmts .NumFmt =append (_eafbc ._cfdc .NumFmts .NumFmt ,_bcaa );_eafbc ._cfdc .NumFmts .CountAttr =_a .Uint32 (uint32 (len (_eafbc ._cfdc .NumFmts .NumFmt )));return NumberFormat {_eafbc ._defd ,_bcaa };};
+func (_eafbc StyleSheet )AddNumberFormat ()NumberFormat {if _eafbc ._cfdc .NumFmts ==nil {_eafbc ._cfdc .NumFmts =_fb .NewCT_NumFmts ();};_bcaa :=_fb .NewCT_NumFmt ();_bcaa .NumFmtIdAttr =nextNumFmtID (_eafbc ._cfdc .NumFmts );_eafbc ._cfdc .NumFmts .NumFmt =append (_eafbc ._cfdc .NumFmts .NumFmt ,_bcaa );_eafbc ._cfdc .NumFmts .CountAttr =_a .Uint32 (uint32 (len (_eafbc ._cfdc .NumFmts .NumFmt )));return NumberFormat {_eafbc ._defd ,_bcaa };};
 
 // SetColOffset sets the column offset of the two cell anchor.
 func (_ecdc TwoCellAnchor )SetColOffset (m _f .Distance ){_gaaee :=m -_ecdc .TopLeft ().ColOffset ();_ecdc .TopLeft ().SetColOffset (m );_ecdc .BottomRight ().SetColOffset (_ecdc .BottomRight ().ColOffset ()+_gaaee );};func (_cdae Fill )Index ()uint32 {if _cdae ._gad ==nil {return 0;};for _edae ,_eead :=range _cdae ._gad .Fill {if _cdae ._egbf ==_eead {return uint32 (_edae );};};return 0;};
@@ -430,7 +430,7 @@ func (_cdf Cell )X ()*_fb .CT_Cell {return _cdf ._cga };
 func (_abgf MergedCell )X ()*_fb .CT_MergeCell {return _abgf ._degf };
 
 // Workbook is the top level container item for a set of spreadsheets.
-type Workbook struct{_bcb .DocBase ;_feeg *_fb .Workbook ;StyleSheet StyleSheet ;SharedStrings SharedStrings ;_efcda []*_fb .Comments ;_dcfb []*_fb .Worksheet ;_bbab []_bcb .Relationships ;_bfdc _bcb .Relationships ;_ebafd []*_ed .Theme ;_dfecb []*_fg .WsDr ;_adfbe []_bcb .Relationships ;_bcag []*_ff .Container ;_dcfbf []*_bda .ChartSpace ;_cgfcd []*_fb .Table ;_adef string ;_ebegb map[string ]string ;_dcabe map[string ]*_bda .ChartSpace ;_ceaca string ;};
+type Workbook struct{_bcb .DocBase ;_feeg *_fb .Workbook ;StyleSheet StyleSheet ;SharedStrings SharedStrings ;_efcda []*_fb .Comments ;_dbfgc []*_bfcgd .ThreadedComments ;_cagfe *_bfcgd .PersonList ;_febgc *externalLinks ;_dcfb []*_fb .Worksheet ;_bbab []_bcb .Relationships ;_bfdc _bcb .Relationships ;_ebafd []*_ed .Theme ;_dfecb []*_fg .WsDr ;_adfbe []_bcb .Relationships ;_bcag []*_ff .Container ;_cgbad map[*_ff .Container ]_bcb .Relationships ;_dcfbf []*_bda .ChartSpace ;_cgfcd []*_fb .Table ;_adef string ;_ebegb map[string ]string ;_dcabe map[string ]*_bda .ChartSpace ;_ceaca string ;_bgdc *styleIndex ;_eedb bool ;};
 
 // InitialView returns the first defined sheet view. If there are no views, one
 // is created and returned.
@@ -462,7 +462,7 @@ func (_bdc CellMarker )SetRowOffset (m _f .Distance ){_bdc ._gdg .RowOff .ST_Coo
 func (_abdc CellMarker )SetCol (col int32 ){_abdc ._gdg .Col =col };func (_dafa *Sheet )removeColumnFromMergedCells (_egfa uint32 )error {if _dafa ._eage .MergeCells ==nil ||_dafa ._eage .MergeCells .MergeCell ==nil {return nil ;};_cbgba :=[]*_fb .CT_MergeCell {};for _ ,_gbbeb :=range _dafa .MergedCells (){_gdbb :=_ebdgc (_gbbeb .Reference (),_egfa ,true );if _gdbb !=""{_gbbeb .SetReference (_gdbb );_cbgba =append (_cbgba ,_gbbeb .X ());};};_dafa ._eage .MergeCells .MergeCell =_cbgba ;return nil ;};type WorkbookProtection struct{_acac *_fb .CT_WorkbookProtection };
 
 // RemoveSheet removes the sheet with the given index from the workbook.
-func (_bggce *Workbook )RemoveSheet (ind int )error {if _bggce .SheetCount ()<=ind {return ErrorNotFound ;};for _ ,_ebadf :=range _bggce ._bfdc .Relationships (){if _ebadf .ID ()==_bggce ._feeg .Sheets .Sheet [ind ].IdAttr {_bggce ._bfdc .Remove (_ebadf );break ;};};_bggce .ContentTypes .RemoveOverride (_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .WorksheetContentType ,ind +1));copy (_bggce ._dcfb [ind :],_bggce ._dcfb [ind +1:]);_bggce ._dcfb =_bggce ._dcfb [:len (_bggce ._dcfb )-1];_dcfbc :=_bggce ._feeg .Sheets .Sheet [ind ];copy (_bggce ._feeg .Sheets .Sheet [ind :],_bggce ._feeg .Sheets .Sheet [ind +1:]);_bggce ._feeg .Sheets .Sheet =_bggce ._feeg .Sheets .Sheet [:len (_bggce ._feeg .Sheets .Sheet )-1];for _gbba :=range _bggce ._feeg .Sheets .Sheet {if _bggce ._feeg .Sheets .Sheet [_gbba ].SheetIdAttr > _dcfbc .SheetIdAttr {_bggce ._feeg .Sheets .Sheet [_gbba ].SheetIdAttr --;};};copy (_bggce ._bbab [ind :],_bggce ._bbab [ind +1:]);_bggce ._bbab =_bggce ._bbab [:len (_bggce ._bbab )-1];copy (_bggce ._efcda [ind :],_bggce ._efcda [ind +1:]);_bggce ._efcda =_bggce ._efcda [:len (_bggce ._efcda )-1];return nil ;};
+func (_bggce *Workbook )RemoveSheet (ind int )error {if _bggce .SheetCount ()<=ind {return ErrorNotFound ;};for _ ,_ebadf :=range _bggce ._bfdc .Relationships (){if _ebadf .ID ()==_bggce ._feeg .Sheets .Sheet [ind ].IdAttr {_bggce ._bfdc .Remove (_ebadf );break ;};};_bggce .ContentTypes .RemoveOverride (_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .WorksheetContentType ,ind +1));copy (_bggce ._dcfb [ind :],_bggce ._dcfb [ind +1:]);_bggce ._dcfb =_bggce ._dcfb [:len (_bggce ._dcfb )-1];_dcfbc :=_bggce ._feeg .Sheets .Sheet [ind ];copy (_bggce ._feeg .Sheets .Sheet [ind :],_bggce ._feeg .Sheets .Sheet [ind +1:]);_bggce ._feeg .Sheets .Sheet =_bggce ._feeg .Sheets .Sheet [:len (_bggce ._feeg .Sheets .Sheet )-1];for _gbba :=range _bggce ._feeg .Sheets .Sheet {if _bggce ._feeg .Sheets .Sheet [_gbba ].SheetIdAttr > _dcfbc .SheetIdAttr {_bggce ._feeg .Sheets .Sheet [_gbba ].SheetIdAttr --;};};copy (_bggce ._bbab [ind :],_bggce ._bbab [ind +1:]);_bggce ._bbab =_bggce ._bbab [:len (_bggce ._bbab )-1];copy (_bggce ._efcda [ind :],_bggce ._efcda [ind +1:]);_bggce ._efcda =_bggce ._efcda [:len (_bggce ._efcda )-1];copy (_bggce ._dbfgc [ind :],_bggce ._dbfgc [ind +1:]);_bggce ._dbfgc =_bggce ._dbfgc [:len (_bggce ._dbfgc )-1];return nil ;};
 
 // Comments returns the list of comments for this sheet
 func (_adg Comments )Comments ()[]Comment {_cec :=[]Comment {};for _ ,_gffe :=range _adg ._bae .CommentList .Comment {_cec =append (_cec ,Comment {_adg ._ebg ,_gffe ,_adg ._bae });};return _cec ;};
@@ -632,7 +632,7 @@ func (_gbbdd *Sheet )GetDrawing ()(*_fg .WsDr ,_bcb .Relationships ){if _addd :=
 // supported,  if formula execution fails either due to a parse error or missing
 // function, or erorr in the result (even if expected) the cached value will be
 // left empty allowing Excel to recompute it on load.
-func (_ecafg *Sheet )RecalculateFormulas (){_cdadb :=_fa .NewEvaluator ();_babf :=_ecafg .FormulaContext ();for _ ,_ecca :=range _ecafg .Rows (){for _ ,_cdcfd :=range _ecca .Cells (){if _cdcfd .X ().F !=nil {_fbbdb :=_cdcfd .X ().F .Content ;if _cdcfd .X ().F .TAttr ==_fb .ST_CellFormulaTypeShared &&len (_fbbdb )==0{continue ;};_cegcb :=_cdadb .Eval (_babf ,_fbbdb ).AsString ();if _cegcb .Type ==_fa .ResultTypeError {_gbc .Log .Debug ("\u0065\u0072\u0072o\u0072\u0020\u0065\u0076a\u0075\u006c\u0061\u0074\u0069\u006e\u0067 \u0066\u006f\u0072\u006d\u0075\u006c\u0061\u0020\u0025\u0073\u003a\u0020\u0025\u0073",_fbbdb ,_cegcb .ErrorMessage );_cdcfd .X ().V =nil ;}else {if _cegcb .Type ==_fa .ResultTypeNumber {_cdcfd .X ().TAttr =_fb .ST_CellTypeN ;}else {_cdcfd .X ().TAttr =_fb .ST_CellTypeInlineStr ;};_cdcfd .X ().V =_a .String (_cegcb .Value ());if _cdcfd .X ().F .TAttr ==_fb .ST_CellFormulaTypeArray {if _cegcb .Type ==_fa .ResultTypeArray {_ecafg .setArray (_cdcfd .Reference (),_cegcb );}else if _cegcb .Type ==_fa .ResultTypeList {_ecafg .setList (_cdcfd .Reference (),_cegcb );};}else if _cdcfd .X ().F .TAttr ==_fb .ST_CellFormulaTypeShared &&_cdcfd .X ().F .RefAttr !=nil {_ecgb ,_ddaa ,_bcgb :=_db .ParseRangeReference (*_cdcfd .X ().F .RefAttr );if _bcgb !=nil {_gbc .Log .Debug ("\u0065\u0072r\u006f\u0072\u0020\u0069n\u0020\u0073h\u0061\u0072\u0065\u0064\u0020\u0066\u006f\u0072m\u0075\u006c\u0061\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063e\u003a\u0020\u0025\u0073",_bcgb );continue ;};_ecafg .setShared (_cdcfd .Reference (),_ecgb ,_ddaa ,_fbbdb );};};};};};};
+func (_ecafg *Sheet )RecalculateFormulas (){_cdadb :=_fa .NewEvaluator ();_babf :=_afgg (_ecafg );for _ ,_ecca :=range _ecafg .Rows (){for _ ,_cdcfd :=range _ecca .Cells (){if _cdcfd .X ().F !=nil {_fbbdb :=_cdcfd .X ().F .Content ;if _cdcfd .X ().F .TAttr ==_fb .ST_CellFormulaTypeShared &&len (_fbbdb )==0{continue ;};_babf ._ffbe =_cdcfd .Reference ();_cegcb :=_cdadb .Eval (_babf ,_fbbdb ).AsString ();if _cegcb .Type ==_fa .ResultTypeError {_gbc .Log .Debug ("\u0065\u0072\u0072o\u0072\u0020\u0065\u0076a\u0075\u006c\u0061\u0074\u0069\u006e\u0067 \u0066\u006f\u0072\u006d\u0075\u006c\u0061\u0020\u0025\u0073\u003a\u0020\u0025\u0073",_fbbdb ,_cegcb .ErrorMessage );_cdcfd .X ().V =nil ;}else {if _cegcb .Type ==_fa .ResultTypeNumber {_cdcfd .X ().TAttr =_fb .ST_CellTypeN ;}else {_cdcfd .X ().TAttr =_fb .ST_CellTypeInlineStr ;};_cdcfd .X ().V =_a .String (_cegcb .Value ());if _cdcfd .X ().F .TAttr ==_fb .ST_CellFormulaTypeArray {if _cegcb .Type ==_fa .ResultTypeArray {_ecafg .setArray (_cdcfd .Reference (),_cegcb );}else if _cegcb .Type ==_fa .ResultTypeList {_ecafg .setList (_cdcfd .Reference (),_cegcb );};}else if _cdcfd .X ().F .TAttr ==_fb .ST_CellFormulaTypeShared &&_cdcfd .X ().F .RefAttr !=nil {_ecgb ,_ddaa ,_bcgb :=_db .ParseRangeReference (*_cdcfd .X ().F .RefAttr );if _bcgb !=nil {_gbc .Log .Debug ("\u0065\u0072r\u006f\u0072\u0020\u0069n\u0020\u0073h\u0061\u0072\u0065\u0064\u0020\u0066\u006f\u0072m\u0075\u006c\u0061\u0020\u0072\u0065\u0066\u0065\u0072\u0065\u006e\u0063e\u003a\u0020\u0025\u0073",_bcgb );continue ;};_ecafg .setShared (_cdcfd .Reference (),_ecgb ,_ddaa ,_fbbdb );};};};};};};
 
 // SetProtectedAndHidden sets protected and hidden for given cellStyle
 func (_bfb CellStyle )SetProtection (protected bool ,hidden bool ){_bfb ._cfc .Protection =&_fb .CT_CellProtection {LockedAttr :&protected ,HiddenAttr :&hidden };};
@@ -651,7 +651,7 @@ func (_ggag RichTextRun )SetSize (m _f .Distance ){_ggag .ensureRpr ();_ggag ._d
 func (_adgf *Sheet )ClearSheetViews (){_adgf ._eage .SheetViews =nil };
 
 // SetOperator sets the operator for the rule.
-func (_bgab ConditionalFormattingRule )SetOperator (t _fb .ST_ConditionalFormattingOperator ){_bgab ._agd .OperatorAttr =t ;};func (_afba *Sheet )removeColumnFromNamedRanges (_fafb uint32 )error {for _ ,_ddeag :=range _afba ._gccb .DefinedNames (){_bffb :=_ddeag .Name ();_fabc :=_ddeag .Content ();_gbfd :=_gg .Split (_fabc ,"\u0021");if len (_gbfd )!=2{return _ad .New ("\u0049\u006e\u0063\u006frr\u0065\u0063\u0074\u0020\u006e\u0061\u006d\u0065\u0064\u0020\u0072\u0061\u006e\u0067e\u003a"+_fabc );};_bdeg :=_gbfd [0];if _afba .Name ()==_bdeg {_dbda :=_afba ._gccb .RemoveDefinedName (_ddeag );if _dbda !=nil {return _dbda ;};_egdb :=_ebdgc (_gbfd [1],_fafb ,true );if _egdb !=""{_acde :=_bdeg +"\u0021"+_egdb ;_afba ._gccb .AddDefinedName (_bffb ,_acde );};};};_abbdf :=0;if _afba ._eage .TableParts !=nil &&_afba ._eage .TableParts .TablePart !=nil {_abbdf =len (_afba ._eage .TableParts .TablePart );};if _abbdf !=0{_ebga :=0;for _ ,_bece :=range _afba ._gccb .Sheets (){if _bece .Name ()==_afba .Name (){break ;}else {if _bece ._eage .TableParts !=nil &&_bece ._eage .TableParts .TablePart !=nil {_ebga +=len (_bece ._eage .TableParts .TablePart );};};};_efff :=_afba ._gccb ._cgfcd [_ebga :_ebga +_abbdf ];for _cbae ,_bbdf :=range _efff {_acca :=_bbdf ;_acca .RefAttr =_ebdgc (_acca .RefAttr ,_fafb ,false );_afba ._gccb ._cgfcd [_ebga +_cbae ]=_acca ;};};return nil ;};
+func (_bgab ConditionalFormattingRule )SetOperator (t _fb .ST_ConditionalFormattingOperator ){_bgab ._agd .OperatorAttr =t ;};func (_afba *Sheet )removeColumnFromNamedRanges (_fafb uint32 )error {for _ ,_ddeag :=range _afba ._gccb .DefinedNames (){_bffb :=_ddeag .Name ();_fabc :=_ddeag .Content ();_gbfd :=_gg .Split (_fabc ,"\u0021");if len (_gbfd )!=2{return _ad .New ("\u0049\u006e\u0063\u006frr\u0065\u0063\u0074\u0020\u006e\u0061\u006d\u0065\u0064\u0020\u0072\u0061\u006e\u0067e\u003a"+_fabc );};_bdeg :=_gbfd [0];if _afba .Name ()==_bdeg {_dbda :=_afba ._gccb .RemoveDefinedName (_ddeag );if _dbda !=nil {return _dbda ;};_egdb :=_ebdgc (_gbfd [1],_fafb ,true );if _egdb !=""{_acde :=_bdeg +"\u0021"+_egdb ;_afba ._gccb .AddDefinedName (_bffb ,_acde );};};};for _ ,_bbdf :=range _afba .Tables (){_bbdf ._bcfd .RefAttr =_ebdgc (_bbdf ._bcfd .RefAttr ,_fafb ,false );};return nil ;};
 
 // X returns the inner wrapped XML type.
 func (_babb DataValidation )X ()*_fb .CT_DataValidation {return _babb ._def };type PatternFill struct{_fba *_fb .CT_PatternFill ;_aacg *_fb .CT_Fill ;};
@@ -720,7 +720,7 @@ func (_ceabg *Workbook )CopySheetByName (name ,copiedSheetName string )(Sheet ,e
 // author's name (as is the case with Excel and Comments.AddCommentWithStyle, it
 // will not be changed).  This method only changes the metadata author of the
 // comment.
-func (_feg Comment )SetAuthor (author string ){_feg ._gbbd .AuthorIdAttr =Comments {_feg ._dcf ,_feg ._bed }.getOrCreateAuthor (author );};func (_bgbd *Sheet )setShared (_aff string ,_eeg ,_bbde _db .CellReference ,_aeee string ){_cae :=_bgbd .FormulaContext ();_geabf :=_fa .NewEvaluator ();for _bddec :=_eeg .RowIdx ;_bddec <=_bbde .RowIdx ;_bddec ++{for _cfaf :=_eeg .ColumnIdx ;_cfaf <=_bbde .ColumnIdx ;_cfaf ++{_gdde :=_bddec -_eeg .RowIdx ;_cfd :=_cfaf -_eeg .ColumnIdx ;_cae .SetOffset (_cfd ,_gdde );_efad :=_geabf .Eval (_cae ,_aeee );_fab :=_bf .Sprintf ("\u0025\u0073\u0025\u0064",_db .IndexToColumn (_cfaf ),_bddec );_ffge :=_bgbd .Cell (_fab );if _efad .Type ==_fa .ResultTypeNumber {_ffge .X ().TAttr =_fb .ST_CellTypeN ;}else {_ffge .X ().TAttr =_fb .ST_CellTypeInlineStr ;};_ffge .X ().V =_a .String (_efad .Value ());};};_ =_geabf ;_ =_cae ;};
+func (_feg Comment )SetAuthor (author string ){_feg ._gbbd .AuthorIdAttr =Comments {_feg ._dcf ,_feg ._bed }.getOrCreateAuthor (author );};func (_bgbd *Sheet )setShared (_aff string ,_eeg ,_bbde _db .CellReference ,_aeee string ){_cae :=_afgg (_bgbd );_geabf :=_fa .NewEvaluator ();for _bddec :=_eeg .RowIdx ;_bddec <=_bbde .RowIdx ;_bddec ++{for _cfaf :=_eeg .ColumnIdx ;_cfaf <=_bbde .ColumnIdx ;_cfaf ++{_gdde :=_bddec -_eeg .RowIdx ;_cfd :=_cfaf -_eeg .ColumnIdx ;_cae .SetOffset (_cfd ,_gdde );_cae ._ffbe =_db .IndexToColumn (_cfaf )+_gb .Itoa (int (_bddec ));_efad :=_geabf .Eval (_cae ,_aeee );_fab :=_bf .Sprintf ("\u0025\u0073\u0025\u0064",_db .IndexToColumn (_cfaf ),_bddec );_ffge :=_bgbd .Cell (_fab );if _efad .Type ==_fa .ResultTypeNumber {_ffge .X ().TAttr =_fb .ST_CellTypeN ;}else {_ffge .X ().TAttr =_fb .ST_CellTypeInlineStr ;};_ffge .X ().V =_a .String (_efad .Value ());};};_ =_geabf ;_ =_cae ;};
 
 // ClearNumberFormat removes any number formatting from the style.
 func (_ffe CellStyle )ClearNumberFormat (){_ffe ._cfc .NumFmtIdAttr =nil ;_ffe ._cfc .ApplyNumberFormatAttr =nil ;};
@@ -802,7 +802,7 @@ func (_dc Cell )Reference ()string {if _dc ._cga .RAttr !=nil {return *_dc ._cga
 func (_fgf Row )SetHeight (d _f .Distance ){_fgf ._cbge .HtAttr =_a .Float64 (float64 (d ));_fgf ._cbge .CustomHeightAttr =_a .Bool (true );};func _bcef ()*_fg .CT_TwoCellAnchor {_gdgc :=_fg .NewCT_TwoCellAnchor ();_gdgc .EditAsAttr =_fg .ST_EditAsOneCell ;_gdgc .From .Col =5;_gdgc .From .Row =0;_gdgc .From .ColOff .ST_CoordinateUnqualified =_a .Int64 (0);_gdgc .From .RowOff .ST_CoordinateUnqualified =_a .Int64 (0);_gdgc .To .Col =10;_gdgc .To .Row =20;_gdgc .To .ColOff .ST_CoordinateUnqualified =_a .Int64 (0);_gdgc .To .RowOff .ST_CoordinateUnqualified =_a .Int64 (0);return _gdgc ;};
 
 // CopySheet copies the existing sheet at index `ind` and puts its copy with the name `copiedSheetName`.
-func (_beba *Workbook )CopySheet (ind int ,copiedSheetName string )(Sheet ,error ){if _beba .SheetCount ()<=ind {return Sheet {},ErrorNotFound ;};var _ggcga _bcb .Relationship ;for _ ,_gfad :=range _beba ._bfdc .Relationships (){if _gfad .ID ()==_beba ._feeg .Sheets .Sheet [ind ].IdAttr {var _feaa bool ;if _ggcga ,_feaa =_beba ._bfdc .CopyRelationship (_gfad .ID ());!_feaa {return Sheet {},ErrorNotFound ;};break ;};};_beba .ContentTypes .CopyOverride (_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .WorksheetContentType ,ind +1),_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .WorksheetContentType ,len (_beba .ContentTypes .X ().Override )));_cfbb :=*_beba ._dcfb [ind ];_beba ._dcfb =append (_beba ._dcfb ,&_cfbb );var _fbccd uint32 =0;for _ ,_cbe :=range _beba ._feeg .Sheets .Sheet {if _cbe .SheetIdAttr > _fbccd {_fbccd =_cbe .SheetIdAttr ;};};_fbccd ++;_cdg :=*_beba ._feeg .Sheets .Sheet [ind ];_cdg .IdAttr =_ggcga .ID ();_cdg .NameAttr =copiedSheetName ;_cdg .SheetIdAttr =_fbccd ;_beba ._feeg .Sheets .Sheet =append (_beba ._feeg .Sheets .Sheet ,&_cdg );_cegd :=_bcb .NewRelationshipsCopy (_beba ._bbab [ind ]);_beba ._bbab =append (_beba ._bbab ,_cegd );_cegce :=_beba ._efcda [ind ];if _cegce ==nil {_beba ._efcda =append (_beba ._efcda ,nil );}else {_afae :=*_cegce ;_beba ._efcda =append (_beba ._efcda ,&_afae );};_gbbb :=Sheet {_beba ,&_cdg ,&_cfbb };return _gbbb ,nil ;};
+func (_beba *Workbook )CopySheet (ind int ,copiedSheetName string )(Sheet ,error ){if _beba .SheetCount ()<=ind {return Sheet {},ErrorNotFound ;};var _ggcga _bcb .Relationship ;for _ ,_gfad :=range _beba ._bfdc .Relationships (){if _gfad .ID ()==_beba ._feeg .Sheets .Sheet [ind ].IdAttr {var _feaa bool ;if _ggcga ,_feaa =_beba ._bfdc .CopyRelationship (_gfad .ID ());!_feaa {return Sheet {},ErrorNotFound ;};break ;};};_beba .ContentTypes .CopyOverride (_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .WorksheetContentType ,ind +1),_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .WorksheetContentType ,len (_beba .ContentTypes .X ().Override )));_cfbb :=*_beba ._dcfb [ind ];_beba ._dcfb =append (_beba ._dcfb ,&_cfbb );var _fbccd uint32 =0;for _ ,_cbe :=range _beba ._feeg .Sheets .Sheet {if _cbe .SheetIdAttr > _fbccd {_fbccd =_cbe .SheetIdAttr ;};};_fbccd ++;_cdg :=*_beba ._feeg .Sheets .Sheet [ind ];_cdg .IdAttr =_ggcga .ID ();_cdg .NameAttr =copiedSheetName ;_cdg .SheetIdAttr =_fbccd ;_beba ._feeg .Sheets .Sheet =append (_beba ._feeg .Sheets .Sheet ,&_cdg );_cegd :=_bcb .NewRelationshipsCopy (_beba ._bbab [ind ]);_beba ._bbab =append (_beba ._bbab ,_cegd );_cegce :=_beba ._efcda [ind ];if _cegce ==nil {_beba ._efcda =append (_beba ._efcda ,nil );}else {_afae :=*_cegce ;_beba ._efcda =append (_beba ._efcda ,&_afae );};_beba .copySheetComments (ind );_beba .copySheetPrintNames (ind );_gbbb :=Sheet {_beba ,&_cdg ,&_cfbb };return _gbbb ,nil ;};
 
 // AddHyperlink adds a hyperlink to a sheet. Adding the hyperlink to the sheet
 // and setting it on a cell is more efficient than setting hyperlinks directly
@@ -820,7 +820,7 @@ func (_ecac StyleSheet )AddDifferentialStyle ()DifferentialStyle {if _ecac ._cfd
 // then formatting the value according to the format string.  This should only
 // be used if you care about replicating what Excel would show, otherwise
 // GetValueAsNumber()/GetValueAsTime
-func (_bgcb Cell )GetFormattedValue ()string {_dbd :=_bgcb .getFormat ();switch _bgcb ._cga .TAttr {case _fb .ST_CellTypeB :_bcge ,_ :=_bgcb .GetValueAsBool ();if _bcge {return "\u0054\u0052\u0055\u0045";};return "\u0046\u0041\u004cS\u0045";case _fb .ST_CellTypeN :_dgc ,_ :=_bgcb .GetValueAsNumber ();return _e .Number (_dgc ,_dbd );case _fb .ST_CellTypeE :if _bgcb ._cga .V !=nil {return *_bgcb ._cga .V ;};return "";case _fb .ST_CellTypeS ,_fb .ST_CellTypeInlineStr :return _e .String (_bgcb .GetString (),_dbd );case _fb .ST_CellTypeStr :_aed :=_bgcb .GetString ();if _e .IsNumber (_aed ){_feea ,_ :=_gb .ParseFloat (_aed ,64);return _e .Number (_feea ,_dbd );};return _e .String (_aed ,_dbd );case _fb .ST_CellTypeUnset :fallthrough;default:_dec ,_ :=_bgcb .GetRawValue ();if len (_dec )==0{return "";};_afa ,_dea :=_bgcb .GetValueAsNumber ();if _dea ==nil {return _e .Number (_afa ,_dbd );};return _e .String (_dec ,_dbd );};};
+func (_bgcb Cell )GetFormattedValue ()string {return _bgcb .GetFormattedValueLocale (nil );};
 
 // X returns the inner wrapped XML type.
 func (_eaeb Row )X ()*_fb .CT_Row {return _eaeb ._cbge };
@@ -869,7 +869,7 @@ func (_cfcc DataBarScale )SetMaxLength (l uint32 ){_cfcc ._efcf .MaxLengthAttr =
 
 // RemoveFont removes a font from the style sheet.  It *does not* update styles that refer
 // to this font.
-func (_gdfa StyleSheet )RemoveFont (f Font )error {for _dcedb ,_gaadf :=range _gdfa ._cfdc .Fonts .Font {if _gaadf ==f .X (){_gdfa ._cfdc .Fonts .Font =append (_gdfa ._cfdc .Fonts .Font [:_dcedb ],_gdfa ._cfdc .Fonts .Font [_dcedb +1:]...);return nil ;};};return _ad .New ("\u0066\u006f\u006e\u0074\u0020\u006e\u006f\u0074\u0020f\u006f\u0075\u006e\u0064");};type Table struct{_bcfd *_fb .Table };type ConditionalFormattingRule struct{_agd *_fb .CT_CfRule };func (_gfbe *Sheet )updateAfterRemove (_dfca uint32 ,_aggf _ce .UpdateAction )error {_eecd :=_gfbe .Name ();_gfdg :=&_ce .UpdateQuery {UpdateType :_aggf ,ColumnIdx :_dfca ,SheetToUpdate :_eecd };for _ ,_ddf :=range _gfbe ._gccb .Sheets (){_gfdg .UpdateCurrentSheet =_eecd ==_ddf .Name ();for _ ,_ccag :=range _ddf .Rows (){for _ ,_dced :=range _ccag .Cells (){if _dced .X ().F !=nil {_ebfc :=_dced .X ().F .Content ;_cgae :=_fa .ParseString (_ebfc );if _cgae ==nil {_dced .SetError ("\u0023\u0052\u0045F\u0021");}else {_cbcf :=_cgae .Update (_gfdg );_dced .X ().F .Content =_bf .Sprintf ("\u003d\u0025\u0073",_cbcf .String ());};};};};};return nil ;};
+func (_gdfa StyleSheet )RemoveFont (f Font )error {for _dcedb ,_gaadf :=range _gdfa ._cfdc .Fonts .Font {if _gaadf ==f .X (){_gdfa ._cfdc .Fonts .Font =append (_gdfa ._cfdc .Fonts .Font [:_dcedb ],_gdfa ._cfdc .Fonts .Font [_dcedb +1:]...);return nil ;};};return _ad .New ("\u0066\u006f\u006e\u0074\u0020\u006e\u006f\u0074\u0020f\u006f\u0075\u006e\u0064");};type Table struct{_bcfd *_fb .Table ;_fbdc *Workbook ;};type ConditionalFormattingRule struct{_agd *_fb .CT_CfRule };func (_gfbe *Sheet )updateAfterRemove (_dfca uint32 ,_aggf _ce .UpdateAction )error {_eecd :=_gfbe .Name ();_gfdg :=&_ce .UpdateQuery {UpdateType :_aggf ,ColumnIdx :_dfca ,SheetToUpdate :_eecd };for _ ,_ddf :=range _gfbe ._gccb .Sheets (){_gfdg .UpdateCurrentSheet =_eecd ==_ddf .Name ();for _ ,_ccag :=range _ddf .Rows (){for _ ,_dced :=range _ccag .Cells (){if _dced .X ().F !=nil {_ebfc :=_dced .X ().F .Content ;_cgae :=_fa .ParseString (_ebfc );if _cgae ==nil {_dced .SetError ("\u0023\u0052\u0045F\u0021");}else {_cbcf :=_cgae .Update (_gfdg );_dced .X ().F .Content =_bf .Sprintf ("\u003d\u0025\u0073",_cbcf .String ());};};};};};return nil ;};
 
 // Type returns the type of anchor
 func (_gcg AbsoluteAnchor )Type ()AnchorType {return AnchorTypeAbsolute };
@@ -920,7 +920,7 @@ func (_dgb Cell )SetFormulaRaw (s string ){_beed :=_fa .ParseString (s );if _bee
 func (_fcfed *Workbook )Protection ()WorkbookProtection {if _fcfed ._feeg .WorkbookProtection ==nil {_fcfed ._feeg .WorkbookProtection =_fb .NewCT_WorkbookProtection ();};return WorkbookProtection {_fcfed ._feeg .WorkbookProtection };};
 
 // AddCommentWithStyle adds a new comment styled in a default way
-func (_gda Comments )AddCommentWithStyle (cellRef string ,author string ,comment string )error {_gfa :=_gda .AddComment (cellRef ,author );_bgcf :=_gfa .AddRun ();_bgcf .SetBold (true );_bgcf .SetSize (10);_bgcf .SetColor (_dfc .Black );_bgcf .SetFont ("\u0043a\u006c\u0069\u0062\u0072\u0069");_bgcf .SetText (author +"\u003a");_bgcf =_gfa .AddRun ();_bgcf .SetSize (10);_bgcf .SetFont ("\u0043a\u006c\u0069\u0062\u0072\u0069");_bgcf .SetColor (_dfc .Black );_bgcf .SetText ("\u000d\u000a"+comment +"\u000d\u000a");_adcd ,_ebe :=_db .ParseCellReference (cellRef );if _ebe !=nil {return _ebe ;};_gda ._ebg ._bcag [0].Shape =append (_gda ._ebg ._bcag [0].Shape ,_ff .NewCommentShape (int64 (_adcd .ColumnIdx ),int64 (_adcd .RowIdx -1)));return nil ;};func (_deg Fills )AddFill ()Fill {_fccf :=_fb .NewCT_Fill ();_deg ._fdaf .Fill =append (_deg ._fdaf .Fill ,_fccf );_deg ._fdaf .CountAttr =_a .Uint32 (uint32 (len (_deg ._fdaf .Fill )));return Fill {_fccf ,_deg ._fdaf };};
+func (_gda Comments )AddCommentWithStyle (cellRef string ,author string ,comment string )error {_gfa :=_gda .AddComment (cellRef ,author );_bgcf :=_gfa .AddRun ();_bgcf .SetBold (true );_bgcf .SetSize (10);_bgcf .SetColor (_dfc .Black );_bgcf .SetFont ("\u0043a\u006c\u0069\u0062\u0072\u0069");_bgcf .SetText (author +"\u003a");_bgcf =_gfa .AddRun ();_bgcf .SetSize (10);_bgcf .SetFont ("\u0043a\u006c\u0069\u0062\u0072\u0069");_bgcf .SetColor (_dfc .Black );_bgcf .SetText ("\u000d\u000a"+comment +"\u000d\u000a");_adcd ,_ebe :=_db .ParseCellReference (cellRef );if _ebe !=nil {return _ebe ;};if _cbda :=_gda .vmlDrawing ();_cbda !=nil {_cbda .Shape =append (_cbda .Shape ,_ff .NewCommentShape (int64 (_adcd .ColumnIdx ),int64 (_adcd .RowIdx -1)));};return nil ;};func (_deg Fills )AddFill ()Fill {_fccf :=_fb .NewCT_Fill ();_deg ._fdaf .Fill =append (_deg ._fdaf .Fill ,_fccf );_deg ._fdaf .CountAttr =_a .Uint32 (uint32 (len (_deg ._fdaf .Fill )));return Fill {_fccf ,_deg ._fdaf };};
 
 // Type returns the type of anchor
 func (_bccf OneCellAnchor )Type ()AnchorType {return AnchorTypeOneCell };
@@ -935,7 +935,7 @@ func (_gccc Cell )GetValueAsNumber ()(float64 ,error ){if _gccc ._cga .V ==nil &
 func (_cf CellMarker )X ()*_fg .CT_Marker {return _cf ._gdg };
 
 // Comments returns the comments for a sheet.
-func (_afee *Sheet )Comments ()Comments {for _ggg ,_gdae :=range _afee ._gccb ._dcfb {if _gdae ==_afee ._eage {if _afee ._gccb ._efcda [_ggg ]==nil {_afee ._gccb ._efcda [_ggg ]=_fb .NewComments ();_afee ._gccb ._bbab [_ggg ].AddAutoRelationship (_a .DocTypeSpreadsheet ,_a .WorksheetType ,_ggg +1,_a .CommentsType );_afee ._gccb .ContentTypes .AddOverride (_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .CommentsType ,_ggg +1),_a .CommentsContentType );};if len (_afee ._gccb ._bcag )==0{_afee ._gccb ._bcag =append (_afee ._gccb ._bcag ,_ff .NewCommentDrawing ());_agaa :=_afee ._gccb ._bbab [_ggg ].AddAutoRelationship (_a .DocTypeSpreadsheet ,_a .WorksheetType ,1,_a .VMLDrawingType );if _afee ._eage .LegacyDrawing ==nil {_afee ._eage .LegacyDrawing =_fb .NewCT_LegacyDrawing ();};_afee ._eage .LegacyDrawing .IdAttr =_agaa .ID ();};return Comments {_afee ._gccb ,_afee ._gccb ._efcda [_ggg ]};};};_gbc .Log .Debug ("\u0061\u0074\u0074\u0065\u006dp\u0074\u0065\u0064\u0020\u0074\u006f\u0020\u0061\u0063\u0063\u0065\u0073\u0073 \u0063\u006f\u006d\u006d\u0065\u006e\u0074\u0073\u0020\u0066\u006f\u0072\u0020\u006e\u006f\u006e\u002d\u0065\u0078\u0069\u0073\u0074\u0065\u006e\u0074\u0020\u0073\u0068\u0065\u0065t");return Comments {};};func (_aege *Sheet )addNumberedRowFast (_ebeg uint32 )Row {_ggcf :=_fb .NewCT_Row ();_ggcf .RAttr =_a .Uint32 (_ebeg );_aege ._eage .SheetData .Row =append (_aege ._eage .SheetData .Row ,_ggcf );return Row {_aege ._gccb ,_aege ,_ggcf };};func (_gbef Font )SetItalic (b bool ){if b {_gbef ._ebgf .I =[]*_fb .CT_BooleanProperty {{}};}else {_gbef ._ebgf .I =nil ;};};
+func (_afee *Sheet )Comments ()Comments {for _ggg ,_gdae :=range _afee ._gccb ._dcfb {if _gdae ==_afee ._eage {if _afee ._gccb ._efcda [_ggg ]==nil {_afee ._gccb ._efcda [_ggg ]=_fb .NewComments ();_afee ._gccb ._bbab [_ggg ].AddAutoRelationship (_a .DocTypeSpreadsheet ,_a .WorksheetType ,_ggg +1,_a .CommentsType );_afee ._gccb .ContentTypes .AddOverride (_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .CommentsType ,_ggg +1),_a .CommentsContentType );};if _afee ._eage .LegacyDrawing ==nil {_afee ._gccb ._bcag =append (_afee ._gccb ._bcag ,_ff .NewCommentDrawing ());_agaa :=_afee ._gccb ._bbab [_ggg ].AddAutoRelationship (_a .DocTypeSpreadsheet ,_a .WorksheetType ,len (_afee ._gccb ._bcag ),_a .VMLDrawingType );if _afee ._eage .LegacyDrawing ==nil {_afee ._eage .LegacyDrawing =_fb .NewCT_LegacyDrawing ();};_afee ._eage .LegacyDrawing .IdAttr =_agaa .ID ();};return Comments {_afee ._gccb ,_afee ._gccb ._efcda [_ggg ]};};};_gbc .Log .Debug ("\u0061\u0074\u0074\u0065\u006dp\u0074\u0065\u0064\u0020\u0074\u006f\u0020\u0061\u0063\u0063\u0065\u0073\u0073 \u0063\u006f\u006d\u006d\u0065\u006e\u0074\u0073\u0020\u0066\u006f\u0072\u0020\u006e\u006f\u006e\u002d\u0065\u0078\u0069\u0073\u0074\u0065\u006e\u0074\u0020\u0073\u0068\u0065\u0065t");return Comments {};};func (_aege *Sheet )addNumberedRowFast (_ebeg uint32 )Row {_ggcf :=_fb .NewCT_Row ();_ggcf .RAttr =_a .Uint32 (_ebeg );_aege ._eage .SheetData .Row =append (_aege ._eage .SheetData .Row ,_ggcf );return Row {_aege ._gccb ,_aege ,_ggcf };};func (_gbef Font )SetItalic (b bool ){if b {_gbef ._ebgf .I =[]*_fb .CT_BooleanProperty {{}};}else {_gbef ._ebgf .I =nil ;};};
 
 // CellText is used for keeping text with references to a cell where it is located.
 type CellText struct{Text string ;Cell Cell ;};
@@ -1012,7 +1012,7 @@ func (_gbed DataBarScale )SetShowValue (b bool ){_gbed ._efcf .ShowValueAttr =_a
 func (_efg Cell )HasFormula ()bool {return _efg ._cga .F !=nil };
 
 // Tables returns a slice of all defined tables in the workbook.
-func (_agce *Workbook )Tables ()[]Table {if _agce ._cgfcd ==nil {return nil ;};_edbf :=[]Table {};for _ ,_aagc :=range _agce ._cgfcd {_edbf =append (_edbf ,Table {_aagc });};return _edbf ;};
+func (_agce *Workbook )Tables ()[]Table {if _agce ._cgfcd ==nil {return nil ;};_edbf :=[]Table {};for _ ,_aagc :=range _agce ._cgfcd {_edbf =append (_edbf ,Table {_aagc ,_agce });};return _edbf ;};
 
 // SetHeight sets the height of the anchored object.
 func (_bdfe OneCellAnchor )SetHeight (h _f .Distance ){_bdfe ._gadf .Ext .CyAttr =int64 (h /_f .EMU )};
@@ -1058,7 +1058,7 @@ func (_dbfg OneCellAnchor )SetRowOffset (m _f .Distance ){_dbfg .TopLeft ().SetR
 func (_ecdg *Sheet )Workbook ()*Workbook {return _ecdg ._gccb };func (_addb Sheet )IsValid ()bool {return _addb ._eage !=nil };
 
 // AddSheet adds a new sheet to a workbook.
-func (_egcf *Workbook )AddSheet ()Sheet {_bbad :=_fb .NewCT_Sheet ();_bbad .SheetIdAttr =1;for _ ,_gcec :=range _egcf ._feeg .Sheets .Sheet {if _bbad .SheetIdAttr <=_gcec .SheetIdAttr {_bbad .SheetIdAttr =_gcec .SheetIdAttr +1;};};_egcf ._feeg .Sheets .Sheet =append (_egcf ._feeg .Sheets .Sheet ,_bbad );_bbad .NameAttr =_bf .Sprintf ("\u0053\u0068\u0065\u0065\u0074\u0020\u0025\u0064",_bbad .SheetIdAttr );_edgg :=_fb .NewWorksheet ();_edgg .Dimension =_fb .NewCT_SheetDimension ();_edgg .Dimension .RefAttr ="\u0041\u0031";_egcf ._dcfb =append (_egcf ._dcfb ,_edgg );_ccgd :=_bcb .NewRelationships ();_egcf ._bbab =append (_egcf ._bbab ,_ccgd );_edgg .SheetData =_fb .NewCT_SheetData ();_egcf ._efcda =append (_egcf ._efcda ,nil );_defg :=_a .DocTypeSpreadsheet ;_fecf :=_egcf ._bfdc .AddAutoRelationship (_defg ,_a .OfficeDocumentType ,len (_egcf ._feeg .Sheets .Sheet ),_a .WorksheetType );_bbad .IdAttr =_fecf .ID ();_egcf .ContentTypes .AddOverride (_a .AbsoluteFilename (_defg ,_a .WorksheetContentType ,len (_egcf ._feeg .Sheets .Sheet )),_a .WorksheetContentType );return Sheet {_egcf ,_bbad ,_edgg };};
+func (_egcf *Workbook )AddSheet ()Sheet {_bbad :=_fb .NewCT_Sheet ();_bbad .SheetIdAttr =1;for _ ,_gcec :=range _egcf ._feeg .Sheets .Sheet {if _bbad .SheetIdAttr <=_gcec .SheetIdAttr {_bbad .SheetIdAttr =_gcec .SheetIdAttr +1;};};_egcf ._feeg .Sheets .Sheet =append (_egcf ._feeg .Sheets .Sheet ,_bbad );_bbad .NameAttr =_bf .Sprintf ("\u0053\u0068\u0065\u0065\u0074\u0020\u0025\u0064",_bbad .SheetIdAttr );_edgg :=_fb .NewWorksheet ();_edgg .Dimension =_fb .NewCT_SheetDimension ();_edgg .Dimension .RefAttr ="\u0041\u0031";_egcf ._dcfb =append (_egcf ._dcfb ,_edgg );_ccgd :=_bcb .NewRelationships ();_egcf ._bbab =append (_egcf ._bbab ,_ccgd );_edgg .SheetData =_fb .NewCT_SheetData ();_egcf ._efcda =append (_egcf ._efcda ,nil );_egcf ._dbfgc =append (_egcf ._dbfgc ,nil );_defg :=_a .DocTypeSpreadsheet ;_fecf :=_egcf ._bfdc .AddAutoRelationship (_defg ,_a .OfficeDocumentType ,len (_egcf ._feeg .Sheets .Sheet ),_a .WorksheetType );_bbad .IdAttr =_fecf .ID ();_egcf .ContentTypes .AddOverride (_a .AbsoluteFilename (_defg ,_a .WorksheetContentType ,len (_egcf ._feeg .Sheets .Sheet )),_a .WorksheetContentType );return Sheet {_egcf ,_bbad ,_edgg };};
 
 // Anchor is the interface implemented by anchors. It's modeled after the most
 // common anchor (Two cell variant with a from/to position), but will also be
@@ -1132,7 +1132,7 @@ type OneCellAnchor struct{_gadf *_fg .CT_OneCellAnchor };
 type DefinedName struct{_dgeb *_fb .CT_DefinedName };
 
 // SetIcons sets the icon set to use for display.
-func (_acee IconScale )SetIcons (t _fb .ST_IconSetType ){_acee ._ebag .IconSetAttr =t };func (_egce *Sheet )getAllCellsInFormulaArrays (_beda bool )(map[string ]bool ,error ){_fabcd :=_fa .NewEvaluator ();_deage :=_egce .FormulaContext ();_bedg :=map[string ]bool {};for _ ,_efed :=range _egce .Rows (){for _ ,_ccd :=range _efed .Cells (){if _ccd .X ().F !=nil {_aaca :=_ccd .X ().F .Content ;if _ccd .X ().F .TAttr ==_fb .ST_CellFormulaTypeArray {_dfdf :=_fabcd .Eval (_deage ,_aaca ).AsString ();if _dfdf .Type ==_fa .ResultTypeError {_gbc .Log .Debug ("\u0065\u0072\u0072o\u0072\u0020\u0065\u0076a\u0075\u006c\u0061\u0074\u0069\u006e\u0067 \u0066\u006f\u0072\u006d\u0075\u006c\u0061\u0020\u0025\u0073\u003a\u0020\u0025\u0073",_aaca ,_dfdf .ErrorMessage );_ccd .X ().V =nil ;};if _dfdf .Type ==_fa .ResultTypeArray {_gcda ,_ddb :=_db .ParseCellReference (_ccd .Reference ());if _ddb !=nil {return map[string ]bool {},_ddb ;};if (_beda &&len (_dfdf .ValueArray )==1)||(!_beda &&len (_dfdf .ValueArray [0])==1){continue ;};for _bdda ,_afbd :=range _dfdf .ValueArray {_adgee :=_gcda .RowIdx +uint32 (_bdda );for _cgfa :=range _afbd {_gcba :=_db .IndexToColumn (_gcda .ColumnIdx +uint32 (_cgfa ));_bedg [_bf .Sprintf ("\u0025\u0073\u0025\u0064",_gcba ,_adgee )]=true ;};};}else if _dfdf .Type ==_fa .ResultTypeList {_gdca ,_gfce :=_db .ParseCellReference (_ccd .Reference ());if _gfce !=nil {return map[string ]bool {},_gfce ;};if _beda ||len (_dfdf .ValueList )==1{continue ;};_eedc :=_gdca .RowIdx ;for _becgc :=range _dfdf .ValueList {_ceef :=_db .IndexToColumn (_gdca .ColumnIdx +uint32 (_becgc ));_bedg [_bf .Sprintf ("\u0025\u0073\u0025\u0064",_ceef ,_eedc )]=true ;};};};};};};return _bedg ,nil ;};
+func (_acee IconScale )SetIcons (t _fb .ST_IconSetType ){_acee ._ebag .IconSetAttr =t };func (_egce *Sheet )getAllCellsInFormulaArrays (_beda bool )(map[string ]bool ,error ){_fabcd :=_fa .NewEvaluator ();_deage :=_afgg (_egce );_bedg :=map[string ]bool {};for _ ,_efed :=range _egce .Rows (){for _ ,_ccd :=range _efed .Cells (){if _ccd .X ().F !=nil {_aaca :=_ccd .X ().F .Content ;if _ccd .X ().F .TAttr ==_fb .ST_CellFormulaTypeArray {_deage ._ffbe =_ccd .Reference ();_dfdf :=_fabcd .Eval (_deage ,_aaca ).AsString ();if _dfdf .Type ==_fa .ResultTypeError {_gbc .Log .Debug ("\u0065\u0072\u0072o\u0072\u0020\u0065\u0076a\u0075\u006c\u0061\u0074\u0069\u006e\u0067 \u0066\u006f\u0072\u006d\u0075\u006c\u0061\u0020\u0025\u0073\u003a\u0020\u0025\u0073",_aaca ,_dfdf .ErrorMessage );_ccd .X ().V =nil ;};if _dfdf .Type ==_fa .ResultTypeArray {_gcda ,_ddb :=_db .ParseCellReference (_ccd .Reference ());if _ddb !=nil {return map[string ]bool {},_ddb ;};if (_beda &&len (_dfdf .ValueArray )==1)||(!_beda &&len (_dfdf .ValueArray [0])==1){continue ;};for _bdda ,_afbd :=range _dfdf .ValueArray {_adgee :=_gcda .RowIdx +uint32 (_bdda );for _cgfa :=range _afbd {_gcba :=_db .IndexToColumn (_gcda .ColumnIdx +uint32 (_cgfa ));_bedg [_bf .Sprintf ("\u0025\u0073\u0025\u0064",_gcba ,_adgee )]=true ;};};}else if _dfdf .Type ==_fa .ResultTypeList {_gdca ,_gfce :=_db .ParseCellReference (_ccd .Reference ());if _gfce !=nil {return map[string ]bool {},_gfce ;};if _beda ||len (_dfdf .ValueList )==1{continue ;};_eedc :=_gdca .RowIdx ;for _becgc :=range _dfdf .ValueList {_ceef :=_db .IndexToColumn (_gdca .ColumnIdx +uint32 (_becgc ));_bedg [_bf .Sprintf ("\u0025\u0073\u0025\u0064",_ceef ,_eedc )]=true ;};};};};};};return _bedg ,nil ;};
 
 // IsDBCS returns if a workbook's default language is among DBCS.
 func (_fefe *evalContext )IsDBCS ()bool {_fcc :=_fefe ._beee ._gccb .CoreProperties .X ().Language ;if _fcc ==nil {return false ;};_aece :=string (_fcc .Data );for _ ,_cbg :=range _gga {if _aece ==_cbg {return true ;};};return false ;};
@@ -1181,14 +1181,14 @@ func (_gdbf *Sheet )ClearCachedFormulaResults (){for _ ,_dfec :=range _gdbf .Row
 
 // GetString returns the string in a cell if it's an inline or string table
 // string. Otherwise it returns an empty string.
-func (_cddg Cell )GetString ()string {switch _cddg ._cga .TAttr {case _fb .ST_CellTypeInlineStr :if _cddg ._cga .Is !=nil &&_cddg ._cga .Is .T !=nil {return *_cddg ._cga .Is .T ;};if _cddg ._cga .V !=nil {return *_cddg ._cga .V ;};case _fb .ST_CellTypeS :if _cddg ._cga .V ==nil {return "";};_ffcf ,_bcc :=_gb .Atoi (*_cddg ._cga .V );if _bcc !=nil {return "";};_ggd ,_bcc :=_cddg ._ea .SharedStrings .GetString (_ffcf );if _bcc !=nil {return "";};return _ggd ;};if _cddg ._cga .V ==nil {return "";};return *_cddg ._cga .V ;};
+func (_cddg Cell )GetString ()string {switch _cddg ._cga .TAttr {case _fb .ST_CellTypeInlineStr :if _cddg ._cga .Is !=nil {return RichText {_cddg ._cga .Is }.Text ();};if _cddg ._cga .V !=nil {return *_cddg ._cga .V ;};case _fb .ST_CellTypeS :if _cddg ._cga .V ==nil {return "";};_ffcf ,_bcc :=_gb .Atoi (*_cddg ._cga .V );if _bcc !=nil {return "";};_ggd ,_bcc :=_cddg ._ea .SharedStrings .GetString (_ffcf );if _bcc !=nil {return "";};return _ggd ;};if _cddg ._cga .V ==nil {return "";};return *_cddg ._cga .V ;};
 
 // AddChart adds an chart to a drawing, returning the chart and an anchor that
 // can be used to position the chart within the sheet.
 func (_dbdccf Drawing )AddChart (at AnchorType )(_c .Chart ,Anchor ){_cgf :=_bda .NewChartSpace ();_dbdccf ._afdc ._dcfbf =append (_dbdccf ._afdc ._dcfbf ,_cgf );_gbfb :=_a .AbsoluteFilename (_a .DocTypeSpreadsheet ,_a .ChartContentType ,len (_dbdccf ._afdc ._dcfbf ));_dbdccf ._afdc .ContentTypes .AddOverride (_gbfb ,_a .ChartContentType );var _dfe string ;for _bbc ,_bggb :=range _dbdccf ._afdc ._dfecb {if _bggb ==_dbdccf ._acce {_cgfb :=_a .RelativeFilename (_a .DocTypeSpreadsheet ,_a .DrawingType ,_a .ChartType ,len (_dbdccf ._afdc ._dcfbf ));_dgce :=_dbdccf ._afdc ._adfbe [_bbc ].AddRelationship (_cgfb ,_a .ChartType );_dfe =_dgce .ID ();break ;};};var _bff Anchor ;var _befdg *_fg .CT_GraphicalObjectFrame ;switch at {case AnchorTypeAbsolute :_aggg :=_aeb ();_dbdccf ._acce .EG_Anchor =append (_dbdccf ._acce .EG_Anchor ,&_fg .EG_Anchor {AbsoluteAnchor :_aggg });_aggg .Choice =&_fg .EG_ObjectChoicesChoice {};_aggg .Choice .GraphicFrame =_fg .NewCT_GraphicalObjectFrame ();_befdg =_aggg .Choice .GraphicFrame ;_bff =AbsoluteAnchor {_aggg };case AnchorTypeOneCell :_ace :=_effc ();_dbdccf ._acce .EG_Anchor =append (_dbdccf ._acce .EG_Anchor ,&_fg .EG_Anchor {OneCellAnchor :_ace });_ace .Choice =&_fg .EG_ObjectChoicesChoice {};_ace .Choice .GraphicFrame =_fg .NewCT_GraphicalObjectFrame ();_befdg =_ace .Choice .GraphicFrame ;_bff =OneCellAnchor {_ace };case AnchorTypeTwoCell :_fecc :=_bcef ();_dbdccf ._acce .EG_Anchor =append (_dbdccf ._acce .EG_Anchor ,&_fg .EG_Anchor {TwoCellAnchor :_fecc });_fecc .Choice =&_fg .EG_ObjectChoicesChoice {};_fecc .Choice .GraphicFrame =_fg .NewCT_GraphicalObjectFrame ();_befdg =_fecc .Choice .GraphicFrame ;_bff =TwoCellAnchor {_fecc };};_befdg .NvGraphicFramePr =_fg .NewCT_GraphicalObjectFrameNonVisual ();_befdg .NvGraphicFramePr .CNvPr .IdAttr =uint32 (len (_dbdccf ._acce .EG_Anchor ));_befdg .NvGraphicFramePr .CNvPr .NameAttr ="\u0043\u0068\u0061r\u0074";_befdg .Graphic =_ed .NewGraphic ();_befdg .Graphic .GraphicData .UriAttr ="\u0068\u0074\u0074\u0070\u003a\u002f\u002f\u0073\u0063\u0068\u0065\u006d\u0061\u0073\u002eo\u0070\u0065\u006e\u0078\u006d\u006c\u0066\u006f\u0072\u006d\u0061\u0074\u0073.\u006f\u0072\u0067\u002f\u0064\u0072\u0061\u0077\u0069\u006e\u0067\u006dl/\u0032\u0030\u0030\u0036\u002f\u0063\u0068\u0061\u0072\u0074";_bcca :=_bda .NewChart ();_bcca .IdAttr =_dfe ;_befdg .Graphic .GraphicData .Any =[]_a .Any {_bcca };_adgd :=_c .MakeChart (_cgf );_adgd .Properties ().SetSolidFill (_dfc .White );_adgd .SetDisplayBlanksAs (_bda .ST_DispBlanksAsGap );return _adgd ,_bff ;};
 
 // AddFont adds a new empty font to the stylesheet.
-func (_ddaaf StyleSheet )AddFont ()Font {_eadee :=_fb .NewCT_Font ();_ddaaf ._cfdc .Fonts .Font =append (_ddaaf ._cfdc .Fonts .Font ,_eadee );_ddaaf ._cfdc .Fonts .CountAttr =_a .Uint32 (uint32 (len (_ddaaf ._cfdc .Fonts .Font )));return Font {_eadee ,_ddaaf ._cfdc };};func (_bbgf *Sheet )setList (_bbaa string ,_ddcb _fa .Result )error {_gegb ,_aaeg :=_db .ParseCellReference (_bbaa );if _aaeg !=nil {return _aaeg ;};_cdcbe :=_bbgf .Row (_gegb .RowIdx );for _eaed ,_dggab :=range _ddcb .ValueList {_acf :=_cdcbe .Cell (_db .IndexToColumn (_gegb .ColumnIdx +uint32 (_eaed )));if _dggab .Type !=_fa .ResultTypeEmpty {if _dggab .IsBoolean {_acf .SetBool (_dggab .ValueNumber !=0);}else {_acf .SetCachedFormulaResult (_dggab .String ());};};};return nil ;};func (_edda *evalContext )Sheet (name string )_fa .Context {for _ ,_bdcb :=range _edda ._beee ._gccb .Sheets (){if _bdcb .Name ()==name {return _bdcb .FormulaContext ();};};return _fa .InvalidReferenceContext ;};func (_gfdd PatternFill )ClearFgColor (){_gfdd ._fba .FgColor =nil };
+func (_ddaaf StyleSheet )AddFont ()Font {_eadee :=_fb .NewCT_Font ();_ddaaf ._cfdc .Fonts .Font =append (_ddaaf ._cfdc .Fonts .Font ,_eadee );_ddaaf ._cfdc .Fonts .CountAttr =_a .Uint32 (uint32 (len (_ddaaf ._cfdc .Fonts .Font )));return Font {_eadee ,_ddaaf ._cfdc };};func (_bbgf *Sheet )setList (_bbaa string ,_ddcb _fa .Result )error {_gegb ,_aaeg :=_db .ParseCellReference (_bbaa );if _aaeg !=nil {return _aaeg ;};_cdcbe :=_bbgf .Row (_gegb .RowIdx );for _eaed ,_dggab :=range _ddcb .ValueList {_acf :=_cdcbe .Cell (_db .IndexToColumn (_gegb .ColumnIdx +uint32 (_eaed )));if _dggab .Type !=_fa .ResultTypeEmpty {if _dggab .IsBoolean {_acf .SetBool (_dggab .ValueNumber !=0);}else {_acf .SetCachedFormulaResult (_dggab .String ());};};};return nil ;};func (_edda *evalContext )Sheet (name string )_fa .Context {if _gbcfa ,_eecd :=_edda .externalSheet (name );_eecd {return _gbcfa ;};for _ ,_bdcb :=range _edda ._beee ._gccb .Sheets (){if _bdcb .Name ()==name {return _bdcb .FormulaContext ();};};return _fa .InvalidReferenceContext ;};func (_gfdd PatternFill )ClearFgColor (){_gfdd ._fba .FgColor =nil };
 
 // NumberFormat is a number formatting string that can be applied to a cell
 // style.
@@ -1242,7 +1242,7 @@ type RichText struct{_cde *_fb .CT_Rst };
 func (_ege DataValidation )SetRange (cellRange string ){_ege ._def .SqrefAttr =_fb .ST_Sqref {cellRange }};
 
 // ClearAutoFilter removes the autofilters from the sheet.
-func (_adca *Sheet )ClearAutoFilter (){_adca ._eage .AutoFilter =nil ;_fbbe :="\u0027"+_adca .Name ()+"\u0027\u0021";for _ ,_ecbaf :=range _adca ._gccb .DefinedNames (){if _ecbaf .Name ()==_gabe {if _gg .HasPrefix (_ecbaf .Content (),_fbbe ){_adca ._gccb .RemoveDefinedName (_ecbaf );break ;};};};};func (_fcffb *evalContext )Cell (ref string ,ev _fa .Evaluator )_fa .Result {if !_aag (ref ){return _fa .MakeErrorResultType (_fa .ErrorTypeName ,"");};_aecb :=_fcffb ._beee .Name ()+"\u0021"+ref ;if _cdcf ,_dgec :=ev .GetFromCache (_aecb );_dgec {return _cdcf ;};_ceaa ,_fffe :=_db .ParseCellReference (ref );if _fffe !=nil {return _fa .MakeErrorResult (_bf .Sprintf ("e\u0072r\u006f\u0072\u0020\u0070\u0061\u0072\u0073\u0069n\u0067\u0020\u0025\u0073: \u0025\u0073",ref ,_fffe ));};if _fcffb ._age !=0&&!_ceaa .AbsoluteColumn {_ceaa .ColumnIdx +=_fcffb ._age ;_ceaa .Column =_db .IndexToColumn (_ceaa .ColumnIdx );};if _fcffb ._ggf !=0&&!_ceaa .AbsoluteRow {_ceaa .RowIdx +=_fcffb ._ggf ;};_bdd :=_fcffb ._beee .Cell (_ceaa .String ());if _bdd .HasFormula (){if _ ,_ebfe :=_fcffb ._bgce [ref ];_ebfe {return _fa .MakeErrorResult ("r\u0065\u0063\u0075\u0072\u0073\u0069\u006f\u006e\u0020\u0064\u0065\u0074\u0065\u0063\u0074\u0065\u0064\u0020d\u0075\u0072\u0069\u006e\u0067\u0020\u0065\u0076\u0061\u006cua\u0074\u0069\u006fn\u0020o\u0066\u0020"+ref );};_fcffb ._bgce [ref ]=struct{}{};_fffc :=ev .Eval (_fcffb ,_bdd .GetFormula ());delete (_fcffb ._bgce ,ref );ev .SetCache (_aecb ,_fffc );return _fffc ;};if _bdd .IsEmpty (){_baee :=_fa .MakeEmptyResult ();ev .SetCache (_aecb ,_baee );return _baee ;}else if _bdd .IsNumber (){_dga ,_ :=_bdd .GetValueAsNumber ();_cge :=_fa .MakeNumberResult (_dga );ev .SetCache (_aecb ,_cge );return _cge ;}else if _bdd .IsBool (){_gbd ,_ :=_bdd .GetValueAsBool ();_bea :=_fa .MakeBoolResult (_gbd );ev .SetCache (_aecb ,_bea );return _bea ;};_dfge ,_ :=_bdd .GetRawValue ();if _bdd .IsError (){_dbdcc :=_fa .MakeErrorResult ("");_dbdcc .ValueString =_dfge ;ev .SetCache (_aecb ,_dbdcc );return _dbdcc ;};_dgg :=_fa .MakeStringResult (_dfge );ev .SetCache (_aecb ,_dgg );return _dgg ;};const (_ggagc ="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061tGe\u006e\u0065\u0072\u0061\u006cS\u0074a\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0057\u0068\u006f\u006ce\u004e\u0075\u006d\u0062\u0065\u0072\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0032\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006da\u0074\u0033\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064F\u006f\u0072\u006d\u0061\u0074\u0034";_ebabb ="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074P\u0065\u0072\u0063\u0065\u006e\u0074\u0053\u0074\u0061nd\u0061r\u0064F\u006fr\u006d\u0061\u0074\u0031\u0030\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061t\u0031\u0031\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064F\u006f\u0072\u006d\u0061\u0074\u0031\u0032\u0053\u0074a\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0033\u0053t\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0044\u0061\u0074\u0065\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046o\u0072\u006d\u0061\u0074\u00315\u0053\u0074\u0061\u006e\u0064a\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0036\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0037S\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0038\u0053\u0074\u0061n\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0054\u0069\u006d\u0065\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u00320\u0053\u0074a\u006e\u0064a\u0072\u0064\u0046\u006f\u0072\u006d\u0061t\u0032\u0031\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0044\u0061t\u0065\u0054\u0069\u006d\u0065";_cfea ="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0033\u0037\u0053t\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006da\u0074\u0033\u0038\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u00339\u0053\u0074\u0061\u006e\u0064\u0061r\u0064\u0046o\u0072\u006da\u00744\u0030";_acfe ="\u0053t\u0061\u006e\u0064a\u0072\u0064\u0046o\u0072ma\u0074\u0034\u0035\u0053\u0074\u0061\u006ed\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0034\u0036\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0034\u0037\u0053ta\u006ed\u0061\u0072\u0064\u0046\u006f\u0072m\u0061\u0074\u0034\u0038\u0053t\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061t\u0034\u0039";);
+func (_adca *Sheet )ClearAutoFilter (){_adca ._eage .AutoFilter =nil ;_fbbe :="\u0027"+_adca .Name ()+"\u0027\u0021";for _ ,_ecbaf :=range _adca ._gccb .DefinedNames (){if _ecbaf .Name ()==_gabe {if _gg .HasPrefix (_ecbaf .Content (),_fbbe ){_adca ._gccb .RemoveDefinedName (_ecbaf );break ;};};};};func (_fcffb *evalContext )Cell (ref string ,ev _fa .Evaluator )_fa .Result {if !_aag (ref ){return _fa .MakeErrorResultType (_fa .ErrorTypeName ,"");};_aecb :=_fcffb ._beee .Name ()+"\u0021"+ref ;if _cdcf ,_dgec :=ev .GetFromCache (_aecb );_dgec {return _cdcf ;};_ceaa ,_fffe :=_db .ParseCellReference (ref );if _fffe !=nil {return _fa .MakeErrorResult (_bf .Sprintf ("e\u0072r\u006f\u0072\u0020\u0070\u0061\u0072\u0073\u0069n\u0067\u0020\u0025\u0073: \u0025\u0073",ref ,_fffe ));};if _fcffb ._age !=0&&!_ceaa .AbsoluteColumn {_ceaa .ColumnIdx +=_fcffb ._age ;_ceaa .Column =_db .IndexToColumn (_ceaa .ColumnIdx );};if _fcffb ._ggf !=0&&!_ceaa .AbsoluteRow {_ceaa .RowIdx +=_fcffb ._ggf ;};_bdd :=_fcffb ._beee .Cell (_ceaa .String ());if _bdd .HasFormula (){if _ ,_ebfe :=_fcffb ._bgce [ref ];_ebfe {return _fa .MakeErrorResult ("r\u0065\u0063\u0075\u0072\u0073\u0069\u006f\u006e\u0020\u0064\u0065\u0074\u0065\u0063\u0074\u0065\u0064\u0020d\u0075\u0072\u0069\u006e\u0067\u0020\u0065\u0076\u0061\u006cua\u0074\u0069\u006fn\u0020o\u0066\u0020"+ref );};_fcffb ._bgce [ref ]=struct{}{};_dfcg :=_fcffb ._ffbe ;_fcffb ._ffbe =_ceaa .String ();_fffc :=ev .Eval (_fcffb ,_bdd .GetFormula ());_fcffb ._ffbe =_dfcg ;delete (_fcffb ._bgce ,ref );ev .SetCache (_aecb ,_fffc );return _fffc ;};if _bdd .IsEmpty (){_baee :=_fa .MakeEmptyResult ();ev .SetCache (_aecb ,_baee );return _baee ;}else if _bdd .IsNumber (){_dga ,_ :=_bdd .GetValueAsNumber ();_cge :=_fa .MakeNumberResult (_dga );ev .SetCache (_aecb ,_cge );return _cge ;}else if _bdd .IsBool (){_gbd ,_ :=_bdd .GetValueAsBool ();_bea :=_fa .MakeBoolResult (_gbd );ev .SetCache (_aecb ,_bea );return _bea ;};_dfge ,_ :=_bdd .GetRawValue ();if _bdd .IsError (){_dbdcc :=_fa .MakeErrorResult ("");_dbdcc .ValueString =_dfge ;ev .SetCache (_aecb ,_dbdcc );return _dbdcc ;};_dgg :=_fa .MakeStringResult (_dfge );ev .SetCache (_aecb ,_dgg );return _dgg ;};const (_ggagc ="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061tGe\u006e\u0065\u0072\u0061\u006cS\u0074a\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0057\u0068\u006f\u006ce\u004e\u0075\u006d\u0062\u0065\u0072\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0032\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006da\u0074\u0033\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064F\u006f\u0072\u006d\u0061\u0074\u0034";_ebabb ="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074P\u0065\u0072\u0063\u0065\u006e\u0074\u0053\u0074\u0061nd\u0061r\u0064F\u006fr\u006d\u0061\u0074\u0031\u0030\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061t\u0031\u0031\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064F\u006f\u0072\u006d\u0061\u0074\u0031\u0032\u0053\u0074a\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0033\u0053t\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0044\u0061\u0074\u0065\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046o\u0072\u006d\u0061\u0074\u00315\u0053\u0074\u0061\u006e\u0064a\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0036\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0037S\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0031\u0038\u0053\u0074\u0061n\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0054\u0069\u006d\u0065\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u00320\u0053\u0074a\u006e\u0064a\u0072\u0064\u0046\u006f\u0072\u006d\u0061t\u0032\u0031\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0044\u0061t\u0065\u0054\u0069\u006d\u0065";_cfea ="\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0033\u0037\u0053t\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006da\u0074\u0033\u0038\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u00339\u0053\u0074\u0061\u006e\u0064\u0061r\u0064\u0046o\u0072\u006da\u00744\u0030";_acfe ="\u0053t\u0061\u006e\u0064a\u0072\u0064\u0046o\u0072ma\u0074\u0034\u0035\u0053\u0074\u0061\u006ed\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0034\u0036\u0053\u0074\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061\u0074\u0034\u0037\u0053ta\u006ed\u0061\u0072\u0064\u0046\u006f\u0072m\u0061\u0074\u0034\u0038\u0053t\u0061\u006e\u0064\u0061\u0072\u0064\u0046\u006f\u0072\u006d\u0061t\u0034\u0039";);
 
 // X returns the inner wrapped XML type.
 func (_dcca Comment )X ()*_fb .CT_Comment {return _dcca ._gbbd };
@@ -1282,13 +1282,13 @@ func (_geb CellStyle )SetBorder (b Border ){_geb ._cfc .BorderIdAttr =_a .Uint32
 func (_abed SheetView )SetYSplit (v float64 ){_abed .ensurePane ();_abed ._ccfb .Pane .YSplitAttr =_a .Float64 (v );};
 
 // GetString retrieves a string from the shared strings table by index.
-func (_geefc SharedStrings )GetString (id int )(string ,error ){if id < 0{return "",_bf .Errorf ("\u0069\u006eva\u006c\u0069\u0064 \u0073\u0074\u0072\u0069ng \u0069nd\u0065\u0078\u0020\u0025\u0064\u002c\u0020mu\u0073\u0074\u0020\u0062\u0065\u0020\u003e \u0030",id );};if id > len (_geefc ._ffed .Si ){return "",_bf .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069d\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0020\u0069\u006e\u0064\u0065\u0078\u0020\u0025\u0064\u002c\u0020\u0074\u0061b\u006c\u0065\u0020\u006f\u006e\u006c\u0079\u0020\u0068\u0061\u0073\u0020\u0025\u0064 \u0076a\u006c\u0075\u0065\u0073",id ,len (_geefc ._ffed .Si ));};_gcb :=_geefc ._ffed .Si [id ];if _gcb .T !=nil {return *_gcb .T ,nil ;};return "",nil ;};
+func (_geefc SharedStrings )GetString (id int )(string ,error ){if id < 0{return "",_bf .Errorf ("\u0069\u006eva\u006c\u0069\u0064 \u0073\u0074\u0072\u0069ng \u0069nd\u0065\u0078\u0020\u0025\u0064\u002c\u0020mu\u0073\u0074\u0020\u0062\u0065\u0020\u003e \u0030",id );};if id >= len (_geefc ._ffed .Si ){return "",_bf .Errorf ("\u0069\u006e\u0076\u0061\u006c\u0069d\u0020\u0073\u0074\u0072\u0069\u006e\u0067\u0020\u0069\u006e\u0064\u0065\u0078\u0020\u0025\u0064\u002c\u0020\u0074\u0061b\u006c\u0065\u0020\u006f\u006e\u006c\u0079\u0020\u0068\u0061\u0073\u0020\u0025\u0064 \u0076a\u006c\u0075\u0065\u0073",id ,len (_geefc ._ffed .Si ));};_gcb :=_geefc ._ffed .Si [id ];return RichText {_gcb }.Text (),nil ;};
 
 // Col returns the column of the cell marker.
 func (_cbc CellMarker )Col ()int32 {return _cbc ._gdg .Col };
 
 // Read reads a workbook from an io.Reader(.xlsx).
-func Read (r _de .ReaderAt ,size int64 )(*Workbook ,error ){const _fcgg ="\u0073\u0070r\u0065\u0061\u0064s\u0068\u0065\u0065\u0074\u003a\u0052\u0065\u0061\u0064";if !_fd .GetLicenseKey ().IsLicensed ()&&!_becd {_bf .Println ("\u0055\u006e\u006ci\u0063\u0065\u006e\u0073e\u0064\u0020\u0076\u0065\u0072\u0073\u0069o\u006e\u0020\u006f\u0066\u0020\u0055\u006e\u0069\u004f\u0066\u0066\u0069\u0063\u0065");_bf .Println ("\u002d\u0020\u0047e\u0074\u0020\u0061\u0020\u0074\u0072\u0069\u0061\u006c\u0020\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0020\u006f\u006e\u0020\u0068\u0074\u0074\u0070\u0073\u003a\u002f\u002fu\u006e\u0069\u0064\u006f\u0063\u002e\u0069\u006f");return nil ,_ad .New ("\u0075\u006e\u0069\u006f\u0066\u0066\u0069\u0063\u0065\u0020\u006ci\u0063\u0065\u006e\u0073\u0065\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0064");};_efa :=New ();_gdce ,_gebd :=_fd .GenRefId ("\u0073\u0072");if _gebd !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_gebd );return nil ,_gebd ;};_efa ._ceaca =_gdce ;if _ebad :=_fd .Track (_efa ._ceaca ,_fcgg );_ebad !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_ebad );return nil ,_ebad ;};_gedg ,_gebd :=_af .TempDir ("\u0075\u006e\u0069\u006f\u0066\u0066\u0069\u0063\u0065-\u0078\u006c\u0073\u0078");if _gebd !=nil {return nil ,_gebd ;};_efa .TmpPath =_gedg ;_gcgf ,_gebd :=_ba .NewReader (r ,size );if _gebd !=nil {return nil ,_bf .Errorf ("\u0070a\u0072s\u0069\u006e\u0067\u0020\u007a\u0069\u0070\u003a\u0020\u0025\u0073",_gebd );};_fdc :=[]*_ba .File {};_fdc =append (_fdc ,_gcgf .File ...);_aga :=false ;for _ ,_fcac :=range _fdc {if _fcac .FileHeader .Name =="\u0064\u006f\u0063\u0050ro\u0070\u0073\u002f\u0063\u0075\u0073\u0074\u006f\u006d\u002e\u0078\u006d\u006c"{_aga =true ;break ;};};if _aga {_efa .CreateCustomProperties ();};_bcgec :=_gd .DecodeMap {};_bcgec .SetOnNewRelationshipFunc (_efa .onNewRelationship );_bcgec .AddTarget (_a .ContentTypesFilename ,_efa .ContentTypes .X (),"",0);_bcgec .AddTarget (_a .BaseRelsFilename ,_efa .Rels .X (),"",0);if _gaad :=_bcgec .Decode (_fdc );_gaad !=nil {return nil ,_gaad ;};for _ ,_gdad :=range _fdc {if _gdad ==nil {continue ;};if _aaag :=_efa .AddExtraFileFromZip (_gdad );_aaag !=nil {return nil ,_aaag ;};};if _aga {_cfce :=false ;for _ ,_dfcb :=range _efa .Rels .X ().Relationship {if _dfcb .TargetAttr =="\u0064\u006f\u0063\u0050ro\u0070\u0073\u002f\u0063\u0075\u0073\u0074\u006f\u006d\u002e\u0078\u006d\u006c"{_cfce =true ;break ;};};if !_cfce {_efa .AddCustomRelationships ();};};return _efa ,nil ;};func (_ecfc DataValidation )SetComparison (t DVCompareType ,op DVCompareOp )DataValidationCompare {_ecfc .clear ();_ecfc ._def .TypeAttr =_fb .ST_DataValidationType (t );_ecfc ._def .OperatorAttr =_fb .ST_DataValidationOperator (op );return DataValidationCompare {_ecfc ._def };};
+func Read (r _de .ReaderAt ,size int64 )(*Workbook ,error ){const _fcgg ="\u0073\u0070r\u0065\u0061\u0064s\u0068\u0065\u0065\u0074\u003a\u0052\u0065\u0061\u0064";if !_fd .GetLicenseKey ().IsLicensed ()&&!_becd {_bf .Println ("\u0055\u006e\u006ci\u0063\u0065\u006e\u0073e\u0064\u0020\u0076\u0065\u0072\u0073\u0069o\u006e\u0020\u006f\u0066\u0020\u0055\u006e\u0069\u004f\u0066\u0066\u0069\u0063\u0065");_bf .Println ("\u002d\u0020\u0047e\u0074\u0020\u0061\u0020\u0074\u0072\u0069\u0061\u006c\u0020\u006c\u0069\u0063\u0065\u006e\u0073\u0065\u0020\u006f\u006e\u0020\u0068\u0074\u0074\u0070\u0073\u003a\u002f\u002fu\u006e\u0069\u0064\u006f\u0063\u002e\u0069\u006f");return nil ,_ad .New ("\u0075\u006e\u0069\u006f\u0066\u0066\u0069\u0063\u0065\u0020\u006ci\u0063\u0065\u006e\u0073\u0065\u0020\u0072\u0065\u0071\u0075i\u0072\u0065\u0064");};_efa :=New ();_gdce ,_gebd :=_fd .GenRefId ("\u0073\u0072");if _gebd !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_gebd );return nil ,_gebd ;};_efa ._ceaca =_gdce ;if _ebad :=_fd .Track (_efa ._ceaca ,_fcgg );_ebad !=nil {_gbc .Log .Error ("\u0045R\u0052\u004f\u0052\u003a\u0020\u0025v",_ebad );return nil ,_ebad ;};_gedg ,_gebd :=_af .TempDir ("\u0075\u006e\u0069\u006f\u0066\u0066\u0069\u0063\u0065-\u0078\u006c\u0073\u0078");if _gebd !=nil {return nil ,_gebd ;};_efa .TmpPath =_gedg ;_gcgf ,_gebd :=_ba .NewReader (r ,size );if _gebd !=nil {return nil ,_bf .Errorf ("\u0070a\u0072s\u0069\u006e\u0067\u0020\u007a\u0069\u0070\u003a\u0020\u0025\u0073",_gebd );};_fdc :=[]*_ba .File {};_fdc =append (_fdc ,_gcgf .File ...);_aga :=false ;for _ ,_fcac :=range _fdc {if _fcac .FileHeader .Name =="\u0064\u006f\u0063\u0050ro\u0070\u0073\u002f\u0063\u0075\u0073\u0074\u006f\u006d\u002e\u0078\u006d\u006c"{_aga =true ;break ;};};if _aga {_efa .CreateCustomProperties ();};_bcgec :=_gd .DecodeMap {};_bcgec .SetOnNewRelationshipFunc (_efa .onNewRelationship );_bcgec .AddTarget (_a .ContentTypesFilename ,_efa .ContentTypes .X (),"",0);_bcgec .AddTarget (_a .BaseRelsFilename ,_efa .Rels .X (),"",0);if _gaad :=_bcgec .Decode (_fdc );_gaad !=nil {return nil ,_gaad ;};_efa .orderSheets ();for _ ,_gdad :=range _fdc {if _gdad ==nil {continue ;};if _aaag :=_efa .AddExtraFileFromZip (_gdad );_aaag !=nil {return nil ,_aaag ;};};if _aga {_cfce :=false ;for _ ,_dfcb :=range _efa .Rels .X ().Relationship {if _dfcb .TargetAttr =="\u0064\u006f\u0063\u0050ro\u0070\u0073\u002f\u0063\u0075\u0073\u0074\u006f\u006d\u002e\u0078\u006d\u006c"{_cfce =true ;break ;};};if !_cfce {_efa .AddCustomRelationships ();};};return _efa ,nil ;};func (_ecfc DataValidation )SetComparison (t DVCompareType ,op DVCompareOp )DataValidationCompare {_ecfc .clear ();_ecfc ._def .TypeAttr =_fb .ST_DataValidationType (t );_ecfc ._def .OperatorAttr =_fb .ST_DataValidationOperator (op );return DataValidationCompare {_ecfc ._def };};
 
 // SetState sets the sheet view state (frozen/split/frozen-split)
 func (_dfdfb SheetView )SetState (st _fb .ST_PaneState ){_dfdfb .ensurePane ();_dfdfb ._ccfb .Pane .StateAttr =st ;};
@@ -0,0 +1,724 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// StyleDescriptor describes the formatting of a cell. Parts that are nil keep
+// the formatting of the named style the descriptor is based on, or the
+// default formatting of the workbook.
+type StyleDescriptor struct {
+	Font       *FontDescriptor
+	Fill       *FillDescriptor
+	Border     *BorderDescriptor
+	Alignment  *AlignmentDescriptor
+	Protection *ProtectionDescriptor
+	// NumberFormat is a number format code such as "0.00%", or empty for the
+	// General format.
+	NumberFormat string
+	// NamedStyle is the name of a named cell style that the style is based
+	// on, or empty for the Normal style.
+	NamedStyle string
+}
+
+// FontDescriptor describes a font. The name and size of the default font of
+// the workbook are used if they are not set.
+type FontDescriptor struct {
+	Name      string
+	Size      float64
+	Bold      bool
+	Italic    bool
+	Strike    bool
+	Underline sml.ST_UnderlineValues
+	Color     *sml.CT_Color
+}
+
+// FillDescriptor describes the fill of a cell, a pattern fill or, if Gradient
+// is set, a gradient fill. A pattern fill with a foreground color and no
+// pattern is solid.
+type FillDescriptor struct {
+	Pattern  sml.ST_PatternType
+	FgColor  *sml.CT_Color
+	BgColor  *sml.CT_Color
+	Gradient *GradientDescriptor
+}
+
+// GradientDescriptor describes a gradient fill, either a linear gradient at
+// an angle in degrees or a path gradient from the rectangle given by the
+// Left, Right, Top and Bottom fractions of the cell.
+type GradientDescriptor struct {
+	Type                     sml.ST_GradientType
+	Degree                   float64
+	Left, Right, Top, Bottom float64
+	Stops                    []GradientStopDescriptor
+}
+
+// GradientStopDescriptor is a color of a gradient at a position between 0
+// and 1.
+type GradientStopDescriptor struct {
+	Position float64
+	Color    *sml.CT_Color
+}
+
+// BorderDescriptor describes the borders of a cell.
+type BorderDescriptor struct {
+	Left, Right, Top, Bottom, Diagonal BorderLineDescriptor
+	DiagonalUp, DiagonalDown           bool
+}
+
+// BorderLineDescriptor describes a border line, which isn't drawn if its
+// style is unset.
+type BorderLineDescriptor struct {
+	Style sml.ST_BorderStyle
+	Color *sml.CT_Color
+}
+
+// AlignmentDescriptor describes the alignment of the text of a cell.
+type AlignmentDescriptor struct {
+	Horizontal  sml.ST_HorizontalAlignment
+	Vertical    sml.ST_VerticalAlignment
+	WrapText    bool
+	ShrinkToFit bool
+	Rotation    uint8
+	Indent      uint32
+}
+
+// ProtectionDescriptor describes the protection of a cell, which is effective
+// if the sheet is protected.
+type ProtectionDescriptor struct {
+	Locked bool
+	Hidden bool
+}
+
+// NamedCellStyle is a named cell style, shown in the cell styles gallery of
+// Excel.
+type NamedCellStyle struct {
+	x  *sml.CT_CellStyle
+	ss StyleSheet
+}
+
+// NewRGBColor returns a color of a style given by its RGB value.
+func NewRGBColor(c color.Color) *sml.CT_Color {
+	clr := sml.NewCT_Color()
+	clr.RgbAttr = c.AsRGBAString()
+	return clr
+}
+
+// builtinStyleIDs are the ids of the named cell styles that are built into
+// Excel.
+var builtinStyleIDs = map[string]uint32{
+	"Normal": 0, "Comma": 3, "Currency": 4, "Percent": 5, "Comma [0]": 6,
+	"Currency [0]": 7, "Hyperlink": 8, "Followed Hyperlink": 9, "Note": 10,
+	"Warning Text": 11, "Title": 15, "Heading 1": 16, "Heading 2": 17,
+	"Heading 3": 18, "Heading 4": 19, "Input": 20, "Output": 21,
+	"Calculation": 22, "Check Cell": 23, "Linked Cell": 24, "Total": 25,
+	"Good": 26, "Bad": 27, "Neutral": 28, "Explanatory Text": 53,
+}
+
+// styleIndex maps the serialized records of a stylesheet to their index, so
+// that identical records are reused instead of added again.
+type styleIndex struct {
+	fonts, fills, borders, xfs, dxfs recordIndex
+}
+
+type recordIndex struct {
+	keys map[string]int
+	n    int
+}
+
+// styleKey returns the serialized form of a record, which is equal for
+// records that format the same way.
+func styleKey(v interface{}) string {
+	b, _ := xml.Marshal(v)
+	return string(b)
+}
+
+// find returns the index of the record of n records with the given key. The
+// index is rebuilt if records have been added since it was built, and a
+// cached index is checked since records can be modified after they are added.
+func (ri *recordIndex) find(key string, n int, keyOf func(i int) string) (int, bool) {
+	for attempt := 0; attempt < 2; attempt++ {
+		if ri.keys == nil || ri.n != n || attempt == 1 {
+			ri.keys = map[string]int{}
+			for i := n - 1; i >= 0; i-- {
+				ri.keys[keyOf(i)] = i
+			}
+			ri.n = n
+		}
+		i, ok := ri.keys[key]
+		if !ok {
+			return 0, false
+		}
+		if i < n && keyOf(i) == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (ri *recordIndex) add(key string, i int) {
+	if ri.keys != nil && ri.n == i {
+		ri.keys[key] = i
+		ri.n++
+	}
+}
+
+func (s StyleSheet) index() *styleIndex {
+	if s._defd == nil {
+		return &styleIndex{}
+	}
+	if s._defd._bgdc == nil {
+		s._defd._bgdc = &styleIndex{}
+	}
+	return s._defd._bgdc
+}
+
+// GetOrCreateStyle returns a cell style with the formatting of a descriptor.
+// Fonts, fills, borders, number formats and cell styles that already exist
+// with the same formatting are reused, so the returned style can be shared by
+// other cells and must not be modified.
+func (s StyleSheet) GetOrCreateStyle(d StyleDescriptor) (CellStyle, error) {
+	xf := s.descriptorXf(d)
+	if d.NamedStyle != "" {
+		named, ok := s.NamedCellStyle(d.NamedStyle)
+		if !ok || s.X().CellStyleXfs == nil || int(named.x.XfIdAttr) >= len(s.X().CellStyleXfs.Xf) {
+			return CellStyle{}, fmt.Errorf("no cell style named %s", d.NamedStyle)
+		}
+		base := s.X().CellStyleXfs.Xf[named.x.XfIdAttr]
+		xf.XfIdAttr = unioffice.Uint32(named.x.XfIdAttr)
+		if d.Font == nil {
+			xf.FontIdAttr = base.FontIdAttr
+		}
+		if d.Fill == nil {
+			xf.FillIdAttr = base.FillIdAttr
+		}
+		if d.Border == nil {
+			xf.BorderIdAttr = base.BorderIdAttr
+		}
+		if d.NumberFormat == "" {
+			xf.NumFmtIdAttr = base.NumFmtIdAttr
+		}
+		if d.Alignment == nil && base.Alignment != nil {
+			xf.Alignment = base.Alignment
+		}
+		if d.Protection == nil && base.Protection != nil {
+			xf.Protection = base.Protection
+		}
+	}
+
+	xfs := s.X().CellXfs
+	idx := s.index()
+	key := styleKey(xf)
+	keyOf := func(i int) string { return styleKey(xfs.Xf[i]) }
+	if i, ok := idx.xfs.find(key, len(xfs.Xf), keyOf); ok {
+		return CellStyle{s._defd, xfs.Xf[i], xfs}, nil
+	}
+	xfs.Xf = append(xfs.Xf, xf)
+	xfs.CountAttr = unioffice.Uint32(uint32(len(xfs.Xf)))
+	idx.xfs.add(key, len(xfs.Xf)-1)
+	return CellStyle{s._defd, xf, xfs}, nil
+}
+
+// descriptorXf returns a cell format for a descriptor, creating the records
+// that it references.
+func (s StyleSheet) descriptorXf(d StyleDescriptor) *sml.CT_Xf {
+	xf := sml.NewCT_Xf()
+	xf.XfIdAttr = unioffice.Uint32(0)
+	xf.FontIdAttr = unioffice.Uint32(0)
+	xf.FillIdAttr = unioffice.Uint32(0)
+	xf.BorderIdAttr = unioffice.Uint32(0)
+	xf.NumFmtIdAttr = unioffice.Uint32(0)
+	if d.Font != nil {
+		xf.FontIdAttr = unioffice.Uint32(s.getOrCreateFont(d.Font))
+		xf.ApplyFontAttr = unioffice.Bool(true)
+	}
+	if d.Fill != nil {
+		xf.FillIdAttr = unioffice.Uint32(s.getOrCreateFill(d.Fill))
+		xf.ApplyFillAttr = unioffice.Bool(true)
+	}
+	if d.Border != nil {
+		xf.BorderIdAttr = unioffice.Uint32(s.getOrCreateBorder(d.Border))
+		xf.ApplyBorderAttr = unioffice.Bool(true)
+	}
+	if d.NumberFormat != "" {
+		xf.NumFmtIdAttr = unioffice.Uint32(s.getOrCreateNumberFormat(d.NumberFormat))
+		xf.ApplyNumberFormatAttr = unioffice.Bool(true)
+	}
+	if a := d.Alignment; a != nil {
+		xf.Alignment = sml.NewCT_CellAlignment()
+		xf.Alignment.HorizontalAttr = a.Horizontal
+		xf.Alignment.VerticalAttr = a.Vertical
+		if a.WrapText {
+			xf.Alignment.WrapTextAttr = unioffice.Bool(true)
+		}
+		if a.ShrinkToFit {
+			xf.Alignment.ShrinkToFitAttr = unioffice.Bool(true)
+		}
+		if a.Rotation != 0 {
+			xf.Alignment.TextRotationAttr = unioffice.Uint8(a.Rotation)
+		}
+		if a.Indent != 0 {
+			xf.Alignment.IndentAttr = unioffice.Uint32(a.Indent)
+		}
+		xf.ApplyAlignmentAttr = unioffice.Bool(true)
+	}
+	if p := d.Protection; p != nil {
+		xf.Protection = &sml.CT_CellProtection{LockedAttr: unioffice.Bool(p.Locked), HiddenAttr: unioffice.Bool(p.Hidden)}
+		xf.ApplyProtectionAttr = unioffice.Bool(true)
+	}
+	return xf
+}
+
+func (s StyleSheet) getOrCreateFont(d *FontDescriptor) uint32 {
+	f := sml.NewCT_Font()
+	name, size := d.Name, d.Size
+	if fonts := s.X().Fonts.Font; len(fonts) > 0 {
+		if name == "" && len(fonts[0].Name) > 0 {
+			name = fonts[0].Name[0].ValAttr
+		}
+		if size == 0 && len(fonts[0].Sz) > 0 {
+			size = fonts[0].Sz[0].ValAttr
+		}
+	}
+	if d.Bold {
+		f.B = []*sml.CT_BooleanProperty{{}}
+	}
+	if d.Italic {
+		f.I = []*sml.CT_BooleanProperty{{}}
+	}
+	if d.Strike {
+		f.Strike = []*sml.CT_BooleanProperty{{}}
+	}
+	if d.Underline != sml.ST_UnderlineValuesUnset {
+		f.U = []*sml.CT_UnderlineProperty{{ValAttr: d.Underline}}
+	}
+	if d.Color != nil {
+		f.Color = []*sml.CT_Color{d.Color}
+	}
+	if size != 0 {
+		f.Sz = []*sml.CT_FontSize{{ValAttr: size}}
+	}
+	if name != "" {
+		f.Name = []*sml.CT_FontName{{ValAttr: name}}
+	}
+
+	fonts := s.X().Fonts
+	idx := s.index()
+	key := styleKey(f)
+	if i, ok := idx.fonts.find(key, len(fonts.Font), func(i int) string { return styleKey(fonts.Font[i]) }); ok {
+		return uint32(i)
+	}
+	fonts.Font = append(fonts.Font, f)
+	fonts.CountAttr = unioffice.Uint32(uint32(len(fonts.Font)))
+	idx.fonts.add(key, len(fonts.Font)-1)
+	return uint32(len(fonts.Font) - 1)
+}
+
+func (s StyleSheet) getOrCreateFill(d *FillDescriptor) uint32 {
+	f := sml.NewCT_Fill()
+	if g := d.Gradient; g != nil {
+		f.GradientFill = sml.NewCT_GradientFill()
+		f.GradientFill.TypeAttr = g.Type
+		if g.Type == sml.ST_GradientTypePath {
+			f.GradientFill.LeftAttr = unioffice.Float64(g.Left)
+			f.GradientFill.RightAttr = unioffice.Float64(g.Right)
+			f.GradientFill.TopAttr = unioffice.Float64(g.Top)
+			f.GradientFill.BottomAttr = unioffice.Float64(g.Bottom)
+		} else if g.Degree != 0 {
+			f.GradientFill.DegreeAttr = unioffice.Float64(g.Degree)
+		}
+		for _, stop := range g.Stops {
+			f.GradientFill.Stop = append(f.GradientFill.Stop, &sml.CT_GradientStop{PositionAttr: stop.Position, Color: stop.Color})
+		}
+	} else {
+		f.PatternFill = sml.NewCT_PatternFill()
+		f.PatternFill.PatternTypeAttr = d.Pattern
+		if d.Pattern == sml.ST_PatternTypeUnset {
+			f.PatternFill.PatternTypeAttr = sml.ST_PatternTypeNone
+			if d.FgColor != nil {
+				f.PatternFill.PatternTypeAttr = sml.ST_PatternTypeSolid
+			}
+		}
+		f.PatternFill.FgColor = d.FgColor
+		f.PatternFill.BgColor = d.BgColor
+	}
+
+	fills := s.X().Fills
+	idx := s.index()
+	key := styleKey(f)
+	if i, ok := idx.fills.find(key, len(fills.Fill), func(i int) string { return styleKey(fills.Fill[i]) }); ok {
+		return uint32(i)
+	}
+	fills.Fill = append(fills.Fill, f)
+	fills.CountAttr = unioffice.Uint32(uint32(len(fills.Fill)))
+	idx.fills.add(key, len(fills.Fill)-1)
+	return uint32(len(fills.Fill) - 1)
+}
+
+func (s StyleSheet) getOrCreateBorder(d *BorderDescriptor) uint32 {
+	b := sml.NewCT_Border()
+	line := func(l BorderLineDescriptor) *sml.CT_BorderPr {
+		pr := sml.NewCT_BorderPr()
+		if l.Style != sml.ST_BorderStyleUnset && l.Style != sml.ST_BorderStyleNone {
+			pr.StyleAttr = l.Style
+			pr.Color = l.Color
+		}
+		return pr
+	}
+	b.Left = line(d.Left)
+	b.Right = line(d.Right)
+	b.Top = line(d.Top)
+	b.Bottom = line(d.Bottom)
+	b.Diagonal = line(d.Diagonal)
+	if d.DiagonalUp {
+		b.DiagonalUpAttr = unioffice.Bool(true)
+	}
+	if d.DiagonalDown {
+		b.DiagonalDownAttr = unioffice.Bool(true)
+	}
+
+	borders := s.X().Borders
+	idx := s.index()
+	key := styleKey(b)
+	if i, ok := idx.borders.find(key, len(borders.Border), func(i int) string { return styleKey(borders.Border[i]) }); ok {
+		return uint32(i)
+	}
+	borders.Border = append(borders.Border, b)
+	borders.CountAttr = unioffice.Uint32(uint32(len(borders.Border)))
+	idx.borders.add(key, len(borders.Border)-1)
+	return uint32(len(borders.Border) - 1)
+}
+
+// getOrCreateNumberFormat returns the id of a number format code, which is
+// the id of the built-in format if there is one.
+func (s StyleSheet) getOrCreateNumberFormat(code string) uint32 {
+	if code == "General" {
+		return 0
+	}
+	if nf := s.X().NumFmts; nf != nil {
+		for _, f := range nf.NumFmt {
+			if f.FormatCodeAttr == code {
+				return f.NumFmtIdAttr
+			}
+		}
+	}
+	for id := 1; id < 50; id++ {
+		if nf := CreateDefaultNumberFormat(StandardFormat(id)); nf.X() != nil && nf.GetFormat() == code {
+			return uint32(id)
+		}
+	}
+	nf := s.AddNumberFormat()
+	nf.SetFormat(code)
+	return nf.ID()
+}
+
+// getOrCreateDxf returns the index of a differential format equal to dxf,
+// adding dxf if there is none.
+func (s StyleSheet) getOrCreateDxf(dxf *sml.CT_Dxf) uint32 {
+	ss := s.X()
+	if ss.Dxfs == nil {
+		ss.Dxfs = sml.NewCT_Dxfs()
+	}
+	dxfs := ss.Dxfs
+	idx := s.index()
+	key := styleKey(dxf)
+	if i, ok := idx.dxfs.find(key, len(dxfs.Dxf), func(i int) string { return styleKey(dxfs.Dxf[i]) }); ok {
+		return uint32(i)
+	}
+	dxfs.Dxf = append(dxfs.Dxf, dxf)
+	dxfs.CountAttr = unioffice.Uint32(uint32(len(dxfs.Dxf)))
+	idx.dxfs.add(key, len(dxfs.Dxf)-1)
+	return uint32(len(dxfs.Dxf) - 1)
+}
+
+// nextNumFmtID returns an unused id for a custom number format.
+func nextNumFmtID(fmts *sml.CT_NumFmts) uint32 {
+	id := uint32(200 + len(fmts.NumFmt))
+	for _, f := range fmts.NumFmt {
+		if f.NumFmtIdAttr >= id {
+			id = f.NumFmtIdAttr + 1
+		}
+	}
+	return id
+}
+
+// NamedCellStyles returns the named cell styles of the stylesheet.
+func (s StyleSheet) NamedCellStyles() []NamedCellStyle {
+	if s.X().CellStyles == nil {
+		return nil
+	}
+	ret := []NamedCellStyle{}
+	for _, cs := range s.X().CellStyles.CellStyle {
+		ret = append(ret, NamedCellStyle{cs, s})
+	}
+	return ret
+}
+
+// NamedCellStyle returns the named cell style with the given name.
+func (s StyleSheet) NamedCellStyle(name string) (NamedCellStyle, bool) {
+	for _, cs := range s.NamedCellStyles() {
+		if cs.Name() == name {
+			return cs, true
+		}
+	}
+	return NamedCellStyle{}, false
+}
+
+// AddNamedCellStyle adds a named cell style with the formatting of a
+// descriptor. Names of the styles built into Excel, such as Good or Heading
+// 1, replace the built-in formatting.
+func (s StyleSheet) AddNamedCellStyle(name string, d StyleDescriptor) (NamedCellStyle, error) {
+	if name == "" {
+		return NamedCellStyle{}, fmt.Errorf("empty cell style name")
+	}
+	if _, ok := s.NamedCellStyle(name); ok {
+		return NamedCellStyle{}, fmt.Errorf("cell style %s already exists", name)
+	}
+	if d.NamedStyle != "" {
+		return NamedCellStyle{}, fmt.Errorf("named cell style %s can't be based on %s", name, d.NamedStyle)
+	}
+	xf := s.descriptorXf(d)
+	xf.XfIdAttr = nil
+
+	ss := s.X()
+	if ss.CellStyleXfs == nil {
+		ss.CellStyleXfs = sml.NewCT_CellStyleXfs()
+	}
+	ss.CellStyleXfs.Xf = append(ss.CellStyleXfs.Xf, xf)
+	ss.CellStyleXfs.CountAttr = unioffice.Uint32(uint32(len(ss.CellStyleXfs.Xf)))
+	if ss.CellStyles == nil {
+		ss.CellStyles = sml.NewCT_CellStyles()
+	}
+	cs := sml.NewCT_CellStyle()
+	cs.NameAttr = unioffice.String(name)
+	cs.XfIdAttr = uint32(len(ss.CellStyleXfs.Xf) - 1)
+	if id, ok := builtinStyleIDs[name]; ok {
+		cs.BuiltinIdAttr = unioffice.Uint32(id)
+	}
+	ss.CellStyles.CellStyle = append(ss.CellStyles.CellStyle, cs)
+	ss.CellStyles.CountAttr = unioffice.Uint32(uint32(len(ss.CellStyles.CellStyle)))
+	return NamedCellStyle{cs, s}, nil
+}
+
+// X returns the inner wrapped XML type.
+func (n NamedCellStyle) X() *sml.CT_CellStyle { return n.x }
+
+// Name returns the name of the style.
+func (n NamedCellStyle) Name() string {
+	if n.x.NameAttr == nil {
+		return ""
+	}
+	return *n.x.NameAttr
+}
+
+// IsBuiltin reports whether the style is one of the styles built into Excel.
+func (n NamedCellStyle) IsBuiltin() bool { return n.x.BuiltinIdAttr != nil }
+
+// SetCompactStylesOnSave controls whether Save compacts the stylesheet first,
+// see StyleSheet.Compact.
+func (wb *Workbook) SetCompactStylesOnSave(b bool) { wb._eedb = b }
+
+// Compact removes the cell formats that no cell, row or column uses and the
+// fonts, fills, borders and number formats that no remaining format uses,
+// and merges records that are identical. Cells, rows and columns are updated
+// to the new indices of their formats. CellStyle values for removed formats
+// must not be used afterwards.
+func (s StyleSheet) Compact() {
+	ss := s.X()
+	if ss.CellXfs == nil || len(ss.CellXfs.Xf) == 0 {
+		return
+	}
+	if s._defd != nil {
+		s._defd._bgdc = nil
+	}
+
+	var sheets []*sml.Worksheet
+	if s._defd != nil {
+		sheets = s._defd._dcfb
+	}
+	forEachStyleRef := func(fn func(*uint32)) {
+		for _, ws := range sheets {
+			if ws.SheetData != nil {
+				for _, row := range ws.SheetData.Row {
+					if row.SAttr != nil {
+						fn(row.SAttr)
+					}
+					for _, c := range row.C {
+						if c.SAttr != nil {
+							fn(c.SAttr)
+						}
+					}
+				}
+			}
+			for _, cols := range ws.Cols {
+				for _, col := range cols.Col {
+					if col.StyleAttr != nil {
+						fn(col.StyleAttr)
+					}
+				}
+			}
+		}
+	}
+
+	used := map[uint32]bool{0: true}
+	forEachStyleRef(func(id *uint32) { used[*id] = true })
+	kept := []*sml.CT_Xf{}
+	keptIdx := []uint32{}
+	for i, xf := range ss.CellXfs.Xf {
+		if used[uint32(i)] {
+			kept = append(kept, xf)
+			keptIdx = append(keptIdx, uint32(i))
+		}
+	}
+	allXfs := append([]*sml.CT_Xf{}, kept...)
+	if ss.CellStyleXfs != nil {
+		allXfs = append(allXfs, ss.CellStyleXfs.Xf...)
+	}
+
+	if ss.Fonts != nil {
+		fonts := ss.Fonts.Font
+		keep := compactRecords(len(fonts), 1, allXfs,
+			func(xf *sml.CT_Xf) **uint32 { return &xf.FontIdAttr },
+			func(i int) string { return styleKey(fonts[i]) })
+		ss.Fonts.Font = make([]*sml.CT_Font, len(keep))
+		for i, k := range keep {
+			ss.Fonts.Font[i] = fonts[k]
+		}
+		ss.Fonts.CountAttr = unioffice.Uint32(uint32(len(keep)))
+	}
+	if ss.Fills != nil {
+		// the first two fills are reserved
+		fills := ss.Fills.Fill
+		keep := compactRecords(len(fills), 2, allXfs,
+			func(xf *sml.CT_Xf) **uint32 { return &xf.FillIdAttr },
+			func(i int) string { return styleKey(fills[i]) })
+		ss.Fills.Fill = make([]*sml.CT_Fill, len(keep))
+		for i, k := range keep {
+			ss.Fills.Fill[i] = fills[k]
+		}
+		ss.Fills.CountAttr = unioffice.Uint32(uint32(len(keep)))
+	}
+	if ss.Borders != nil {
+		borders := ss.Borders.Border
+		keep := compactRecords(len(borders), 1, allXfs,
+			func(xf *sml.CT_Xf) **uint32 { return &xf.BorderIdAttr },
+			func(i int) string { return styleKey(borders[i]) })
+		ss.Borders.Border = make([]*sml.CT_Border, len(keep))
+		for i, k := range keep {
+			ss.Borders.Border[i] = borders[k]
+		}
+		ss.Borders.CountAttr = unioffice.Uint32(uint32(len(keep)))
+	}
+	if ss.NumFmts != nil {
+		compactNumberFormats(ss, allXfs)
+	}
+
+	// merge identical cell formats, keeping the first, and renumber them
+	remap := map[uint32]uint32{}
+	keys := map[string]uint32{}
+	xfs := []*sml.CT_Xf{}
+	for i, xf := range kept {
+		key := styleKey(xf)
+		if j, ok := keys[key]; ok && i != 0 {
+			remap[keptIdx[i]] = j
+			continue
+		}
+		keys[key] = uint32(len(xfs))
+		remap[keptIdx[i]] = uint32(len(xfs))
+		xfs = append(xfs, xf)
+	}
+	ss.CellXfs.Xf = xfs
+	ss.CellXfs.CountAttr = unioffice.Uint32(uint32(len(xfs)))
+	forEachStyleRef(func(id *uint32) {
+		if n, ok := remap[*id]; ok {
+			*id = n
+		}
+	})
+}
+
+// compactRecords finds the records of a list of n records that no cell
+// format references and merges identical ones, keeping the first reserved
+// records. It updates the references of the formats and returns the indices
+// of the records to keep.
+func compactRecords(n, reserved int, xfs []*sml.CT_Xf, ref func(*sml.CT_Xf) **uint32, keyOf func(int) string) []int {
+	used := make([]bool, n)
+	for i := 0; i < reserved && i < n; i++ {
+		used[i] = true
+	}
+	for _, xf := range xfs {
+		if id := *ref(xf); id != nil && int(*id) < n {
+			used[*id] = true
+		}
+	}
+	remap := make([]uint32, n)
+	keys := map[string]uint32{}
+	keep := []int{}
+	for i := 0; i < n; i++ {
+		if !used[i] {
+			continue
+		}
+		key := keyOf(i)
+		if j, ok := keys[key]; ok && i >= reserved {
+			remap[i] = j
+			continue
+		}
+		if i >= reserved {
+			keys[key] = uint32(len(keep))
+		}
+		remap[i] = uint32(len(keep))
+		keep = append(keep, i)
+	}
+	for _, xf := range xfs {
+		if id := *ref(xf); id != nil && int(*id) < n {
+			*ref(xf) = unioffice.Uint32(remap[*id])
+		}
+	}
+	return keep
+}
+
+// compactNumberFormats removes the custom number formats that no cell format
+// uses and merges those with the same format code.
+func compactNumberFormats(ss *sml.StyleSheet, xfs []*sml.CT_Xf) {
+	used := map[uint32]bool{}
+	for _, xf := range xfs {
+		if xf.NumFmtIdAttr != nil {
+			used[*xf.NumFmtIdAttr] = true
+		}
+	}
+	remap := map[uint32]uint32{}
+	codes := map[string]uint32{}
+	kept := []*sml.CT_NumFmt{}
+	for _, nf := range ss.NumFmts.NumFmt {
+		if !used[nf.NumFmtIdAttr] {
+			continue
+		}
+		if id, ok := codes[nf.FormatCodeAttr]; ok {
+			remap[nf.NumFmtIdAttr] = id
+			continue
+		}
+		codes[nf.FormatCodeAttr] = nf.NumFmtIdAttr
+		kept = append(kept, nf)
+	}
+	for _, xf := range xfs {
+		if xf.NumFmtIdAttr == nil {
+			continue
+		}
+		if id, ok := remap[*xf.NumFmtIdAttr]; ok {
+			xf.NumFmtIdAttr = unioffice.Uint32(id)
+		}
+	}
+	ss.NumFmts.NumFmt = kept
+	ss.NumFmts.CountAttr = unioffice.Uint32(uint32(len(kept)))
+}
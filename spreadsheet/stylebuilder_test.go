@@ -0,0 +1,164 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"testing"
+
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// xfOf returns the cell format of a style.
+func xfOf(ss StyleSheet, cs CellStyle) *sml.CT_Xf { return ss.X().CellXfs.Xf[cs.Index()] }
+
+func TestGetOrCreateStyle(t *testing.T) {
+	wb := New()
+	ss := wb.StyleSheet
+	red := NewRGBColor(color.Red)
+	td := []struct {
+		Name string
+		Desc StyleDescriptor
+	}{
+		{"bold", StyleDescriptor{Font: &FontDescriptor{Bold: true}}},
+		{"bold red", StyleDescriptor{Font: &FontDescriptor{Bold: true, Color: red}}},
+		{"solid fill", StyleDescriptor{Fill: &FillDescriptor{FgColor: red}}},
+		{"gradient", StyleDescriptor{Fill: &FillDescriptor{Gradient: &GradientDescriptor{
+			Degree: 90, Stops: []GradientStopDescriptor{{0, red}, {1, NewRGBColor(color.White)}}}}}},
+		{"border", StyleDescriptor{Border: &BorderDescriptor{Bottom: BorderLineDescriptor{Style: sml.ST_BorderStyleThin}}}},
+		{"percent", StyleDescriptor{NumberFormat: "0.00%"}},
+		{"custom format", StyleDescriptor{NumberFormat: "#,##0.000"}},
+		{"wrapped", StyleDescriptor{Alignment: &AlignmentDescriptor{WrapText: true, Horizontal: sml.ST_HorizontalAlignmentCenter}}},
+		{"unlocked", StyleDescriptor{Protection: &ProtectionDescriptor{Hidden: true}}},
+	}
+	seen := map[uint32]string{}
+	for _, tc := range td {
+		cs, err := ss.GetOrCreateStyle(tc.Desc)
+		if err != nil {
+			t.Fatalf("%s: error creating style: %s", tc.Name, err)
+		}
+		if other, ok := seen[cs.Index()]; ok {
+			t.Errorf("%s: expected a new style, got the style of %s", tc.Name, other)
+		}
+		seen[cs.Index()] = tc.Name
+
+		fonts, fills, borders, xfs := len(ss.X().Fonts.Font), len(ss.X().Fills.Fill), len(ss.X().Borders.Border), len(ss.X().CellXfs.Xf)
+		again, err := ss.GetOrCreateStyle(tc.Desc)
+		if err != nil || again.Index() != cs.Index() {
+			t.Errorf("%s: expected the style to be reused", tc.Name)
+		}
+		if len(ss.X().Fonts.Font) != fonts || len(ss.X().Fills.Fill) != fills ||
+			len(ss.X().Borders.Border) != borders || len(ss.X().CellXfs.Xf) != xfs {
+			t.Errorf("%s: expected no records to be added for an existing style", tc.Name)
+		}
+	}
+
+	// styles that only differ in their fill share the font
+	a, _ := ss.GetOrCreateStyle(StyleDescriptor{Font: &FontDescriptor{Italic: true}})
+	b, _ := ss.GetOrCreateStyle(StyleDescriptor{Font: &FontDescriptor{Italic: true}, Fill: &FillDescriptor{FgColor: red}})
+	if *xfOf(ss, a).FontIdAttr != *xfOf(ss, b).FontIdAttr {
+		t.Errorf("expected the styles to share their font")
+	}
+
+	pct, _ := ss.GetOrCreateStyle(StyleDescriptor{NumberFormat: "0.00%"})
+	sheet := wb.AddSheet()
+	cell := sheet.Cell("A1")
+	cell.SetNumber(0.125)
+	cell.SetStyle(pct)
+	if got := cell.GetFormattedValue(); got != "12.50%" {
+		t.Errorf("expected 12.50%%, got %s", got)
+	}
+}
+
+func TestNamedCellStyles(t *testing.T) {
+	wb := New()
+	ss := wb.StyleSheet
+	good, err := ss.AddNamedCellStyle("Good", StyleDescriptor{
+		Font: &FontDescriptor{Color: NewRGBColor(color.RGB(0, 0x61, 0))},
+		Fill: &FillDescriptor{FgColor: NewRGBColor(color.RGB(0xC6, 0xEF, 0xCE))},
+	})
+	if err != nil {
+		t.Fatalf("error adding named style: %s", err)
+	}
+	if !good.IsBuiltin() || *good.X().BuiltinIdAttr != 26 {
+		t.Errorf("expected Good to be a built-in style")
+	}
+	mine, err := ss.AddNamedCellStyle("Mine", StyleDescriptor{Font: &FontDescriptor{Bold: true}})
+	if err != nil || mine.IsBuiltin() {
+		t.Errorf("expected a custom named style, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		Name string
+		Desc StyleDescriptor
+	}{
+		{"", StyleDescriptor{}},
+		{"Good", StyleDescriptor{}},
+		{"Based", StyleDescriptor{NamedStyle: "Good"}},
+	} {
+		if _, err := ss.AddNamedCellStyle(tc.Name, tc.Desc); err == nil {
+			t.Errorf("expected an error adding the named style %q", tc.Name)
+		}
+	}
+
+	// a style based on a named style keeps the parts it doesn't set
+	cs, err := ss.GetOrCreateStyle(StyleDescriptor{NamedStyle: "Good", NumberFormat: "0.0"})
+	if err != nil {
+		t.Fatalf("error creating style: %s", err)
+	}
+	base := ss.X().CellStyleXfs.Xf[good.X().XfIdAttr]
+	xf := xfOf(ss, cs)
+	if *xf.XfIdAttr != good.X().XfIdAttr || *xf.FontIdAttr != *base.FontIdAttr || *xf.FillIdAttr != *base.FillIdAttr {
+		t.Errorf("expected the style to be based on Good")
+	}
+	if _, err := ss.GetOrCreateStyle(StyleDescriptor{NamedStyle: "Missing"}); err == nil {
+		t.Errorf("expected an error for a missing named style")
+	}
+
+	rd := saveAndRead(t, wb)
+	if named, ok := rd.StyleSheet.NamedCellStyle("Mine"); !ok || named.Name() != "Mine" {
+		t.Errorf("expected the named style to be preserved")
+	}
+}
+
+func TestCompactStyles(t *testing.T) {
+	wb := New()
+	ss := wb.StyleSheet
+	sheet := wb.AddSheet()
+	bold, _ := ss.GetOrCreateStyle(StyleDescriptor{Font: &FontDescriptor{Bold: true}, NumberFormat: "0.000"})
+	ss.GetOrCreateStyle(StyleDescriptor{Font: &FontDescriptor{Italic: true}})
+	ss.GetOrCreateStyle(StyleDescriptor{NumberFormat: "0.0000"})
+	// a duplicate added without the builder is merged
+	dup := ss.AddCellStyle()
+	*xfOf(ss, dup) = *xfOf(ss, bold)
+
+	sheet.Cell("A1").SetNumber(1.5)
+	sheet.Cell("A1").SetStyle(bold)
+	sheet.Cell("A2").SetNumber(2.5)
+	sheet.Cell("A2").SetStyle(dup)
+
+	fonts, xfs, numFmts := len(ss.X().Fonts.Font), len(ss.X().CellXfs.Xf), len(ss.X().NumFmts.NumFmt)
+	wb.SetCompactStylesOnSave(true)
+	rd := saveAndRead(t, wb)
+	rss := rd.StyleSheet
+	if len(rss.X().Fonts.Font) >= fonts || len(rss.X().CellXfs.Xf) >= xfs || len(rss.X().NumFmts.NumFmt) >= numFmts {
+		t.Errorf("expected unused records to be removed")
+	}
+	rs := rd.Sheets()[0]
+	a1, a2 := rs.Cell("A1"), rs.Cell("A2")
+	if *a1.X().SAttr != *a2.X().SAttr {
+		t.Errorf("expected identical styles to be merged")
+	}
+	font := rss.GetCellStyle(*a1.X().SAttr).GetFont()
+	if font == nil || len(font.B) == 0 {
+		t.Errorf("expected A1 to stay bold")
+	}
+	if got := a1.GetFormattedValue(); got != "1.500" {
+		t.Errorf("expected 1.500, got %s", got)
+	}
+}
@@ -0,0 +1,749 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/formula"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+)
+
+// DefaultTableStyle is the style applied to new tables, the default of Excel.
+// Other built-in styles are TableStyleLight1 to TableStyleLight21,
+// TableStyleMedium1 to TableStyleMedium28 and TableStyleDark1 to
+// TableStyleDark11.
+const DefaultTableStyle = "TableStyleMedium2"
+
+// tableRect is the zero based, inclusive cell range of a table.
+type tableRect struct {
+	row0, col0, row1, col1 int
+}
+
+func parseTableRect(ref string) (tableRect, error) {
+	from, to, err := reference.ParseRangeReference(strings.Replace(ref, "$", "", -1))
+	if err != nil {
+		return tableRect{}, err
+	}
+	r := tableRect{int(from.RowIdx) - 1, int(from.ColumnIdx), int(to.RowIdx) - 1, int(to.ColumnIdx)}
+	if r.row1 < r.row0 {
+		r.row0, r.row1 = r.row1, r.row0
+	}
+	if r.col1 < r.col0 {
+		r.col0, r.col1 = r.col1, r.col0
+	}
+	if r.row0 < 0 {
+		return tableRect{}, fmt.Errorf("invalid range %s", ref)
+	}
+	return r, nil
+}
+
+func (r tableRect) String() string {
+	return cellName(r.row0, r.col0) + ":" + cellName(r.row1, r.col1)
+}
+
+func (r tableRect) overlaps(o tableRect) bool {
+	return r.row0 <= o.row1 && o.row0 <= r.row1 && r.col0 <= o.col1 && o.col0 <= r.col1
+}
+
+func cellName(row, col int) string {
+	return reference.IndexToColumn(uint32(col)) + strconv.Itoa(row+1)
+}
+
+// AddTable creates a table named name over the range ref, e.g. "A1:D10". The
+// first row of the range is the header row: its cells provide the column
+// names and empty or duplicate names are replaced by unique ones (Column1,
+// Column2, Amount2, ...) which are written back to the sheet. The table gets
+// an autofilter and the DefaultTableStyle with banded rows, like tables
+// inserted in Excel.
+func (s *Sheet) AddTable(ref, name string) (Table, error) {
+	r, err := parseTableRect(ref)
+	if err != nil {
+		return Table{}, err
+	}
+	if r.row1 == r.row0 {
+		return Table{}, errors.New("a table needs a header row and at least one data row")
+	}
+	wb := s._gccb
+	if err := wb.validateTableName(name, nil); err != nil {
+		return Table{}, err
+	}
+	if err := s.checkTableRange(r, nil); err != nil {
+		return Table{}, err
+	}
+
+	x := sml.NewTable()
+	x.IdAttr = wb.nextTableID()
+	x.NameAttr = unioffice.String(name)
+	x.DisplayNameAttr = name
+	x.RefAttr = r.String()
+	x.AutoFilter = sml.NewCT_AutoFilter()
+	x.AutoFilter.RefAttr = unioffice.String(x.RefAttr)
+	x.TableStyleInfo = sml.NewCT_TableStyleInfo()
+	x.TableStyleInfo.NameAttr = unioffice.String(DefaultTableStyle)
+	x.TableStyleInfo.ShowFirstColumnAttr = unioffice.Bool(false)
+	x.TableStyleInfo.ShowLastColumnAttr = unioffice.Bool(false)
+	x.TableStyleInfo.ShowRowStripesAttr = unioffice.Bool(true)
+	x.TableStyleInfo.ShowColumnStripesAttr = unioffice.Bool(false)
+	t := Table{x, wb}
+	t.setColumns(s, r)
+
+	wb._cgfcd = append(wb._cgfcd, x)
+	idx := len(wb._cgfcd)
+	wb.ContentTypes.AddOverride(unioffice.AbsoluteFilename(unioffice.DocTypeSpreadsheet, unioffice.TableType, idx), unioffice.TableContentType)
+	rel := s.relationships().AddAutoRelationship(unioffice.DocTypeSpreadsheet, unioffice.WorksheetType, idx, unioffice.TableType)
+	ws := s.X()
+	if ws.TableParts == nil {
+		ws.TableParts = sml.NewCT_TableParts()
+	}
+	part := sml.NewCT_TablePart()
+	part.IdAttr = rel.ID()
+	ws.TableParts.TablePart = append(ws.TableParts.TablePart, part)
+	ws.TableParts.CountAttr = unioffice.Uint32(uint32(len(ws.TableParts.TablePart)))
+	return t, nil
+}
+
+// Tables returns the tables of the sheet.
+func (s *Sheet) Tables() []Table {
+	ws := s.X()
+	if ws.TableParts == nil {
+		return nil
+	}
+	rels := s.relationships()
+	tables := []Table{}
+	for _, part := range ws.TableParts.TablePart {
+		if i := s._gccb.tableIndex(rels.GetTargetByRelId(part.IdAttr)); i >= 0 {
+			tables = append(tables, Table{s._gccb._cgfcd[i], s._gccb})
+		}
+	}
+	return tables
+}
+
+// RemoveTable removes a table from the sheet. The cells of the table keep their
+// contents, as with Convert to Range in Excel.
+func (s *Sheet) RemoveTable(t Table) error {
+	ws := s.X()
+	wb := s._gccb
+	rels := s.relationships()
+	if ws.TableParts == nil {
+		return ErrorNotFound
+	}
+	for i, part := range ws.TableParts.TablePart {
+		idx := wb.tableIndex(rels.GetTargetByRelId(part.IdAttr))
+		if idx < 0 || wb._cgfcd[idx] != t._bcfd {
+			continue
+		}
+		rels.Remove(rels.GetByRelId(part.IdAttr))
+		copy(ws.TableParts.TablePart[i:], ws.TableParts.TablePart[i+1:])
+		ws.TableParts.TablePart = ws.TableParts.TablePart[:len(ws.TableParts.TablePart)-1]
+		if len(ws.TableParts.TablePart) == 0 {
+			ws.TableParts = nil
+		} else {
+			ws.TableParts.CountAttr = unioffice.Uint32(uint32(len(ws.TableParts.TablePart)))
+		}
+		wb.removeTablePart(idx)
+		return nil
+	}
+	return ErrorNotFound
+}
+
+// relationships returns the relationships of the sheet part.
+func (s *Sheet) relationships() common.Relationships {
+	for i, ws := range s._gccb._dcfb {
+		if ws == s._eage {
+			return s._gccb._bbab[i]
+		}
+	}
+	return common.NewRelationships()
+}
+
+// checkTableRange verifies that a table range neither overlaps another table,
+// except skip, nor a merged region.
+func (s *Sheet) checkTableRange(r tableRect, skip *sml.Table) error {
+	for _, t := range s.Tables() {
+		if t._bcfd == skip {
+			continue
+		}
+		if o, err := parseTableRect(t.Reference()); err == nil && r.overlaps(o) {
+			return fmt.Errorf("range %s overlaps table %s", r, t.Name())
+		}
+	}
+	for _, mc := range s.MergedCells() {
+		if o, err := parseTableRect(mc.Reference()); err == nil && r.overlaps(o) {
+			return fmt.Errorf("range %s overlaps merged cells %s", r, mc.Reference())
+		}
+	}
+	return nil
+}
+
+// tableIndex returns the position in the table part list of the table a sheet
+// relationship targets, or -1.
+func (wb *Workbook) tableIndex(target string) int {
+	for i := range wb._cgfcd {
+		if unioffice.RelativeFilename(unioffice.DocTypeSpreadsheet, unioffice.WorksheetType, unioffice.TableType, i+1) == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeTablePart drops a table part. Table parts are stored by position, so
+// the relationships to the following tables are renumbered.
+func (wb *Workbook) removeTablePart(idx int) {
+	n := len(wb._cgfcd)
+	for _, rels := range wb._bbab {
+		for _, rel := range rels.Relationships() {
+			if rel.Type() != unioffice.TableType {
+				continue
+			}
+			if i := wb.tableIndex(rel.Target()); i > idx {
+				rel.SetTarget(unioffice.RelativeFilename(unioffice.DocTypeSpreadsheet, unioffice.WorksheetType, unioffice.TableType, i))
+			}
+		}
+	}
+	copy(wb._cgfcd[idx:], wb._cgfcd[idx+1:])
+	wb._cgfcd = wb._cgfcd[:n-1]
+	wb.ContentTypes.RemoveOverride(unioffice.AbsoluteFilename(unioffice.DocTypeSpreadsheet, unioffice.TableType, n))
+}
+
+func (wb *Workbook) nextTableID() uint32 {
+	id := uint32(0)
+	for _, t := range wb._cgfcd {
+		if t.IdAttr > id {
+			id = t.IdAttr
+		}
+	}
+	return id + 1
+}
+
+// GetTable returns the table with the given name, compared case-insensitively
+// as Excel does.
+func (wb *Workbook) GetTable(name string) (Table, error) {
+	for _, t := range wb.Tables() {
+		if strings.EqualFold(t.Name(), name) {
+			return t, nil
+		}
+	}
+	return Table{}, ErrorNotFound
+}
+
+// validateTableName checks that a name can be used for a table: it must
+// start with a letter, an underscore or a backslash, contain only letters,
+// digits, periods and underscores, not look like a cell reference and not be
+// used by another table or a defined name.
+func (wb *Workbook) validateTableName(name string, self *sml.Table) error {
+	if name == "" || len(name) > 255 {
+		return fmt.Errorf("invalid table name %q", name)
+	}
+	for i, c := range name {
+		switch {
+		case unicode.IsLetter(c) || c == '_':
+		case c == '\\' && i == 0:
+		case (unicode.IsDigit(c) || c == '.') && i > 0:
+		default:
+			return fmt.Errorf("invalid table name %q", name)
+		}
+	}
+	if looksLikeReference(name) {
+		return fmt.Errorf("table name %q is a cell reference", name)
+	}
+	for _, t := range wb._cgfcd {
+		if t != self && (strings.EqualFold(t.DisplayNameAttr, name) || t.NameAttr != nil && strings.EqualFold(*t.NameAttr, name)) {
+			return fmt.Errorf("table name %q is already used", name)
+		}
+	}
+	for _, dn := range wb.DefinedNames() {
+		if strings.EqualFold(dn.Name(), name) {
+			return fmt.Errorf("table name %q is already used by a defined name", name)
+		}
+	}
+	return nil
+}
+
+// looksLikeReference reports whether a name would be read as an A1 or R1C1
+// reference.
+func looksLikeReference(name string) bool {
+	u := strings.ToUpper(name)
+	if u == "R" || u == "C" {
+		return true
+	}
+	// names such as Table1 are only references if they are inside the sheet,
+	// which ends at XFD1048576
+	if cr, err := reference.ParseCellReference(u); err == nil {
+		return cr.ColumnIdx < 16384 && cr.RowIdx >= 1 && cr.RowIdx <= 1048576
+	}
+	if !strings.HasPrefix(u, "R") {
+		return false
+	}
+	rest := strings.TrimLeft(u[1:], "0123456789")
+	if !strings.HasPrefix(rest, "C") {
+		return false
+	}
+	return strings.TrimLeft(rest[1:], "0123456789") == ""
+}
+
+// TableColumn is a column of a table.
+type TableColumn struct {
+	x *sml.CT_TableColumn
+	t Table
+}
+
+// X returns the inner wrapped XML type.
+func (c TableColumn) X() *sml.CT_TableColumn { return c.x }
+
+// Name returns the name of the column, which is the text of its header cell.
+func (c TableColumn) Name() string { return c.x.NameAttr }
+
+// TotalsRowFunction returns the function shown in the totals row for the
+// column.
+func (c TableColumn) TotalsRowFunction() sml.ST_TotalsRowFunction {
+	return c.x.TotalsRowFunctionAttr
+}
+
+// SetTotalsRowFunction sets the function that summarizes the column in the
+// totals row. The totals row cell gets a SUBTOTAL formula over the column, so
+// that rows hidden by the filter are left out as in Excel.
+func (c TableColumn) SetTotalsRowFunction(fn sml.ST_TotalsRowFunction) {
+	c.x.TotalsRowFunctionAttr = fn
+	if fn != sml.ST_TotalsRowFunctionCustom {
+		c.x.TotalsRowFormula = nil
+	}
+	if fn != sml.ST_TotalsRowFunctionNone && fn != sml.ST_TotalsRowFunctionUnset {
+		c.x.TotalsRowLabelAttr = nil
+	}
+	c.t.writeTotals()
+}
+
+// SetTotalsRowLabel shows a text instead of a function in the totals row.
+func (c TableColumn) SetTotalsRowLabel(label string) {
+	c.x.TotalsRowFunctionAttr = sml.ST_TotalsRowFunctionUnset
+	c.x.TotalsRowFormula = nil
+	c.x.TotalsRowLabelAttr = unioffice.String(label)
+	c.t.writeTotals()
+}
+
+// SetTotalsRowFormula shows the result of a custom formula in the totals row.
+func (c TableColumn) SetTotalsRowFormula(formula string) {
+	c.x.TotalsRowFunctionAttr = sml.ST_TotalsRowFunctionCustom
+	c.x.TotalsRowLabelAttr = nil
+	c.x.TotalsRowFormula = sml.NewCT_TableFormula()
+	c.x.TotalsRowFormula.Content = strings.TrimPrefix(formula, "=")
+	c.t.writeTotals()
+}
+
+// Columns returns the columns of the table.
+func (t Table) Columns() []TableColumn {
+	if t._bcfd.TableColumns == nil {
+		return nil
+	}
+	cols := []TableColumn{}
+	for _, c := range t._bcfd.TableColumns.TableColumn {
+		cols = append(cols, TableColumn{c, t})
+	}
+	return cols
+}
+
+// Column returns the column with the given name, compared case-insensitively.
+func (t Table) Column(name string) (TableColumn, error) {
+	for _, c := range t.Columns() {
+		if strings.EqualFold(c.Name(), name) {
+			return c, nil
+		}
+	}
+	return TableColumn{}, ErrorNotFound
+}
+
+// Sheet returns the sheet that contains the table.
+func (t Table) Sheet() (Sheet, error) {
+	if t._fbdc == nil {
+		return Sheet{}, ErrorNotFound
+	}
+	for _, s := range t._fbdc.Sheets() {
+		for _, o := range s.Tables() {
+			if o._bcfd == t._bcfd {
+				return s, nil
+			}
+		}
+	}
+	return Sheet{}, ErrorNotFound
+}
+
+// Style returns the name of the table style.
+func (t Table) Style() string {
+	if si := t._bcfd.TableStyleInfo; si != nil && si.NameAttr != nil {
+		return *si.NameAttr
+	}
+	return ""
+}
+
+// SetStyle sets the table style by name, e.g. TableStyleLight9 or the name of
+// a custom table style of the workbook. An empty name removes the style.
+func (t Table) SetStyle(name string) {
+	si := t.styleInfo()
+	if name == "" {
+		si.NameAttr = nil
+		return
+	}
+	si.NameAttr = unioffice.String(name)
+}
+
+// SetShowRowStripes controls whether alternating rows are banded.
+func (t Table) SetShowRowStripes(b bool) { t.styleInfo().ShowRowStripesAttr = unioffice.Bool(b) }
+
+// SetShowColumnStripes controls whether alternating columns are banded.
+func (t Table) SetShowColumnStripes(b bool) { t.styleInfo().ShowColumnStripesAttr = unioffice.Bool(b) }
+
+// SetShowFirstColumn controls whether the first column is highlighted.
+func (t Table) SetShowFirstColumn(b bool) { t.styleInfo().ShowFirstColumnAttr = unioffice.Bool(b) }
+
+// SetShowLastColumn controls whether the last column is highlighted.
+func (t Table) SetShowLastColumn(b bool) { t.styleInfo().ShowLastColumnAttr = unioffice.Bool(b) }
+
+func (t Table) styleInfo() *sml.CT_TableStyleInfo {
+	if t._bcfd.TableStyleInfo == nil {
+		t._bcfd.TableStyleInfo = sml.NewCT_TableStyleInfo()
+	}
+	return t._bcfd.TableStyleInfo
+}
+
+// SetAutoFilter shows or hides the filter buttons in the header row.
+func (t Table) SetAutoFilter(show bool) {
+	if !show {
+		t._bcfd.AutoFilter = nil
+		return
+	}
+	if t._bcfd.AutoFilter == nil {
+		t._bcfd.AutoFilter = sml.NewCT_AutoFilter()
+	}
+	if r, err := parseTableRect(t._bcfd.RefAttr); err == nil {
+		t._bcfd.AutoFilter.RefAttr = unioffice.String(t.filterRect(r).String())
+	}
+}
+
+// HasTotalsRow reports whether the table shows a totals row.
+func (t Table) HasTotalsRow() bool {
+	return t._bcfd.TotalsRowCountAttr != nil && *t._bcfd.TotalsRowCountAttr > 0
+}
+
+// HasHeaderRow reports whether the table shows a header row.
+func (t Table) HasHeaderRow() bool {
+	return t._bcfd.HeaderRowCountAttr == nil || *t._bcfd.HeaderRowCountAttr > 0
+}
+
+// SetShowTotalsRow adds a totals row below the data of the table or removes it.
+// If no column summarizes its values yet, the first column gets the label
+// "Total" and the last column a sum, as in Excel.
+func (t Table) SetShowTotalsRow(show bool) error {
+	if show == t.HasTotalsRow() {
+		return nil
+	}
+	s, err := t.Sheet()
+	if err != nil {
+		return err
+	}
+	r, err := parseTableRect(t._bcfd.RefAttr)
+	if err != nil {
+		return err
+	}
+	if !show {
+		t.clearRow(&s, r.row1, r.col0, r.col1)
+		r.row1--
+		t._bcfd.RefAttr = r.String()
+		t._bcfd.TotalsRowCountAttr = nil
+		t._bcfd.TotalsRowShownAttr = unioffice.Bool(false)
+		return nil
+	}
+	r.row1++
+	if err := s.checkTableRange(r, t._bcfd); err != nil {
+		return err
+	}
+	t._bcfd.RefAttr = r.String()
+	t._bcfd.TotalsRowCountAttr = unioffice.Uint32(1)
+	t._bcfd.TotalsRowShownAttr = nil
+	cols := t.Columns()
+	summarized := false
+	for _, c := range cols {
+		if c.x.TotalsRowLabelAttr != nil || (c.x.TotalsRowFunctionAttr != sml.ST_TotalsRowFunctionUnset && c.x.TotalsRowFunctionAttr != sml.ST_TotalsRowFunctionNone) {
+			summarized = true
+		}
+	}
+	if !summarized && len(cols) > 0 {
+		if len(cols) > 1 {
+			cols[0].x.TotalsRowLabelAttr = unioffice.String("Total")
+		}
+		cols[len(cols)-1].x.TotalsRowFunctionAttr = sml.ST_TotalsRowFunctionSum
+	}
+	t.writeTotals()
+	return nil
+}
+
+// Resize changes the range of the table, e.g. to include appended rows. The
+// header row must stay in place. Columns are added or removed on the right,
+// new columns are named from their header cells.
+func (t Table) Resize(ref string) error {
+	s, err := t.Sheet()
+	if err != nil {
+		return err
+	}
+	old, err := parseTableRect(t._bcfd.RefAttr)
+	if err != nil {
+		return err
+	}
+	r, err := parseTableRect(ref)
+	if err != nil {
+		return err
+	}
+	if r.row0 != old.row0 {
+		return errors.New("the header row of a table must stay in the same row")
+	}
+	// columns are matched to the table columns by position
+	if r.col0 != old.col0 {
+		return errors.New("the first column of a table must stay in the same column")
+	}
+	if !r.overlaps(old) {
+		return errors.New("the new range must overlap the table")
+	}
+	dataRows := r.row1 - r.row0
+	if t.HasTotalsRow() {
+		dataRows--
+	}
+	if dataRows < 1 {
+		return errors.New("a table needs a header row and at least one data row")
+	}
+	if err := s.checkTableRange(r, t._bcfd); err != nil {
+		return err
+	}
+	if t.HasTotalsRow() && r.row1 != old.row1 {
+		t.clearRow(&s, old.row1, old.col0, old.col1)
+	}
+	t._bcfd.RefAttr = r.String()
+	t.setColumns(&s, r)
+	if t._bcfd.AutoFilter != nil {
+		t._bcfd.AutoFilter.RefAttr = unioffice.String(t.filterRect(r).String())
+	}
+	t.writeTotals()
+	return nil
+}
+
+// filterRect returns the range of the autofilter, the table without its
+// totals row.
+func (t Table) filterRect(r tableRect) tableRect {
+	if t.HasTotalsRow() {
+		r.row1--
+	}
+	return r
+}
+
+// setColumns updates the table columns to the columns of the range. Existing
+// columns keep their settings, new ones are named after their header cells
+// which are filled in if needed.
+func (t Table) setColumns(s *Sheet, r tableRect) {
+	cols := t._bcfd.TableColumns
+	if cols == nil {
+		cols = sml.NewCT_TableColumns()
+		t._bcfd.TableColumns = cols
+	}
+	n := r.col1 - r.col0 + 1
+	if len(cols.TableColumn) > n {
+		cols.TableColumn = cols.TableColumn[:n]
+	}
+	used := map[string]bool{}
+	nextID := uint32(1)
+	for _, c := range cols.TableColumn {
+		used[strings.ToLower(c.NameAttr)] = true
+		if c.IdAttr >= nextID {
+			nextID = c.IdAttr + 1
+		}
+	}
+	header := t.HasHeaderRow()
+	for i := len(cols.TableColumn); i < n; i++ {
+		name := ""
+		var cell Cell
+		if header {
+			cell = s.Cell(cellName(r.row0, r.col0+i))
+			name = strings.TrimSpace(cell.GetFormattedValue())
+		}
+		if name == "" {
+			name = "Column" + strconv.Itoa(i+1)
+		}
+		name = uniqueColumnName(name, used)
+		if header {
+			// header cells always hold text, also for numeric column names
+			cell.SetString(name)
+		}
+		c := sml.NewCT_TableColumn()
+		c.IdAttr = nextID
+		c.NameAttr = name
+		nextID++
+		cols.TableColumn = append(cols.TableColumn, c)
+	}
+	cols.CountAttr = unioffice.Uint32(uint32(len(cols.TableColumn)))
+}
+
+func uniqueColumnName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[strings.ToLower(unique)]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[strings.ToLower(unique)] = true
+	return unique
+}
+
+// writeTotals fills the cells of the totals row from the column settings.
+func (t Table) writeTotals() {
+	if !t.HasTotalsRow() {
+		return
+	}
+	s, err := t.Sheet()
+	if err != nil {
+		return
+	}
+	r, err := parseTableRect(t._bcfd.RefAttr)
+	if err != nil {
+		return
+	}
+	for i, c := range t.Columns() {
+		cell := s.Cell(cellName(r.row1, r.col0+i))
+		x := c.x
+		switch {
+		case x.TotalsRowFunctionAttr == sml.ST_TotalsRowFunctionCustom && x.TotalsRowFormula != nil:
+			cell.SetFormulaRaw(x.TotalsRowFormula.Content)
+		case subtotalFunctions[x.TotalsRowFunctionAttr] != 0:
+			cell.SetFormulaRaw(fmt.Sprintf("SUBTOTAL(%d,%s[%s])", subtotalFunctions[x.TotalsRowFunctionAttr], t.Name(), EscapeTableColumnName(x.NameAttr)))
+		case x.TotalsRowLabelAttr != nil:
+			cell.SetString(*x.TotalsRowLabelAttr)
+		default:
+			cell.Clear()
+		}
+	}
+}
+
+func (t Table) clearRow(s *Sheet, row, col0, col1 int) {
+	for c := col0; c <= col1; c++ {
+		s.Cell(cellName(row, c)).Clear()
+	}
+}
+
+// subtotalFunctions maps totals row functions to the SUBTOTAL function numbers
+// that ignore hidden rows.
+var subtotalFunctions = map[sml.ST_TotalsRowFunction]int{
+	sml.ST_TotalsRowFunctionAverage:   101,
+	sml.ST_TotalsRowFunctionCountNums: 102,
+	sml.ST_TotalsRowFunctionCount:     103,
+	sml.ST_TotalsRowFunctionMax:       104,
+	sml.ST_TotalsRowFunctionMin:       105,
+	sml.ST_TotalsRowFunctionStdDev:    107,
+	sml.ST_TotalsRowFunctionSum:       109,
+	sml.ST_TotalsRowFunctionVar:       110,
+}
+
+// EscapeTableColumnName escapes the characters of a column name that have a
+// meaning in structured references, [ ] # and ', with a single quote.
+func EscapeTableColumnName(name string) string {
+	var b strings.Builder
+	for _, c := range name {
+		switch c {
+		case '[', ']', '#', '\'':
+			b.WriteByte('\'')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Table implements formula.TableContext, it returns the table with the given
+// name or the table of the sheet that contains the cell being evaluated.
+func (e *evalContext) Table(name string) (formula.TableInfo, bool) {
+	var t Table
+	if name != "" {
+		tbl, err := e._beee._gccb.GetTable(name)
+		if err != nil {
+			return formula.TableInfo{}, false
+		}
+		t = tbl
+	} else {
+		cur, err := reference.ParseCellReference(e._ffbe)
+		if err != nil {
+			return formula.TableInfo{}, false
+		}
+		for _, tbl := range e._beee.Tables() {
+			if r, err := parseTableRect(tbl.Reference()); err == nil && r.overlaps(tableRect{int(cur.RowIdx) - 1, int(cur.ColumnIdx), int(cur.RowIdx) - 1, int(cur.ColumnIdx)}) {
+				t = tbl
+				break
+			}
+		}
+		if t._bcfd == nil {
+			return formula.TableInfo{}, false
+		}
+	}
+	return t.info()
+}
+
+// CurrentCell implements formula.TableContext.
+func (e *evalContext) CurrentCell() string { return e._ffbe }
+
+// FormulaContextAt returns a formula evaluation context for a formula in the
+// cell cellRef, e.g. "D2". Structured references to the current row of a
+// table such as [@Qty] refer to the row of that cell, while they evaluate to
+// #REF! with the context returned by FormulaContext, which has no cell.
+func (s *Sheet) FormulaContextAt(cellRef string) formula.Context {
+	ctx := _afgg(s)
+	ctx._ffbe = strings.Replace(cellRef, "$", "", -1)
+	return ctx
+}
+
+// tableRange returns the data rows of the table with the given name as a sheet
+// qualified range, which is what a table name without brackets refers to.
+func (e *evalContext) tableRange(name string) (formula.Reference, bool) {
+	t, err := e._beee._gccb.GetTable(name)
+	if err != nil {
+		return formula.ReferenceInvalid, false
+	}
+	info, ok := t.info()
+	if !ok {
+		return formula.ReferenceInvalid, false
+	}
+	r, _ := parseTableRect(info.Ref)
+	r.row0 += info.HeaderRows
+	r.row1 -= info.TotalsRows
+	if r.row1 < r.row0 {
+		return formula.ReferenceInvalid, false
+	}
+	return formula.MakeRangeReference(info.Sheet + "!" + r.String()), true
+}
+
+// info returns the description of the table used to resolve structured
+// references.
+func (t Table) info() (formula.TableInfo, bool) {
+	s, err := t.Sheet()
+	if err != nil {
+		return formula.TableInfo{}, false
+	}
+	r, err := parseTableRect(t.Reference())
+	if err != nil {
+		return formula.TableInfo{}, false
+	}
+	info := formula.TableInfo{Name: t.Name(), Sheet: s.Name(), Ref: r.String()}
+	for _, c := range t.Columns() {
+		info.Columns = append(info.Columns, c.Name())
+	}
+	if t.HasHeaderRow() {
+		info.HeaderRows = 1
+	}
+	if t.HasTotalsRow() {
+		info.TotalsRows = 1
+	}
+	return info, true
+}
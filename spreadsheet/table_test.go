@@ -0,0 +1,170 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// tableSheet returns a workbook with a table Sales over A1:C4 whose columns
+// are Item, Qty and Price.
+func tableSheet(t *testing.T) (*Workbook, *Sheet, Table) {
+	wb := New()
+	sheet := wb.AddSheet()
+	for i, h := range []string{"Item", "Qty", "Price"} {
+		sheet.Cell(cellName(0, i)).SetString(h)
+	}
+	for i, row := range []struct {
+		item       string
+		qty, price float64
+	}{{"apple", 3, 0.5}, {"pear", 4, 0.75}, {"plum", 10, 0.2}} {
+		sheet.Cell(cellName(i+1, 0)).SetString(row.item)
+		sheet.Cell(cellName(i+1, 1)).SetNumber(row.qty)
+		sheet.Cell(cellName(i+1, 2)).SetNumber(row.price)
+	}
+	tbl, err := sheet.AddTable("A1:C4", "Sales")
+	if err != nil {
+		t.Fatalf("error adding table: %s", err)
+	}
+	return wb, &sheet, tbl
+}
+
+func TestTableRoundTrip(t *testing.T) {
+	wb, _, tbl := tableSheet(t)
+	if err := tbl.SetShowTotalsRow(true); err != nil {
+		t.Fatalf("error adding totals row: %s", err)
+	}
+	qty, err := tbl.Column("Qty")
+	if err != nil {
+		t.Fatalf("expected a Qty column: %s", err)
+	}
+	qty.SetTotalsRowFunction(sml.ST_TotalsRowFunctionAverage)
+	tbl.SetStyle("TableStyleMedium2")
+
+	rd := saveAndRead(t, wb)
+	got, err := rd.GetTable("sales")
+	if err != nil {
+		t.Fatalf("expected the table after reading: %s", err)
+	}
+	if got.Reference() != "A1:C5" {
+		t.Errorf("expected reference A1:C5, got %s", got.Reference())
+	}
+	if !got.HasTotalsRow() || !got.HasHeaderRow() {
+		t.Errorf("expected header and totals rows")
+	}
+	if got.Style() != "TableStyleMedium2" {
+		t.Errorf("expected style TableStyleMedium2, got %s", got.Style())
+	}
+	var names []string
+	for _, c := range got.Columns() {
+		names = append(names, fmt.Sprintf("%s:%s", c.Name(), c.TotalsRowFunction()))
+	}
+	if s, exp := fmt.Sprint(names), "[Item: Qty:average Price:sum]"; s != exp {
+		t.Errorf("expected columns %s, got %s", exp, s)
+	}
+	sheet := rd.Sheets()[0]
+	if f := sheet.Cell("B5").GetFormula(); f != "SUBTOTAL(101,Sales[Qty])" {
+		t.Errorf("expected the totals formula in B5, got %q", f)
+	}
+	if s := sheet.Cell("A5").GetString(); s != "Total" {
+		t.Errorf("expected the totals label in A5, got %q", s)
+	}
+}
+
+func TestTableCurrentRow(t *testing.T) {
+	_, sheet, tbl := tableSheet(t)
+	sheet.Cell("D1").SetString("Total")
+	if err := tbl.Resize("A1:D4"); err != nil {
+		t.Fatalf("error resizing table: %s", err)
+	}
+	td := []struct {
+		Ctx, Inp, Exp string
+	}{
+		{"D2", "[@Qty]*[@Price]", "1.5"},
+		{"D3", "Sales[@Qty]*Sales[@Price]", "3"},
+		{"$D$4", "[@Qty]*[@Price]", "2"},
+		{"D4", "SUM(Sales[Qty])", "17"},
+		{"D4", "SUM(Sales[[#This Row],[Qty]:[Price]])", "10.2"},
+		// outside of the data rows of the table
+		{"D6", "Sales[@Qty]", "#REF!"},
+		{"D6", "[@Qty]", "#REF!"},
+	}
+	for _, tc := range td {
+		expectResult(t, sheet.FormulaContextAt(tc.Ctx), tc.Inp, tc.Exp)
+	}
+	// without a cell the current row isn't known
+	expectResult(t, sheet.FormulaContext(), "Sales[@Qty]", "#REF!")
+}
+
+func TestTableResizeAndRemove(t *testing.T) {
+	wb, sheet, tbl := tableSheet(t)
+	sheet.Cell("D1").SetString("Total")
+	if err := tbl.Resize("A1:D4"); err != nil {
+		t.Fatalf("error resizing table: %s", err)
+	}
+	if _, err := tbl.Column("Total"); err != nil {
+		t.Errorf("expected a Total column after resizing: %s", err)
+	}
+	if err := tbl.Resize("A2:D4"); err == nil {
+		t.Errorf("expected an error moving the header row")
+	}
+	if err := tbl.Resize("B1:D4"); err == nil {
+		t.Errorf("expected an error moving the first column")
+	}
+	if _, err := sheet.AddTable("C3:E6", "Other"); err == nil {
+		t.Errorf("expected an error for an overlapping table")
+	}
+	if _, err := sheet.AddTable("F1:G3", "sales"); err == nil {
+		t.Errorf("expected an error for a duplicate name")
+	}
+	if err := sheet.RemoveTable(tbl); err != nil {
+		t.Fatalf("error removing table: %s", err)
+	}
+	if len(sheet.Tables()) != 0 {
+		t.Errorf("expected no tables after removing")
+	}
+	rd := saveAndRead(t, wb)
+	if _, err := rd.GetTable("Sales"); err == nil {
+		t.Errorf("expected the removed table to be gone after reading")
+	}
+	if s := rd.Sheets()[0].Cell("B2").GetString(); s != "3" {
+		t.Errorf("expected the cells to keep their values, got %q", s)
+	}
+}
+
+func TestTableNames(t *testing.T) {
+	td := []struct {
+		Name  string
+		Valid bool
+	}{
+		{"Table1", true},
+		{"Sales_2020", true},
+		{"XFE1", true},
+		{"A1", false},
+		{"xfd1048576", false},
+		{"R1C1", false},
+		{"R", false},
+		{"1Table", false},
+		{"Sales 2020", false},
+		{"", false},
+	}
+	for _, tc := range td {
+		wb := New()
+		sheet := wb.AddSheet()
+		_, err := sheet.AddTable("A1:B3", tc.Name)
+		if tc.Valid && err != nil {
+			t.Errorf("expected %q to be a valid table name: %s", tc.Name, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Errorf("expected %q to be an invalid table name", tc.Name)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/soo/dml"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// ThemeColor is a color of the color scheme of the workbook theme, referenced
+// by its index.
+type ThemeColor uint32
+
+// ThemeColor constants, in the order that styles reference them. Note that
+// the first four are the light and dark colors in the opposite order of the
+// theme color scheme.
+const (
+	ThemeColorLight1 ThemeColor = iota
+	ThemeColorDark1
+	ThemeColorLight2
+	ThemeColorDark2
+	ThemeColorAccent1
+	ThemeColorAccent2
+	ThemeColorAccent3
+	ThemeColorAccent4
+	ThemeColorAccent5
+	ThemeColorAccent6
+	ThemeColorHyperlink
+	ThemeColorFollowedHyperlink
+)
+
+// defaultThemeColors are the colors of the Office theme, used if the workbook
+// has no theme.
+var defaultThemeColors = [...]string{
+	"FFFFFF", "000000", "E7E6E6", "44546A", "4472C4", "ED7D31",
+	"A5A5A5", "FFC000", "5B9BD5", "70AD47", "0563C1", "954F72",
+}
+
+// indexedColors is the legacy palette of indexed colors. The indices 64 and
+// 65 are the system foreground and background colors.
+var indexedColors = [...]string{
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"800000", "008000", "000080", "808000", "800080", "008080", "C0C0C0", "808080",
+	"9999FF", "993366", "FFFFCC", "CCFFFF", "660066", "FF8080", "0066CC", "CCCCFF",
+	"000080", "FF00FF", "FFFF00", "00FFFF", "800080", "800000", "008080", "0000FF",
+	"00CCFF", "CCFFFF", "CCFFCC", "FFFF99", "99CCFF", "FF99CC", "CC99FF", "FFCC99",
+	"3366FF", "33CCCC", "99CC00", "FFCC00", "FF9900", "FF6600", "666699", "969696",
+	"003366", "339966", "003300", "333300", "993300", "993366", "333399", "333333",
+	"000000", "FFFFFF",
+}
+
+// NewThemeColor returns a color of a style that references a theme color,
+// lightened by a positive tint or darkened by a negative tint between -1 and
+// 1.
+func NewThemeColor(t ThemeColor, tint float64) *sml.CT_Color {
+	clr := sml.NewCT_Color()
+	clr.ThemeAttr = unioffice.Uint32(uint32(t))
+	if tint != 0 {
+		clr.TintAttr = unioffice.Float64(tint)
+	}
+	return clr
+}
+
+// SetThemeColor sets the font color to a theme color with a tint.
+func (f Font) SetThemeColor(t ThemeColor, tint float64) {
+	f.X().Color = []*sml.CT_Color{NewThemeColor(t, tint)}
+}
+
+// SetFgThemeColor sets the foreground color of the fill to a theme color with
+// a tint.
+func (f PatternFill) SetFgThemeColor(t ThemeColor, tint float64) {
+	f.X().FgColor = NewThemeColor(t, tint)
+}
+
+// SetBgThemeColor sets the background color of the fill to a theme color with
+// a tint.
+func (f PatternFill) SetBgThemeColor(t ThemeColor, tint float64) {
+	f.X().BgColor = NewThemeColor(t, tint)
+}
+
+// SetLeftThemeColor sets the left border to a style and a theme color.
+func (b Border) SetLeftThemeColor(style sml.ST_BorderStyle, t ThemeColor, tint float64) {
+	b.X().Left = &sml.CT_BorderPr{StyleAttr: style, Color: NewThemeColor(t, tint)}
+}
+
+// SetRightThemeColor sets the right border to a style and a theme color.
+func (b Border) SetRightThemeColor(style sml.ST_BorderStyle, t ThemeColor, tint float64) {
+	b.X().Right = &sml.CT_BorderPr{StyleAttr: style, Color: NewThemeColor(t, tint)}
+}
+
+// SetTopThemeColor sets the top border to a style and a theme color.
+func (b Border) SetTopThemeColor(style sml.ST_BorderStyle, t ThemeColor, tint float64) {
+	b.X().Top = &sml.CT_BorderPr{StyleAttr: style, Color: NewThemeColor(t, tint)}
+}
+
+// SetBottomThemeColor sets the bottom border to a style and a theme color.
+func (b Border) SetBottomThemeColor(style sml.ST_BorderStyle, t ThemeColor, tint float64) {
+	b.X().Bottom = &sml.CT_BorderPr{StyleAttr: style, Color: NewThemeColor(t, tint)}
+}
+
+// X returns the inner wrapped XML type.
+func (f Fill) X() *sml.CT_Fill { return f._egbf }
+
+// GradientFill is a fill that blends colors, either linearly at an angle or
+// from a rectangle towards the edges of the cell.
+type GradientFill struct {
+	x *sml.CT_GradientFill
+}
+
+// SetGradientFill replaces the fill with a linear gradient fill from left to
+// right without stops.
+func (f Fill) SetGradientFill() GradientFill {
+	f.X().PatternFill = nil
+	f.X().GradientFill = sml.NewCT_GradientFill()
+	return GradientFill{f.X().GradientFill}
+}
+
+// GradientFill returns the gradient fill of the fill, if it has one.
+func (f Fill) GradientFill() (GradientFill, bool) {
+	if f.X().GradientFill == nil {
+		return GradientFill{}, false
+	}
+	return GradientFill{f.X().GradientFill}, true
+}
+
+// X returns the inner wrapped XML type.
+func (g GradientFill) X() *sml.CT_GradientFill { return g.x }
+
+// SetLinear makes the gradient linear, blending along the angle in degrees,
+// where 0 blends from left to right and 90 from top to bottom.
+func (g GradientFill) SetLinear(degree float64) {
+	g.x.TypeAttr = sml.ST_GradientTypeUnset
+	g.x.LeftAttr, g.x.RightAttr, g.x.TopAttr, g.x.BottomAttr = nil, nil, nil, nil
+	g.x.DegreeAttr = nil
+	if degree != 0 {
+		g.x.DegreeAttr = unioffice.Float64(degree)
+	}
+}
+
+// SetPath makes the gradient blend from the rectangle given by the fractions
+// of the cell width and height at its left, right, top and bottom towards the
+// edges of the cell, e.g. 0.5 for all of them to blend from the center.
+func (g GradientFill) SetPath(left, right, top, bottom float64) {
+	g.x.TypeAttr = sml.ST_GradientTypePath
+	g.x.DegreeAttr = nil
+	g.x.LeftAttr = unioffice.Float64(left)
+	g.x.RightAttr = unioffice.Float64(right)
+	g.x.TopAttr = unioffice.Float64(top)
+	g.x.BottomAttr = unioffice.Float64(bottom)
+}
+
+// AddStop adds a color at a position between 0 and 1 of the gradient.
+func (g GradientFill) AddStop(position float64, c color.Color) {
+	g.x.Stop = append(g.x.Stop, &sml.CT_GradientStop{PositionAttr: position, Color: NewRGBColor(c)})
+}
+
+// AddThemeStop adds a theme color with a tint at a position between 0 and 1
+// of the gradient.
+func (g GradientFill) AddThemeStop(position float64, t ThemeColor, tint float64) {
+	g.x.Stop = append(g.x.Stop, &sml.CT_GradientStop{PositionAttr: position, Color: NewThemeColor(t, tint)})
+}
+
+// ResolveColor returns the effective RGB value of a color of a style, which
+// can be an RGB value, an indexed color of the palette of the workbook or a
+// theme color, with a tint applied. It returns false for automatic colors,
+// whose value depends on where they are used.
+func (wb *Workbook) ResolveColor(c *sml.CT_Color) (color.Color, bool) {
+	if c == nil || (c.AutoAttr != nil && *c.AutoAttr) {
+		return color.Auto, false
+	}
+	rgb := ""
+	switch {
+	case c.RgbAttr != nil:
+		rgb = *c.RgbAttr
+		if len(rgb) == 8 {
+			rgb = rgb[2:]
+		}
+	case c.ThemeAttr != nil:
+		rgb = wb.themeColor(ThemeColor(*c.ThemeAttr))
+	case c.IndexedAttr != nil:
+		rgb = wb.indexedColor(*c.IndexedAttr)
+	}
+	if len(rgb) != 6 {
+		return color.Auto, false
+	}
+	if c.TintAttr != nil && *c.TintAttr != 0 {
+		rgb = applyTint(rgb, *c.TintAttr)
+	}
+	return color.FromHex(rgb), true
+}
+
+// themeColor returns the RGB value of a theme color.
+func (wb *Workbook) themeColor(t ThemeColor) string {
+	themes := wb.Themes()
+	if len(themes) == 0 || themes[0].ThemeElements == nil || themes[0].ThemeElements.ClrScheme == nil {
+		if int(t) < len(defaultThemeColors) {
+			return defaultThemeColors[t]
+		}
+		return ""
+	}
+	cs := themes[0].ThemeElements.ClrScheme
+	var clr *dml.CT_Color
+	switch t {
+	case ThemeColorLight1:
+		clr = cs.Lt1
+	case ThemeColorDark1:
+		clr = cs.Dk1
+	case ThemeColorLight2:
+		clr = cs.Lt2
+	case ThemeColorDark2:
+		clr = cs.Dk2
+	case ThemeColorAccent1:
+		clr = cs.Accent1
+	case ThemeColorAccent2:
+		clr = cs.Accent2
+	case ThemeColorAccent3:
+		clr = cs.Accent3
+	case ThemeColorAccent4:
+		clr = cs.Accent4
+	case ThemeColorAccent5:
+		clr = cs.Accent5
+	case ThemeColorAccent6:
+		clr = cs.Accent6
+	case ThemeColorHyperlink:
+		clr = cs.Hlink
+	case ThemeColorFollowedHyperlink:
+		clr = cs.FolHlink
+	}
+	switch {
+	case clr == nil:
+		return ""
+	case clr.SrgbClr != nil:
+		return clr.SrgbClr.ValAttr
+	case clr.SysClr != nil && clr.SysClr.LastClrAttr != nil:
+		return *clr.SysClr.LastClrAttr
+	}
+	return ""
+}
+
+// indexedColor returns the RGB value of an indexed color, taken from the
+// palette of the workbook if it has its own.
+func (wb *Workbook) indexedColor(i uint32) string {
+	if c := wb.StyleSheet.X().Colors; c != nil && c.IndexedColors != nil && int(i) < len(c.IndexedColors.RgbColor) {
+		if rgb := c.IndexedColors.RgbColor[i].RgbAttr; rgb != nil {
+			if len(*rgb) == 8 {
+				return (*rgb)[2:]
+			}
+			return *rgb
+		}
+	}
+	if int(i) < len(indexedColors) {
+		return indexedColors[i]
+	}
+	return ""
+}
+
+// applyTint lightens or darkens an RGB value by changing its luminance as
+// Excel does.
+func applyTint(rgb string, tint float64) string {
+	v, err := strconv.ParseUint(rgb, 16, 32)
+	if err != nil {
+		return rgb
+	}
+	r := float64(v>>16&0xff) / 255
+	g := float64(v>>8&0xff) / 255
+	b := float64(v&0xff) / 255
+
+	// convert to HSL
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l := (max + min) / 2
+	h, s := 0.0, 0.0
+	if d := max - min; d != 0 {
+		if l < 0.5 {
+			s = d / (max + min)
+		} else {
+			s = d / (2 - max - min)
+		}
+		switch max {
+		case r:
+			h = (g - b) / d
+			if g < b {
+				h += 6
+			}
+		case g:
+			h = (b-r)/d + 2
+		default:
+			h = (r-g)/d + 4
+		}
+		h /= 6
+	}
+
+	if tint < 0 {
+		l *= 1 + tint
+	} else {
+		l = l*(1-tint) + tint
+	}
+
+	// and back to RGB
+	if s == 0 {
+		r, g, b = l, l, l
+	} else {
+		q := l * (1 + s)
+		if l >= 0.5 {
+			q = l + s - l*s
+		}
+		p := 2*l - q
+		r, g, b = hueToRGB(p, q, h+1.0/3), hueToRGB(p, q, h), hueToRGB(p, q, h-1.0/3)
+	}
+	to := func(c float64) uint64 { return uint64(math.Round(math.Max(0, math.Min(1, c)) * 255)) }
+	out := strconv.FormatUint(to(r)<<16|to(g)<<8|to(b), 16)
+	for len(out) < 6 {
+		out = "0" + out
+	}
+	return out
+}
+
+func hueToRGB(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+	if t > 1 {
+		t--
+	}
+	switch {
+	case t < 1.0/6:
+		return p + (q-p)*6*t
+	case t < 0.5:
+		return q
+	case t < 2.0/3:
+		return p + (q-p)*(2.0/3-t)*6
+	}
+	return p
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/color"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+)
+
+// The expected colors are the shades of the theme colors of the Office theme
+// that the color pickers of Office show.
+func TestResolveColor(t *testing.T) {
+	td := []struct {
+		Name  string
+		Color *sml.CT_Color
+		Exp   string
+	}{
+		{"rgb", NewRGBColor(color.RGB(0x12, 0x34, 0x56)), "123456"},
+		{"accent 1", NewThemeColor(ThemeColorAccent1, 0), "4472C4"},
+		{"accent 1 lighter 80%", NewThemeColor(ThemeColorAccent1, 0.7999816888943144), "DAE3F3"},
+		{"accent 1 lighter 60%", NewThemeColor(ThemeColorAccent1, 0.5999938962981048), "B4C7E7"},
+		{"accent 1 lighter 40%", NewThemeColor(ThemeColorAccent1, 0.3999755851924192), "8FAADC"},
+		{"accent 1 darker 25%", NewThemeColor(ThemeColorAccent1, -0.249977111117893), "2F5597"},
+		{"accent 1 darker 50%", NewThemeColor(ThemeColorAccent1, -0.499984740745262), "203864"},
+		{"white darker 5%", NewThemeColor(ThemeColorLight1, -4.9989318521683403e-2), "F2F2F2"},
+		{"white darker 15%", NewThemeColor(ThemeColorLight1, -0.1499984740745262), "D9D9D9"},
+		{"black lighter 50%", NewThemeColor(ThemeColorDark1, 0.499984740745262), "7F7F7F"},
+		{"hyperlink", NewThemeColor(ThemeColorHyperlink, 0), "0563C1"},
+		{"indexed red", &sml.CT_Color{IndexedAttr: unioffice.Uint32(10)}, "FF0000"},
+		{"indexed gray", &sml.CT_Color{IndexedAttr: unioffice.Uint32(22)}, "C0C0C0"},
+		{"indexed tinted", &sml.CT_Color{IndexedAttr: unioffice.Uint32(8), TintAttr: unioffice.Float64(0.5)}, "808080"},
+		{"auto", &sml.CT_Color{AutoAttr: unioffice.Bool(true)}, ""},
+		{"nil", nil, ""},
+	}
+	wb := New()
+	for _, tc := range td {
+		got := ""
+		if c, ok := wb.ResolveColor(tc.Color); ok {
+			got = strings.ToUpper(*c.AsRGBString())
+		}
+		if got != tc.Exp {
+			t.Errorf("%s: expected %q, got %q", tc.Name, tc.Exp, got)
+		}
+	}
+
+	// a custom palette replaces the indexed colors
+	wb.StyleSheet.X().Colors = sml.NewCT_Colors()
+	wb.StyleSheet.X().Colors.IndexedColors = sml.NewCT_IndexedColors()
+	for i := 0; i < 11; i++ {
+		wb.StyleSheet.X().Colors.IndexedColors.RgbColor = append(wb.StyleSheet.X().Colors.IndexedColors.RgbColor,
+			&sml.CT_RgbColor{RgbAttr: unioffice.String("FF00AA00")})
+	}
+	if c, ok := wb.ResolveColor(&sml.CT_Color{IndexedAttr: unioffice.Uint32(10)}); !ok || strings.ToUpper(*c.AsRGBString()) != "00AA00" {
+		t.Errorf("expected the indexed color of the palette")
+	}
+}
+
+func TestGradientFill(t *testing.T) {
+	wb := New()
+	fill := wb.StyleSheet.Fills().AddFill()
+	g := fill.SetGradientFill()
+	g.SetLinear(90)
+	g.AddStop(0, color.White)
+	g.AddThemeStop(1, ThemeColorAccent1, 0.5)
+	cs := wb.StyleSheet.AddCellStyle()
+	cs.SetFill(fill)
+
+	path := wb.StyleSheet.Fills().AddFill()
+	pg := path.SetGradientFill()
+	pg.SetPath(0.5, 0.5, 0.5, 0.5)
+	pg.AddStop(0, color.Red)
+	pg.AddStop(1, color.Blue)
+
+	rd := saveAndRead(t, wb)
+	fills := rd.StyleSheet.Fills().X().Fill
+	var linear, radial *sml.CT_GradientFill
+	for _, f := range fills {
+		if f.GradientFill == nil {
+			continue
+		}
+		if f.GradientFill.TypeAttr == sml.ST_GradientTypePath {
+			radial = f.GradientFill
+		} else {
+			linear = f.GradientFill
+		}
+	}
+	if linear == nil || linear.DegreeAttr == nil || *linear.DegreeAttr != 90 || len(linear.Stop) != 2 {
+		t.Fatalf("expected a linear gradient with two stops")
+	}
+	stop := linear.Stop[1]
+	if stop.PositionAttr != 1 || stop.Color.ThemeAttr == nil || *stop.Color.ThemeAttr != uint32(ThemeColorAccent1) ||
+		stop.Color.TintAttr == nil || *stop.Color.TintAttr != 0.5 {
+		t.Errorf("expected a theme color stop")
+	}
+	if radial == nil || radial.LeftAttr == nil || *radial.LeftAttr != 0.5 || radial.DegreeAttr != nil {
+		t.Errorf("expected a path gradient from the center")
+	}
+
+	if _, ok := fill.GradientFill(); !ok {
+		t.Errorf("expected a gradient fill")
+	}
+	fill.SetPatternFill()
+	if _, ok := fill.GradientFill(); ok {
+		t.Errorf("expected the pattern fill to replace the gradient")
+	}
+}
@@ -0,0 +1,702 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/common"
+	"github.com/unidoc/unioffice/schema/schemas.microsoft.com/office/spreadsheetml/threadedcomments"
+	"github.com/unidoc/unioffice/schema/soo/pkg/relationships"
+	"github.com/unidoc/unioffice/schema/soo/sml"
+	"github.com/unidoc/unioffice/spreadsheet/reference"
+	"github.com/unidoc/unioffice/vmldrawing"
+	"github.com/unidoc/unioffice/zippkg"
+)
+
+// threadedCommentNote is the text of the legacy note that Excel stores along
+// with a threaded comment for versions of Excel that don't support them.
+const threadedCommentNote = "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    "
+
+// Person is an author of threaded comments, or a person mentioned in them.
+type Person struct {
+	x *threadedcomments.CT_Person
+}
+
+// X returns the inner wrapped XML type.
+func (p Person) X() *threadedcomments.CT_Person { return p.x }
+
+// ID returns the unique identifier of the person.
+func (p Person) ID() string { return p.x.IdAttr }
+
+// Name returns the display name of the person.
+func (p Person) Name() string { return p.x.DisplayNameAttr }
+
+// UserID returns the identifier of the person for the identity provider, e.g.
+// an email address.
+func (p Person) UserID() string {
+	if p.x.UserIdAttr == nil {
+		return ""
+	}
+	return *p.x.UserIdAttr
+}
+
+// Persons returns the authors of threaded comments of the workbook and the
+// persons mentioned in them.
+func (wb *Workbook) Persons() []Person {
+	if wb._cagfe == nil {
+		return nil
+	}
+	ret := []Person{}
+	for _, p := range wb._cagfe.Person {
+		ret = append(ret, Person{p})
+	}
+	return ret
+}
+
+// AddPerson adds a person that can author or be mentioned in threaded
+// comments, returning the existing person if there is one with the same name
+// and user id. The user id and provider identify the account of the person,
+// e.g. an email address and "AD" for Azure Active Directory. If both are
+// empty, the person is a local user identified by the name.
+func (wb *Workbook) AddPerson(name, userID, providerID string) Person {
+	if userID == "" && providerID == "" {
+		userID, providerID = name, "None"
+	}
+	for _, p := range wb.Persons() {
+		if p.Name() == name && p.UserID() == userID {
+			return p
+		}
+	}
+	if wb._cagfe == nil {
+		wb._cagfe = threadedcomments.NewPersonList()
+	}
+	p := threadedcomments.NewCT_Person()
+	p.DisplayNameAttr = name
+	p.IdAttr = newGUID()
+	p.UserIdAttr = unioffice.String(userID)
+	p.ProviderIdAttr = unioffice.String(providerID)
+	wb._cagfe.Person = append(wb._cagfe.Person, p)
+	return Person{p}
+}
+
+// person returns the person with the given id.
+func (wb *Workbook) person(id string) (Person, bool) {
+	for _, p := range wb.Persons() {
+		if p.ID() == id {
+			return p, true
+		}
+	}
+	return Person{}, false
+}
+
+// ThreadedComments is the container for the threaded comments of a sheet. A
+// thread is a comment on a cell with its replies, Excel shows only one thread
+// per cell.
+type ThreadedComments struct {
+	wb    *Workbook
+	sheet Sheet
+	x     *threadedcomments.ThreadedComments
+}
+
+// ThreadedComment is a comment that starts a thread or a reply to it.
+type ThreadedComment struct {
+	tc ThreadedComments
+	x  *threadedcomments.CT_ThreadedComment
+}
+
+// Mention is a mention of a person, such as @Name, within the text of a
+// threaded comment.
+type Mention struct {
+	Person Person
+	// Start is the index of the character where the mention starts and
+	// Length its length in characters, including the @.
+	Start  int
+	Length int
+}
+
+// ThreadedComments returns the threaded comments of the sheet.
+func (s *Sheet) ThreadedComments() ThreadedComments {
+	wb := s._gccb
+	for i, ws := range wb._dcfb {
+		if ws == s._eage {
+			if wb._dbfgc[i] == nil {
+				wb._dbfgc[i] = threadedcomments.NewThreadedComments()
+			}
+			return ThreadedComments{wb, *s, wb._dbfgc[i]}
+		}
+	}
+	return ThreadedComments{wb, *s, threadedcomments.NewThreadedComments()}
+}
+
+// X returns the inner wrapped XML type.
+func (t ThreadedComments) X() *threadedcomments.ThreadedComments { return t.x }
+
+// Threads returns the first comments of the threads of the sheet.
+func (t ThreadedComments) Threads() []ThreadedComment {
+	ret := []ThreadedComment{}
+	for _, c := range t.x.ThreadedComment {
+		if c.ParentIdAttr == nil {
+			ret = append(ret, ThreadedComment{t, c})
+		}
+	}
+	return ret
+}
+
+// Thread returns the first comment of the thread on a cell.
+func (t ThreadedComments) Thread(cellRef string) (ThreadedComment, bool) {
+	for _, c := range t.Threads() {
+		if c.CellReference() == cellRef {
+			return c, true
+		}
+	}
+	return ThreadedComment{}, false
+}
+
+// AddThread starts a new thread on a cell. Along with the thread, a legacy
+// note with the text of the thread is added for older versions of Excel.
+func (t ThreadedComments) AddThread(cellRef string, author Person, text string) (ThreadedComment, error) {
+	if _, err := reference.ParseCellReference(cellRef); err != nil {
+		return ThreadedComment{}, err
+	}
+	if _, ok := t.Thread(cellRef); ok {
+		return ThreadedComment{}, fmt.Errorf("cell %s already has a thread", cellRef)
+	}
+	for _, c := range t.sheet.Comments().Comments() {
+		if c.CellReference() == cellRef {
+			return ThreadedComment{}, fmt.Errorf("cell %s already has a note", cellRef)
+		}
+	}
+	c := t.newComment(author, text)
+	c.RefAttr = unioffice.String(cellRef)
+	t.x.ThreadedComment = append(t.x.ThreadedComment, c)
+	tc := ThreadedComment{t, c}
+	tc.syncNote()
+	return tc, nil
+}
+
+// RemoveThread removes the thread on a cell with its replies and its legacy
+// note.
+func (t ThreadedComments) RemoveThread(cellRef string) {
+	root, ok := t.Thread(cellRef)
+	if !ok {
+		return
+	}
+	comments := t.x.ThreadedComment[:0]
+	for _, c := range t.x.ThreadedComment {
+		if c != root.x && (c.ParentIdAttr == nil || *c.ParentIdAttr != root.ID()) {
+			comments = append(comments, c)
+		}
+	}
+	t.x.ThreadedComment = comments
+
+	notes, ok := t.sheet.existingComments()
+	if !ok {
+		return
+	}
+	list := notes.X().CommentList.Comment[:0]
+	for _, c := range notes.X().CommentList.Comment {
+		if c.RefAttr != cellRef {
+			list = append(list, c)
+		}
+	}
+	notes.X().CommentList.Comment = list
+	if d := notes.vmlDrawing(); d != nil {
+		ref, _ := reference.ParseCellReference(cellRef)
+		removeNoteShape(d, int64(ref.RowIdx-1), int64(ref.ColumnIdx))
+	}
+}
+
+func (t ThreadedComments) newComment(author Person, text string) *threadedcomments.CT_ThreadedComment {
+	c := threadedcomments.NewCT_ThreadedComment()
+	now := time.Now()
+	c.DTAttr = &now
+	c.PersonIdAttr = author.ID()
+	c.IdAttr = newGUID()
+	c.Text = unioffice.String(text)
+	return c
+}
+
+// X returns the inner wrapped XML type.
+func (c ThreadedComment) X() *threadedcomments.CT_ThreadedComment { return c.x }
+
+// ID returns the unique identifier of the comment.
+func (c ThreadedComment) ID() string { return c.x.IdAttr }
+
+// CellReference returns the cell the comment belongs to, e.g. A1.
+func (c ThreadedComment) CellReference() string {
+	if c.x.RefAttr != nil {
+		return *c.x.RefAttr
+	}
+	if root, ok := c.root(); ok && root.x.RefAttr != nil {
+		return *root.x.RefAttr
+	}
+	return ""
+}
+
+// Author returns the author of the comment.
+func (c ThreadedComment) Author() (Person, bool) { return c.tc.wb.person(c.x.PersonIdAttr) }
+
+// Text returns the text of the comment.
+func (c ThreadedComment) Text() string {
+	if c.x.Text == nil {
+		return ""
+	}
+	return *c.x.Text
+}
+
+// SetText changes the text of the comment. Mentions that no longer match the
+// text are removed.
+func (c ThreadedComment) SetText(text string) {
+	c.x.Text = unioffice.String(text)
+	if c.x.Mentions != nil {
+		runes := []rune(text)
+		mentions := c.x.Mentions.Mention[:0]
+		for _, m := range c.x.Mentions.Mention {
+			p, ok := c.tc.wb.person(m.MentionpersonIdAttr)
+			end := int(m.StartIndexAttr + m.LengthAttr)
+			if ok && end <= len(runes) && string(runes[m.StartIndexAttr:end]) == "@"+p.Name() {
+				mentions = append(mentions, m)
+			}
+		}
+		c.x.Mentions.Mention = mentions
+		if len(mentions) == 0 {
+			c.x.Mentions = nil
+		}
+	}
+	if root, ok := c.root(); ok {
+		root.syncNote()
+	}
+}
+
+// Time returns the time the comment was written.
+func (c ThreadedComment) Time() time.Time {
+	if c.x.DTAttr == nil {
+		return time.Time{}
+	}
+	return *c.x.DTAttr
+}
+
+// IsReply returns true if the comment is a reply to the first comment of a
+// thread.
+func (c ThreadedComment) IsReply() bool { return c.x.ParentIdAttr != nil }
+
+// Replies returns the replies of the thread the comment belongs to.
+func (c ThreadedComment) Replies() []ThreadedComment {
+	root, ok := c.root()
+	if !ok {
+		return nil
+	}
+	ret := []ThreadedComment{}
+	for _, r := range c.tc.x.ThreadedComment {
+		if r.ParentIdAttr != nil && *r.ParentIdAttr == root.ID() {
+			ret = append(ret, ThreadedComment{c.tc, r})
+		}
+	}
+	return ret
+}
+
+// AddReply adds a reply to the end of the thread the comment belongs to.
+func (c ThreadedComment) AddReply(author Person, text string) ThreadedComment {
+	root, _ := c.root()
+	r := c.tc.newComment(author, text)
+	r.RefAttr = unioffice.String(root.CellReference())
+	r.ParentIdAttr = unioffice.String(root.ID())
+
+	// replies follow their thread
+	pos := len(c.tc.x.ThreadedComment)
+	for i, tc := range c.tc.x.ThreadedComment {
+		if tc == root.x || (tc.ParentIdAttr != nil && *tc.ParentIdAttr == root.ID()) {
+			pos = i + 1
+		}
+	}
+	list := c.tc.x.ThreadedComment
+	list = append(list, nil)
+	copy(list[pos+1:], list[pos:])
+	list[pos] = r
+	c.tc.x.ThreadedComment = list
+	root.syncNote()
+	return ThreadedComment{c.tc, r}
+}
+
+// IsResolved returns true if the thread the comment belongs to is resolved.
+func (c ThreadedComment) IsResolved() bool {
+	root, ok := c.root()
+	return ok && root.x.DoneAttr != nil && *root.x.DoneAttr
+}
+
+// SetResolved marks the thread the comment belongs to as resolved or
+// reopens it.
+func (c ThreadedComment) SetResolved(b bool) {
+	if root, ok := c.root(); ok {
+		if b {
+			root.x.DoneAttr = unioffice.Bool(true)
+		} else {
+			root.x.DoneAttr = nil
+		}
+	}
+}
+
+// Mentions returns the persons mentioned in the comment.
+func (c ThreadedComment) Mentions() []Mention {
+	if c.x.Mentions == nil {
+		return nil
+	}
+	ret := []Mention{}
+	for _, m := range c.x.Mentions.Mention {
+		p, ok := c.tc.wb.person(m.MentionpersonIdAttr)
+		if !ok {
+			continue
+		}
+		ret = append(ret, Mention{Person: p, Start: int(m.StartIndexAttr), Length: int(m.LengthAttr)})
+	}
+	return ret
+}
+
+// AddMention marks the first occurrence of @ followed by the name of a person
+// in the text of the comment, which isn't a mention yet, as a mention of the
+// person. Excel notifies mentioned persons of the comment.
+func (c ThreadedComment) AddMention(p Person) error {
+	runes := []rune(c.Text())
+	name := []rune("@" + p.Name())
+	mentioned := func(start int) bool {
+		for _, m := range c.Mentions() {
+			if start < m.Start+m.Length && m.Start < start+len(name) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i+len(name) <= len(runes); i++ {
+		if string(runes[i:i+len(name)]) != string(name) || mentioned(i) {
+			continue
+		}
+		if c.x.Mentions == nil {
+			c.x.Mentions = threadedcomments.NewCT_Mentions()
+		}
+		m := threadedcomments.NewCT_Mention()
+		m.MentionpersonIdAttr = p.ID()
+		m.MentionIdAttr = newGUID()
+		m.StartIndexAttr = uint32(i)
+		m.LengthAttr = uint32(len(name))
+		c.x.Mentions.Mention = append(c.x.Mentions.Mention, m)
+		return nil
+	}
+	return fmt.Errorf("%s not found in comment", string(name))
+}
+
+// root returns the first comment of the thread the comment belongs to.
+func (c ThreadedComment) root() (ThreadedComment, bool) {
+	if c.x == nil {
+		return ThreadedComment{}, false
+	}
+	if c.x.ParentIdAttr == nil {
+		return c, true
+	}
+	for _, tc := range c.tc.x.ThreadedComment {
+		if tc.IdAttr == *c.x.ParentIdAttr {
+			return ThreadedComment{c.tc, tc}, true
+		}
+	}
+	return ThreadedComment{}, false
+}
+
+// syncNote updates the legacy note of a thread to contain the text of the
+// thread, adding the note if there is none.
+func (c ThreadedComment) syncNote() {
+	ref := c.CellReference()
+	notes := c.tc.sheet.Comments()
+	if notes.X() == nil {
+		return
+	}
+	author := "tc=" + c.ID()
+	var note *sml.CT_Comment
+	for _, n := range notes.X().CommentList.Comment {
+		if n.RefAttr == ref {
+			note = n
+			break
+		}
+	}
+	if note == nil {
+		notes.AddComment(ref, author)
+		note = notes.X().CommentList.Comment[len(notes.X().CommentList.Comment)-1]
+		if d := notes.vmlDrawing(); d != nil {
+			cr, _ := reference.ParseCellReference(ref)
+			d.Shape = append(d.Shape, vmldrawing.NewCommentShape(int64(cr.ColumnIdx), int64(cr.RowIdx-1)))
+		}
+	} else {
+		Comment{notes._ebg, note, notes._bae}.SetAuthor(author)
+	}
+	text := strings.Builder{}
+	text.WriteString(threadedCommentNote)
+	text.WriteString(c.Text())
+	for _, r := range c.Replies() {
+		text.WriteString("\nReply:\n    ")
+		text.WriteString(r.Text())
+	}
+	note.Text = sml.NewCT_Rst()
+	note.Text.T = unioffice.String(text.String())
+}
+
+// prepareComments updates the relationships and content types of the notes,
+// threaded comments and persons parts before saving, as sheets may have been
+// added, copied or removed.
+func (wb *Workbook) prepareComments() {
+	dt := unioffice.DocTypeSpreadsheet
+	overrides := []string{}
+	for _, o := range wb.ContentTypes.X().Override {
+		switch o.ContentTypeAttr {
+		case unioffice.CommentsContentType, unioffice.ThreadedCommentsContentType, unioffice.PersonContentType:
+			overrides = append(overrides, o.PartNameAttr)
+		}
+	}
+	for _, o := range overrides {
+		wb.ContentTypes.RemoveOverride(o)
+	}
+
+	for i := range wb._dcfb {
+		hasComments := wb._efcda[i] != nil
+		hasThreads := wb._dbfgc[i] != nil && len(wb._dbfgc[i].ThreadedComment) > 0
+		wb.updateSheetRelationship(i, unioffice.CommentsType, hasComments)
+		wb.updateSheetRelationship(i, unioffice.ThreadedCommentsType, hasThreads)
+		if hasComments {
+			wb.ContentTypes.AddOverride(unioffice.AbsoluteFilename(dt, unioffice.CommentsType, i+1), unioffice.CommentsContentType)
+		}
+		if hasThreads {
+			wb.ContentTypes.AddOverride(unioffice.AbsoluteFilename(dt, unioffice.ThreadedCommentsType, i+1), unioffice.ThreadedCommentsContentType)
+		}
+	}
+
+	hasPersons := false
+	for _, r := range wb._bfdc.X().Relationship {
+		if r.TypeAttr == unioffice.PersonType {
+			r.TargetAttr = unioffice.RelativeFilename(dt, unioffice.OfficeDocumentType, unioffice.PersonType, 0)
+			hasPersons = true
+		}
+	}
+	if wb._cagfe == nil {
+		return
+	}
+	if !hasPersons {
+		wb._bfdc.AddAutoRelationship(dt, unioffice.OfficeDocumentType, 0, unioffice.PersonType)
+	}
+	wb.ContentTypes.AddOverride(unioffice.AbsoluteFilename(dt, unioffice.PersonType, 0), unioffice.PersonContentType)
+}
+
+// prepareSheets updates the relationships and content types of the worksheet
+// parts before saving, as they are written in the order of the sheets.
+func (wb *Workbook) prepareSheets() {
+	dt := unioffice.DocTypeSpreadsheet
+	overrides := []string{}
+	for _, o := range wb.ContentTypes.X().Override {
+		if o.ContentTypeAttr == unioffice.WorksheetContentType {
+			overrides = append(overrides, o.PartNameAttr)
+		}
+	}
+	for _, o := range overrides {
+		wb.ContentTypes.RemoveOverride(o)
+	}
+	rels := wb._bfdc.X()
+	for i, sh := range wb._feeg.Sheets.Sheet {
+		for _, r := range rels.Relationship {
+			if r.IdAttr == sh.IdAttr {
+				r.TargetAttr = unioffice.RelativeFilename(dt, unioffice.OfficeDocumentType, unioffice.WorksheetType, i+1)
+			}
+		}
+		wb.ContentTypes.AddOverride(unioffice.AbsoluteFilename(dt, unioffice.WorksheetContentType, i+1), unioffice.WorksheetContentType)
+	}
+}
+
+// orderSheets sorts the worksheet parts read from a file in the order of the
+// sheets in the workbook, which can differ from the order of their
+// relationships if the sheets were moved.
+func (wb *Workbook) orderSheets() {
+	dt := unioffice.DocTypeSpreadsheet
+	index := map[string]int{}
+	for _, r := range wb._bfdc.X().Relationship {
+		for i := range wb._dcfb {
+			if r.TargetAttr == unioffice.RelativeFilename(dt, unioffice.OfficeDocumentType, unioffice.WorksheetType, i+1) {
+				index[r.IdAttr] = i
+			}
+		}
+	}
+	sheets := wb._feeg.Sheets.Sheet
+	if len(sheets) != len(wb._dcfb) {
+		return
+	}
+	order := make([]int, len(sheets))
+	seen := map[int]bool{}
+	for i, sh := range sheets {
+		j, ok := index[sh.IdAttr]
+		if !ok || seen[j] {
+			return
+		}
+		order[i] = j
+		seen[j] = true
+	}
+	ws := make([]*sml.Worksheet, len(order))
+	rels := make([]common.Relationships, len(order))
+	notes := make([]*sml.Comments, len(order))
+	threads := make([]*threadedcomments.ThreadedComments, len(order))
+	for i, j := range order {
+		ws[i], rels[i], notes[i], threads[i] = wb._dcfb[j], wb._bbab[j], wb._efcda[j], wb._dbfgc[j]
+	}
+	wb._dcfb, wb._bbab, wb._efcda, wb._dbfgc = ws, rels, notes, threads
+}
+
+// updateSheetRelationship points the relationship of the given type of the
+// sheet with index i to the part written for that sheet, adding it if needed,
+// or removes it if the sheet has no such part. Relationships of copied sheets
+// are shared with the original sheet, so they are replaced rather than
+// modified.
+func (wb *Workbook) updateSheetRelationship(i int, typ string, exists bool) {
+	dt := unioffice.DocTypeSpreadsheet
+	rels := wb._bbab[i].X()
+	rels.Relationship = append([]*relationships.Relationship{}, rels.Relationship...)
+	for j, r := range rels.Relationship {
+		if r.TypeAttr != typ {
+			continue
+		}
+		if !exists {
+			rels.Relationship = append(rels.Relationship[:j], rels.Relationship[j+1:]...)
+			return
+		}
+		cp := *r
+		cp.TargetAttr = unioffice.RelativeFilename(dt, unioffice.WorksheetType, typ, i+1)
+		rels.Relationship[j] = &cp
+		return
+	}
+	if exists {
+		wb._bbab[i].AddAutoRelationship(dt, unioffice.WorksheetType, i+1, typ)
+	}
+}
+
+// writeThreadedComments writes the threaded comments and persons parts.
+func (wb *Workbook) writeThreadedComments(z *zip.Writer) error {
+	dt := unioffice.DocTypeSpreadsheet
+	for i, tc := range wb._dbfgc {
+		if tc == nil || len(tc.ThreadedComment) == 0 {
+			continue
+		}
+		if err := zippkg.MarshalXML(z, unioffice.AbsoluteFilename(dt, unioffice.ThreadedCommentsType, i+1), tc); err != nil {
+			return err
+		}
+	}
+	if wb._cagfe != nil {
+		return zippkg.MarshalXML(z, unioffice.AbsoluteFilename(dt, unioffice.PersonType, 0), wb._cagfe)
+	}
+	return nil
+}
+
+// copySheetComments appends a copy of the threaded comments of the sheet with
+// the given index for a copy of the sheet. The comments of the copy get new
+// identifiers, and the notes and their drawing are copied too so that they can
+// be modified independently of the original sheet.
+func (wb *Workbook) copySheetComments(ind int) {
+	ids := map[string]string{}
+	src := wb._dbfgc[ind]
+	if src == nil {
+		wb._dbfgc = append(wb._dbfgc, nil)
+	} else {
+		cp := threadedcomments.NewThreadedComments()
+		for _, c := range src.ThreadedComment {
+			n := *c
+			n.IdAttr = newGUID()
+			ids[c.IdAttr] = n.IdAttr
+			if c.Mentions != nil {
+				n.Mentions = threadedcomments.NewCT_Mentions()
+				for _, m := range c.Mentions.Mention {
+					nm := *m
+					nm.MentionIdAttr = newGUID()
+					n.Mentions.Mention = append(n.Mentions.Mention, &nm)
+				}
+			}
+			cp.ThreadedComment = append(cp.ThreadedComment, &n)
+		}
+		for _, c := range cp.ThreadedComment {
+			if c.ParentIdAttr != nil {
+				c.ParentIdAttr = unioffice.String(ids[*c.ParentIdAttr])
+			}
+		}
+		wb._dbfgc = append(wb._dbfgc, cp)
+	}
+
+	last := len(wb._efcda) - 1
+	wb.copyVMLDrawings(last)
+	notes := wb._efcda[last]
+	if notes == nil {
+		return
+	}
+	cp := sml.NewComments()
+	for _, a := range notes.Authors.Author {
+		if strings.HasPrefix(a, "tc=") {
+			if id, ok := ids[a[3:]]; ok {
+				a = "tc=" + id
+			}
+		}
+		cp.Authors.Author = append(cp.Authors.Author, a)
+	}
+	for _, c := range notes.CommentList.Comment {
+		n := *c
+		if c.Text != nil {
+			n.Text = copyRst(c.Text)
+		}
+		cp.CommentList.Comment = append(cp.CommentList.Comment, &n)
+	}
+	wb._efcda[last] = cp
+}
+
+// copyVMLDrawings gives the copy of a sheet its own copies of the VML
+// drawings of the notes and the header and footer pictures.
+func (wb *Workbook) copyVMLDrawings(last int) {
+	dt := unioffice.DocTypeSpreadsheet
+	rels := wb._bbab[last].X()
+	rels.Relationship = append([]*relationships.Relationship{}, rels.Relationship...)
+	for j, r := range rels.Relationship {
+		if r.TypeAttr != unioffice.VMLDrawingType {
+			continue
+		}
+		m := vmlDrawingTarget.FindStringSubmatch(r.TargetAttr)
+		if m == nil {
+			continue
+		}
+		idx, _ := strconv.Atoi(m[1])
+		if idx < 1 || idx > len(wb._bcag) {
+			continue
+		}
+		buf, err := xml.Marshal(wb._bcag[idx-1])
+		if err != nil {
+			continue
+		}
+		drawing := vmldrawing.NewContainer()
+		if err := xml.Unmarshal(buf, drawing); err != nil {
+			continue
+		}
+		wb._bcag = append(wb._bcag, drawing)
+		wb.copyVMLRelationships(wb._bcag[idx-1], drawing)
+		rel := *r
+		rel.TargetAttr = unioffice.RelativeFilename(dt, unioffice.WorksheetType, unioffice.VMLDrawingType, len(wb._bcag))
+		rels.Relationship[j] = &rel
+	}
+}
+
+// newGUID returns a random GUID in the braced upper case form used by Excel.
+func newGUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,311 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unioffice/measurement"
+)
+
+// reverseSheets saves a workbook and reads it back with the order of the sheets
+// in the workbook part reversed, so that the worksheet parts are no longer in
+// the order of the sheets.
+func reverseSheets(t *testing.T, wb *Workbook) *Workbook {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := wb.Save(&buf); err != nil {
+		t.Fatalf("error saving workbook: %s", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("error opening workbook: %s", err)
+	}
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	for _, f := range zr.File {
+		r, _ := f.Open()
+		data, _ := ioutil.ReadAll(r)
+		r.Close()
+		if f.Name == "xl/workbook.xml" {
+			re := regexp.MustCompile(`<(\w+:)?sheet [^>]*/>`)
+			sheets := re.FindAll(data, -1)
+			i := len(sheets)
+			data = re.ReplaceAllFunc(data, func([]byte) []byte { i--; return sheets[i] })
+		}
+		w, _ := zw.Create(f.Name)
+		w.Write(data)
+	}
+	zw.Close()
+	rd, err := Read(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("error reading workbook: %s", err)
+	}
+	return rd
+}
+
+// noteText returns the text of the legacy note on a cell.
+func noteText(sheet *Sheet, cellRef string) (string, bool) {
+	for _, c := range sheet.Comments().Comments() {
+		if c.CellReference() == cellRef {
+			return c.Text(), true
+		}
+	}
+	return "", false
+}
+
+func TestThreadedCommentsRoundTrip(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	ann := wb.AddPerson("Ann", "ann@example.com", "AD")
+	bob := wb.AddPerson("Bob", "", "")
+	if again := wb.AddPerson("Ann", "ann@example.com", "AD"); again.ID() != ann.ID() {
+		t.Errorf("expected the existing person to be returned")
+	}
+	if bob.UserID() != "Bob" {
+		t.Errorf("expected a local user to be identified by the name, got %q", bob.UserID())
+	}
+
+	tcs := sheet.ThreadedComments()
+	thread, err := tcs.AddThread("B2", ann, "Is @Bob sure?")
+	if err != nil {
+		t.Fatalf("error adding thread: %s", err)
+	}
+	if err := thread.AddMention(bob); err != nil {
+		t.Errorf("error adding mention: %s", err)
+	}
+	if err := thread.AddMention(bob); err == nil {
+		t.Errorf("expected an error mentioning Bob twice")
+	}
+	thread.AddReply(bob, "Yes")
+	if _, err := tcs.AddThread("C3", ann, "Second"); err != nil {
+		t.Fatalf("error adding thread: %s", err)
+	}
+	thread.AddReply(ann, "Thanks")
+	thread.SetResolved(true)
+
+	if _, err := tcs.AddThread("B2", bob, "again"); err == nil {
+		t.Errorf("expected an error adding a second thread to a cell")
+	}
+	sheet.Comments().AddCommentWithStyle("D4", "Carl", "a note")
+	if _, err := tcs.AddThread("D4", bob, "on a note"); err == nil {
+		t.Errorf("expected an error adding a thread to a cell with a note")
+	}
+
+	rd := saveAndRead(t, wb)
+	if len(rd.Persons()) != 2 {
+		t.Errorf("expected 2 persons, got %d", len(rd.Persons()))
+	}
+	rs := rd.Sheets()[0]
+	threads := rs.ThreadedComments().Threads()
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(threads))
+	}
+	got, ok := rs.ThreadedComments().Thread("B2")
+	if !ok {
+		t.Fatalf("expected a thread on B2")
+	}
+	if author, ok := got.Author(); !ok || author.Name() != "Ann" || author.UserID() != "ann@example.com" {
+		t.Errorf("expected the thread to be written by Ann")
+	}
+	if got.Text() != "Is @Bob sure?" || !got.IsResolved() || got.IsReply() || got.Time().IsZero() {
+		t.Errorf("unexpected thread %q", got.Text())
+	}
+	mentions := got.Mentions()
+	if len(mentions) != 1 || mentions[0].Person.Name() != "Bob" || mentions[0].Start != 3 || mentions[0].Length != 4 {
+		t.Errorf("expected a mention of Bob, got %v", mentions)
+	}
+	replies := got.Replies()
+	if len(replies) != 2 || replies[0].Text() != "Yes" || replies[1].Text() != "Thanks" ||
+		!replies[0].IsReply() || replies[0].CellReference() != "B2" {
+		t.Fatalf("expected two replies in order")
+	}
+
+	// older versions of Excel show the thread as a note
+	note, ok := noteText(&rs, "B2")
+	if !ok || !strings.HasPrefix(note, threadedCommentNote) || !strings.HasSuffix(note, "Is @Bob sure?\nReply:\n    Yes\nReply:\n    Thanks") {
+		t.Errorf("unexpected note %q", note)
+	}
+
+	// changing the text drops the mentions it no longer contains
+	got.SetText("Is Bob sure?")
+	if len(got.Mentions()) != 0 {
+		t.Errorf("expected the mention to be removed")
+	}
+	replies[1].SetText("Thank you")
+	if note, _ := noteText(&rs, "B2"); !strings.HasSuffix(note, "Is Bob sure?\nReply:\n    Yes\nReply:\n    Thank you") {
+		t.Errorf("expected the note to follow the thread, got %q", note)
+	}
+
+	rs.ThreadedComments().RemoveThread("B2")
+	if _, ok := rs.ThreadedComments().Thread("B2"); ok {
+		t.Errorf("expected the thread to be removed")
+	}
+	if _, ok := noteText(&rs, "B2"); ok {
+		t.Errorf("expected the note to be removed with the thread")
+	}
+	if n := len(rs.ThreadedComments().X().ThreadedComment); n != 1 {
+		t.Errorf("expected the replies to be removed, got %d comments", n)
+	}
+	rd = saveAndRead(t, rd)
+	if threads := rd.Sheets()[0].ThreadedComments().Threads(); len(threads) != 1 || threads[0].CellReference() != "C3" {
+		t.Errorf("expected the thread on C3 to remain")
+	}
+}
+
+func TestCopySheetThreadedComments(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	ann := wb.AddPerson("Ann", "", "")
+	thread, err := sheet.ThreadedComments().AddThread("A1", ann, "original")
+	if err != nil {
+		t.Fatalf("error adding thread: %s", err)
+	}
+	thread.AddReply(ann, "reply")
+
+	cp, err := wb.CopySheet(0, "Copy")
+	if err != nil {
+		t.Fatalf("error copying sheet: %s", err)
+	}
+	copied, ok := cp.ThreadedComments().Thread("A1")
+	if !ok || copied.ID() == thread.ID() || len(copied.Replies()) != 1 {
+		t.Fatalf("expected a copy of the thread with new identifiers")
+	}
+	copied.SetText("copy")
+
+	rd := saveAndRead(t, wb)
+	for i, exp := range []string{"original", "copy"} {
+		rs := rd.Sheets()[i]
+		got, ok := rs.ThreadedComments().Thread("A1")
+		if !ok || got.Text() != exp || len(got.Replies()) != 1 {
+			t.Errorf("sheet %d: expected the thread %q", i, exp)
+		}
+		if note, _ := noteText(&rs, "A1"); !strings.Contains(note, exp+"\nReply:") {
+			t.Errorf("sheet %d: expected the note %q, got %q", i, exp, note)
+		}
+	}
+}
+
+func TestNoteBox(t *testing.T) {
+	wb := New()
+	sheet := wb.AddSheet()
+	sheet.Comments().AddCommentWithStyle("B2", "Ann", "hello")
+	note := sheet.Comments().Comments()[0]
+	if note.IsVisible() {
+		t.Errorf("expected a hidden note box by default")
+	}
+	if err := note.SetVisible(true); err != nil {
+		t.Fatalf("error showing note: %s", err)
+	}
+	if err := note.SetSize(150*measurement.Point, 75*measurement.Point); err != nil {
+		t.Fatalf("error sizing note: %s", err)
+	}
+
+	rd := saveAndRead(t, wb)
+	rs := rd.Sheets()[0]
+	got := rs.Comments().Comments()[0]
+	if !got.IsVisible() {
+		t.Errorf("expected the note box to stay visible")
+	}
+	if !strings.Contains(got.Text(), "hello") {
+		t.Errorf("expected the note text, got %q", got.Text())
+	}
+	style := *got.shape().StyleAttr
+	if styleProperty(style, "width") != "150pt" || styleProperty(style, "height") != "75pt" {
+		t.Errorf("expected a 150pt by 75pt box, got %s", style)
+	}
+	if err := got.SetVisible(false); err != nil || got.IsVisible() {
+		t.Errorf("expected the note box to be hidden")
+	}
+}
+
+func TestRemoveSheetWithComments(t *testing.T) {
+	wb := New()
+	ann := wb.AddPerson("Ann", "", "")
+	for _, name := range []string{"First", "Second", "Third"} {
+		sheet := wb.AddSheet()
+		sheet.SetName(name)
+	}
+	sheets := wb.Sheets()
+	sheets[0].Comments().AddCommentWithStyle("A1", "Carl", "first note")
+	sheets[0].ThreadedComments().AddThread("A2", ann, "first thread")
+	second, _ := sheets[1].ThreadedComments().AddThread("B2", ann, "second thread")
+	second.AddReply(ann, "reply")
+	sheets[2].Comments().AddCommentWithStyle("C3", "Carl", "third note")
+
+	if err := wb.RemoveSheet(0); err != nil {
+		t.Fatalf("error removing sheet: %s", err)
+	}
+	rd := saveAndRead(t, wb)
+	check := func(rd *Workbook) {
+		t.Helper()
+		sheets := rd.Sheets()
+		if len(sheets) != 2 || sheets[0].Name() != "Second" || sheets[1].Name() != "Third" {
+			t.Fatalf("expected the sheets Second and Third")
+		}
+		thread, ok := sheets[0].ThreadedComments().Thread("B2")
+		if !ok || thread.Text() != "second thread" || len(thread.Replies()) != 1 {
+			t.Errorf("expected the thread of Second to be kept")
+		}
+		if note, ok := noteText(&sheets[0], "B2"); !ok || !strings.Contains(note, "second thread") {
+			t.Errorf("expected the note of the thread on Second, got %q", note)
+		}
+		if _, ok := noteText(&sheets[0], "A1"); ok {
+			t.Errorf("expected the notes of the removed sheet to be gone")
+		}
+		if note, ok := noteText(&sheets[1], "C3"); !ok || !strings.Contains(note, "third note") {
+			t.Errorf("expected the note of Third to be kept, got %q", note)
+		}
+		if n := len(sheets[1].ThreadedComments().Threads()); n != 0 {
+			t.Errorf("expected no threads on Third, got %d", n)
+		}
+	}
+	check(rd)
+	check(saveAndRead(t, rd))
+}
+
+func TestReorderedSheetsWithComments(t *testing.T) {
+	wb := New()
+	ann := wb.AddPerson("Ann", "", "")
+	first := wb.AddSheet()
+	first.SetName("First")
+	first.Cell("D4").SetString("first")
+	first.ThreadedComments().AddThread("A1", ann, "one")
+	second := wb.AddSheet()
+	second.SetName("Second")
+	second.Comments().AddCommentWithStyle("B2", "Carl", "two")
+	second.ThreadedComments().AddThread("C3", ann, "three")
+
+	rd := reverseSheets(t, wb)
+	for i, rd := range []*Workbook{rd, saveAndRead(t, rd)} {
+		sheets := rd.Sheets()
+		if len(sheets) != 2 || sheets[0].Name() != "Second" || sheets[1].Name() != "First" {
+			t.Fatalf("%d: expected the sheets in reverse order", i)
+		}
+		if got := sheets[1].Cell("D4").GetString(); got != "first" {
+			t.Errorf("%d: expected the cells of First to stay on First, got %q", i, got)
+		}
+		if thread, ok := sheets[0].ThreadedComments().Thread("C3"); !ok || thread.Text() != "three" {
+			t.Errorf("%d: expected the thread of Second", i)
+		}
+		if note, ok := noteText(&sheets[0], "B2"); !ok || !strings.Contains(note, "two") {
+			t.Errorf("%d: expected the note of Second, got %q", i, note)
+		}
+		if thread, ok := sheets[1].ThreadedComments().Thread("A1"); !ok || thread.Text() != "one" {
+			t.Errorf("%d: expected the thread of First", i)
+		}
+		if _, ok := noteText(&sheets[1], "B2"); ok {
+			t.Errorf("%d: expected the notes of Second to stay on Second", i)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 FoxyUtils ehf. All rights reserved.
+//
+// Use of this software package and source code is governed by the terms of the
+// UniDoc End User License Agreement (EULA) that is available at:
+// https://unidoc.io/eula/
+// A trial license code for evaluation can be obtained at https://unidoc.io.
+
+package vmldrawing
+
+import (
+	"fmt"
+
+	"github.com/unidoc/unioffice"
+	"github.com/unidoc/unioffice/schema/soo/ofc/sharedTypes"
+	"github.com/unidoc/unioffice/schema/urn/schemas_microsoft_com/vml"
+)
+
+// imageFormulas are the formulas of the picture frame shape type.
+var imageFormulas = []string{
+	"if lineDrawn pixelLineWidth 0",
+	"sum @0 1 0",
+	"sum 0 0 @1",
+	"prod @2 1 2",
+	"prod @3 21600 pixelWidth",
+	"prod @3 21600 pixelHeight",
+	"sum @0 0 1",
+	"prod @6 1 2",
+	"prod @7 21600 pixelWidth",
+	"sum @8 21600 0",
+	"prod @7 21600 pixelHeight",
+	"sum @10 21600 0",
+}
+
+// NewImageDrawing constructs a new drawing for the pictures of the headers and
+// footers of a sheet.
+func NewImageDrawing() *Container {
+	c := NewContainer()
+	c.Layout = vml.NewOfcShapelayout()
+	c.Layout.ExtAttr = vml.ST_ExtEdit
+	c.Layout.Idmap = vml.NewOfcCT_IdMap()
+	c.Layout.Idmap.DataAttr = unioffice.String("1")
+	c.Layout.Idmap.ExtAttr = vml.ST_ExtEdit
+
+	c.ShapeType = vml.NewShapetype()
+	c.ShapeType.IdAttr = unioffice.String("_x0000_t75")
+	c.ShapeType.CoordsizeAttr = unioffice.String("21600,21600")
+	c.ShapeType.SptAttr = unioffice.Float32(75)
+	c.ShapeType.PreferrelativeAttr = sharedTypes.ST_TrueFalseT
+	c.ShapeType.PathAttr = unioffice.String("m@4@5l@4@11@9@11@9@5xe")
+	c.ShapeType.FilledAttr = sharedTypes.ST_TrueFalseF
+	c.ShapeType.StrokedAttr = sharedTypes.ST_TrueFalseF
+
+	stroke := vml.NewEG_ShapeElements()
+	stroke.Stroke = vml.NewStroke()
+	stroke.Stroke.JoinstyleAttr = vml.ST_StrokeJoinStyleMiter
+	formulas := vml.NewEG_ShapeElements()
+	formulas.Formulas = vml.NewFormulas()
+	for _, f := range imageFormulas {
+		formulas.Formulas.F = append(formulas.Formulas.F, CreateFormula(f))
+	}
+	path := vml.NewEG_ShapeElements()
+	path.Path = vml.NewPath()
+	path.Path.ExtrusionokAttr = sharedTypes.ST_TrueFalseF
+	path.Path.GradientshapeokAttr = sharedTypes.ST_TrueFalseT
+	path.Path.ConnecttypeAttr = vml.OfcST_ConnectTypeRect
+	lock := vml.NewEG_ShapeElements()
+	lock.Lock = vml.NewOfcLock()
+	lock.Lock.ExtAttr = vml.ST_ExtEdit
+	lock.Lock.AspectratioAttr = sharedTypes.ST_TrueFalseT
+	c.ShapeType.EG_ShapeElements = append(c.ShapeType.EG_ShapeElements, stroke, formulas, path, lock)
+	return c
+}
+
+// NewImageShape creates a new picture shape of a header or footer. The id
+// names the section the picture is drawn in (e.g. "CH" for the center of the
+// header), relID is the relationship of the drawing to the image and the size
+// is in points.
+func NewImageShape(id, relID string, spid int, width, height float64) *vml.Shape {
+	s := vml.NewShape()
+	s.IdAttr = unioffice.String(id)
+	s.SpidAttr = unioffice.String(fmt.Sprintf("_x0000_s%d", spid))
+	s.TypeAttr = unioffice.String("#_x0000_t75")
+	s.StyleAttr = unioffice.String(fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%.2fpt;height:%.2fpt;z-index:1", width, height))
+
+	img := vml.NewEG_ShapeElements()
+	img.Imagedata = vml.NewImagedata()
+	img.Imagedata.RelidAttr = unioffice.String(relID)
+	lock := vml.NewEG_ShapeElements()
+	lock.Lock = vml.NewOfcLock()
+	lock.Lock.ExtAttr = vml.ST_ExtEdit
+	lock.Lock.RotationAttr = sharedTypes.ST_TrueFalseT
+	s.EG_ShapeElements = append(s.EG_ShapeElements, img, lock)
+	return s
+}